@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package startcmd
 
 import (
+	"crypto/sha256"
 	"crypto/subtle"
 	"errors"
 	"fmt"
@@ -37,6 +38,9 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/defaults"
 	"github.com/hyperledger/aries-framework-go/pkg/framework/context"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
 	"github.com/hyperledger/aries-framework-go/pkg/vdr/httpbinding"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -207,6 +211,20 @@ const (
 		" Alternatively, this can be set with the following environment variable (in CSV format): " +
 		agentMediaTypeProfilesEnvKey
 
+	// master key cipher path flag.
+	agentMasterKeyCipherPathFlagName  = "master-key-cipher-path"
+	agentMasterKeyCipherPathEnvKey    = "ARIESD_MASTER_KEY_CIPHER_PATH"
+	agentMasterKeyCipherPathFlagUsage = "Path of the file holding the master key cipher of the local secret lock" +
+		" protecting the keys of the agent, as returned by the kms rewrap endpoint." +
+		" The master key passphrase must be set as well. If not set, keys are stored unprotected." +
+		" Alternatively, this can be set with the following environment variable: " + agentMasterKeyCipherPathEnvKey
+
+	// master key passphrase flag.
+	agentMasterKeyPassphraseFlagName  = "master-key-passphrase"
+	agentMasterKeyPassphraseEnvKey    = "ARIESD_MASTER_KEY_PASSPHRASE" // nolint:gosec
+	agentMasterKeyPassphraseFlagUsage = "Passphrase the master key cipher is encrypted with." +
+		" Alternatively, this can be set with the following environment variable: " + agentMasterKeyPassphraseEnvKey
+
 	httpProtocol      = "http"
 	websocketProtocol = "ws"
 
@@ -235,10 +253,11 @@ var (
 
 	//nolint:gochecknoglobals
 	keyAgreementTypes = map[string]kms.KeyType{
-		"x25519kw": kms.X25519ECDHKWType,
-		"p256kw":   kms.NISTP256ECDHKWType,
-		"p384kw":   kms.NISTP384ECDHKWType,
-		"p521kw":   kms.NISTP521ECDHKWType,
+		"x25519kw":         kms.X25519ECDHKWType,
+		"p256kw":           kms.NISTP256ECDHKWType,
+		"p384kw":           kms.NISTP384ECDHKWType,
+		"p521kw":           kms.NISTP521ECDHKWType,
+		"x25519mlkem768kw": kms.X25519MLKEM768ECDHKWType,
 	}
 )
 
@@ -248,6 +267,7 @@ type AgentParameters struct {
 	host, defaultLabel, transportReturnRoute       string
 	tlsCertFile, tlsKeyFile                        string
 	token, keyType, keyAgreementType               string
+	masterKeyCipherPath, masterKeyPassphrase       string
 	webhookURLs, httpResolvers, outboundTransports []string
 	inboundHostInternals, inboundHostExternals     []string
 	websocketReadLimit                             int64
@@ -422,6 +442,18 @@ func NewAgentParameters(server server, cmd *cobra.Command) (*AgentParameters, er
 		return nil, err
 	}
 
+	masterKeyCipherPath, err := getUserSetVar(cmd, agentMasterKeyCipherPathFlagName, agentMasterKeyCipherPathEnvKey,
+		true)
+	if err != nil {
+		return nil, err
+	}
+
+	masterKeyPassphrase, err := getUserSetVar(cmd, agentMasterKeyPassphraseFlagName, agentMasterKeyPassphraseEnvKey,
+		masterKeyCipherPath == "")
+	if err != nil {
+		return nil, err
+	}
+
 	parameters := &AgentParameters{
 		server:               server,
 		host:                 host,
@@ -443,6 +475,8 @@ func NewAgentParameters(server server, cmd *cobra.Command) (*AgentParameters, er
 		keyType:              keyType,
 		keyAgreementType:     keyAgreementType,
 		mediaTypeProfiles:    mediaTypeProfiles,
+		masterKeyCipherPath:  masterKeyCipherPath,
+		masterKeyPassphrase:  masterKeyPassphrase,
 	}
 
 	return parameters, nil
@@ -613,6 +647,10 @@ func createFlags(startCmd *cobra.Command) {
 	startCmd.Flags().StringP(agentKeyAgreementTypeFlagName, "", "", agentKeyAgreementTypeUsage)
 
 	startCmd.Flags().StringSliceP(agentMediaTypeProfilesFlagName, "", []string{}, agentMediaTypeProfilesUsage)
+
+	startCmd.Flags().StringP(agentMasterKeyCipherPathFlagName, "", "", agentMasterKeyCipherPathFlagUsage)
+
+	startCmd.Flags().StringP(agentMasterKeyPassphraseFlagName, "", "", agentMasterKeyPassphraseFlagUsage)
 }
 
 func getUserSetVar(cmd *cobra.Command, flagName, envKey string, isOptional bool) (string, error) {
@@ -925,6 +963,16 @@ func createAriesAgent(parameters *AgentParameters) (*context.Provider, error) {
 		opts = append(opts, aries.WithMediaTypeProfiles(parameters.mediaTypeProfiles))
 	}
 
+	if parameters.masterKeyCipherPath != "" {
+		secretLock, e := createSecretLock(parameters.masterKeyCipherPath, parameters.masterKeyPassphrase)
+		if e != nil {
+			return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to create secret lock : %w",
+				parameters.host, e)
+		}
+
+		opts = append(opts, aries.WithSecretLock(secretLock))
+	}
+
 	framework, err := aries.New(opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start aries agent rest on port [%s], failed to initialize framework :  %w",
@@ -940,6 +988,21 @@ func createAriesAgent(parameters *AgentParameters) (*context.Provider, error) {
 	return ctx, nil
 }
 
+// createSecretLock creates the local secret lock of the master key cipher read from path, encrypted with passphrase.
+func createSecretLock(path, passphrase string) (secretlock.Service, error) {
+	masterKeyReader, err := local.MasterKeyFromPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read master key cipher: %w", err)
+	}
+
+	masterLock, err := hkdf.NewMasterLock(passphrase, sha256.New, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create master lock: %w", err)
+	}
+
+	return local.NewService(masterKeyReader, masterLock)
+}
+
 func createStoreProviders(parameters *AgentParameters) (storage.Provider, error) {
 	provider, supported := supportedStorageProviders[parameters.dbParam.dbType]
 	if !supported {
@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package startcmd
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,15 +15,20 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/tink/go/subtle/random"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
 	spi "github.com/hyperledger/aries-framework-go/spi/log"
 )
 
@@ -899,6 +905,73 @@ func TestStoreProvider(t *testing.T) {
 	})
 }
 
+func TestCreateAriesWithMasterKeyCipher(t *testing.T) {
+	const passphrase = "passphrase"
+
+	masterLock, err := hkdf.NewMasterLock(passphrase, sha256.New, nil)
+	require.NoError(t, err)
+
+	masterKeyCipher, err := masterLock.Encrypt("", &secretlock.EncryptRequest{
+		Plaintext: string(random.GetRandomBytes(32)),
+	})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "master-key")
+	require.NoError(t, os.WriteFile(path, []byte(masterKeyCipher.Ciphertext), 0o600))
+
+	t.Run("create aries with master key cipher - success", func(t *testing.T) {
+		parameters := &AgentParameters{
+			dbParam:             &dbParam{dbType: databaseTypeMemOption},
+			masterKeyCipherPath: path,
+			masterKeyPassphrase: passphrase,
+		}
+
+		ctx, err := createAriesAgent(parameters)
+		require.NoError(t, err)
+		require.IsType(t, &local.Lock{}, ctx.SecretLock())
+	})
+
+	t.Run("create aries with master key cipher - wrong passphrase", func(t *testing.T) {
+		parameters := &AgentParameters{
+			dbParam:             &dbParam{dbType: databaseTypeMemOption},
+			masterKeyCipherPath: path,
+			masterKeyPassphrase: "other passphrase",
+		}
+
+		_, err := createAriesAgent(parameters)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to create secret lock")
+	})
+
+	t.Run("create aries with master key cipher - missing file", func(t *testing.T) {
+		parameters := &AgentParameters{
+			dbParam:             &dbParam{dbType: databaseTypeMemOption},
+			masterKeyCipherPath: filepath.Join(t.TempDir(), "missing"),
+			masterKeyPassphrase: passphrase,
+		}
+
+		_, err := createAriesAgent(parameters)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to read master key cipher")
+	})
+
+	t.Run("start with master key cipher - missing passphrase", func(t *testing.T) {
+		startCmd, err := Cmd(&mockServer{})
+		require.NoError(t, err)
+
+		startCmd.SetArgs([]string{
+			"--" + agentHostFlagName, randomURL(),
+			"--" + databaseTypeFlagName, databaseTypeMemOption,
+			"--" + agentWebhookFlagName, "",
+			"--" + agentMasterKeyCipherPathFlagName, path,
+		})
+
+		err = startCmd.Execute()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), agentMasterKeyPassphraseFlagName)
+	})
+}
+
 func TestStartCmdInvalidAutoExecuteRFC0593Value(t *testing.T) {
 	startCmd, err := Cmd(&mockServer{})
 	require.NoError(t, err)
@@ -994,6 +1067,12 @@ func TestNewAgentParametersUsingEnv(t *testing.T) {
 	os.Setenv(agentMediaTypeProfilesEnvKey, "agentMediaTypeProfiles")
 	defer os.Unsetenv(agentMediaTypeProfilesEnvKey)
 
+	os.Setenv(agentMasterKeyCipherPathEnvKey, "agentMasterKeyCipherPath")
+	defer os.Unsetenv(agentMasterKeyCipherPathEnvKey)
+
+	os.Setenv(agentMasterKeyPassphraseEnvKey, "agentMasterKeyPassphrase")
+	defer os.Unsetenv(agentMasterKeyPassphraseEnvKey)
+
 	parameters, err := NewAgentParameters(&mockServer{}, nil)
 
 	require.Nil(t, err)
@@ -1018,6 +1097,8 @@ func TestNewAgentParametersUsingEnv(t *testing.T) {
 	require.Equal(t, "agentKeyType", parameters.keyType)
 	require.Equal(t, "agentKeyAgreementType", parameters.keyAgreementType)
 	require.Equal(t, "agentMediaTypeProfiles", parameters.mediaTypeProfiles[0])
+	require.Equal(t, "agentMasterKeyCipherPath", parameters.masterKeyCipherPath)
+	require.Equal(t, "agentMasterKeyPassphrase", parameters.masterKeyPassphrase)
 }
 
 func waitForServerToStart(t *testing.T, host, inboundHost string) {
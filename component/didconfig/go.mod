@@ -4,7 +4,7 @@
 
 module github.com/hyperledger/aries-framework-go/component/didconfig
 
-go 1.20
+go 1.22.0
 
 require (
 	github.com/hyperledger/aries-framework-go/component/kmscrypto v0.0.0-20230622082138-3ffab1691857
@@ -0,0 +1,245 @@
+//go:build pkcs11
+// +build pkcs11
+
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package pkcs11crypto provides a Crypto signing with the keys held by a PKCS#11 token and managed by
+// kms/pkcs11kms. Signatures are computed by the token, verification is done locally. Other operations are not
+// supported.
+package pkcs11crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	p11 "github.com/miekg/pkcs11"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/spi/crypto"
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/pkcs11kms"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/pkcs11"
+)
+
+var (
+	errBadKeyHandleFormat = errors.New("bad key handle format")
+	errNotSupported       = errors.New("not supported by pkcs11Crypto")
+)
+
+// Crypto is the PKCS#11 implementation of crypto.Crypto, for the key handles returned by pkcs11kms.
+type Crypto struct {
+	token *pkcs11.Token
+}
+
+var _ cryptoapi.Crypto = (*Crypto)(nil)
+
+// New creates a new PKCS#11 Crypto service using the keys of token.
+func New(token *pkcs11.Token) *Crypto {
+	return &Crypto{token: token}
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// Sign will sign msg using the private key of kh, a *pkcs11kms.KeyHandle returned by pkcs11kms. ECDSA signatures are
+// ASN.1 DER encoded for the DER key types and IEEE P1363 encoded otherwise.
+func (c *Crypto) Sign(msg []byte, kh interface{}) ([]byte, error) {
+	keyHandle, ok := kh.(*pkcs11kms.KeyHandle)
+	if !ok || keyHandle.KeyID == "" {
+		return nil, fmt.Errorf("sign: %w", errBadKeyHandleFormat)
+	}
+
+	mechanism, input, err := signatureInput(msg, keyHandle.KeyType)
+	if err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+
+	var sig []byte
+
+	err = c.token.Do(func(s *pkcs11.Session) error {
+		priv, e := s.FindObject([]*p11.Attribute{
+			p11.NewAttribute(p11.CKA_CLASS, p11.CKO_PRIVATE_KEY),
+			p11.NewAttribute(p11.CKA_ID, keyHandle.KeyID),
+		})
+		if e != nil {
+			return fmt.Errorf("private key '%s': %w", keyHandle.KeyID, e)
+		}
+
+		e = s.Ctx.SignInit(s.Handle, []*p11.Mechanism{p11.NewMechanism(mechanism, nil)}, priv)
+		if e != nil {
+			return fmt.Errorf("failed to initialize signature: %w", e)
+		}
+
+		sig, e = s.Ctx.Sign(s.Handle, input)
+		if e != nil {
+			return fmt.Errorf("failed to sign: %w", e)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+
+	switch keyHandle.KeyType {
+	case kmsapi.ECDSAP256TypeDER, kmsapi.ECDSAP384TypeDER:
+		// the token returns IEEE P1363 (r||s) ECDSA signatures.
+		half := len(sig) / 2 // nolint:gomnd
+
+		sig, err = asn1.Marshal(ecdsaSignature{
+			R: new(big.Int).SetBytes(sig[:half]),
+			S: new(big.Int).SetBytes(sig[half:]),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("sign: failed to marshal ECDSA signature: %w", err)
+		}
+	}
+
+	return sig, nil
+}
+
+// Verify will verify signature of msg using the public key of kh, a *pkcs11kms.KeyHandle returned by pkcs11kms. It
+// returns nil if signature verification was successful.
+func (c *Crypto) Verify(signature, msg []byte, kh interface{}) error {
+	keyHandle, ok := kh.(*pkcs11kms.KeyHandle)
+	if !ok {
+		return fmt.Errorf("verify: %w", errBadKeyHandleFormat)
+	}
+
+	pubKey, err := keyHandle.Public()
+	if err != nil {
+		return fmt.Errorf("verify: %w", err)
+	}
+
+	switch key := pubKey.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, msg, signature) {
+			return errors.New("verify: invalid signature")
+		}
+	case *ecdsa.PublicKey:
+		_, digest, e := signatureInput(msg, keyHandle.KeyType)
+		if e != nil {
+			return fmt.Errorf("verify: %w", e)
+		}
+
+		if !verifyECDSA(key, keyHandle.KeyType, digest, signature) {
+			return errors.New("verify: invalid signature")
+		}
+	}
+
+	return nil
+}
+
+func verifyECDSA(key *ecdsa.PublicKey, kt kmsapi.KeyType, digest, signature []byte) bool {
+	switch kt {
+	case kmsapi.ECDSAP256TypeDER, kmsapi.ECDSAP384TypeDER:
+		return ecdsa.VerifyASN1(key, digest, signature)
+	default:
+		size := (key.Curve.Params().BitSize + 7) / 8 // nolint:gomnd
+		if len(signature) != 2*size {
+			return false
+		}
+
+		return ecdsa.Verify(key, digest, new(big.Int).SetBytes(signature[:size]),
+			new(big.Int).SetBytes(signature[size:]))
+	}
+}
+
+// signatureInput returns the PKCS#11 signature mechanism of kt and the data it signs for msg.
+func signatureInput(msg []byte, kt kmsapi.KeyType) (uint, []byte, error) {
+	if kt == kmsapi.ED25519Type {
+		return pkcs11.MechanismEdDSA, msg, nil
+	}
+
+	switch pkcs11kms.Curve(kt) {
+	case elliptic.P256():
+		digest := sha256.Sum256(msg)
+
+		return p11.CKM_ECDSA, digest[:], nil
+	case elliptic.P384():
+		digest := sha512.Sum384(msg)
+
+		return p11.CKM_ECDSA, digest[:], nil
+	default:
+		return 0, nil, fmt.Errorf("key type '%s' is not supported", kt)
+	}
+}
+
+// Encrypt is not supported by pkcs11Crypto.
+func (c *Crypto) Encrypt([]byte, []byte, interface{}) ([]byte, []byte, error) {
+	return nil, nil, fmt.Errorf("encrypt: %w", errNotSupported)
+}
+
+// Decrypt is not supported by pkcs11Crypto.
+func (c *Crypto) Decrypt([]byte, []byte, []byte, interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("decrypt: %w", errNotSupported)
+}
+
+// ComputeMAC is not supported by pkcs11Crypto.
+func (c *Crypto) ComputeMAC([]byte, interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("computeMAC: %w", errNotSupported)
+}
+
+// VerifyMAC is not supported by pkcs11Crypto.
+func (c *Crypto) VerifyMAC([]byte, []byte, interface{}) error {
+	return fmt.Errorf("verifyMAC: %w", errNotSupported)
+}
+
+// WrapKey is not supported by pkcs11Crypto.
+func (c *Crypto) WrapKey([]byte, []byte, []byte, *cryptoapi.PublicKey,
+	...cryptoapi.WrapKeyOpts) (*cryptoapi.RecipientWrappedKey, error) {
+	return nil, fmt.Errorf("wrapKey: %w", errNotSupported)
+}
+
+// UnwrapKey is not supported by pkcs11Crypto.
+func (c *Crypto) UnwrapKey(*cryptoapi.RecipientWrappedKey, interface{}, ...cryptoapi.WrapKeyOpts) ([]byte, error) {
+	return nil, fmt.Errorf("unwrapKey: %w", errNotSupported)
+}
+
+// SignMulti is not supported by pkcs11Crypto.
+func (c *Crypto) SignMulti([][]byte, interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("signMulti: %w", errNotSupported)
+}
+
+// VerifyMulti is not supported by pkcs11Crypto.
+func (c *Crypto) VerifyMulti([][]byte, []byte, interface{}) error {
+	return fmt.Errorf("verifyMulti: %w", errNotSupported)
+}
+
+// VerifyProof is not supported by pkcs11Crypto.
+func (c *Crypto) VerifyProof([][]byte, []byte, []byte, interface{}) error {
+	return fmt.Errorf("verifyProof: %w", errNotSupported)
+}
+
+// DeriveProof is not supported by pkcs11Crypto.
+func (c *Crypto) DeriveProof([][]byte, []byte, []byte, []int, interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("deriveProof: %w", errNotSupported)
+}
+
+// Blind is not supported by pkcs11Crypto.
+func (c *Crypto) Blind(interface{}, ...map[string]interface{}) ([][]byte, error) {
+	return nil, fmt.Errorf("blind: %w", errNotSupported)
+}
+
+// GetCorrectnessProof is not supported by pkcs11Crypto.
+func (c *Crypto) GetCorrectnessProof(interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("getCorrectnessProof: %w", errNotSupported)
+}
+
+// SignWithSecrets is not supported by pkcs11Crypto.
+func (c *Crypto) SignWithSecrets(interface{}, map[string]interface{}, []byte, []byte, [][]byte,
+	string) ([]byte, []byte, error) {
+	return nil, nil, fmt.Errorf("signWithSecrets: %w", errNotSupported)
+}
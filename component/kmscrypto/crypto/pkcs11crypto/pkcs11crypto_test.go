@@ -0,0 +1,111 @@
+//go:build pkcs11
+// +build pkcs11
+
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkcs11crypto
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/internal/pkcs11test"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/pkcs11kms"
+)
+
+func TestCrypto_SignVerify(t *testing.T) {
+	token := pkcs11test.OpenToken(t)
+	k := pkcs11kms.New(token)
+	c := New(token)
+	msg := []byte("lorem ipsum")
+
+	for _, kt := range []kmsapi.KeyType{
+		kmsapi.ECDSAP256TypeDER,
+		kmsapi.ECDSAP256TypeIEEEP1363,
+		kmsapi.ECDSAP384TypeDER,
+		kmsapi.ECDSAP384TypeIEEEP1363,
+		kmsapi.ED25519Type,
+	} {
+		kt := kt
+
+		t.Run(string(kt), func(t *testing.T) {
+			keyID, kh, err := k.Create(kt)
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, k.Delete(keyID))
+			}()
+
+			sig, err := c.Sign(msg, kh)
+			require.NoError(t, err)
+
+			require.NoError(t, c.Verify(sig, msg, kh))
+
+			pubKey, _, err := k.ExportPubKeyBytes(keyID)
+			require.NoError(t, err)
+
+			pkh, err := k.PubKeyBytesToHandle(pubKey, kt)
+			require.NoError(t, err)
+
+			require.NoError(t, c.Verify(sig, msg, pkh))
+			require.EqualError(t, c.Verify(sig, []byte("other message"), pkh), "verify: invalid signature")
+
+			_, err = c.Sign(msg, pkh)
+			require.True(t, errors.Is(err, errBadKeyHandleFormat))
+		})
+	}
+
+	t.Run("disabled key", func(t *testing.T) {
+		keyID, kh, err := k.Create(kmsapi.ED25519Type)
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, k.Delete(keyID))
+		}()
+
+		require.NoError(t, k.Disable(keyID))
+
+		_, err = c.Sign(msg, kh)
+		require.Error(t, err)
+	})
+}
+
+func TestCrypto_BadKeyHandle(t *testing.T) {
+	c := New(nil)
+
+	_, err := c.Sign([]byte("msg"), "bad handle")
+	require.True(t, errors.Is(err, errBadKeyHandleFormat))
+
+	err = c.Verify([]byte("sig"), []byte("msg"), "bad handle")
+	require.True(t, errors.Is(err, errBadKeyHandleFormat))
+
+	_, err = c.Sign([]byte("msg"), &pkcs11kms.KeyHandle{KeyID: "keyID", KeyType: kmsapi.BLS12381G2Type})
+	require.EqualError(t, err, "sign: key type 'BLS12381G2' is not supported")
+}
+
+func TestCrypto_NotSupported(t *testing.T) {
+	c := New(nil)
+
+	_, _, err := c.Encrypt(nil, nil, nil)
+	require.True(t, errors.Is(err, errNotSupported))
+
+	_, err = c.Decrypt(nil, nil, nil, nil)
+	require.True(t, errors.Is(err, errNotSupported))
+
+	_, err = c.ComputeMAC(nil, nil)
+	require.True(t, errors.Is(err, errNotSupported))
+
+	_, err = c.WrapKey(nil, nil, nil, nil)
+	require.True(t, errors.Is(err, errNotSupported))
+
+	_, err = c.SignMulti(nil, nil)
+	require.True(t, errors.Is(err, errNotSupported))
+}
@@ -0,0 +1,462 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package frost implements the FROST(Ed25519, SHA-512) threshold signature scheme of RFC 9591. A group key is split
+// in shares held by n participants, any t of them (the threshold) can sign together without ever reconstructing the
+// group private key. Group signatures are regular Ed25519 signatures of the group public key.
+//
+// Signing is done in two rounds: each signing participant first publishes a Commitment to a pair of single use
+// Nonces, then computes its signature share over the message and the commitments of all the signing participants.
+// The coordinator of the signature verifies and aggregates the shares into the group signature.
+//
+// Keys are generated (or split from an existing Ed25519 key) by a trusted dealer, see Split.
+package frost
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	"filippo.io/edwards25519"
+)
+
+const contextString = "FROST-ED25519-SHA512-v1"
+
+// ScalarSize is the size of the encoded secret shares and signature shares.
+const ScalarSize = 32
+
+// KeyShare is the share of a group key held by a participant.
+type KeyShare struct {
+	// ID is the identifier of the participant, it must not be 0.
+	ID uint16 `json:"id"`
+	// Secret is the secret share of the participant.
+	Secret []byte `json:"secret"`
+	// PublicKey is the Ed25519 group public key.
+	PublicKey []byte `json:"publicKey"`
+}
+
+// Commitment is the commitment of a signing participant to its Nonces, sent to the other signing participants.
+type Commitment struct {
+	ID      uint16 `json:"id"`
+	Hiding  []byte `json:"hiding"`
+	Binding []byte `json:"binding"`
+}
+
+// Nonces are the single use secret nonces of a signing participant.
+type Nonces struct {
+	hiding  *edwards25519.Scalar
+	binding *edwards25519.Scalar
+}
+
+// GroupKey is a group key split by Split.
+type GroupKey struct {
+	// PublicKey is the Ed25519 group public key.
+	PublicKey ed25519.PublicKey
+	// Shares of the participants.
+	Shares []*KeyShare
+	// VerifyingShares are the public keys of the participants shares, used to verify their signature shares.
+	VerifyingShares map[uint16][]byte
+}
+
+// GenerateKey generates a new random group key split for the participants ids with the given threshold.
+func GenerateKey(threshold int, ids []uint16) (*GroupKey, error) {
+	secret, err := randomScalar()
+	if err != nil {
+		return nil, err
+	}
+
+	return Split(secret, threshold, ids)
+}
+
+// SplitEd25519 splits the existing Ed25519 privKey for the participants ids with the given threshold.
+func SplitEd25519(privKey ed25519.PrivateKey, threshold int, ids []uint16) (*GroupKey, error) {
+	if len(privKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("frost: invalid Ed25519 private key")
+	}
+
+	h := sha512.Sum512(privKey.Seed())
+
+	secret, err := edwards25519.NewScalar().SetBytesWithClamping(h[:32])
+	if err != nil {
+		return nil, fmt.Errorf("frost: %w", err)
+	}
+
+	return Split(secret, threshold, ids)
+}
+
+// Split splits secret with Shamir secret sharing, as the trusted dealer of RFC 9591 Appendix C. Any threshold shares
+// of the participants ids (which must be unique and not 0) can sign with the group key.
+func Split(secret *edwards25519.Scalar, threshold int, ids []uint16) (*GroupKey, error) {
+	if threshold < 2 || threshold > len(ids) {
+		return nil, fmt.Errorf("frost: invalid threshold %d for %d participants", threshold, len(ids))
+	}
+
+	seen := make(map[uint16]bool, len(ids))
+
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			return nil, fmt.Errorf("frost: invalid participant identifier %d", id)
+		}
+
+		seen[id] = true
+	}
+
+	// f(x) = secret + a1*x + ... + a(t-1)*x^(t-1)
+	coefficients := []*edwards25519.Scalar{secret}
+
+	for i := 1; i < threshold; i++ {
+		c, err := randomScalar()
+		if err != nil {
+			return nil, err
+		}
+
+		coefficients = append(coefficients, c)
+	}
+
+	return splitWithCoefficients(coefficients, ids), nil
+}
+
+// splitWithCoefficients evaluates the shares of ids on the secret sharing polynomial, the secret being its
+// first coefficient.
+func splitWithCoefficients(coefficients []*edwards25519.Scalar, ids []uint16) *GroupKey {
+	publicKey := new(edwards25519.Point).ScalarBaseMult(coefficients[0]).Bytes()
+
+	groupKey := &GroupKey{
+		PublicKey:       publicKey,
+		VerifyingShares: make(map[uint16][]byte, len(ids)),
+	}
+
+	for _, id := range ids {
+		x := identifier(id)
+
+		// Horner's method.
+		share := edwards25519.NewScalar()
+		for i := len(coefficients) - 1; i >= 0; i-- {
+			share.MultiplyAdd(share, x, coefficients[i])
+		}
+
+		groupKey.Shares = append(groupKey.Shares, &KeyShare{
+			ID:        id,
+			Secret:    share.Bytes(),
+			PublicKey: publicKey,
+		})
+		groupKey.VerifyingShares[id] = new(edwards25519.Point).ScalarBaseMult(share).Bytes()
+	}
+
+	return groupKey
+}
+
+// Commit generates the single use Nonces of the first signing round and their Commitment.
+func (k *KeyShare) Commit() (*Nonces, *Commitment, error) {
+	secret, err := edwards25519.NewScalar().SetCanonicalBytes(k.Secret)
+	if err != nil {
+		return nil, nil, fmt.Errorf("frost: invalid secret share: %w", err)
+	}
+
+	hiding, err := generateNonce(secret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	binding, err := generateNonce(secret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nonces, commitment := commit(k.ID, hiding, binding)
+
+	return nonces, commitment, nil
+}
+
+func commit(id uint16, hiding, binding *edwards25519.Scalar) (*Nonces, *Commitment) {
+	return &Nonces{hiding: hiding, binding: binding}, &Commitment{
+		ID:      id,
+		Hiding:  new(edwards25519.Point).ScalarBaseMult(hiding).Bytes(),
+		Binding: new(edwards25519.Point).ScalarBaseMult(binding).Bytes(),
+	}
+}
+
+// Sign computes the signature share of msg in the second signing round, given the Nonces of the first round and the
+// commitments of all the signing participants (including this one). Nonces must not be used again.
+func (k *KeyShare) Sign(nonces *Nonces, msg []byte, commitments []*Commitment) ([]byte, error) {
+	secret, err := edwards25519.NewScalar().SetCanonicalBytes(k.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("frost: invalid secret share: %w", err)
+	}
+
+	sc, err := newSigningContext(k.PublicKey, msg, commitments)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := sc.bindingFactors[k.ID]; !ok {
+		return nil, fmt.Errorf("frost: missing commitment of participant %d", k.ID)
+	}
+
+	lambda := sc.lambda(k.ID)
+
+	// z = hiding + binding * rho + lambda * secret * c
+	z := edwards25519.NewScalar().Multiply(lambda, secret)
+	z.Multiply(z, sc.challenge)
+	z.MultiplyAdd(nonces.binding, sc.bindingFactors[k.ID], z)
+	z.Add(z, nonces.hiding)
+
+	return z.Bytes(), nil
+}
+
+// VerifyShare verifies the signature share of msg of the participant id, whose verifying share is verifyingShare.
+func VerifyShare(publicKey []byte, id uint16, verifyingShare, sigShare, msg []byte, commitments []*Commitment) error {
+	sc, err := newSigningContext(publicKey, msg, commitments)
+	if err != nil {
+		return err
+	}
+
+	return sc.verifyShare(id, verifyingShare, sigShare)
+}
+
+// Aggregate verifies the signature shares of the signing participants and aggregates them in the Ed25519 signature of
+// msg by the group key. verifyingShares and sigShares are indexed by participant identifiers.
+func Aggregate(publicKey []byte, msg []byte, commitments []*Commitment, verifyingShares,
+	sigShares map[uint16][]byte) ([]byte, error) {
+	sc, err := newSigningContext(publicKey, msg, commitments)
+	if err != nil {
+		return nil, err
+	}
+
+	z := edwards25519.NewScalar()
+
+	for _, c := range sc.commitments {
+		err = sc.verifyShare(c.ID, verifyingShares[c.ID], sigShares[c.ID])
+		if err != nil {
+			return nil, err
+		}
+
+		share, _ := edwards25519.NewScalar().SetCanonicalBytes(sigShares[c.ID]) // nolint:errcheck // verified above
+
+		z.Add(z, share)
+	}
+
+	sig := append(sc.groupCommitment.Bytes(), z.Bytes()...)
+
+	if !ed25519.Verify(publicKey, msg, sig) {
+		return nil, errors.New("frost: invalid group signature")
+	}
+
+	return sig, nil
+}
+
+// signingContext holds the values derived from the commitments shared by all the signing participants.
+type signingContext struct {
+	publicKey       *edwards25519.Point
+	commitments     []*Commitment
+	bindingFactors  map[uint16]*edwards25519.Scalar
+	groupCommitment *edwards25519.Point
+	challenge       *edwards25519.Scalar
+}
+
+func newSigningContext(publicKey, msg []byte, commitments []*Commitment) (*signingContext, error) {
+	pk, err := new(edwards25519.Point).SetBytes(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("frost: invalid group public key: %w", err)
+	}
+
+	sorted := append([]*Commitment{}, commitments...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	sc := &signingContext{
+		publicKey:       pk,
+		commitments:     sorted,
+		bindingFactors:  make(map[uint16]*edwards25519.Scalar, len(sorted)),
+		groupCommitment: edwards25519.NewIdentityPoint(),
+	}
+
+	// encode_group_commitment_list
+	var encodedCommitments []byte
+
+	for i, c := range sorted {
+		if c.ID == 0 || (i > 0 && sorted[i-1].ID == c.ID) {
+			return nil, fmt.Errorf("frost: invalid commitment identifier %d", c.ID)
+		}
+
+		encodedCommitments = append(encodedCommitments, identifier(c.ID).Bytes()...)
+		encodedCommitments = append(encodedCommitments, c.Hiding...)
+		encodedCommitments = append(encodedCommitments, c.Binding...)
+	}
+
+	// compute_binding_factors
+	prefix := append([]byte{}, publicKey...)
+	prefix = append(prefix, hash("msg", msg)...)
+	prefix = append(prefix, hash("com", encodedCommitments)...)
+
+	for _, c := range sorted {
+		rho, e := edwards25519.NewScalar().SetUniformBytes(hash("rho", append(prefix, identifier(c.ID).Bytes()...)))
+		if e != nil {
+			return nil, fmt.Errorf("frost: %w", e)
+		}
+
+		sc.bindingFactors[c.ID] = rho
+
+		// compute_group_commitment
+		commitmentShare, e := commitmentShare(c, rho)
+		if e != nil {
+			return nil, e
+		}
+
+		sc.groupCommitment.Add(sc.groupCommitment, commitmentShare)
+	}
+
+	// compute_challenge, H2 has no context string for compatibility with Ed25519.
+	h := sha512.New()
+	h.Write(sc.groupCommitment.Bytes())
+	h.Write(publicKey)
+	h.Write(msg)
+
+	sc.challenge, err = edwards25519.NewScalar().SetUniformBytes(h.Sum(nil))
+	if err != nil {
+		return nil, fmt.Errorf("frost: %w", err)
+	}
+
+	return sc, nil
+}
+
+// lambda is the Lagrange coefficient of id for the signing participants (derive_interpolating_value).
+func (sc *signingContext) lambda(id uint16) *edwards25519.Scalar {
+	x := identifier(id)
+	num := edwards25519.NewScalar().Set(scalarOne())
+	den := edwards25519.NewScalar().Set(scalarOne())
+
+	for _, c := range sc.commitments {
+		if c.ID == id {
+			continue
+		}
+
+		xj := identifier(c.ID)
+		num.Multiply(num, xj)
+		den.Multiply(den, edwards25519.NewScalar().Subtract(xj, x))
+	}
+
+	return num.Multiply(num, den.Invert(den))
+}
+
+// verifyShare implements verify_signature_share.
+func (sc *signingContext) verifyShare(id uint16, verifyingShare, sigShare []byte) error {
+	var commitment *Commitment
+
+	for _, c := range sc.commitments {
+		if c.ID == id {
+			commitment = c
+		}
+	}
+
+	if commitment == nil {
+		return fmt.Errorf("frost: missing commitment of participant %d", id)
+	}
+
+	pk, err := new(edwards25519.Point).SetBytes(verifyingShare)
+	if err != nil {
+		return fmt.Errorf("frost: invalid verifying share of participant %d", id)
+	}
+
+	z, err := edwards25519.NewScalar().SetCanonicalBytes(sigShare)
+	if err != nil {
+		return fmt.Errorf("frost: invalid signature share of participant %d", id)
+	}
+
+	commShare, err := commitmentShare(commitment, sc.bindingFactors[id])
+	if err != nil {
+		return err
+	}
+
+	// z * G == commShare + (c * lambda) * pk
+	cl := edwards25519.NewScalar().Multiply(sc.challenge, sc.lambda(id))
+	r := new(edwards25519.Point).ScalarMult(cl, pk)
+	r.Add(r, commShare)
+
+	if new(edwards25519.Point).ScalarBaseMult(z).Equal(r) != 1 {
+		return fmt.Errorf("frost: invalid signature share of participant %d", id)
+	}
+
+	return nil
+}
+
+func commitmentShare(c *Commitment, rho *edwards25519.Scalar) (*edwards25519.Point, error) {
+	hiding, err := new(edwards25519.Point).SetBytes(c.Hiding)
+	if err != nil {
+		return nil, fmt.Errorf("frost: invalid commitment of participant %d", c.ID)
+	}
+
+	binding, err := new(edwards25519.Point).SetBytes(c.Binding)
+	if err != nil {
+		return nil, fmt.Errorf("frost: invalid commitment of participant %d", c.ID)
+	}
+
+	share := new(edwards25519.Point).ScalarMult(rho, binding)
+
+	return share.Add(share, hiding), nil
+}
+
+// generateNonce implements nonce_generate, mixing fresh randomness with the secret share.
+func generateNonce(secret *edwards25519.Scalar) (*edwards25519.Scalar, error) {
+	random := make([]byte, ScalarSize)
+
+	_, err := rand.Read(random)
+	if err != nil {
+		return nil, fmt.Errorf("frost: failed to generate nonce: %w", err)
+	}
+
+	return deriveNonce(random, secret)
+}
+
+func deriveNonce(random []byte, secret *edwards25519.Scalar) (*edwards25519.Scalar, error) {
+	nonce, err := edwards25519.NewScalar().SetUniformBytes(hash("nonce", append(append([]byte{}, random...), secret.Bytes()...)))
+	if err != nil {
+		return nil, fmt.Errorf("frost: %w", err)
+	}
+
+	return nonce, nil
+}
+
+func randomScalar() (*edwards25519.Scalar, error) {
+	b := make([]byte, 64) // nolint:gomnd
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return nil, fmt.Errorf("frost: failed to generate scalar: %w", err)
+	}
+
+	s, err := edwards25519.NewScalar().SetUniformBytes(b)
+	if err != nil {
+		return nil, fmt.Errorf("frost: %w", err)
+	}
+
+	return s, nil
+}
+
+// hash implements H1, H3, H4 and H5 of the ciphersuite, tag being their domain separation.
+func hash(tag string, msg []byte) []byte {
+	h := sha512.New()
+	h.Write([]byte(contextString + tag))
+	h.Write(msg)
+
+	return h.Sum(nil)
+}
+
+func identifier(id uint16) *edwards25519.Scalar {
+	b := make([]byte, ScalarSize)
+	binary.LittleEndian.PutUint16(b, id)
+
+	s, _ := edwards25519.NewScalar().SetCanonicalBytes(b) // nolint:errcheck // always canonical
+
+	return s
+}
+
+func scalarOne() *edwards25519.Scalar {
+	return identifier(1)
+}
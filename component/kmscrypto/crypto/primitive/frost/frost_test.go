@@ -0,0 +1,265 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package frost
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"filippo.io/edwards25519"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndAggregate(t *testing.T) {
+	ids := []uint16{1, 2, 3, 4, 5}
+
+	groupKey, err := GenerateKey(3, ids)
+	require.NoError(t, err)
+	require.Len(t, groupKey.Shares, len(ids))
+	require.Len(t, groupKey.VerifyingShares, len(ids))
+
+	msg := []byte("test message")
+
+	for _, signers := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {0, 1, 2, 3, 4}} {
+		sig := sign(t, groupKey, signers, msg)
+		require.True(t, ed25519.Verify(groupKey.PublicKey, msg, sig))
+	}
+}
+
+func TestSplitEd25519(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	groupKey, err := SplitEd25519(privKey, 2, []uint16{10, 20, 30})
+	require.NoError(t, err)
+	require.Equal(t, pubKey, groupKey.PublicKey)
+
+	msg := []byte("test message")
+
+	sig := sign(t, groupKey, []int{2, 0}, msg)
+	require.True(t, ed25519.Verify(pubKey, msg, sig))
+
+	_, err = SplitEd25519(privKey[:10], 2, []uint16{1, 2})
+	require.EqualError(t, err, "frost: invalid Ed25519 private key")
+}
+
+func TestSplit_Failures(t *testing.T) {
+	_, err := GenerateKey(1, []uint16{1, 2})
+	require.EqualError(t, err, "frost: invalid threshold 1 for 2 participants")
+
+	_, err = GenerateKey(3, []uint16{1, 2})
+	require.EqualError(t, err, "frost: invalid threshold 3 for 2 participants")
+
+	_, err = GenerateKey(2, []uint16{1, 0})
+	require.EqualError(t, err, "frost: invalid participant identifier 0")
+
+	_, err = GenerateKey(2, []uint16{1, 1})
+	require.EqualError(t, err, "frost: invalid participant identifier 1")
+}
+
+func TestAggregate_Failures(t *testing.T) {
+	groupKey, err := GenerateKey(2, []uint16{1, 2, 3})
+	require.NoError(t, err)
+
+	msg := []byte("test message")
+	shares := groupKey.Shares[:2]
+
+	nonces := make([]*Nonces, len(shares))
+	commitments := make([]*Commitment, len(shares))
+
+	for i, share := range shares {
+		nonces[i], commitments[i], err = share.Commit()
+		require.NoError(t, err)
+	}
+
+	sigShares := map[uint16][]byte{}
+
+	for i, share := range shares {
+		sigShares[share.ID], err = share.Sign(nonces[i], msg, commitments)
+		require.NoError(t, err)
+
+		err = VerifyShare(groupKey.PublicKey, share.ID, groupKey.VerifyingShares[share.ID], sigShares[share.ID], msg,
+			commitments)
+		require.NoError(t, err)
+	}
+
+	t.Run("signature share of another message", func(t *testing.T) {
+		err = VerifyShare(groupKey.PublicKey, 1, groupKey.VerifyingShares[1], sigShares[1], []byte("other"),
+			commitments)
+		require.EqualError(t, err, "frost: invalid signature share of participant 1")
+
+		_, err = Aggregate(groupKey.PublicKey, []byte("other"), commitments, groupKey.VerifyingShares, sigShares)
+		require.EqualError(t, err, "frost: invalid signature share of participant 1")
+	})
+
+	t.Run("tampered signature share", func(t *testing.T) {
+		badShares := map[uint16][]byte{1: sigShares[1], 2: append([]byte{}, sigShares[2]...)}
+		badShares[2][0] ^= 1
+
+		_, err = Aggregate(groupKey.PublicKey, msg, commitments, groupKey.VerifyingShares, badShares)
+		require.EqualError(t, err, "frost: invalid signature share of participant 2")
+	})
+
+	t.Run("wrong verifying share", func(t *testing.T) {
+		err = VerifyShare(groupKey.PublicKey, 1, groupKey.VerifyingShares[3], sigShares[1], msg, commitments)
+		require.EqualError(t, err, "frost: invalid signature share of participant 1")
+	})
+
+	t.Run("signer without commitment", func(t *testing.T) {
+		_, err = groupKey.Shares[2].Sign(nonces[0], msg, commitments)
+		require.EqualError(t, err, "frost: missing commitment of participant 3")
+	})
+
+	t.Run("duplicate commitment", func(t *testing.T) {
+		_, err = Aggregate(groupKey.PublicKey, msg, []*Commitment{commitments[0], commitments[0]},
+			groupKey.VerifyingShares, sigShares)
+		require.EqualError(t, err, "frost: invalid commitment identifier 1")
+	})
+
+	t.Run("invalid commitment", func(t *testing.T) {
+		_, err = Aggregate(groupKey.PublicKey, msg, []*Commitment{commitments[0], {ID: 2, Hiding: []byte("bad")}},
+			groupKey.VerifyingShares, sigShares)
+		require.EqualError(t, err, "frost: invalid commitment of participant 2")
+	})
+}
+
+func sign(t *testing.T, groupKey *GroupKey, signers []int, msg []byte) []byte {
+	t.Helper()
+
+	nonces := make([]*Nonces, len(signers))
+	commitments := make([]*Commitment, len(signers))
+
+	var err error
+
+	for i, s := range signers {
+		nonces[i], commitments[i], err = groupKey.Shares[s].Commit()
+		require.NoError(t, err)
+	}
+
+	sigShares := map[uint16][]byte{}
+
+	for i, s := range signers {
+		share := groupKey.Shares[s]
+
+		sigShares[share.ID], err = share.Sign(nonces[i], msg, commitments)
+		require.NoError(t, err)
+	}
+
+	sig, err := Aggregate(groupKey.PublicKey, msg, commitments, groupKey.VerifyingShares, sigShares)
+	require.NoError(t, err)
+
+	return sig
+}
+
+// TestRFC9591Vectors checks the FROST(Ed25519, SHA-512) test vectors of RFC 9591 Appendix E.1.
+func TestRFC9591Vectors(t *testing.T) {
+	msg := decodeHex(t, "74657374")
+
+	groupKey := splitWithCoefficients([]*edwards25519.Scalar{
+		decodeScalar(t, "7b1c33d3f5291d85de664833beb1ad469f7fb6025a0ec78b3a790c6e13a98304"),
+		decodeScalar(t, "178199860edd8c62f5212ee91eff1295d0d670ab4ed4506866bae57e7030b204"),
+	}, []uint16{1, 2, 3})
+
+	require.Equal(t, decodeHex(t, "15d21ccd7ee42959562fc8aa63224c8851fb3ec85a3faf66040d380fb9738673"),
+		[]byte(groupKey.PublicKey))
+	require.Equal(t, decodeHex(t, "929dcc590407aae7d388761cddb0c0db6f5627aea8e217f4a033f2ec83d93509"),
+		groupKey.Shares[0].Secret)
+	require.Equal(t, decodeHex(t, "a91e66e012e4364ac9aaa405fcafd370402d9859f7b6685c07eed76bf409e80d"),
+		groupKey.Shares[1].Secret)
+	require.Equal(t, decodeHex(t, "d3cb090a075eb154e82fdb4b3cb507f110040905468bb9c46da8bdea643a9a02"),
+		groupKey.Shares[2].Secret)
+
+	signers := []struct {
+		share                               *KeyShare
+		hidingRandomness, bindingRandomness string
+		hidingNonce, bindingNonce           string
+		hidingCommitment, bindingCommitment string
+		bindingFactor, sigShare             string
+	}{
+		{
+			share:             groupKey.Shares[0],
+			hidingRandomness:  "0fd2e39e111cdc266f6c0f4d0fd45c947761f1f5d3cb583dfcb9bbaf8d4c9fec",
+			bindingRandomness: "69cd85f631d5f7f2721ed5e40519b1366f340a87c2f6856363dbdcda348a7501",
+			hidingNonce:       "812d6104142944d5a55924de6d49940956206909f2acaeedecda2b726e630407",
+			bindingNonce:      "b1110165fc2334149750b28dd813a39244f315cff14d4e89e6142f262ed83301",
+			hidingCommitment:  "b5aa8ab305882a6fc69cbee9327e5a45e54c08af61ae77cb8207be3d2ce13de3",
+			bindingCommitment: "67e98ab55aa310c3120418e5050c9cf76cf387cb20ac9e4b6fdb6f82a469f932",
+			bindingFactor:     "f2cb9d7dd9beff688da6fcc83fa89046b3479417f47f55600b106760eb3b5603",
+			sigShare:          "001719ab5a53ee1a12095cd088fd149702c0720ce5fd2f29dbecf24b7281b603",
+		},
+		{
+			share:             groupKey.Shares[2],
+			hidingRandomness:  "86d64a260059e495d0fb4fcc17ea3da7452391baa494d4b00321098ed2a0062f",
+			bindingRandomness: "13e6b25afb2eba51716a9a7d44130c0dbae0004a9ef8d7b5550c8a0e07c61775",
+			hidingNonce:       "c256de65476204095ebdc01bd11dc10e57b36bc96284595b8215222374f99c0e",
+			bindingNonce:      "243d71944d929063bc51205714ae3c2218bd3451d0214dfb5aeec2a90c35180d",
+			hidingCommitment:  "cfbdb165bd8aad6eb79deb8d287bcc0ab6658ae57fdcc98ed12c0669e90aec91",
+			bindingCommitment: "7487bc41a6e712eea2f2af24681b58b1cf1da278ea11fe4e8b78398965f13552",
+			bindingFactor:     "b087686bf35a13f3dc78e780a34b0fe8a77fef1b9938c563f5573d71d8d7890f",
+			sigShare:          "bd86125de990acc5e1f13781d8e32c03a9bbd4c53539bbc106058bfd14326007",
+		},
+	}
+
+	nonces := make([]*Nonces, len(signers))
+	commitments := make([]*Commitment, len(signers))
+
+	for i, signer := range signers {
+		secret := decodeScalar(t, hex.EncodeToString(signer.share.Secret))
+
+		hiding, err := deriveNonce(decodeHex(t, signer.hidingRandomness), secret)
+		require.NoError(t, err)
+		require.Equal(t, decodeHex(t, signer.hidingNonce), hiding.Bytes())
+
+		binding, err := deriveNonce(decodeHex(t, signer.bindingRandomness), secret)
+		require.NoError(t, err)
+		require.Equal(t, decodeHex(t, signer.bindingNonce), binding.Bytes())
+
+		nonces[i], commitments[i] = commit(signer.share.ID, hiding, binding)
+		require.Equal(t, decodeHex(t, signer.hidingCommitment), commitments[i].Hiding)
+		require.Equal(t, decodeHex(t, signer.bindingCommitment), commitments[i].Binding)
+	}
+
+	sc, err := newSigningContext(groupKey.PublicKey, msg, commitments)
+	require.NoError(t, err)
+
+	sigShares := make(map[uint16][]byte, len(signers))
+
+	for i, signer := range signers {
+		require.Equal(t, decodeHex(t, signer.bindingFactor), sc.bindingFactors[signer.share.ID].Bytes())
+
+		sigShare, e := signer.share.Sign(nonces[i], msg, commitments)
+		require.NoError(t, e)
+		require.Equal(t, decodeHex(t, signer.sigShare), sigShare)
+
+		sigShares[signer.share.ID] = sigShare
+	}
+
+	sig, err := Aggregate(groupKey.PublicKey, msg, commitments, groupKey.VerifyingShares, sigShares)
+	require.NoError(t, err)
+	require.Equal(t, decodeHex(t, "36282629c383bb820a88b71cae937d41f2f2adfcc3d02e55507e2fb9e2dd3cbe"+
+		"bd9d2b0844e49ae0f3fa935161e1419aab7b47d21a37ebeae1f17d4987b3160b"), sig)
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+
+	return b
+}
+
+func decodeScalar(t *testing.T, s string) *edwards25519.Scalar {
+	t.Helper()
+
+	scalar, err := edwards25519.NewScalar().SetCanonicalBytes(decodeHex(t, s))
+	require.NoError(t, err)
+
+	return scalar
+}
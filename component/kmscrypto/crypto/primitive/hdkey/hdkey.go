@@ -0,0 +1,162 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package hdkey provides hierarchical deterministic key derivation from a seed: SLIP-0010 for Ed25519 keys and BIP32
+// for secp256k1 keys. Seeds can be encoded as BIP39 mnemonics.
+package hdkey
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+)
+
+// HardenedOffset is added to the index of hardened children.
+const HardenedOffset uint32 = 0x80000000
+
+const (
+	minSeedSize = 16
+	maxSeedSize = 64
+	keySize     = 32
+
+	ed25519Curve   = "ed25519 seed"
+	secp256k1Curve = "Bitcoin seed"
+)
+
+// ParsePath parses a derivation path like m/0'/1/2' in the indexes of its children. Hardened children, marked by '
+// or h, get HardenedOffset added to their index.
+func ParsePath(path string) ([]uint32, error) {
+	segments := strings.Split(path, "/")
+	if segments[0] != "m" {
+		return nil, fmt.Errorf("hdkey: invalid derivation path '%s'", path)
+	}
+
+	indexes := make([]uint32, 0, len(segments)-1)
+
+	for _, s := range segments[1:] {
+		offset := uint32(0)
+
+		if strings.HasSuffix(s, "'") || strings.HasSuffix(s, "h") {
+			offset = HardenedOffset
+			s = s[:len(s)-1]
+		}
+
+		i, err := strconv.ParseUint(s, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("hdkey: invalid derivation path '%s'", path)
+		}
+
+		indexes = append(indexes, uint32(i)+offset)
+	}
+
+	return indexes, nil
+}
+
+// DeriveEd25519 derives the Ed25519 private key of path from seed, following SLIP-0010. Ed25519 only supports
+// hardened derivation.
+func DeriveEd25519(seed []byte, path string) (ed25519.PrivateKey, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, chainCode, err := masterKey(seed, ed25519Curve)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range indexes {
+		if i < HardenedOffset {
+			return nil, fmt.Errorf("hdkey: Ed25519 derivation path '%s' has non hardened children", path)
+		}
+
+		key, chainCode = child(chainCode, append([]byte{0}, key...), i)
+	}
+
+	return ed25519.NewKeyFromSeed(key), nil
+}
+
+// DeriveSecp256k1 derives the secp256k1 private key of path from seed, following BIP32.
+func DeriveSecp256k1(seed []byte, path string) (*ecdsa.PrivateKey, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, chainCode, err := masterKey(seed, secp256k1Curve)
+	if err != nil {
+		return nil, err
+	}
+
+	n := btcec.S256().N
+	k := new(big.Int).SetBytes(key)
+
+	if k.Sign() == 0 || k.Cmp(n) >= 0 {
+		return nil, errors.New("hdkey: invalid master key, use another seed")
+	}
+
+	for _, i := range indexes {
+		var data []byte
+
+		if i >= HardenedOffset {
+			data = append([]byte{0}, k.FillBytes(make([]byte, keySize))...)
+		} else {
+			_, pub := btcec.PrivKeyFromBytes(btcec.S256(), k.Bytes())
+			data = pub.SerializeCompressed()
+		}
+
+		var il []byte
+
+		il, chainCode = child(chainCode, data, i)
+
+		tweak := new(big.Int).SetBytes(il)
+		if tweak.Cmp(n) >= 0 {
+			return nil, fmt.Errorf("hdkey: invalid child %d, use another index", i)
+		}
+
+		k.Add(k, tweak).Mod(k, n)
+
+		if k.Sign() == 0 {
+			return nil, fmt.Errorf("hdkey: invalid child %d, use another index", i)
+		}
+	}
+
+	privKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), k.FillBytes(make([]byte, keySize)))
+
+	return privKey.ToECDSA(), nil
+}
+
+func masterKey(seed []byte, curve string) ([]byte, []byte, error) {
+	if len(seed) < minSeedSize || len(seed) > maxSeedSize {
+		return nil, nil, fmt.Errorf("hdkey: invalid seed size %d", len(seed))
+	}
+
+	mac := hmac.New(sha512.New, []byte(curve))
+	mac.Write(seed) // nolint:errcheck,gosec // never returns an error
+
+	sum := mac.Sum(nil)
+
+	return sum[:keySize], sum[keySize:], nil
+}
+
+// child returns the left and right halves of HMAC-SHA512(chainCode, data || index).
+func child(chainCode, data []byte, index uint32) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(binary.BigEndian.AppendUint32(data, index)) // nolint:errcheck,gosec // never returns an error
+
+	sum := mac.Sum(nil)
+
+	return sum[:keySize], sum[keySize:]
+}
@@ -0,0 +1,126 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hdkey
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// test vectors 1 of SLIP-0010 and BIP32.
+const testSeed = "000102030405060708090a0b0c0d0e0f"
+
+func TestDeriveEd25519(t *testing.T) {
+	seed, err := hex.DecodeString(testSeed)
+	require.NoError(t, err)
+
+	for path, expected := range map[string]string{
+		"m":                         "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7",
+		"m/0'":                      "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3",
+		"m/0'/1'/2'/2'/1000000000'": "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793",
+	} {
+		key, e := DeriveEd25519(seed, path)
+		require.NoError(t, e, path)
+		require.Equal(t, expected, hex.EncodeToString(key.Seed()), path)
+	}
+
+	_, err = DeriveEd25519(seed, "m/0'/1")
+	require.EqualError(t, err, "hdkey: Ed25519 derivation path 'm/0'/1' has non hardened children")
+
+	_, err = DeriveEd25519(seed[:8], "m/0'")
+	require.EqualError(t, err, "hdkey: invalid seed size 8")
+}
+
+func TestDeriveSecp256k1(t *testing.T) {
+	seed, err := hex.DecodeString(testSeed)
+	require.NoError(t, err)
+
+	for path, expected := range map[string]string{
+		"m":                          "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35",
+		"m/0'":                       "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+		"m/0'/1":                     "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368",
+		"m/0h/1/2h/2/1000000000":     "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8",
+		"m/0'/1/2'/2/1000000000/0'":  "",
+		"m/2147483647'/1/0'/5/3'/7h": "",
+	} {
+		key, e := DeriveSecp256k1(seed, path)
+		require.NoError(t, e, path)
+
+		if expected != "" {
+			require.Equal(t, expected, hex.EncodeToString(key.D.FillBytes(make([]byte, 32))), path)
+		}
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	indexes, err := ParsePath("m/44'/0h/7")
+	require.NoError(t, err)
+	require.Equal(t, []uint32{44 + HardenedOffset, HardenedOffset, 7}, indexes)
+
+	for _, path := range []string{"", "n/0", "m/", "m/a", "m/-1", "m/2147483648", "m/0''"} {
+		_, err = ParsePath(path)
+		require.EqualError(t, err, "hdkey: invalid derivation path '"+path+"'")
+	}
+}
+
+func TestMnemonic(t *testing.T) {
+	// BIP39 test vectors, with the TREZOR passphrase.
+	for entropy, expected := range map[string][2]string{
+		"00000000000000000000000000000000": {
+			strings.Repeat("abandon ", 11) + "about",
+			"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4" +
+				"ab7c81b2f001698e7463b04",
+		},
+		"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f": {
+			"legal winner thank year wave sausage worth useful legal winner thank yellow",
+			"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5b" +
+				"d381ee6260e8d9739fce1f607",
+		},
+	} {
+		entropyBytes, err := hex.DecodeString(entropy)
+		require.NoError(t, err)
+
+		mnemonic, err := NewMnemonic(entropyBytes)
+		require.NoError(t, err)
+		require.Equal(t, expected[0], mnemonic)
+
+		decoded, err := ValidateMnemonic(mnemonic)
+		require.NoError(t, err)
+		require.Equal(t, entropyBytes, decoded)
+
+		seed, err := MnemonicToSeed(mnemonic, "TREZOR")
+		require.NoError(t, err)
+		require.Equal(t, expected[1], hex.EncodeToString(seed))
+	}
+
+	for _, size := range []int{128, 160, 192, 224, 256} {
+		mnemonic, err := GenerateMnemonic(size)
+		require.NoError(t, err)
+		require.Len(t, strings.Fields(mnemonic), size*33/32/11)
+
+		_, err = MnemonicToSeed(mnemonic, "")
+		require.NoError(t, err)
+	}
+
+	_, err := GenerateMnemonic(100)
+	require.EqualError(t, err, "hdkey: invalid entropy size 100")
+
+	_, err = NewMnemonic(make([]byte, 8))
+	require.EqualError(t, err, "hdkey: invalid entropy size 64")
+
+	for _, mnemonic := range []string{
+		strings.Repeat("abandon ", 12),
+		strings.Repeat("abandon ", 11) + "unknownword",
+		"abandon abandon about",
+	} {
+		_, err = MnemonicToSeed(mnemonic, "")
+		require.ErrorIs(t, err, ErrInvalidMnemonic)
+	}
+}
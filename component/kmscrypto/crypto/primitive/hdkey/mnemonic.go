@@ -0,0 +1,142 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hdkey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed" // for the BIP39 word list
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	wordBits        = 11
+	seedIterations  = 2048
+	seedSize        = 64
+	minEntropyBits  = 128
+	maxEntropyBits  = 256
+	entropyBitsStep = 32
+)
+
+// englishWords is the BIP39 English word list.
+//
+//go:embed english.txt
+var englishWords string
+
+// nolint:gochecknoglobals
+var (
+	wordList  = strings.Fields(englishWords)
+	wordIndex = func() map[string]int {
+		index := make(map[string]int, len(wordList))
+
+		for i, w := range wordList {
+			index[w] = i
+		}
+
+		return index
+	}()
+)
+
+// ErrInvalidMnemonic is returned for mnemonics with unknown words, an invalid length or an invalid checksum.
+var ErrInvalidMnemonic = errors.New("hdkey: invalid mnemonic")
+
+// GenerateMnemonic returns a new BIP39 English mnemonic encoding bitSize bits of random entropy. bitSize must be a
+// multiple of 32 between 128 (12 words) and 256 (24 words).
+func GenerateMnemonic(bitSize int) (string, error) {
+	if bitSize < minEntropyBits || bitSize > maxEntropyBits || bitSize%entropyBitsStep != 0 {
+		return "", fmt.Errorf("hdkey: invalid entropy size %d", bitSize)
+	}
+
+	entropy := make([]byte, bitSize/8)
+
+	_, err := rand.Read(entropy)
+	if err != nil {
+		return "", fmt.Errorf("hdkey: failed to generate entropy: %w", err)
+	}
+
+	return NewMnemonic(entropy)
+}
+
+// NewMnemonic returns the BIP39 English mnemonic encoding entropy, of 16 to 32 bytes by steps of 4.
+func NewMnemonic(entropy []byte) (string, error) {
+	bitSize := len(entropy) * 8
+	if bitSize < minEntropyBits || bitSize > maxEntropyBits || bitSize%entropyBitsStep != 0 {
+		return "", fmt.Errorf("hdkey: invalid entropy size %d", bitSize)
+	}
+
+	checksumBits := bitSize / entropyBitsStep
+	checksum := sha256.Sum256(entropy)
+
+	// entropy followed by the first checksumBits bits of its hash.
+	data := new(big.Int).SetBytes(entropy)
+	data.Lsh(data, uint(checksumBits))
+	data.Or(data, big.NewInt(int64(checksum[0]>>(8-checksumBits))))
+
+	words := make([]string, (bitSize+checksumBits)/wordBits)
+	mask := big.NewInt(1<<wordBits - 1)
+
+	for i := len(words) - 1; i >= 0; i-- {
+		words[i] = wordList[new(big.Int).And(data, mask).Int64()]
+		data.Rsh(data, wordBits)
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+// ValidateMnemonic checks the words and the checksum of a BIP39 English mnemonic and returns its entropy.
+func ValidateMnemonic(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+
+	bitSize := len(words) * wordBits
+	checksumBits := bitSize / (entropyBitsStep + 1)
+	entropyBits := bitSize - checksumBits
+
+	if entropyBits < minEntropyBits || entropyBits > maxEntropyBits || entropyBits%entropyBitsStep != 0 {
+		return nil, ErrInvalidMnemonic
+	}
+
+	data := new(big.Int)
+
+	for _, w := range words {
+		i, ok := wordIndex[w]
+		if !ok {
+			return nil, ErrInvalidMnemonic
+		}
+
+		data.Lsh(data, wordBits)
+		data.Or(data, big.NewInt(int64(i)))
+	}
+
+	checksum := new(big.Int).And(data, big.NewInt(1<<checksumBits-1)).Int64()
+	entropy := data.Rsh(data, uint(checksumBits)).FillBytes(make([]byte, entropyBits/8))
+
+	hash := sha256.Sum256(entropy)
+	if int64(hash[0]>>(8-checksumBits)) != checksum {
+		return nil, ErrInvalidMnemonic
+	}
+
+	return entropy, nil
+}
+
+// MnemonicToSeed validates a BIP39 English mnemonic and returns its 64 bytes seed, protected by the optional
+// passphrase. Words must be separated by spaces and the passphrase must be in Unicode NFKD form.
+func MnemonicToSeed(mnemonic, passphrase string) ([]byte, error) {
+	_, err := ValidateMnemonic(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := strings.Join(strings.Fields(mnemonic), " ")
+
+	return pbkdf2.Key([]byte(normalized), []byte("mnemonic"+passphrase), seedIterations, seedSize, sha512.New), nil
+}
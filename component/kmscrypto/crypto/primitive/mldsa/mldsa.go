@@ -0,0 +1,275 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package mldsa implements ML-DSA (FIPS 204) keys and signatures for the ML-DSA-44, ML-DSA-65 and ML-DSA-87
+// parameter sets on top of github.com/cloudflare/circl/sign/mldsa.
+//
+// Private keys are encoded as their 32 bytes seed, public keys with the FIPS 204 pkEncode encoding. Signatures use
+// the pure (non pre-hashed) ML-DSA variant and are hedged with randomness from crypto/rand.
+package mldsa
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cloudflare/circl/sign"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa44"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa87"
+)
+
+const (
+	// PrivateKeySize is the size of private key seeds.
+	PrivateKeySize = mldsa65.SeedSize
+
+	// MLDSA44PublicKeySize is the size of ML-DSA-44 public keys.
+	MLDSA44PublicKeySize = mldsa44.PublicKeySize
+	// MLDSA65PublicKeySize is the size of ML-DSA-65 public keys.
+	MLDSA65PublicKeySize = mldsa65.PublicKeySize
+	// MLDSA87PublicKeySize is the size of ML-DSA-87 public keys.
+	MLDSA87PublicKeySize = mldsa87.PublicKeySize
+
+	// MLDSA44SignatureSize is the size of ML-DSA-44 signatures.
+	MLDSA44SignatureSize = mldsa44.SignatureSize
+	// MLDSA65SignatureSize is the size of ML-DSA-65 signatures.
+	MLDSA65SignatureSize = mldsa65.SignatureSize
+	// MLDSA87SignatureSize is the size of ML-DSA-87 signatures.
+	MLDSA87SignatureSize = mldsa87.SignatureSize
+)
+
+// ErrInvalidSignature is returned when a signature does not verify.
+var ErrInvalidSignature = errors.New("mldsa: invalid signature")
+
+type parameters struct {
+	scheme sign.Scheme
+	signTo func(key sign.PrivateKey, message, context, signature []byte) error
+}
+
+var (
+	mldsa44Parameters = &parameters{scheme: mldsa44.Scheme(), signTo: hedgedSigner(mldsa44.SignTo)}
+	mldsa65Parameters = &parameters{scheme: mldsa65.Scheme(), signTo: hedgedSigner(mldsa65.SignTo)}
+	mldsa87Parameters = &parameters{scheme: mldsa87.Scheme(), signTo: hedgedSigner(mldsa87.SignTo)}
+)
+
+// Parameters is an ML-DSA parameter set. Parameters values are comparable, the zero value is invalid.
+type Parameters struct {
+	p *parameters
+}
+
+// MLDSA44 returns the ML-DSA-44 parameter set.
+func MLDSA44() Parameters { return Parameters{p: mldsa44Parameters} }
+
+// MLDSA65 returns the ML-DSA-65 parameter set.
+func MLDSA65() Parameters { return Parameters{p: mldsa65Parameters} }
+
+// MLDSA87 returns the ML-DSA-87 parameter set.
+func MLDSA87() Parameters { return Parameters{p: mldsa87Parameters} }
+
+// String returns the name of the parameter set, e.g. "ML-DSA-65".
+func (p Parameters) String() string {
+	if p.p == nil {
+		return "invalid"
+	}
+
+	return p.p.scheme.Name()
+}
+
+// PublicKeySize returns the size of the public keys of the parameter set.
+func (p Parameters) PublicKeySize() int {
+	if p.p == nil {
+		return 0
+	}
+
+	return p.p.scheme.PublicKeySize()
+}
+
+// SignatureSize returns the size of the signatures of the parameter set.
+func (p Parameters) SignatureSize() int {
+	if p.p == nil {
+		return 0
+	}
+
+	return p.p.scheme.SignatureSize()
+}
+
+// Options are the signing and verification options.
+type Options struct {
+	// Context is the optional FIPS 204 context string, at most 255 bytes.
+	Context string
+}
+
+// HashFunc returns zero, ML-DSA signs messages directly. It implements crypto.SignerOpts.
+func (*Options) HashFunc() crypto.Hash {
+	return 0
+}
+
+// PublicKey is an ML-DSA public key.
+type PublicKey struct {
+	params Parameters
+	key    sign.PublicKey
+}
+
+// PrivateKey is an ML-DSA private key. It implements crypto.Signer.
+type PrivateKey struct {
+	params Parameters
+	seed   []byte
+	key    sign.PrivateKey
+	pub    *PublicKey
+}
+
+// GenerateKey generates a new random private key for params.
+func GenerateKey(params Parameters) (*PrivateKey, error) {
+	seed := make([]byte, PrivateKeySize)
+
+	_, err := rand.Read(seed)
+	if err != nil {
+		return nil, fmt.Errorf("mldsa: failed to generate seed: %w", err)
+	}
+
+	return NewPrivateKey(params, seed)
+}
+
+// NewPrivateKey derives the private key of params from its 32 bytes seed.
+func NewPrivateKey(params Parameters, seed []byte) (*PrivateKey, error) {
+	if params.p == nil {
+		return nil, errors.New("mldsa: invalid parameters")
+	}
+
+	if len(seed) != PrivateKeySize {
+		return nil, errors.New("mldsa: invalid private key size")
+	}
+
+	pub, priv := params.p.scheme.DeriveKey(seed)
+
+	return &PrivateKey{
+		params: params,
+		seed:   append([]byte(nil), seed...),
+		key:    priv,
+		pub:    &PublicKey{params: params, key: pub},
+	}, nil
+}
+
+// NewPublicKey decodes the public key of params from its encoding.
+func NewPublicKey(params Parameters, encoding []byte) (*PublicKey, error) {
+	if params.p == nil {
+		return nil, errors.New("mldsa: invalid parameters")
+	}
+
+	key, err := params.p.scheme.UnmarshalBinaryPublicKey(encoding)
+	if err != nil {
+		return nil, fmt.Errorf("mldsa: invalid public key: %w", err)
+	}
+
+	return &PublicKey{params: params, key: key}, nil
+}
+
+// Parameters returns the parameter set of k.
+func (k *PrivateKey) Parameters() Parameters {
+	return k.params
+}
+
+// Bytes returns the private key encoding, its seed.
+func (k *PrivateKey) Bytes() []byte {
+	return append([]byte(nil), k.seed...)
+}
+
+// PublicKey returns the public key of k.
+func (k *PrivateKey) PublicKey() *PublicKey {
+	return k.pub
+}
+
+// Public returns the public key of k, implements crypto.Signer.
+func (k *PrivateKey) Public() crypto.PublicKey {
+	return k.pub
+}
+
+// Equal reports whether k and x are the same key.
+func (k *PrivateKey) Equal(x crypto.PrivateKey) bool {
+	other, ok := x.(*PrivateKey)
+
+	return ok && k.params == other.params && bytes.Equal(k.seed, other.seed)
+}
+
+// Sign returns the signature of message, implements crypto.Signer. opts may be an *Options to set a context,
+// otherwise its HashFunc must be zero. The io.Reader argument is ignored.
+func (k *PrivateKey) Sign(_ io.Reader, message []byte, opts crypto.SignerOpts) ([]byte, error) {
+	var context []byte
+
+	switch o := opts.(type) {
+	case *Options:
+		if o != nil {
+			context = []byte(o.Context)
+		}
+	case nil:
+	default:
+		if o.HashFunc() != 0 {
+			return nil, errors.New("mldsa: cannot sign pre-hashed messages")
+		}
+	}
+
+	signature := make([]byte, k.params.SignatureSize())
+
+	err := k.params.p.signTo(k.key, message, context, signature)
+	if err != nil {
+		return nil, fmt.Errorf("mldsa: %w", err)
+	}
+
+	return signature, nil
+}
+
+// Parameters returns the parameter set of k.
+func (k *PublicKey) Parameters() Parameters {
+	return k.params
+}
+
+// Bytes returns the public key encoding.
+func (k *PublicKey) Bytes() []byte {
+	encoding, _ := k.key.MarshalBinary() //nolint:errcheck // never fails for ML-DSA keys
+
+	return encoding
+}
+
+// Equal reports whether k and x are the same key.
+func (k *PublicKey) Equal(x crypto.PublicKey) bool {
+	other, ok := x.(*PublicKey)
+
+	return ok && k.params == other.params && k.key.Equal(other.key)
+}
+
+// Verify checks the signature of message by pub. opts may be nil or set the context of the signature.
+func Verify(pub *PublicKey, message, signature []byte, opts *Options) error {
+	signOpts := &sign.SignatureOpts{}
+	if opts != nil {
+		signOpts.Context = opts.Context
+	}
+
+	if len(signature) != pub.params.SignatureSize() || len(signOpts.Context) > 255 {
+		return ErrInvalidSignature
+	}
+
+	if !pub.params.p.scheme.Verify(pub.key, message, signature, signOpts) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func hedgedSigner[K sign.PrivateKey](
+	signTo func(K, []byte, []byte, bool, []byte) error,
+) func(sign.PrivateKey, []byte, []byte, []byte) error {
+	return func(key sign.PrivateKey, message, context, signature []byte) error {
+		k, ok := key.(K)
+		if !ok {
+			return sign.ErrTypeMismatch
+		}
+
+		return signTo(k, message, context, true, signature)
+	}
+}
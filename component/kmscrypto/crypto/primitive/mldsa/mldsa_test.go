@@ -0,0 +1,120 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mldsa
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVerify(t *testing.T) {
+	for _, params := range []Parameters{MLDSA44(), MLDSA65(), MLDSA87()} {
+		t.Run(params.String(), func(t *testing.T) {
+			privKey, err := GenerateKey(params)
+			require.NoError(t, err)
+			require.Equal(t, params, privKey.Parameters())
+
+			msg := []byte("long-lived credential")
+
+			sig, err := privKey.Sign(rand.Reader, msg, nil)
+			require.NoError(t, err)
+			require.Len(t, sig, params.SignatureSize())
+
+			require.NoError(t, Verify(privKey.PublicKey(), msg, sig, nil))
+
+			ctxSig, err := privKey.Sign(rand.Reader, msg, &Options{Context: "context"})
+			require.NoError(t, err)
+
+			require.NoError(t, Verify(privKey.PublicKey(), msg, ctxSig, &Options{Context: "context"}))
+			require.ErrorIs(t, Verify(privKey.PublicKey(), msg, ctxSig, nil), ErrInvalidSignature)
+
+			otherKey, err := GenerateKey(params)
+			require.NoError(t, err)
+
+			require.ErrorIs(t, Verify(otherKey.PublicKey(), msg, sig, nil), ErrInvalidSignature)
+			require.ErrorIs(t, Verify(privKey.PublicKey(), []byte("other message"), sig, nil), ErrInvalidSignature)
+			require.ErrorIs(t, Verify(privKey.PublicKey(), msg, sig[1:], nil), ErrInvalidSignature)
+		})
+	}
+
+	t.Run("pre-hashed messages are not supported", func(t *testing.T) {
+		privKey, err := GenerateKey(MLDSA44())
+		require.NoError(t, err)
+
+		_, err = privKey.Sign(rand.Reader, []byte("msg"), crypto.SHA512)
+		require.EqualError(t, err, "mldsa: cannot sign pre-hashed messages")
+	})
+}
+
+func TestKeyEncoding(t *testing.T) {
+	seed := make([]byte, PrivateKeySize)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+
+	// SHA-256 digests of the FIPS 204 public keys derived from seed.
+	tests := []struct {
+		params    Parameters
+		pubKeySum string
+	}{
+		{MLDSA44(), "9f107644c1084526af3bc8098680b05499a2325a644e388fb4f970e058d19d46"},
+		{MLDSA65(), "d666806e11cee19a7c989f7445f90dd419cf4d2d51db8c0fdb4c0f0a542238c9"},
+		{MLDSA87(), "91dc389cfaa01470b7f66eee45a4ae9026d154817c754dfe22298b3fa241ffcd"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.params.String(), func(t *testing.T) {
+			privKey, err := NewPrivateKey(tc.params, seed)
+			require.NoError(t, err)
+			require.Equal(t, seed, privKey.Bytes())
+
+			pubKeyBytes := privKey.PublicKey().Bytes()
+			require.Len(t, pubKeyBytes, tc.params.PublicKeySize())
+
+			pubKeySum := sha256.Sum256(pubKeyBytes)
+			require.Equal(t, tc.pubKeySum, hex.EncodeToString(pubKeySum[:]))
+
+			decodedPubKey, err := NewPublicKey(tc.params, pubKeyBytes)
+			require.NoError(t, err)
+			require.True(t, decodedPubKey.Equal(privKey.Public()))
+
+			otherPrivKey, err := NewPrivateKey(tc.params, privKey.Bytes())
+			require.NoError(t, err)
+			require.True(t, privKey.Equal(otherPrivKey))
+
+			_, err = NewPublicKey(tc.params, pubKeyBytes[1:])
+			require.ErrorContains(t, err, "mldsa: invalid public key")
+		})
+	}
+
+	t.Run("keys of different parameter sets differ", func(t *testing.T) {
+		privKey44, err := NewPrivateKey(MLDSA44(), seed)
+		require.NoError(t, err)
+
+		privKey65, err := NewPrivateKey(MLDSA65(), seed)
+		require.NoError(t, err)
+
+		require.False(t, privKey44.Equal(privKey65))
+		require.False(t, privKey44.PublicKey().Equal(privKey65.PublicKey()))
+	})
+
+	t.Run("invalid private keys", func(t *testing.T) {
+		_, err := NewPrivateKey(MLDSA65(), seed[1:])
+		require.EqualError(t, err, "mldsa: invalid private key size")
+
+		_, err = NewPrivateKey(Parameters{}, seed)
+		require.EqualError(t, err, "mldsa: invalid parameters")
+
+		_, err = NewPublicKey(Parameters{}, nil)
+		require.EqualError(t, err, "mldsa: invalid parameters")
+	})
+}
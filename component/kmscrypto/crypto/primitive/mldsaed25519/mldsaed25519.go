@@ -0,0 +1,186 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package mldsaed25519 implements the composite ML-DSA-65 and Ed25519 signature scheme, a hybrid post-quantum
+// signature valid only if both its ML-DSA-65 (FIPS 204) and Ed25519 components are valid. It follows the
+// id-MLDSA65-Ed25519-SHA512 construction of the IETF composite ML-DSA draft (draft-ietf-lamps-pq-composite-sigs):
+//
+//	M' = Prefix || Label || len(ctx) || ctx || SHA-512(M), with an empty ctx
+//	signature = ML-DSA-65.Sign(M', ctx=Label) || Ed25519.Sign(M')
+//
+// Public keys are the concatenation of the ML-DSA-65 and Ed25519 public keys, private keys the concatenation of
+// their seeds.
+package mldsaed25519
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsa"
+)
+
+const (
+	// PublicKeySize is the size of composite public keys.
+	PublicKeySize = mldsa.MLDSA65PublicKeySize + ed25519.PublicKeySize
+	// PrivateKeySize is the size of composite private keys.
+	PrivateKeySize = mldsa.PrivateKeySize + ed25519.SeedSize
+	// SignatureSize is the size of composite signatures.
+	SignatureSize = mldsa.MLDSA65SignatureSize + ed25519.SignatureSize
+
+	prefix = "CompositeAlgorithmSignatures2025"
+	label  = "COMPSIG-MLDSA65-Ed25519-SHA512"
+)
+
+// ErrInvalidSignature is returned when a composite signature does not verify.
+var ErrInvalidSignature = errors.New("mldsaed25519: invalid signature")
+
+// PublicKey is a composite ML-DSA-65 and Ed25519 public key.
+type PublicKey struct {
+	mldsaKey   *mldsa.PublicKey
+	ed25519Key ed25519.PublicKey
+}
+
+// PrivateKey is a composite ML-DSA-65 and Ed25519 private key. It implements crypto.Signer.
+type PrivateKey struct {
+	mldsaKey   *mldsa.PrivateKey
+	ed25519Key ed25519.PrivateKey
+}
+
+// GenerateKey generates a new random composite private key.
+func GenerateKey() (*PrivateKey, error) {
+	seed := make([]byte, PrivateKeySize)
+
+	_, err := rand.Read(seed)
+	if err != nil {
+		return nil, fmt.Errorf("mldsaed25519: failed to generate seed: %w", err)
+	}
+
+	return NewPrivateKey(seed)
+}
+
+// NewPrivateKey decodes a composite private key from the ML-DSA-65 seed followed by the Ed25519 seed.
+func NewPrivateKey(seed []byte) (*PrivateKey, error) {
+	if len(seed) != PrivateKeySize {
+		return nil, errors.New("mldsaed25519: invalid private key size")
+	}
+
+	mldsaKey, err := mldsa.NewPrivateKey(mldsa.MLDSA65(), seed[:mldsa.PrivateKeySize])
+	if err != nil {
+		return nil, fmt.Errorf("mldsaed25519: %w", err)
+	}
+
+	return &PrivateKey{
+		mldsaKey:   mldsaKey,
+		ed25519Key: ed25519.NewKeyFromSeed(seed[mldsa.PrivateKeySize:]),
+	}, nil
+}
+
+// NewPublicKey decodes a composite public key from the ML-DSA-65 public key followed by the Ed25519 public key.
+func NewPublicKey(encoding []byte) (*PublicKey, error) {
+	if len(encoding) != PublicKeySize {
+		return nil, errors.New("mldsaed25519: invalid public key size")
+	}
+
+	mldsaKey, err := mldsa.NewPublicKey(mldsa.MLDSA65(), encoding[:mldsa.MLDSA65PublicKeySize])
+	if err != nil {
+		return nil, fmt.Errorf("mldsaed25519: %w", err)
+	}
+
+	edKey := make(ed25519.PublicKey, ed25519.PublicKeySize)
+	copy(edKey, encoding[mldsa.MLDSA65PublicKeySize:])
+
+	return &PublicKey{mldsaKey: mldsaKey, ed25519Key: edKey}, nil
+}
+
+// Bytes returns the private key encoding, the ML-DSA-65 seed followed by the Ed25519 seed.
+func (k *PrivateKey) Bytes() []byte {
+	return append(k.mldsaKey.Bytes(), k.ed25519Key.Seed()...)
+}
+
+// PublicKey returns the public key of k.
+func (k *PrivateKey) PublicKey() *PublicKey {
+	edKey, _ := k.ed25519Key.Public().(ed25519.PublicKey) //nolint:errcheck // always an ed25519.PublicKey
+
+	return &PublicKey{mldsaKey: k.mldsaKey.PublicKey(), ed25519Key: edKey}
+}
+
+// Public returns the public key of k, implements crypto.Signer.
+func (k *PrivateKey) Public() crypto.PublicKey {
+	return k.PublicKey()
+}
+
+// Equal reports whether k and x are the same key.
+func (k *PrivateKey) Equal(x crypto.PrivateKey) bool {
+	other, ok := x.(*PrivateKey)
+
+	return ok && k.mldsaKey.Equal(other.mldsaKey) && k.ed25519Key.Equal(other.ed25519Key)
+}
+
+// Sign returns the composite signature of message, implements crypto.Signer. The message is signed directly, opts
+// must be nil or have a zero HashFunc. The io.Reader argument is ignored.
+func (k *PrivateKey) Sign(_ io.Reader, message []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if opts != nil && opts.HashFunc() != 0 {
+		return nil, errors.New("mldsaed25519: cannot sign pre-hashed messages")
+	}
+
+	mPrime := messageRepresentative(message)
+
+	mldsaSig, err := k.mldsaKey.Sign(nil, mPrime, &mldsa.Options{Context: label})
+	if err != nil {
+		return nil, fmt.Errorf("mldsaed25519: %w", err)
+	}
+
+	return append(mldsaSig, ed25519.Sign(k.ed25519Key, mPrime)...), nil
+}
+
+// Bytes returns the public key encoding, the ML-DSA-65 public key followed by the Ed25519 public key.
+func (k *PublicKey) Bytes() []byte {
+	return append(k.mldsaKey.Bytes(), k.ed25519Key...)
+}
+
+// Equal reports whether k and x are the same key.
+func (k *PublicKey) Equal(x crypto.PublicKey) bool {
+	other, ok := x.(*PublicKey)
+
+	return ok && bytes.Equal(k.Bytes(), other.Bytes())
+}
+
+// Verify checks the composite signature of message, both components must be valid.
+func Verify(pub *PublicKey, message, signature []byte) error {
+	if len(signature) != SignatureSize {
+		return ErrInvalidSignature
+	}
+
+	mPrime := messageRepresentative(message)
+
+	err := mldsa.Verify(pub.mldsaKey, mPrime, signature[:mldsa.MLDSA65SignatureSize], &mldsa.Options{Context: label})
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if !ed25519.Verify(pub.ed25519Key, mPrime, signature[mldsa.MLDSA65SignatureSize:]) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func messageRepresentative(message []byte) []byte {
+	digest := sha512.Sum512(message)
+
+	mPrime := make([]byte, 0, len(prefix)+len(label)+1+len(digest))
+	mPrime = append(mPrime, prefix...)
+	mPrime = append(mPrime, label...)
+	mPrime = append(mPrime, 0) // empty context.
+
+	return append(mPrime, digest[:]...)
+}
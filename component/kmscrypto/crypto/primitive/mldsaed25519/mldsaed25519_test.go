@@ -0,0 +1,77 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mldsaed25519
+
+import (
+	"crypto"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVerify(t *testing.T) {
+	privKey, err := GenerateKey()
+	require.NoError(t, err)
+
+	msg := []byte("long-lived credential")
+
+	sig, err := privKey.Sign(rand.Reader, msg, nil)
+	require.NoError(t, err)
+	require.Len(t, sig, SignatureSize)
+
+	require.NoError(t, Verify(privKey.PublicKey(), msg, sig))
+
+	t.Run("invalid signatures", func(t *testing.T) {
+		require.ErrorIs(t, Verify(privKey.PublicKey(), []byte("other message"), sig), ErrInvalidSignature)
+		require.ErrorIs(t, Verify(privKey.PublicKey(), msg, sig[1:]), ErrInvalidSignature)
+
+		// both components must be valid.
+		for _, i := range []int{0, SignatureSize - 1} {
+			badSig := append([]byte{}, sig...)
+			badSig[i] ^= 0xff
+
+			require.ErrorIs(t, Verify(privKey.PublicKey(), msg, badSig), ErrInvalidSignature)
+		}
+
+		otherKey, err := GenerateKey()
+		require.NoError(t, err)
+
+		require.ErrorIs(t, Verify(otherKey.PublicKey(), msg, sig), ErrInvalidSignature)
+	})
+
+	t.Run("pre-hashed messages are not supported", func(t *testing.T) {
+		_, err := privKey.Sign(rand.Reader, msg, crypto.SHA512)
+		require.EqualError(t, err, "mldsaed25519: cannot sign pre-hashed messages")
+	})
+}
+
+func TestKeyEncoding(t *testing.T) {
+	privKey, err := GenerateKey()
+	require.NoError(t, err)
+
+	privKeyBytes := privKey.Bytes()
+	require.Len(t, privKeyBytes, PrivateKeySize)
+
+	decodedPrivKey, err := NewPrivateKey(privKeyBytes)
+	require.NoError(t, err)
+	require.True(t, privKey.Equal(decodedPrivKey))
+
+	pubKeyBytes := privKey.PublicKey().Bytes()
+	require.Len(t, pubKeyBytes, PublicKeySize)
+
+	decodedPubKey, err := NewPublicKey(pubKeyBytes)
+	require.NoError(t, err)
+	require.True(t, decodedPubKey.Equal(privKey.Public()))
+	require.True(t, decodedPubKey.Equal(decodedPrivKey.PublicKey()))
+
+	_, err = NewPrivateKey(privKeyBytes[1:])
+	require.EqualError(t, err, "mldsaed25519: invalid private key size")
+
+	_, err = NewPublicKey(pubKeyBytes[1:])
+	require.EqualError(t, err, "mldsaed25519: invalid public key size")
+}
@@ -0,0 +1,155 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package x25519mlkem768 implements hybrid X25519 and ML-KEM-768 (FIPS 203) key agreement keys. Shared secrets agreed
+// with these keys are safe as long as either X25519 or ML-KEM-768 is not broken, this protects messages against
+// harvest-now-decrypt-later attacks by quantum computers.
+//
+// Public keys are the concatenation of the X25519 public key and the ML-KEM-768 encapsulation key, private keys the
+// concatenation of the X25519 private key and the ML-KEM-768 decapsulation key seed. The X25519 part is used for
+// ECDH (ES or 1PU) with an ephemeral X25519 key, the ML-KEM-768 part to encapsulate an additional shared secret.
+package x25519mlkem768
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/cloudflare/circl/kem/mlkem/mlkem768"
+	"golang.org/x/crypto/curve25519"
+)
+
+const (
+	// PublicKeySize is the size of hybrid public keys.
+	PublicKeySize = curve25519.PointSize + mlkem768.PublicKeySize
+	// PrivateKeySize is the size of hybrid private keys.
+	PrivateKeySize = curve25519.ScalarSize + mlkem768.KeySeedSize
+	// CiphertextSize is the size of the ML-KEM-768 ciphertexts encapsulating a shared secret to a public key.
+	CiphertextSize = mlkem768.CiphertextSize
+)
+
+// PublicKey is a hybrid X25519 and ML-KEM-768 public key.
+type PublicKey struct {
+	x25519Key []byte
+	mlkemKey  *mlkem768.PublicKey
+}
+
+// PrivateKey is a hybrid X25519 and ML-KEM-768 private key.
+type PrivateKey struct {
+	x25519Key   []byte
+	mlkemSeed   []byte
+	mlkemKey    *mlkem768.PrivateKey
+	mlkemPubKey *mlkem768.PublicKey
+}
+
+// GenerateKey generates a new random hybrid private key.
+func GenerateKey() (*PrivateKey, error) {
+	seed := make([]byte, PrivateKeySize)
+
+	_, err := rand.Read(seed)
+	if err != nil {
+		return nil, fmt.Errorf("x25519mlkem768: failed to generate seed: %w", err)
+	}
+
+	return NewPrivateKey(seed)
+}
+
+// NewPrivateKey decodes a hybrid private key from the X25519 private key followed by the ML-KEM-768 seed.
+func NewPrivateKey(encoding []byte) (*PrivateKey, error) {
+	if len(encoding) != PrivateKeySize {
+		return nil, errors.New("x25519mlkem768: invalid private key size")
+	}
+
+	mlkemSeed := append([]byte{}, encoding[curve25519.ScalarSize:]...)
+	mlkemPubKey, mlkemKey := mlkem768.NewKeyFromSeed(mlkemSeed)
+
+	return &PrivateKey{
+		x25519Key:   append([]byte{}, encoding[:curve25519.ScalarSize]...),
+		mlkemSeed:   mlkemSeed,
+		mlkemKey:    mlkemKey,
+		mlkemPubKey: mlkemPubKey,
+	}, nil
+}
+
+// NewPublicKey decodes a hybrid public key from the X25519 public key followed by the ML-KEM-768 encapsulation key.
+func NewPublicKey(encoding []byte) (*PublicKey, error) {
+	if len(encoding) != PublicKeySize {
+		return nil, errors.New("x25519mlkem768: invalid public key size")
+	}
+
+	mlkemKey := new(mlkem768.PublicKey)
+
+	err := mlkemKey.Unpack(encoding[curve25519.PointSize:])
+	if err != nil {
+		return nil, fmt.Errorf("x25519mlkem768: %w", err)
+	}
+
+	return &PublicKey{
+		x25519Key: append([]byte{}, encoding[:curve25519.PointSize]...),
+		mlkemKey:  mlkemKey,
+	}, nil
+}
+
+// Bytes returns the private key encoding, the X25519 private key followed by the ML-KEM-768 seed.
+func (k *PrivateKey) Bytes() []byte {
+	return append(append([]byte{}, k.x25519Key...), k.mlkemSeed...)
+}
+
+// X25519 returns the X25519 private key of k.
+func (k *PrivateKey) X25519() []byte {
+	return k.x25519Key
+}
+
+// PublicKey returns the public key of k.
+func (k *PrivateKey) PublicKey() *PublicKey {
+	x25519Pub, err := curve25519.X25519(k.x25519Key, curve25519.Basepoint)
+	if err != nil { // X25519 with the base point never fails.
+		panic(fmt.Sprintf("x25519mlkem768: %v", err))
+	}
+
+	return &PublicKey{x25519Key: x25519Pub, mlkemKey: k.mlkemPubKey}
+}
+
+// Decapsulate returns the ML-KEM-768 shared secret encapsulated in ciphertext.
+func (k *PrivateKey) Decapsulate(ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) != CiphertextSize {
+		return nil, errors.New("x25519mlkem768: invalid ciphertext size")
+	}
+
+	sharedKey := make([]byte, mlkem768.SharedKeySize)
+	k.mlkemKey.DecapsulateTo(sharedKey, ciphertext)
+
+	return sharedKey, nil
+}
+
+// Bytes returns the public key encoding, the X25519 public key followed by the ML-KEM-768 encapsulation key.
+func (k *PublicKey) Bytes() []byte {
+	mlkemKey := make([]byte, mlkem768.PublicKeySize)
+	k.mlkemKey.Pack(mlkemKey)
+
+	return append(append([]byte{}, k.x25519Key...), mlkemKey...)
+}
+
+// X25519 returns the X25519 public key of k.
+func (k *PublicKey) X25519() []byte {
+	return k.x25519Key
+}
+
+// Equal reports whether k and x are the same key.
+func (k *PublicKey) Equal(x *PublicKey) bool {
+	return x != nil && bytes.Equal(k.Bytes(), x.Bytes())
+}
+
+// Encapsulate generates a new ML-KEM-768 shared secret and returns it with its ciphertext for k.
+func (k *PublicKey) Encapsulate() (sharedKey, ciphertext []byte) {
+	sharedKey = make([]byte, mlkem768.SharedKeySize)
+	ciphertext = make([]byte, CiphertextSize)
+
+	k.mlkemKey.EncapsulateTo(ciphertext, sharedKey, nil)
+
+	return sharedKey, ciphertext
+}
@@ -0,0 +1,73 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package x25519mlkem768
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/curve25519"
+)
+
+func TestEncapsulateDecapsulate(t *testing.T) {
+	privKey, err := GenerateKey()
+	require.NoError(t, err)
+
+	sharedKey, ct := privKey.PublicKey().Encapsulate()
+	require.Len(t, ct, CiphertextSize)
+
+	decapsulated, err := privKey.Decapsulate(ct)
+	require.NoError(t, err)
+	require.Equal(t, sharedKey, decapsulated)
+
+	_, err = privKey.Decapsulate(ct[1:])
+	require.EqualError(t, err, "x25519mlkem768: invalid ciphertext size")
+
+	otherKey, err := GenerateKey()
+	require.NoError(t, err)
+
+	decapsulated, err = otherKey.Decapsulate(ct)
+	require.NoError(t, err) // ML-KEM implicitly rejects with a pseudo-random shared secret.
+	require.NotEqual(t, sharedKey, decapsulated)
+}
+
+func TestX25519(t *testing.T) {
+	privKey, err := GenerateKey()
+	require.NoError(t, err)
+
+	x25519Pub, err := curve25519.X25519(privKey.X25519(), curve25519.Basepoint)
+	require.NoError(t, err)
+	require.Equal(t, x25519Pub, privKey.PublicKey().X25519())
+	require.Equal(t, x25519Pub, privKey.PublicKey().Bytes()[:curve25519.PointSize])
+}
+
+func TestKeyEncoding(t *testing.T) {
+	privKey, err := GenerateKey()
+	require.NoError(t, err)
+
+	privKeyBytes := privKey.Bytes()
+	require.Len(t, privKeyBytes, PrivateKeySize)
+
+	decodedPrivKey, err := NewPrivateKey(privKeyBytes)
+	require.NoError(t, err)
+	require.Equal(t, privKeyBytes, decodedPrivKey.Bytes())
+
+	pubKeyBytes := privKey.PublicKey().Bytes()
+	require.Len(t, pubKeyBytes, PublicKeySize)
+
+	decodedPubKey, err := NewPublicKey(pubKeyBytes)
+	require.NoError(t, err)
+	require.True(t, decodedPubKey.Equal(privKey.PublicKey()))
+	require.True(t, decodedPubKey.Equal(decodedPrivKey.PublicKey()))
+	require.False(t, decodedPubKey.Equal(nil))
+
+	_, err = NewPrivateKey(privKeyBytes[1:])
+	require.EqualError(t, err, "x25519mlkem768: invalid private key size")
+
+	_, err = NewPublicKey(pubKeyBytes[1:])
+	require.EqualError(t, err, "x25519mlkem768: invalid public key size")
+}
@@ -0,0 +1,231 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package thresholdcrypto provides a Crypto signing with the keys managed by kms/thresholdkms. It coordinates the
+// two FROST rounds with the participants holding the key shares and aggregates their signature shares in a standard
+// Ed25519 signature. Verification is done locally. Other operations are not supported.
+package thresholdcrypto
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/spi/crypto"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/frost"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/thresholdkms"
+)
+
+const sessionIDSize = 16
+
+var (
+	errBadKeyHandleFormat = errors.New("bad key handle format")
+	errNotSupported       = errors.New("not supported by thresholdCrypto")
+)
+
+// Crypto is the threshold implementation of crypto.Crypto, for the key handles returned by thresholdkms.
+type Crypto struct {
+	transport thresholdkms.Transport
+}
+
+var _ cryptoapi.Crypto = (*Crypto)(nil)
+
+// New creates a new threshold Crypto service reaching the participants holding the key shares with transport.
+func New(transport thresholdkms.Transport) *Crypto {
+	return &Crypto{transport: transport}
+}
+
+// Sign will sign msg with the key of kh, a *thresholdkms.KeyHandle returned by thresholdkms. The first participants
+// of the key answering the commitment round are asked for their signature shares, so that signing succeeds as long as
+// a threshold of them is reachable. The result is an Ed25519 signature of msg by the key.
+func (c *Crypto) Sign(msg []byte, kh interface{}) ([]byte, error) {
+	keyHandle, ok := kh.(*thresholdkms.KeyHandle)
+	if !ok || keyHandle.KeyID == "" {
+		return nil, fmt.Errorf("sign: %w", errBadKeyHandleFormat)
+	}
+
+	sessionID, err := newSessionID()
+	if err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+
+	commitments, err := c.commit(keyHandle, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+
+	sigShares := map[uint16][]byte{}
+
+	for _, commitment := range commitments {
+		resp, e := c.transport.Send(commitment.ID, &thresholdkms.Request{
+			Type:        thresholdkms.SignRequest,
+			KeyID:       keyHandle.KeyID,
+			SessionID:   sessionID,
+			Message:     msg,
+			Commitments: commitments,
+		})
+		if e != nil {
+			c.abort(keyHandle.KeyID, sessionID, commitments)
+
+			return nil, fmt.Errorf("sign: participant %d failed to sign: %w", commitment.ID, e)
+		}
+
+		sigShares[commitment.ID] = resp.SignatureShare
+	}
+
+	sig, err := frost.Aggregate(keyHandle.PublicKey, msg, commitments, keyHandle.VerifyingShares, sigShares)
+	if err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+
+	return sig, nil
+}
+
+// commit runs the commitment round with the participants of kh until a threshold of them committed.
+func (c *Crypto) commit(kh *thresholdkms.KeyHandle, sessionID string) ([]*frost.Commitment, error) {
+	var (
+		commitments []*frost.Commitment
+		lastErr     error
+	)
+
+	for _, id := range kh.Participants() {
+		resp, err := c.transport.Send(id, &thresholdkms.Request{
+			Type:      thresholdkms.CommitRequest,
+			KeyID:     kh.KeyID,
+			SessionID: sessionID,
+		})
+		if err != nil {
+			lastErr = err
+
+			continue
+		}
+
+		if resp.Commitment == nil || resp.Commitment.ID != id {
+			lastErr = fmt.Errorf("invalid commitment of participant %d", id)
+
+			continue
+		}
+
+		commitments = append(commitments, resp.Commitment)
+
+		if len(commitments) == kh.Threshold {
+			return commitments, nil
+		}
+	}
+
+	c.abort(kh.KeyID, sessionID, commitments)
+
+	return nil, fmt.Errorf("%d of the %d required participants committed, last error: %w", len(commitments),
+		kh.Threshold, lastErr)
+}
+
+// abort asks the participants which committed to discard the nonces of the session. It is best effort: the
+// participants which can't be reached discard them when the session times out.
+func (c *Crypto) abort(keyID, sessionID string, commitments []*frost.Commitment) {
+	for _, commitment := range commitments {
+		_, _ = c.transport.Send(commitment.ID, &thresholdkms.Request{ // nolint:errcheck
+			Type:      thresholdkms.AbortRequest,
+			KeyID:     keyID,
+			SessionID: sessionID,
+		})
+	}
+}
+
+func newSessionID() (string, error) {
+	b := make([]byte, sessionIDSize)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate session ID: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Verify will verify the Ed25519 signature of msg using the public key of kh, a *thresholdkms.KeyHandle returned by
+// thresholdkms. It returns nil if signature verification was successful.
+func (c *Crypto) Verify(signature, msg []byte, kh interface{}) error {
+	keyHandle, ok := kh.(*thresholdkms.KeyHandle)
+	if !ok || len(keyHandle.PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("verify: %w", errBadKeyHandleFormat)
+	}
+
+	if !ed25519.Verify(keyHandle.PublicKey, msg, signature) {
+		return errors.New("verify: invalid signature")
+	}
+
+	return nil
+}
+
+// Encrypt is not supported by thresholdCrypto.
+func (c *Crypto) Encrypt([]byte, []byte, interface{}) ([]byte, []byte, error) {
+	return nil, nil, fmt.Errorf("encrypt: %w", errNotSupported)
+}
+
+// Decrypt is not supported by thresholdCrypto.
+func (c *Crypto) Decrypt([]byte, []byte, []byte, interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("decrypt: %w", errNotSupported)
+}
+
+// ComputeMAC is not supported by thresholdCrypto.
+func (c *Crypto) ComputeMAC([]byte, interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("computeMAC: %w", errNotSupported)
+}
+
+// VerifyMAC is not supported by thresholdCrypto.
+func (c *Crypto) VerifyMAC([]byte, []byte, interface{}) error {
+	return fmt.Errorf("verifyMAC: %w", errNotSupported)
+}
+
+// WrapKey is not supported by thresholdCrypto.
+func (c *Crypto) WrapKey([]byte, []byte, []byte, *cryptoapi.PublicKey,
+	...cryptoapi.WrapKeyOpts) (*cryptoapi.RecipientWrappedKey, error) {
+	return nil, fmt.Errorf("wrapKey: %w", errNotSupported)
+}
+
+// UnwrapKey is not supported by thresholdCrypto.
+func (c *Crypto) UnwrapKey(*cryptoapi.RecipientWrappedKey, interface{}, ...cryptoapi.WrapKeyOpts) ([]byte, error) {
+	return nil, fmt.Errorf("unwrapKey: %w", errNotSupported)
+}
+
+// SignMulti is not supported by thresholdCrypto.
+func (c *Crypto) SignMulti([][]byte, interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("signMulti: %w", errNotSupported)
+}
+
+// VerifyMulti is not supported by thresholdCrypto.
+func (c *Crypto) VerifyMulti([][]byte, []byte, interface{}) error {
+	return fmt.Errorf("verifyMulti: %w", errNotSupported)
+}
+
+// VerifyProof is not supported by thresholdCrypto.
+func (c *Crypto) VerifyProof([][]byte, []byte, []byte, interface{}) error {
+	return fmt.Errorf("verifyProof: %w", errNotSupported)
+}
+
+// DeriveProof is not supported by thresholdCrypto.
+func (c *Crypto) DeriveProof([][]byte, []byte, []byte, []int, interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("deriveProof: %w", errNotSupported)
+}
+
+// Blind is not supported by thresholdCrypto.
+func (c *Crypto) Blind(interface{}, ...map[string]interface{}) ([][]byte, error) {
+	return nil, fmt.Errorf("blind: %w", errNotSupported)
+}
+
+// GetCorrectnessProof is not supported by thresholdCrypto.
+func (c *Crypto) GetCorrectnessProof(interface{}) ([]byte, error) {
+	return nil, fmt.Errorf("getCorrectnessProof: %w", errNotSupported)
+}
+
+// SignWithSecrets is not supported by thresholdCrypto.
+func (c *Crypto) SignWithSecrets(interface{}, map[string]interface{}, []byte, []byte, [][]byte,
+	string) ([]byte, []byte, error) {
+	return nil, nil, fmt.Errorf("signWithSecrets: %w", errNotSupported)
+}
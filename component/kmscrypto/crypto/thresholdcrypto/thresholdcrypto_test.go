@@ -0,0 +1,127 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package thresholdcrypto
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mockstorage "github.com/hyperledger/aries-framework-go/component/storageutil/mock/storage"
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/thresholdkms"
+	mockkms "github.com/hyperledger/aries-framework-go/component/kmscrypto/mock/kms"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/secretlock/noop"
+)
+
+// unreachableTransport fails the requests sent to the participants in down, and records the aborted sessions.
+type unreachableTransport struct {
+	thresholdkms.Transport
+	down    map[uint16]bool
+	aborted []uint16
+}
+
+func (u *unreachableTransport) Send(id uint16, req *thresholdkms.Request) (*thresholdkms.Response, error) {
+	if u.down[id] {
+		return nil, errors.New("participant unreachable")
+	}
+
+	if req.Type == thresholdkms.AbortRequest {
+		u.aborted = append(u.aborted, id)
+	}
+
+	return u.Transport.Send(id, req)
+}
+
+func newProvider(t *testing.T) kmsapi.Provider {
+	t.Helper()
+
+	p, err := mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{})
+	require.NoError(t, err)
+
+	return p
+}
+
+func TestCrypto_SignVerify(t *testing.T) {
+	ids := []uint16{1, 2, 3, 4, 5}
+	participants := make([]*thresholdkms.Participant, len(ids))
+
+	for i, id := range ids {
+		participants[i] = thresholdkms.NewParticipant(id, "local-lock://test/key/uri", newProvider(t))
+	}
+
+	transport := &unreachableTransport{
+		Transport: thresholdkms.NewInProcessTransport(participants...),
+		down:      map[uint16]bool{},
+	}
+
+	k, err := thresholdkms.New(newProvider(t), transport, 3, ids)
+	require.NoError(t, err)
+
+	keyID, kh, err := k.Create(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	pubKey, _, err := k.ExportPubKeyBytes(keyID)
+	require.NoError(t, err)
+
+	c := New(transport)
+	msg := []byte("test message")
+
+	t.Run("sign with all participants reachable", func(t *testing.T) {
+		sig, e := c.Sign(msg, kh)
+		require.NoError(t, e)
+		require.True(t, ed25519.Verify(pubKey, msg, sig))
+
+		require.NoError(t, c.Verify(sig, msg, kh))
+	})
+
+	t.Run("sign with a threshold of participants reachable", func(t *testing.T) {
+		transport.down = map[uint16]bool{1: true, 3: true}
+		defer func() { transport.down = map[uint16]bool{} }()
+
+		sig, e := c.Sign(msg, kh)
+		require.NoError(t, e)
+
+		vh, e := k.PubKeyBytesToHandle(pubKey, kmsapi.ED25519Type)
+		require.NoError(t, e)
+		require.NoError(t, c.Verify(sig, msg, vh))
+		require.EqualError(t, c.Verify(sig, []byte("other"), vh), "verify: invalid signature")
+	})
+
+	t.Run("sign without a threshold of participants reachable", func(t *testing.T) {
+		transport.down = map[uint16]bool{1: true, 3: true, 5: true}
+		defer func() { transport.down = map[uint16]bool{} }()
+
+		_, e := c.Sign(msg, kh)
+		require.EqualError(t, e, "sign: 2 of the 3 required participants committed, last error: "+
+			"participant unreachable")
+
+		// the participants which committed discard the nonces of the session.
+		require.Equal(t, []uint16{2, 4}, transport.aborted)
+	})
+
+	t.Run("bad key handles", func(t *testing.T) {
+		_, e := c.Sign(msg, &thresholdkms.KeyHandle{PublicKey: pubKey})
+		require.EqualError(t, e, "sign: bad key handle format")
+
+		_, e = c.Sign(msg, "bad")
+		require.EqualError(t, e, "sign: bad key handle format")
+
+		require.EqualError(t, c.Verify(nil, msg, "bad"), "verify: bad key handle format")
+	})
+
+	t.Run("unsupported operations", func(t *testing.T) {
+		_, _, e := c.Encrypt(msg, nil, kh)
+		require.ErrorIs(t, e, errNotSupported)
+
+		_, e = c.ComputeMAC(msg, kh)
+		require.ErrorIs(t, e, errNotSupported)
+	})
+}
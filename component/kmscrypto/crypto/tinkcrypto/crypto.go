@@ -41,9 +41,31 @@ const (
 	ECDHESXC20PKWAlg = "ECDH-ES+XC20PKW"
 	// ECDH1PUXC20PKWAlg is the ECDH-1PU with XChacha20Poly1305 key wrapping algorithm.
 	ECDH1PUXC20PKWAlg = "ECDH-1PU+XC20PKW"
+	// ECDHESX25519MLKEM768A256KWAlg is the ECDH-ES with hybrid X25519 and ML-KEM-768 key agreement and AES-GCM 256 key
+	// wrapping algorithm.
+	ECDHESX25519MLKEM768A256KWAlg = "ECDH-ES+X25519MLKEM768+A256KW"
+	// ECDHESX25519MLKEM768XC20PKWAlg is the ECDH-ES with hybrid X25519 and ML-KEM-768 key agreement and
+	// XChacha20Poly1305 key wrapping algorithm.
+	ECDHESX25519MLKEM768XC20PKWAlg = "ECDH-ES+X25519MLKEM768+XC20PKW"
+	// ECDH1PUX25519MLKEM768A256KWAlg is the ECDH-1PU with hybrid X25519 and ML-KEM-768 key agreement and AES-GCM 256
+	// key wrapping algorithm.
+	ECDH1PUX25519MLKEM768A256KWAlg = "ECDH-1PU+X25519MLKEM768+A256KW"
+	// ECDH1PUX25519MLKEM768XC20PKWAlg is the ECDH-1PU with hybrid X25519 and ML-KEM-768 key agreement and
+	// XChacha20Poly1305 key wrapping algorithm.
+	ECDH1PUX25519MLKEM768XC20PKWAlg = "ECDH-1PU+X25519MLKEM768+XC20PKW"
+	// ECDHESAlg is the ECDH-ES key agreement algorithm in direct mode (the derived key is the CEK).
+	ECDHESAlg = "ECDH-ES"
+	// RSAOAEPAlg is the RSA-OAEP (SHA-1) key encryption algorithm. It is supported for unwrapping only.
+	RSAOAEPAlg = "RSA-OAEP"
+	// RSAOAEP256Alg is the RSA-OAEP (SHA-256) key encryption algorithm.
+	RSAOAEP256Alg = "RSA-OAEP-256"
+	// A256KWAlg is the AES-256 Key Wrap algorithm.
+	A256KWAlg = "A256KW"
 
 	nistPECDHKWPrivateKeyTypeURL  = "type.hyperledger.org/hyperledger.aries.crypto.tink.NistPEcdhKwPrivateKey"
 	x25519ECDHKWPrivateKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.X25519EcdhKwPrivateKey"
+	// nolint:gosec,lll // not a credential.
+	x25519MLKEM768ECDHKWPrivateKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.X25519MLKEM768EcdhKwPrivateKey"
 )
 
 var errBadKeyHandleFormat = errors.New("bad key handle format")
@@ -238,6 +260,10 @@ func (t *Crypto) VerifyMAC(macBytes, data []byte, kh interface{}) error {
 //     `Concat KDF` as per https://tools.ietf.org/html/rfc7518#section-4.6 (for recPubKey with NIST P curves) or
 //     `Curve25519`+`Concat KDF` as per https://tools.ietf.org/html/rfc7748#section-6.1
 //     (for recPubKey with X25519 curve).
+//   - Key Encryption: `RSA-OAEP-256` alg if recPubKey is an RSA key (recPubKey.Type 'RSA', with X and Y set as the
+//     modulus and public exponent).
+//   - Key Wrapping: `A256KW` alg (AES Key Wrap) using crypto.WithKEK() option in wrapKeyOpts with an AES256-GCM key
+//     handle. recPubKey is only used for its KID in this case.
 //
 // returns the resulting key wrapping info as *composite.RecipientWrappedKey or error in case of wrapping failure.
 func (t *Crypto) WrapKey(cek, apu, apv []byte, recPubKey *crypto.PublicKey,
@@ -252,8 +278,21 @@ func (t *Crypto) WrapKey(cek, apu, apv []byte, recPubKey *crypto.PublicKey,
 		opt(pOpts)
 	}
 
-	wk, err := t.deriveKEKAndWrap(cek, apu, apv, pOpts.Tag(), pOpts.SenderKey(), recPubKey, pOpts.EPK(),
-		pOpts.UseXC20PKW())
+	var (
+		wk  *crypto.RecipientWrappedKey
+		err error
+	)
+
+	switch {
+	case pOpts.KEK() != nil:
+		wk, err = wrapWithKEK(cek, recPubKey.KID, pOpts.KEK())
+	case recPubKey.Type == rsaKeyType:
+		wk, err = wrapRSAOAEP(cek, recPubKey)
+	default:
+		wk, err = t.deriveKEKAndWrap(cek, apu, apv, pOpts.Tag(), pOpts.SenderKey(), recPubKey, pOpts.EPK(),
+			pOpts.UseXC20PKW())
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("wrapKey: %w", err)
 	}
@@ -277,6 +316,10 @@ func (t *Crypto) WrapKey(cek, apu, apv []byte, recPubKey *crypto.PublicKey,
 //   - KDF (based on recWk.EPK.KeyType): `Concat KDF` as per https://tools.ietf.org/html/rfc7518#section-4.6 (for type
 //     value as EC) or `Curve25519`+`Concat KDF` as per https://tools.ietf.org/html/rfc7748#section-6.1 (for type value
 //     as OKP, ie X25519 key).
+//   - Key Decryption: `RSA-OAEP` or `RSA-OAEP-256` algs with an RSA-OAEP recipientKH.
+//   - Key Unwrapping: `A256KW` alg (AES Key Wrap) with an AES256-GCM recipientKH.
+//   - Key Agreement: `ECDH-ES` alg in direct mode using crypto.WithDirectKeyAgreement() option in wrapKeyOpts. The
+//     returned key is the derived CEK (recWK.EncryptedCEK is not used).
 //
 // returns the resulting unwrapping key or error in case of unwrapping failure.
 //
@@ -307,8 +350,23 @@ func (t *Crypto) UnwrapKey(recWK *crypto.RecipientWrappedKey, recipientKH interf
 		opt(pOpts)
 	}
 
-	key, err := t.deriveKEKAndUnwrap(recWK.Alg, recWK.EncryptedCEK, recWK.APU, recWK.APV, pOpts.Tag(), &recWK.EPK,
-		pOpts.SenderKey(), recipientKH)
+	var (
+		key []byte
+		err error
+	)
+
+	switch recWK.Alg {
+	case RSAOAEPAlg, RSAOAEP256Alg:
+		key, err = unwrapRSAOAEP(recWK.Alg, recWK.EncryptedCEK, recipientKH)
+	case A256KWAlg:
+		key, err = unwrapWithKEK(recWK.EncryptedCEK, recipientKH)
+	case ECDHESAlg:
+		key, err = t.deriveDirectKey(recWK, pOpts.EncAlg(), pOpts.CEKSize(), recipientKH)
+	default:
+		key, err = t.deriveKEKAndUnwrap(recWK.Alg, recWK.EncryptedCEK, recWK.APU, recWK.APV, pOpts.Tag(), &recWK.EPK,
+			pOpts.SenderKey(), recipientKH)
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("unwrapKey: %w", err)
 	}
@@ -33,6 +33,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/composite/ecdh"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/composite/keyio"
 	ecdhpb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/mldsa"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/secp256k1"
 )
 
@@ -273,6 +274,35 @@ func TestCrypto_SignVerify(t *testing.T) {
 		err = c.Verify(s, msg, badKH)
 		require.Error(t, err)
 	})
+
+	t.Run("test with ML-DSA signatures", func(t *testing.T) {
+		mldsa65Template, err := mldsa.MLDSA65KeyTemplate()
+		require.NoError(t, err)
+
+		compositeTemplate, err := mldsa.MLDSA65Ed25519KeyTemplate()
+		require.NoError(t, err)
+
+		for _, template := range []*tinkpb.KeyTemplate{mldsa65Template, compositeTemplate} {
+			kh, err := keyset.NewHandle(template)
+			require.NoError(t, err)
+
+			c := Crypto{}
+			msg := []byte(testMessage)
+			s, err := c.Sign(msg, kh)
+			require.NoError(t, err)
+
+			// get corresponding public key handle to verify
+			pubKH, err := kh.Public()
+			require.NoError(t, err)
+
+			err = c.Verify(s, msg, pubKH)
+			require.NoError(t, err)
+
+			// verify a different message - should fail
+			err = c.Verify(s, []byte("other message"), pubKH)
+			require.Error(t, err)
+		}
+	})
 }
 
 func TestCrypto_ComputeMAC(t *testing.T) {
@@ -561,6 +591,38 @@ func TestCrypto_ECDHES_Wrap_Unwrap_ForAllKeyTypes(t *testing.T) {
 			senderKT: ecdh.X25519ECDHKWKeyTemplate(),
 			useXC20P: true,
 		},
+		{
+			tcName:   "key wrap using ECDH-ES with X25519MLKEM768 key and A256GCM kw",
+			keyTempl: ecdh.X25519MLKEM768ECDHKWKeyTemplate(),
+			kwAlg:    ECDHESX25519MLKEM768A256KWAlg,
+			keyType:  ecdhpb.KeyType_OKP.String(),
+			keyCurve: "X25519",
+		},
+		{
+			tcName:   "key wrap using ECDH-ES with X25519MLKEM768 key and XC20P kw",
+			keyTempl: ecdh.X25519MLKEM768ECDHKWKeyTemplate(),
+			kwAlg:    ECDHESX25519MLKEM768XC20PKWAlg,
+			keyType:  ecdhpb.KeyType_OKP.String(),
+			keyCurve: "X25519",
+			useXC20P: true,
+		},
+		{
+			tcName:   "key wrap using ECDH-1PU with X25519MLKEM768 key and A256GCM kw",
+			keyTempl: ecdh.X25519MLKEM768ECDHKWKeyTemplate(),
+			kwAlg:    ECDH1PUX25519MLKEM768A256KWAlg,
+			keyType:  ecdhpb.KeyType_OKP.String(),
+			keyCurve: "X25519",
+			senderKT: ecdh.X25519MLKEM768ECDHKWKeyTemplate(),
+		},
+		{
+			tcName:   "key wrap using ECDH-1PU with X25519MLKEM768 key and XC20P kw and X25519 sender key",
+			keyTempl: ecdh.X25519MLKEM768ECDHKWKeyTemplate(),
+			kwAlg:    ECDH1PUX25519MLKEM768XC20PKWAlg,
+			keyType:  ecdhpb.KeyType_OKP.String(),
+			keyCurve: "X25519",
+			senderKT: ecdh.X25519ECDHKWKeyTemplate(),
+			useXC20P: true,
+		},
 	}
 
 	c, err := New()
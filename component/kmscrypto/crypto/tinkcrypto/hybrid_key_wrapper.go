@@ -0,0 +1,157 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tinkcrypto
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/spi/crypto"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/x25519mlkem768"
+)
+
+// x25519MLKEM768Curve is the curve name of hybrid X25519 and ML-KEM-768 public keys.
+const x25519MLKEM768Curve = "X25519MLKEM768"
+
+// deriveHybridKEKAndWrap wraps cek for a hybrid X25519 and ML-KEM-768 recipient key. The kek is derived from the
+// X25519 ECDH shared secret(s) (ES or 1PU with an ephemeral X25519 key) followed by a secret encapsulated with
+// ML-KEM-768 to the recipient. The ML-KEM-768 ciphertext is prepended to the wrapped key in the returned
+// EncryptedCEK.
+func (t *Crypto) deriveHybridKEKAndWrap(cek, apu, apv, tag []byte, senderKH interface{},
+	recPubKey *cryptoapi.PublicKey, epkPrv *cryptoapi.PrivateKey, useXC20PKW bool) (*cryptoapi.RecipientWrappedKey,
+	error) {
+	recKey, err := x25519mlkem768.NewPublicKey(recPubKey.X)
+	if err != nil {
+		return nil, fmt.Errorf("deriveHybridKEKAndWrap: invalid recipient key: %w", err)
+	}
+
+	ephemeralPubKey, ephemeralPrivKey, err := t.generateOrGetEphemeralOKPKey(epkPrv)
+	if err != nil {
+		return nil, fmt.Errorf("deriveHybridKEKAndWrap: failed to generate ephemeral key: %w", err)
+	}
+
+	if len(apu) == 0 {
+		apu = make([]byte, base64.RawURLEncoding.EncodedLen(len(ephemeralPubKey)))
+		base64.RawURLEncoding.Encode(apu, ephemeralPubKey)
+	}
+
+	z, err := curve25519.X25519(ephemeralPrivKey, recKey.X25519())
+	if err != nil {
+		return nil, fmt.Errorf("deriveHybridKEKAndWrap: %w", err)
+	}
+
+	wrappingAlg := ECDHESX25519MLKEM768A256KWAlg
+
+	if senderKH != nil { // ecdh1pu
+		wrappingAlg = ECDH1PUX25519MLKEM768A256KWAlg
+
+		senderPrivKey, e := ksToPrivateX25519Key(senderKH)
+		if e != nil {
+			return nil, fmt.Errorf("deriveHybridKEKAndWrap: failed to retrieve sender key: %w", e)
+		}
+
+		zs, e := curve25519.X25519(senderPrivKey, recKey.X25519())
+		if e != nil {
+			return nil, fmt.Errorf("deriveHybridKEKAndWrap: %w", e)
+		}
+
+		z = append(z, zs...)
+	}
+
+	if useXC20PKW {
+		wrappingAlg = hybridXC20PKWAlg(wrappingAlg)
+	}
+
+	kemSecret, kemCT := recKey.Encapsulate()
+	z = append(z, kemSecret...)
+
+	kek := kdfWithTag(wrappingAlg, z, apu, apv, tag, chacha20poly1305.KeySize, senderKH != nil)
+
+	epk := &cryptoapi.PublicKey{
+		X:     ephemeralPubKey,
+		Curve: "X25519",
+		Type:  recPubKey.Type,
+	}
+
+	wk, err := t.wrapRaw(kek, cek, apu, apv, wrappingAlg, recPubKey.KID, epk, useXC20PKW)
+	if err != nil {
+		return nil, err
+	}
+
+	wk.EncryptedCEK = append(kemCT, wk.EncryptedCEK...)
+
+	return wk, nil
+}
+
+// deriveHybridKEKAndUnwrap is the counterpart of deriveHybridKEKAndWrap for recipients with a hybrid X25519 and
+// ML-KEM-768 private key.
+func (t *Crypto) deriveHybridKEKAndUnwrap(alg string, encCEK, apu, apv, tag []byte, epk *cryptoapi.PublicKey,
+	senderKH interface{}, recipientPrivateKey interface{}) ([]byte, error) {
+	recPrivKey, ok := recipientPrivateKey.(*x25519mlkem768.PrivateKey)
+	if !ok {
+		return nil, errors.New("deriveHybridKEKAndUnwrap: recipient key is not an X25519MLKEM768 key")
+	}
+
+	if len(encCEK) <= x25519mlkem768.CiphertextSize {
+		return nil, errors.New("deriveHybridKEKAndUnwrap: encrypted key too short")
+	}
+
+	z, err := curve25519.X25519(recPrivKey.X25519(), epk.X)
+	if err != nil {
+		return nil, fmt.Errorf("deriveHybridKEKAndUnwrap: %w", err)
+	}
+
+	is1PU := alg == ECDH1PUX25519MLKEM768A256KWAlg || alg == ECDH1PUX25519MLKEM768XC20PKWAlg
+
+	if is1PU {
+		if senderKH == nil {
+			return nil, fmt.Errorf("deriveHybridKEKAndUnwrap: sender's public keyset handle option is required "+
+				"for '%s'", alg)
+		}
+
+		senderPubKey, e := ksToPublicX25519Key(senderKH)
+		if e != nil {
+			return nil, fmt.Errorf("deriveHybridKEKAndUnwrap: failed to retrieve sender key: %w", e)
+		}
+
+		if len(senderPubKey) < curve25519.PointSize {
+			return nil, errors.New("deriveHybridKEKAndUnwrap: invalid sender key")
+		}
+
+		// hybrid sender keys start with their X25519 public key.
+		zs, e := curve25519.X25519(recPrivKey.X25519(), senderPubKey[:curve25519.PointSize])
+		if e != nil {
+			return nil, fmt.Errorf("deriveHybridKEKAndUnwrap: %w", e)
+		}
+
+		z = append(z, zs...)
+	}
+
+	kemSecret, err := recPrivKey.Decapsulate(encCEK[:x25519mlkem768.CiphertextSize])
+	if err != nil {
+		return nil, fmt.Errorf("deriveHybridKEKAndUnwrap: %w", err)
+	}
+
+	z = append(z, kemSecret...)
+
+	kek := kdfWithTag(alg, z, apu, apv, tag, chacha20poly1305.KeySize, is1PU)
+
+	return t.unwrapRaw(alg, kek, encCEK[x25519mlkem768.CiphertextSize:])
+}
+
+func hybridXC20PKWAlg(alg string) string {
+	if alg == ECDH1PUX25519MLKEM768A256KWAlg {
+		return ECDH1PUX25519MLKEM768XC20PKWAlg
+	}
+
+	return ECDHESX25519MLKEM768XC20PKWAlg
+}
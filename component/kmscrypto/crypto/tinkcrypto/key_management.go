@@ -0,0 +1,231 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tinkcrypto
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" // nolint:gosec // RSA-OAEP (RFC 7518 section 4.3) uses SHA-1.
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+
+	josecipher "github.com/go-jose/go-jose/v3/cipher"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/keyset"
+	gcmpb "github.com/google/tink/go/proto/aes_gcm_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"golang.org/x/crypto/chacha20poly1305"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/spi/crypto"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/composite"
+	ecdhpb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	_ "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/rsaoaep" // RSA-OAEP keys.
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/util/cryptoutil"
+)
+
+// This file contains the JWE key management algorithms not using a key agreement to wrap the CEK: RSA-OAEP key
+// encryption, AES Key Wrap with a symmetric key encryption key, and the ECDH-ES key agreement in direct mode (where
+// the derived key is the CEK).
+
+const rsaKeyType = "RSA"
+
+// wrapRSAOAEP encrypts cek with RSA-OAEP-256 for the RSA recipient public key recPubKey, whose X and Y are the
+// modulus and public exponent.
+func wrapRSAOAEP(cek []byte, recPubKey *cryptoapi.PublicKey) (*cryptoapi.RecipientWrappedKey, error) {
+	e := new(big.Int).SetBytes(recPubKey.Y)
+	if !e.IsInt64() {
+		return nil, errors.New("wrapRSAOAEP: invalid RSA public exponent")
+	}
+
+	pubKey := &rsa.PublicKey{N: new(big.Int).SetBytes(recPubKey.X), E: int(e.Int64())}
+
+	wk, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pubKey, cek, nil)
+	if err != nil {
+		return nil, fmt.Errorf("wrapRSAOAEP: %w", err)
+	}
+
+	return &cryptoapi.RecipientWrappedKey{
+		KID:          recPubKey.KID,
+		EncryptedCEK: wk,
+		Alg:          RSAOAEP256Alg,
+	}, nil
+}
+
+// unwrapRSAOAEP decrypts encCEK with the RSA-OAEP private key in recKH.
+func unwrapRSAOAEP(alg string, encCEK []byte, recKH interface{}) ([]byte, error) {
+	keyHandle, ok := recKH.(*keyset.Handle)
+	if !ok {
+		return nil, fmt.Errorf("unwrapRSAOAEP: %w", errBadKeyHandleFormat)
+	}
+
+	ps, err := keyHandle.Primitives()
+	if err != nil {
+		return nil, fmt.Errorf("unwrapRSAOAEP: get primitives: %w", err)
+	}
+
+	privKey, ok := ps.Primary.Primitive.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("unwrapRSAOAEP: recipient key is not an RSA-OAEP private key")
+	}
+
+	var h hash.Hash
+
+	switch alg {
+	case RSAOAEPAlg:
+		h = sha1.New() // nolint:gosec // RSA-OAEP (RFC 7518 section 4.3) uses SHA-1.
+	default:
+		h = sha256.New()
+	}
+
+	cek, err := rsa.DecryptOAEP(h, rand.Reader, privKey, encCEK, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unwrapRSAOAEP: %w", err)
+	}
+
+	return cek, nil
+}
+
+// wrapWithKEK wraps cek with AES Key Wrap (RFC 3394) using the AES-256 key in kek.
+func wrapWithKEK(cek []byte, kid string, kek interface{}) (*cryptoapi.RecipientWrappedKey, error) {
+	key, err := extractAES256Key(kek)
+	if err != nil {
+		return nil, fmt.Errorf("wrapWithKEK: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("wrapWithKEK: %w", err)
+	}
+
+	wk, err := josecipher.KeyWrap(block, cek)
+	if err != nil {
+		return nil, fmt.Errorf("wrapWithKEK: %w", err)
+	}
+
+	return &cryptoapi.RecipientWrappedKey{
+		KID:          kid,
+		EncryptedCEK: wk,
+		Alg:          A256KWAlg,
+	}, nil
+}
+
+// unwrapWithKEK unwraps encCEK with AES Key Wrap (RFC 3394) using the AES-256 key in kek.
+func unwrapWithKEK(encCEK []byte, kek interface{}) ([]byte, error) {
+	key, err := extractAES256Key(kek)
+	if err != nil {
+		return nil, fmt.Errorf("unwrapWithKEK: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("unwrapWithKEK: %w", err)
+	}
+
+	cek, err := josecipher.KeyUnwrap(block, encCEK)
+	if err != nil {
+		return nil, fmt.Errorf("unwrapWithKEK: %w", err)
+	}
+
+	return cek, nil
+}
+
+// extractAES256Key returns the raw key of the primary AES256-GCM key in kh (eg: a kms AES256GCMNoPrefixType key).
+func extractAES256Key(kh interface{}) ([]byte, error) {
+	keyHandle, ok := kh.(*keyset.Handle)
+	if !ok {
+		return nil, errBadKeyHandleFormat
+	}
+
+	buf := new(bytes.Buffer)
+
+	err := keyHandle.Write(&privKeyWriter{w: buf}, &noopAEAD{})
+	if err != nil {
+		return nil, fmt.Errorf("retrieving key encryption key failed: %w", err)
+	}
+
+	ks := new(tinkpb.Keyset)
+
+	err = proto.Unmarshal(buf.Bytes(), ks)
+	if err != nil {
+		return nil, errors.New("invalid key encryption key")
+	}
+
+	for _, key := range ks.Key {
+		if key.KeyId != ks.PrimaryKeyId {
+			continue
+		}
+
+		if key.KeyData.TypeUrl != composite.AESGCMTypeURL {
+			return nil, fmt.Errorf("unsupported key encryption key '%s'", key.KeyData.TypeUrl)
+		}
+
+		gcmKey := new(gcmpb.AesGcmKey)
+
+		err = proto.Unmarshal(key.KeyData.Value, gcmKey)
+		if err != nil || len(gcmKey.KeyValue) != chacha20poly1305.KeySize {
+			return nil, errors.New("key encryption key is not an AES-256 key")
+		}
+
+		return gcmKey.KeyValue, nil
+	}
+
+	return nil, errors.New("key encryption key not found")
+}
+
+// deriveDirectKey returns the CEK of encAlg and size cekSize derived by the ECDH-ES key agreement in direct mode, as
+// per https://tools.ietf.org/html/rfc7518#section-4.6, between the recipient private key in recKH and recWK.EPK.
+func (t *Crypto) deriveDirectKey(recWK *cryptoapi.RecipientWrappedKey, encAlg string, cekSize int,
+	recKH interface{}) ([]byte, error) {
+	if encAlg == "" || cekSize <= 0 {
+		return nil, errors.New("deriveDirectKey: direct key agreement option is required")
+	}
+
+	switch recWK.EPK.Type {
+	case ecdhpb.KeyType_EC.String():
+		recPrivKey, err := ksToPrivateECDSAKey(recKH)
+		if err != nil {
+			return nil, fmt.Errorf("deriveDirectKey: %w", err)
+		}
+
+		epkPubKey, err := ksToPublicECDSAKey(&recWK.EPK, t.ecKW)
+		if err != nil {
+			return nil, fmt.Errorf("deriveDirectKey: %w", err)
+		}
+
+		if recPrivKey.Curve != epkPubKey.Curve || !recPrivKey.Curve.IsOnCurve(epkPubKey.X, epkPubKey.Y) {
+			return nil, errors.New("deriveDirectKey: recipient and ephemeral keys are not on the same curve")
+		}
+
+		return josecipher.DeriveECDHES(encAlg, recWK.APU, recWK.APV, recPrivKey, epkPubKey, cekSize), nil
+	case ecdhpb.KeyType_OKP.String():
+		recPrivKey, err := ksToPrivateX25519Key(recKH)
+		if err != nil {
+			return nil, fmt.Errorf("deriveDirectKey: %w", err)
+		}
+
+		recPrivKeyChacha := new([chacha20poly1305.KeySize]byte)
+		copy(recPrivKeyChacha[:], recPrivKey)
+
+		epkChacha := new([chacha20poly1305.KeySize]byte)
+		copy(epkChacha[:], recWK.EPK.X)
+
+		z, err := cryptoutil.DeriveECDHX25519(recPrivKeyChacha, epkChacha)
+		if err != nil {
+			return nil, fmt.Errorf("deriveDirectKey: %w", err)
+		}
+
+		return kdf(encAlg, z, recWK.APU, recWK.APV, cekSize), nil
+	default:
+		return nil, errors.New("deriveDirectKey: invalid EPK key type for ECDH-ES")
+	}
+}
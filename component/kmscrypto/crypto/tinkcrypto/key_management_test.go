@@ -0,0 +1,252 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tinkcrypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" // nolint:gosec // RSA-OAEP (RFC 7518 section 4.3) uses SHA-1.
+	"math/big"
+	"testing"
+
+	josecipher "github.com/go-jose/go-jose/v3/cipher"
+	"github.com/google/tink/go/aead"
+	"github.com/google/tink/go/keyset"
+	"github.com/google/tink/go/subtle/random"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/curve25519"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/spi/crypto"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/composite/ecdh"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/composite/keyio"
+	ecdhpb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/rsaoaep"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/util/cryptoutil"
+)
+
+func TestCrypto_RSAOAEP_Wrap_Unwrap_Key(t *testing.T) {
+	template, err := rsaoaep.RSAOAEP3072KeyTemplate()
+	require.NoError(t, err)
+
+	recipientKeyHandle, err := keyset.NewHandle(template)
+	require.NoError(t, err)
+
+	ps, err := recipientKeyHandle.Primitives()
+	require.NoError(t, err)
+
+	rsaKey, ok := ps.Primary.Primitive.(*rsa.PrivateKey)
+	require.True(t, ok)
+
+	recipientKey := &cryptoapi.PublicKey{
+		KID:  "rsa-kid",
+		X:    rsaKey.N.Bytes(),
+		Y:    big.NewInt(int64(rsaKey.E)).Bytes(),
+		Type: "RSA",
+	}
+
+	c, err := New()
+	require.NoError(t, err)
+
+	cek := random.GetRandomBytes(uint32(crypto.DefKeySize))
+
+	wrappedKey, err := c.WrapKey(cek, nil, nil, recipientKey)
+	require.NoError(t, err)
+	require.Equal(t, RSAOAEP256Alg, wrappedKey.Alg)
+	require.Equal(t, recipientKey.KID, wrappedKey.KID)
+	require.Empty(t, wrappedKey.EPK)
+
+	uCEK, err := c.UnwrapKey(wrappedKey, recipientKeyHandle)
+	require.NoError(t, err)
+	require.EqualValues(t, cek, uCEK)
+
+	t.Run("unwrap RSA-OAEP (SHA-1) key", func(t *testing.T) {
+		encCEK, err := rsa.EncryptOAEP(sha1.New(), rand.Reader, &rsaKey.PublicKey, cek, nil) // nolint:gosec
+		require.NoError(t, err)
+
+		uCEK, err = c.UnwrapKey(&cryptoapi.RecipientWrappedKey{EncryptedCEK: encCEK, Alg: RSAOAEPAlg},
+			recipientKeyHandle)
+		require.NoError(t, err)
+		require.EqualValues(t, cek, uCEK)
+	})
+
+	t.Run("unwrap with invalid key", func(t *testing.T) {
+		_, err = c.UnwrapKey(wrappedKey, nil)
+		require.EqualError(t, err, "unwrapKey: unwrapRSAOAEP: bad key handle format")
+
+		ecKH, err := keyset.NewHandle(ecdh.NISTP256ECDHKWKeyTemplate())
+		require.NoError(t, err)
+
+		_, err = c.UnwrapKey(wrappedKey, ecKH)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unwrapKey: unwrapRSAOAEP")
+	})
+
+	t.Run("wrap with invalid public exponent", func(t *testing.T) {
+		_, err = c.WrapKey(cek, nil, nil, &cryptoapi.PublicKey{
+			X:    recipientKey.X,
+			Y:    random.GetRandomBytes(16),
+			Type: "RSA",
+		})
+		require.EqualError(t, err, "wrapKey: wrapRSAOAEP: invalid RSA public exponent")
+	})
+}
+
+func TestCrypto_A256KW_Wrap_Unwrap_Key(t *testing.T) {
+	kek, err := keyset.NewHandle(aead.AES256GCMNoPrefixKeyTemplate())
+	require.NoError(t, err)
+
+	c, err := New()
+	require.NoError(t, err)
+
+	cek := random.GetRandomBytes(uint32(crypto.DefKeySize))
+
+	wrappedKey, err := c.WrapKey(cek, nil, nil, &cryptoapi.PublicKey{KID: "kek-kid"}, cryptoapi.WithKEK(kek))
+	require.NoError(t, err)
+	require.Equal(t, A256KWAlg, wrappedKey.Alg)
+	require.Equal(t, "kek-kid", wrappedKey.KID)
+	require.Len(t, wrappedKey.EncryptedCEK, len(cek)+8)
+
+	uCEK, err := c.UnwrapKey(wrappedKey, kek)
+	require.NoError(t, err)
+	require.EqualValues(t, cek, uCEK)
+
+	t.Run("unwrap with different kek", func(t *testing.T) {
+		otherKEK, err := keyset.NewHandle(aead.AES256GCMNoPrefixKeyTemplate())
+		require.NoError(t, err)
+
+		_, err = c.UnwrapKey(wrappedKey, otherKEK)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unwrapKey: unwrapWithKEK")
+	})
+
+	t.Run("wrap with invalid kek", func(t *testing.T) {
+		_, err = c.WrapKey(cek, nil, nil, &cryptoapi.PublicKey{}, cryptoapi.WithKEK("bad kek"))
+		require.EqualError(t, err, "wrapKey: wrapWithKEK: bad key handle format")
+
+		aes128KEK, err := keyset.NewHandle(aead.AES128GCMKeyTemplate())
+		require.NoError(t, err)
+
+		_, err = c.WrapKey(cek, nil, nil, &cryptoapi.PublicKey{}, cryptoapi.WithKEK(aes128KEK))
+		require.EqualError(t, err, "wrapKey: wrapWithKEK: key encryption key is not an AES-256 key")
+
+		ecKH, err := keyset.NewHandle(ecdh.NISTP256ECDHKWKeyTemplate())
+		require.NoError(t, err)
+
+		_, err = c.WrapKey(cek, nil, nil, &cryptoapi.PublicKey{}, cryptoapi.WithKEK(ecKH))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "wrapKey: wrapWithKEK: unsupported key encryption key")
+	})
+}
+
+func TestCrypto_ECDHES_Direct_Key_Agreement(t *testing.T) {
+	const (
+		encAlg  = "A256GCM"
+		cekSize = 32
+	)
+
+	c, err := New()
+	require.NoError(t, err)
+
+	apu := random.GetRandomBytes(uint32(10))
+	apv := random.GetRandomBytes(uint32(10))
+
+	t.Run("EC key", func(t *testing.T) {
+		recipientKeyHandle, err := keyset.NewHandle(ecdh.NISTP256ECDHKWKeyTemplate())
+		require.NoError(t, err)
+
+		recipientKey, err := keyio.ExtractPrimaryPublicKey(recipientKeyHandle)
+		require.NoError(t, err)
+
+		epk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		expectedCEK := josecipher.DeriveECDHES(encAlg, apu, apv, epk, &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(recipientKey.X),
+			Y:     new(big.Int).SetBytes(recipientKey.Y),
+		}, cekSize)
+
+		recWK := &cryptoapi.RecipientWrappedKey{
+			EPK: cryptoapi.PublicKey{
+				X:     epk.X.Bytes(),
+				Y:     epk.Y.Bytes(),
+				Curve: recipientKey.Curve,
+				Type:  ecdhpb.KeyType_EC.String(),
+			},
+			APU: apu,
+			APV: apv,
+			Alg: ECDHESAlg,
+		}
+
+		cek, err := c.UnwrapKey(recWK, recipientKeyHandle, cryptoapi.WithDirectKeyAgreement(encAlg, cekSize))
+		require.NoError(t, err)
+		require.EqualValues(t, expectedCEK, cek)
+
+		_, err = c.UnwrapKey(recWK, recipientKeyHandle)
+		require.EqualError(t, err, "unwrapKey: deriveDirectKey: direct key agreement option is required")
+
+		ecdh384Key, err := keyset.NewHandle(ecdh.NISTP384ECDHKWKeyTemplate())
+		require.NoError(t, err)
+
+		_, err = c.UnwrapKey(recWK, ecdh384Key, cryptoapi.WithDirectKeyAgreement(encAlg, cekSize))
+		require.EqualError(t, err, "unwrapKey: deriveDirectKey: recipient and ephemeral keys are not on the same "+
+			"curve")
+
+		recWK.EPK.Y = random.GetRandomBytes(32)
+
+		_, err = c.UnwrapKey(recWK, recipientKeyHandle, cryptoapi.WithDirectKeyAgreement(encAlg, cekSize))
+		require.EqualError(t, err, "unwrapKey: deriveDirectKey: recipient and ephemeral keys are not on the same "+
+			"curve")
+	})
+
+	t.Run("OKP key", func(t *testing.T) {
+		recipientKeyHandle, err := keyset.NewHandle(ecdh.X25519ECDHKWKeyTemplate())
+		require.NoError(t, err)
+
+		recipientKey, err := keyio.ExtractPrimaryPublicKey(recipientKeyHandle)
+		require.NoError(t, err)
+
+		epkPriv := random.GetRandomBytes(uint32(curve25519.ScalarSize))
+
+		epkPub, err := curve25519.X25519(epkPriv, curve25519.Basepoint)
+		require.NoError(t, err)
+
+		epkPrivChacha := new([cryptoutil.Curve25519KeySize]byte)
+		copy(epkPrivChacha[:], epkPriv)
+
+		recPubChacha := new([cryptoutil.Curve25519KeySize]byte)
+		copy(recPubChacha[:], recipientKey.X)
+
+		z, err := cryptoutil.DeriveECDHX25519(epkPrivChacha, recPubChacha)
+		require.NoError(t, err)
+
+		recWK := &cryptoapi.RecipientWrappedKey{
+			EPK: cryptoapi.PublicKey{
+				X:     epkPub,
+				Curve: recipientKey.Curve,
+				Type:  ecdhpb.KeyType_OKP.String(),
+			},
+			APU: apu,
+			APV: apv,
+			Alg: ECDHESAlg,
+		}
+
+		cek, err := c.UnwrapKey(recWK, recipientKeyHandle, cryptoapi.WithDirectKeyAgreement(encAlg, cekSize))
+		require.NoError(t, err)
+		require.EqualValues(t, kdf(encAlg, z, apu, apv, cekSize), cek)
+	})
+
+	t.Run("invalid EPK type", func(t *testing.T) {
+		_, err = c.UnwrapKey(&cryptoapi.RecipientWrappedKey{Alg: ECDHESAlg}, nil,
+			cryptoapi.WithDirectKeyAgreement(encAlg, cekSize))
+		require.EqualError(t, err, "unwrapKey: deriveDirectKey: invalid EPK key type for ECDH-ES")
+	})
+}
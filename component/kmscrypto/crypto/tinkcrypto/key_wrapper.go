@@ -24,6 +24,7 @@ import (
 
 	cryptoapi "github.com/hyperledger/aries-framework-go/spi/crypto"
 
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/x25519mlkem768"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/aead/subtle"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/composite/keyio"
 	ecdhpb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
@@ -41,6 +42,10 @@ func (t *Crypto) deriveKEKAndWrap(cek, apu, apv, tag []byte, senderKH interface{
 		err         error
 	)
 
+	if recPubKey.Curve == x25519MLKEM768Curve {
+		return t.deriveHybridKEKAndWrap(cek, apu, apv, tag, senderKH, recPubKey, epkPrv, useXC20PKW)
+	}
+
 	if senderKH != nil { // ecdh1pu
 		wrappingAlg, kek, epk, apu, err = t.derive1PUKEK(len(cek), apu, apv, tag, senderKH, recPubKey, epkPrv,
 			useXC20PKW)
@@ -122,6 +127,9 @@ func (t *Crypto) deriveKEKAndUnwrap(alg string, encCEK, apu, apv, tag []byte, ep
 		if err != nil {
 			return nil, fmt.Errorf("deriveKEKAndUnwrap: error ECDH-ES kek derivation: %w", err)
 		}
+	case ECDHESX25519MLKEM768A256KWAlg, ECDHESX25519MLKEM768XC20PKWAlg, ECDH1PUX25519MLKEM768A256KWAlg,
+		ECDH1PUX25519MLKEM768XC20PKWAlg:
+		return t.deriveHybridKEKAndUnwrap(alg, encCEK, apu, apv, tag, epk, senderKH, recipientPrivateKey)
 	default:
 		return nil, fmt.Errorf("deriveKEKAndUnwrap: unsupported JWE KW Alg '%s'", alg)
 	}
@@ -134,7 +142,8 @@ func (t *Crypto) unwrapRaw(alg string, kek, encCEK []byte) ([]byte, error) {
 
 	// key unwrapping does not depend on an option (like key wrapping), because kw primitive can be detected from alg.
 	switch alg {
-	case ECDHESXC20PKWAlg, ECDH1PUXC20PKWAlg: // XC20P key unwrap
+	case ECDHESXC20PKWAlg, ECDH1PUXC20PKWAlg, ECDHESX25519MLKEM768XC20PKWAlg,
+		ECDH1PUX25519MLKEM768XC20PKWAlg: // XC20P key unwrap
 		aead, err := t.okpKW.createPrimitive(kek)
 		if err != nil {
 			return nil, fmt.Errorf("deriveKEKAndUnwrap: failed to create new XC20P primitive: %w", err)
@@ -144,7 +153,8 @@ func (t *Crypto) unwrapRaw(alg string, kek, encCEK []byte) ([]byte, error) {
 		if err != nil {
 			return nil, fmt.Errorf("deriveKEKAndUnwrap: failed to XC20P unwrap key: %w", err)
 		}
-	case ECDHESA256KWAlg, ECDH1PUA128KWAlg, ECDH1PUA192KWAlg, ECDH1PUA256KWAlg:
+	case ECDHESA256KWAlg, ECDH1PUA128KWAlg, ECDH1PUA192KWAlg, ECDH1PUA256KWAlg, ECDHESX25519MLKEM768A256KWAlg,
+		ECDH1PUX25519MLKEM768A256KWAlg:
 		// A256GCM key (ES) unwrap or CBC+HMAC (1PU)
 		block, err := t.ecKW.createPrimitive(kek)
 		if err != nil {
@@ -646,12 +656,14 @@ func ksToPrivateX25519Key(ks interface{}) ([]byte, error) {
 		return nil, fmt.Errorf("ksToPrivateX25519Key: failed to extract sender key: %w", err)
 	}
 
-	prvKey, ok := senderPrivKey.([]byte)
-	if !ok {
+	switch prvKey := senderPrivKey.(type) {
+	case []byte:
+		return prvKey, nil
+	case *x25519mlkem768.PrivateKey:
+		return prvKey.X25519(), nil
+	default:
 		return nil, errors.New("ksToPrivateX25519Key: not an OKP key")
 	}
-
-	return prvKey, nil
 }
 
 func ksToPublicECDSAKey(ks interface{}, kw keyWrapper) (*ecdsa.PublicKey, error) {
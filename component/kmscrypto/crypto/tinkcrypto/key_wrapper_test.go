@@ -478,3 +478,65 @@ func TestX25519ECDHVector(t *testing.T) {
 	require.EqualValues(t, sharedSecretVector, sharedSecretFromAlice)
 	require.EqualValues(t, sharedSecretVector, sharedSecretFromBob)
 }
+
+func Test_deriveHybridKEKAndUnwrap_Failure(t *testing.T) {
+	c, err := New()
+	require.NoError(t, err)
+
+	recKH, err := keyset.NewHandle(ecdh.X25519MLKEM768ECDHKWKeyTemplate())
+	require.NoError(t, err)
+
+	recPubKey, err := keyio.ExtractPrimaryPublicKey(recKH)
+	require.NoError(t, err)
+	require.Equal(t, x25519MLKEM768Curve, recPubKey.Curve)
+
+	cek := random.GetRandomBytes(uint32(defKeySize))
+
+	wk, err := c.WrapKey(cek, nil, nil, recPubKey)
+	require.NoError(t, err)
+	require.Equal(t, ECDHESX25519MLKEM768A256KWAlg, wk.Alg)
+
+	t.Run("unwrap with truncated encrypted key", func(t *testing.T) {
+		badWK := *wk
+		badWK.EncryptedCEK = wk.EncryptedCEK[:100]
+
+		_, err = c.UnwrapKey(&badWK, recKH)
+		require.EqualError(t, err, "unwrapKey: deriveHybridKEKAndUnwrap: encrypted key too short")
+	})
+
+	t.Run("unwrap with tampered ML-KEM ciphertext", func(t *testing.T) {
+		badWK := *wk
+		badWK.EncryptedCEK = append([]byte{}, wk.EncryptedCEK...)
+		badWK.EncryptedCEK[0] ^= 0xff
+
+		_, err = c.UnwrapKey(&badWK, recKH)
+		require.EqualError(t, err, "unwrapKey: deriveKEKAndUnwrap: failed to AES unwrap key: go-jose/go-jose: "+
+			"failed to unwrap key")
+	})
+
+	t.Run("unwrap with X25519 only recipient key", func(t *testing.T) {
+		x25519KH, e := keyset.NewHandle(ecdh.X25519ECDHKWKeyTemplate())
+		require.NoError(t, e)
+
+		_, err = c.UnwrapKey(wk, x25519KH)
+		require.EqualError(t, err, "unwrapKey: deriveHybridKEKAndUnwrap: recipient key is not an X25519MLKEM768 key")
+	})
+
+	t.Run("unwrap 1PU without sender key", func(t *testing.T) {
+		badWK := *wk
+		badWK.Alg = ECDH1PUX25519MLKEM768A256KWAlg
+
+		_, err = c.UnwrapKey(&badWK, recKH)
+		require.EqualError(t, err, "unwrapKey: deriveHybridKEKAndUnwrap: sender's public keyset handle option is "+
+			"required for 'ECDH-1PU+X25519MLKEM768+A256KW'")
+	})
+
+	t.Run("wrap with invalid hybrid recipient key", func(t *testing.T) {
+		badKey := *recPubKey
+		badKey.X = recPubKey.X[:curve25519.PointSize]
+
+		_, err = c.WrapKey(cek, nil, nil, &badKey)
+		require.EqualError(t, err, "wrapKey: deriveHybridKEKAndWrap: invalid recipient key: x25519mlkem768: "+
+			"invalid public key size")
+	})
+}
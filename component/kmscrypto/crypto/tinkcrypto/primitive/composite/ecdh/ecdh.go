@@ -124,4 +124,14 @@ func init() {
 	if err != nil {
 		panic(fmt.Sprintf("ecdh.init() failed: %v", err))
 	}
+
+	err = registry.RegisterKeyManager(newX25519MLKEM768ECDHKWPrivateKeyManager())
+	if err != nil {
+		panic(fmt.Sprintf("ecdh.init() failed: %v", err))
+	}
+
+	err = registry.RegisterKeyManager(newX25519MLKEM768ECDHKWPublicKeyManager())
+	if err != nil {
+		panic(fmt.Sprintf("ecdh.init() failed: %v", err))
+	}
 }
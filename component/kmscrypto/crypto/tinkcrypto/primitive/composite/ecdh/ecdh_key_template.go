@@ -93,6 +93,18 @@ func X25519ECDHKWKeyTemplate() *tinkpb.KeyTemplate {
 	return createKeyTemplate(false, XC20P, commonpb.EllipticCurveType_CURVE25519, nil)
 }
 
+// X25519MLKEM768ECDHKWKeyTemplate is a KeyTemplate that generates a hybrid X25519 and ML-KEM-768 key that accepts a CEK
+// for JWE content encryption. CEK wrapping is done outside of this Tink key (in the tinkcrypto service) using a key
+// derived from both the X25519 ECDH shared secret and a shared secret encapsulated with ML-KEM-768.
+// Keys from this template represent a valid recipient public/private key pairs and can be stored in the KMS.
+func X25519MLKEM768ECDHKWKeyTemplate() *tinkpb.KeyTemplate {
+	// xc20p is set to pass key generation in the key manager, it's irrelevant to the key or its intended use.
+	kt := createKeyTemplate(false, XC20P, commonpb.EllipticCurveType_CURVE25519, nil)
+	kt.TypeUrl = x25519MLKEM768ECDHKWPrivateKeyTypeURL
+
+	return kt
+}
+
 // KeyTemplateForECDHPrimitiveWithCEK is similar to NISTP256ECDHKWKeyTemplate but adding the cek to execute the
 // CompositeEncrypt primitive for encrypting a message targeted to one ore more recipients. KW is not executed by this
 // template, so it is ignored and set to NIST P Curved key by default.
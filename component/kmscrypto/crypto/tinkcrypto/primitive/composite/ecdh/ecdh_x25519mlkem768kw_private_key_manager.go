@@ -0,0 +1,175 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdh
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/tink/go/core/registry"
+	"github.com/google/tink/go/keyset"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/x25519mlkem768"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/composite"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/composite/ecdh/subtle"
+	ecdhpb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+)
+
+const (
+	x25519MLKEM768ECDHKWPrivateKeyVersion = 0
+	x25519MLKEM768ECDHKWPrivateKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.X25519MLKEM768EcdhKwPrivateKey" // nolint:lll
+)
+
+// common errors.
+var (
+	errInvalidx25519MLKEM768ECDHKWPrivateKey       = errors.New("x25519mlkem768kw_ecdh_private_key_manager: invalid key")
+	errInvalidx25519MLKEM768ECDHKWPrivateKeyFormat = errors.New("x25519mlkem768kw_ecdh_private_key_manager: " +
+		"invalid key format")
+)
+
+// x25519MLKEM768ECDHKWPrivateKeyManager is an implementation of PrivateKeyManager interface for hybrid X25519 and
+// ML-KEM-768 key wrapping. It generates new ECDHPrivateKey (X25519+ML-KEM-768 KW) keys and produces new instances of
+// ECDHAEADCompositeDecrypt subtle.
+// The key value is the X25519 private key followed by the ML-KEM-768 seed, the public key X value is the X25519 public
+// key followed by the ML-KEM-768 encapsulation key.
+type x25519MLKEM768ECDHKWPrivateKeyManager struct{}
+
+// Assert that x25519MLKEM768ECDHKWPrivateKeyManager implements the PrivateKeyManager interface.
+var _ registry.PrivateKeyManager = (*x25519MLKEM768ECDHKWPrivateKeyManager)(nil)
+
+// newX25519MLKEM768ECDHKWPrivateKeyManager creates a new x25519MLKEM768ECDHKWPrivateKeyManager.
+func newX25519MLKEM768ECDHKWPrivateKeyManager() *x25519MLKEM768ECDHKWPrivateKeyManager {
+	return new(x25519MLKEM768ECDHKWPrivateKeyManager)
+}
+
+// Primitive creates an ECDHESPrivateKey subtle for the given serialized ECDHESPrivateKey proto.
+func (km *x25519MLKEM768ECDHKWPrivateKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidx25519MLKEM768ECDHKWPrivateKey
+	}
+
+	key := new(ecdhpb.EcdhAeadPrivateKey)
+
+	err := proto.Unmarshal(serializedKey, key)
+	if err != nil {
+		return nil, errInvalidx25519MLKEM768ECDHKWPrivateKey
+	}
+
+	err = km.validateKey(key)
+	if err != nil {
+		return nil, errInvalidx25519MLKEM768ECDHKWPrivateKey
+	}
+
+	rEnc, err := composite.NewRegisterCompositeAEADEncHelper(key.PublicKey.Params.EncParams.AeadEnc)
+	if err != nil {
+		return nil, fmt.Errorf("x25519mlkem768kw_ecdh_private_key_manager: NewRegisterCompositeAEADEncHelper "+
+			"failed: %w", err)
+	}
+
+	return subtle.NewECDHAEADCompositeDecrypt(rEnc, key.PublicKey.Params.EncParams.CEK), nil
+}
+
+// NewKey creates a new key according to the specification of ECDHESPrivateKey format.
+func (km *x25519MLKEM768ECDHKWPrivateKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	if len(serializedKeyFormat) == 0 {
+		return nil, errInvalidx25519MLKEM768ECDHKWPrivateKeyFormat
+	}
+
+	keyFormat := new(ecdhpb.EcdhAeadKeyFormat)
+
+	err := proto.Unmarshal(serializedKeyFormat, keyFormat)
+	if err != nil {
+		return nil, errInvalidx25519MLKEM768ECDHKWPrivateKeyFormat
+	}
+
+	err = validateKeyXChachaFormat(keyFormat.Params)
+	if err != nil || keyFormat.Params.EncParams.CEK != nil {
+		return nil, errInvalidx25519MLKEM768ECDHKWPrivateKeyFormat
+	}
+
+	pvt, err := x25519mlkem768.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("x25519mlkem768kw_ecdh_private_key_manager: GenerateKey failed: %w", err)
+	}
+
+	return &ecdhpb.EcdhAeadPrivateKey{
+		Version:  x25519MLKEM768ECDHKWPrivateKeyVersion,
+		KeyValue: pvt.Bytes(),
+		PublicKey: &ecdhpb.EcdhAeadPublicKey{
+			Version: x25519MLKEM768ECDHKWPrivateKeyVersion,
+			Params:  keyFormat.Params,
+			X:       pvt.PublicKey().Bytes(),
+		},
+	}, nil
+}
+
+// NewKeyData creates a new KeyData according to the specification of ECDHESPrivateKey Format.
+// It should be used solely by the key management API.
+func (km *x25519MLKEM768ECDHKWPrivateKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	key, err := km.NewKey(serializedKeyFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	serializedKey, err := proto.Marshal(key)
+	if err != nil {
+		return nil, fmt.Errorf("x25519mlkem768kw_ecdh_private_key_manager: Proto.Marshal failed: %w", err)
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         x25519MLKEM768ECDHKWPrivateKeyTypeURL,
+		Value:           serializedKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PRIVATE,
+	}, nil
+}
+
+// PublicKeyData returns the enclosed public key data of serializedPrivKey.
+func (km *x25519MLKEM768ECDHKWPrivateKeyManager) PublicKeyData(serializedPrivKey []byte) (*tinkpb.KeyData, error) {
+	privKey := new(ecdhpb.EcdhAeadPrivateKey)
+
+	err := proto.Unmarshal(serializedPrivKey, privKey)
+	if err != nil {
+		return nil, errInvalidx25519MLKEM768ECDHKWPrivateKey
+	}
+
+	serializedPubKey, err := proto.Marshal(privKey.PublicKey)
+	if err != nil {
+		return nil, errInvalidx25519MLKEM768ECDHKWPrivateKey
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         x25519MLKEM768ECDHKWPublicKeyTypeURL,
+		Value:           serializedPubKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PUBLIC,
+	}, nil
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *x25519MLKEM768ECDHKWPrivateKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == x25519MLKEM768ECDHKWPrivateKeyTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *x25519MLKEM768ECDHKWPrivateKeyManager) TypeURL() string {
+	return x25519MLKEM768ECDHKWPrivateKeyTypeURL
+}
+
+// validateKey validates the given ECDHPrivateKey.
+func (km *x25519MLKEM768ECDHKWPrivateKeyManager) validateKey(key *ecdhpb.EcdhAeadPrivateKey) error {
+	err := keyset.ValidateKeyVersion(key.Version, x25519MLKEM768ECDHKWPrivateKeyVersion)
+	if err != nil {
+		return fmt.Errorf("x25519mlkem768kw_ecdh_private_key_manager: invalid key: %w", err)
+	}
+
+	if len(key.KeyValue) != x25519mlkem768.PrivateKeySize {
+		return errors.New("x25519mlkem768kw_ecdh_private_key_manager: invalid key size")
+	}
+
+	return validateKeyXChachaFormat(key.PublicKey.Params)
+}
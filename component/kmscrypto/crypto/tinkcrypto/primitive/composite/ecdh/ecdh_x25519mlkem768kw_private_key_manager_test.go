@@ -0,0 +1,105 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdh
+
+import (
+	"testing"
+
+	"github.com/google/tink/go/aead"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/x25519mlkem768"
+	ecdhpb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+)
+
+func TestECDHX25519MLKEM768PrivateKeyManager_NewKey(t *testing.T) {
+	km := newX25519MLKEM768ECDHKWPrivateKeyManager()
+	require.True(t, km.DoesSupport(x25519MLKEM768ECDHKWPrivateKeyTypeURL))
+	require.False(t, km.DoesSupport(x25519ECDHKWPrivateKeyTypeURL))
+
+	t.Run("Test private key manager NewKey() with nil key", func(t *testing.T) {
+		k, err := km.NewKey(nil)
+		require.EqualError(t, err, errInvalidx25519MLKEM768ECDHKWPrivateKeyFormat.Error())
+		require.Empty(t, k)
+	})
+
+	t.Run("Test private key manager NewKey() with bad curve", func(t *testing.T) {
+		k, err := km.NewKey(marshalX25519MLKEM768KeyFormat(t, commonpb.EllipticCurveType_NIST_P256))
+		require.EqualError(t, err, errInvalidx25519MLKEM768ECDHKWPrivateKeyFormat.Error())
+		require.Empty(t, k)
+	})
+
+	t.Run("success private key manager NewKeyData(), PublicKeyData() and Primitive()", func(t *testing.T) {
+		kd, err := km.NewKeyData(marshalX25519MLKEM768KeyFormat(t, commonpb.EllipticCurveType_CURVE25519))
+		require.NoError(t, err)
+		require.Equal(t, x25519MLKEM768ECDHKWPrivateKeyTypeURL, kd.TypeUrl)
+		require.Equal(t, tinkpb.KeyData_ASYMMETRIC_PRIVATE, kd.KeyMaterialType)
+
+		privKey := new(ecdhpb.EcdhAeadPrivateKey)
+		require.NoError(t, proto.Unmarshal(kd.Value, privKey))
+		require.Len(t, privKey.KeyValue, x25519mlkem768.PrivateKeySize)
+		require.Len(t, privKey.PublicKey.X, x25519mlkem768.PublicKeySize)
+
+		hybridKey, err := x25519mlkem768.NewPrivateKey(privKey.KeyValue)
+		require.NoError(t, err)
+		require.Equal(t, hybridKey.PublicKey().Bytes(), privKey.PublicKey.X)
+
+		p, err := km.Primitive(kd.Value)
+		require.NoError(t, err)
+		require.NotEmpty(t, p)
+
+		pubKD, err := km.PublicKeyData(kd.Value)
+		require.NoError(t, err)
+		require.Equal(t, x25519MLKEM768ECDHKWPublicKeyTypeURL, pubKD.TypeUrl)
+
+		pubKM := newX25519MLKEM768ECDHKWPublicKeyManager()
+		require.True(t, pubKM.DoesSupport(pubKD.TypeUrl))
+
+		p, err = pubKM.Primitive(pubKD.Value)
+		require.NoError(t, err)
+		require.NotEmpty(t, p)
+
+		// an X25519 only public key must be rejected.
+		privKey.PublicKey.X = privKey.PublicKey.X[:32]
+		badPubKey, err := proto.Marshal(privKey.PublicKey)
+		require.NoError(t, err)
+
+		_, err = pubKM.Primitive(badPubKey)
+		require.EqualError(t, err, errInvalidx25519MLKEM768ECDHKWPublicKey.Error())
+
+		// same for an X25519 only private key.
+		privKey.KeyValue = privKey.KeyValue[:32]
+		badPrivKey, err := proto.Marshal(privKey)
+		require.NoError(t, err)
+
+		_, err = km.Primitive(badPrivKey)
+		require.EqualError(t, err, errInvalidx25519MLKEM768ECDHKWPrivateKey.Error())
+	})
+}
+
+func marshalX25519MLKEM768KeyFormat(t *testing.T, c commonpb.EllipticCurveType) []byte {
+	t.Helper()
+
+	keyFormat, err := proto.Marshal(&ecdhpb.EcdhAeadKeyFormat{
+		Params: &ecdhpb.EcdhAeadParams{
+			KwParams: &ecdhpb.EcdhKwParams{
+				CurveType: c,
+				KeyType:   ecdhpb.KeyType_OKP,
+			},
+			EncParams: &ecdhpb.EcdhAeadEncParams{
+				AeadEnc: aead.XChaCha20Poly1305KeyTemplate(),
+			},
+			EcPointFormat: commonpb.EcPointFormat_UNCOMPRESSED,
+		},
+	})
+	require.NoError(t, err)
+
+	return keyFormat
+}
@@ -0,0 +1,103 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdh
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/tink/go/core/registry"
+	"github.com/google/tink/go/keyset"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/x25519mlkem768"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/composite"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/composite/ecdh/subtle"
+	ecdhpb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+)
+
+const (
+	x25519MLKEM768ECDHKWPublicKeyVersion = 0
+	x25519MLKEM768ECDHKWPublicKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.X25519MLKEM768EcdhKwPublicKey" // nolint:lll
+)
+
+// common errors.
+var errInvalidx25519MLKEM768ECDHKWPublicKey = errors.New("x25519mlkem768kw_ecdh_public_key_manager: invalid key")
+
+// x25519MLKEM768ECDHKWPublicKeyManager is an implementation of KeyManager interface for hybrid X25519 and ML-KEM-768
+// key wrapping. It produces new instances of ECDHAEADCompositeEncrypt subtle.
+type x25519MLKEM768ECDHKWPublicKeyManager struct{}
+
+// Assert that x25519MLKEM768ECDHKWPublicKeyManager implements the KeyManager interface.
+var _ registry.KeyManager = (*x25519MLKEM768ECDHKWPublicKeyManager)(nil)
+
+// newX25519MLKEM768ECDHKWPublicKeyManager creates a new x25519MLKEM768ECDHKWPublicKeyManager.
+func newX25519MLKEM768ECDHKWPublicKeyManager() *x25519MLKEM768ECDHKWPublicKeyManager {
+	return new(x25519MLKEM768ECDHKWPublicKeyManager)
+}
+
+// Primitive creates an ECDHESXChachaPublicKey subtle for the given serialized ECDHESXChachaPublicKey proto.
+func (km *x25519MLKEM768ECDHKWPublicKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidx25519MLKEM768ECDHKWPublicKey
+	}
+
+	ecdhPubKey := new(ecdhpb.EcdhAeadPublicKey)
+
+	err := proto.Unmarshal(serializedKey, ecdhPubKey)
+	if err != nil {
+		return nil, errInvalidx25519MLKEM768ECDHKWPublicKey
+	}
+
+	err = km.validateKey(ecdhPubKey)
+	if err != nil {
+		return nil, errInvalidx25519MLKEM768ECDHKWPublicKey
+	}
+
+	rEnc, err := composite.NewRegisterCompositeAEADEncHelper(ecdhPubKey.Params.EncParams.AeadEnc)
+	if err != nil {
+		return nil, fmt.Errorf("x25519mlkem768kw_ecdh_public_key_manager: NewRegisterCompositeAEADEncHelper "+
+			"failed: %w", err)
+	}
+
+	return subtle.NewECDHAEADCompositeEncrypt(rEnc, ecdhPubKey.Params.EncParams.CEK), nil
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *x25519MLKEM768ECDHKWPublicKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == x25519MLKEM768ECDHKWPublicKeyTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *x25519MLKEM768ECDHKWPublicKeyManager) TypeURL() string {
+	return x25519MLKEM768ECDHKWPublicKeyTypeURL
+}
+
+// NewKey is not implemented for public key manager.
+func (km *x25519MLKEM768ECDHKWPublicKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	return nil, errors.New("x25519mlkem768kw_ecdh_public_key_manager: NewKey not implemented")
+}
+
+// NewKeyData is not implemented for public key manager.
+func (km *x25519MLKEM768ECDHKWPublicKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	return nil, errors.New("x25519mlkem768kw_ecdh_public_key_manager: NewKeyData not implemented")
+}
+
+// validateKey validates the given EcdhAeadPublicKey.
+func (km *x25519MLKEM768ECDHKWPublicKeyManager) validateKey(key *ecdhpb.EcdhAeadPublicKey) error {
+	err := keyset.ValidateKeyVersion(key.Version, x25519MLKEM768ECDHKWPublicKeyVersion)
+	if err != nil {
+		return fmt.Errorf("x25519mlkem768kw_ecdh_public_key_manager: invalid key: %w", err)
+	}
+
+	if len(key.X) != x25519mlkem768.PublicKeySize {
+		return errors.New("x25519mlkem768kw_ecdh_public_key_manager: invalid key size")
+	}
+
+	return validateKeyXChachaFormat(key.Params)
+}
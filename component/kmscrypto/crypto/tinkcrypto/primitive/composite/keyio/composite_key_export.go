@@ -39,6 +39,14 @@ const (
 	x25519ECDHKWPublicKeyTypeURL  = "type.hyperledger.org/hyperledger.aries.crypto.tink.X25519EcdhKwPublicKey"
 	nistPECDHKWPrivateKeyTypeURL  = "type.hyperledger.org/hyperledger.aries.crypto.tink.NistPEcdhKwPrivateKey"
 	x25519ECDHKWPrivateKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.X25519EcdhKwPrivateKey"
+	// nolint:lll
+	x25519MLKEM768ECDHKWPublicKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.X25519MLKEM768EcdhKwPublicKey"
+	// nolint:gosec,lll // not a credential.
+	x25519MLKEM768ECDHKWPrivateKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.X25519MLKEM768EcdhKwPrivateKey"
+
+	// x25519MLKEM768Curve is the JWK curve name of hybrid X25519 and ML-KEM-768 keys. These keys use the Curve25519
+	// curve type in their proto params.
+	x25519MLKEM768Curve = "X25519MLKEM768"
 )
 
 //nolint:gochecknoglobals
@@ -53,6 +61,7 @@ var ecdhKeyTypes = map[string]kms.KeyType{
 // The keyset must have a keyURL value equal to either one of the public key URLs:
 //   - `nistPECDHKWPublicKeyTypeURL`
 //   - `x25519ECDHKWPublicKeyTypeURL`
+//   - `x25519MLKEM768ECDHKWPublicKeyTypeURL`
 //
 // constants of ecdh package.
 // Note: This writer should be used only for ECDH public key exports. Other export of public keys should be
@@ -139,6 +148,20 @@ func protoToCompositeKey(keyData *tinkpb.KeyData) (*cryptoapi.PublicKey, kms.Key
 		if err != nil {
 			return nil, "", err
 		}
+	case x25519MLKEM768ECDHKWPublicKeyTypeURL:
+		cKey, err = newECDHKey(keyData.Value)
+		if err != nil {
+			return nil, "", err
+		}
+
+		pubKey, _, e := buildKey(cKey)
+		if e != nil {
+			return nil, "", e
+		}
+
+		pubKey.Curve = x25519MLKEM768Curve
+
+		return pubKey, kms.X25519MLKEM768ECDHKWType, nil
 	default:
 		return nil, "", fmt.Errorf("can't export key with keyURL:%s", keyData.TypeUrl)
 	}
@@ -292,6 +315,10 @@ func PublicKeyToKeysetHandle(pubKey *cryptoapi.PublicKey, aeadAlg ecdh.AEADAlg)
 		return nil, fmt.Errorf("publicKeyToKeysetHandle: %w", err)
 	}
 
+	if pubKey.Curve == x25519MLKEM768Curve {
+		keyURL = x25519MLKEM768ECDHKWPublicKeyTypeURL
+	}
+
 	protoKey := &ecdhpb.EcdhAeadPublicKey{
 		Version: 0,
 		Params: &ecdhpb.EcdhAeadParams{
@@ -350,6 +377,10 @@ func PrivateKeyToKeysetHandle(privKey *cryptoapi.PrivateKey, aeadAlg ecdh.AEADAl
 		return nil, fmt.Errorf("privateKeyToKeysetHandle: %w", err)
 	}
 
+	if privKey.PublicKey.Curve == x25519MLKEM768Curve {
+		keyURL = x25519MLKEM768ECDHKWPrivateKeyTypeURL
+	}
+
 	protoKey := &ecdhpb.EcdhAeadPrivateKey{
 		Version: 0,
 		PublicKey: &ecdhpb.EcdhAeadPublicKey{
@@ -449,7 +480,7 @@ func getCurveProto(c string) (commonpb.EllipticCurveType, error) {
 		return commonpb.EllipticCurveType_NIST_P384, nil
 	case "secp521r1", "NIST_P521", "P-521", "EllipticCurveType_NIST_P521":
 		return commonpb.EllipticCurveType_NIST_P521, nil
-	case commonpb.EllipticCurveType_CURVE25519.String(), "X25519":
+	case commonpb.EllipticCurveType_CURVE25519.String(), "X25519", x25519MLKEM768Curve:
 		return commonpb.EllipticCurveType_CURVE25519, nil
 	default:
 		return commonpb.EllipticCurveType_UNKNOWN_CURVE, errors.New("unsupported curve")
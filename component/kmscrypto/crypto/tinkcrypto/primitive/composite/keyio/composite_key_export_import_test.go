@@ -53,6 +53,10 @@ func TestPubKeyExport(t *testing.T) {
 			tcName:      "export then read ECDH KW X25519 public recipient key",
 			keyTemplate: ecdh.X25519ECDHKWKeyTemplate(),
 		},
+		{
+			tcName:      "export then read ECDH KW X25519MLKEM768 public recipient key",
+			keyTemplate: ecdh.X25519MLKEM768ECDHKWKeyTemplate(),
+		},
 	}
 
 	for _, tc := range flagTests {
@@ -166,9 +170,12 @@ func testPrivateKeyToKeySetHandle(t *testing.T, privKey *cryptoapi.PrivateKey, a
 	require.NoError(t, err)
 	require.NotEmpty(t, pkh)
 
-	if privKey.PublicKey.Type == "EC" {
+	switch {
+	case privKey.PublicKey.Type == "EC":
 		require.Equal(t, nistPECDHKWPrivateKeyTypeURL, pkh.KeysetInfo().KeyInfo[0].TypeUrl)
-	} else {
+	case privKey.PublicKey.Curve == x25519MLKEM768Curve:
+		require.Equal(t, x25519MLKEM768ECDHKWPrivateKeyTypeURL, pkh.KeysetInfo().KeyInfo[0].TypeUrl)
+	default:
 		require.Equal(t, x25519ECDHKWPrivateKeyTypeURL, pkh.KeysetInfo().KeyInfo[0].TypeUrl)
 	}
 }
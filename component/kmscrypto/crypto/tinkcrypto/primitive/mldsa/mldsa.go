@@ -0,0 +1,29 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package mldsa provides implementations of the Signer and Verifier primitives for ML-DSA (FIPS 204) keys and
+// composite ML-DSA-65 and Ed25519 keys.
+//
+// To sign data using Tink you can use the ML-DSA key templates with tink's signature.NewSigner() and
+// signature.NewVerifier() primitive factories.
+package mldsa
+
+import (
+	"fmt"
+
+	"github.com/google/tink/go/core/registry"
+)
+
+// nolint:gochecknoinits
+func init() {
+	if err := registry.RegisterKeyManager(newMLDSASignerKeyManager()); err != nil {
+		panic(fmt.Sprintf("mldsa.init() failed: %v", err))
+	}
+
+	if err := registry.RegisterKeyManager(newMLDSAVerifierKeyManager()); err != nil {
+		panic(fmt.Sprintf("mldsa.init() failed: %v", err))
+	}
+}
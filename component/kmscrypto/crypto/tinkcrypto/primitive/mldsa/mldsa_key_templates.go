@@ -0,0 +1,69 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mldsa
+
+import (
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"google.golang.org/protobuf/proto"
+
+	mldsapb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/mldsa_go_proto"
+)
+
+// This file contains pre-generated KeyTemplates for Signer and Verifier.
+// One can use these templates to generate new Keysets.
+
+// MLDSA44KeyTemplate is a KeyTemplate that generates a new ML-DSA-44 private key with the following parameters:
+//   - Parameter set: ML-DSA-44
+//   - Output prefix type: RAW
+func MLDSA44KeyTemplate() (*tinkpb.KeyTemplate, error) {
+	return createKeyTemplate(mldsapb.MLDSAParameterSet_MLDSA_44, mldsapb.MLDSACompositeType_NO_MLDSA_COMPOSITE)
+}
+
+// MLDSA65KeyTemplate is a KeyTemplate that generates a new ML-DSA-65 private key with the following parameters:
+//   - Parameter set: ML-DSA-65
+//   - Output prefix type: RAW
+func MLDSA65KeyTemplate() (*tinkpb.KeyTemplate, error) {
+	return createKeyTemplate(mldsapb.MLDSAParameterSet_MLDSA_65, mldsapb.MLDSACompositeType_NO_MLDSA_COMPOSITE)
+}
+
+// MLDSA87KeyTemplate is a KeyTemplate that generates a new ML-DSA-87 private key with the following parameters:
+//   - Parameter set: ML-DSA-87
+//   - Output prefix type: RAW
+func MLDSA87KeyTemplate() (*tinkpb.KeyTemplate, error) {
+	return createKeyTemplate(mldsapb.MLDSAParameterSet_MLDSA_87, mldsapb.MLDSACompositeType_NO_MLDSA_COMPOSITE)
+}
+
+// MLDSA65Ed25519KeyTemplate is a KeyTemplate that generates a new composite ML-DSA-65 and Ed25519 private key with
+// the following parameters:
+//   - Parameter set: ML-DSA-65
+//   - Composite: Ed25519
+//   - Output prefix type: RAW
+func MLDSA65Ed25519KeyTemplate() (*tinkpb.KeyTemplate, error) {
+	return createKeyTemplate(mldsapb.MLDSAParameterSet_MLDSA_65, mldsapb.MLDSACompositeType_MLDSA_COMPOSITE_ED25519)
+}
+
+// createKeyTemplate creates a KeyTemplate containing a MLDSAKeyFormat with the given parameters.
+func createKeyTemplate(parameterSet mldsapb.MLDSAParameterSet,
+	composite mldsapb.MLDSACompositeType) (*tinkpb.KeyTemplate, error) {
+	format := &mldsapb.MLDSAKeyFormat{
+		Params: &mldsapb.MLDSAParams{
+			ParameterSet: parameterSet,
+			Composite:    composite,
+		},
+	}
+
+	serializedFormat, err := proto.Marshal(format)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tinkpb.KeyTemplate{
+		TypeUrl:          mldsaSignerTypeURL,
+		Value:            serializedFormat,
+		OutputPrefixType: tinkpb.OutputPrefixType_RAW,
+	}, nil
+}
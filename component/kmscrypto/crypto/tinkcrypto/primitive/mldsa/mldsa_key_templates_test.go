@@ -0,0 +1,59 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mldsa_test
+
+import (
+	"testing"
+
+	"github.com/google/tink/go/keyset"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"github.com/google/tink/go/signature"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/mldsa"
+)
+
+func TestKeyTemplates(t *testing.T) {
+	testCases := []struct {
+		name     string
+		template func() (*tinkpb.KeyTemplate, error)
+	}{
+		{name: "ML-DSA-44", template: mldsa.MLDSA44KeyTemplate},
+		{name: "ML-DSA-65", template: mldsa.MLDSA65KeyTemplate},
+		{name: "ML-DSA-87", template: mldsa.MLDSA87KeyTemplate},
+		{name: "ML-DSA-65 with Ed25519", template: mldsa.MLDSA65Ed25519KeyTemplate},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			template, err := tc.template()
+			require.NoError(t, err)
+
+			kh, err := keyset.NewHandle(template)
+			require.NoError(t, err)
+
+			signer, err := signature.NewSigner(kh)
+			require.NoError(t, err)
+
+			pubKH, err := kh.Public()
+			require.NoError(t, err)
+
+			verifier, err := signature.NewVerifier(pubKH)
+			require.NoError(t, err)
+
+			data := []byte("data to sign")
+
+			sig, err := signer.Sign(data)
+			require.NoError(t, err)
+
+			require.NoError(t, verifier.Verify(sig, data))
+			require.Error(t, verifier.Verify(sig, []byte("other data")))
+		})
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mldsa
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/tink/go/keyset"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/mldsa/subtle"
+	mldsapb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/mldsa_go_proto"
+)
+
+const (
+	mldsaSignerKeyVersion = 0
+	mldsaSignerTypeURL    = "type.hyperledger.org/hyperledger.aries.crypto.tink.MLDSAPrivateKey"
+)
+
+// common errors.
+var (
+	errInvalidMLDSASignKey       = errors.New("mldsa_signer_key_manager: invalid key")
+	errInvalidMLDSASignKeyFormat = errors.New("mldsa_signer_key_manager: invalid key format")
+)
+
+// mldsaSignerKeyManager is an implementation of KeyManager interface.
+// It generates new MLDSAPrivateKeys and produces new instances of MLDSASigner subtle.
+type mldsaSignerKeyManager struct{}
+
+// newMLDSASignerKeyManager creates a new mldsaSignerKeyManager.
+func newMLDSASignerKeyManager() *mldsaSignerKeyManager {
+	return new(mldsaSignerKeyManager)
+}
+
+// Primitive creates an MLDSASigner subtle for the given serialized MLDSAPrivateKey proto.
+func (km *mldsaSignerKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidMLDSASignKey
+	}
+
+	key := new(mldsapb.MLDSAPrivateKey)
+	if err := proto.Unmarshal(serializedKey, key); err != nil {
+		return nil, errInvalidMLDSASignKey
+	}
+
+	if err := km.validateKey(key); err != nil {
+		return nil, err
+	}
+
+	parameterSet, composite := getMLDSAParamNames(key.PublicKey.Params)
+
+	ret, err := subtle.NewMLDSASigner(parameterSet, composite, key.KeyValue)
+	if err != nil {
+		return nil, fmt.Errorf("mldsa_signer_key_manager: %w", err)
+	}
+
+	return ret, nil
+}
+
+// NewKey creates a new MLDSAPrivateKey according to specification the given serialized MLDSAKeyFormat.
+func (km *mldsaSignerKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	if len(serializedKeyFormat) == 0 {
+		return nil, errInvalidMLDSASignKeyFormat
+	}
+
+	keyFormat := new(mldsapb.MLDSAKeyFormat)
+	if err := proto.Unmarshal(serializedKeyFormat, keyFormat); err != nil {
+		return nil, fmt.Errorf("mldsa_signer_key_manager: invalid proto: %w", err)
+	}
+
+	if keyFormat.Params == nil {
+		return nil, errInvalidMLDSASignKeyFormat
+	}
+
+	parameterSet, composite := getMLDSAParamNames(keyFormat.Params)
+
+	pubKey, privKey, err := subtle.GenerateKey(parameterSet, composite)
+	if err != nil {
+		return nil, fmt.Errorf("mldsa_signer_key_manager: cannot generate ML-DSA key: %w", err)
+	}
+
+	return &mldsapb.MLDSAPrivateKey{
+		Version: mldsaSignerKeyVersion,
+		PublicKey: &mldsapb.MLDSAPublicKey{
+			Version:  mldsaSignerKeyVersion,
+			Params:   keyFormat.Params,
+			KeyValue: pubKey,
+		},
+		KeyValue: privKey,
+	}, nil
+}
+
+// NewKeyData creates a new KeyData according to specification in the given
+// serialized MLDSAKeyFormat. It should be used solely by the key management API.
+func (km *mldsaSignerKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	key, err := km.NewKey(serializedKeyFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	serializedKey, err := proto.Marshal(key)
+	if err != nil {
+		return nil, errInvalidMLDSASignKeyFormat
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         mldsaSignerTypeURL,
+		Value:           serializedKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PRIVATE,
+	}, nil
+}
+
+// PublicKeyData extracts the public key data from the private key.
+func (km *mldsaSignerKeyManager) PublicKeyData(serializedPrivKey []byte) (*tinkpb.KeyData, error) {
+	privKey := new(mldsapb.MLDSAPrivateKey)
+	if err := proto.Unmarshal(serializedPrivKey, privKey); err != nil {
+		return nil, errInvalidMLDSASignKey
+	}
+
+	serializedPubKey, err := proto.Marshal(privKey.PublicKey)
+	if err != nil {
+		return nil, errInvalidMLDSASignKey
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         mldsaVerifierTypeURL,
+		Value:           serializedPubKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PUBLIC,
+	}, nil
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *mldsaSignerKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == mldsaSignerTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *mldsaSignerKeyManager) TypeURL() string {
+	return mldsaSignerTypeURL
+}
+
+// validateKey validates the given MLDSAPrivateKey.
+func (km *mldsaSignerKeyManager) validateKey(key *mldsapb.MLDSAPrivateKey) error {
+	if err := keyset.ValidateKeyVersion(key.Version, mldsaSignerKeyVersion); err != nil {
+		return fmt.Errorf("mldsa_signer_key_manager: invalid key: %w", err)
+	}
+
+	if key.PublicKey == nil || key.PublicKey.Params == nil {
+		return errInvalidMLDSASignKey
+	}
+
+	parameterSet, composite := getMLDSAParamNames(key.PublicKey.Params)
+
+	return subtle.ValidateParams(parameterSet, composite)
+}
+
+// getMLDSAParamNames returns the string representations of each parameter in the given MLDSAParams.
+func getMLDSAParamNames(params *mldsapb.MLDSAParams) (string, string) {
+	parameterSet := mldsapb.MLDSAParameterSet_name[int32(params.ParameterSet)]
+	composite := mldsapb.MLDSACompositeType_name[int32(params.Composite)]
+
+	return parameterSet, composite
+}
@@ -0,0 +1,114 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mldsa
+
+import (
+	"testing"
+
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/mldsa/subtle"
+	mldsapb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/mldsa_go_proto"
+)
+
+func TestMLDSASignerKeyManager(t *testing.T) {
+	km := newMLDSASignerKeyManager()
+
+	require.True(t, km.DoesSupport(mldsaSignerTypeURL))
+	require.Equal(t, mldsaSignerTypeURL, km.TypeURL())
+
+	template, err := MLDSA65Ed25519KeyTemplate()
+	require.NoError(t, err)
+
+	keyData, err := km.NewKeyData(template.Value)
+	require.NoError(t, err)
+	require.Equal(t, mldsaSignerTypeURL, keyData.TypeUrl)
+	require.Equal(t, tinkpb.KeyData_ASYMMETRIC_PRIVATE, keyData.KeyMaterialType)
+
+	p, err := km.Primitive(keyData.Value)
+	require.NoError(t, err)
+	require.IsType(t, &subtle.MLDSASigner{}, p)
+
+	pubKeyData, err := km.PublicKeyData(keyData.Value)
+	require.NoError(t, err)
+	require.Equal(t, mldsaVerifierTypeURL, pubKeyData.TypeUrl)
+	require.Equal(t, tinkpb.KeyData_ASYMMETRIC_PUBLIC, pubKeyData.KeyMaterialType)
+
+	vp, err := newMLDSAVerifierKeyManager().Primitive(pubKeyData.Value)
+	require.NoError(t, err)
+
+	sig, err := p.(*subtle.MLDSASigner).Sign([]byte("data"))
+	require.NoError(t, err)
+	require.NoError(t, vp.(*subtle.MLDSAVerifier).Verify(sig, []byte("data")))
+}
+
+func TestMLDSASignerKeyManagerWithInvalidInput(t *testing.T) {
+	km := newMLDSASignerKeyManager()
+
+	t.Run("invalid key formats", func(t *testing.T) {
+		_, err := km.NewKey(nil)
+		require.ErrorIs(t, err, errInvalidMLDSASignKeyFormat)
+
+		_, err = km.NewKey([]byte("bad format"))
+		require.ErrorContains(t, err, "mldsa_signer_key_manager: invalid proto")
+
+		// composites require ML-DSA-65.
+		format, err := proto.Marshal(&mldsapb.MLDSAKeyFormat{Params: &mldsapb.MLDSAParams{
+			ParameterSet: mldsapb.MLDSAParameterSet_MLDSA_87,
+			Composite:    mldsapb.MLDSACompositeType_MLDSA_COMPOSITE_ED25519,
+		}})
+		require.NoError(t, err)
+
+		_, err = km.NewKeyData(format)
+		require.ErrorContains(t, err, "unsupported composite")
+
+		format, err = proto.Marshal(&mldsapb.MLDSAKeyFormat{Params: &mldsapb.MLDSAParams{}})
+		require.NoError(t, err)
+
+		_, err = km.NewKey(format)
+		require.ErrorContains(t, err, "unsupported parameter set")
+	})
+
+	t.Run("invalid keys", func(t *testing.T) {
+		_, err := km.Primitive(nil)
+		require.ErrorIs(t, err, errInvalidMLDSASignKey)
+
+		_, err = km.Primitive([]byte("bad key"))
+		require.ErrorIs(t, err, errInvalidMLDSASignKey)
+
+		template, err := MLDSA44KeyTemplate()
+		require.NoError(t, err)
+
+		key, err := km.NewKey(template.Value)
+		require.NoError(t, err)
+
+		privKey, ok := key.(*mldsapb.MLDSAPrivateKey)
+		require.True(t, ok)
+
+		privKey.Version = mldsaSignerKeyVersion + 1
+
+		serializedKey, err := proto.Marshal(privKey)
+		require.NoError(t, err)
+
+		_, err = km.Primitive(serializedKey)
+		require.ErrorContains(t, err, "mldsa_signer_key_manager: invalid key")
+
+		privKey.Version = mldsaSignerKeyVersion
+		privKey.KeyValue = privKey.KeyValue[1:]
+
+		serializedKey, err = proto.Marshal(privKey)
+		require.NoError(t, err)
+
+		_, err = km.Primitive(serializedKey)
+		require.ErrorContains(t, err, "mldsa_signer: invalid private key")
+
+		_, err = km.PublicKeyData([]byte("bad key"))
+		require.ErrorIs(t, err, errInvalidMLDSASignKey)
+	})
+}
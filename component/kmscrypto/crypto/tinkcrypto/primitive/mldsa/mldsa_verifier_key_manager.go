@@ -0,0 +1,99 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mldsa
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/tink/go/keyset"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/mldsa/subtle"
+	mldsapb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/mldsa_go_proto"
+)
+
+const (
+	mldsaVerifierKeyVersion = 0
+	mldsaVerifierTypeURL    = "type.hyperledger.org/hyperledger.aries.crypto.tink.MLDSAPublicKey"
+)
+
+// common errors.
+var (
+	errInvalidMLDSAVerifierKey     = errors.New("mldsa_verifier_key_manager: invalid key")
+	errMLDSAVerifierNotImplemented = errors.New("mldsa_verifier_key_manager: not implemented")
+)
+
+// mldsaVerifierKeyManager is an implementation of KeyManager interface.
+// It doesn't support key generation.
+type mldsaVerifierKeyManager struct{}
+
+// newMLDSAVerifierKeyManager creates a new mldsaVerifierKeyManager.
+func newMLDSAVerifierKeyManager() *mldsaVerifierKeyManager {
+	return new(mldsaVerifierKeyManager)
+}
+
+// Primitive creates an MLDSAVerifier subtle for the given serialized MLDSAPublicKey proto.
+func (km *mldsaVerifierKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidMLDSAVerifierKey
+	}
+
+	key := new(mldsapb.MLDSAPublicKey)
+	if err := proto.Unmarshal(serializedKey, key); err != nil {
+		return nil, errInvalidMLDSAVerifierKey
+	}
+
+	if err := km.validateKey(key); err != nil {
+		return nil, fmt.Errorf("mldsa_verifier_key_manager: %w", err)
+	}
+
+	parameterSet, composite := getMLDSAParamNames(key.Params)
+
+	ret, err := subtle.NewMLDSAVerifier(parameterSet, composite, key.KeyValue)
+	if err != nil {
+		return nil, fmt.Errorf("mldsa_verifier_key_manager: invalid key: %w", err)
+	}
+
+	return ret, nil
+}
+
+// NewKey is not implemented.
+func (km *mldsaVerifierKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	return nil, errMLDSAVerifierNotImplemented
+}
+
+// NewKeyData is not implemented.
+func (km *mldsaVerifierKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	return nil, errMLDSAVerifierNotImplemented
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *mldsaVerifierKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == mldsaVerifierTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *mldsaVerifierKeyManager) TypeURL() string {
+	return mldsaVerifierTypeURL
+}
+
+// validateKey validates the given MLDSAPublicKey.
+func (km *mldsaVerifierKeyManager) validateKey(key *mldsapb.MLDSAPublicKey) error {
+	if err := keyset.ValidateKeyVersion(key.Version, mldsaVerifierKeyVersion); err != nil {
+		return fmt.Errorf("mldsa_verifier_key_manager: %w", err)
+	}
+
+	if key.Params == nil {
+		return errInvalidMLDSAVerifierKey
+	}
+
+	parameterSet, composite := getMLDSAParamNames(key.Params)
+
+	return subtle.ValidateParams(parameterSet, composite)
+}
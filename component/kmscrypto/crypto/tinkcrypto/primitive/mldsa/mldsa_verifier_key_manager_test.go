@@ -0,0 +1,50 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mldsa
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	mldsapb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/mldsa_go_proto"
+)
+
+func TestMLDSAVerifierKeyManagerWithInvalidInput(t *testing.T) {
+	km := newMLDSAVerifierKeyManager()
+
+	require.True(t, km.DoesSupport(mldsaVerifierTypeURL))
+	require.Equal(t, mldsaVerifierTypeURL, km.TypeURL())
+
+	_, err := km.NewKey(nil)
+	require.ErrorIs(t, err, errMLDSAVerifierNotImplemented)
+
+	_, err = km.NewKeyData(nil)
+	require.ErrorIs(t, err, errMLDSAVerifierNotImplemented)
+
+	_, err = km.Primitive(nil)
+	require.ErrorIs(t, err, errInvalidMLDSAVerifierKey)
+
+	_, err = km.Primitive([]byte("bad key"))
+	require.ErrorIs(t, err, errInvalidMLDSAVerifierKey)
+
+	serializedKey, err := proto.Marshal(&mldsapb.MLDSAPublicKey{
+		Version: mldsaVerifierKeyVersion,
+		Params:  &mldsapb.MLDSAParams{ParameterSet: mldsapb.MLDSAParameterSet_MLDSA_65},
+	})
+	require.NoError(t, err)
+
+	_, err = km.Primitive(serializedKey)
+	require.ErrorContains(t, err, "mldsa_verifier_key_manager: invalid key")
+
+	serializedKey, err = proto.Marshal(&mldsapb.MLDSAPublicKey{Version: mldsaVerifierKeyVersion})
+	require.NoError(t, err)
+
+	_, err = km.Primitive(serializedKey)
+	require.ErrorIs(t, err, errInvalidMLDSAVerifierKey)
+}
@@ -0,0 +1,56 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subtle
+
+import (
+	"crypto"
+	"crypto/rand"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsa"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsaed25519"
+)
+
+// MLDSASigner is an implementation of Signer for ML-DSA and composite ML-DSA keys. Messages are signed directly,
+// with an empty context.
+type MLDSASigner struct {
+	privateKey crypto.Signer
+}
+
+// NewMLDSASigner creates a new instance of MLDSASigner from the private key seed keyValue.
+func NewMLDSASigner(parameterSet, composite string, keyValue []byte) (*MLDSASigner, error) {
+	err := ValidateParams(parameterSet, composite)
+	if err != nil {
+		return nil, fmt.Errorf("mldsa_signer: %w", err)
+	}
+
+	var privKey crypto.Signer
+
+	if composite == CompositeEd25519 {
+		privKey, err = mldsaed25519.NewPrivateKey(keyValue)
+	} else {
+		params, _ := getParameters(parameterSet) //nolint:errcheck // validated above
+
+		privKey, err = mldsa.NewPrivateKey(params, keyValue)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("mldsa_signer: invalid private key: %w", err)
+	}
+
+	return &MLDSASigner{privateKey: privKey}, nil
+}
+
+// Sign computes a signature for the given data.
+func (s *MLDSASigner) Sign(data []byte) ([]byte, error) {
+	sig, err := s.privateKey.Sign(rand.Reader, data, crypto.Hash(0))
+	if err != nil {
+		return nil, fmt.Errorf("mldsa_signer: signing failed: %w", err)
+	}
+
+	return sig, nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subtle_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/mldsa/subtle"
+)
+
+func TestMLDSASignVerify(t *testing.T) {
+	tcs := []struct {
+		parameterSet string
+		composite    string
+	}{
+		{subtle.MLDSA44, subtle.NoComposite},
+		{subtle.MLDSA65, subtle.NoComposite},
+		{subtle.MLDSA87, subtle.NoComposite},
+		{subtle.MLDSA65, subtle.CompositeEd25519},
+	}
+
+	data := []byte("data to sign")
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.parameterSet+" "+tc.composite, func(t *testing.T) {
+			pubKey, privKey, err := subtle.GenerateKey(tc.parameterSet, tc.composite)
+			require.NoError(t, err)
+
+			signer, err := subtle.NewMLDSASigner(tc.parameterSet, tc.composite, privKey)
+			require.NoError(t, err)
+
+			verifier, err := subtle.NewMLDSAVerifier(tc.parameterSet, tc.composite, pubKey)
+			require.NoError(t, err)
+
+			sig, err := signer.Sign(data)
+			require.NoError(t, err)
+
+			require.NoError(t, verifier.Verify(sig, data))
+			require.EqualError(t, verifier.Verify(sig, []byte("other data")), "mldsa_verifier: invalid signature")
+			require.EqualError(t, verifier.Verify(sig[1:], data), "mldsa_verifier: invalid signature")
+		})
+	}
+}
+
+func TestMLDSAInvalidParams(t *testing.T) {
+	_, _, err := subtle.GenerateKey("MLDSA_128", subtle.NoComposite)
+	require.EqualError(t, err, "unsupported parameter set: MLDSA_128")
+
+	_, _, err = subtle.GenerateKey(subtle.MLDSA44, subtle.CompositeEd25519)
+	require.EqualError(t, err, "unsupported composite: MLDSA_44 with MLDSA_COMPOSITE_ED25519")
+
+	_, _, err = subtle.GenerateKey(subtle.MLDSA44, "RSA")
+	require.EqualError(t, err, "unsupported composite: RSA")
+
+	_, err = subtle.NewMLDSASigner(subtle.MLDSA44, subtle.NoComposite, []byte("bad seed"))
+	require.Error(t, err)
+
+	_, err = subtle.NewMLDSAVerifier(subtle.MLDSA65, subtle.CompositeEd25519, []byte("bad key"))
+	require.Error(t, err)
+
+	_, err = subtle.NewMLDSAVerifier("", subtle.NoComposite, nil)
+	require.EqualError(t, err, "mldsa_verifier: unsupported parameter set: ")
+}
@@ -0,0 +1,66 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package subtle
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsa"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsaed25519"
+)
+
+var errInvalidSignature = errors.New("mldsa_verifier: invalid signature")
+
+// MLDSAVerifier is an implementation of Verifier for ML-DSA and composite ML-DSA keys.
+type MLDSAVerifier struct {
+	publicKey          *mldsa.PublicKey
+	compositePublicKey *mldsaed25519.PublicKey
+}
+
+// NewMLDSAVerifier creates a new instance of MLDSAVerifier from the public key encoding keyValue.
+func NewMLDSAVerifier(parameterSet, composite string, keyValue []byte) (*MLDSAVerifier, error) {
+	err := ValidateParams(parameterSet, composite)
+	if err != nil {
+		return nil, fmt.Errorf("mldsa_verifier: %w", err)
+	}
+
+	if composite == CompositeEd25519 {
+		pubKey, e := mldsaed25519.NewPublicKey(keyValue)
+		if e != nil {
+			return nil, fmt.Errorf("mldsa_verifier: invalid public key: %w", e)
+		}
+
+		return &MLDSAVerifier{compositePublicKey: pubKey}, nil
+	}
+
+	params, _ := getParameters(parameterSet) //nolint:errcheck // validated above
+
+	pubKey, err := mldsa.NewPublicKey(params, keyValue)
+	if err != nil {
+		return nil, fmt.Errorf("mldsa_verifier: invalid public key: %w", err)
+	}
+
+	return &MLDSAVerifier{publicKey: pubKey}, nil
+}
+
+// Verify verifies whether the given signature is valid for the given data.
+func (v *MLDSAVerifier) Verify(signature, data []byte) error {
+	var err error
+
+	if v.compositePublicKey != nil {
+		err = mldsaed25519.Verify(v.compositePublicKey, data, signature)
+	} else {
+		err = mldsa.Verify(v.publicKey, data, signature, nil)
+	}
+
+	if err != nil {
+		return errInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package subtle provides subtle implementations of the ML-DSA and composite ML-DSA digital signature primitives.
+package subtle
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsa"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsaed25519"
+)
+
+const (
+	// MLDSA44 is the name of the ML-DSA-44 parameter set.
+	MLDSA44 = "MLDSA_44"
+	// MLDSA65 is the name of the ML-DSA-65 parameter set.
+	MLDSA65 = "MLDSA_65"
+	// MLDSA87 is the name of the ML-DSA-87 parameter set.
+	MLDSA87 = "MLDSA_87"
+
+	// NoComposite is the composite type name of ML-DSA only keys.
+	NoComposite = "NO_MLDSA_COMPOSITE"
+	// CompositeEd25519 is the composite type name of ML-DSA and Ed25519 keys.
+	CompositeEd25519 = "MLDSA_COMPOSITE_ED25519"
+)
+
+// ValidateParams validates the ML-DSA parameter set and composite type names. The only supported composite is
+// ML-DSA-65 with Ed25519.
+func ValidateParams(parameterSet, composite string) error {
+	_, err := getParameters(parameterSet)
+	if err != nil {
+		return err
+	}
+
+	switch composite {
+	case NoComposite:
+	case CompositeEd25519:
+		if parameterSet != MLDSA65 {
+			return fmt.Errorf("unsupported composite: %s with %s", parameterSet, composite)
+		}
+	default:
+		return fmt.Errorf("unsupported composite: %s", composite)
+	}
+
+	return nil
+}
+
+// GenerateKey generates a new key pair of the ML-DSA parameter set and composite type.
+// returns:
+//
+//	the public key encoding
+//	the private key seed
+//	error in case of errors
+func GenerateKey(parameterSet, composite string) ([]byte, []byte, error) {
+	err := ValidateParams(parameterSet, composite)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if composite == CompositeEd25519 {
+		privKey, e := mldsaed25519.GenerateKey()
+		if e != nil {
+			return nil, nil, e
+		}
+
+		return privKey.PublicKey().Bytes(), privKey.Bytes(), nil
+	}
+
+	params, err := getParameters(parameterSet)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	privKey, err := mldsa.GenerateKey(params)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate ML-DSA key: %w", err)
+	}
+
+	return privKey.PublicKey().Bytes(), privKey.Bytes(), nil
+}
+
+func getParameters(parameterSet string) (mldsa.Parameters, error) {
+	switch parameterSet {
+	case MLDSA44:
+		return mldsa.MLDSA44(), nil
+	case MLDSA65:
+		return mldsa.MLDSA65(), nil
+	case MLDSA87:
+		return mldsa.MLDSA87(), nil
+	default:
+		return mldsa.Parameters{}, fmt.Errorf("unsupported parameter set: %s", parameterSet)
+	}
+}
@@ -0,0 +1,520 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        (unknown)
+// source: proto/mldsa.proto
+
+package mldsa_go_proto
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MLDSAParameterSet int32
+
+const (
+	MLDSAParameterSet_UNKNOWN_MLDSA_PARAMETER_SET MLDSAParameterSet = 0
+	MLDSAParameterSet_MLDSA_44                    MLDSAParameterSet = 1
+	MLDSAParameterSet_MLDSA_65                    MLDSAParameterSet = 2
+	MLDSAParameterSet_MLDSA_87                    MLDSAParameterSet = 3
+)
+
+// Enum value maps for MLDSAParameterSet.
+var (
+	MLDSAParameterSet_name = map[int32]string{
+		0: "UNKNOWN_MLDSA_PARAMETER_SET",
+		1: "MLDSA_44",
+		2: "MLDSA_65",
+		3: "MLDSA_87",
+	}
+	MLDSAParameterSet_value = map[string]int32{
+		"UNKNOWN_MLDSA_PARAMETER_SET": 0,
+		"MLDSA_44":                    1,
+		"MLDSA_65":                    2,
+		"MLDSA_87":                    3,
+	}
+)
+
+func (x MLDSAParameterSet) Enum() *MLDSAParameterSet {
+	p := new(MLDSAParameterSet)
+	*p = x
+	return p
+}
+
+func (x MLDSAParameterSet) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MLDSAParameterSet) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_mldsa_proto_enumTypes[0].Descriptor()
+}
+
+func (MLDSAParameterSet) Type() protoreflect.EnumType {
+	return &file_proto_mldsa_proto_enumTypes[0]
+}
+
+func (x MLDSAParameterSet) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MLDSAParameterSet.Descriptor instead.
+func (MLDSAParameterSet) EnumDescriptor() ([]byte, []int) {
+	return file_proto_mldsa_proto_rawDescGZIP(), []int{0}
+}
+
+type MLDSACompositeType int32
+
+const (
+	MLDSACompositeType_NO_MLDSA_COMPOSITE      MLDSACompositeType = 0
+	MLDSACompositeType_MLDSA_COMPOSITE_ED25519 MLDSACompositeType = 1
+)
+
+// Enum value maps for MLDSACompositeType.
+var (
+	MLDSACompositeType_name = map[int32]string{
+		0: "NO_MLDSA_COMPOSITE",
+		1: "MLDSA_COMPOSITE_ED25519",
+	}
+	MLDSACompositeType_value = map[string]int32{
+		"NO_MLDSA_COMPOSITE":      0,
+		"MLDSA_COMPOSITE_ED25519": 1,
+	}
+)
+
+func (x MLDSACompositeType) Enum() *MLDSACompositeType {
+	p := new(MLDSACompositeType)
+	*p = x
+	return p
+}
+
+func (x MLDSACompositeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MLDSACompositeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_mldsa_proto_enumTypes[1].Descriptor()
+}
+
+func (MLDSACompositeType) Type() protoreflect.EnumType {
+	return &file_proto_mldsa_proto_enumTypes[1]
+}
+
+func (x MLDSACompositeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MLDSACompositeType.Descriptor instead.
+func (MLDSACompositeType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_mldsa_proto_rawDescGZIP(), []int{1}
+}
+
+type MLDSAParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ParameterSet MLDSAParameterSet  `protobuf:"varint,1,opt,name=parameter_set,json=parameterSet,proto3,enum=google.crypto.tink.MLDSAParameterSet" json:"parameter_set,omitempty"`
+	Composite    MLDSACompositeType `protobuf:"varint,2,opt,name=composite,proto3,enum=google.crypto.tink.MLDSACompositeType" json:"composite,omitempty"`
+}
+
+func (x *MLDSAParams) Reset() {
+	*x = MLDSAParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_mldsa_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MLDSAParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MLDSAParams) ProtoMessage() {}
+
+func (x *MLDSAParams) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mldsa_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MLDSAParams.ProtoReflect.Descriptor instead.
+func (*MLDSAParams) Descriptor() ([]byte, []int) {
+	return file_proto_mldsa_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MLDSAParams) GetParameterSet() MLDSAParameterSet {
+	if x != nil {
+		return x.ParameterSet
+	}
+	return MLDSAParameterSet_UNKNOWN_MLDSA_PARAMETER_SET
+}
+
+func (x *MLDSAParams) GetComposite() MLDSACompositeType {
+	if x != nil {
+		return x.Composite
+	}
+	return MLDSACompositeType_NO_MLDSA_COMPOSITE
+}
+
+type MLDSAPublicKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version  uint32       `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Params   *MLDSAParams `protobuf:"bytes,2,opt,name=params,proto3" json:"params,omitempty"`
+	KeyValue []byte       `protobuf:"bytes,3,opt,name=key_value,json=keyValue,proto3" json:"key_value,omitempty"`
+}
+
+func (x *MLDSAPublicKey) Reset() {
+	*x = MLDSAPublicKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_mldsa_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MLDSAPublicKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MLDSAPublicKey) ProtoMessage() {}
+
+func (x *MLDSAPublicKey) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mldsa_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MLDSAPublicKey.ProtoReflect.Descriptor instead.
+func (*MLDSAPublicKey) Descriptor() ([]byte, []int) {
+	return file_proto_mldsa_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MLDSAPublicKey) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *MLDSAPublicKey) GetParams() *MLDSAParams {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *MLDSAPublicKey) GetKeyValue() []byte {
+	if x != nil {
+		return x.KeyValue
+	}
+	return nil
+}
+
+type MLDSAPrivateKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version   uint32          `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	PublicKey *MLDSAPublicKey `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	KeyValue  []byte          `protobuf:"bytes,3,opt,name=key_value,json=keyValue,proto3" json:"key_value,omitempty"`
+}
+
+func (x *MLDSAPrivateKey) Reset() {
+	*x = MLDSAPrivateKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_mldsa_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MLDSAPrivateKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MLDSAPrivateKey) ProtoMessage() {}
+
+func (x *MLDSAPrivateKey) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mldsa_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MLDSAPrivateKey.ProtoReflect.Descriptor instead.
+func (*MLDSAPrivateKey) Descriptor() ([]byte, []int) {
+	return file_proto_mldsa_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MLDSAPrivateKey) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *MLDSAPrivateKey) GetPublicKey() *MLDSAPublicKey {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *MLDSAPrivateKey) GetKeyValue() []byte {
+	if x != nil {
+		return x.KeyValue
+	}
+	return nil
+}
+
+type MLDSAKeyFormat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Params *MLDSAParams `protobuf:"bytes,1,opt,name=params,proto3" json:"params,omitempty"`
+}
+
+func (x *MLDSAKeyFormat) Reset() {
+	*x = MLDSAKeyFormat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_mldsa_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MLDSAKeyFormat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MLDSAKeyFormat) ProtoMessage() {}
+
+func (x *MLDSAKeyFormat) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_mldsa_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MLDSAKeyFormat.ProtoReflect.Descriptor instead.
+func (*MLDSAKeyFormat) Descriptor() ([]byte, []int) {
+	return file_proto_mldsa_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MLDSAKeyFormat) GetParams() *MLDSAParams {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+var File_proto_mldsa_proto protoreflect.FileDescriptor
+
+var file_proto_mldsa_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x6c, 0x64, 0x73, 0x61, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x12, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x72, 0x79, 0x70,
+	0x74, 0x6f, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x22, 0x9f, 0x01, 0x0a, 0x0b, 0x4d, 0x4c, 0x44, 0x53,
+	0x41, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x4a, 0x0a, 0x0d, 0x70, 0x61, 0x72, 0x61, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x74,
+	0x69, 0x6e, 0x6b, 0x2e, 0x4d, 0x4c, 0x44, 0x53, 0x41, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x53, 0x65, 0x74, 0x52, 0x0c, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x53, 0x65, 0x74, 0x12, 0x44, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x4d, 0x4c, 0x44, 0x53,
+	0x41, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09,
+	0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x22, 0x80, 0x01, 0x0a, 0x0e, 0x4d, 0x4c,
+	0x44, 0x53, 0x41, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x4d, 0x4c, 0x44, 0x53,
+	0x41, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12,
+	0x1b, 0x0a, 0x09, 0x6b, 0x65, 0x79, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x08, 0x6b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x8b, 0x01, 0x0a,
+	0x0f, 0x4d, 0x4c, 0x44, 0x53, 0x41, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x41, 0x0a, 0x0a, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x74,
+	0x69, 0x6e, 0x6b, 0x2e, 0x4d, 0x4c, 0x44, 0x53, 0x41, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b,
+	0x65, 0x79, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x12, 0x1b, 0x0a,
+	0x09, 0x6b, 0x65, 0x79, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x08, 0x6b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x49, 0x0a, 0x0e, 0x4d, 0x4c,
+	0x44, 0x53, 0x41, 0x4b, 0x65, 0x79, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x37, 0x0a, 0x06,
+	0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x74, 0x69, 0x6e,
+	0x6b, 0x2e, 0x4d, 0x4c, 0x44, 0x53, 0x41, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x2a, 0x5e, 0x0a, 0x11, 0x4d, 0x4c, 0x44, 0x53, 0x41, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x53, 0x65, 0x74, 0x12, 0x1f, 0x0a, 0x1b, 0x55, 0x4e,
+	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x4d, 0x4c, 0x44, 0x53, 0x41, 0x5f, 0x50, 0x41, 0x52, 0x41,
+	0x4d, 0x45, 0x54, 0x45, 0x52, 0x5f, 0x53, 0x45, 0x54, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x4d,
+	0x4c, 0x44, 0x53, 0x41, 0x5f, 0x34, 0x34, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x4d, 0x4c, 0x44,
+	0x53, 0x41, 0x5f, 0x36, 0x35, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x4d, 0x4c, 0x44, 0x53, 0x41,
+	0x5f, 0x38, 0x37, 0x10, 0x03, 0x2a, 0x49, 0x0a, 0x12, 0x4d, 0x4c, 0x44, 0x53, 0x41, 0x43, 0x6f,
+	0x6d, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x12, 0x4e,
+	0x4f, 0x5f, 0x4d, 0x4c, 0x44, 0x53, 0x41, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x4f, 0x53, 0x49, 0x54,
+	0x45, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x4d, 0x4c, 0x44, 0x53, 0x41, 0x5f, 0x43, 0x4f, 0x4d,
+	0x50, 0x4f, 0x53, 0x49, 0x54, 0x45, 0x5f, 0x45, 0x44, 0x32, 0x35, 0x35, 0x31, 0x39, 0x10, 0x01,
+	0x42, 0x99, 0x01, 0x0a, 0x1c, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2e, 0x74, 0x69, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x50, 0x01, 0x5a, 0x6e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x68, 0x79, 0x70, 0x65, 0x72, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2f, 0x61, 0x72, 0x69, 0x65,
+	0x73, 0x2d, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x2d, 0x67, 0x6f, 0x2f, 0x63,
+	0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x2f, 0x6b, 0x6d, 0x73, 0x63, 0x72, 0x79, 0x70,
+	0x74, 0x6f, 0x2f, 0x63, 0x72, 0x79, 0x70, 0x74, 0x6f, 0x2f, 0x74, 0x69, 0x6e, 0x6b, 0x63, 0x72,
+	0x79, 0x70, 0x74, 0x6f, 0x2f, 0x70, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6d, 0x6c, 0x64, 0x73, 0x61, 0x5f, 0x67, 0x6f, 0x5f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0xa2, 0x02, 0x06, 0x54, 0x49, 0x4e, 0x4b, 0x50, 0x42, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_mldsa_proto_rawDescOnce sync.Once
+	file_proto_mldsa_proto_rawDescData = file_proto_mldsa_proto_rawDesc
+)
+
+func file_proto_mldsa_proto_rawDescGZIP() []byte {
+	file_proto_mldsa_proto_rawDescOnce.Do(func() {
+		file_proto_mldsa_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_mldsa_proto_rawDescData)
+	})
+	return file_proto_mldsa_proto_rawDescData
+}
+
+var file_proto_mldsa_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_mldsa_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_mldsa_proto_goTypes = []interface{}{
+	(MLDSAParameterSet)(0),  // 0: google.crypto.tink.MLDSAParameterSet
+	(MLDSACompositeType)(0), // 1: google.crypto.tink.MLDSACompositeType
+	(*MLDSAParams)(nil),     // 2: google.crypto.tink.MLDSAParams
+	(*MLDSAPublicKey)(nil),  // 3: google.crypto.tink.MLDSAPublicKey
+	(*MLDSAPrivateKey)(nil), // 4: google.crypto.tink.MLDSAPrivateKey
+	(*MLDSAKeyFormat)(nil),  // 5: google.crypto.tink.MLDSAKeyFormat
+}
+var file_proto_mldsa_proto_depIdxs = []int32{
+	0, // 0: google.crypto.tink.MLDSAParams.parameter_set:type_name -> google.crypto.tink.MLDSAParameterSet
+	1, // 1: google.crypto.tink.MLDSAParams.composite:type_name -> google.crypto.tink.MLDSACompositeType
+	2, // 2: google.crypto.tink.MLDSAPublicKey.params:type_name -> google.crypto.tink.MLDSAParams
+	3, // 3: google.crypto.tink.MLDSAPrivateKey.public_key:type_name -> google.crypto.tink.MLDSAPublicKey
+	2, // 4: google.crypto.tink.MLDSAKeyFormat.params:type_name -> google.crypto.tink.MLDSAParams
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_proto_mldsa_proto_init() }
+func file_proto_mldsa_proto_init() {
+	if File_proto_mldsa_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_mldsa_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MLDSAParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_mldsa_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MLDSAPublicKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_mldsa_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MLDSAPrivateKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_mldsa_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MLDSAKeyFormat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_mldsa_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_mldsa_proto_goTypes,
+		DependencyIndexes: file_proto_mldsa_proto_depIdxs,
+		EnumInfos:         file_proto_mldsa_proto_enumTypes,
+		MessageInfos:      file_proto_mldsa_proto_msgTypes,
+	}.Build()
+	File_proto_mldsa_proto = out.File
+	file_proto_mldsa_proto_rawDesc = nil
+	file_proto_mldsa_proto_goTypes = nil
+	file_proto_mldsa_proto_depIdxs = nil
+}
@@ -0,0 +1,31 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package rsaoaep provides the key managers of RSA-OAEP decryption keys, as used by the JWE 'RSA-OAEP' and
+// 'RSA-OAEP-256' key management algorithms.
+//
+// Tink has no RSA encryption keys: the keys are serialized with Tink's RsaSsaPkcs1 key protos under their own type
+// URLs. The hash type of their params is not used, the OAEP hash is set by the key management algorithm. The primitive
+// of private keys is their *rsa.PrivateKey and the primitive of public keys is their *rsa.PublicKey, to be used with
+// the tinkcrypto Crypto's WrapKey() and UnwrapKey() functions.
+package rsaoaep
+
+import (
+	"fmt"
+
+	"github.com/google/tink/go/core/registry"
+)
+
+// nolint:gochecknoinits
+func init() {
+	if err := registry.RegisterKeyManager(newRSAOAEPPrivateKeyManager()); err != nil {
+		panic(fmt.Sprintf("rsaoaep.init() failed: %v", err))
+	}
+
+	if err := registry.RegisterKeyManager(newRSAOAEPPublicKeyManager()); err != nil {
+		panic(fmt.Sprintf("rsaoaep.init() failed: %v", err))
+	}
+}
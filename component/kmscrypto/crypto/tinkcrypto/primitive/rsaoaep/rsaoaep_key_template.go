@@ -0,0 +1,44 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rsaoaep
+
+import (
+	"math/big"
+
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	rsapb "github.com/google/tink/go/proto/rsa_ssa_pkcs1_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	rsaOAEP3072ModulusSize = 3072
+	rsaOAEPPublicExponent  = 65537
+)
+
+// RSAOAEP3072KeyTemplate is a KeyTemplate that generates a new RSA-OAEP private key with the following parameters:
+//   - Modulus size: 3072 bits
+//   - Public exponent: 65537
+//   - Output prefix type: RAW
+func RSAOAEP3072KeyTemplate() (*tinkpb.KeyTemplate, error) {
+	format := &rsapb.RsaSsaPkcs1KeyFormat{
+		Params:            &rsapb.RsaSsaPkcs1Params{HashType: commonpb.HashType_SHA256},
+		ModulusSizeInBits: rsaOAEP3072ModulusSize,
+		PublicExponent:    big.NewInt(rsaOAEPPublicExponent).Bytes(),
+	}
+
+	serializedFormat, err := proto.Marshal(format)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tinkpb.KeyTemplate{
+		TypeUrl:          rsaOAEPPrivateKeyTypeURL,
+		Value:            serializedFormat,
+		OutputPrefixType: tinkpb.OutputPrefixType_RAW,
+	}, nil
+}
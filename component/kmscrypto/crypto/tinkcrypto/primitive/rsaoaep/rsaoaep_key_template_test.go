@@ -0,0 +1,53 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rsaoaep_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/google/tink/go/keyset"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/rsaoaep"
+)
+
+func TestRSAOAEP3072KeyTemplate(t *testing.T) {
+	template, err := rsaoaep.RSAOAEP3072KeyTemplate()
+	require.NoError(t, err)
+
+	kh, err := keyset.NewHandle(template)
+	require.NoError(t, err)
+
+	ps, err := kh.Primitives()
+	require.NoError(t, err)
+
+	privKey, ok := ps.Primary.Primitive.(*rsa.PrivateKey)
+	require.True(t, ok)
+	require.Equal(t, 3072, privKey.N.BitLen())
+
+	pubKH, err := kh.Public()
+	require.NoError(t, err)
+
+	ps, err = pubKH.Primitives()
+	require.NoError(t, err)
+
+	pubKey, ok := ps.Primary.Primitive.(*rsa.PublicKey)
+	require.True(t, ok)
+	require.True(t, pubKey.Equal(&privKey.PublicKey))
+
+	cek := []byte("0123456789abcdef0123456789abcdef")
+
+	ct, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pubKey, cek, nil)
+	require.NoError(t, err)
+
+	pt, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, privKey, ct, nil)
+	require.NoError(t, err)
+	require.Equal(t, cek, pt)
+}
@@ -0,0 +1,174 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rsaoaep
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/google/tink/go/keyset"
+	rsapb "github.com/google/tink/go/proto/rsa_ssa_pkcs1_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	rsaOAEPPrivateKeyVersion = 0
+	rsaOAEPPrivateKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.RsaOaepPrivateKey"
+	// minModulusSize is the minimum RSA modulus size in bits.
+	minModulusSize = 2048
+)
+
+// common errors.
+var (
+	errInvalidRSAOAEPPrivateKey       = errors.New("rsaoaep_private_key_manager: invalid key")
+	errInvalidRSAOAEPPrivateKeyFormat = errors.New("rsaoaep_private_key_manager: invalid key format")
+)
+
+// rsaOAEPPrivateKeyManager is an implementation of PrivateKeyManager interface.
+// It generates new RsaOaepPrivateKeys and produces new instances of *rsa.PrivateKey.
+type rsaOAEPPrivateKeyManager struct{}
+
+// newRSAOAEPPrivateKeyManager creates a new rsaOAEPPrivateKeyManager.
+func newRSAOAEPPrivateKeyManager() *rsaOAEPPrivateKeyManager {
+	return new(rsaOAEPPrivateKeyManager)
+}
+
+// Primitive creates an *rsa.PrivateKey for the given serialized RsaOaepPrivateKey proto.
+func (km *rsaOAEPPrivateKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidRSAOAEPPrivateKey
+	}
+
+	key := new(rsapb.RsaSsaPkcs1PrivateKey)
+	if err := proto.Unmarshal(serializedKey, key); err != nil {
+		return nil, errInvalidRSAOAEPPrivateKey
+	}
+
+	if err := keyset.ValidateKeyVersion(key.Version, rsaOAEPPrivateKeyVersion); err != nil {
+		return nil, fmt.Errorf("rsaoaep_private_key_manager: invalid key: %w", err)
+	}
+
+	if key.PublicKey == nil || len(key.D) == 0 || len(key.P) == 0 || len(key.Q) == 0 {
+		return nil, errInvalidRSAOAEPPrivateKey
+	}
+
+	pubKey, err := newRSAPublicKey(key.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("rsaoaep_private_key_manager: %w", err)
+	}
+
+	privKey := &rsa.PrivateKey{
+		PublicKey: *pubKey,
+		D:         new(big.Int).SetBytes(key.D),
+		Primes:    []*big.Int{new(big.Int).SetBytes(key.P), new(big.Int).SetBytes(key.Q)},
+	}
+
+	if err = privKey.Validate(); err != nil {
+		return nil, fmt.Errorf("rsaoaep_private_key_manager: invalid key: %w", err)
+	}
+
+	privKey.Precompute()
+
+	return privKey, nil
+}
+
+// NewKey creates a new RsaOaepPrivateKey according to specification the given serialized RsaSsaPkcs1KeyFormat.
+func (km *rsaOAEPPrivateKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	if len(serializedKeyFormat) == 0 {
+		return nil, errInvalidRSAOAEPPrivateKeyFormat
+	}
+
+	keyFormat := new(rsapb.RsaSsaPkcs1KeyFormat)
+	if err := proto.Unmarshal(serializedKeyFormat, keyFormat); err != nil {
+		return nil, fmt.Errorf("rsaoaep_private_key_manager: invalid proto: %w", err)
+	}
+
+	if keyFormat.ModulusSizeInBits < minModulusSize ||
+		new(big.Int).SetBytes(keyFormat.PublicExponent).Cmp(big.NewInt(rsaOAEPPublicExponent)) != 0 {
+		return nil, errInvalidRSAOAEPPrivateKeyFormat
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, int(keyFormat.ModulusSizeInBits))
+	if err != nil {
+		return nil, fmt.Errorf("rsaoaep_private_key_manager: cannot generate RSA key: %w", err)
+	}
+
+	return NewPrivateKeyProto(rsaKey), nil
+}
+
+// NewKeyData creates a new KeyData according to specification in the given
+// serialized RsaSsaPkcs1KeyFormat. It should be used solely by the key management API.
+func (km *rsaOAEPPrivateKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	key, err := km.NewKey(serializedKeyFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	serializedKey, err := proto.Marshal(key)
+	if err != nil {
+		return nil, errInvalidRSAOAEPPrivateKeyFormat
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         rsaOAEPPrivateKeyTypeURL,
+		Value:           serializedKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PRIVATE,
+	}, nil
+}
+
+// PublicKeyData extracts the public key data from the private key.
+func (km *rsaOAEPPrivateKeyManager) PublicKeyData(serializedPrivKey []byte) (*tinkpb.KeyData, error) {
+	privKey := new(rsapb.RsaSsaPkcs1PrivateKey)
+	if err := proto.Unmarshal(serializedPrivKey, privKey); err != nil {
+		return nil, errInvalidRSAOAEPPrivateKey
+	}
+
+	if privKey.PublicKey == nil {
+		return nil, errInvalidRSAOAEPPrivateKey
+	}
+
+	serializedPubKey, err := proto.Marshal(privKey.PublicKey)
+	if err != nil {
+		return nil, errInvalidRSAOAEPPrivateKey
+	}
+
+	return &tinkpb.KeyData{
+		TypeUrl:         rsaOAEPPublicKeyTypeURL,
+		Value:           serializedPubKey,
+		KeyMaterialType: tinkpb.KeyData_ASYMMETRIC_PUBLIC,
+	}, nil
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *rsaOAEPPrivateKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == rsaOAEPPrivateKeyTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *rsaOAEPPrivateKeyManager) TypeURL() string {
+	return rsaOAEPPrivateKeyTypeURL
+}
+
+// NewPrivateKeyProto returns the RsaOaepPrivateKey proto of privKey, to import it in a keyset.
+func NewPrivateKeyProto(privKey *rsa.PrivateKey) *rsapb.RsaSsaPkcs1PrivateKey {
+	privKey.Precompute()
+
+	return &rsapb.RsaSsaPkcs1PrivateKey{
+		Version:   rsaOAEPPrivateKeyVersion,
+		PublicKey: NewPublicKeyProto(&privKey.PublicKey),
+		D:         privKey.D.Bytes(),
+		P:         privKey.Primes[0].Bytes(),
+		Q:         privKey.Primes[1].Bytes(),
+		Dp:        privKey.Precomputed.Dp.Bytes(),
+		Dq:        privKey.Precomputed.Dq.Bytes(),
+		Crt:       privKey.Precomputed.Qinv.Bytes(),
+	}
+}
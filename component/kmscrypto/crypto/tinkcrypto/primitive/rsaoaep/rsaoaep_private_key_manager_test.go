@@ -0,0 +1,112 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rsaoaep
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"math/big"
+	"testing"
+
+	rsapb "github.com/google/tink/go/proto/rsa_ssa_pkcs1_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRSAOAEPPrivateKeyManager(t *testing.T) {
+	km := newRSAOAEPPrivateKeyManager()
+
+	require.True(t, km.DoesSupport(rsaOAEPPrivateKeyTypeURL))
+	require.Equal(t, rsaOAEPPrivateKeyTypeURL, km.TypeURL())
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, minModulusSize)
+	require.NoError(t, err)
+
+	serializedKey, err := proto.Marshal(NewPrivateKeyProto(rsaKey))
+	require.NoError(t, err)
+
+	p, err := km.Primitive(serializedKey)
+	require.NoError(t, err)
+	require.True(t, rsaKey.Equal(p))
+
+	pubKeyData, err := km.PublicKeyData(serializedKey)
+	require.NoError(t, err)
+	require.Equal(t, rsaOAEPPublicKeyTypeURL, pubKeyData.TypeUrl)
+	require.Equal(t, tinkpb.KeyData_ASYMMETRIC_PUBLIC, pubKeyData.KeyMaterialType)
+
+	pubKey, err := newRSAOAEPPublicKeyManager().Primitive(pubKeyData.Value)
+	require.NoError(t, err)
+	require.True(t, rsaKey.PublicKey.Equal(pubKey))
+}
+
+func TestRSAOAEPPrivateKeyManagerWithInvalidInput(t *testing.T) {
+	km := newRSAOAEPPrivateKeyManager()
+
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := km.Primitive(nil)
+		require.ErrorIs(t, err, errInvalidRSAOAEPPrivateKey)
+
+		_, err = km.Primitive([]byte("bad key"))
+		require.ErrorIs(t, err, errInvalidRSAOAEPPrivateKey)
+
+		serializedKey, err := proto.Marshal(&rsapb.RsaSsaPkcs1PrivateKey{Version: rsaOAEPPrivateKeyVersion})
+		require.NoError(t, err)
+
+		_, err = km.Primitive(serializedKey)
+		require.ErrorIs(t, err, errInvalidRSAOAEPPrivateKey)
+
+		_, err = km.PublicKeyData(serializedKey)
+		require.ErrorIs(t, err, errInvalidRSAOAEPPrivateKey)
+
+		rsaKey, err := rsa.GenerateKey(rand.Reader, minModulusSize)
+		require.NoError(t, err)
+
+		keyProto := NewPrivateKeyProto(rsaKey)
+		keyProto.D = big.NewInt(1).Bytes()
+
+		serializedKey, err = proto.Marshal(keyProto)
+		require.NoError(t, err)
+
+		_, err = km.Primitive(serializedKey)
+		require.ErrorContains(t, err, "rsaoaep_private_key_manager: invalid key")
+
+		keyProto.Version = rsaOAEPPrivateKeyVersion + 1
+
+		serializedKey, err = proto.Marshal(keyProto)
+		require.NoError(t, err)
+
+		_, err = km.Primitive(serializedKey)
+		require.ErrorContains(t, err, "rsaoaep_private_key_manager: invalid key")
+	})
+
+	t.Run("invalid key format", func(t *testing.T) {
+		_, err := km.NewKey(nil)
+		require.ErrorIs(t, err, errInvalidRSAOAEPPrivateKeyFormat)
+
+		_, err = km.NewKeyData([]byte("bad format"))
+		require.ErrorContains(t, err, "rsaoaep_private_key_manager: invalid proto")
+
+		serializedFormat, err := proto.Marshal(&rsapb.RsaSsaPkcs1KeyFormat{
+			ModulusSizeInBits: 1024,
+			PublicExponent:    big.NewInt(rsaOAEPPublicExponent).Bytes(),
+		})
+		require.NoError(t, err)
+
+		_, err = km.NewKey(serializedFormat)
+		require.ErrorIs(t, err, errInvalidRSAOAEPPrivateKeyFormat)
+
+		serializedFormat, err = proto.Marshal(&rsapb.RsaSsaPkcs1KeyFormat{
+			ModulusSizeInBits: minModulusSize,
+			PublicExponent:    big.NewInt(3).Bytes(),
+		})
+		require.NoError(t, err)
+
+		_, err = km.NewKey(serializedFormat)
+		require.ErrorIs(t, err, errInvalidRSAOAEPPrivateKeyFormat)
+	})
+}
@@ -0,0 +1,109 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rsaoaep
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/google/tink/go/keyset"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	rsapb "github.com/google/tink/go/proto/rsa_ssa_pkcs1_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	rsaOAEPPublicKeyVersion = 0
+	rsaOAEPPublicKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.RsaOaepPublicKey"
+)
+
+// common errors.
+var (
+	errInvalidRSAOAEPPublicKey     = errors.New("rsaoaep_public_key_manager: invalid key")
+	errRSAOAEPPublicNotImplemented = errors.New("rsaoaep_public_key_manager: not implemented")
+)
+
+// rsaOAEPPublicKeyManager is an implementation of KeyManager interface.
+// It doesn't support key generation.
+type rsaOAEPPublicKeyManager struct{}
+
+// newRSAOAEPPublicKeyManager creates a new rsaOAEPPublicKeyManager.
+func newRSAOAEPPublicKeyManager() *rsaOAEPPublicKeyManager {
+	return new(rsaOAEPPublicKeyManager)
+}
+
+// Primitive creates an *rsa.PublicKey for the given serialized RsaOaepPublicKey proto.
+func (km *rsaOAEPPublicKeyManager) Primitive(serializedKey []byte) (interface{}, error) {
+	if len(serializedKey) == 0 {
+		return nil, errInvalidRSAOAEPPublicKey
+	}
+
+	key := new(rsapb.RsaSsaPkcs1PublicKey)
+	if err := proto.Unmarshal(serializedKey, key); err != nil {
+		return nil, errInvalidRSAOAEPPublicKey
+	}
+
+	pubKey, err := newRSAPublicKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("rsaoaep_public_key_manager: %w", err)
+	}
+
+	return pubKey, nil
+}
+
+// NewKey is not implemented.
+func (km *rsaOAEPPublicKeyManager) NewKey(serializedKeyFormat []byte) (proto.Message, error) {
+	return nil, errRSAOAEPPublicNotImplemented
+}
+
+// NewKeyData is not implemented.
+func (km *rsaOAEPPublicKeyManager) NewKeyData(serializedKeyFormat []byte) (*tinkpb.KeyData, error) {
+	return nil, errRSAOAEPPublicNotImplemented
+}
+
+// DoesSupport indicates if this key manager supports the given key type.
+func (km *rsaOAEPPublicKeyManager) DoesSupport(typeURL string) bool {
+	return typeURL == rsaOAEPPublicKeyTypeURL
+}
+
+// TypeURL returns the key type of keys managed by this key manager.
+func (km *rsaOAEPPublicKeyManager) TypeURL() string {
+	return rsaOAEPPublicKeyTypeURL
+}
+
+// NewPublicKeyProto returns the RsaOaepPublicKey proto of pubKey.
+func NewPublicKeyProto(pubKey *rsa.PublicKey) *rsapb.RsaSsaPkcs1PublicKey {
+	return &rsapb.RsaSsaPkcs1PublicKey{
+		Version: rsaOAEPPublicKeyVersion,
+		Params:  &rsapb.RsaSsaPkcs1Params{HashType: commonpb.HashType_SHA256},
+		N:       pubKey.N.Bytes(),
+		E:       big.NewInt(int64(pubKey.E)).Bytes(),
+	}
+}
+
+// newRSAPublicKey validates key and returns its *rsa.PublicKey.
+func newRSAPublicKey(key *rsapb.RsaSsaPkcs1PublicKey) (*rsa.PublicKey, error) {
+	if err := keyset.ValidateKeyVersion(key.Version, rsaOAEPPublicKeyVersion); err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+
+	n := new(big.Int).SetBytes(key.N)
+	e := new(big.Int).SetBytes(key.E)
+
+	if n.BitLen() < minModulusSize {
+		return nil, fmt.Errorf("invalid key: modulus size %d is smaller than %d bits", n.BitLen(), minModulusSize)
+	}
+
+	if !e.IsInt64() || e.Int64() < 3 || e.Bit(0) == 0 { // nolint:gomnd // smallest valid RSA exponent.
+		return nil, errors.New("invalid key: bad public exponent")
+	}
+
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
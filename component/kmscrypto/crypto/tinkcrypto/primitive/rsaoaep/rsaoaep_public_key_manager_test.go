@@ -0,0 +1,57 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rsaoaep
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRSAOAEPPublicKeyManagerWithInvalidInput(t *testing.T) {
+	km := newRSAOAEPPublicKeyManager()
+
+	require.True(t, km.DoesSupport(rsaOAEPPublicKeyTypeURL))
+	require.Equal(t, rsaOAEPPublicKeyTypeURL, km.TypeURL())
+
+	_, err := km.NewKey(nil)
+	require.ErrorIs(t, err, errRSAOAEPPublicNotImplemented)
+
+	_, err = km.NewKeyData(nil)
+	require.ErrorIs(t, err, errRSAOAEPPublicNotImplemented)
+
+	_, err = km.Primitive(nil)
+	require.ErrorIs(t, err, errInvalidRSAOAEPPublicKey)
+
+	_, err = km.Primitive([]byte("bad key"))
+	require.ErrorIs(t, err, errInvalidRSAOAEPPublicKey)
+
+	smallKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	serializedKey, err := proto.Marshal(NewPublicKeyProto(&smallKey.PublicKey))
+	require.NoError(t, err)
+
+	_, err = km.Primitive(serializedKey)
+	require.ErrorContains(t, err, "modulus size 1024 is smaller than 2048 bits")
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, minModulusSize)
+	require.NoError(t, err)
+
+	keyProto := NewPublicKeyProto(&rsaKey.PublicKey)
+	keyProto.E = big.NewInt(4).Bytes()
+
+	serializedKey, err = proto.Marshal(keyProto)
+	require.NoError(t, err)
+
+	_, err = km.Primitive(serializedKey)
+	require.ErrorContains(t, err, "bad public exponent")
+}
@@ -0,0 +1,58 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tinkcrypto
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/google/tink/go/keyset"
+	"github.com/google/tink/go/streamingaead"
+)
+
+// NewEncryptingWriter returns a writer encrypting the plaintext written to it with aad using the streaming AEAD
+// primitive in kh key handle (eg: an AES256GCMHKDF1MB kms key) and writing the ciphertext to w. The writer must be
+// closed to write the last segment of the ciphertext.
+func (t *Crypto) NewEncryptingWriter(w io.Writer, aad []byte, kh interface{}) (io.WriteCloser, error) {
+	keyHandle, ok := kh.(*keyset.Handle)
+	if !ok {
+		return nil, errBadKeyHandleFormat
+	}
+
+	s, err := streamingaead.New(keyHandle)
+	if err != nil {
+		return nil, fmt.Errorf("create new streaming aead: %w", err)
+	}
+
+	ew, err := s.NewEncryptingWriter(w, aad)
+	if err != nil {
+		return nil, fmt.Errorf("create encrypting writer: %w", err)
+	}
+
+	return ew, nil
+}
+
+// NewDecryptingReader returns a reader decrypting the ciphertext read from r with aad using the streaming AEAD
+// primitive in kh key handle. Read returns an error if the ciphertext was modified or truncated.
+func (t *Crypto) NewDecryptingReader(r io.Reader, aad []byte, kh interface{}) (io.Reader, error) {
+	keyHandle, ok := kh.(*keyset.Handle)
+	if !ok {
+		return nil, errBadKeyHandleFormat
+	}
+
+	s, err := streamingaead.New(keyHandle)
+	if err != nil {
+		return nil, fmt.Errorf("create new streaming aead: %w", err)
+	}
+
+	dr, err := s.NewDecryptingReader(r, aad)
+	if err != nil {
+		return nil, fmt.Errorf("create decrypting reader: %w", err)
+	}
+
+	return dr, nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tinkcrypto
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	tinkaead "github.com/google/tink/go/aead"
+	"github.com/google/tink/go/keyset"
+	"github.com/google/tink/go/streamingaead"
+	"github.com/google/tink/go/subtle/random"
+	"github.com/stretchr/testify/require"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/spi/crypto"
+)
+
+// Assert that Crypto implements the StreamingCrypto interface.
+var _ cryptoapi.StreamingCrypto = (*Crypto)(nil)
+
+func TestCrypto_StreamingEncryptDecrypt(t *testing.T) {
+	c := Crypto{}
+	aad := []byte("attachment id")
+
+	kh, err := keyset.NewHandle(streamingaead.AES256GCMHKDF4KBKeyTemplate())
+	require.NoError(t, err)
+
+	// spans several 4KB segments.
+	plaintext := random.GetRandomBytes(10*4096 + 123)
+
+	ciphertext := new(bytes.Buffer)
+
+	w, err := c.NewEncryptingWriter(ciphertext, aad, kh)
+	require.NoError(t, err)
+
+	_, err = io.Copy(w, bytes.NewReader(plaintext))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	r, err := c.NewDecryptingReader(bytes.NewReader(ciphertext.Bytes()), aad, kh)
+	require.NoError(t, err)
+
+	decrypted, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, decrypted)
+
+	t.Run("modified, truncated or other aad ciphertexts fail", func(t *testing.T) {
+		modified := append([]byte{}, ciphertext.Bytes()...)
+		modified[len(modified)/2] ^= 1
+
+		for name, test := range map[string]struct {
+			ciphertext []byte
+			aad        []byte
+		}{
+			"modified":  {modified, aad},
+			"truncated": {ciphertext.Bytes()[:ciphertext.Len()-4096], aad},
+			"other aad": {ciphertext.Bytes(), []byte("other id")},
+		} {
+			r, e := c.NewDecryptingReader(bytes.NewReader(test.ciphertext), test.aad, kh)
+			require.NoError(t, e, name)
+
+			_, e = io.ReadAll(r)
+			require.Error(t, e, name)
+		}
+	})
+
+	t.Run("bad key handles", func(t *testing.T) {
+		_, err = c.NewEncryptingWriter(ciphertext, aad, "bad")
+		require.ErrorIs(t, err, errBadKeyHandleFormat)
+
+		_, err = c.NewDecryptingReader(ciphertext, aad, "bad")
+		require.ErrorIs(t, err, errBadKeyHandleFormat)
+
+		aeadKH, e := keyset.NewHandle(tinkaead.AES256GCMKeyTemplate())
+		require.NoError(t, e)
+
+		_, err = c.NewEncryptingWriter(ciphertext, aad, aeadKH)
+		require.Contains(t, err.Error(), "create new streaming aead")
+
+		_, err = c.NewDecryptingReader(ciphertext, aad, aeadKH)
+		require.Contains(t, err.Error(), "create new streaming aead")
+	})
+}
@@ -20,6 +20,7 @@ import (
 	commonpb "github.com/google/tink/go/proto/common_go_proto"
 	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
 
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/x25519mlkem768"
 	ecdhpb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
 )
 
@@ -76,6 +77,15 @@ func extractPrivKey(kh *keyset.Handle) (interface{}, error) {
 		}
 
 		return pbKey.KeyValue, nil
+	case x25519MLKEM768ECDHKWPrivateKeyTypeURL:
+		pbKey := new(ecdhpb.EcdhAeadPrivateKey)
+
+		err = proto.Unmarshal(primaryKey.KeyData.Value, pbKey)
+		if err != nil {
+			return nil, errors.New("extractPrivKey: invalid key in keyset")
+		}
+
+		return x25519mlkem768.NewPrivateKey(pbKey.KeyValue)
 	}
 
 	return nil, fmt.Errorf("extractPrivKey: can't extract unsupported private key '%s'", primaryKey.KeyData.TypeUrl)
@@ -0,0 +1,251 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webkms
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/spi/crypto"
+)
+
+const (
+	signBatchURI   = "/signbatch"
+	verifyBatchURI = "/verifybatch"
+	wrapBatchURI   = "/wrapbatch"
+	unwrapBatchURI = "/unwrapbatch"
+)
+
+type signBatchReq struct {
+	Messages [][]byte `json:"messages"`
+}
+
+type signBatchResp struct {
+	Signatures [][]byte `json:"signatures"`
+}
+
+type verifyBatchReq struct {
+	Signatures [][]byte `json:"signatures"`
+	Messages   [][]byte `json:"messages"`
+}
+
+// wrapBatchReq serializable WrapKeys request.
+type wrapBatchReq struct {
+	CEK              []byte                 `json:"cek"`
+	APU              []byte                 `json:"apu"`
+	APV              []byte                 `json:"apv"`
+	RecipientPubKeys []*cryptoapi.PublicKey `json:"recipient_pub_keys"`
+	Tag              []byte                 `json:"tag,omitempty"`
+}
+
+// wrapBatchResp serializable WrapKeys response.
+type wrapBatchResp struct {
+	WrappedKeys []*cryptoapi.RecipientWrappedKey `json:"wrapped_keys"`
+}
+
+// unwrapBatchReq serializable UnwrapKeys request.
+type unwrapBatchReq struct {
+	WrappedKeys  []*cryptoapi.RecipientWrappedKey `json:"wrapped_keys"`
+	SenderPubKey *cryptoapi.PublicKey             `json:"sender_pub_key,omitempty"`
+	Tag          []byte                           `json:"tag,omitempty"`
+}
+
+// unwrapBatchResp serializable UnwrapKeys response.
+type unwrapBatchResp struct {
+	Keys [][]byte `json:"keys"`
+}
+
+// SignBatch will remotely sign each message of msgs using a matching signature primitive in remote kh key handle at
+// keyURL of a private key, in a single request.
+// returns:
+//
+//	signatures in [][]byte, in the order of msgs
+//	error in case of errors
+func (r *RemoteCrypto) SignBatch(msgs [][]byte, keyURL interface{}) ([][]byte, error) {
+	startSign := time.Now()
+	destination := fmt.Sprintf("%s", keyURL) + signBatchURI
+
+	httpResp := &signBatchResp{}
+
+	err := r.postBatch(destination, "SignBatch", &signBatchReq{Messages: msgs}, httpResp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(httpResp.Signatures) != len(msgs) {
+		return nil, fmt.Errorf("SignBatch returned %d signatures for %d messages [%s]", len(httpResp.Signatures),
+			len(msgs), destination)
+	}
+
+	logger.Debugf("overall SignBatch duration: %s", time.Since(startSign))
+
+	return httpResp.Signatures, nil
+}
+
+// VerifyBatch will remotely verify each signature of signatures for the message of msgs at the same index using a
+// matching signature primitive in a remote key handle at keyURL of a public key, in a single request.
+// returns:
+//
+//	error in case of errors or nil if all signature verifications were successful
+func (r *RemoteCrypto) VerifyBatch(signatures, msgs [][]byte, keyURL interface{}) error {
+	startVerify := time.Now()
+	destination := fmt.Sprintf("%s", keyURL) + verifyBatchURI
+
+	if len(signatures) != len(msgs) {
+		return fmt.Errorf("VerifyBatch: %d signatures for %d messages", len(signatures), len(msgs))
+	}
+
+	err := r.postBatch(destination, "VerifyBatch", &verifyBatchReq{Signatures: signatures, Messages: msgs}, nil)
+	if err != nil {
+		return err
+	}
+
+	logger.Debugf("overall VerifyBatch duration: %s", time.Since(startVerify))
+
+	return nil
+}
+
+// WrapKeys will remotely execute key wrapping of cek using apu, apv for each recipient public key of 'recPubKeys', in
+// a single request. 'opts' is the same as for WrapKey.
+// returns:
+//
+//	RecipientWrappedKeys containing the wrapped cek values, in the order of recPubKeys
+//	error in case of errors
+func (r *RemoteCrypto) WrapKeys(cek, apu, apv []byte, recPubKeys []*cryptoapi.PublicKey,
+	opts ...cryptoapi.WrapKeyOpts) ([]*cryptoapi.RecipientWrappedKey, error) {
+	startWrapKeys := time.Now()
+	destination := r.keystoreURL + wrapBatchURI
+
+	pOpts := cryptoapi.NewOpt()
+
+	for _, opt := range opts {
+		opt(pOpts)
+	}
+
+	if senderURL := pOpts.SenderKey(); senderURL != nil {
+		senderURLStr, ok := senderURL.(string)
+		if !ok {
+			return nil, fmt.Errorf("wrapKeys invalid senderKey type, should be string with key URL")
+		}
+
+		// if senderURL is set, add its keyID to the request url (for ECDH-1PU wrapping)
+		if senderURLStr != "" {
+			destination = r.keystoreURL + keysURI + "/" + senderURLStr[strings.LastIndex(senderURLStr, "/")+1:] +
+				wrapBatchURI
+		}
+	}
+
+	httpResp := &wrapBatchResp{}
+
+	err := r.postBatch(destination, "WrapKeys", &wrapBatchReq{
+		CEK:              cek,
+		APU:              apu,
+		APV:              apv,
+		RecipientPubKeys: recPubKeys,
+		Tag:              pOpts.Tag(),
+	}, httpResp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(httpResp.WrappedKeys) != len(recPubKeys) {
+		return nil, fmt.Errorf("WrapKeys returned %d wrapped keys for %d recipients [%s]",
+			len(httpResp.WrappedKeys), len(recPubKeys), destination)
+	}
+
+	logger.Debugf("overall WrapKeys duration: %s", time.Since(startWrapKeys))
+
+	return httpResp.WrappedKeys, nil
+}
+
+// UnwrapKeys remotely unwraps each key of recWKs using recipient private key found at keyURL, in a single request.
+// 'opts' is the same as for UnwrapKey.
+// returns:
+//
+//	unwrapped keys in raw bytes, in the order of recWKs
+//	error in case of errors
+func (r *RemoteCrypto) UnwrapKeys(recWKs []*cryptoapi.RecipientWrappedKey, keyURL interface{},
+	opts ...cryptoapi.WrapKeyOpts) ([][]byte, error) {
+	startUnwrapKeys := time.Now()
+	destination := fmt.Sprintf("%s", keyURL) + unwrapBatchURI
+
+	pOpts := cryptoapi.NewOpt()
+
+	for _, opt := range opts {
+		opt(pOpts)
+	}
+
+	uReq := &unwrapBatchReq{
+		WrappedKeys: recWKs,
+		Tag:         pOpts.Tag(),
+	}
+
+	if pOpts.SenderKey() != nil {
+		sk, err := ksToCryptoPublicKey(pOpts.SenderKey())
+		if err != nil {
+			return nil, err
+		}
+
+		uReq.SenderPubKey = sk
+	}
+
+	httpResp := &unwrapBatchResp{}
+
+	err := r.postBatch(destination, "UnwrapKeys", uReq, httpResp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(httpResp.Keys) != len(recWKs) {
+		return nil, fmt.Errorf("UnwrapKeys returned %d keys for %d wrapped keys [%s]", len(httpResp.Keys),
+			len(recWKs), destination)
+	}
+
+	logger.Debugf("overall UnwrapKeys duration: %s", time.Since(startUnwrapKeys))
+
+	return httpResp.Keys, nil
+}
+
+// postBatch posts the batch request req of operation op to destination and reads its response in httpResp (if not
+// nil).
+func (r *RemoteCrypto) postBatch(destination, op string, req, httpResp interface{}) error {
+	httpReqBytes, err := r.marshalFunc(req)
+	if err != nil {
+		return fmt.Errorf("marshal request for %s failed [%s, %w]", op, destination, err)
+	}
+
+	resp, err := r.postHTTPRequest(destination, httpReqBytes)
+	if err != nil {
+		return fmt.Errorf("posting %s failed [%s, %w]", op, destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, op)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("posting %s returned http error: %s", op, resp.Status)
+	}
+
+	if httpResp == nil {
+		return nil
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response for %s failed [%s, %w]", op, destination, err)
+	}
+
+	err = r.unmarshalFunc(respBody, httpResp)
+	if err != nil {
+		return fmt.Errorf("unmarshal response for %s failed [%s, %w]", op, destination, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webkms
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/tink/go/subtle/random"
+	"github.com/stretchr/testify/require"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/spi/crypto"
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose"
+	webkmsimpl "github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/webkms"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/webkms/webkmstest"
+)
+
+func TestRemoteCrypto_Batch(t *testing.T) {
+	server, err := webkmstest.NewServer()
+	require.NoError(t, err)
+
+	defer server.Close()
+
+	keystoreURL, _, err := webkmsimpl.CreateKeyStore(server.Client(), server.URL, "controller", "", nil)
+	require.NoError(t, err)
+
+	remoteKMS := webkmsimpl.New(keystoreURL, server.Client())
+	remoteCrypto := New(keystoreURL, server.Client())
+
+	t.Run("sign and verify batch", func(t *testing.T) {
+		_, keyURL, err := remoteKMS.Create(kmsapi.ED25519Type)
+		require.NoError(t, err)
+
+		msgs := [][]byte{[]byte("msg 1"), []byte("msg 2"), []byte("msg 3")}
+		requestCount := server.RequestCount()
+
+		sigs, err := remoteCrypto.SignBatch(msgs, keyURL)
+		require.NoError(t, err)
+		require.Len(t, sigs, len(msgs))
+
+		err = remoteCrypto.VerifyBatch(sigs, msgs, keyURL)
+		require.NoError(t, err)
+		require.Equal(t, requestCount+2, server.RequestCount())
+
+		err = remoteCrypto.Verify(sigs[1], msgs[1], keyURL)
+		require.NoError(t, err)
+
+		err = remoteCrypto.VerifyBatch([][]byte{sigs[0], sigs[2], sigs[1]}, msgs, keyURL)
+		require.EqualError(t, err, "posting VerifyBatch returned http error: 400 Bad Request")
+
+		err = remoteCrypto.VerifyBatch(sigs[:1], msgs, keyURL)
+		require.EqualError(t, err, "VerifyBatch: 1 signatures for 3 messages")
+
+		_, err = remoteCrypto.SignBatch(msgs, keystoreURL+"/keys/missing")
+		require.EqualError(t, err, "posting SignBatch returned http error: 400 Bad Request")
+	})
+
+	t.Run("wrap and unwrap batch", func(t *testing.T) {
+		recKeyURLs, recPubKeys := createRecipientKeys(t, remoteKMS, 3)
+
+		cek := random.GetRandomBytes(32)
+		apu := random.GetRandomBytes(16)
+		apv := random.GetRandomBytes(16)
+
+		wks, err := remoteCrypto.WrapKeys(cek, apu, apv, recPubKeys)
+		require.NoError(t, err)
+		require.Len(t, wks, len(recPubKeys))
+
+		for i, wk := range wks {
+			require.Equal(t, recPubKeys[i].KID, wk.KID)
+
+			key, e := remoteCrypto.UnwrapKey(wk, recKeyURLs[i])
+			require.NoError(t, e)
+			require.Equal(t, cek, key)
+		}
+
+		keys, err := remoteCrypto.UnwrapKeys([]*cryptoapi.RecipientWrappedKey{wks[0], wks[0]}, recKeyURLs[0])
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{cek, cek}, keys)
+
+		_, err = remoteCrypto.UnwrapKeys(wks, recKeyURLs[0])
+		require.EqualError(t, err, "posting UnwrapKeys returned http error: 400 Bad Request")
+	})
+
+	t.Run("wrap and unwrap batch with sender key", func(t *testing.T) {
+		recKeyURLs, recPubKeys := createRecipientKeys(t, remoteKMS, 2)
+		senderKeyURLs, senderPubKeys := createRecipientKeys(t, remoteKMS, 1)
+
+		cek := random.GetRandomBytes(32)
+		tag := random.GetRandomBytes(16)
+
+		wks, err := remoteCrypto.WrapKeys(cek, nil, nil, recPubKeys, cryptoapi.WithSender(senderKeyURLs[0]),
+			cryptoapi.WithTag(tag))
+		require.NoError(t, err)
+		require.Len(t, wks, len(recPubKeys))
+
+		keys, err := remoteCrypto.UnwrapKeys(wks[1:], recKeyURLs[1], cryptoapi.WithSender(senderPubKeys[0]),
+			cryptoapi.WithTag(tag))
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{cek}, keys)
+
+		_, err = remoteCrypto.WrapKeys(cek, nil, nil, recPubKeys, cryptoapi.WithSender(senderPubKeys[0]))
+		require.EqualError(t, err, "wrapKeys invalid senderKey type, should be string with key URL")
+
+		_, err = remoteCrypto.UnwrapKeys(wks, recKeyURLs[1], cryptoapi.WithSender("bad sender"))
+		require.EqualError(t, err, "ksToCryptoPublicKey: unsupported keyset type bad sender")
+	})
+
+	t.Run("JWE encryption with batched key wrapping", func(t *testing.T) {
+		_, recPubKeys := createRecipientKeys(t, remoteKMS, 3)
+
+		jweEncrypter, err := jose.NewJWEEncrypt(jose.A256GCM, "", "", "", nil, recPubKeys, remoteCrypto)
+		require.NoError(t, err)
+
+		requestCount := server.RequestCount()
+
+		jwe, err := jweEncrypter.Encrypt([]byte("secret message"))
+		require.NoError(t, err)
+		require.Len(t, jwe.Recipients, len(recPubKeys))
+		require.Equal(t, requestCount+1, server.RequestCount())
+
+		serializedJWE, err := jwe.FullSerialize(json.Marshal)
+		require.NoError(t, err)
+
+		jwe, err = jose.Deserialize(serializedJWE)
+		require.NoError(t, err)
+
+		plaintext, err := jose.NewJWEDecrypt(nil, remoteCrypto, remoteKMS).Decrypt(jwe)
+		require.NoError(t, err)
+		require.Equal(t, []byte("secret message"), plaintext)
+	})
+
+	t.Run("batch request errors", func(t *testing.T) {
+		_, keyURL, err := remoteKMS.Create(kmsapi.ED25519Type)
+		require.NoError(t, err)
+
+		failingCrypto := New(keystoreURL, server.Client())
+		failingCrypto.marshalFunc = failingMarshal
+
+		_, err = failingCrypto.SignBatch([][]byte{[]byte("msg")}, keyURL)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "marshal request for SignBatch failed")
+
+		failingCrypto = New(keystoreURL, server.Client())
+		failingCrypto.unmarshalFunc = failingUnmarshal
+
+		_, err = failingCrypto.SignBatch([][]byte{[]byte("msg")}, keyURL)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unmarshal response for SignBatch failed")
+
+		_, err = New(keystoreURL, &mockHTTPClient{}).SignBatch([][]byte{[]byte("msg")}, keyURL)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "posting SignBatch failed")
+	})
+}
+
+// createRecipientKeys creates n NISTP256ECDHKW keys with remoteKMS and returns their key URLs and public keys.
+func createRecipientKeys(t *testing.T, remoteKMS *webkmsimpl.RemoteKMS, n int) ([]string, []*cryptoapi.PublicKey) {
+	t.Helper()
+
+	keyURLs := make([]string, n)
+	pubKeys := make([]*cryptoapi.PublicKey, n)
+
+	for i := 0; i < n; i++ {
+		kid, pubKeyBytes, err := remoteKMS.CreateAndExportPubKeyBytes(kmsapi.NISTP256ECDHKWType)
+		require.NoError(t, err)
+
+		pubKeys[i] = &cryptoapi.PublicKey{}
+
+		err = json.Unmarshal(pubKeyBytes, pubKeys[i])
+		require.NoError(t, err)
+
+		pubKeys[i].KID = kid
+
+		keyURL, err := remoteKMS.Get(kid)
+		require.NoError(t, err)
+
+		keyURLs[i] = keyURL.(string)
+	}
+
+	return keyURLs, pubKeys
+}
+
+type mockHTTPClient struct{}
+
+func (m *mockHTTPClient) Do(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
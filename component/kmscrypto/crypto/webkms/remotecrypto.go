@@ -244,18 +244,7 @@ func (r *RemoteCrypto) doHTTPRequest(method, destination string, mReq []byte) (*
 		httpReq.Header.Set("Content-Type", webkmsimpl.ContentType)
 	}
 
-	if r.opts.HeadersFunc != nil {
-		httpHeaders, e := r.opts.HeadersFunc(httpReq)
-		if e != nil {
-			return nil, fmt.Errorf("add optional request headers error: %w", e)
-		}
-
-		if httpHeaders != nil {
-			httpReq.Header = httpHeaders.Clone()
-		}
-	}
-
-	resp, err := r.httpClient.Do(httpReq)
+	resp, err := webkmsimpl.DoHTTPRequest(r.httpClient, httpReq, mReq, r.opts)
 
 	logger.Debugf("  HTTP %s %s call duration: %s", method, destination, time.Since(start))
 
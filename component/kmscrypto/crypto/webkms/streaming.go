@@ -0,0 +1,114 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webkms
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/google/tink/go/streamingaead/subtle"
+	"github.com/google/tink/go/subtle/random"
+)
+
+const (
+	// streamingKeySize and streamingSegmentSize are the parameters of Tink's AES256_GCM_HKDF_1MB streaming keys.
+	streamingKeySize     = 32
+	streamingSegmentSize = 1 << 20
+	streamingHKDFAlg     = "SHA256"
+)
+
+// NewEncryptingWriter returns a writer encrypting the plaintext written to it with aad and writing the ciphertext to
+// w with bounded memory. As streaming the plaintext to the key server is not practical, the plaintext is encrypted
+// locally with a new AES256-GCM-HKDF streaming key (1MB segments) which is encrypted remotely, with aad, by the AEAD key
+// at keyURL. The encrypted streaming key is written ahead of the ciphertext. The writer must be closed to write the
+// last segment of the ciphertext.
+func (r *RemoteCrypto) NewEncryptingWriter(w io.Writer, aad []byte, keyURL interface{}) (io.WriteCloser, error) {
+	key := random.GetRandomBytes(streamingKeySize)
+
+	encKey, nonce, err := r.Encrypt(key, aad, keyURL)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt streaming key: %w", err)
+	}
+
+	for _, field := range [][]byte{nonce, encKey} {
+		err = writeField(w, field)
+		if err != nil {
+			return nil, fmt.Errorf("write encrypted streaming key: %w", err)
+		}
+	}
+
+	s, err := subtle.NewAESGCMHKDF(key, streamingHKDFAlg, streamingKeySize, streamingSegmentSize, 0)
+	if err != nil {
+		return nil, fmt.Errorf("create new streaming aead: %w", err)
+	}
+
+	return s.NewEncryptingWriter(w, aad)
+}
+
+// NewDecryptingReader returns a reader decrypting the ciphertext read from r, as written by NewEncryptingWriter, with
+// aad. The streaming key heading the ciphertext is decrypted remotely by the AEAD key at keyURL. Read returns an error
+// if the ciphertext was modified or truncated.
+func (r *RemoteCrypto) NewDecryptingReader(cipher io.Reader, aad []byte, keyURL interface{}) (io.Reader, error) {
+	nonce, err := readField(cipher)
+	if err != nil {
+		return nil, fmt.Errorf("read encrypted streaming key: %w", err)
+	}
+
+	encKey, err := readField(cipher)
+	if err != nil {
+		return nil, fmt.Errorf("read encrypted streaming key: %w", err)
+	}
+
+	key, err := r.Decrypt(encKey, aad, nonce, keyURL)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt streaming key: %w", err)
+	}
+
+	s, err := subtle.NewAESGCMHKDF(key, streamingHKDFAlg, streamingKeySize, streamingSegmentSize, 0)
+	if err != nil {
+		return nil, fmt.Errorf("create new streaming aead: %w", err)
+	}
+
+	return s.NewDecryptingReader(cipher, aad)
+}
+
+// writeField writes field prefixed with its 2 bytes big endian length.
+func writeField(w io.Writer, field []byte) error {
+	if len(field) > math.MaxUint16 {
+		return errors.New("field too long")
+	}
+
+	_, err := w.Write(binary.BigEndian.AppendUint16(nil, uint16(len(field))))
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(field)
+
+	return err
+}
+
+func readField(r io.Reader) ([]byte, error) {
+	size := make([]byte, 2) // nolint:gomnd // uint16 size.
+
+	_, err := io.ReadFull(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	field := make([]byte, binary.BigEndian.Uint16(size))
+
+	_, err = io.ReadFull(r, field)
+	if err != nil {
+		return nil, err
+	}
+
+	return field, nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webkms
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/tink/go/aead"
+	"github.com/google/tink/go/keyset"
+	"github.com/google/tink/go/subtle/random"
+	"github.com/stretchr/testify/require"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/spi/crypto"
+)
+
+// Assert that RemoteCrypto implements the StreamingCrypto interface.
+var _ cryptoapi.StreamingCrypto = (*RemoteCrypto)(nil)
+
+func TestRemoteCrypto_StreamingEncryptDecrypt(t *testing.T) {
+	kh, err := keyset.NewHandle(aead.AES256GCMKeyTemplate())
+	require.NoError(t, err)
+
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if e := processPOSTEncRequest(w, r, kh); e != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	keystoreURL := server.URL + "/v1/keystores/" + defaultKeyStoreID
+	keyURL := keystoreURL + "/keys/" + defaultKID
+	rCrypto := New(keystoreURL, server.Client())
+	aad := []byte("attachment id")
+
+	// spans several 1MB segments.
+	plaintext := random.GetRandomBytes(2*streamingSegmentSize + 123)
+
+	ciphertext := new(bytes.Buffer)
+
+	w, err := rCrypto.NewEncryptingWriter(ciphertext, aad, keyURL)
+	require.NoError(t, err)
+
+	_, err = io.Copy(w, bytes.NewReader(plaintext))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	r, err := rCrypto.NewDecryptingReader(bytes.NewReader(ciphertext.Bytes()), aad, keyURL)
+	require.NoError(t, err)
+
+	decrypted, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, decrypted)
+
+	// the key server is only called to encrypt and decrypt the streaming key.
+	require.Equal(t, 2, requests)
+
+	t.Run("modified ciphertext fails", func(t *testing.T) {
+		modified := append([]byte{}, ciphertext.Bytes()...)
+		modified[len(modified)-10] ^= 1
+
+		r, e := rCrypto.NewDecryptingReader(bytes.NewReader(modified), aad, keyURL)
+		require.NoError(t, e)
+
+		_, e = io.ReadAll(r)
+		require.Error(t, e)
+	})
+
+	t.Run("other aad fails to decrypt the streaming key", func(t *testing.T) {
+		_, e := rCrypto.NewDecryptingReader(bytes.NewReader(ciphertext.Bytes()), []byte("other id"), keyURL)
+		require.Error(t, e)
+		require.Contains(t, e.Error(), "decrypt streaming key")
+	})
+
+	t.Run("truncated streaming key header fails", func(t *testing.T) {
+		_, e := rCrypto.NewDecryptingReader(bytes.NewReader(ciphertext.Bytes()[:10]), aad, keyURL)
+		require.Error(t, e)
+		require.Contains(t, e.Error(), "read encrypted streaming key")
+	})
+
+	t.Run("key server failure", func(t *testing.T) {
+		failing := New(keystoreURL, &http.Client{})
+
+		_, e := failing.NewEncryptingWriter(ciphertext, aad, "``#$%")
+		require.Error(t, e)
+		require.Contains(t, e.Error(), "encrypt streaming key")
+	})
+}
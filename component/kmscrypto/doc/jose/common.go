@@ -100,6 +100,24 @@ const (
 	A256CBCHS384ALG = "A256CBC-HS384"
 	// A256CBCHS512ALG represents AES_256_CBC_HMAC_SHA_512 encryption algorithm value.
 	A256CBCHS512ALG = "A256CBC-HS512"
+	// A256GCMHKDF1MBALG represents Tink's AES256_GCM_HKDF_1MB streaming content encryption algorithm value used by
+	// streaming JWEs (not defined in JWA spec).
+	A256GCMHKDF1MBALG = "A256GCM-HKDF-1MB"
+)
+
+// JWE key management algorithm values (https://tools.ietf.org/html/rfc7518#section-4.1) other than the ECDH key
+// wrapping ones used by DIDComm.
+const (
+	// ECDHESALG represents the ECDH-ES key agreement in direct mode (the derived key is used as the CEK).
+	ECDHESALG = "ECDH-ES"
+	// RSAOAEPALG represents the RSA-OAEP (SHA-1) key encryption algorithm value (supported for decryption only).
+	RSAOAEPALG = "RSA-OAEP"
+	// RSAOAEP256ALG represents the RSA-OAEP (SHA-256) key encryption algorithm value.
+	RSAOAEP256ALG = "RSA-OAEP-256"
+	// A256KWALG represents the AES-256 Key Wrap algorithm value.
+	A256KWALG = "A256KW"
+	// DIRALG represents the direct use of a shared symmetric key as the CEK.
+	DIRALG = "dir"
 )
 
 var aeadAlg = map[EncAlg]ecdh.AEADAlg{ //nolint:gochecknoglobals
@@ -10,6 +10,7 @@ import (
 	"crypto/ecdsa"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -73,6 +74,20 @@ func (jd *JWEDecrypt) Decrypt(jwe *JSONWebEncryption) ([]byte, error) {
 		return nil, fmt.Errorf("jwedecrypt: %w", err)
 	}
 
+	if alg, ok := jwe.ProtectedHeaders.Algorithm(); ok && alg == DIRALG {
+		return jd.decryptDirect(jwe, encAlg)
+	}
+
+	cek, err := jd.decryptCEK(jwe, encAlg)
+	if err != nil {
+		return nil, fmt.Errorf("jwedecrypt: %w", err)
+	}
+
+	return jd.decryptJWE(jwe, cek)
+}
+
+// decryptCEK unwraps the CEK of jwe with the first recipient key found in the kms.
+func (jd *JWEDecrypt) decryptCEK(jwe *JSONWebEncryption, encAlg string) ([]byte, error) {
 	var wkOpts []cryptoapi.WrapKeyOpts
 
 	skid, ok := jwe.ProtectedHeaders.SenderKeyID()
@@ -83,33 +98,70 @@ func (jd *JWEDecrypt) Decrypt(jwe *JSONWebEncryption) ([]byte, error) {
 	if ok && skid != "" {
 		senderKH, e := jd.fetchSenderPubKey(skid, EncAlg(encAlg))
 		if e != nil {
-			return nil, fmt.Errorf("jwedecrypt: failed to add sender public key for skid: %w", e)
+			return nil, fmt.Errorf("failed to add sender public key for skid: %w", e)
 		}
 
 		wkOpts = append(wkOpts, cryptoapi.WithSender(senderKH), cryptoapi.WithTag([]byte(jwe.Tag)))
 	}
 
+	if alg, ok := jwe.ProtectedHeaders.Algorithm(); ok && alg == ECDHESALG {
+		wkOpts = append(wkOpts, cryptoapi.WithDirectKeyAgreement(encAlg, cekSize(EncAlg(encAlg))))
+	}
+
 	recWK, err := buildRecipientsWrappedKey(jwe)
 	if err != nil {
-		return nil, fmt.Errorf("jwedecrypt: failed to build recipients WK: %w", err)
+		return nil, fmt.Errorf("failed to build recipients WK: %w", err)
 	}
 
 	cek, err := jd.unwrapCEK(recWK, wkOpts...)
 	if err != nil {
-		return nil, fmt.Errorf("jwedecrypt: %w", err)
+		return nil, err
 	}
 
-	if len(recWK) == 1 {
+	if len(recWK) == 1 && hasEPK(&recWK[0].EPK) {
 		// ensure EPK is marshalled the same way as during encryption since it is merged into ProtectHeaders.
 		marshalledEPK, err := convertRecEPKToMarshalledJWK(&recWK[0].EPK)
 		if err != nil {
-			return nil, fmt.Errorf("jwedecrypt: %w", err)
+			return nil, err
 		}
 
 		jwe.ProtectedHeaders["epk"] = json.RawMessage(marshalledEPK)
 	}
 
-	return jd.decryptJWE(jwe, cek)
+	return cek, nil
+}
+
+// decryptDirect decrypts a 'dir' jwe using the kms key of its kid as the CEK.
+func (jd *JWEDecrypt) decryptDirect(jwe *JSONWebEncryption, encAlg string) ([]byte, error) {
+	if encAlg != A256GCMALG {
+		return nil, fmt.Errorf("jwedecrypt: encryption algorithm '%s' not supported with 'dir'", encAlg)
+	}
+
+	kid, ok := jwe.ProtectedHeaders.KeyID()
+	if !ok || kid == "" {
+		return nil, errors.New("jwedecrypt: 'dir' JWE is missing 'kid' header")
+	}
+
+	kh, err := jd.kms.Get(kid)
+	if err != nil {
+		return nil, fmt.Errorf("jwedecrypt: failed to get cek: %w", err)
+	}
+
+	authData, err := computeAuthData(jwe.ProtectedHeaders, jwe.OrigProtectedHders, []byte(jwe.AAD))
+	if err != nil {
+		return nil, err
+	}
+
+	ct := make([]byte, 0, len(jwe.Ciphertext)+len(jwe.Tag))
+	ct = append(ct, jwe.Ciphertext...)
+	ct = append(ct, jwe.Tag...)
+
+	pt, err := jd.crypto.Decrypt(ct, authData, []byte(jwe.IV), kh)
+	if err != nil {
+		return nil, fmt.Errorf("jwedecrypt: %w", err)
+	}
+
+	return pt, nil
 }
 
 func fetchSKIDFromAPU(jwe *JSONWebEncryption) (string, bool) {
@@ -305,9 +357,17 @@ func buildRecipientsWrappedKey(jwe *JSONWebEncryption) ([]*cryptoapi.RecipientWr
 }
 
 func createRecWK(headers *RecipientHeaders, encryptedKey []byte) (*cryptoapi.RecipientWrappedKey, error) {
-	recWK, err := convertMarshalledJWKToRecKey(headers.EPK)
-	if err != nil {
-		return nil, err
+	var (
+		recWK = &cryptoapi.RecipientWrappedKey{}
+		err   error
+	)
+
+	// key encryption algorithms (RSA-OAEP, A256KW) have no epk.
+	if len(headers.EPK) > 0 {
+		recWK, err = convertMarshalledJWKToRecKey(headers.EPK)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	recWK.KID = headers.KID
@@ -349,21 +409,24 @@ func extractRecipientHeaders(headers map[string]interface{}) (*RecipientHeaders,
 	// Since headers is a generic map, epk value is converted to a generic map by Serialize(), ie we lose RawMessage
 	// type of epk. We need to convert epk value (generic map) to marshaled json so we can call RawMessage.Unmarshal()
 	// to get the original epk value (RawMessage type).
-	mapData, ok := headers[HeaderEPK].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("JSON value is not a map (%#v)", headers[HeaderEPK])
-	}
+	var epk json.RawMessage
 
-	epkBytes, err := json.Marshal(mapData)
-	if err != nil {
-		return nil, err
-	}
+	// key encryption algorithms (RSA-OAEP, A256KW) have no epk.
+	if headers[HeaderEPK] != nil {
+		mapData, ok := headers[HeaderEPK].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("JSON value is not a map (%#v)", headers[HeaderEPK])
+		}
 
-	epk := json.RawMessage{}
+		epkBytes, err := json.Marshal(mapData)
+		if err != nil {
+			return nil, err
+		}
 
-	err = epk.UnmarshalJSON(epkBytes)
-	if err != nil {
-		return nil, err
+		err = epk.UnmarshalJSON(epkBytes)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	alg := ""
@@ -8,11 +8,13 @@ package jose
 
 import (
 	"bytes"
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -21,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/go-jose/go-jose/v3"
+	josecipher "github.com/go-jose/go-jose/v3/cipher"
 	hybrid "github.com/google/tink/go/hybrid/subtle"
 	"github.com/google/tink/go/keyset"
 	"github.com/google/tink/go/subtle/random"
@@ -53,6 +56,16 @@ const (
 	A256CBCHS384 = EncAlg(A256CBCHS384ALG)
 	// A256CBCHS512 for A256CBC-HS512 (AES256-CBC+HMAC-SHA512) content encryption.
 	A256CBCHS512 = EncAlg(A256CBCHS512ALG)
+	// A256GCMHKDF1MB for A256GCM-HKDF-1MB (AES256-GCM with HKDF derived keys over 1MB segments) streaming content
+	// encryption, see JWEEncrypt.EncryptStream.
+	A256GCMHKDF1MB = EncAlg(A256GCMHKDF1MBALG)
+)
+
+const (
+	rsaKty            = "RSA"
+	x25519MLKEM768Crv = "X25519MLKEM768"
+	// aesGCMTagSize is the size of the tag appended to the ciphertext by crypto.Encrypt() with AES-GCM keys.
+	aesGCMTagSize = 16
 )
 
 // Encrypter interface to Encrypt/Decrypt JWE messages.
@@ -65,6 +78,13 @@ type Encrypter interface {
 	Encrypt(plaintext []byte) (*JSONWebEncryption, error)
 }
 
+// batchKeyWrapper is implemented by the crypto services wrapping a CEK for several recipients at once (eg: the webkms
+// RemoteCrypto wrapping them in a single request to the key server).
+type batchKeyWrapper interface {
+	WrapKeys(cek, apu, apv []byte, recPubKeys []*cryptoapi.PublicKey,
+		opts ...cryptoapi.WrapKeyOpts) ([]*cryptoapi.RecipientWrappedKey, error)
+}
+
 // JWEEncrypt is responsible for encrypting a plaintext and its AAD into a protected JWE and decrypting it.
 type JWEEncrypt struct {
 	recipientsKeys []*cryptoapi.PublicKey
@@ -74,6 +94,10 @@ type JWEEncrypt struct {
 	encTyp         string
 	cty            string
 	crypto         cryptoapi.Crypto
+	// alg is the key management algorithm set by the constructors not using the ECDH key wrapping of the CEK.
+	alg string
+	// kek is the A256KW key encryption key or the dir CEK.
+	kek interface{}
 }
 
 // NewJWEEncrypt creates a new JWEEncrypt instance to build JWE with recipientsPubKeys
@@ -85,7 +109,7 @@ func NewJWEEncrypt(encAlg EncAlg, envelopMediaType, cty, senderKID string, sende
 	}
 
 	switch encAlg {
-	case A256GCM, XC20P, A128CBCHS256, A192CBCHS384, A256CBCHS384, A256CBCHS512:
+	case A256GCM, XC20P, A128CBCHS256, A192CBCHS384, A256CBCHS384, A256CBCHS512, A256GCMHKDF1MB:
 	default:
 		return nil, fmt.Errorf("encryption algorithm '%s' not supported", encAlg)
 	}
@@ -99,6 +123,12 @@ func NewJWEEncrypt(encAlg EncAlg, envelopMediaType, cty, senderKID string, sende
 		if senderKID == "" {
 			return nil, errors.New("senderKID is required with senderKH")
 		}
+
+		for _, recPubKey := range recipientsPubKeys {
+			if recPubKey.Type == rsaKty {
+				return nil, errors.New("RSA recipient keys are not supported with senderKH")
+			}
+		}
 	}
 
 	return &JWEEncrypt{
@@ -112,6 +142,75 @@ func NewJWEEncrypt(encAlg EncAlg, envelopMediaType, cty, senderKID string, sende
 	}, nil
 }
 
+// NewJWEEncryptWithKEK creates a new JWEEncrypt instance to build JWEs with the 'A256KW' key management algorithm: the
+// CEK is wrapped with AES Key Wrap using the AES-256 key encryption key kek (eg: a kms AES256GCMNoPrefixType key
+// handle) shared with the recipient under kid.
+func NewJWEEncryptWithKEK(encAlg EncAlg, envelopMediaType, cty, kid string, kek interface{},
+	crypto cryptoapi.Crypto) (*JWEEncrypt, error) {
+	if kek == nil {
+		return nil, errors.New("kek is required")
+	}
+
+	je, err := NewJWEEncrypt(encAlg, envelopMediaType, cty, "", nil, []*cryptoapi.PublicKey{{KID: kid}}, crypto)
+	if err != nil {
+		return nil, err
+	}
+
+	je.alg = A256KWALG
+	je.kek = kek
+
+	return je, nil
+}
+
+// NewJWEEncryptDirect creates a new JWEEncrypt instance to build JWEs with the 'dir' key management algorithm: the
+// AES-256 key cek (eg: a kms AES256GCMNoPrefixType key handle) shared with the recipient under kid is used as the CEK
+// of A256GCM content encryption.
+func NewJWEEncryptDirect(envelopMediaType, cty, kid string, cek interface{},
+	crypto cryptoapi.Crypto) (*JWEEncrypt, error) {
+	if cek == nil {
+		return nil, errors.New("cek is required")
+	}
+
+	je, err := NewJWEEncrypt(A256GCM, envelopMediaType, cty, "", nil, []*cryptoapi.PublicKey{{KID: kid}}, crypto)
+	if err != nil {
+		return nil, err
+	}
+
+	je.alg = DIRALG
+	je.kek = cek
+
+	return je, nil
+}
+
+// NewJWEEncryptECDHESDirect creates a new JWEEncrypt instance to build JWEs with the 'ECDH-ES' key management
+// algorithm: the CEK is derived by an ECDH-ES key agreement in direct mode between an ephemeral key and
+// recipientPubKey (an EC or X25519 OKP key). There is no wrapped CEK, so only one recipient is supported.
+func NewJWEEncryptECDHESDirect(encAlg EncAlg, envelopMediaType, cty string,
+	recipientPubKey *cryptoapi.PublicKey) (*JWEEncrypt, error) {
+	if recipientPubKey == nil {
+		return nil, errors.New("recipientPubKey is required")
+	}
+
+	if _, ok := aeadAlg[encAlg]; !ok {
+		return nil, fmt.Errorf("encryption algorithm '%s' not supported", encAlg)
+	}
+
+	switch {
+	case recipientPubKey.Type == ecdhpb.KeyType_EC.String():
+	case recipientPubKey.Type == ecdhpb.KeyType_OKP.String() && recipientPubKey.Curve != x25519MLKEM768Crv:
+	default:
+		return nil, fmt.Errorf("recipient key type '%s' not supported with ECDH-ES", recipientPubKey.Type)
+	}
+
+	return &JWEEncrypt{
+		recipientsKeys: []*cryptoapi.PublicKey{recipientPubKey},
+		encAlg:         encAlg,
+		encTyp:         envelopMediaType,
+		cty:            cty,
+		alg:            ECDHESALG,
+	}, nil
+}
+
 func (je *JWEEncrypt) getECDHEncPrimitive(cek []byte) (api.CompositeEncrypt, error) {
 	nistpKW := je.useNISTPKW()
 
@@ -149,6 +248,13 @@ func (je *JWEEncrypt) EncryptWithAuthData(plaintext, aad []byte) (*JSONWebEncryp
 
 	je.addExtraProtectedHeaders(protectedHeaders)
 
+	switch je.alg {
+	case DIRALG:
+		return je.encryptDirect(protectedHeaders, plaintext, aad)
+	case ECDHESALG:
+		return je.encryptECDHESDirect(protectedHeaders, plaintext, aad)
+	}
+
 	cek := je.newCEK()
 
 	// creating the crypto primitive requires a pre-built cek
@@ -246,6 +352,155 @@ func (je *JWEEncrypt) encryptWithSender(primitive api.CompositeEncrypt,
 	return getJSONWebEncryption(encData, recipientsHeaders, newProtectedHeaders, aad), nil
 }
 
+func (je *JWEEncrypt) encryptDirect(protectedHeaders map[string]interface{},
+	plaintext, aad []byte) (*JSONWebEncryption, error) {
+	protectedHeaders[HeaderAlgorithm] = DIRALG
+
+	if je.recipientsKeys[0].KID != "" {
+		protectedHeaders[HeaderKeyID] = je.recipientsKeys[0].KID
+	}
+
+	authData, err := computeAuthData(protectedHeaders, "", aad)
+	if err != nil {
+		return nil, fmt.Errorf("jweencryptdirect: computeAuthData: marshal error %w", err)
+	}
+
+	ct, iv, err := je.crypto.Encrypt(plaintext, authData, je.kek)
+	if err != nil {
+		return nil, fmt.Errorf("jweencryptdirect: failed to Encrypt: %w", err)
+	}
+
+	if len(ct) < aesGCMTagSize {
+		return nil, errors.New("jweencryptdirect: invalid ciphertext")
+	}
+
+	tagIdx := len(ct) - aesGCMTagSize
+
+	return &JSONWebEncryption{
+		IV:               string(iv),
+		Tag:              string(ct[tagIdx:]),
+		Ciphertext:       string(ct[:tagIdx]),
+		Recipients:       []*Recipient{{}},
+		ProtectedHeaders: protectedHeaders,
+		AAD:              string(aad),
+	}, nil
+}
+
+func (je *JWEEncrypt) encryptECDHESDirect(protectedHeaders map[string]interface{},
+	plaintext, aad []byte) (*JSONWebEncryption, error) {
+	cek, epk, err := je.deriveECDHESDirectCEK()
+	if err != nil {
+		return nil, fmt.Errorf("jweencryptecdhesdirect: %w", err)
+	}
+
+	mEPK, err := convertRecEPKToMarshalledJWK(epk)
+	if err != nil {
+		return nil, fmt.Errorf("jweencryptecdhesdirect: epk marshall: %w", err)
+	}
+
+	protectedHeaders[HeaderAlgorithm] = ECDHESALG
+	protectedHeaders[HeaderEPK] = json.RawMessage(mEPK)
+
+	if je.recipientsKeys[0].KID != "" {
+		protectedHeaders[HeaderKeyID] = je.recipientsKeys[0].KID
+	}
+
+	authData, err := computeAuthData(protectedHeaders, "", aad)
+	if err != nil {
+		return nil, fmt.Errorf("jweencryptecdhesdirect: computeAuthData: marshal error %w", err)
+	}
+
+	encPrimitive, err := je.getECDHEncPrimitive(cek)
+	if err != nil {
+		return nil, fmt.Errorf("jweencryptecdhesdirect: failed to get encryption primitive: %w", err)
+	}
+
+	serializedEncData, err := encPrimitive.Encrypt(plaintext, authData)
+	if err != nil {
+		return nil, fmt.Errorf("jweencryptecdhesdirect: failed to Encrypt: %w", err)
+	}
+
+	encData := new(composite.EncryptedData)
+
+	err = json.Unmarshal(serializedEncData, encData)
+	if err != nil {
+		return nil, fmt.Errorf("jweencryptecdhesdirect: unmarshal encrypted data failed: %w", err)
+	}
+
+	return getJSONWebEncryption(encData, []*Recipient{{}}, protectedHeaders, aad), nil
+}
+
+// deriveECDHESDirectCEK generates an ephemeral key and derives the CEK with the recipient key using ECDH-ES in direct
+// mode as per https://tools.ietf.org/html/rfc7518#section-4.6 (with empty apu and apv).
+func (je *JWEEncrypt) deriveECDHESDirectCEK() ([]byte, *cryptoapi.PublicKey, error) {
+	recPubKey := je.recipientsKeys[0]
+	keySize := cekSize(je.encAlg)
+
+	epk, _, err := je.newEPK(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if epk.PublicKey.Type == ecdhpb.KeyType_OKP.String() {
+		epkPriv := new([cryptoutil.Curve25519KeySize]byte)
+		copy(epkPriv[:], epk.D)
+
+		recPub := new([cryptoutil.Curve25519KeySize]byte)
+		copy(recPub[:], recPubKey.X)
+
+		z, e := cryptoutil.DeriveECDHX25519(epkPriv, recPub)
+		if e != nil {
+			return nil, nil, e
+		}
+
+		return concatKDF(string(je.encAlg), z, keySize), &epk.PublicKey, nil
+	}
+
+	curve, err := hybrid.GetCurve(recPubKey.Curve)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	recPub := &ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(recPubKey.X),
+		Y:     new(big.Int).SetBytes(recPubKey.Y),
+	}
+
+	if !curve.IsOnCurve(recPub.X, recPub.Y) {
+		return nil, nil, errors.New("recipient key is not on its curve")
+	}
+
+	epkPriv := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(epk.PublicKey.X),
+			Y:     new(big.Int).SetBytes(epk.PublicKey.Y),
+		},
+		D: new(big.Int).SetBytes(epk.D),
+	}
+
+	return josecipher.DeriveECDHES(string(je.encAlg), []byte{}, []byte{}, epkPriv, recPub, keySize),
+		&epk.PublicKey, nil
+}
+
+// concatKDF derives a key of keySize bytes from the X25519 shared secret z with the Concat KDF of ECDH-ES in direct
+// mode (algID set to the enc value, empty apu and apv).
+func concatKDF(encAlg string, z []byte, keySize int) []byte {
+	byteLen := 8
+	supPubInfo := make([]byte, 4) // nolint:gomnd // keydatalen is a 32 bits big-endian integer.
+	binary.BigEndian.PutUint32(supPubInfo, uint32(keySize*byteLen))
+
+	reader := josecipher.NewConcatKDF(crypto.SHA256, z, cryptoutil.LengthPrefix([]byte(encAlg)),
+		cryptoutil.LengthPrefix([]byte{}), cryptoutil.LengthPrefix([]byte{}), supPubInfo, []byte{})
+
+	key := make([]byte, keySize)
+
+	_, _ = reader.Read(key) // nolint:errcheck // ConcatKDF's Read() never returns an error
+
+	return key
+}
+
 func getJSONWebEncryption(encData *composite.EncryptedData, recipientsHeaders []*Recipient,
 	protectedHeaders map[string]interface{}, aad []byte) *JSONWebEncryption {
 	return &JSONWebEncryption{
@@ -306,6 +561,19 @@ func (je *JWEEncrypt) wrapKey(cek, apu, apv, aad []byte, wrapOpts []cryptoapi.Wr
 		singleRecipientAAD []byte
 	)
 
+	if bw, ok := je.crypto.(batchKeyWrapper); ok && je.alg == "" && len(je.recipientsKeys) > 1 {
+		recipientsWK, err := bw.WrapKeys(cek, apu, apv, je.recipientsKeys, wrapOpts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("wrapKey: %w", err)
+		}
+
+		for _, kek := range recipientsWK {
+			je.encodeAPUAPV(kek)
+		}
+
+		return recipientsWK, nil, nil
+	}
+
 	for i, recPubKey := range je.recipientsKeys {
 		var (
 			kek *cryptoapi.RecipientWrappedKey
@@ -361,6 +629,10 @@ func (je *JWEEncrypt) getWrapKeyOpts(tag []byte, epk *cryptoapi.PrivateKey) []cr
 		wrapOpts = append(wrapOpts, cryptoapi.WithXC20PKW())
 	}
 
+	if je.alg == A256KWALG {
+		wrapOpts = append(wrapOpts, cryptoapi.WithKEK(je.kek))
+	}
+
 	if je.skid != "" && je.senderKH != nil {
 		wrapOpts = append(wrapOpts, cryptoapi.WithSender(je.senderKH))
 	}
@@ -443,12 +715,15 @@ func addKDFHeaders(rawHeaders map[string]json.RawMessage, recipientWK *cryptoapi
 	marshaller marshalFunc) error {
 	var err error
 
-	mEPK, err := convertRecEPKToMarshalledJWK(&recipientWK.EPK)
-	if err != nil {
-		return err
-	}
+	// key encryption algorithms (RSA-OAEP, A256KW) have no epk.
+	if hasEPK(&recipientWK.EPK) {
+		mEPK, e := convertRecEPKToMarshalledJWK(&recipientWK.EPK)
+		if e != nil {
+			return e
+		}
 
-	rawHeaders["epk"] = mEPK
+		rawHeaders["epk"] = mEPK
+	}
 
 	if len(recipientWK.APU) != 0 {
 		rawHeaders["apu"], err = marshaller(fmt.Sprintf("%s", recipientWK.APU))
@@ -474,7 +749,9 @@ func mergeRecipientHeaders(headers map[string]interface{}, recHeaders *Recipient
 	}
 
 	// EPK, APU, APV will be marshalled by Serialize
-	headers[HeaderEPK] = recHeaders.EPK
+	if len(recHeaders.EPK) > 0 {
+		headers[HeaderEPK] = recHeaders.EPK
+	}
 	if recHeaders.APU != "" {
 		headers["apu"] = base64.RawURLEncoding.EncodeToString([]byte(recHeaders.APU))
 	}
@@ -554,7 +831,9 @@ func (je *JWEEncrypt) useNISTPKW() bool {
 			"type.hyperledger.org/hyperledger.aries.crypto.tink.NistPEcdhKwPrivateKey":
 			return true
 		case "type.hyperledger.org/hyperledger.aries.crypto.tink.X25519EcdhKwPublicKey",
-			"type.hyperledger.org/hyperledger.aries.crypto.tink.X25519EcdhKwPrivateKey":
+			"type.hyperledger.org/hyperledger.aries.crypto.tink.X25519EcdhKwPrivateKey",
+			"type.hyperledger.org/hyperledger.aries.crypto.tink.X25519MLKEM768EcdhKwPublicKey",
+			"type.hyperledger.org/hyperledger.aries.crypto.tink.X25519MLKEM768EcdhKwPrivateKey":
 			return false
 		}
 	}
@@ -563,22 +842,27 @@ func (je *JWEEncrypt) useNISTPKW() bool {
 }
 
 func (je *JWEEncrypt) newCEK() []byte {
+	return random.GetRandomBytes(uint32(cekSize(je.encAlg)))
+}
+
+// cekSize returns the size in bytes of the CEK of encAlg.
+func cekSize(encAlg EncAlg) int {
 	twoKeys := 2
 	defKeySize := 32
 
-	switch je.encAlg {
+	switch encAlg {
 	case A256GCM, XC20P:
-		return random.GetRandomBytes(uint32(defKeySize))
+		return defKeySize
 	case A128CBCHS256:
-		return random.GetRandomBytes(uint32(subtle.AES128Size * twoKeys)) // cek: 32 bytes.
+		return subtle.AES128Size * twoKeys // cek: 32 bytes.
 	case A192CBCHS384:
-		return random.GetRandomBytes(uint32(subtle.AES192Size * twoKeys)) // cek: 48 bytes.
+		return subtle.AES192Size * twoKeys // cek: 48 bytes.
 	case A256CBCHS384:
-		return random.GetRandomBytes(uint32(subtle.AES256Size + subtle.AES192Size)) // cek: 56 bytes.
+		return subtle.AES256Size + subtle.AES192Size // cek: 56 bytes.
 	case A256CBCHS512:
-		return random.GetRandomBytes(uint32(subtle.AES256Size * twoKeys)) // cek: 64 bytes.
+		return subtle.AES256Size * twoKeys // cek: 64 bytes.
 	default:
-		return random.GetRandomBytes(uint32(defKeySize)) // default cek: 32 bytes.
+		return defKeySize // default cek: 32 bytes.
 	}
 }
 
@@ -756,6 +1040,11 @@ func (je *JWEEncrypt) okpEPKAndAlg() (*cryptoapi.PrivateKey, string, error) {
 
 	kwAlg := tinkcrypto.ECDH1PUXC20PKWAlg
 
+	// hybrid recipient keys use an ephemeral X25519 key too but require their own alg.
+	if je.recipientsKeys[0].Curve == x25519MLKEM768Crv {
+		kwAlg = tinkcrypto.ECDH1PUX25519MLKEM768XC20PKWAlg
+	}
+
 	epk := &cryptoapi.PrivateKey{
 		PublicKey: cryptoapi.PublicKey{
 			Type:  "OKP",
@@ -793,9 +1082,16 @@ func decodeAPUAPV(headers *RecipientHeaders) ([]byte, []byte, error) {
 }
 
 func buildRecipientHeaders(rec *cryptoapi.RecipientWrappedKey, forAuthcrypt bool) (*RecipientHeaders, error) {
-	mRecJWK, err := convertRecEPKToMarshalledJWK(&rec.EPK)
-	if err != nil {
-		return nil, fmt.Errorf("failed to convert recipient key to marshalled JWK: %w", err)
+	var mRecJWK []byte
+
+	// key encryption algorithms (RSA-OAEP, A256KW) have no epk.
+	if hasEPK(&rec.EPK) {
+		var err error
+
+		mRecJWK, err = convertRecEPKToMarshalledJWK(&rec.EPK)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert recipient key to marshalled JWK: %w", err)
+		}
 	}
 
 	rh := &RecipientHeaders{
@@ -813,6 +1109,10 @@ func buildRecipientHeaders(rec *cryptoapi.RecipientWrappedKey, forAuthcrypt bool
 	return rh, nil
 }
 
+func hasEPK(epk *cryptoapi.PublicKey) bool {
+	return epk.Type != "" || epk.Curve != "" || len(epk.X) > 0
+}
+
 func convertRecEPKToMarshalledJWK(recEPK *cryptoapi.PublicKey) ([]byte, error) {
 	var (
 		c   elliptic.Curve
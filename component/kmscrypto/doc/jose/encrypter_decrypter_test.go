@@ -22,8 +22,12 @@ import (
 	"time"
 
 	"github.com/go-jose/go-jose/v3"
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/aead"
 	hybrid "github.com/google/tink/go/hybrid/subtle"
+	"github.com/google/tink/go/insecurecleartextkeyset"
 	"github.com/google/tink/go/keyset"
+	gcmpb "github.com/google/tink/go/proto/aes_gcm_go_proto"
 	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
 	"github.com/google/tink/go/subtle"
 	"github.com/stretchr/testify/require"
@@ -32,8 +36,10 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/composite/ecdh"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/composite/keyio"
 	ecdhpb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/rsaoaep"
 	ariesjose "github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk/jwksupport"
 	resolver "github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/kidresolver"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/jwkkid"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/kmsdidkey"
@@ -1578,6 +1584,141 @@ func TestECDH1PU(t *testing.T) {
 	}
 }
 
+func TestJWEEncryptDecryptX25519MLKEM768(t *testing.T) {
+	tests := []struct {
+		name       string
+		enc        ariesjose.EncAlg
+		nbRec      int
+		useCompact bool
+		authcrypt  bool
+	}{
+		{
+			name:  "anoncrypt with 2 recipients (Full serialization)",
+			enc:   ariesjose.A256GCM,
+			nbRec: 2,
+		},
+		{
+			name:       "anoncrypt with 1 recipient (Compact serialization)",
+			enc:        ariesjose.XC20P,
+			nbRec:      1,
+			useCompact: true,
+		},
+		{
+			name:      "authcrypt with 2 recipients (Full serialization)",
+			enc:       ariesjose.A256CBCHS512,
+			nbRec:     2,
+			authcrypt: true,
+		},
+		{
+			name:      "authcrypt with 1 recipient (Flattened serialization)",
+			enc:       ariesjose.XC20P,
+			nbRec:     1,
+			authcrypt: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			recKeys, recKHs, _ := createX25519MLKEM768Entities(t, tc.nbRec)
+			cryptoSvc, kmsSvc := createCryptoAndKMSServices(t, recKHs)
+
+			var (
+				senderKID string
+				senderKH  *keyset.Handle
+				resolvers []resolver.KIDResolver
+			)
+
+			if tc.authcrypt {
+				senders, senderKHs, senderKIDs := createX25519MLKEM768Entities(t, 1)
+				senderKID = senderKIDs[0]
+				senderKH = senderKHs[senderKID]
+
+				senderPubKey, err := json.Marshal(senders[0])
+				require.NoError(t, err)
+
+				resolvers = []resolver.KIDResolver{&resolver.StoreResolver{Store: &mockstorage.MockStore{
+					Store: map[string]mockstorage.DBEntry{senderKID: {Value: senderPubKey}},
+				}}}
+			}
+
+			jweEncrypter, err := ariesjose.NewJWEEncrypt(tc.enc, EnvelopeEncodingType, DIDCommContentEncodingType,
+				senderKID, senderKH, recKeys, cryptoSvc)
+			require.NoError(t, err)
+
+			pt := []byte("secret message")
+
+			jwe, err := jweEncrypter.Encrypt(pt)
+			require.NoError(t, err)
+			require.Len(t, jwe.Recipients, tc.nbRec)
+
+			alg, ok := jwe.ProtectedHeaders.Algorithm()
+			if tc.authcrypt {
+				require.True(t, ok)
+				require.Equal(t, tinkcrypto.ECDH1PUX25519MLKEM768XC20PKWAlg, alg)
+			} else if alg != "" {
+				require.Contains(t, []string{
+					tinkcrypto.ECDHESX25519MLKEM768A256KWAlg,
+					tinkcrypto.ECDHESX25519MLKEM768XC20PKWAlg,
+				}, alg)
+			}
+
+			var serializedJWE string
+
+			if tc.useCompact {
+				serializedJWE, err = jwe.CompactSerialize(json.Marshal)
+			} else {
+				serializedJWE, err = jwe.FullSerialize(json.Marshal)
+			}
+
+			require.NoError(t, err)
+
+			localJWE, err := ariesjose.Deserialize(serializedJWE)
+			require.NoError(t, err)
+
+			msg, err := ariesjose.NewJWEDecrypt(resolvers, cryptoSvc, kmsSvc).Decrypt(localJWE)
+			require.NoError(t, err)
+			require.EqualValues(t, pt, msg)
+		})
+	}
+}
+
+// createX25519MLKEM768Entities creates nbOfEntities hybrid X25519 and ML-KEM-768 keys. It does not build did:keys
+// since there is no did:key multicodec for this key type.
+func createX25519MLKEM768Entities(t *testing.T, nbOfEntities int) ([]*cryptoapi.PublicKey,
+	map[string]*keyset.Handle, []string) {
+	t.Helper()
+
+	r := make([]*cryptoapi.PublicKey, 0)
+	rKH := make(map[string]*keyset.Handle)
+	rKID := make([]string, 0)
+
+	for i := 0; i < nbOfEntities; i++ {
+		kh, err := keyset.NewHandle(ecdh.X25519MLKEM768ECDHKWKeyTemplate())
+		require.NoError(t, err)
+
+		pubKH, err := kh.Public()
+		require.NoError(t, err)
+
+		buf := new(bytes.Buffer)
+		require.NoError(t, pubKH.WriteWithNoSecrets(keyio.NewWriter(buf)))
+
+		kid, err := jwkkid.CreateKID(buf.Bytes(), kms.X25519MLKEM768ECDHKWType)
+		require.NoError(t, err)
+
+		pubKey := new(cryptoapi.PublicKey)
+		require.NoError(t, json.Unmarshal(buf.Bytes(), pubKey))
+
+		pubKey.KID = kid
+		rKH[kid] = kh
+
+		r = append(r, pubKey)
+		rKID = append(rKID, kid)
+	}
+
+	return r, rKH, rKID
+}
+
 func createCryptoAndKMSServices(t *testing.T, keys map[string]*keyset.Handle) (cryptoapi.Crypto, kms.KeyManager) {
 	c, err := tinkcrypto.New()
 	require.NoError(t, err)
@@ -1703,3 +1844,407 @@ func convertX25519ToKH(t *testing.T, keys, kids []string) map[string]*keyset.Han
 
 	return khs
 }
+
+func TestJWEKeyManagementAlgorithmsRoundTrip(t *testing.T) {
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	pt := []byte("secret message")
+
+	rsaKT, err := rsaoaep.RSAOAEP3072KeyTemplate()
+	require.NoError(t, err)
+
+	rsaKH, err := keyset.NewHandle(rsaKT)
+	require.NoError(t, err)
+
+	rsaPubKey := rsaPublicKeyFromKH(t, rsaKH)
+	rsaPubKey.KID = "rsa-kid"
+
+	aesKH, err := keyset.NewHandle(aead.AES256GCMNoPrefixKeyTemplate())
+	require.NoError(t, err)
+
+	ecKeys, ecKHs, ecKIDs, _ := createRecipients(t, 1)
+	x25519Keys, x25519KHs, x25519KIDs, _ := createRecipientsByKeyTemplate(t, 1, ecdh.X25519ECDHKWKeyTemplate(),
+		kms.X25519ECDHKWType)
+
+	keys := map[string]*keyset.Handle{
+		rsaPubKey.KID: rsaKH,
+		"aes-kid":     aesKH,
+		ecKIDs[0]:     ecKHs[ecKIDs[0]],
+		x25519KIDs[0]: x25519KHs[x25519KIDs[0]],
+	}
+
+	newEncrypter := func(encAlg ariesjose.EncAlg, pubKey *cryptoapi.PublicKey) *ariesjose.JWEEncrypt {
+		enc, e := ariesjose.NewJWEEncrypt(encAlg, EnvelopeEncodingType, DIDCommContentEncodingType, "", nil,
+			[]*cryptoapi.PublicKey{pubKey}, c)
+		require.NoError(t, e)
+
+		return enc
+	}
+
+	newECDHESDirectEncrypter := func(encAlg ariesjose.EncAlg, pubKey *cryptoapi.PublicKey) *ariesjose.JWEEncrypt {
+		enc, e := ariesjose.NewJWEEncryptECDHESDirect(encAlg, EnvelopeEncodingType, DIDCommContentEncodingType,
+			pubKey)
+		require.NoError(t, e)
+
+		return enc
+	}
+
+	kekEncrypter, err := ariesjose.NewJWEEncryptWithKEK(ariesjose.A256GCM, EnvelopeEncodingType,
+		DIDCommContentEncodingType, "aes-kid", aesKH, c)
+	require.NoError(t, err)
+
+	dirEncrypter, err := ariesjose.NewJWEEncryptDirect(EnvelopeEncodingType, DIDCommContentEncodingType, "aes-kid",
+		aesKH, c)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		alg       string
+		encrypter *ariesjose.JWEEncrypt
+	}{
+		{
+			name:      "RSA-OAEP-256 with A256GCM",
+			alg:       ariesjose.RSAOAEP256ALG,
+			encrypter: newEncrypter(ariesjose.A256GCM, rsaPubKey),
+		},
+		{
+			name:      "RSA-OAEP-256 with A256CBC-HS512",
+			alg:       ariesjose.RSAOAEP256ALG,
+			encrypter: newEncrypter(ariesjose.A256CBCHS512, rsaPubKey),
+		},
+		{
+			name:      "A256KW with A256GCM",
+			alg:       ariesjose.A256KWALG,
+			encrypter: kekEncrypter,
+		},
+		{
+			name:      "dir with A256GCM",
+			alg:       ariesjose.DIRALG,
+			encrypter: dirEncrypter,
+		},
+		{
+			name:      "ECDH-ES with NIST P-256 key and A256GCM",
+			alg:       ariesjose.ECDHESALG,
+			encrypter: newECDHESDirectEncrypter(ariesjose.A256GCM, ecKeys[0]),
+		},
+		{
+			name:      "ECDH-ES with NIST P-256 key and A128CBC-HS256",
+			alg:       ariesjose.ECDHESALG,
+			encrypter: newECDHESDirectEncrypter(ariesjose.A128CBCHS256, ecKeys[0]),
+		},
+		{
+			name:      "ECDH-ES with X25519 key and XC20P",
+			alg:       ariesjose.ECDHESALG,
+			encrypter: newECDHESDirectEncrypter(ariesjose.XC20P, x25519Keys[0]),
+		},
+	}
+
+	for _, tt := range tests {
+		tc := tt
+		t.Run(tc.name, func(t *testing.T) {
+			jwe, err := tc.encrypter.Encrypt(pt)
+			require.NoError(t, err)
+
+			alg, ok := jwe.ProtectedHeaders.Algorithm()
+			require.True(t, ok)
+			require.Equal(t, tc.alg, alg)
+
+			_, ok = jwe.ProtectedHeaders[ariesjose.HeaderEPK]
+			require.Equal(t, tc.alg == ariesjose.ECDHESALG, ok)
+
+			serializedJWE, err := jwe.CompactSerialize(json.Marshal)
+			require.NoError(t, err)
+
+			localJWE, err := ariesjose.Deserialize(serializedJWE)
+			require.NoError(t, err)
+
+			if tc.alg == ariesjose.ECDHESALG || tc.alg == ariesjose.DIRALG {
+				require.Empty(t, localJWE.Recipients[0].EncryptedKey)
+			}
+
+			cr, k := createCryptoAndKMSServices(t, keys)
+
+			msg, err := ariesjose.NewJWEDecrypt(nil, cr, k).Decrypt(localJWE)
+			require.NoError(t, err)
+			require.EqualValues(t, pt, msg)
+
+			serializedJWE, err = jwe.FullSerialize(json.Marshal)
+			require.NoError(t, err)
+
+			localJWE, err = ariesjose.Deserialize(serializedJWE)
+			require.NoError(t, err)
+
+			msg, err = ariesjose.NewJWEDecrypt(nil, cr, k).Decrypt(localJWE)
+			require.NoError(t, err)
+			require.EqualValues(t, pt, msg)
+		})
+	}
+
+	t.Run("RSA-OAEP-256 with multiple recipients", func(t *testing.T) {
+		enc, err := ariesjose.NewJWEEncrypt(ariesjose.A256GCM, EnvelopeEncodingType, DIDCommContentEncodingType, "",
+			nil, []*cryptoapi.PublicKey{ecKeys[0], rsaPubKey}, c)
+		require.NoError(t, err)
+
+		jwe, err := enc.Encrypt(pt)
+		require.NoError(t, err)
+
+		serializedJWE, err := jwe.FullSerialize(json.Marshal)
+		require.NoError(t, err)
+
+		localJWE, err := ariesjose.Deserialize(serializedJWE)
+		require.NoError(t, err)
+
+		cr, k := createCryptoAndKMSServices(t, map[string]*keyset.Handle{rsaPubKey.KID: rsaKH})
+
+		msg, err := ariesjose.NewJWEDecrypt(nil, cr, k).Decrypt(localJWE)
+		require.NoError(t, err)
+		require.EqualValues(t, pt, msg)
+	})
+}
+
+func TestFailJWEKeyManagementAlgorithms(t *testing.T) {
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	ecKeys, ecKHs, ecKIDs, _ := createRecipients(t, 1)
+
+	t.Run("test with invalid constructor arguments", func(t *testing.T) {
+		_, err = ariesjose.NewJWEEncryptWithKEK(ariesjose.A256GCM, "", "", "aes-kid", nil, c)
+		require.EqualError(t, err, "kek is required")
+
+		_, err = ariesjose.NewJWEEncryptDirect("", "", "aes-kid", nil, c)
+		require.EqualError(t, err, "cek is required")
+
+		_, err = ariesjose.NewJWEEncryptECDHESDirect(ariesjose.A256GCM, "", "", nil)
+		require.EqualError(t, err, "recipientPubKey is required")
+
+		_, err = ariesjose.NewJWEEncryptECDHESDirect(ariesjose.A256GCMHKDF1MB, "", "", ecKeys[0])
+		require.EqualError(t, err, "encryption algorithm 'A256GCM-HKDF-1MB' not supported")
+
+		_, err = ariesjose.NewJWEEncryptECDHESDirect(ariesjose.A256GCM, "", "", &cryptoapi.PublicKey{Type: "RSA"})
+		require.EqualError(t, err, "recipient key type 'RSA' not supported with ECDH-ES")
+
+		_, err = ariesjose.NewJWEEncrypt(ariesjose.A256GCM, "", "", ecKIDs[0], ecKHs[ecKIDs[0]],
+			[]*cryptoapi.PublicKey{{Type: "RSA"}}, c)
+		require.EqualError(t, err, "RSA recipient keys are not supported with senderKH")
+	})
+
+	t.Run("test dir decryption failures", func(t *testing.T) {
+		aesKH, err := keyset.NewHandle(aead.AES256GCMNoPrefixKeyTemplate())
+		require.NoError(t, err)
+
+		enc, err := ariesjose.NewJWEEncryptDirect("", "", "aes-kid", aesKH, c)
+		require.NoError(t, err)
+
+		encJWE, err := enc.Encrypt([]byte("secret message"))
+		require.NoError(t, err)
+
+		serializedJWE, err := encJWE.CompactSerialize(json.Marshal)
+		require.NoError(t, err)
+
+		jwe, err := ariesjose.Deserialize(serializedJWE)
+		require.NoError(t, err)
+
+		otherKH, err := keyset.NewHandle(aead.AES256GCMNoPrefixKeyTemplate())
+		require.NoError(t, err)
+
+		cr, k := createCryptoAndKMSServices(t, map[string]*keyset.Handle{"aes-kid": otherKH})
+
+		_, err = ariesjose.NewJWEDecrypt(nil, cr, k).Decrypt(jwe)
+		require.EqualError(t, err, "jwedecrypt: decrypt cipher: decryption failed")
+
+		delete(jwe.ProtectedHeaders, ariesjose.HeaderKeyID)
+
+		_, err = ariesjose.NewJWEDecrypt(nil, cr, k).Decrypt(jwe)
+		require.EqualError(t, err, "jwedecrypt: 'dir' JWE is missing 'kid' header")
+
+		jwe.ProtectedHeaders[ariesjose.HeaderEncryption] = ariesjose.XC20PALG
+
+		_, err = ariesjose.NewJWEDecrypt(nil, cr, k).Decrypt(jwe)
+		require.EqualError(t, err, "jwedecrypt: encryption algorithm 'XC20P' not supported with 'dir'")
+	})
+}
+
+func TestInteropWithGoJoseKeyManagementAlgorithms(t *testing.T) {
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	pt := []byte("Test secret message")
+
+	rsaKT, err := rsaoaep.RSAOAEP3072KeyTemplate()
+	require.NoError(t, err)
+
+	rsaKH, err := keyset.NewHandle(rsaKT)
+	require.NoError(t, err)
+
+	ps, err := rsaKH.Primitives()
+	require.NoError(t, err)
+
+	rsaKey, ok := ps.Primary.Primitive.(*rsa.PrivateKey)
+	require.True(t, ok)
+
+	aesKH, err := keyset.NewHandle(aead.AES256GCMNoPrefixKeyTemplate())
+	require.NoError(t, err)
+
+	aesKey := aesKeyFromKH(t, aesKH)
+
+	ecKeys, ecKHs, ecKIDs, _ := createRecipients(t, 1)
+
+	ecPubKey := &ecdsa.PublicKey{
+		Curve: subtle.GetCurve(ecKeys[0].Curve),
+		X:     new(big.Int).SetBytes(ecKeys[0].X),
+		Y:     new(big.Int).SetBytes(ecKeys[0].Y),
+	}
+
+	cr, k := createCryptoAndKMSServices(t, map[string]*keyset.Handle{
+		"rsa-kid": rsaKH,
+		"aes-kid": aesKH,
+		ecKIDs[0]: ecKHs[ecKIDs[0]],
+	})
+
+	t.Run("go-jose encrypt and local jose decrypt", func(t *testing.T) {
+		tests := []struct {
+			alg jose.KeyAlgorithm
+			enc jose.ContentEncryption
+			kid string
+			key interface{}
+		}{
+			{alg: jose.RSA_OAEP, enc: jose.A256GCM, kid: "rsa-kid", key: &rsaKey.PublicKey},
+			{alg: jose.RSA_OAEP_256, enc: jose.A256GCM, kid: "rsa-kid", key: &rsaKey.PublicKey},
+			{alg: jose.RSA_OAEP_256, enc: jose.A128CBC_HS256, kid: "rsa-kid", key: &rsaKey.PublicKey},
+			{alg: jose.A256KW, enc: jose.A256GCM, kid: "aes-kid", key: aesKey},
+			{alg: jose.DIRECT, enc: jose.A256GCM, kid: "aes-kid", key: aesKey},
+			{alg: jose.ECDH_ES, enc: jose.A256GCM, kid: ecKIDs[0], key: ecPubKey},
+			{alg: jose.ECDH_ES, enc: jose.A256CBC_HS512, kid: ecKIDs[0], key: ecPubKey},
+		}
+
+		for _, tt := range tests {
+			tc := tt
+			t.Run(fmt.Sprintf("%s with %s", tc.alg, tc.enc), func(t *testing.T) {
+				gjEncrypter, err := jose.NewEncrypter(tc.enc, jose.Recipient{
+					Algorithm: tc.alg,
+					Key:       tc.key,
+					KeyID:     tc.kid,
+				}, nil)
+				require.NoError(t, err)
+
+				gjJWE, err := gjEncrypter.Encrypt(pt)
+				require.NoError(t, err)
+
+				gjSerializedJWE, err := gjJWE.CompactSerialize()
+				require.NoError(t, err)
+
+				localJWE, err := ariesjose.Deserialize(gjSerializedJWE)
+				require.NoError(t, err)
+
+				msg, err := ariesjose.NewJWEDecrypt(nil, cr, k).Decrypt(localJWE)
+				require.NoError(t, err)
+				require.EqualValues(t, pt, msg)
+			})
+		}
+	})
+
+	t.Run("local jose encrypt and go-jose decrypt", func(t *testing.T) {
+		rsaEncrypter, err := ariesjose.NewJWEEncrypt(ariesjose.A256GCM, "", "", "", nil,
+			[]*cryptoapi.PublicKey{rsaPublicKeyFromKH(t, rsaKH)}, c)
+		require.NoError(t, err)
+
+		kekEncrypter, err := ariesjose.NewJWEEncryptWithKEK(ariesjose.A256GCM, "", "", "aes-kid", aesKH, c)
+		require.NoError(t, err)
+
+		dirEncrypter, err := ariesjose.NewJWEEncryptDirect("", "", "aes-kid", aesKH, c)
+		require.NoError(t, err)
+
+		ecdhesEncrypter, err := ariesjose.NewJWEEncryptECDHESDirect(ariesjose.A256GCM, "", "", ecKeys[0])
+		require.NoError(t, err)
+
+		ecPrivKey, err := ecdsa.GenerateKey(ecPubKey.Curve, rand.Reader)
+		require.NoError(t, err)
+
+		ecdhesEncrypter2, err := ariesjose.NewJWEEncryptECDHESDirect(ariesjose.A256CBCHS512, "", "",
+			&cryptoapi.PublicKey{
+				Type:  "EC",
+				Curve: "P-256",
+				X:     ecPrivKey.X.Bytes(),
+				Y:     ecPrivKey.Y.Bytes(),
+			})
+		require.NoError(t, err)
+
+		tests := []struct {
+			name      string
+			encrypter *ariesjose.JWEEncrypt
+			key       interface{}
+		}{
+			{name: "RSA-OAEP-256", encrypter: rsaEncrypter, key: rsaKey},
+			{name: "A256KW", encrypter: kekEncrypter, key: aesKey},
+			{name: "dir", encrypter: dirEncrypter, key: aesKey},
+			{name: "ECDH-ES with A256CBC-HS512", encrypter: ecdhesEncrypter2, key: ecPrivKey},
+		}
+
+		for _, tt := range tests {
+			tc := tt
+			t.Run(tc.name, func(t *testing.T) {
+				jwe, err := tc.encrypter.Encrypt(pt)
+				require.NoError(t, err)
+
+				serializedJWE, err := jwe.CompactSerialize(json.Marshal)
+				require.NoError(t, err)
+
+				gjJWE, err := jose.ParseEncrypted(serializedJWE)
+				require.NoError(t, err)
+
+				msg, err := gjJWE.Decrypt(tc.key)
+				require.NoError(t, err)
+				require.EqualValues(t, pt, msg)
+			})
+		}
+
+		// go-jose can't decrypt with a kms key, so check the ECDH-ES JWE built for the kms recipient key locally.
+		jwe, err := ecdhesEncrypter.Encrypt(pt)
+		require.NoError(t, err)
+
+		serializedJWE, err := jwe.CompactSerialize(json.Marshal)
+		require.NoError(t, err)
+
+		localJWE, err := ariesjose.Deserialize(serializedJWE)
+		require.NoError(t, err)
+
+		msg, err := ariesjose.NewJWEDecrypt(nil, cr, k).Decrypt(localJWE)
+		require.NoError(t, err)
+		require.EqualValues(t, pt, msg)
+	})
+}
+
+func rsaPublicKeyFromKH(t *testing.T, kh *keyset.Handle) *cryptoapi.PublicKey {
+	t.Helper()
+
+	ps, err := kh.Primitives()
+	require.NoError(t, err)
+
+	rsaKey, ok := ps.Primary.Primitive.(*rsa.PrivateKey)
+	require.True(t, ok)
+
+	j, err := jwksupport.JWKFromKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+
+	pubKey, err := jwksupport.PublicKeyFromJWK(j)
+	require.NoError(t, err)
+
+	pubKey.KID = "rsa-kid"
+
+	return pubKey
+}
+
+func aesKeyFromKH(t *testing.T, kh *keyset.Handle) []byte {
+	t.Helper()
+
+	ks := insecurecleartextkeyset.KeysetMaterial(kh)
+
+	aesKey := new(gcmpb.AesGcmKey)
+
+	err := proto.Unmarshal(ks.Key[0].KeyData.Value, aesKey)
+	require.NoError(t, err)
+
+	return aesKey.KeyValue
+}
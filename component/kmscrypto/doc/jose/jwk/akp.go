@@ -0,0 +1,200 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-jose/go-jose/v3"
+
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsa"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsaed25519"
+)
+
+// AKP (Algorithm Key Pair) JWKs of ML-DSA and composite ML-DSA keys, as defined by the JOSE ML-DSA and composite
+// signatures drafts: the algorithm is set by "alg", the public key is in "pub" and the private key seed in "priv".
+const (
+	akpKty = "AKP"
+
+	// MLDSA44Alg is the JWK algorithm of ML-DSA-44 keys.
+	MLDSA44Alg = "ML-DSA-44"
+	// MLDSA65Alg is the JWK algorithm of ML-DSA-65 keys.
+	MLDSA65Alg = "ML-DSA-65"
+	// MLDSA87Alg is the JWK algorithm of ML-DSA-87 keys.
+	MLDSA87Alg = "ML-DSA-87"
+	// MLDSA65Ed25519Alg is the JWK algorithm of composite ML-DSA-65 and Ed25519 keys.
+	MLDSA65Ed25519Alg = "ML-DSA-65-Ed25519"
+)
+
+// Thumbprint computes the JWK Thumbprint of a key using the indicated hash algorithm. The required members of AKP
+// keys are "alg", "kty" and "pub", other keys are handled by go-jose.
+func (j *JWK) Thumbprint(hash crypto.Hash) ([]byte, error) {
+	if !j.isAKP() {
+		return j.JSONWebKey.Thumbprint(hash)
+	}
+
+	alg, pub, err := akpPublicKey(j.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	input := fmt.Sprintf(`{"alg":"%s","kty":"%s","pub":"%s"}`, alg, akpKty, (&byteBuffer{data: pub}).base64())
+
+	h := hash.New()
+	_, _ = h.Write([]byte(input)) // hash Write() never returns an error.
+
+	return h.Sum(nil), nil
+}
+
+func (j *JWK) isAKP() bool {
+	switch j.Key.(type) {
+	case *mldsa.PublicKey, *mldsa.PrivateKey, *mldsaed25519.PublicKey, *mldsaed25519.PrivateKey:
+		return true
+	default:
+		return false
+	}
+}
+
+func isAKP(kty string) bool {
+	return strings.EqualFold(kty, akpKty)
+}
+
+func akpKeyType(key interface{}) (kms.KeyType, error) {
+	alg, _, err := akpPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	switch alg {
+	case MLDSA44Alg:
+		return kms.MLDSA44Type, nil
+	case MLDSA65Alg:
+		return kms.MLDSA65Type, nil
+	case MLDSA87Alg:
+		return kms.MLDSA87Type, nil
+	default:
+		return kms.MLDSA65ED25519Type, nil
+	}
+}
+
+// akpPublicKey returns the JWK algorithm and public key encoding of an ML-DSA or composite key.
+func akpPublicKey(key interface{}) (string, []byte, error) {
+	switch k := key.(type) {
+	case *mldsa.PublicKey:
+		return k.Parameters().String(), k.Bytes(), nil
+	case *mldsa.PrivateKey:
+		return k.PublicKey().Parameters().String(), k.PublicKey().Bytes(), nil
+	case *mldsaed25519.PublicKey:
+		return MLDSA65Ed25519Alg, k.Bytes(), nil
+	case *mldsaed25519.PrivateKey:
+		return MLDSA65Ed25519Alg, k.PublicKey().Bytes(), nil
+	default:
+		return "", nil, errors.New("invalid AKP key")
+	}
+}
+
+func unmarshalAKP(jwk *jsonWebKey) (*JWK, error) {
+	if jwk.Pub == nil {
+		return nil, ErrInvalidKey
+	}
+
+	var (
+		key interface{}
+		err error
+	)
+
+	switch jwk.Alg {
+	case MLDSA65Ed25519Alg:
+		key, err = unmarshalMLDSAEd25519(jwk.Pub.data, jwk.Priv)
+	case MLDSA44Alg:
+		key, err = unmarshalMLDSA(mldsa.MLDSA44(), jwk.Pub.data, jwk.Priv)
+	case MLDSA65Alg:
+		key, err = unmarshalMLDSA(mldsa.MLDSA65(), jwk.Pub.data, jwk.Priv)
+	case MLDSA87Alg:
+		key, err = unmarshalMLDSA(mldsa.MLDSA87(), jwk.Pub.data, jwk.Priv)
+	default:
+		return nil, fmt.Errorf("unsupported AKP algorithm '%s'", jwk.Alg)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &JWK{
+		JSONWebKey: jose.JSONWebKey{
+			Key: key, KeyID: jwk.Kid, Algorithm: jwk.Alg, Use: jwk.Use,
+		},
+		Kty: jwk.Kty,
+	}, nil
+}
+
+func unmarshalMLDSA(params mldsa.Parameters, pub []byte, priv *byteBuffer) (interface{}, error) {
+	pubKey, err := mldsa.NewPublicKey(params, pub)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+
+	if priv == nil {
+		return pubKey, nil
+	}
+
+	privKey, err := mldsa.NewPrivateKey(params, priv.data)
+	if err != nil || !privKey.PublicKey().Equal(pubKey) {
+		return nil, ErrInvalidKey
+	}
+
+	return privKey, nil
+}
+
+func unmarshalMLDSAEd25519(pub []byte, priv *byteBuffer) (interface{}, error) {
+	pubKey, err := mldsaed25519.NewPublicKey(pub)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+
+	if priv == nil {
+		return pubKey, nil
+	}
+
+	privKey, err := mldsaed25519.NewPrivateKey(priv.data)
+	if err != nil || !privKey.PublicKey().Equal(pubKey) {
+		return nil, ErrInvalidKey
+	}
+
+	return privKey, nil
+}
+
+func marshalAKP(jwk *JWK) ([]byte, error) {
+	alg, pub, err := akpPublicKey(jwk.Key)
+	if err != nil {
+		return nil, fmt.Errorf("marshalAKP: %w", err)
+	}
+
+	raw := jsonWebKey{
+		Kty: akpKty,
+		Alg: alg,
+		Pub: &byteBuffer{data: pub},
+	}
+
+	switch key := jwk.Key.(type) {
+	case *mldsa.PrivateKey:
+		raw.Priv = &byteBuffer{data: key.Bytes()}
+	case *mldsaed25519.PrivateKey:
+		raw.Priv = &byteBuffer{data: key.Bytes()}
+	}
+
+	raw.Kid = jwk.KeyID
+	raw.Use = jwk.Use
+
+	return json.Marshal(raw)
+}
@@ -0,0 +1,112 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jwk
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsa"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsaed25519"
+)
+
+func TestJWK_AKP(t *testing.T) {
+	mldsa44Key, err := mldsa.GenerateKey(mldsa.MLDSA44())
+	require.NoError(t, err)
+
+	mldsa65Key, err := mldsa.GenerateKey(mldsa.MLDSA65())
+	require.NoError(t, err)
+
+	mldsa87Key, err := mldsa.GenerateKey(mldsa.MLDSA87())
+	require.NoError(t, err)
+
+	compositeKey, err := mldsaed25519.GenerateKey()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		alg     string
+		keyType kms.KeyType
+		privKey crypto.Signer
+		pubKey  []byte
+	}{
+		{MLDSA44Alg, kms.MLDSA44Type, mldsa44Key, mldsa44Key.PublicKey().Bytes()},
+		{MLDSA65Alg, kms.MLDSA65Type, mldsa65Key, mldsa65Key.PublicKey().Bytes()},
+		{MLDSA87Alg, kms.MLDSA87Type, mldsa87Key, mldsa87Key.PublicKey().Bytes()},
+		{MLDSA65Ed25519Alg, kms.MLDSA65ED25519Type, compositeKey, compositeKey.PublicKey().Bytes()},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.alg, func(t *testing.T) {
+			for _, key := range []interface{}{tc.privKey, tc.privKey.Public()} {
+				j := &JWK{JSONWebKey: jose.JSONWebKey{Key: key, KeyID: "kid"}}
+
+				jwkBytes, err := json.Marshal(j)
+				require.NoError(t, err)
+
+				raw := map[string]interface{}{}
+				require.NoError(t, json.Unmarshal(jwkBytes, &raw))
+				require.Equal(t, "AKP", raw["kty"])
+				require.Equal(t, tc.alg, raw["alg"])
+				require.Equal(t, base64.RawURLEncoding.EncodeToString(tc.pubKey), raw["pub"])
+
+				_, hasPriv := raw["priv"]
+				require.Equal(t, key == tc.privKey, hasPriv)
+
+				parsed := &JWK{}
+				require.NoError(t, json.Unmarshal(jwkBytes, parsed))
+				require.Equal(t, "AKP", parsed.Kty)
+				require.Equal(t, tc.alg, parsed.Algorithm)
+				require.Equal(t, "kid", parsed.KeyID)
+				require.IsType(t, key, parsed.Key)
+
+				kt, err := parsed.KeyType()
+				require.NoError(t, err)
+				require.Equal(t, tc.keyType, kt)
+
+				pubKey, err := parsed.PublicKeyBytes()
+				require.NoError(t, err)
+				require.Equal(t, tc.pubKey, pubKey)
+
+				tp, err := parsed.Thumbprint(crypto.SHA256)
+				require.NoError(t, err)
+
+				expected := sha256.Sum256([]byte(fmt.Sprintf(`{"alg":"%s","kty":"AKP","pub":"%s"}`,
+					tc.alg, base64.RawURLEncoding.EncodeToString(tc.pubKey))))
+				require.Equal(t, expected[:], tp)
+			}
+		})
+	}
+
+	t.Run("invalid AKP JWKs", func(t *testing.T) {
+		pub := base64.RawURLEncoding.EncodeToString(mldsa44Key.PublicKey().Bytes())
+		priv := base64.RawURLEncoding.EncodeToString(mldsa65Key.Bytes())
+
+		for _, jwkJSON := range []string{
+			`{"kty":"AKP","alg":"ML-DSA-44"}`,
+			`{"kty":"AKP","alg":"ML-DSA-65","pub":"` + pub + `"}`,
+			`{"kty":"AKP","alg":"ML-DSA-65-Ed25519","pub":"` + pub + `"}`,
+			`{"kty":"AKP","alg":"ML-DSA-44","pub":"` + pub + `","priv":"` + priv + `"}`,
+		} {
+			err := json.Unmarshal([]byte(jwkJSON), &JWK{})
+			require.ErrorIs(t, err, ErrInvalidKey, jwkJSON)
+		}
+
+		err := json.Unmarshal([]byte(`{"kty":"AKP","alg":"SLH-DSA","pub":"`+pub+`"}`), &JWK{})
+		require.EqualError(t, err, "unable to read AKP JWK: unsupported AKP algorithm 'SLH-DSA'")
+	})
+}
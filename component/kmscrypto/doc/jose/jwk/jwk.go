@@ -27,6 +27,9 @@ import (
 	"github.com/hyperledger/aries-framework-go/spi/kms"
 
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsa"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsaed25519"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/x25519mlkem768"
 )
 
 const (
@@ -37,6 +40,7 @@ const (
 	ecKty          = "EC"
 	okpKty         = "OKP"
 	x25519Crv      = "X25519"
+	x25519MLKEMCrv = "X25519MLKEM768"
 	ed25519Crv     = "Ed25519"
 	bls12381G2Crv  = "BLS12381_G2"
 	bls12381G2Size = 96
@@ -55,6 +59,12 @@ type JWK struct {
 // Note: the Public() member function is in go-jose, this means keys not supported by go-jose are not supported using
 // j.Public(). Instead use this function to get the public raw bytes.
 func (j *JWK) PublicKeyBytes() ([]byte, error) { //nolint:gocyclo
+	if j.isAKP() {
+		_, pub, err := akpPublicKey(j.Key)
+
+		return pub, err
+	}
+
 	if j.isBLS12381G2() {
 		switch bbsKey := j.Key.(type) {
 		case *bbs12381g2pub.PrivateKey:
@@ -64,7 +74,7 @@ func (j *JWK) PublicKeyBytes() ([]byte, error) { //nolint:gocyclo
 		}
 	}
 
-	if j.isX25519() {
+	if j.isX25519() || j.isX25519MLKEM768() {
 		x25519Key, ok := j.Key.([]byte)
 		if !ok {
 			return nil, fmt.Errorf("invalid public key in kid '%s'", j.KeyID)
@@ -132,6 +142,20 @@ func (j *JWK) UnmarshalJSON(jwkBytes []byte) error {
 			return fmt.Errorf("unable to read X25519 JWE: %w", err)
 		}
 
+		*j = *jwk
+	} else if isX25519MLKEM768(key.Kty, key.Crv) {
+		jwk, err := unmarshalX25519MLKEM768(&key)
+		if err != nil {
+			return fmt.Errorf("unable to read X25519MLKEM768 JWK: %w", err)
+		}
+
+		*j = *jwk
+	} else if isAKP(key.Kty) {
+		jwk, err := unmarshalAKP(&key)
+		if err != nil {
+			return fmt.Errorf("unable to read AKP JWK: %w", err)
+		}
+
 		*j = *jwk
 	} else {
 		var joseJWK jose.JSONWebKey
@@ -160,10 +184,18 @@ func (j *JWK) MarshalJSON() ([]byte, error) {
 		return marshalX25519(j)
 	}
 
+	if j.isX25519MLKEM768() {
+		return marshalX25519MLKEM768(j)
+	}
+
 	if j.isBLS12381G2() {
 		return marshalBLS12381G2(j)
 	}
 
+	if j.isAKP() {
+		return marshalAKP(j)
+	}
+
 	return (&j.JSONWebKey).MarshalJSON()
 }
 
@@ -180,11 +212,15 @@ func (j *JWK) KeyType() (kms.KeyType, error) {
 		return ecdsaPubKeyType(&(key.PublicKey))
 	case *rsa.PublicKey, *rsa.PrivateKey:
 		return kms.RSAPS256Type, nil
+	case *mldsa.PublicKey, *mldsa.PrivateKey, *mldsaed25519.PublicKey, *mldsaed25519.PrivateKey:
+		return akpKeyType(key)
 	}
 
 	switch {
 	case isX25519(j.Kty, j.Crv):
 		return kms.X25519ECDHKWType, nil
+	case isX25519MLKEM768(j.Kty, j.Crv):
+		return kms.X25519MLKEM768ECDHKWType, nil
 	case isEd25519(j.Kty, j.Crv):
 		return kms.ED25519Type, nil
 	case isSecp256k1(j.Algorithm, j.Kty, j.Crv):
@@ -218,6 +254,15 @@ func (j *JWK) isX25519() bool {
 	}
 }
 
+func (j *JWK) isX25519MLKEM768() bool {
+	switch j.Key.(type) {
+	case []byte:
+		return isX25519MLKEM768(j.Kty, j.Crv)
+	default:
+		return false
+	}
+}
+
 func (j *JWK) isBLS12381G2() bool {
 	switch j.Key.(type) {
 	case *bbs12381g2pub.PublicKey, *bbs12381g2pub.PrivateKey:
@@ -246,6 +291,10 @@ func isX25519(kty, crv string) bool {
 	return strings.EqualFold(kty, okpKty) && strings.EqualFold(crv, x25519Crv)
 }
 
+func isX25519MLKEM768(kty, crv string) bool {
+	return strings.EqualFold(kty, okpKty) && strings.EqualFold(crv, x25519MLKEMCrv)
+}
+
 func isEd25519(kty, crv string) bool {
 	return strings.EqualFold(kty, okpKty) && strings.EqualFold(crv, ed25519Crv)
 }
@@ -358,6 +407,42 @@ func marshalX25519(jwk *JWK) ([]byte, error) {
 	return json.Marshal(raw)
 }
 
+func unmarshalX25519MLKEM768(jwk *jsonWebKey) (*JWK, error) {
+	if jwk.X == nil {
+		return nil, ErrInvalidKey
+	}
+
+	if len(jwk.X.data) != x25519mlkem768.PublicKeySize {
+		return nil, ErrInvalidKey
+	}
+
+	return &JWK{
+		JSONWebKey: jose.JSONWebKey{
+			Key: jwk.X.data, KeyID: jwk.Kid, Algorithm: jwk.Alg, Use: jwk.Use,
+		},
+		Crv: jwk.Crv,
+		Kty: jwk.Kty,
+	}, nil
+}
+
+func marshalX25519MLKEM768(jwk *JWK) ([]byte, error) {
+	key, ok := jwk.Key.([]byte)
+	if !ok || len(key) != x25519mlkem768.PublicKeySize {
+		return nil, errors.New("marshalX25519MLKEM768: invalid key")
+	}
+
+	raw := jsonWebKey{
+		Kty: okpKty,
+		Crv: x25519MLKEMCrv,
+		X:   newFixedSizeBuffer(key, x25519mlkem768.PublicKeySize),
+		Kid: jwk.KeyID,
+		Alg: jwk.Algorithm,
+		Use: jwk.Use,
+	}
+
+	return json.Marshal(raw)
+}
+
 func unmarshalBLS12381G2(jwk *jsonWebKey) (*JWK, error) {
 	if jwk.X == nil {
 		return nil, ErrInvalidKey
@@ -493,6 +578,9 @@ type jsonWebKey struct {
 	Y *byteBuffer `json:"y,omitempty"`
 
 	D *byteBuffer `json:"d,omitempty"`
+
+	Pub  *byteBuffer `json:"pub,omitempty"`
+	Priv *byteBuffer `json:"priv,omitempty"`
 }
 
 // Get size of curve in bytes.
@@ -10,6 +10,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/elliptic"
+	"crypto/rsa"
 	"crypto/x509"
 	"encoding/json"
 	"errors"
@@ -23,6 +24,8 @@ import (
 	cryptoapi "github.com/hyperledger/aries-framework-go/spi/crypto"
 
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsa"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsaed25519"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk"
 )
 
@@ -30,13 +33,14 @@ const (
 	ecKty          = "EC"
 	okpKty         = "OKP"
 	x25519Crv      = "X25519"
+	x25519MLKEMCrv = "X25519MLKEM768"
 	bls12381G2Crv  = "BLS12381_G2"
 	bls12381G2Size = 96
 )
 
 // JWKFromKey creates a JWK from an opaque key struct.
-// It's e.g. *ecdsa.PublicKey, *ecdsa.PrivateKey, ed25519.VerificationMethod, *bbs12381g2pub.PrivateKey or
-// *bbs12381g2pub.PublicKey.
+// It's e.g. *ecdsa.PublicKey, *ecdsa.PrivateKey, ed25519.VerificationMethod, *bbs12381g2pub.PrivateKey,
+// *bbs12381g2pub.PublicKey, *mldsa.PublicKey or *mldsaed25519.PublicKey.
 func JWKFromKey(opaqueKey interface{}) (*jwk.JWK, error) {
 	key := &jwk.JWK{
 		JSONWebKey: jose.JSONWebKey{
@@ -62,11 +66,21 @@ func JWKFromKey(opaqueKey interface{}) (*jwk.JWK, error) {
 // This builder function presets the curve and key type in the JWK.
 // Using JWKFromKey for X25519 raw keys will not have these fields set and will not provide the right JWK output.
 func JWKFromX25519Key(pubKey []byte) (*jwk.JWK, error) {
+	return jwkFromRawOKPKey(pubKey, x25519Crv)
+}
+
+// JWKFromX25519MLKEM768Key is similar to JWKFromX25519Key but for hybrid X25519 and ML-KEM-768 public keys as raw
+// []byte (the X25519 public key followed by the ML-KEM-768 encapsulation key).
+func JWKFromX25519MLKEM768Key(pubKey []byte) (*jwk.JWK, error) {
+	return jwkFromRawOKPKey(pubKey, x25519MLKEMCrv)
+}
+
+func jwkFromRawOKPKey(pubKey []byte, crv string) (*jwk.JWK, error) {
 	key := &jwk.JWK{
 		JSONWebKey: jose.JSONWebKey{
 			Key: pubKey,
 		},
-		Crv: x25519Crv,
+		Crv: crv,
 		Kty: okpKty,
 	}
 
@@ -131,6 +145,29 @@ func PubKeyBytesToJWK(bytes []byte, keyType kms.KeyType) (*jwk.JWK, error) { //
 		return JWKFromKey(ecdsaKey)
 	case kms.X25519ECDHKWType:
 		return JWKFromX25519Key(bytes)
+	case kms.X25519MLKEM768ECDHKWType:
+		return JWKFromX25519MLKEM768Key(bytes)
+	case kms.MLDSA44Type, kms.MLDSA65Type, kms.MLDSA87Type:
+		mldsaKey, err := mldsa.NewPublicKey(getMLDSAParameters(keyType), bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		return JWKFromKey(mldsaKey)
+	case kms.MLDSA65ED25519Type:
+		compositeKey, err := mldsaed25519.NewPublicKey(bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		return JWKFromKey(compositeKey)
+	case kms.RSAOAEPType:
+		rsaKey, err := x509.ParsePKCS1PublicKey(bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		return JWKFromKey(rsaKey)
 	default:
 		return nil, fmt.Errorf("convertPubKeyJWK: invalid key type: %s", keyType)
 	}
@@ -149,6 +186,17 @@ func getECDSACurve(keyType kms.KeyType) elliptic.Curve {
 	return nil
 }
 
+func getMLDSAParameters(keyType kms.KeyType) mldsa.Parameters {
+	switch keyType {
+	case kms.MLDSA44Type:
+		return mldsa.MLDSA44()
+	case kms.MLDSA87Type:
+		return mldsa.MLDSA87()
+	default:
+		return mldsa.MLDSA65()
+	}
+}
+
 // PublicKeyFromJWK builds a cryptoapi.PublicKey from jwkKey.
 func PublicKeyFromJWK(jwkKey *jwk.JWK) (*cryptoapi.PublicKey, error) {
 	if jwkKey != nil {
@@ -184,6 +232,22 @@ func PublicKeyFromJWK(jwkKey *jwk.JWK) (*cryptoapi.PublicKey, error) {
 			}
 
 			pubKey.X = pubEdKey
+		case []byte: // raw X25519 or hybrid X25519MLKEM768 public key.
+			pubKey.X = key
+		case *mldsa.PublicKey, *mldsa.PrivateKey, *mldsaed25519.PublicKey, *mldsaed25519.PrivateKey:
+			mldsaKey, err := jwkKey.PublicKeyBytes()
+			if err != nil {
+				return nil, fmt.Errorf("publicKeyFromJWK: %w", err)
+			}
+
+			pubKey.Curve = jwkKey.Algorithm
+			pubKey.X = mldsaKey
+		case *rsa.PublicKey: // RSA public keys have their modulus in X and their public exponent in Y.
+			pubKey.X = key.N.Bytes()
+			pubKey.Y = big.NewInt(int64(key.E)).Bytes()
+		case *rsa.PrivateKey:
+			pubKey.X = key.N.Bytes()
+			pubKey.Y = big.NewInt(int64(key.E)).Bytes()
 		default:
 			return nil, fmt.Errorf("publicKeyFromJWK: unsupported jwk key type %T", jwkKey.Key)
 		}
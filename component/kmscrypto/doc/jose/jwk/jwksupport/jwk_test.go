@@ -11,11 +11,13 @@ import (
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"strings"
 	"testing"
 
@@ -29,6 +31,9 @@ import (
 	cryptoapi "github.com/hyperledger/aries-framework-go/spi/crypto"
 
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsa"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsaed25519"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/x25519mlkem768"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk"
 )
 
@@ -376,6 +381,10 @@ func TestPubKeyBytesToJWK(t *testing.T) {
 			name:    "X25519 test",
 			keyType: kms.X25519ECDHKWType,
 		},
+		{
+			name:    "X25519MLKEM768 test",
+			keyType: kms.X25519MLKEM768ECDHKWType,
+		},
 		{
 			name:    "P-256 KW test",
 			keyType: kms.NISTP256ECDHKWType,
@@ -388,6 +397,22 @@ func TestPubKeyBytesToJWK(t *testing.T) {
 			name:    "P-521 KW test",
 			keyType: kms.NISTP521ECDHKWType,
 		},
+		{
+			name:    "ML-DSA-44 test",
+			keyType: kms.MLDSA44Type,
+		},
+		{
+			name:    "ML-DSA-87 test",
+			keyType: kms.MLDSA87Type,
+		},
+		{
+			name:    "ML-DSA-65 with Ed25519 test",
+			keyType: kms.MLDSA65ED25519Type,
+		},
+		{
+			name:    "RSA-OAEP test",
+			keyType: kms.RSAOAEPType,
+		},
 		{
 			name:    "undefined type test",
 			keyType: "undefined",
@@ -495,6 +520,75 @@ func TestPubKeyBytesToJWK(t *testing.T) {
 				require.NotEmpty(t, jwkKey)
 				require.Equal(t, okpKty, jwkKey.Kty)
 				require.Equal(t, x25519Crv, jwkKey.Crv)
+			case kms.X25519MLKEM768ECDHKWType:
+				privKey, err := x25519mlkem768.GenerateKey()
+				require.NoError(t, err)
+
+				jwkKey, err := PubKeyBytesToJWK(privKey.PublicKey().Bytes(), tc.keyType)
+				require.NoError(t, err)
+				require.Equal(t, okpKty, jwkKey.Kty)
+				require.Equal(t, x25519MLKEMCrv, jwkKey.Crv)
+
+				kt, err := jwkKey.KeyType()
+				require.NoError(t, err)
+				require.Equal(t, tc.keyType, kt)
+
+				pubKey, err := PublicKeyFromJWK(jwkKey)
+				require.NoError(t, err)
+				require.Equal(t, privKey.PublicKey().Bytes(), pubKey.X)
+				require.Equal(t, x25519MLKEMCrv, pubKey.Curve)
+
+				mJWK, err := jwkKey.MarshalJSON()
+				require.NoError(t, err)
+
+				parsedJWK := &jwk.JWK{}
+				require.NoError(t, parsedJWK.UnmarshalJSON(mJWK))
+				require.Equal(t, privKey.PublicKey().Bytes(), parsedJWK.Key)
+
+				_, err = PubKeyBytesToJWK(privKey.PublicKey().X25519(), tc.keyType)
+				require.EqualError(t, err, "create JWK: marshalX25519MLKEM768: invalid key")
+			case kms.RSAOAEPType:
+				privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+				require.NoError(t, err)
+
+				jwkKey, err := PubKeyBytesToJWK(x509.MarshalPKCS1PublicKey(&privKey.PublicKey), tc.keyType)
+				require.NoError(t, err)
+				require.Equal(t, "RSA", jwkKey.Kty)
+
+				pubKey, err := PublicKeyFromJWK(jwkKey)
+				require.NoError(t, err)
+				require.Equal(t, "RSA", pubKey.Type)
+				require.Equal(t, privKey.N.Bytes(), pubKey.X)
+				require.Equal(t, big.NewInt(int64(privKey.E)).Bytes(), pubKey.Y)
+
+				_, err = PubKeyBytesToJWK([]byte("invalid RSA Key"), tc.keyType)
+				require.Error(t, err)
+			case kms.MLDSA44Type, kms.MLDSA87Type:
+				privKey, err := mldsa.GenerateKey(getMLDSAParameters(tc.keyType))
+				require.NoError(t, err)
+
+				jwkKey, err := PubKeyBytesToJWK(privKey.PublicKey().Bytes(), tc.keyType)
+				require.NoError(t, err)
+				require.Equal(t, "AKP", jwkKey.Kty)
+				require.Equal(t, privKey.PublicKey().Parameters().String(), jwkKey.Algorithm)
+
+				pubKey, err := PublicKeyFromJWK(jwkKey)
+				require.NoError(t, err)
+				require.Equal(t, privKey.PublicKey().Bytes(), pubKey.X)
+
+				_, err = PubKeyBytesToJWK([]byte("invalid ML-DSA Key"), tc.keyType)
+				require.Error(t, err)
+			case kms.MLDSA65ED25519Type:
+				privKey, err := mldsaed25519.GenerateKey()
+				require.NoError(t, err)
+
+				jwkKey, err := PubKeyBytesToJWK(privKey.PublicKey().Bytes(), tc.keyType)
+				require.NoError(t, err)
+				require.Equal(t, "AKP", jwkKey.Kty)
+				require.Equal(t, jwk.MLDSA65Ed25519Alg, jwkKey.Algorithm)
+
+				_, err = PubKeyBytesToJWK([]byte("invalid ML-DSA Key"), tc.keyType)
+				require.EqualError(t, err, "mldsaed25519: invalid public key size")
 			default:
 				_, err := PubKeyBytesToJWK([]byte{}, tc.keyType)
 				require.EqualError(t, err, "convertPubKeyJWK: invalid key type: undefined")
@@ -0,0 +1,206 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/google/tink/go/insecurecleartextkeyset"
+	"github.com/google/tink/go/keyset"
+	gcmhkdfpb "github.com/google/tink/go/proto/aes_gcm_hkdf_streaming_go_proto"
+	commonpb "github.com/google/tink/go/proto/common_go_proto"
+	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto"
+)
+
+// A streaming JWE encrypts a payload too large to be held in memory (eg: a DIDComm attachment of hundreds of MB) with
+// the A256GCM-HKDF-1MB content encryption, ie a Tink streaming AEAD ciphertext made of 1MB segments authenticated one
+// by one. It is serialized as the JWE JSON serialization of its headers, recipients and AAD (the iv, ciphertext and
+// tag members are omitted) on a single line, followed by the binary streaming AEAD ciphertext.
+
+const (
+	streamingSegmentSize = 1 << 20
+	// maxStreamingHeaderSize limits the memory used to read the JSON line of streaming JWEs.
+	maxStreamingHeaderSize = 1 << 20
+
+	aesGCMHKDFStreamingTypeURL = "type.googleapis.com/google.crypto.tink.AesGcmHkdfStreamingKey"
+)
+
+// EncryptStream returns a writer encrypting the plaintext written to it, with aad, into w as a streaming JWE, so that
+// large payloads are encrypted with bounded memory. The JWEEncrypt must use A256GCMHKDF1MB content encryption. The
+// JWE is complete once the writer is closed.
+// Authcrypt (ECDH-1PU) is not supported since it binds the tag of the whole ciphertext to the key wrapping.
+func (je *JWEEncrypt) EncryptStream(w io.Writer, aad []byte) (io.WriteCloser, error) {
+	if je.encAlg != A256GCMHKDF1MB {
+		return nil, fmt.Errorf("jweencryptstream: encryption algorithm '%s' not supported", je.encAlg)
+	}
+
+	if je.senderKH != nil {
+		return nil, errors.New("jweencryptstream: authcrypt is not supported")
+	}
+
+	protectedHeaders := map[string]interface{}{
+		HeaderEncryption: je.encAlg,
+		HeaderType:       je.encTyp,
+	}
+
+	je.addExtraProtectedHeaders(protectedHeaders)
+
+	cek := je.newCEK()
+
+	authData, err := computeAuthData(protectedHeaders, "", aad)
+	if err != nil {
+		return nil, fmt.Errorf("jweencryptstream: computeAuthData: marshal error %w", err)
+	}
+
+	recipients, singleRecipientAAD, err := je.wrapCEKForRecipients(cek, []byte{}, []byte{}, authData, json.Marshal)
+	if err != nil {
+		return nil, fmt.Errorf("jweencryptstream: failed to wrap cek: %w", err)
+	}
+
+	if len(singleRecipientAAD) > 0 {
+		authData = singleRecipientAAD
+	}
+
+	recipientsHeaders, singleRecipientHeaders, err := je.buildRecs(recipients, false)
+	if err != nil {
+		return nil, fmt.Errorf("jweencryptstream: failed to build recipients: %w", err)
+	}
+
+	if singleRecipientHeaders != nil {
+		mergeRecipientHeaders(protectedHeaders, singleRecipientHeaders)
+	}
+
+	jwe := &JSONWebEncryption{
+		ProtectedHeaders: protectedHeaders,
+		Recipients:       recipientsHeaders,
+		AAD:              string(aad),
+	}
+
+	header, err := jwe.streamingHeader(json.Marshal)
+	if err != nil {
+		return nil, fmt.Errorf("jweencryptstream: failed to serialize header: %w", err)
+	}
+
+	kh, err := streamingCEKHandle(cek)
+	if err != nil {
+		return nil, fmt.Errorf("jweencryptstream: %w", err)
+	}
+
+	_, err = w.Write(append(header, '\n'))
+	if err != nil {
+		return nil, fmt.Errorf("jweencryptstream: failed to write header: %w", err)
+	}
+
+	ew, err := new(tinkcrypto.Crypto).NewEncryptingWriter(w, authData, kh)
+	if err != nil {
+		return nil, fmt.Errorf("jweencryptstream: %w", err)
+	}
+
+	return ew, nil
+}
+
+// DecryptStream reads a streaming JWE, as written by JWEEncrypt.EncryptStream, from r and returns a reader of its
+// plaintext. Segments are authenticated as they are read: a Read error means the JWE was modified or truncated and the
+// plaintext read so far must be discarded.
+func (jd *JWEDecrypt) DecryptStream(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReaderSize(r, maxStreamingHeaderSize)
+
+	header, err := br.ReadSlice('\n')
+	if err != nil {
+		return nil, fmt.Errorf("jwedecryptstream: failed to read header: %w", err)
+	}
+
+	jwe, err := deserializeFull(string(header[:len(header)-1]))
+	if err != nil {
+		return nil, fmt.Errorf("jwedecryptstream: failed to deserialize header: %w", err)
+	}
+
+	if encAlg, ok := jwe.ProtectedHeaders.Encryption(); !ok || encAlg != A256GCMHKDF1MBALG {
+		return nil, fmt.Errorf("jwedecryptstream: encryption algorithm '%s' not supported", encAlg)
+	}
+
+	cek, err := jd.decryptCEK(jwe, A256GCMHKDF1MBALG)
+	if err != nil {
+		return nil, fmt.Errorf("jwedecryptstream: %w", err)
+	}
+
+	authData, err := computeAuthData(jwe.ProtectedHeaders, jwe.OrigProtectedHders, []byte(jwe.AAD))
+	if err != nil {
+		return nil, fmt.Errorf("jwedecryptstream: %w", err)
+	}
+
+	kh, err := streamingCEKHandle(cek)
+	if err != nil {
+		return nil, fmt.Errorf("jwedecryptstream: %w", err)
+	}
+
+	dr, err := new(tinkcrypto.Crypto).NewDecryptingReader(br, authData, kh)
+	if err != nil {
+		return nil, fmt.Errorf("jwedecryptstream: %w", err)
+	}
+
+	return dr, nil
+}
+
+// streamingHeader serializes the JWE as the JSON serialization does, without its iv, ciphertext and tag.
+func (e *JSONWebEncryption) streamingHeader(marshal marshalFunc) ([]byte, error) {
+	b64ProtectedHeaders, unprotectedHeaders, err := e.prepareHeaders(marshal)
+	if err != nil {
+		return nil, err
+	}
+
+	recipientsJSON, b64SingleRecipientEncKey, singleRecipientHeader, err := e.prepareRecipients(marshal)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshal(rawJSONWebEncryption{
+		B64ProtectedHeaders:      b64ProtectedHeaders,
+		UnprotectedHeaders:       unprotectedHeaders,
+		Recipients:               recipientsJSON,
+		B64SingleRecipientEncKey: b64SingleRecipientEncKey,
+		SingleRecipientHeader:    singleRecipientHeader,
+		B64AAD:                   base64.RawURLEncoding.EncodeToString([]byte(e.AAD)),
+	})
+}
+
+// streamingCEKHandle returns the handle of the AES256-GCM-HKDF-1MB streaming key of cek.
+func streamingCEKHandle(cek []byte) (*keyset.Handle, error) {
+	key, err := proto.Marshal(&gcmhkdfpb.AesGcmHkdfStreamingKey{
+		KeyValue: cek,
+		Params: &gcmhkdfpb.AesGcmHkdfStreamingParams{
+			CiphertextSegmentSize: streamingSegmentSize,
+			DerivedKeySize:        uint32(len(cek)),
+			HkdfHashType:          commonpb.HashType_SHA256,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal streaming key: %w", err)
+	}
+
+	return insecurecleartextkeyset.Read(&keyset.MemReaderWriter{Keyset: &tinkpb.Keyset{
+		PrimaryKeyId: 1,
+		Key: []*tinkpb.Keyset_Key{{
+			KeyData: &tinkpb.KeyData{
+				TypeUrl:         aesGCMHKDFStreamingTypeURL,
+				Value:           key,
+				KeyMaterialType: tinkpb.KeyData_SYMMETRIC,
+			},
+			Status:           tinkpb.KeyStatusType_ENABLED,
+			KeyId:            1,
+			OutputPrefixType: tinkpb.OutputPrefixType_RAW,
+		}},
+	}})
+}
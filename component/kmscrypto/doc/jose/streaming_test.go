@@ -0,0 +1,177 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package jose_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/tink/go/subtle/random"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/composite/ecdh"
+	ariesjose "github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose"
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+func TestJWEEncryptDecryptStream(t *testing.T) {
+	// spans several 1MB segments.
+	plaintext := random.GetRandomBytes(2<<20 + 123)
+
+	for _, tc := range []struct {
+		name  string
+		nbRec int
+		okp   bool
+		aad   []byte
+	}{
+		{name: "NIST P-256 single recipient", nbRec: 1},
+		{name: "NIST P-256 3 recipients with aad", nbRec: 3, aad: []byte("attachment id")},
+		{name: "X25519 single recipient with aad", nbRec: 1, okp: true, aad: []byte("attachment id")},
+		{name: "X25519 2 recipients", nbRec: 2, okp: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			kt, keyType := ecdh.NISTP256ECDHKWKeyTemplate(), kms.NISTP256ECDHKWType
+			if tc.okp {
+				kt, keyType = ecdh.X25519ECDHKWKeyTemplate(), kms.X25519ECDHKWType
+			}
+
+			recKeys, recKHs, _, _ := createRecipientsByKeyTemplate(t, tc.nbRec, kt, keyType)
+			cryptoSvc, kmsSvc := createCryptoAndKMSServices(t, recKHs)
+
+			jweEncrypter, err := ariesjose.NewJWEEncrypt(ariesjose.A256GCMHKDF1MB, EnvelopeEncodingType,
+				"application/octet-stream", "", nil, recKeys, cryptoSvc)
+			require.NoError(t, err)
+
+			jwe := new(bytes.Buffer)
+
+			w, err := jweEncrypter.EncryptStream(jwe, tc.aad)
+			require.NoError(t, err)
+
+			_, err = io.Copy(w, bytes.NewReader(plaintext))
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+
+			// the JWE header line is a JWE JSON serialization without ciphertext.
+			header, _, found := bytes.Cut(jwe.Bytes(), []byte("\n"))
+			require.True(t, found)
+
+			parsedHeader, err := ariesjose.Deserialize(string(header))
+			require.NoError(t, err)
+			require.Len(t, parsedHeader.Recipients, tc.nbRec)
+			require.Empty(t, parsedHeader.Ciphertext)
+			require.Equal(t, string(tc.aad), parsedHeader.AAD)
+
+			r, err := ariesjose.NewJWEDecrypt(nil, cryptoSvc, kmsSvc).DecryptStream(bytes.NewReader(jwe.Bytes()))
+			require.NoError(t, err)
+
+			decrypted, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, plaintext, decrypted)
+		})
+	}
+}
+
+func TestJWEEncryptDecryptStream_Failures(t *testing.T) {
+	recKeys, recKHs, _, _ := createRecipients(t, 2)
+	cryptoSvc, kmsSvc := createCryptoAndKMSServices(t, recKHs)
+	jweDecrypter := ariesjose.NewJWEDecrypt(nil, cryptoSvc, kmsSvc)
+
+	jweEncrypter, err := ariesjose.NewJWEEncrypt(ariesjose.A256GCMHKDF1MB, EnvelopeEncodingType, "", "", nil,
+		recKeys, cryptoSvc)
+	require.NoError(t, err)
+
+	jwe := new(bytes.Buffer)
+
+	w, err := jweEncrypter.EncryptStream(jwe, nil)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("secret message"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	t.Run("modified ciphertext", func(t *testing.T) {
+		modified := append([]byte{}, jwe.Bytes()...)
+		modified[len(modified)-1] ^= 1
+
+		r, e := jweDecrypter.DecryptStream(bytes.NewReader(modified))
+		require.NoError(t, e)
+
+		_, e = io.ReadAll(r)
+		require.Error(t, e)
+	})
+
+	t.Run("modified header", func(t *testing.T) {
+		header, ciphertext, _ := bytes.Cut(jwe.Bytes(), []byte("\n"))
+
+		jweHeader, e := ariesjose.Deserialize(string(header))
+		require.NoError(t, e)
+
+		jweHeader.AAD = "other aad"
+		jweHeader.Ciphertext = "placeholder"
+
+		modifiedHeader, e := jweHeader.FullSerialize(json.Marshal)
+		require.NoError(t, e)
+
+		modifiedHeader = strings.Replace(modifiedHeader, `,"ciphertext":"cGxhY2Vob2xkZXI"`, "", 1)
+
+		r, e := jweDecrypter.DecryptStream(io.MultiReader(strings.NewReader(modifiedHeader+"\n"),
+			bytes.NewReader(ciphertext)))
+		require.NoError(t, e)
+
+		_, e = io.ReadAll(r)
+		require.Error(t, e)
+	})
+
+	t.Run("missing or invalid header", func(t *testing.T) {
+		_, e := jweDecrypter.DecryptStream(strings.NewReader("no header line"))
+		require.EqualError(t, e, "jwedecryptstream: failed to read header: EOF")
+
+		_, e = jweDecrypter.DecryptStream(strings.NewReader("{\n"))
+		require.Contains(t, e.Error(), "jwedecryptstream: failed to deserialize header")
+	})
+
+	t.Run("non streaming JWEs", func(t *testing.T) {
+		encrypter, e := ariesjose.NewJWEEncrypt(ariesjose.A256GCM, EnvelopeEncodingType, "", "", nil,
+			recKeys, cryptoSvc)
+		require.NoError(t, e)
+
+		_, e = encrypter.EncryptStream(new(bytes.Buffer), nil)
+		require.EqualError(t, e, "jweencryptstream: encryption algorithm 'A256GCM' not supported")
+
+		nonStreaming, e := encrypter.Encrypt([]byte("secret message"))
+		require.NoError(t, e)
+
+		serialized, e := nonStreaming.FullSerialize(json.Marshal)
+		require.NoError(t, e)
+
+		_, e = jweDecrypter.DecryptStream(strings.NewReader(serialized + "\n"))
+		require.EqualError(t, e, "jwedecryptstream: encryption algorithm 'A256GCM' not supported")
+
+		// streaming JWEs are not decrypted by Decrypt.
+		header, _, _ := bytes.Cut(jwe.Bytes(), []byte("\n"))
+
+		jweHeader, e := ariesjose.Deserialize(string(header))
+		require.NoError(t, e)
+
+		_, e = jweDecrypter.Decrypt(jweHeader)
+		require.EqualError(t, e, "jwedecrypt: encryption algorithm 'A256GCM-HKDF-1MB' not supported")
+	})
+
+	t.Run("authcrypt is not supported", func(t *testing.T) {
+		_, senderKHs, senderKIDs, _ := createRecipients(t, 1)
+
+		encrypter, e := ariesjose.NewJWEEncrypt(ariesjose.A256GCMHKDF1MB, EnvelopeEncodingType, "",
+			senderKIDs[0], senderKHs[senderKIDs[0]], recKeys, cryptoSvc)
+		require.NoError(t, e)
+
+		_, e = encrypter.EncryptStream(new(bytes.Buffer), nil)
+		require.EqualError(t, e, "jweencryptstream: authcrypt is not supported")
+	})
+}
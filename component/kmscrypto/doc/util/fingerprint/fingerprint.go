@@ -35,6 +35,12 @@ const (
 	P384PubKeyMultiCodec = 0x1201
 	// P521PubKeyMultiCodec for NIST P-521 public key in multicodec table.
 	P521PubKeyMultiCodec = 0x1202
+	// MLDSA44PubKeyMultiCodec for ML-DSA-44 public key in multicodec table.
+	MLDSA44PubKeyMultiCodec = 0x1210
+	// MLDSA65PubKeyMultiCodec for ML-DSA-65 public key in multicodec table.
+	MLDSA65PubKeyMultiCodec = 0x1211
+	// MLDSA87PubKeyMultiCodec for ML-DSA-87 public key in multicodec table.
+	MLDSA87PubKeyMultiCodec = 0x1212
 
 	// Default BLS 12-381 public key length in G2 field.
 	bls12381G2PublicKeyLen = 96
@@ -104,6 +110,20 @@ func CreateDIDKeyByJwk(jsonWebKey *jwk.JWK) (string, string, error) {
 		default:
 			return "", "", fmt.Errorf("unexpected OKP key type %T", key)
 		}
+	case "AKP":
+		code, err := akpCode(jsonWebKey.Algorithm)
+		if err != nil {
+			return "", "", err
+		}
+
+		bytes, err := jsonWebKey.PublicKeyBytes()
+		if err != nil {
+			return "", "", err
+		}
+
+		didKey, keyID := CreateDIDKeyByCode(code, bytes)
+
+		return didKey, keyID, nil
 	default:
 		return "", "", fmt.Errorf("unsupported kty %s", jsonWebKey.Kty)
 	}
@@ -132,6 +152,19 @@ func ecCodeAndCurve(ecCurve string) (uint64, elliptic.Curve, error) {
 	return code, curve, nil
 }
 
+func akpCode(alg string) (uint64, error) {
+	switch alg {
+	case jwk.MLDSA44Alg:
+		return MLDSA44PubKeyMultiCodec, nil
+	case jwk.MLDSA65Alg:
+		return MLDSA65PubKeyMultiCodec, nil
+	case jwk.MLDSA87Alg:
+		return MLDSA87PubKeyMultiCodec, nil
+	default:
+		return 0, fmt.Errorf("unsupported AKP alg %s", alg)
+	}
+}
+
 // KeyFingerprint generates a multicode fingerprint for pubKeyValue (raw key []byte).
 // It is mainly used as the controller ID (methodSpecification ID) of a did key.
 func KeyFingerprint(code uint64, pubKeyValue []byte) string {
@@ -202,7 +235,8 @@ func PubKeyFromDIDKey(didKey string) ([]byte, error) {
 
 	switch code {
 	case X25519PubKeyMultiCodec, ED25519PubKeyMultiCodec, BLS12381g2PubKeyMultiCodec, BLS12381g1g2PubKeyMultiCodec,
-		P256PubKeyMultiCodec, P384PubKeyMultiCodec, P521PubKeyMultiCodec:
+		P256PubKeyMultiCodec, P384PubKeyMultiCodec, P521PubKeyMultiCodec, MLDSA44PubKeyMultiCodec,
+		MLDSA65PubKeyMultiCodec, MLDSA87PubKeyMultiCodec:
 		break
 	default:
 		return nil, fmt.Errorf("pubKeyFromDIDKey: unsupported key multicodec code [0x%x]", code)
@@ -19,6 +19,8 @@ import (
 	"github.com/go-jose/go-jose/v3"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsa"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsaed25519"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk/jwksupport"
 )
@@ -337,6 +339,39 @@ func TestDIDKeyX25519(t *testing.T) {
 	require.Equal(t, x25519Base58, base58.Encode(pubKey))
 }
 
+func TestDIDKeyMLDSA(t *testing.T) {
+	privKey, err := mldsa.GenerateKey(mldsa.MLDSA65())
+	require.NoError(t, err)
+
+	jwkKey, err := jwksupport.JWKFromKey(privKey.PublicKey())
+	require.NoError(t, err)
+
+	didKey, keyID, err := CreateDIDKeyByJwk(jwkKey)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(didKey, "did:key:z"))
+	require.Equal(t, didKey+"#"+strings.TrimPrefix(didKey, "did:key:"), keyID)
+
+	pubKey, code, err := PubKeyFromFingerprint(strings.TrimPrefix(didKey, "did:key:"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(MLDSA65PubKeyMultiCodec), code)
+	require.Equal(t, privKey.PublicKey().Bytes(), pubKey)
+
+	pubKey, err = PubKeyFromDIDKey(didKey)
+	require.NoError(t, err)
+	require.Equal(t, privKey.PublicKey().Bytes(), pubKey)
+
+	t.Run("composite keys have no did:key codec", func(t *testing.T) {
+		compositeKey, err := mldsaed25519.GenerateKey()
+		require.NoError(t, err)
+
+		jwkKey, err := jwksupport.JWKFromKey(compositeKey.PublicKey())
+		require.NoError(t, err)
+
+		_, _, err = CreateDIDKeyByJwk(jwkKey)
+		require.EqualError(t, err, "unsupported AKP alg ML-DSA-65-Ed25519")
+	})
+}
+
 func TestPubKeyFromDIDKeyFailure(t *testing.T) {
 	_, err := PubKeyFromDIDKey("did:key:****")
 	require.EqualError(t, err, "pubKeyFromDIDKey: MethodIDFromDIDKey: not a valid did:key identifier "+
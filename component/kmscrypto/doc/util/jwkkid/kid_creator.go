@@ -27,6 +27,7 @@ import (
 
 	cryptoapi "github.com/hyperledger/aries-framework-go/spi/crypto"
 
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/x25519mlkem768"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk/jwksupport"
 )
@@ -34,7 +35,7 @@ import (
 var errInvalidKeyType = errors.New("key type is not supported")
 
 // CreateKID creates a KID value based on the marshalled keyBytes of type kt. This function should be called for
-// asymmetric public keys only (ECDSA DER or IEEE-P1363, ED25519, X25519, BLS12381G2).
+// asymmetric public keys only (ECDSA DER or IEEE-P1363, ED25519, X25519, BLS12381G2, ML-DSA, RSA-OAEP).
 // returns:
 //   - base64 raw (no padding) URL encoded KID
 //   - error in case of error
@@ -53,6 +54,13 @@ func CreateKID(keyBytes []byte, kt kms.KeyType) (string, error) {
 		}
 
 		return x25519KID, nil
+	case kms.X25519MLKEM768ECDHKWType: // hybrid X25519 JWK is not supported by go jose either.
+		hybridKID, err := createX25519MLKEM768KID(keyBytes)
+		if err != nil {
+			return "", fmt.Errorf("createKID: %w", err)
+		}
+
+		return hybridKID, nil
 	case kms.BLS12381G2Type: // BBS+ as JWK thumbprint.
 		bbsKID, err := createBLS12381G2KID(keyBytes)
 		if err != nil {
@@ -213,6 +221,16 @@ func BuildJWK(keyBytes []byte, kt kms.KeyType) (*jwk.JWK, error) { //nolint: goc
 		if err != nil {
 			return nil, fmt.Errorf("buildJWK: failed to build JWK from ecdh key: %w", err)
 		}
+	case kms.MLDSA44Type, kms.MLDSA65Type, kms.MLDSA87Type, kms.MLDSA65ED25519Type:
+		j, err = jwksupport.PubKeyBytesToJWK(keyBytes, kt)
+		if err != nil {
+			return nil, fmt.Errorf("buildJWK: failed to build JWK from ML-DSA key: %w", err)
+		}
+	case kms.RSAOAEPType:
+		j, err = jwksupport.PubKeyBytesToJWK(keyBytes, kt)
+		if err != nil {
+			return nil, fmt.Errorf("buildJWK: failed to build JWK from RSA key: %w", err)
+		}
 	case kms.X25519ECDHKWType:
 		pubKey, err := unmarshalECDHKey(keyBytes)
 		if err != nil {
@@ -223,6 +241,16 @@ func BuildJWK(keyBytes []byte, kt kms.KeyType) (*jwk.JWK, error) { //nolint: goc
 		if err != nil {
 			return nil, fmt.Errorf("buildJWK: failed to build JWK from X25519 key: %w", err)
 		}
+	case kms.X25519MLKEM768ECDHKWType:
+		pubKey, err := unmarshalECDHKey(keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("buildJWK: failed to unmarshal public key from X25519MLKEM768 key: %w", err)
+		}
+
+		j, err = jwksupport.JWKFromX25519MLKEM768Key(pubKey.X)
+		if err != nil {
+			return nil, fmt.Errorf("buildJWK: failed to build JWK from X25519MLKEM768 key: %w", err)
+		}
 	default:
 		return nil, fmt.Errorf("buildJWK: %w: '%s'", errInvalidKeyType, kt)
 	}
@@ -318,6 +346,23 @@ func buildX25519JWK(keyBytes []byte) (string, error) {
 	return j, nil
 }
 
+func createX25519MLKEM768KID(marshalledKey []byte) (string, error) {
+	const x25519MLKEM768ThumbprintTemplate = `{"crv":"X25519MLKEM768","kty":"OKP","x":"%s"}`
+
+	compositeKey, err := unmarshalECDHKey(marshalledKey)
+	if err != nil {
+		return "", fmt.Errorf("createX25519MLKEM768KID: %w", err)
+	}
+
+	if len(compositeKey.X) != x25519mlkem768.PublicKeySize {
+		return "", errors.New("createX25519MLKEM768KID: invalid ECDH X25519MLKEM768 key")
+	}
+
+	j := fmt.Sprintf(x25519MLKEM768ThumbprintTemplate, base64.RawURLEncoding.EncodeToString(compositeKey.X))
+
+	return base64.RawURLEncoding.EncodeToString(sha256Sum(j)), nil
+}
+
 func createBLS12381G2KID(keyBytes []byte) (string, error) {
 	const (
 		bls12381g2ThumbprintTemplate = `{"crv":"Bls12381g2","kty":"OKP","x":"%s"}`
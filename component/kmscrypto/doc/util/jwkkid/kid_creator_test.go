@@ -11,6 +11,7 @@ import (
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
@@ -30,6 +31,9 @@ import (
 	cryptoapi "github.com/hyperledger/aries-framework-go/spi/crypto"
 
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsa"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsaed25519"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/x25519mlkem768"
 	ecdhpb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
 )
 
@@ -282,6 +286,47 @@ func TestBuildJWKX25519(t *testing.T) {
 	})
 }
 
+func TestCreateKIDAndBuildJWK_X25519MLKEM768(t *testing.T) {
+	privKey, err := x25519mlkem768.GenerateKey()
+	require.NoError(t, err)
+
+	ecdhKeyMarshalled, err := json.Marshal(&cryptoapi.PublicKey{
+		Curve: "X25519MLKEM768",
+		X:     privKey.PublicKey().Bytes(),
+		Type:  ecdhpb.KeyType_OKP.String(),
+	})
+	require.NoError(t, err)
+
+	kid, err := CreateKID(ecdhKeyMarshalled, kms.X25519MLKEM768ECDHKWType)
+	require.NoError(t, err)
+
+	j, err := BuildJWK(ecdhKeyMarshalled, kms.X25519MLKEM768ECDHKWType)
+	require.NoError(t, err)
+	require.Equal(t, "X25519MLKEM768", j.Crv)
+
+	// the KID is the thumbprint of the JWK with its required members only.
+	mJWK, err := j.MarshalJSON()
+	require.NoError(t, err)
+
+	expectedThumbprint := sha256.Sum256([]byte(fmt.Sprintf(`{"crv":"X25519MLKEM768","kty":"OKP","x":"%s"}`,
+		base64.RawURLEncoding.EncodeToString(privKey.PublicKey().Bytes()))))
+	require.Equal(t, base64.RawURLEncoding.EncodeToString(expectedThumbprint[:]), kid)
+	require.Contains(t, string(mJWK), base64.RawURLEncoding.EncodeToString(privKey.PublicKey().Bytes()))
+
+	badKeyMarshalled, err := json.Marshal(&cryptoapi.PublicKey{
+		Curve: "X25519MLKEM768",
+		X:     privKey.PublicKey().X25519(),
+	})
+	require.NoError(t, err)
+
+	_, err = CreateKID(badKeyMarshalled, kms.X25519MLKEM768ECDHKWType)
+	require.EqualError(t, err, "createKID: createX25519MLKEM768KID: invalid ECDH X25519MLKEM768 key")
+
+	_, err = BuildJWK(badKeyMarshalled, kms.X25519MLKEM768ECDHKWType)
+	require.EqualError(t, err, "buildJWK: failed to build JWK from X25519MLKEM768 key: create JWK: "+
+		"marshalX25519MLKEM768: invalid key")
+}
+
 func TestBuildJWK_Ed25519(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		pubKey, _, err := ed25519.GenerateKey(rand.Reader)
@@ -367,3 +412,40 @@ func createED25519KID(t *testing.T, keyBytes []byte) string {
 
 	return base64.RawURLEncoding.EncodeToString(thumbprint)
 }
+
+func TestCreateMLDSAKID(t *testing.T) {
+	privKey, err := mldsa.GenerateKey(mldsa.MLDSA65())
+	require.NoError(t, err)
+
+	pubKeyBytes := privKey.PublicKey().Bytes()
+
+	kid, err := CreateKID(pubKeyBytes, kms.MLDSA65Type)
+	require.NoError(t, err)
+
+	j := fmt.Sprintf(`{"alg":"ML-DSA-65","kty":"AKP","pub":"%s"}`, base64.RawURLEncoding.EncodeToString(pubKeyBytes))
+	require.Equal(t, base64.RawURLEncoding.EncodeToString(sha256Sum(j)), kid)
+
+	_, err = CreateKID(pubKeyBytes, kms.MLDSA44Type)
+	require.ErrorContains(t, err, "buildJWK: failed to build JWK from ML-DSA key")
+
+	compositeKey, err := mldsaed25519.GenerateKey()
+	require.NoError(t, err)
+
+	kid, err = CreateKID(compositeKey.PublicKey().Bytes(), kms.MLDSA65ED25519Type)
+	require.NoError(t, err)
+	require.NotEmpty(t, kid)
+}
+
+func TestCreateRSAOAEPKID(t *testing.T) {
+	privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	kid, err := CreateKID(x509.MarshalPKCS1PublicKey(&privKey.PublicKey), kms.RSAOAEPType)
+	require.NoError(t, err)
+
+	j := fmt.Sprintf(`{"e":"AQAB","kty":"RSA","n":"%s"}`, base64.RawURLEncoding.EncodeToString(privKey.N.Bytes()))
+	require.Equal(t, base64.RawURLEncoding.EncodeToString(sha256Sum(j)), kid)
+
+	_, err = CreateKID([]byte("invalid RSA key"), kms.RSAOAEPType)
+	require.ErrorContains(t, err, "buildJWK: failed to build JWK from RSA key")
+}
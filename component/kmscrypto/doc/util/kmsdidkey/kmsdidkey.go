@@ -34,6 +34,9 @@ var keyTypeCodecs = map[kms.KeyType]uint64{
 	kms.ECDSAP384TypeDER:       fingerprint.P384PubKeyMultiCodec,
 	kms.ECDSAP521TypeIEEEP1363: fingerprint.P521PubKeyMultiCodec,
 	kms.ECDSAP521TypeDER:       fingerprint.P521PubKeyMultiCodec,
+	kms.MLDSA44Type:            fingerprint.MLDSA44PubKeyMultiCodec,
+	kms.MLDSA65Type:            fingerprint.MLDSA65PubKeyMultiCodec,
+	kms.MLDSA87Type:            fingerprint.MLDSA87PubKeyMultiCodec,
 
 	// encryption keys
 	kms.X25519ECDHKWType:   fingerprint.X25519PubKeyMultiCodec,
@@ -51,6 +51,9 @@ func TestBuildDIDKeyByKMSKeyType(t *testing.T) {
 	_, p521DERKey, err := k.CreateAndExportPubKeyBytes(kmsapi.ECDSAP521TypeDER)
 	require.NoError(t, err)
 
+	_, mldsa65Key, err := k.CreateAndExportPubKeyBytes(kmsapi.MLDSA65Type)
+	require.NoError(t, err)
+
 	_, x25519Key, err := k.CreateAndExportPubKeyBytes(kmsapi.X25519ECDHKWType)
 	require.NoError(t, err)
 
@@ -117,6 +120,11 @@ func TestBuildDIDKeyByKMSKeyType(t *testing.T) {
 			keyBytes: p521DERKey,
 			keyType:  kmsapi.ECDSAP521TypeDER,
 		},
+		{
+			name:     "test MLDSA65Type key",
+			keyBytes: mldsa65Key,
+			keyType:  kmsapi.MLDSA65Type,
+		},
 		{
 			name:     "test X25519ECDHKWType key",
 			keyBytes: x25519Key,
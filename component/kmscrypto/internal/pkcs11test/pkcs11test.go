@@ -0,0 +1,54 @@
+//go:build pkcs11
+// +build pkcs11
+
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package pkcs11test opens the PKCS#11 token used by the tests of the PKCS#11 packages.
+package pkcs11test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/pkcs11"
+)
+
+const (
+	defaultTokenLabel = "aries"
+	defaultPIN        = "1234"
+)
+
+// OpenToken opens the token described by the PKCS11_MODULE, PKCS11_TOKEN_LABEL and PKCS11_PIN environment
+// variables, closing it at the end of the test. The test is skipped if PKCS11_MODULE is not set.
+func OpenToken(t *testing.T) *pkcs11.Token {
+	t.Helper()
+
+	modulePath := os.Getenv("PKCS11_MODULE")
+	if modulePath == "" {
+		t.Skip("PKCS11_MODULE is not set, skipping PKCS#11 test")
+	}
+
+	token, err := pkcs11.Open(modulePath, envOrDefault("PKCS11_TOKEN_LABEL", defaultTokenLabel),
+		envOrDefault("PKCS11_PIN", defaultPIN))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, token.Close())
+	})
+
+	return token
+}
+
+func envOrDefault(name, defaultValue string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+
+	return defaultValue
+}
@@ -15,6 +15,12 @@ import (
 // key ID could be found.
 var ErrKeyNotFound = errors.New("key not found")
 
+// ErrKeyDisabled is the error returned by a KMS when a disabled key is requested for use.
+var ErrKeyDisabled = errors.New("key is disabled")
+
+// ErrKeyDestroyed is the error returned by a KMS when a destroyed key is requested for use or update.
+var ErrKeyDestroyed = errors.New("key is destroyed")
+
 // CryptoBox is a libsodium crypto service used by legacy authcrypt packer.
 // TODO remove this service when legacy packer is retired from the framework.
 type CryptoBox interface {
@@ -7,9 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package kms
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 
+	"github.com/hyperledger/aries-framework-go/component/log"
 	"github.com/hyperledger/aries-framework-go/spi/kms"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
@@ -17,12 +19,33 @@ import (
 // AriesWrapperStoreName is the store name used when creating a KMS store using kms.NewAriesProviderWrapper.
 const AriesWrapperStoreName = "kmsdb"
 
+const (
+	// KeysetTagName is the tag name marking the keyset entries in a KMS store created using
+	// kms.NewAriesProviderWrapper.
+	KeysetTagName = "KMSKeyset"
+	// MetadataTagName is the tag name marking the key metadata entries in a KMS store created using
+	// kms.NewAriesProviderWrapper.
+	MetadataTagName = "KMSKeyMetadata"
+	// KeyTypeTagName is the tag name holding the key type of the key metadata entries.
+	KeyTypeTagName = "KMSKeyType"
+	// KeyStateTagName is the tag name holding the key state of the key metadata entries.
+	KeyStateTagName = "KMSKeyState"
+
+	metadataKeyPrefix = "metadata_"
+
+	// allKeysetsTaggedMarkerKey is the key of the entry marking the stores which have been tagging their keysets
+	// since they were created.
+	allKeysetsTaggedMarkerKey = "kms_store_all_keysets_tagged"
+)
+
+var logger = log.New("aries-framework/kms")
+
 type ariesProviderKMSStoreWrapper struct {
 	store storage.Store
 }
 
 func (a *ariesProviderKMSStoreWrapper) Put(keysetID string, key []byte) error {
-	return a.store.Put(keysetID, key)
+	return a.store.Put(keysetID, key, storage.Tag{Name: KeysetTagName})
 }
 
 func (a *ariesProviderKMSStoreWrapper) Get(keysetID string) ([]byte, error) {
@@ -43,15 +66,251 @@ func (a *ariesProviderKMSStoreWrapper) Delete(keysetID string) error {
 	return a.store.Delete(keysetID)
 }
 
+// KeysetIDs returns the IDs of the keysets tagged on Put. The keysets stored before tagging was introduced are tagged
+// first if they have key metadata, keysets stored without tag nor metadata can't be listed.
+func (a *ariesProviderKMSStoreWrapper) KeysetIDs() ([]string, error) {
+	ids, err := a.queryKeys(KeysetTagName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keysets: %w", err)
+	}
+
+	listed := make(map[string]bool, len(ids))
+
+	for _, id := range ids {
+		listed[id] = true
+	}
+
+	metadata, err := a.QueryMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range metadata {
+		if listed[m.KeyID] || m.State == kms.KeyStateDestroyed {
+			continue
+		}
+
+		err = a.tagKeyset(m.KeyID)
+		if errors.Is(err, storage.ErrDataNotFound) {
+			// the keyset of a rotated key is deleted, the metadata of the key is kept.
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to tag keyset '%s': %w", m.KeyID, err)
+		}
+
+		ids = append(ids, m.KeyID)
+	}
+
+	return ids, nil
+}
+
+// AllKeysetsListed reports whether the store has been tagging its keysets since it was created, it returns false for
+// the stores created before keysets were tagged, whose keysets stored without key metadata can't be listed.
+func (a *ariesProviderKMSStoreWrapper) AllKeysetsListed() (bool, error) {
+	_, err := a.store.Get(allKeysetsTaggedMarkerKey)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to get store marker: %w", err)
+	}
+
+	return true, nil
+}
+
+// markIfEmpty marks the store as tagging all its keysets if it holds neither keysets nor key metadata.
+//
+// Keysets stored before tagging was introduced can't be queried: a store holding only such keysets is
+// indistinguishable from an empty one and gets marked too, their IDs must then be provided to walk them.
+func (a *ariesProviderKMSStoreWrapper) markIfEmpty() error {
+	marked, err := a.AllKeysetsListed()
+	if err != nil || marked {
+		return err
+	}
+
+	for _, tagName := range []string{KeysetTagName, MetadataTagName} {
+		keys, e := a.queryKeys(tagName)
+		if e != nil {
+			return e
+		}
+
+		if len(keys) > 0 {
+			return nil
+		}
+	}
+
+	return a.store.Put(allKeysetsTaggedMarkerKey, []byte("true"))
+}
+
+// tagKeyset adds the keyset tag to the keyset id, stored without tags.
+func (a *ariesProviderKMSStoreWrapper) tagKeyset(id string) error {
+	value, err := a.store.Get(id)
+	if err != nil {
+		return err
+	}
+
+	return a.store.Put(id, value, storage.Tag{Name: KeysetTagName})
+}
+
+func (a *ariesProviderKMSStoreWrapper) queryKeys(expression string) ([]string, error) {
+	iterator, err := a.store.Query(expression)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+
+	defer closeIterator(iterator)
+
+	var keys []string
+
+	for {
+		more, err := iterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate: %w", err)
+		}
+
+		if !more {
+			break
+		}
+
+		key, err := iterator.Key()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get key: %w", err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (a *ariesProviderKMSStoreWrapper) PutMetadata(metadata *kms.KeyMetadata) error {
+	if metadata == nil || metadata.KeyID == "" {
+		return errors.New("key metadata must have a key ID")
+	}
+
+	metadataBytes, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal key metadata: %w", err)
+	}
+
+	return a.store.Put(metadataKeyPrefix+metadata.KeyID, metadataBytes,
+		storage.Tag{Name: MetadataTagName},
+		storage.Tag{Name: KeyTypeTagName, Value: string(metadata.KeyType)},
+		storage.Tag{Name: KeyStateTagName, Value: string(metadata.State)},
+	)
+}
+
+func (a *ariesProviderKMSStoreWrapper) GetMetadata(keysetID string) (*kms.KeyMetadata, error) {
+	metadataBytes, err := a.Get(metadataKeyPrefix + keysetID)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &kms.KeyMetadata{}
+
+	err = json.Unmarshal(metadataBytes, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal key metadata: %w", err)
+	}
+
+	return metadata, nil
+}
+
+func (a *ariesProviderKMSStoreWrapper) DeleteMetadata(keysetID string) error {
+	return a.store.Delete(metadataKeyPrefix + keysetID)
+}
+
+func (a *ariesProviderKMSStoreWrapper) QueryMetadata(opts ...kms.ListOpts) ([]*kms.KeyMetadata, error) {
+	listOpts := kms.NewListOpt()
+
+	for _, opt := range opts {
+		opt(listOpts)
+	}
+
+	// query on the most selective indexed tag, the remaining filters are applied on the results.
+	expression := MetadataTagName
+
+	switch {
+	case listOpts.KeyType() != "":
+		expression = KeyTypeTagName + ":" + string(listOpts.KeyType())
+	case listOpts.State() != "":
+		expression = KeyStateTagName + ":" + string(listOpts.State())
+	}
+
+	iterator, err := a.store.Query(expression)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query key metadata: %w", err)
+	}
+
+	defer closeIterator(iterator)
+
+	var result []*kms.KeyMetadata
+
+	for {
+		more, err := iterator.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate over key metadata: %w", err)
+		}
+
+		if !more {
+			break
+		}
+
+		metadataBytes, err := iterator.Value()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get key metadata value: %w", err)
+		}
+
+		metadata := &kms.KeyMetadata{}
+
+		err = json.Unmarshal(metadataBytes, metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal key metadata: %w", err)
+		}
+
+		if listOpts.Match(metadata) {
+			result = append(result, metadata)
+		}
+	}
+
+	return result, nil
+}
+
+func closeIterator(iterator storage.Iterator) {
+	err := iterator.Close()
+	if err != nil {
+		logger.Warnf("failed to close iterator: %s", err)
+	}
+}
+
 // NewAriesProviderWrapper returns an implementation of the kms.Store interface that wraps an
 // Aries provider implementation, allowing it to be used with a KMS.
+// The returned store also implements kms.MetadataStore, indexing the key metadata with tags, and kms.IterableStore:
+// the keysets of a store opened empty for the first time are all listed, those of a store holding keysets put by
+// versions that didn't tag them are listed only if they have key metadata.
 func NewAriesProviderWrapper(provider storage.Provider) (kms.Store, error) {
 	store, err := provider.OpenStore(AriesWrapperStoreName)
 	if err != nil {
 		return nil, err
 	}
 
+	err = provider.SetStoreConfig(AriesWrapperStoreName, storage.StoreConfiguration{
+		TagNames: []string{KeysetTagName, MetadataTagName, KeyTypeTagName, KeyStateTagName},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set store configuration: %w", err)
+	}
+
 	storeWrapper := ariesProviderKMSStoreWrapper{store: store}
 
+	err = storeWrapper.markIfEmpty()
+	if err != nil {
+		// an unmarked store only requires the keyset IDs to be provided to walk its keysets.
+		logger.Warnf("failed to mark the kms store as tagging all its keysets: %s", err)
+	}
+
 	return &storeWrapper, nil
 }
@@ -0,0 +1,406 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package hdkms provides a LocalKMS deriving its signing keys from a seed, so that they can be regenerated from the
+// seed (typically encoded as a BIP39 mnemonic, see crypto/primitive/hdkey) when the KMS storage is lost.
+//
+// Ed25519 keys are derived with SLIP-0010 and secp256k1 keys with BIP32, along hardened paths m/0'/<branch>'/<index>'
+// where the branch depends on the key type (see DerivationPath) and the index counts the keys of that type. Derived
+// keys are stored like any LocalKMS key, with the JWK thumbprint of their public key as key ID and their derivation
+// path in the PathLabel label of their metadata. The next index of each key type is saved in the KMS storage, so that
+// the index of a deleted or destroyed key is never reused. Recover derives the keys of a seed again after the storage
+// was lost.
+//
+// Other key types are created randomly by the underlying LocalKMS and cannot be recovered.
+package hdkms
+
+import (
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/hdkey"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/jwkkid"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/localkms"
+)
+
+// PathLabel is the metadata label holding the derivation path of derived keys.
+const PathLabel = "hdPath"
+
+const (
+	pathPrefix = "m/0'/"
+
+	indexKeyIDPrefix = localkms.StateKeyIDPrefix + "hdkms_next_index_"
+)
+
+// nolint:gochecknoglobals
+var branches = map[kmsapi.KeyType]uint32{
+	kmsapi.ED25519Type:             0,
+	kmsapi.ECDSASecp256k1IEEEP1363: 1,
+	kmsapi.ECDSASecp256k1DER:       2, // nolint:gomnd
+}
+
+var errMetadataNotSupported = errors.New("kms store does not support key metadata")
+
+// HDKMS is a LocalKMS deriving its Ed25519 and secp256k1 keys from a seed.
+type HDKMS struct {
+	*localkms.LocalKMS
+	seed  []byte
+	keys  kmsapi.Store
+	store kmsapi.MetadataStore
+	mutex sync.Mutex
+}
+
+var _ kmsapi.KeyManager = (*HDKMS)(nil)
+
+// New creates a new HDKMS deriving its keys from seed, of 16 to 64 bytes. The storage of p must implement
+// kms.MetadataStore (as the stores created with kms.NewAriesProviderWrapper do), to keep the derivation paths of the
+// keys.
+func New(primaryKeyURI string, p kmsapi.Provider, seed []byte) (*HDKMS, error) {
+	ms, ok := p.StorageProvider().(kmsapi.MetadataStore)
+	if !ok {
+		return nil, fmt.Errorf("new: %w", errMetadataNotSupported)
+	}
+
+	// validates the seed.
+	_, err := hdkey.DeriveEd25519(seed, "m")
+	if err != nil {
+		return nil, fmt.Errorf("new: %w", err)
+	}
+
+	localKMS, err := localkms.New(primaryKeyURI, p)
+	if err != nil {
+		return nil, fmt.Errorf("new: %w", err)
+	}
+
+	return &HDKMS{LocalKMS: localKMS, seed: seed, keys: p.StorageProvider(), store: ms}, nil
+}
+
+// Derivable reports whether the keys of type kt are derived from the seed.
+func Derivable(kt kmsapi.KeyType) bool {
+	_, ok := branches[kt]
+
+	return ok
+}
+
+// DerivationPath returns the derivation path of the key of type kt at index.
+func DerivationPath(kt kmsapi.KeyType, index uint32) (string, error) {
+	branch, ok := branches[kt]
+	if !ok {
+		return "", fmt.Errorf("key type '%s' cannot be derived", kt)
+	}
+
+	return fmt.Sprintf("%s%d'/%d'", pathPrefix, branch, index), nil
+}
+
+// Create a new key of type kt. Keys of derivable types are derived at the next index of their type, other keys are
+// created by LocalKMS.
+// Returns:
+//   - keyID of the handle
+//   - handle instance (to private key)
+//   - error if failure
+func (k *HDKMS) Create(kt kmsapi.KeyType, opts ...kmsapi.KeyOpts) (string, interface{}, error) {
+	if !Derivable(kt) {
+		return k.LocalKMS.Create(kt, opts...)
+	}
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	index, err := k.nextIndex(kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("create: %w", err)
+	}
+
+	keyID, err := k.derive(kt, index)
+	if err != nil {
+		return "", nil, fmt.Errorf("create: %w", err)
+	}
+
+	err = k.saveNextIndex(kt, index+1)
+	if err != nil {
+		return "", nil, fmt.Errorf("create: %w", err)
+	}
+
+	kh, err := k.Get(keyID)
+	if err != nil {
+		return "", nil, fmt.Errorf("create: %w", err)
+	}
+
+	return keyID, kh, nil
+}
+
+// CreateAndExportPubKeyBytes creates a new key of type kt, as Create does, and returns its keyID and public key.
+func (k *HDKMS) CreateAndExportPubKeyBytes(kt kmsapi.KeyType, opts ...kmsapi.KeyOpts) (string, []byte, error) {
+	keyID, _, err := k.Create(kt, opts...)
+	if err != nil {
+		return "", nil, fmt.Errorf("createAndExportPubKeyBytes: %w", err)
+	}
+
+	pubKeyBytes, _, err := k.ExportPubKeyBytes(keyID)
+	if err != nil {
+		return "", nil, fmt.Errorf("createAndExportPubKeyBytes: %w", err)
+	}
+
+	return keyID, pubKeyBytes, nil
+}
+
+// Rotate replaces the key referenced by keyID with a new key of type kt. For derivable types, the new key is derived
+// at the next index, keeps the labels and usage of the old key, and the old key is deleted (it can still be
+// recovered). Other keys are rotated by LocalKMS.
+func (k *HDKMS) Rotate(kt kmsapi.KeyType, keyID string, opts ...kmsapi.KeyOpts) (string, interface{}, error) {
+	if !Derivable(kt) {
+		return k.LocalKMS.Rotate(kt, keyID, opts...)
+	}
+
+	previous, err := k.GetMetadata(keyID)
+	if err != nil {
+		return "", nil, fmt.Errorf("rotate: %w", err)
+	}
+
+	newID, kh, err := k.Create(kt, opts...)
+	if err != nil {
+		return "", nil, fmt.Errorf("rotate: %w", err)
+	}
+
+	err = k.UpdateMetadata(newID, kmsapi.WithLabels(previous.Labels), kmsapi.WithUsage(previous.Usage...))
+	if err != nil {
+		return "", nil, fmt.Errorf("rotate: %w", err)
+	}
+
+	err = k.Delete(keyID)
+	if err != nil {
+		return "", nil, fmt.Errorf("rotate: %w", err)
+	}
+
+	return newID, kh, nil
+}
+
+// UpdateMetadata sets the labels and usage of the key referenced by keyID described in `opts`. The PathLabel label of
+// derived keys cannot be changed.
+func (k *HDKMS) UpdateMetadata(keyID string, opts ...kmsapi.MetadataOpts) error {
+	metadataOpts := kmsapi.NewMetadataOpt()
+
+	for _, opt := range opts {
+		opt(metadataOpts)
+	}
+
+	if metadataOpts.Labels() != nil {
+		path, err := k.Path(keyID)
+		if err != nil {
+			return fmt.Errorf("updateMetadata: %w", err)
+		}
+
+		labels := map[string]string{}
+
+		for name, value := range metadataOpts.Labels() {
+			labels[name] = value
+		}
+
+		delete(labels, PathLabel)
+
+		if path != "" {
+			labels[PathLabel] = path
+		}
+
+		opts = append(opts, kmsapi.WithLabels(labels))
+	}
+
+	return k.LocalKMS.UpdateMetadata(keyID, opts...)
+}
+
+// Path returns the derivation path of the key referenced by keyID, or an empty path if it was not derived.
+func (k *HDKMS) Path(keyID string) (string, error) {
+	metadata, err := k.GetMetadata(keyID)
+	if err != nil {
+		return "", fmt.Errorf("path: %w", err)
+	}
+
+	return metadata.Labels[PathLabel], nil
+}
+
+// Recover derives the first count keys of type kt from the seed and stores the ones missing from the KMS storage,
+// typically after the storage was lost. Keys get the same key IDs as when they were first created, so the DIDs and
+// credentials referencing them remain usable. Destroyed keys are not recovered.
+// Returns the key IDs of the recovered keys, in index order.
+func (k *HDKMS) Recover(kt kmsapi.KeyType, count int) ([]string, error) {
+	if !Derivable(kt) {
+		return nil, fmt.Errorf("recover: key type '%s' cannot be derived", kt)
+	}
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	keyIDs := make([]string, 0, count)
+
+	for i := 0; i < count; i++ {
+		keyID, err := k.derive(kt, uint32(i))
+		if errors.Is(err, kms.ErrKeyDestroyed) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("recover: %w", err)
+		}
+
+		keyIDs = append(keyIDs, keyID)
+	}
+
+	next, err := k.nextIndex(kt)
+	if err != nil {
+		return nil, fmt.Errorf("recover: %w", err)
+	}
+
+	if uint32(count) > next {
+		next = uint32(count)
+	}
+
+	err = k.saveNextIndex(kt, next)
+	if err != nil {
+		return nil, fmt.Errorf("recover: %w", err)
+	}
+
+	return keyIDs, nil
+}
+
+// derive derives the key of type kt at index and stores it, unless it is already stored. It returns its key ID, or an
+// error wrapping kms.ErrKeyDestroyed if the key was destroyed.
+func (k *HDKMS) derive(kt kmsapi.KeyType, index uint32) (string, error) {
+	path, err := DerivationPath(kt, index)
+	if err != nil {
+		return "", err
+	}
+
+	var (
+		privKey interface{}
+		pubKey  []byte
+		kidType = kt
+	)
+
+	if kt == kmsapi.ED25519Type {
+		edKey, e := hdkey.DeriveEd25519(k.seed, path)
+		if e != nil {
+			return "", e
+		}
+
+		privKey, pubKey = edKey, edKey.Public().(ed25519.PublicKey)
+	} else {
+		ecKey, e := hdkey.DeriveSecp256k1(k.seed, path)
+		if e != nil {
+			return "", e
+		}
+
+		// the JWK thumbprint of secp256k1 keys does not depend on their signature format.
+		privKey, pubKey = ecKey, elliptic.Marshal(ecKey.Curve, ecKey.X, ecKey.Y)
+		kidType = kmsapi.ECDSASecp256k1IEEEP1363
+	}
+
+	keyID, err := jwkkid.CreateKID(pubKey, kidType)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate kid: %w", err)
+	}
+
+	metadata, err := k.store.GetMetadata(keyID)
+	if err == nil && metadata.State == kmsapi.KeyStateDestroyed {
+		return "", fmt.Errorf("key '%s': %w", keyID, kms.ErrKeyDestroyed)
+	}
+
+	if err != nil && !errors.Is(err, kms.ErrKeyNotFound) {
+		return "", fmt.Errorf("failed to get metadata of key '%s': %w", keyID, err)
+	}
+
+	_, err = k.keys.Get(keyID)
+	if err == nil {
+		return keyID, nil
+	}
+
+	if !errors.Is(err, kms.ErrKeyNotFound) {
+		return "", fmt.Errorf("failed to get key '%s': %w", keyID, err)
+	}
+
+	_, _, err = k.ImportPrivateKey(privKey, kt, kmsapi.WithKeyID(keyID))
+	if err != nil {
+		return "", fmt.Errorf("failed to store derived key: %w", err)
+	}
+
+	err = k.LocalKMS.UpdateMetadata(keyID, kmsapi.WithLabels(map[string]string{PathLabel: path}))
+	if err != nil {
+		return "", err
+	}
+
+	return keyID, nil
+}
+
+// nextIndex returns the next index of the keys of type kt: the saved one, or the index following the highest index
+// of the derived keys of type kt if it is higher (eg. for keys derived before the next index was saved).
+func (k *HDKMS) nextIndex(kt kmsapi.KeyType) (uint32, error) {
+	next, err := k.savedNextIndex(kt)
+	if err != nil {
+		return 0, err
+	}
+
+	keys, err := k.store.QueryMetadata(kmsapi.WithKeyTypeFilter(kt))
+	if err != nil {
+		return 0, err
+	}
+
+	prefix, err := DerivationPath(kt, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	prefix = strings.TrimSuffix(prefix, "0'")
+
+	for _, metadata := range keys {
+		path := metadata.Labels[PathLabel]
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+
+		index, e := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(path, prefix), "'"), 10, 31)
+		if e == nil && uint32(index) >= next {
+			next = uint32(index) + 1
+		}
+	}
+
+	return next, nil
+}
+
+// savedNextIndex returns the next index of the keys of type kt saved in the KMS storage, 0 if none is saved.
+func (k *HDKMS) savedNextIndex(kt kmsapi.KeyType) (uint32, error) {
+	data, err := k.keys.Get(indexKeyIDPrefix + string(kt))
+	if errors.Is(err, kms.ErrKeyNotFound) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to get next index of '%s' keys: %w", kt, err)
+	}
+
+	index, err := strconv.ParseUint(string(data), 10, 31)
+	if err != nil {
+		return 0, fmt.Errorf("invalid next index of '%s' keys: %w", kt, err)
+	}
+
+	return uint32(index), nil
+}
+
+// saveNextIndex saves index as the next index of the keys of type kt in the KMS storage.
+func (k *HDKMS) saveNextIndex(kt kmsapi.KeyType, index uint32) error {
+	err := k.keys.Put(indexKeyIDPrefix+string(kt), []byte(strconv.FormatUint(uint64(index), 10)))
+	if err != nil {
+		return fmt.Errorf("failed to save next index of '%s' keys: %w", kt, err)
+	}
+
+	return nil
+}
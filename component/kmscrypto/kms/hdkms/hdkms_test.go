@@ -0,0 +1,231 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package hdkms
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/google/tink/go/keyset"
+	"github.com/stretchr/testify/require"
+
+	mockstorage "github.com/hyperledger/aries-framework-go/component/storageutil/mock/storage"
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/hdkey"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/component/kmscrypto/mock/kms"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/secretlock/noop"
+)
+
+const (
+	testMasterKeyURI = "local-lock://test/key/uri"
+	testMnemonic     = "legal winner thank year wave sausage worth useful legal winner thank yellow"
+)
+
+func newHDKMS(t *testing.T) *HDKMS {
+	t.Helper()
+
+	seed, err := hdkey.MnemonicToSeed(testMnemonic, "")
+	require.NoError(t, err)
+
+	p, err := mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{})
+	require.NoError(t, err)
+
+	k, err := New(testMasterKeyURI, p, seed)
+	require.NoError(t, err)
+
+	return k
+}
+
+func TestHDKMS_CreateAndRecover(t *testing.T) {
+	k := newHDKMS(t)
+
+	var edKeyIDs []string
+
+	for i := 0; i < 3; i++ {
+		keyID, pubKey, err := k.CreateAndExportPubKeyBytes(kmsapi.ED25519Type)
+		require.NoError(t, err)
+		require.Len(t, pubKey, ed25519.PublicKeySize)
+
+		path, err := k.Path(keyID)
+		require.NoError(t, err)
+
+		expected, err := DerivationPath(kmsapi.ED25519Type, uint32(i))
+		require.NoError(t, err)
+		require.Equal(t, expected, path)
+
+		edKeyIDs = append(edKeyIDs, keyID)
+	}
+
+	secpKeyID, _, err := k.Create(kmsapi.ECDSASecp256k1IEEEP1363)
+	require.NoError(t, err)
+
+	path, err := k.Path(secpKeyID)
+	require.NoError(t, err)
+	require.Equal(t, "m/0'/1'/0'", path)
+
+	secpDERKeyID, _, err := k.Create(kmsapi.ECDSASecp256k1DER)
+	require.NoError(t, err)
+
+	// keys that cannot be derived are random.
+	randomKeyID, _, err := k.Create(kmsapi.X25519ECDHKWType)
+	require.NoError(t, err)
+
+	path, err = k.Path(randomKeyID)
+	require.NoError(t, err)
+	require.Empty(t, path)
+
+	// the derived keys sign.
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	for _, keyID := range []string{edKeyIDs[0], secpKeyID, secpDERKeyID} {
+		kh, e := k.Get(keyID)
+		require.NoError(t, e)
+
+		sig, e := c.Sign([]byte("test message"), kh)
+		require.NoError(t, e)
+
+		pubKH, e := kh.(*keyset.Handle).Public()
+		require.NoError(t, e)
+		require.NoError(t, c.Verify(sig, []byte("test message"), pubKH))
+	}
+
+	t.Run("recover the keys in a new storage", func(t *testing.T) {
+		recovered := newHDKMS(t)
+
+		keyIDs, e := recovered.Recover(kmsapi.ED25519Type, 3)
+		require.NoError(t, e)
+		require.Equal(t, edKeyIDs, keyIDs)
+
+		for _, keyID := range keyIDs {
+			expected, _, e := k.ExportPubKeyBytes(keyID)
+			require.NoError(t, e)
+
+			pubKey, _, e := recovered.ExportPubKeyBytes(keyID)
+			require.NoError(t, e)
+			require.Equal(t, expected, pubKey)
+		}
+
+		keyIDs, e = recovered.Recover(kmsapi.ECDSASecp256k1DER, 1)
+		require.NoError(t, e)
+		require.Equal(t, []string{secpDERKeyID}, keyIDs)
+
+		// recovering again keeps the keys and new keys follow the recovered ones.
+		keyIDs, e = recovered.Recover(kmsapi.ED25519Type, 2)
+		require.NoError(t, e)
+		require.Equal(t, edKeyIDs[:2], keyIDs)
+
+		keyID, _, e := recovered.Create(kmsapi.ED25519Type)
+		require.NoError(t, e)
+
+		path, e := recovered.Path(keyID)
+		require.NoError(t, e)
+		require.Equal(t, "m/0'/0'/3'", path)
+
+		_, e = recovered.Recover(kmsapi.X25519ECDHKWType, 1)
+		require.EqualError(t, e, "recover: key type 'X25519ECDHKW' cannot be derived")
+	})
+
+	t.Run("rotate derives the next key", func(t *testing.T) {
+		require.NoError(t, k.UpdateMetadata(edKeyIDs[2], kmsapi.WithLabels(map[string]string{"role": "auth"})))
+
+		newID, _, e := k.Rotate(kmsapi.ED25519Type, edKeyIDs[2])
+		require.NoError(t, e)
+
+		metadata, e := k.GetMetadata(newID)
+		require.NoError(t, e)
+		require.Equal(t, map[string]string{"role": "auth", PathLabel: "m/0'/0'/3'"}, metadata.Labels)
+
+		_, e = k.Get(edKeyIDs[2])
+		require.ErrorIs(t, e, kms.ErrKeyNotFound)
+	})
+}
+
+func TestHDKMS_DeleteAndDestroy(t *testing.T) {
+	p, err := mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{})
+	require.NoError(t, err)
+
+	seed, err := hdkey.MnemonicToSeed(testMnemonic, "")
+	require.NoError(t, err)
+
+	k, err := New(testMasterKeyURI, p, seed)
+	require.NoError(t, err)
+
+	var keyIDs []string
+
+	for i := 0; i < 3; i++ {
+		keyID, _, e := k.Create(kmsapi.ED25519Type)
+		require.NoError(t, e)
+
+		keyIDs = append(keyIDs, keyID)
+	}
+
+	t.Run("create after deleting the last key does not reuse its index", func(t *testing.T) {
+		require.NoError(t, k.Delete(keyIDs[2]))
+
+		keyID, _, e := k.Create(kmsapi.ED25519Type)
+		require.NoError(t, e)
+		require.NotEqual(t, keyIDs[2], keyID)
+
+		path, e := k.Path(keyID)
+		require.NoError(t, e)
+		require.Equal(t, "m/0'/0'/3'", path)
+
+		// a new HDKMS on the same storage continues from the saved index.
+		other, e := New(testMasterKeyURI, p, seed)
+		require.NoError(t, e)
+
+		require.NoError(t, other.Delete(keyID))
+
+		keyID, _, e = other.Create(kmsapi.ED25519Type)
+		require.NoError(t, e)
+
+		path, e = other.Path(keyID)
+		require.NoError(t, e)
+		require.Equal(t, "m/0'/0'/4'", path)
+	})
+
+	t.Run("recover skips destroyed keys", func(t *testing.T) {
+		require.NoError(t, k.Destroy(keyIDs[0]))
+
+		recovered, e := k.Recover(kmsapi.ED25519Type, 3)
+		require.NoError(t, e)
+		require.Equal(t, keyIDs[1:], recovered)
+
+		_, e = k.Get(keyIDs[0])
+		require.ErrorIs(t, e, kms.ErrKeyDestroyed)
+
+		_, e = p.StorageProvider().Get(keyIDs[0])
+		require.ErrorIs(t, e, kms.ErrKeyNotFound)
+
+		metadata, e := k.GetMetadata(keyIDs[0])
+		require.NoError(t, e)
+		require.Equal(t, kmsapi.KeyStateDestroyed, metadata.State)
+	})
+
+	t.Run("rewrap skips the saved indexes", func(t *testing.T) {
+		masterKey := localkms.MasterKey{PrimaryKeyURI: testMasterKeyURI, SecretLock: &noop.NoLock{}}
+
+		_, e := localkms.Rewrap(p.StorageProvider(), masterKey, masterKey)
+		require.NoError(t, e)
+	})
+}
+
+func TestNew_Failures(t *testing.T) {
+	p, err := mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{})
+	require.NoError(t, err)
+
+	_, err = New(testMasterKeyURI, p, []byte("short"))
+	require.EqualError(t, err, "new: hdkey: invalid seed size 5")
+
+	_, err = New(testMasterKeyURI, &mockkms.Provider{}, make([]byte, 32))
+	require.EqualError(t, err, "new: kms store does not support key metadata")
+}
@@ -16,11 +16,14 @@ import (
 	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
 	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
 	"github.com/google/tink/go/signature"
+	"github.com/google/tink/go/streamingaead"
 
 	"github.com/hyperledger/aries-framework-go/spi/kms"
 
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/bbs"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/composite/ecdh"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/mldsa"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/rsaoaep"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/secp256k1"
 )
 
@@ -38,6 +41,10 @@ func keyTemplate(keyType kms.KeyType, _ ...kms.KeyOpts) (*tinkpb.KeyTemplate, er
 		return aead.ChaCha20Poly1305KeyTemplate(), nil
 	case kms.XChaCha20Poly1305Type:
 		return aead.XChaCha20Poly1305KeyTemplate(), nil
+	case kms.AES256GCMHKDF4KBType:
+		return streamingaead.AES256GCMHKDF4KBKeyTemplate(), nil
+	case kms.AES256GCMHKDF1MBType:
+		return streamingaead.AES256GCMHKDF1MBKeyTemplate(), nil
 	case kms.ECDSAP256TypeDER:
 		return signature.ECDSAP256KeyWithoutPrefixTemplate(), nil
 	case kms.ECDSAP384TypeDER:
@@ -67,12 +74,24 @@ func keyTemplate(keyType kms.KeyType, _ ...kms.KeyOpts) (*tinkpb.KeyTemplate, er
 		return ecdh.NISTP521ECDHKWKeyTemplate(), nil
 	case kms.X25519ECDHKWType:
 		return ecdh.X25519ECDHKWKeyTemplate(), nil
+	case kms.X25519MLKEM768ECDHKWType:
+		return ecdh.X25519MLKEM768ECDHKWKeyTemplate(), nil
 	case kms.BLS12381G2Type:
 		return bbs.BLS12381G2KeyTemplate(), nil
 	case kms.ECDSASecp256k1DER:
 		return secp256k1.DERKeyTemplate()
 	case kms.ECDSASecp256k1IEEEP1363:
 		return secp256k1.IEEEP1363KeyTemplate()
+	case kms.MLDSA44Type:
+		return mldsa.MLDSA44KeyTemplate()
+	case kms.MLDSA65Type:
+		return mldsa.MLDSA65KeyTemplate()
+	case kms.MLDSA87Type:
+		return mldsa.MLDSA87KeyTemplate()
+	case kms.MLDSA65ED25519Type:
+		return mldsa.MLDSA65Ed25519KeyTemplate()
+	case kms.RSAOAEPType:
+		return rsaoaep.RSAOAEP3072KeyTemplate()
 	default:
 		return nil, fmt.Errorf("getKeyTemplate: key type '%s' unrecognized", keyType)
 	}
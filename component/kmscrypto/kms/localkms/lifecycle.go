@@ -0,0 +1,280 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms"
+)
+
+var errMetadataNotSupported = errors.New("kms store does not support key metadata")
+
+// Delete removes the key referenced by keyID along with its metadata from the KMS storage.
+// Deleting a non-existent key does not return an error.
+func (l *LocalKMS) Delete(keyID string) error {
+	err := l.deleteKeySet(keyID)
+	if err != nil {
+		return fmt.Errorf("delete: failed to delete key '%s': %w", keyID, err)
+	}
+
+	if ms, ok := l.store.(kmsapi.MetadataStore); ok {
+		err = ms.DeleteMetadata(keyID)
+		if err != nil {
+			return fmt.Errorf("delete: failed to delete metadata of key '%s': %w", keyID, err)
+		}
+	}
+
+	return nil
+}
+
+// Disable marks the key referenced by keyID as disabled. A disabled key is kept in storage but cannot be fetched,
+// rotated or exported until it is enabled again.
+func (l *LocalKMS) Disable(keyID string) error {
+	err := l.setState(keyID, kmsapi.KeyStateDisabled)
+	if err != nil {
+		return fmt.Errorf("disable: %w", err)
+	}
+
+	return nil
+}
+
+// Enable restores a key previously disabled with Disable.
+func (l *LocalKMS) Enable(keyID string) error {
+	err := l.setState(keyID, kmsapi.KeyStateEnabled)
+	if err != nil {
+		return fmt.Errorf("enable: %w", err)
+	}
+
+	return nil
+}
+
+// Destroy erases the key material referenced by keyID and keeps its metadata with the destroyed state.
+// Destruction is irreversible.
+func (l *LocalKMS) Destroy(keyID string) error {
+	ms, metadata, err := l.metadata(keyID)
+	if err != nil {
+		return fmt.Errorf("destroy: %w", err)
+	}
+
+	if metadata.State == kmsapi.KeyStateDestroyed {
+		return nil
+	}
+
+	err = l.deleteKeySet(keyID)
+	if err != nil {
+		return fmt.Errorf("destroy: failed to delete key '%s': %w", keyID, err)
+	}
+
+	metadata.State = kmsapi.KeyStateDestroyed
+
+	err = putMetadata(ms, metadata)
+	if err != nil {
+		return fmt.Errorf("destroy: %w", err)
+	}
+
+	return nil
+}
+
+// GetMetadata returns the metadata of the key referenced by keyID.
+func (l *LocalKMS) GetMetadata(keyID string) (*kmsapi.KeyMetadata, error) {
+	_, metadata, err := l.metadata(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("getMetadata: %w", err)
+	}
+
+	return metadata, nil
+}
+
+// UpdateMetadata sets the labels and usage of the key referenced by keyID described in `opts`.
+func (l *LocalKMS) UpdateMetadata(keyID string, opts ...kmsapi.MetadataOpts) error {
+	metadataOpts := kmsapi.NewMetadataOpt()
+
+	for _, opt := range opts {
+		opt(metadataOpts)
+	}
+
+	ms, metadata, err := l.metadata(keyID)
+	if err != nil {
+		return fmt.Errorf("updateMetadata: %w", err)
+	}
+
+	if metadata.State == kmsapi.KeyStateDestroyed {
+		return fmt.Errorf("updateMetadata: %w", kms.ErrKeyDestroyed)
+	}
+
+	if metadataOpts.Labels() != nil {
+		metadata.Labels = metadataOpts.Labels()
+	}
+
+	if metadataOpts.Usage() != nil {
+		metadata.Usage = metadataOpts.Usage()
+	}
+
+	err = putMetadata(ms, metadata)
+	if err != nil {
+		return fmt.Errorf("updateMetadata: %w", err)
+	}
+
+	return nil
+}
+
+// List returns the metadata of the keys matching the filters described in `opts`. Only the keys having metadata,
+// ie. created, imported or updated while the KMS store supports kms.MetadataStore, are listed.
+func (l *LocalKMS) List(opts ...kmsapi.ListOpts) ([]*kmsapi.KeyMetadata, error) {
+	ms, ok := l.store.(kmsapi.MetadataStore)
+	if !ok {
+		return nil, fmt.Errorf("list: %w", errMetadataNotSupported)
+	}
+
+	result, err := ms.QueryMetadata(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("list: %w", err)
+	}
+
+	return result, nil
+}
+
+// metadata returns the metadata of keyID. Keys stored without metadata get a new enabled one.
+func (l *LocalKMS) metadata(keyID string) (kmsapi.MetadataStore, *kmsapi.KeyMetadata, error) {
+	ms, ok := l.store.(kmsapi.MetadataStore)
+	if !ok {
+		return nil, nil, errMetadataNotSupported
+	}
+
+	metadata, err := ms.GetMetadata(keyID)
+	if err == nil {
+		return ms, metadata, nil
+	}
+
+	if !errors.Is(err, kms.ErrKeyNotFound) {
+		return nil, nil, fmt.Errorf("failed to get metadata of key '%s': %w", keyID, err)
+	}
+
+	kh, err := l.readKeySet(keyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metadata = &kmsapi.KeyMetadata{
+		KeyID: keyID,
+		State: kmsapi.KeyStateEnabled,
+	}
+
+	// the key type is only known for asymmetric keys.
+	if _, kt, e := l.exportPubKeyBytes(kh); e == nil {
+		metadata.KeyType = kt
+	}
+
+	return ms, metadata, nil
+}
+
+func (l *LocalKMS) setState(keyID string, state kmsapi.KeyState) error {
+	ms, metadata, err := l.metadata(keyID)
+	if err != nil {
+		return err
+	}
+
+	if metadata.State == kmsapi.KeyStateDestroyed {
+		return kms.ErrKeyDestroyed
+	}
+
+	metadata.State = state
+
+	return putMetadata(ms, metadata)
+}
+
+// checkUsable returns an error if the metadata of keyID forbids its use. Keys without metadata are usable.
+func (l *LocalKMS) checkUsable(keyID string) error {
+	ms, ok := l.store.(kmsapi.MetadataStore)
+	if !ok {
+		return nil
+	}
+
+	metadata, err := ms.GetMetadata(keyID)
+	if errors.Is(err, kms.ErrKeyNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to get metadata of key '%s': %w", keyID, err)
+	}
+
+	switch metadata.State {
+	case kmsapi.KeyStateDisabled:
+		return fmt.Errorf("key '%s': %w", keyID, kms.ErrKeyDisabled)
+	case kmsapi.KeyStateDestroyed:
+		return fmt.Errorf("key '%s': %w", keyID, kms.ErrKeyDestroyed)
+	default:
+		return nil
+	}
+}
+
+// createMetadata saves the metadata of a new key, carrying over the labels and usage of previous, if set.
+func (l *LocalKMS) createMetadata(keyID string, kt kmsapi.KeyType, previous *kmsapi.KeyMetadata) error {
+	ms, ok := l.store.(kmsapi.MetadataStore)
+	if !ok {
+		return nil
+	}
+
+	now := time.Now().UTC()
+
+	metadata := &kmsapi.KeyMetadata{
+		KeyID:   keyID,
+		KeyType: kt,
+		State:   kmsapi.KeyStateEnabled,
+		Created: &now,
+	}
+
+	if previous != nil {
+		metadata.Labels = previous.Labels
+		metadata.Usage = previous.Usage
+	}
+
+	err := ms.PutMetadata(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to store metadata of key '%s': %w", keyID, err)
+	}
+
+	return nil
+}
+
+// rotateMetadata moves the metadata of the rotated oldID key to newID.
+func (l *LocalKMS) rotateMetadata(oldID, newID string, kt kmsapi.KeyType) error {
+	ms, ok := l.store.(kmsapi.MetadataStore)
+	if !ok {
+		return nil
+	}
+
+	previous, err := ms.GetMetadata(oldID)
+	if err != nil && !errors.Is(err, kms.ErrKeyNotFound) {
+		return fmt.Errorf("failed to get metadata of key '%s': %w", oldID, err)
+	}
+
+	err = ms.DeleteMetadata(oldID)
+	if err != nil {
+		return fmt.Errorf("failed to delete metadata of key '%s': %w", oldID, err)
+	}
+
+	return l.createMetadata(newID, kt, previous)
+}
+
+func putMetadata(ms kmsapi.MetadataStore, metadata *kmsapi.KeyMetadata) error {
+	now := time.Now().UTC()
+	metadata.Updated = &now
+
+	err := ms.PutMetadata(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to store metadata of key '%s': %w", metadata.KeyID, err)
+	}
+
+	return nil
+}
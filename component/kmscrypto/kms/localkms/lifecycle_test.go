@@ -0,0 +1,239 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mockstorage "github.com/hyperledger/aries-framework-go/component/storageutil/mock/storage"
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/secretlock/noop"
+)
+
+func TestLocalKMS_Lifecycle(t *testing.T) {
+	newKMS := func(t *testing.T) *LocalKMS {
+		t.Helper()
+
+		store, err := kms.NewAriesProviderWrapper(mockstorage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		localKMS, err := New(testMasterKeyURI, &mockProvider{storage: store, secretLock: &noop.NoLock{}})
+		require.NoError(t, err)
+
+		return localKMS
+	}
+
+	t.Run("created keys have enabled metadata", func(t *testing.T) {
+		localKMS := newKMS(t)
+
+		keyID, _, err := localKMS.Create(kmsapi.ED25519Type)
+		require.NoError(t, err)
+
+		metadata, err := localKMS.GetMetadata(keyID)
+		require.NoError(t, err)
+		require.Equal(t, keyID, metadata.KeyID)
+		require.Equal(t, kmsapi.ED25519Type, metadata.KeyType)
+		require.Equal(t, kmsapi.KeyStateEnabled, metadata.State)
+		require.NotNil(t, metadata.Created)
+	})
+
+	t.Run("disabled keys cannot be used until enabled", func(t *testing.T) {
+		localKMS := newKMS(t)
+
+		keyID, _, err := localKMS.Create(kmsapi.ECDSAP256TypeIEEEP1363)
+		require.NoError(t, err)
+
+		require.NoError(t, localKMS.Disable(keyID))
+
+		_, err = localKMS.Get(keyID)
+		require.ErrorIs(t, err, kms.ErrKeyDisabled)
+
+		_, _, err = localKMS.ExportPubKeyBytes(keyID)
+		require.ErrorIs(t, err, kms.ErrKeyDisabled)
+
+		_, _, err = localKMS.Rotate(kmsapi.ECDSAP256TypeIEEEP1363, keyID)
+		require.ErrorIs(t, err, kms.ErrKeyDisabled)
+
+		require.NoError(t, localKMS.Enable(keyID))
+
+		_, err = localKMS.Get(keyID)
+		require.NoError(t, err)
+	})
+
+	t.Run("destroyed keys are erased but kept in the inventory", func(t *testing.T) {
+		localKMS := newKMS(t)
+
+		keyID, _, err := localKMS.Create(kmsapi.AES256GCMType)
+		require.NoError(t, err)
+
+		require.NoError(t, localKMS.Destroy(keyID))
+		require.NoError(t, localKMS.Destroy(keyID))
+
+		_, err = localKMS.store.Get(keyID)
+		require.ErrorIs(t, err, kms.ErrKeyNotFound)
+
+		_, err = localKMS.Get(keyID)
+		require.ErrorIs(t, err, kms.ErrKeyDestroyed)
+
+		require.ErrorIs(t, localKMS.Enable(keyID), kms.ErrKeyDestroyed)
+		require.ErrorIs(t, localKMS.UpdateMetadata(keyID, kmsapi.WithUsage("encrypt")), kms.ErrKeyDestroyed)
+
+		destroyed, err := localKMS.List(kmsapi.WithStateFilter(kmsapi.KeyStateDestroyed))
+		require.NoError(t, err)
+		require.Len(t, destroyed, 1)
+		require.Equal(t, keyID, destroyed[0].KeyID)
+	})
+
+	t.Run("deleted keys are removed with their metadata", func(t *testing.T) {
+		localKMS := newKMS(t)
+
+		keyID, _, err := localKMS.Create(kmsapi.ED25519Type)
+		require.NoError(t, err)
+
+		require.NoError(t, localKMS.Delete(keyID))
+		require.NoError(t, localKMS.Delete(keyID))
+
+		_, err = localKMS.GetMetadata(keyID)
+		require.ErrorIs(t, err, kms.ErrKeyNotFound)
+
+		keys, err := localKMS.List()
+		require.NoError(t, err)
+		require.Empty(t, keys)
+	})
+
+	t.Run("list keys by type, state and labels", func(t *testing.T) {
+		localKMS := newKMS(t)
+
+		signingKeyID, _, err := localKMS.Create(kmsapi.ED25519Type)
+		require.NoError(t, err)
+
+		require.NoError(t, localKMS.UpdateMetadata(signingKeyID,
+			kmsapi.WithLabels(map[string]string{"team": "issuance"}), kmsapi.WithUsage("sign")))
+
+		_, _, err = localKMS.Create(kmsapi.ED25519Type)
+		require.NoError(t, err)
+
+		encKeyID, _, err := localKMS.Create(kmsapi.NISTP256ECDHKWType)
+		require.NoError(t, err)
+
+		require.NoError(t, localKMS.Disable(encKeyID))
+
+		keys, err := localKMS.List()
+		require.NoError(t, err)
+		require.Len(t, keys, 3)
+
+		keys, err = localKMS.List(kmsapi.WithKeyTypeFilter(kmsapi.ED25519Type))
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+
+		keys, err = localKMS.List(kmsapi.WithKeyTypeFilter(kmsapi.ED25519Type),
+			kmsapi.WithLabelFilter("team", "issuance"))
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		require.Equal(t, signingKeyID, keys[0].KeyID)
+		require.Equal(t, []string{"sign"}, keys[0].Usage)
+
+		keys, err = localKMS.List(kmsapi.WithStateFilter(kmsapi.KeyStateDisabled))
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		require.Equal(t, encKeyID, keys[0].KeyID)
+	})
+
+	t.Run("rotated keys keep their labels under the new key ID", func(t *testing.T) {
+		localKMS := newKMS(t)
+
+		keyID, _, err := localKMS.Create(kmsapi.AES256GCMType)
+		require.NoError(t, err)
+
+		require.NoError(t, localKMS.UpdateMetadata(keyID, kmsapi.WithLabels(map[string]string{"env": "prod"})))
+
+		newKeyID, _, err := localKMS.Rotate(kmsapi.AES256GCMType, keyID)
+		require.NoError(t, err)
+
+		_, err = localKMS.GetMetadata(keyID)
+		require.ErrorIs(t, err, kms.ErrKeyNotFound)
+
+		metadata, err := localKMS.GetMetadata(newKeyID)
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"env": "prod"}, metadata.Labels)
+	})
+
+	t.Run("keys stored without metadata are enabled", func(t *testing.T) {
+		localKMS := newKMS(t)
+
+		keyID, _, err := localKMS.Create(kmsapi.ECDSAP256TypeDER)
+		require.NoError(t, err)
+
+		ms, ok := localKMS.store.(kmsapi.MetadataStore)
+		require.True(t, ok)
+		require.NoError(t, ms.DeleteMetadata(keyID))
+
+		metadata, err := localKMS.GetMetadata(keyID)
+		require.NoError(t, err)
+		require.Equal(t, kmsapi.KeyStateEnabled, metadata.State)
+		require.Equal(t, kmsapi.ECDSAP256TypeDER, metadata.KeyType)
+
+		require.NoError(t, localKMS.Disable(keyID))
+
+		_, err = localKMS.Get(keyID)
+		require.ErrorIs(t, err, kms.ErrKeyDisabled)
+	})
+
+	t.Run("the KMS store is configured with the queried tags", func(t *testing.T) {
+		provider := &configRecordingProvider{MockStoreProvider: mockstorage.NewMockStoreProvider()}
+
+		_, err := kms.NewAriesProviderWrapper(provider)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{
+			kms.KeysetTagName, kms.MetadataTagName, kms.KeyTypeTagName, kms.KeyStateTagName,
+		}, provider.configs[kms.AriesWrapperStoreName].TagNames)
+
+		provider.ErrSetStoreConfig = errors.New("set config error")
+
+		_, err = kms.NewAriesProviderWrapper(provider)
+		require.EqualError(t, err, "failed to set store configuration: set config error")
+	})
+
+	t.Run("metadata operations require a metadata store", func(t *testing.T) {
+		localKMS, err := New(testMasterKeyURI, &mockProvider{storage: newInMemoryKMSStore(), secretLock: &noop.NoLock{}})
+		require.NoError(t, err)
+
+		keyID, _, err := localKMS.Create(kmsapi.ED25519Type)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, localKMS.Disable(keyID), errMetadataNotSupported)
+
+		_, err = localKMS.List()
+		require.ErrorIs(t, err, errMetadataNotSupported)
+
+		require.NoError(t, localKMS.Delete(keyID))
+
+		_, err = localKMS.Get(keyID)
+		require.ErrorIs(t, err, kms.ErrKeyNotFound)
+	})
+}
+
+type configRecordingProvider struct {
+	*mockstorage.MockStoreProvider
+	configs map[string]storage.StoreConfiguration
+}
+
+func (p *configRecordingProvider) SetStoreConfig(name string, config storage.StoreConfiguration) error {
+	if p.configs == nil {
+		p.configs = map[string]storage.StoreConfiguration{}
+	}
+
+	p.configs[name] = config
+
+	return p.MockStoreProvider.SetStoreConfig(name, config)
+}
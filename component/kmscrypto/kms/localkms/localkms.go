@@ -10,9 +10,11 @@ import (
 	"bytes"
 	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/rsa"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/google/tink/go/aead"
 	"github.com/google/tink/go/keyset"
@@ -24,6 +26,8 @@ import (
 	cryptoapi "github.com/hyperledger/aries-framework-go/spi/crypto"
 
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsa"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsaed25519"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/jwkkid"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/localkms/internal/keywrapper"
@@ -54,6 +58,9 @@ type LocalKMS struct {
 	primaryKeyURI     string
 	store             kmsapi.Store
 	primaryKeyEnvAEAD *aead.KMSEnvelopeAEAD
+
+	// mutex is locked by Rewrap, which changes the master key, and read locked while reading or writing keysets.
+	mutex sync.RWMutex
 }
 
 // New will create a new (local) KMS service.
@@ -111,6 +118,11 @@ func (l *LocalKMS) Create(kt kmsapi.KeyType, opts ...kmsapi.KeyOpts) (string, in
 		return "", nil, fmt.Errorf("create: failed to store keyset: %w", err)
 	}
 
+	err = l.createMetadata(keyID, kt, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("create: %w", err)
+	}
+
 	return keyID, kh, nil
 }
 
@@ -151,7 +163,7 @@ func (l *LocalKMS) Rotate(kt kmsapi.KeyType, keyID string, opts ...kmsapi.KeyOpt
 		return "", nil, fmt.Errorf("rotate: failed to get kms keyest handle: %w", err)
 	}
 
-	err = l.store.Delete(keyID)
+	err = l.deleteKeySet(keyID)
 	if err != nil {
 		return "", nil, fmt.Errorf("rotate: failed to delete entry for kid '%s': %w", keyID, err)
 	}
@@ -161,6 +173,11 @@ func (l *LocalKMS) Rotate(kt kmsapi.KeyType, keyID string, opts ...kmsapi.KeyOpt
 		return "", nil, fmt.Errorf("rotate: failed to store keySet: %w", err)
 	}
 
+	err = l.rotateMetadata(keyID, newID, kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("rotate: %w", err)
+	}
+
 	return newID, updatedKH, nil
 }
 
@@ -172,7 +189,8 @@ func (l *LocalKMS) storeKeySet(kh *keyset.Handle, kt kmsapi.KeyType) (string, er
 
 	switch kt {
 	case kmsapi.AES128GCMType, kmsapi.AES256GCMType, kmsapi.AES256GCMNoPrefixType, kmsapi.ChaCha20Poly1305Type,
-		kmsapi.XChaCha20Poly1305Type, kmsapi.HMACSHA256Tag256Type, kmsapi.CLMasterSecretType:
+		kmsapi.XChaCha20Poly1305Type, kmsapi.AES256GCMHKDF4KBType, kmsapi.AES256GCMHKDF1MBType,
+		kmsapi.HMACSHA256Tag256Type, kmsapi.CLMasterSecretType:
 		// symmetric keys will have random kid value (generated in the local storeWriter)
 	case kmsapi.CLCredDefType:
 		// ignoring custom KID generation for the asymmetric CL CredDef
@@ -184,6 +202,9 @@ func (l *LocalKMS) storeKeySet(kh *keyset.Handle, kt kmsapi.KeyType) (string, er
 		}
 	}
 
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
 	buf := new(bytes.Buffer)
 	jsonKeysetWriter := keyset.NewJSONWriter(buf)
 
@@ -201,6 +222,13 @@ func (l *LocalKMS) storeKeySet(kh *keyset.Handle, kt kmsapi.KeyType) (string, er
 	return writeToStore(l.store, buf)
 }
 
+func (l *LocalKMS) deleteKeySet(id string) error {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.store.Delete(id)
+}
+
 func writeToStore(store kmsapi.Store, buf *bytes.Buffer, opts ...kmsapi.PrivateKeyOpts) (string, error) {
 	w := newWriter(store, opts...)
 
@@ -214,6 +242,18 @@ func writeToStore(store kmsapi.Store, buf *bytes.Buffer, opts ...kmsapi.PrivateK
 }
 
 func (l *LocalKMS) getKeySet(id string) (*keyset.Handle, error) {
+	err := l.checkUsable(id)
+	if err != nil {
+		return nil, fmt.Errorf("getKeySet: %w", err)
+	}
+
+	return l.readKeySet(id)
+}
+
+func (l *LocalKMS) readKeySet(id string) (*keyset.Handle, error) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
 	localDBReader := newReader(l.store, id)
 
 	jsonKeysetReader := keyset.NewJSONReader(localDBReader)
@@ -316,8 +356,9 @@ func (l *LocalKMS) PubKeyBytesToHandle(pubKey []byte, kt kmsapi.KeyType, opts ..
 
 // ImportPrivateKey will import privKey into the KMS storage for the given keyType then returns the new key id and
 // the newly persisted Handle.
-// 'privKey' possible types are: *ecdsa.PrivateKey and ed25519.PrivateKey
-// 'keyType' possible types are signing key types only (ECDSA keys or Ed25519)
+// 'privKey' possible types are: *ecdsa.PrivateKey, ed25519.PrivateKey, *bbs12381g2pub.PrivateKey, *mldsa.PrivateKey,
+// *mldsaed25519.PrivateKey and *rsa.PrivateKey
+// 'keyType' possible types are signing key types only (ECDSA keys, Ed25519, BBS+ or ML-DSA) and RSAOAEPType
 // 'opts' allows setting the keysetID of the imported key using WithKeyID() option. If the ID is already used,
 // then an error is returned.
 // Returns:
@@ -325,6 +366,21 @@ func (l *LocalKMS) PubKeyBytesToHandle(pubKey []byte, kt kmsapi.KeyType, opts ..
 //   - handle instance (to private key)
 //   - error if import failure (key empty, invalid, doesn't match keyType, unsupported keyType or storing key failed)
 func (l *LocalKMS) ImportPrivateKey(privKey interface{}, kt kmsapi.KeyType,
+	opts ...kmsapi.PrivateKeyOpts) (string, interface{}, error) {
+	keyID, kh, err := l.importPrivateKey(privKey, kt, opts...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	err = l.createMetadata(keyID, kt, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("import private key: %w", err)
+	}
+
+	return keyID, kh, nil
+}
+
+func (l *LocalKMS) importPrivateKey(privKey interface{}, kt kmsapi.KeyType,
 	opts ...kmsapi.PrivateKeyOpts) (string, interface{}, error) {
 	switch pk := privKey.(type) {
 	case *ecdsa.PrivateKey:
@@ -333,6 +389,12 @@ func (l *LocalKMS) ImportPrivateKey(privKey interface{}, kt kmsapi.KeyType,
 		return l.importEd25519Key(pk, kt, opts...)
 	case *bbs12381g2pub.PrivateKey:
 		return l.importBBSKey(pk, kt, opts...)
+	case *mldsa.PrivateKey:
+		return l.importMLDSAKey(pk, kt, opts...)
+	case *mldsaed25519.PrivateKey:
+		return l.importMLDSAEd25519Key(pk, kt, opts...)
+	case *rsa.PrivateKey:
+		return l.importRSAKey(pk, kt, opts...)
 	default:
 		return "", nil, fmt.Errorf("import private key does not support this key type or key is public")
 	}
@@ -11,6 +11,7 @@ import (
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
@@ -32,6 +33,8 @@ import (
 	"github.com/hyperledger/aries-framework-go/spi/secretlock"
 
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsa"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsaed25519"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/localkms/internal/keywrapper"
@@ -323,6 +326,8 @@ func TestLocalKMS_Success(t *testing.T) {
 		kmsapi.AES256GCMType,
 		kmsapi.ChaCha20Poly1305Type,
 		kmsapi.XChaCha20Poly1305Type,
+		kmsapi.AES256GCMHKDF4KBType,
+		kmsapi.AES256GCMHKDF1MBType,
 		kmsapi.ECDSAP256TypeDER,
 		kmsapi.ECDSAP384TypeDER,
 		kmsapi.ECDSAP521TypeDER,
@@ -334,9 +339,15 @@ func TestLocalKMS_Success(t *testing.T) {
 		kmsapi.NISTP384ECDHKWType,
 		kmsapi.NISTP521ECDHKWType,
 		kmsapi.X25519ECDHKWType,
+		kmsapi.X25519MLKEM768ECDHKWType,
 		kmsapi.BLS12381G2Type,
 		kmsapi.ECDSASecp256k1DER,
 		kmsapi.ECDSASecp256k1IEEEP1363,
+		kmsapi.MLDSA44Type,
+		kmsapi.MLDSA65Type,
+		kmsapi.MLDSA87Type,
+		kmsapi.MLDSA65ED25519Type,
+		kmsapi.RSAOAEPType,
 	}
 
 	for _, v := range keyTemplates {
@@ -393,7 +404,8 @@ func TestLocalKMS_Success(t *testing.T) {
 		require.Equal(t, len(newKHPrimitives.Entries), len(rotatedKHPrimitives.Entries))
 		require.Equal(t, len(readKHPrimitives.Entries), len(rotatedKHPrimitives.Entries))
 
-		if strings.Contains(string(v), "ECDSA") || v == kmsapi.ED25519Type || v == kmsapi.BLS12381G2Type {
+		if strings.Contains(string(v), "ECDSA") || strings.HasPrefix(string(v), "MLDSA") ||
+			v == kmsapi.ED25519Type || v == kmsapi.BLS12381G2Type {
 			pubKeyBytes, kt, e := kmsService.ExportPubKeyBytes(keyID)
 			require.Errorf(t, e, "KeyID has been rotated. An error must be returned")
 			require.Empty(t, pubKeyBytes)
@@ -501,6 +513,22 @@ func TestLocalKMS_ImportPrivateKey(t *testing.T) { // nolint:gocyclo
 			tcName:  "import private key using BLS12381G2Type type",
 			keyType: kmsapi.BLS12381G2Type,
 		},
+		{
+			tcName:  "import private key using MLDSA44Type type",
+			keyType: kmsapi.MLDSA44Type,
+		},
+		{
+			tcName:  "import private key using MLDSA87Type type",
+			keyType: kmsapi.MLDSA87Type,
+		},
+		{
+			tcName:  "import private key using MLDSA65ED25519Type type",
+			keyType: kmsapi.MLDSA65ED25519Type,
+		},
+		{
+			tcName:  "import private key using RSAOAEPType type",
+			keyType: kmsapi.RSAOAEPType,
+		},
 		{
 			tcName:  "import private key using ECDSAP256DER type and a set empty KeyID",
 			keyType: kmsapi.ECDSAP256TypeDER,
@@ -565,6 +593,60 @@ func TestLocalKMS_ImportPrivateKey(t *testing.T) { // nolint:gocyclo
 				return
 			}
 
+			if tt.keyType == kmsapi.RSAOAEPType {
+				privKey, err := rsa.GenerateKey(rand.Reader, 2048)
+				require.NoError(t, err)
+
+				_, _, err = kmsService.ImportPrivateKey(privKey, kmsapi.ECDSAP256TypeDER)
+				require.EqualError(t, err, "import private RSA key failed: invalid key type")
+
+				ksID, _, err := kmsService.ImportPrivateKey(privKey, tt.keyType)
+				require.NoError(t, err)
+
+				pubKeyBytes, kt, err := kmsService.ExportPubKeyBytes(ksID)
+				require.NoError(t, err)
+				require.Equal(t, tt.keyType, kt)
+				require.EqualValues(t, x509.MarshalPKCS1PublicKey(&privKey.PublicKey), pubKeyBytes)
+				return
+			}
+
+			if strings.HasPrefix(string(tt.keyType), "MLDSA") {
+				var (
+					privKey             interface{}
+					expectedPubKeyBytes []byte
+				)
+
+				switch tt.keyType {
+				case kmsapi.MLDSA44Type:
+					mldsaKey, err := mldsa.GenerateKey(mldsa.MLDSA44())
+					require.NoError(t, err)
+
+					privKey, expectedPubKeyBytes = mldsaKey, mldsaKey.PublicKey().Bytes()
+				case kmsapi.MLDSA87Type:
+					mldsaKey, err := mldsa.GenerateKey(mldsa.MLDSA87())
+					require.NoError(t, err)
+
+					privKey, expectedPubKeyBytes = mldsaKey, mldsaKey.PublicKey().Bytes()
+				default:
+					compositeKey, err := mldsaed25519.GenerateKey()
+					require.NoError(t, err)
+
+					privKey, expectedPubKeyBytes = compositeKey, compositeKey.PublicKey().Bytes()
+				}
+
+				_, _, err := kmsService.ImportPrivateKey(privKey, kmsapi.MLDSA65Type)
+				require.EqualError(t, err, "import private ML-DSA key failed: invalid key type")
+
+				ksID, _, err := kmsService.ImportPrivateKey(privKey, tt.keyType)
+				require.NoError(t, err)
+
+				pubKeyBytes, kt, err := kmsService.ExportPubKeyBytes(ksID)
+				require.NoError(t, err)
+				require.Equal(t, tt.keyType, kt)
+				require.EqualValues(t, expectedPubKeyBytes, pubKeyBytes)
+				return
+			}
+
 			privKey, err := ecdsa.GenerateKey(tt.curve, rand.Reader)
 			require.NoError(t, err)
 
@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"crypto/ecdsa"
 	"crypto/ed25519"
+	"crypto/rsa"
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
@@ -22,11 +23,15 @@ import (
 	"github.com/hyperledger/aries-framework-go/spi/kms"
 
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsa"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsaed25519"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/composite/ecdh"
 	bbspb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/bbs_go_proto"
 	clpb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/cl_go_proto"
 	ecdhpb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/ecdh_aead_go_proto"
+	mldsapb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/mldsa_go_proto"
 	secp256k1pb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/secp256k1_go_proto"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/rsaoaep"
 )
 
 const (
@@ -34,7 +39,9 @@ const (
 	ed25519SignerTypeURL         = "type.googleapis.com/google.crypto.tink.Ed25519PrivateKey"
 	bbsSignerKeyTypeURL          = "type.hyperledger.org/hyperledger.aries.crypto.tink.BBSPrivateKey"
 	secp256k1SignerTypeURL       = "type.googleapis.com/google.crypto.tink.secp256k1PrivateKey"
+	mldsaSignerTypeURL           = "type.hyperledger.org/hyperledger.aries.crypto.tink.MLDSAPrivateKey"
 	nistpECDHKWPrivateKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.NistPEcdhKwPrivateKey"
+	rsaOAEPPrivateKeyTypeURL     = "type.hyperledger.org/hyperledger.aries.crypto.tink.RsaOaepPrivateKey"
 )
 
 //nolint:funlen,gocyclo
@@ -91,13 +98,13 @@ func (l *LocalKMS) importECDSAKey(privKey *ecdsa.PrivateKey, kt kms.KeyType,
 			HashType: commonpb.HashType_SHA256,
 			Curve:    secp256k1pb.BitcoinCurveType_SECP256K1,
 			Encoding: secp256k1pb.Secp256K1SignatureEncoding_Bitcoin_DER,
-		})
+		}, opts...)
 	case kms.ECDSASecp256k1IEEEP1363:
 		return l.importSecp256K1Key(privKey, &secp256k1pb.Secp256K1Params{
 			HashType: commonpb.HashType_SHA256,
 			Curve:    secp256k1pb.BitcoinCurveType_SECP256K1,
 			Encoding: secp256k1pb.Secp256K1SignatureEncoding_Bitcoin_IEEE_P1363,
-		})
+		}, opts...)
 	default:
 		return "", nil, fmt.Errorf("import private EC key failed: invalid ECDSA key type")
 	}
@@ -240,6 +247,90 @@ func (l *LocalKMS) importBBSKey(privKey *bbs12381g2pub.PrivateKey, kt kms.KeyTyp
 	return l.importKeySet(ks, opts...)
 }
 
+func (l *LocalKMS) importMLDSAKey(privKey *mldsa.PrivateKey, kt kms.KeyType,
+	opts ...kms.PrivateKeyOpts) (string, *keyset.Handle, error) {
+	if privKey == nil {
+		return "", nil, fmt.Errorf("import private ML-DSA key failed: private key is nil")
+	}
+
+	var keyType kms.KeyType
+
+	switch privKey.PublicKey().Parameters() {
+	case mldsa.MLDSA44():
+		keyType = kms.MLDSA44Type
+	case mldsa.MLDSA65():
+		keyType = kms.MLDSA65Type
+	case mldsa.MLDSA87():
+		keyType = kms.MLDSA87Type
+	}
+
+	if kt != keyType {
+		return "", nil, fmt.Errorf("import private ML-DSA key failed: invalid key type")
+	}
+
+	return l.importMLDSAKeySet(privKey.PublicKey().Bytes(), privKey.Bytes(), kt, opts...)
+}
+
+func (l *LocalKMS) importMLDSAEd25519Key(privKey *mldsaed25519.PrivateKey, kt kms.KeyType,
+	opts ...kms.PrivateKeyOpts) (string, *keyset.Handle, error) {
+	if privKey == nil {
+		return "", nil, fmt.Errorf("import private ML-DSA key failed: private key is nil")
+	}
+
+	if kt != kms.MLDSA65ED25519Type {
+		return "", nil, fmt.Errorf("import private ML-DSA key failed: invalid key type")
+	}
+
+	return l.importMLDSAKeySet(privKey.PublicKey().Bytes(), privKey.Bytes(), kt, opts...)
+}
+
+func (l *LocalKMS) importMLDSAKeySet(pubKey, seed []byte, kt kms.KeyType,
+	opts ...kms.PrivateKeyOpts) (string, *keyset.Handle, error) {
+	privKeyProto := &mldsapb.MLDSAPrivateKey{
+		Version: 0,
+		PublicKey: &mldsapb.MLDSAPublicKey{
+			Version:  0,
+			Params:   buildMLDSAParams(kt),
+			KeyValue: pubKey,
+		},
+		KeyValue: seed,
+	}
+
+	mKeyValue, err := proto.Marshal(privKeyProto)
+	if err != nil {
+		return "", nil, fmt.Errorf("import private ML-DSA key failed: %w", err)
+	}
+
+	ks := newKeySet(mldsaSignerTypeURL, mKeyValue, tinkpb.KeyData_ASYMMETRIC_PRIVATE)
+
+	return l.importKeySet(ks, opts...)
+}
+
+func (l *LocalKMS) importRSAKey(privKey *rsa.PrivateKey, kt kms.KeyType,
+	opts ...kms.PrivateKeyOpts) (string, *keyset.Handle, error) {
+	if privKey == nil {
+		return "", nil, fmt.Errorf("import private RSA key failed: private key is nil")
+	}
+
+	if kt != kms.RSAOAEPType {
+		return "", nil, fmt.Errorf("import private RSA key failed: invalid key type")
+	}
+
+	// RSA keys with more than 2 primes can't be stored.
+	if len(privKey.Primes) != 2 || privKey.Validate() != nil {
+		return "", nil, fmt.Errorf("import private RSA key failed: invalid private key")
+	}
+
+	mKeyValue, err := proto.Marshal(rsaoaep.NewPrivateKeyProto(privKey))
+	if err != nil {
+		return "", nil, fmt.Errorf("import private RSA key failed: %w", err)
+	}
+
+	ks := newKeySet(rsaOAEPPrivateKeyTypeURL, mKeyValue, tinkpb.KeyData_ASYMMETRIC_PRIVATE)
+
+	return l.importKeySet(ks, opts...)
+}
+
 func validECPrivateKey(privateKey *ecdsa.PrivateKey) error {
 	if privateKey == nil {
 		return fmt.Errorf("private key is nil")
@@ -335,6 +426,22 @@ func buidBBSParams(kt kms.KeyType) *bbspb.BBSParams {
 	return nil
 }
 
+func buildMLDSAParams(kt kms.KeyType) *mldsapb.MLDSAParams {
+	switch kt {
+	case kms.MLDSA44Type:
+		return &mldsapb.MLDSAParams{ParameterSet: mldsapb.MLDSAParameterSet_MLDSA_44}
+	case kms.MLDSA87Type:
+		return &mldsapb.MLDSAParams{ParameterSet: mldsapb.MLDSAParameterSet_MLDSA_87}
+	case kms.MLDSA65ED25519Type:
+		return &mldsapb.MLDSAParams{
+			ParameterSet: mldsapb.MLDSAParameterSet_MLDSA_65,
+			Composite:    mldsapb.MLDSACompositeType_MLDSA_COMPOSITE_ED25519,
+		}
+	default:
+		return &mldsapb.MLDSAParams{ParameterSet: mldsapb.MLDSAParameterSet_MLDSA_65}
+	}
+}
+
 func buidCLCredDefParams(kt kms.KeyType, opts ...kms.KeyOpts) *clpb.CLCredDefParams {
 	if kt == kms.CLCredDefType {
 		keyOpts := kms.NewKeyOpt()
@@ -357,6 +464,9 @@ func (l *LocalKMS) writeImportedKey(ks *tinkpb.Keyset, opts ...kms.PrivateKeyOpt
 		return "", fmt.Errorf("invalid keyset data")
 	}
 
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
 	encrypted, err := l.primaryKeyEnvAEAD.Encrypt(serializedKeyset, []byte{})
 	if err != nil {
 		return "", fmt.Errorf("encrypted failed: %w", err)
@@ -33,6 +33,12 @@ func TestPubKeyExportAndRead(t *testing.T) {
 	p521DERTemplate, err := getKeyTemplate(kms.ECDSAP521TypeDER)
 	require.NoError(t, err)
 
+	mldsa44Template, err := getKeyTemplate(kms.MLDSA44Type)
+	require.NoError(t, err)
+
+	mldsa65Ed25519Template, err := getKeyTemplate(kms.MLDSA65ED25519Type)
+	require.NoError(t, err)
+
 	flagTests := []struct {
 		tcName      string
 		keyType     kms.KeyType
@@ -87,6 +93,18 @@ func TestPubKeyExportAndRead(t *testing.T) {
 			keyTemplate: bbs.BLS12381G2KeyTemplate(),
 			doSign:      true,
 		},
+		{
+			tcName:      "export then read ML-DSA-44 public key",
+			keyType:     kms.MLDSA44Type,
+			keyTemplate: mldsa44Template,
+			doSign:      true,
+		},
+		{
+			tcName:      "export then read composite ML-DSA-65 and Ed25519 public key",
+			keyType:     kms.MLDSA65ED25519Type,
+			keyTemplate: mldsa65Ed25519Template,
+			doSign:      true,
+		},
 	}
 
 	for _, tc := range flagTests {
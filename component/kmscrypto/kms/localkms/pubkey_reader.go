@@ -25,6 +25,7 @@ import (
 
 	bbspb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/bbs_go_proto"
 	clpb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/cl_go_proto"
+	mldsapb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/mldsa_go_proto"
 	secp256k1pb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/secp256k1_go_proto"
 	secp256k1subtle "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/secp256k1/subtle"
 )
@@ -209,6 +210,18 @@ func getMarshalledProtoKeyAndKeyURL(pubKey []byte, kt kms.KeyType,
 		if err != nil {
 			return nil, "", err
 		}
+	case kms.MLDSA44Type, kms.MLDSA65Type, kms.MLDSA87Type, kms.MLDSA65ED25519Type:
+		tURL = mldsaVerifierTypeURL
+		pubKeyProto := new(mldsapb.MLDSAPublicKey)
+		pubKeyProto.Version = 0
+		pubKeyProto.Params = buildMLDSAParams(kt)
+		pubKeyProto.KeyValue = make([]byte, len(pubKey))
+		copy(pubKeyProto.KeyValue, pubKey)
+
+		keyValue, err = proto.Marshal(pubKeyProto)
+		if err != nil {
+			return nil, "", err
+		}
 	default:
 		return nil, "", fmt.Errorf("invalid key type")
 	}
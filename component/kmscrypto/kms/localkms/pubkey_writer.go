@@ -9,6 +9,7 @@ package localkms
 import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	"crypto/rsa"
 	"crypto/x509"
 	"fmt"
 	"io"
@@ -18,6 +19,7 @@ import (
 	commonpb "github.com/google/tink/go/proto/common_go_proto"
 	ecdsapb "github.com/google/tink/go/proto/ecdsa_go_proto"
 	ed25519pb "github.com/google/tink/go/proto/ed25519_go_proto"
+	rsapb "github.com/google/tink/go/proto/rsa_ssa_pkcs1_go_proto"
 	tinkpb "github.com/google/tink/go/proto/tink_go_proto"
 	"github.com/google/tink/go/subtle"
 
@@ -26,6 +28,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/composite/keyio"
 	bbspb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/bbs_go_proto"
 	clpb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/cl_go_proto"
+	mldsapb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/mldsa_go_proto"
 	secp256k1pb "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/proto/secp256k1_go_proto"
 	secp256k1subtle "github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto/primitive/secp256k1/subtle"
 )
@@ -35,11 +38,15 @@ const (
 	ed25519VerifierTypeURL       = "type.googleapis.com/google.crypto.tink.Ed25519PublicKey"
 	nistPECDHKWPublicKeyTypeURL  = "type.hyperledger.org/hyperledger.aries.crypto.tink.NistPEcdhKwPublicKey"
 	x25519ECDHKWPublicKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.X25519EcdhKwPublicKey"
-	bbsVerifierKeyTypeURL        = "type.hyperledger.org/hyperledger.aries.crypto.tink.BBSPublicKey"
-	clCredDefKeyTypeURL          = "type.hyperledger.org/hyperledger.aries.crypto.tink.CLCredDefKey"
-	secp256k1VerifierTypeURL     = "type.googleapis.com/google.crypto.tink.secp256k1PublicKey"
-	derPrefix                    = "der-"
-	p13163Prefix                 = "p1363-"
+	// nolint:lll
+	x25519MLKEM768ECDHKWPublicKeyTypeURL = "type.hyperledger.org/hyperledger.aries.crypto.tink.X25519MLKEM768EcdhKwPublicKey"
+	bbsVerifierKeyTypeURL                = "type.hyperledger.org/hyperledger.aries.crypto.tink.BBSPublicKey"
+	clCredDefKeyTypeURL                  = "type.hyperledger.org/hyperledger.aries.crypto.tink.CLCredDefKey"
+	secp256k1VerifierTypeURL             = "type.googleapis.com/google.crypto.tink.secp256k1PublicKey"
+	mldsaVerifierTypeURL                 = "type.hyperledger.org/hyperledger.aries.crypto.tink.MLDSAPublicKey"
+	rsaOAEPPublicKeyTypeURL              = "type.hyperledger.org/hyperledger.aries.crypto.tink.RsaOaepPublicKey"
+	derPrefix                            = "der-"
+	p13163Prefix                         = "p1363-"
 )
 
 //nolint:gochecknoglobals
@@ -102,12 +109,12 @@ func write(w io.Writer, msg *tinkpb.Keyset) (kms.KeyType, error) {
 		if key.KeyId == primaryKID && key.Status == tinkpb.KeyStatusType_ENABLED {
 			switch key.KeyData.TypeUrl {
 			case ecdsaVerifierTypeURL, ed25519VerifierTypeURL, bbsVerifierKeyTypeURL, clCredDefKeyTypeURL,
-				secp256k1VerifierTypeURL:
+				secp256k1VerifierTypeURL, mldsaVerifierTypeURL, rsaOAEPPublicKeyTypeURL:
 				created, kt, err = writePubKey(w, key)
 				if err != nil {
 					return "", err
 				}
-			case nistPECDHKWPublicKeyTypeURL, x25519ECDHKWPublicKeyTypeURL:
+			case nistPECDHKWPublicKeyTypeURL, x25519ECDHKWPublicKeyTypeURL, x25519MLKEM768ECDHKWPublicKeyTypeURL:
 				pkW := keyio.NewWriter(w)
 
 				err = pkW.Write(msg)
@@ -202,6 +209,39 @@ func writePubKey(w io.Writer, key *tinkpb.Keyset_Key) (bool, kms.KeyType, error)
 		if err != nil {
 			return false, "", err
 		}
+	case mldsaVerifierTypeURL:
+		pubKeyProto := new(mldsapb.MLDSAPublicKey)
+
+		err := proto.Unmarshal(key.KeyData.Value, pubKeyProto)
+		if err != nil {
+			return false, "", err
+		}
+
+		kt, err = getMLDSAKeyType(pubKeyProto.Params)
+		if err != nil {
+			return false, "", err
+		}
+
+		marshaledRawPubKey = make([]byte, len(pubKeyProto.KeyValue))
+		copy(marshaledRawPubKey, pubKeyProto.KeyValue)
+	case rsaOAEPPublicKeyTypeURL:
+		pubKeyProto := new(rsapb.RsaSsaPkcs1PublicKey)
+
+		err := proto.Unmarshal(key.KeyData.Value, pubKeyProto)
+		if err != nil {
+			return false, "", err
+		}
+
+		e := new(big.Int).SetBytes(pubKeyProto.E)
+		if !e.IsInt64() {
+			return false, "", fmt.Errorf("can't export RSA key with bad public exponent")
+		}
+
+		marshaledRawPubKey = x509.MarshalPKCS1PublicKey(&rsa.PublicKey{
+			N: new(big.Int).SetBytes(pubKeyProto.N),
+			E: int(e.Int64()),
+		})
+		kt = kms.RSAOAEPType
 	default:
 		return false, "", fmt.Errorf("can't export key with keyURL:%s", key.KeyData.TypeUrl)
 	}
@@ -295,3 +335,25 @@ func getMarshalledSecp256K1KeyValueFromProto(pkPB *secp256k1pb.Secp256K1PublicKe
 
 	return marshaledRawPubKey, kt, nil
 }
+
+func getMLDSAKeyType(params *mldsapb.MLDSAParams) (kms.KeyType, error) {
+	if params == nil {
+		return "", fmt.Errorf("missing ML-DSA key params")
+	}
+
+	switch {
+	case params.Composite == mldsapb.MLDSACompositeType_MLDSA_COMPOSITE_ED25519 &&
+		params.ParameterSet == mldsapb.MLDSAParameterSet_MLDSA_65:
+		return kms.MLDSA65ED25519Type, nil
+	case params.Composite != mldsapb.MLDSACompositeType_NO_MLDSA_COMPOSITE:
+		return "", fmt.Errorf("can't export key with unsupported ML-DSA composite: '%s'", params.Composite)
+	case params.ParameterSet == mldsapb.MLDSAParameterSet_MLDSA_44:
+		return kms.MLDSA44Type, nil
+	case params.ParameterSet == mldsapb.MLDSAParameterSet_MLDSA_65:
+		return kms.MLDSA65Type, nil
+	case params.ParameterSet == mldsapb.MLDSAParameterSet_MLDSA_87:
+		return kms.MLDSA87Type, nil
+	default:
+		return "", fmt.Errorf("can't export key with unsupported ML-DSA parameter set: '%s'", params.ParameterSet)
+	}
+}
@@ -0,0 +1,310 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/tink/go/aead"
+	"github.com/google/tink/go/keyset"
+
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+	"github.com/hyperledger/aries-framework-go/spi/secretlock"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/localkms/internal/keywrapper"
+)
+
+// RewrapProgressKeyID is the ID of the entry saved in the KMS store by Rewrap to track its progress. It is removed
+// once all keysets are rewrapped.
+const RewrapProgressKeyID = "rewrap_progress"
+
+// StateKeyIDPrefix prefixes the IDs of the entries saved in the KMS store by the KMS built on LocalKMS to keep their
+// own state. These entries are not keysets and are skipped by Rewrap.
+const StateKeyIDPrefix = "kms_state_"
+
+// MasterKey identifies the master key protecting the keysets of a LocalKMS: the primary key URI given to New and
+// the secret lock service holding it.
+type MasterKey struct {
+	PrimaryKeyURI string
+	SecretLock    secretlock.Service
+}
+
+type rewrapProgress struct {
+	PrimaryKeyURI string `json:"primaryKeyURI"`
+	LastKeyID     string `json:"lastKeyID"`
+}
+
+// Rewrap re-encrypts the keysets found in store, decrypting them with the `from` master key and encrypting them with
+// the `to` master key. keyIDs restricts the keysets to rewrap, otherwise all keysets are rewrapped, in which case
+// store must implement kms.IterableStore (as the stores created with kms.NewAriesProviderWrapper do) and list all its
+// keysets.
+//
+// All keysets are decrypted before any is rewrapped, Rewrap leaving store unchanged if one can't be read. They are then
+// processed in key ID order and the last one rewrapped is saved in store under RewrapProgressKeyID, so that an
+// interrupted Rewrap resumes where it stopped when called again with the same arguments. A keyset that cannot be
+// decrypted with `from` but can be with `to` is considered already rewrapped.
+//
+// Rewrap returns the number of keysets rewrapped. Once it succeeds, the LocalKMS instances using the `from` master key
+// must be recreated with the `to` one. The keysets must not be used meanwhile: a keyset created during Rewrap with the
+// `from` master key may not be rewrapped. LocalKMS.Rewrap locks the KMS while rewrapping its keysets.
+func Rewrap(store kmsapi.Store, from, to MasterKey, keyIDs ...string) (int, error) {
+	fromAEAD, err := newEnvelopeAEAD(from)
+	if err != nil {
+		return 0, fmt.Errorf("rewrap: old master key: %w", err)
+	}
+
+	toAEAD, err := newEnvelopeAEAD(to)
+	if err != nil {
+		return 0, fmt.Errorf("rewrap: new master key: %w", err)
+	}
+
+	ids, err := rewrapKeyIDs(store, keyIDs)
+	if err != nil {
+		return 0, fmt.Errorf("rewrap: %w", err)
+	}
+
+	progress, err := readRewrapProgress(store)
+	if err != nil {
+		return 0, fmt.Errorf("rewrap: %w", err)
+	}
+
+	if progress != nil && progress.PrimaryKeyURI != to.PrimaryKeyURI {
+		return 0, fmt.Errorf("rewrap: a rewrap to primary key '%s' is in progress", progress.PrimaryKeyURI)
+	}
+
+	pending := ids[:0]
+
+	for _, id := range ids {
+		if progress == nil || id > progress.LastKeyID {
+			pending = append(pending, id)
+		}
+	}
+
+	// all keysets are decrypted before any is rewrapped, so that Rewrap fails without side effects on a keyset which
+	// can't be read. A nil handle is a keyset already rewrapped.
+	handles := make([]*keyset.Handle, len(pending))
+
+	for i, id := range pending {
+		handles[i], err = decryptKeySet(store, id, fromAEAD, toAEAD)
+		if err != nil {
+			return 0, fmt.Errorf("rewrap: %w", err)
+		}
+	}
+
+	count := 0
+
+	for i, id := range pending {
+		if handles[i] != nil {
+			e := writeKeySet(store, id, handles[i], toAEAD)
+			if e != nil {
+				return count, fmt.Errorf("rewrap: %w", e)
+			}
+
+			count++
+		}
+
+		e := writeRewrapProgress(store, &rewrapProgress{PrimaryKeyURI: to.PrimaryKeyURI, LastKeyID: id})
+		if e != nil {
+			return count, fmt.Errorf("rewrap: %w", e)
+		}
+	}
+
+	err = store.Delete(RewrapProgressKeyID)
+	if err != nil {
+		return count, fmt.Errorf("rewrap: failed to delete progress marker: %w", err)
+	}
+
+	return count, nil
+}
+
+// Rewrap re-encrypts all the keysets of the KMS with the `to` master key, which the KMS uses from then on. The keysets
+// are listed from the KMS store, which must implement kms.IterableStore, legacyKeyIDs adding the keysets the store can't
+// list (see kms.NewAriesProviderWrapper): Rewrap fails without legacyKeyIDs if the store doesn't list all its keysets.
+// The KMS is locked while rewrapping so that no keyset is used meanwhile, the LocalKMS instances of other processes
+// sharing the store must be stopped.
+//
+// Like the Rewrap function, it resumes an interrupted rewrap and returns the number of keysets rewrapped. If it fails,
+// the KMS keeps the `from` master key and the keysets rewrapped before an interruption can't be used until Rewrap
+// succeeds.
+func (l *LocalKMS) Rewrap(to MasterKey, legacyKeyIDs ...string) (int, error) {
+	toAEAD, err := newEnvelopeAEAD(to)
+	if err != nil {
+		return 0, fmt.Errorf("rewrap: new master key: %w", err)
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	ids, err := listKeysetIDs(l.store, len(legacyKeyIDs) == 0)
+	if err != nil {
+		return 0, fmt.Errorf("rewrap: %w", err)
+	}
+
+	ids = append(ids, legacyKeyIDs...)
+	count := 0
+
+	if len(ids) > 0 {
+		from := MasterKey{PrimaryKeyURI: l.primaryKeyURI, SecretLock: l.secretLock}
+
+		count, err = Rewrap(l.store, from, to, ids...)
+		if err != nil {
+			return count, err
+		}
+	}
+
+	l.primaryKeyURI = to.PrimaryKeyURI
+	l.secretLock = to.SecretLock
+	l.primaryKeyEnvAEAD = toAEAD
+
+	return count, nil
+}
+
+// PrimaryKeyURI returns the primary key URI of the master key used by the KMS.
+func (l *LocalKMS) PrimaryKeyURI() string {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.primaryKeyURI
+}
+
+func newEnvelopeAEAD(masterKey MasterKey) (*aead.KMSEnvelopeAEAD, error) {
+	kw, err := keywrapper.New(masterKey.SecretLock, masterKey.PrimaryKeyURI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new keywrapper: %w", err)
+	}
+
+	return aead.NewKMSEnvelopeAEAD2(aead.AES256GCMKeyTemplate(), kw), nil
+}
+
+// rewrapKeyIDs returns the sorted keyset IDs to rewrap, listing them from store if keyIDs is empty.
+func rewrapKeyIDs(store kmsapi.Store, keyIDs []string) ([]string, error) {
+	if len(keyIDs) == 0 {
+		var err error
+
+		keyIDs, err = listKeysetIDs(store, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ids := make([]string, 0, len(keyIDs))
+	seen := make(map[string]bool, len(keyIDs))
+
+	for _, id := range keyIDs {
+		if !seen[id] && id != RewrapProgressKeyID && !strings.HasPrefix(id, StateKeyIDPrefix) {
+			ids = append(ids, id)
+			seen[id] = true
+		}
+	}
+
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// listKeysetIDs returns the keyset IDs listed by store. If requireAll is set, it fails unless store lists all its
+// keysets, rather than rewrapping some of them only.
+func listKeysetIDs(store kmsapi.Store, requireAll bool) ([]string, error) {
+	is, ok := store.(kmsapi.IterableStore)
+	if !ok {
+		if requireAll {
+			return nil, errors.New("kms store cannot list its keysets, key IDs must be provided")
+		}
+
+		return nil, nil
+	}
+
+	if requireAll {
+		all, err := is.AllKeysetsListed()
+		if err != nil {
+			return nil, err
+		}
+
+		if !all {
+			return nil, errors.New("kms store cannot list all its keysets, key IDs must be provided")
+		}
+	}
+
+	return is.KeysetIDs()
+}
+
+// decryptKeySet reads keyset id with fromAEAD. It returns a nil handle if the keyset is already encrypted with toAEAD.
+func decryptKeySet(store kmsapi.Store, id string, fromAEAD, toAEAD *aead.KMSEnvelopeAEAD) (*keyset.Handle, error) {
+	data, err := store.Get(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get keyset '%s': %w", id, err)
+	}
+
+	kh, err := keyset.Read(keyset.NewJSONReader(bytes.NewReader(data)), fromAEAD)
+	if err != nil {
+		// the keyset may have been rewrapped before an interruption, prior to saving the progress marker.
+		if _, e := keyset.Read(keyset.NewJSONReader(bytes.NewReader(data)), toAEAD); e == nil {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to decrypt keyset '%s': %w", id, err)
+	}
+
+	return kh, nil
+}
+
+// writeKeySet stores keyset id encrypted with toAEAD.
+func writeKeySet(store kmsapi.Store, id string, kh *keyset.Handle, toAEAD *aead.KMSEnvelopeAEAD) error {
+	buf := new(bytes.Buffer)
+
+	err := kh.Write(keyset.NewJSONWriter(buf), toAEAD)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt keyset '%s': %w", id, err)
+	}
+
+	err = store.Put(id, buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to store keyset '%s': %w", id, err)
+	}
+
+	return nil
+}
+
+func readRewrapProgress(store kmsapi.Store) (*rewrapProgress, error) {
+	data, err := store.Get(RewrapProgressKeyID)
+	if errors.Is(err, kms.ErrKeyNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get progress marker: %w", err)
+	}
+
+	progress := &rewrapProgress{}
+
+	err = json.Unmarshal(data, progress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal progress marker: %w", err)
+	}
+
+	return progress, nil
+}
+
+func writeRewrapProgress(store kmsapi.Store, progress *rewrapProgress) error {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return fmt.Errorf("failed to marshal progress marker: %w", err)
+	}
+
+	err = store.Put(RewrapProgressKeyID, data)
+	if err != nil {
+		return fmt.Errorf("failed to store progress marker: %w", err)
+	}
+
+	return nil
+}
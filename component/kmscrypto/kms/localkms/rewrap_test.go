@@ -0,0 +1,290 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package localkms
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mockstorage "github.com/hyperledger/aries-framework-go/component/storageutil/mock/storage"
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms"
+)
+
+func TestRewrap(t *testing.T) {
+	setup := func(t *testing.T) (kmsapi.Store, MasterKey, MasterKey, []string) {
+		t.Helper()
+
+		store, err := kms.NewAriesProviderWrapper(mockstorage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		from := MasterKey{PrimaryKeyURI: testMasterKeyURI, SecretLock: createMasterKeyAndSecretLock(t)}
+		to := MasterKey{PrimaryKeyURI: testMasterKeyURI, SecretLock: createMasterKeyAndSecretLock(t)}
+
+		localKMS, err := New(from.PrimaryKeyURI, &mockProvider{storage: store, secretLock: from.SecretLock})
+		require.NoError(t, err)
+
+		var keyIDs []string
+
+		for _, kt := range []kmsapi.KeyType{kmsapi.ED25519Type, kmsapi.AES256GCMType, kmsapi.NISTP256ECDHKWType} {
+			keyID, _, e := localKMS.Create(kt)
+			require.NoError(t, e)
+
+			keyIDs = append(keyIDs, keyID)
+		}
+
+		return store, from, to, keyIDs
+	}
+
+	requireReadable := func(t *testing.T, store kmsapi.Store, masterKey MasterKey, keyIDs []string) {
+		t.Helper()
+
+		localKMS, err := New(masterKey.PrimaryKeyURI, &mockProvider{storage: store, secretLock: masterKey.SecretLock})
+		require.NoError(t, err)
+
+		for _, keyID := range keyIDs {
+			_, err = localKMS.Get(keyID)
+			require.NoError(t, err)
+		}
+	}
+
+	t.Run("rewrap all keysets", func(t *testing.T) {
+		store, from, to, keyIDs := setup(t)
+
+		count, err := Rewrap(store, from, to)
+		require.NoError(t, err)
+		require.Equal(t, len(keyIDs), count)
+
+		requireReadable(t, store, to, keyIDs)
+
+		localKMS, err := New(from.PrimaryKeyURI, &mockProvider{storage: store, secretLock: from.SecretLock})
+		require.NoError(t, err)
+
+		_, err = localKMS.Get(keyIDs[0])
+		require.Error(t, err)
+
+		_, err = store.Get(RewrapProgressKeyID)
+		require.ErrorIs(t, err, kms.ErrKeyNotFound)
+	})
+
+	t.Run("rewrap to a new primary key URI", func(t *testing.T) {
+		store, from, to, keyIDs := setup(t)
+		to.PrimaryKeyURI = "local-lock://test/key-uri/rotated"
+
+		count, err := Rewrap(store, from, to)
+		require.NoError(t, err)
+		require.Equal(t, len(keyIDs), count)
+
+		requireReadable(t, store, to, keyIDs)
+	})
+
+	t.Run("rewrap selected keysets", func(t *testing.T) {
+		store, from, to, keyIDs := setup(t)
+
+		count, err := Rewrap(store, from, to, keyIDs[0])
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		requireReadable(t, store, to, keyIDs[:1])
+		requireReadable(t, store, from, keyIDs[1:])
+	})
+
+	t.Run("resume an interrupted rewrap", func(t *testing.T) {
+		store, from, to, keyIDs := setup(t)
+
+		ids, err := rewrapKeyIDs(store, nil)
+		require.NoError(t, err)
+		require.Len(t, ids, len(keyIDs))
+
+		// simulate a crash after the first keyset was saved but before the second one was recorded as done.
+		count, err := Rewrap(store, from, to, ids[0], ids[1])
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		data, err := json.Marshal(&rewrapProgress{PrimaryKeyURI: to.PrimaryKeyURI, LastKeyID: ids[0]})
+		require.NoError(t, err)
+		require.NoError(t, store.Put(RewrapProgressKeyID, data))
+
+		count, err = Rewrap(store, from, to)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		requireReadable(t, store, to, keyIDs)
+
+		_, err = store.Get(RewrapProgressKeyID)
+		require.ErrorIs(t, err, kms.ErrKeyNotFound)
+	})
+
+	t.Run("rewrap to another primary key in progress", func(t *testing.T) {
+		store, from, to, _ := setup(t)
+
+		data, err := json.Marshal(&rewrapProgress{PrimaryKeyURI: "local-lock://other", LastKeyID: "a"})
+		require.NoError(t, err)
+		require.NoError(t, store.Put(RewrapProgressKeyID, data))
+
+		_, err = Rewrap(store, from, to)
+		require.EqualError(t, err, "rewrap: a rewrap to primary key 'local-lock://other' is in progress")
+	})
+
+	t.Run("keysets not encrypted with either master key", func(t *testing.T) {
+		store, from, _, _ := setup(t)
+		to := MasterKey{PrimaryKeyURI: testMasterKeyURI, SecretLock: createMasterKeyAndSecretLock(t)}
+		other := MasterKey{PrimaryKeyURI: testMasterKeyURI, SecretLock: createMasterKeyAndSecretLock(t)}
+
+		_, err := Rewrap(store, other, to)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "rewrap: failed to decrypt keyset")
+
+		requireReadable(t, store, from, nil)
+	})
+
+	t.Run("rewrap the keysets of the KMS", func(t *testing.T) {
+		storeProvider := mockstorage.NewMockStoreProvider()
+
+		store, err := kms.NewAriesProviderWrapper(storeProvider)
+		require.NoError(t, err)
+
+		from := MasterKey{PrimaryKeyURI: testMasterKeyURI, SecretLock: createMasterKeyAndSecretLock(t)}
+		to := MasterKey{PrimaryKeyURI: testMasterKeyURI, SecretLock: createMasterKeyAndSecretLock(t)}
+
+		localKMS, err := New(from.PrimaryKeyURI, &mockProvider{storage: store, secretLock: from.SecretLock})
+		require.NoError(t, err)
+
+		var keyIDs []string
+
+		for i := 0; i < 3; i++ {
+			keyID, _, e := localKMS.Create(kmsapi.ED25519Type)
+			require.NoError(t, e)
+
+			keyIDs = append(keyIDs, keyID)
+		}
+
+		// keysets stored before they were tagged, with and without key metadata.
+		for _, keyID := range keyIDs[1:] {
+			data, e := storeProvider.Store.Get(keyID)
+			require.NoError(t, e)
+			require.NoError(t, storeProvider.Store.Put(keyID, data))
+		}
+
+		ms, ok := store.(kmsapi.MetadataStore)
+		require.True(t, ok)
+		require.NoError(t, ms.DeleteMetadata(keyIDs[2]))
+
+		is, ok := store.(kmsapi.IterableStore)
+		require.True(t, ok)
+
+		// the store was opened empty, it lists all its keysets.
+		all, err := is.AllKeysetsListed()
+		require.NoError(t, err)
+		require.True(t, all)
+
+		// the store was created before keysets were tagged, it is not marked once reopened with keysets.
+		require.NoError(t, storeProvider.Store.Delete("kms_store_all_keysets_tagged"))
+
+		store, err = kms.NewAriesProviderWrapper(storeProvider)
+		require.NoError(t, err)
+
+		is, ok = store.(kmsapi.IterableStore)
+		require.True(t, ok)
+
+		all, err = is.AllKeysetsListed()
+		require.NoError(t, err)
+		require.False(t, all)
+
+		ids, err := is.KeysetIDs()
+		require.NoError(t, err)
+		require.ElementsMatch(t, keyIDs[:2], ids)
+
+		// the listed keyset is tagged, it is listed without its metadata.
+		require.NoError(t, ms.DeleteMetadata(keyIDs[1]))
+
+		ids, err = is.KeysetIDs()
+		require.NoError(t, err)
+		require.ElementsMatch(t, keyIDs[:2], ids)
+
+		// the keysets the store can't list must be provided.
+		_, err = localKMS.Rewrap(to)
+		require.EqualError(t, err, "rewrap: kms store cannot list all its keysets, key IDs must be provided")
+
+		_, err = Rewrap(store, from, to)
+		require.EqualError(t, err, "rewrap: kms store cannot list all its keysets, key IDs must be provided")
+
+		requireReadable(t, store, from, keyIDs)
+
+		count, err := localKMS.Rewrap(to, keyIDs[2])
+		require.NoError(t, err)
+		require.Equal(t, len(keyIDs), count)
+
+		// the KMS uses the new master key from then on.
+		keyID, _, err := localKMS.Create(kmsapi.ED25519Type)
+		require.NoError(t, err)
+
+		requireReadable(t, store, to, append(keyIDs, keyID))
+
+		for _, id := range append(keyIDs, keyID) {
+			_, err = localKMS.Get(id)
+			require.NoError(t, err)
+		}
+
+		_, err = localKMS.Rewrap(MasterKey{PrimaryKeyURI: "bad-uri", SecretLock: to.SecretLock})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "rewrap: new master key")
+
+		// the rewrap fails on keysets which can't be decrypted, the KMS keeps its master key.
+		other := MasterKey{PrimaryKeyURI: testMasterKeyURI, SecretLock: createMasterKeyAndSecretLock(t)}
+		otherKMS, err := New(other.PrimaryKeyURI, &mockProvider{storage: store, secretLock: other.SecretLock})
+		require.NoError(t, err)
+
+		_, err = otherKMS.Rewrap(other, keyIDs[2])
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "rewrap: failed to decrypt keyset")
+
+		_, err = localKMS.Rewrap(to, "missing")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "rewrap: failed to get keyset 'missing'")
+
+		requireReadable(t, store, to, keyIDs)
+	})
+
+	t.Run("rewrap the keysets of an empty KMS", func(t *testing.T) {
+		store, err := kms.NewAriesProviderWrapper(mockstorage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		from := MasterKey{PrimaryKeyURI: testMasterKeyURI, SecretLock: createMasterKeyAndSecretLock(t)}
+		to := MasterKey{PrimaryKeyURI: testMasterKeyURI, SecretLock: createMasterKeyAndSecretLock(t)}
+
+		localKMS, err := New(from.PrimaryKeyURI, &mockProvider{storage: store, secretLock: from.SecretLock})
+		require.NoError(t, err)
+
+		count, err := localKMS.Rewrap(to)
+		require.NoError(t, err)
+		require.Zero(t, count)
+
+		keyID, _, err := localKMS.Create(kmsapi.ED25519Type)
+		require.NoError(t, err)
+
+		requireReadable(t, store, to, []string{keyID})
+	})
+
+	t.Run("store not iterable", func(t *testing.T) {
+		from := MasterKey{PrimaryKeyURI: testMasterKeyURI, SecretLock: createMasterKeyAndSecretLock(t)}
+
+		_, err := Rewrap(newInMemoryKMSStore(), from, from)
+		require.EqualError(t, err, "rewrap: kms store cannot list its keysets, key IDs must be provided")
+	})
+
+	t.Run("invalid primary key URI", func(t *testing.T) {
+		from := MasterKey{PrimaryKeyURI: "bad-uri", SecretLock: createMasterKeyAndSecretLock(t)}
+
+		_, err := Rewrap(newInMemoryKMSStore(), from, from)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "rewrap: old master key")
+	})
+}
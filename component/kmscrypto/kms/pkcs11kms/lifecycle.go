@@ -0,0 +1,201 @@
+//go:build pkcs11
+// +build pkcs11
+
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkcs11kms
+
+import (
+	"errors"
+	"fmt"
+
+	p11 "github.com/miekg/pkcs11"
+
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/pkcs11"
+)
+
+// Delete removes the key pair referenced by keyID from the token.
+// Deleting a non-existent key does not return an error.
+func (k *PKCS11KMS) Delete(keyID string) error {
+	err := k.token.Do(func(s *pkcs11.Session) error {
+		objects, err := s.FindObjects([]*p11.Attribute{p11.NewAttribute(p11.CKA_ID, keyID)})
+		if err != nil {
+			return err
+		}
+
+		for _, object := range objects {
+			err = s.Ctx.DestroyObject(s.Handle, object)
+			if err != nil {
+				return fmt.Errorf("failed to destroy key object: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	return nil
+}
+
+// Disable revokes the signing capability (CKA_SIGN) of the private key referenced by keyID until it is enabled again.
+func (k *PKCS11KMS) Disable(keyID string) error {
+	err := k.setSign(keyID, false)
+	if err != nil {
+		return fmt.Errorf("disable: %w", err)
+	}
+
+	return nil
+}
+
+// Enable restores a key previously disabled with Disable.
+func (k *PKCS11KMS) Enable(keyID string) error {
+	err := k.setSign(keyID, true)
+	if err != nil {
+		return fmt.Errorf("enable: %w", err)
+	}
+
+	return nil
+}
+
+func (k *PKCS11KMS) setSign(keyID string, sign bool) error {
+	return k.token.Do(func(s *pkcs11.Session) error {
+		_, priv, err := keyMetadata(s, keyID)
+		if err != nil {
+			return err
+		}
+
+		err = s.Ctx.SetAttributeValue(s.Handle, priv, []*p11.Attribute{p11.NewAttribute(p11.CKA_SIGN, sign)})
+		if err != nil {
+			return fmt.Errorf("failed to update key '%s': %w", keyID, err)
+		}
+
+		return nil
+	})
+}
+
+// Destroy erases the key pair referenced by keyID from the token. Unlike localkms, the token keeps no trace of
+// destroyed keys, Destroy is the same as Delete.
+func (k *PKCS11KMS) Destroy(keyID string) error {
+	err := k.Delete(keyID)
+	if err != nil {
+		return fmt.Errorf("destroy: %w", err)
+	}
+
+	return nil
+}
+
+// GetMetadata returns the key type and state of the key referenced by keyID.
+func (k *PKCS11KMS) GetMetadata(keyID string) (*kmsapi.KeyMetadata, error) {
+	var metadata *kmsapi.KeyMetadata
+
+	err := k.token.Do(func(s *pkcs11.Session) error {
+		var err error
+
+		metadata, _, err = keyMetadata(s, keyID)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getMetadata: %w", err)
+	}
+
+	return metadata, nil
+}
+
+// UpdateMetadata is not supported by pkcs11KMS.
+func (k *PKCS11KMS) UpdateMetadata(string, ...kmsapi.MetadataOpts) error {
+	return fmt.Errorf("updateMetadata: %w", errMetadataNotSupported)
+}
+
+// List returns the metadata of the keys of the token managed by pkcs11KMS matching the filters described in `opts`.
+// Filtering by labels matches no key.
+func (k *PKCS11KMS) List(opts ...kmsapi.ListOpts) ([]*kmsapi.KeyMetadata, error) {
+	listOpts := kmsapi.NewListOpt()
+
+	for _, opt := range opts {
+		opt(listOpts)
+	}
+
+	var result []*kmsapi.KeyMetadata
+
+	err := k.token.Do(func(s *pkcs11.Session) error {
+		objects, err := s.FindObjects([]*p11.Attribute{p11.NewAttribute(p11.CKA_CLASS, p11.CKO_PRIVATE_KEY)})
+		if err != nil {
+			return err
+		}
+
+		for _, priv := range objects {
+			metadata, e := privateKeyMetadata(s, priv)
+			if errors.Is(e, errUnsupportedKeyType) {
+				// not a key managed by pkcs11KMS.
+				continue
+			}
+
+			if e != nil {
+				return e
+			}
+
+			if listOpts.Match(metadata) {
+				result = append(result, metadata)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list: %w", err)
+	}
+
+	return result, nil
+}
+
+// keyMetadata returns the metadata and private key object of keyID, or kms.ErrKeyNotFound.
+func keyMetadata(s *pkcs11.Session, keyID string) (*kmsapi.KeyMetadata, p11.ObjectHandle, error) {
+	priv, _, err := findKeyPair(s, keyID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	metadata, err := privateKeyMetadata(s, priv)
+	if err != nil {
+		return nil, 0, fmt.Errorf("key '%s': %w", keyID, err)
+	}
+
+	return metadata, priv, nil
+}
+
+func privateKeyMetadata(s *pkcs11.Session, priv p11.ObjectHandle) (*kmsapi.KeyMetadata, error) {
+	attrs, err := s.Ctx.GetAttributeValue(s.Handle, priv, []*p11.Attribute{
+		p11.NewAttribute(p11.CKA_ID, nil),
+		p11.NewAttribute(p11.CKA_LABEL, nil),
+		p11.NewAttribute(p11.CKA_SIGN, nil),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get private key attributes: %w", err)
+	}
+
+	kt := kmsapi.KeyType(attrs[1].Value)
+	if _, ok := keyTypeOIDs[kt]; !ok {
+		return nil, fmt.Errorf("'%s': %w", kt, errUnsupportedKeyType)
+	}
+
+	metadata := &kmsapi.KeyMetadata{
+		KeyID:   string(attrs[0].Value),
+		KeyType: kt,
+		State:   kmsapi.KeyStateEnabled,
+	}
+
+	if len(attrs[2].Value) == 0 || attrs[2].Value[0] == 0 {
+		metadata.State = kmsapi.KeyStateDisabled
+	}
+
+	return metadata, nil
+}
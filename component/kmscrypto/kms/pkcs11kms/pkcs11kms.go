@@ -0,0 +1,516 @@
+//go:build pkcs11
+// +build pkcs11
+
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package pkcs11kms provides a KeyManager creating and using signing keys held by a PKCS#11 token. Private keys are
+// generated (or imported) as sensitive, non extractable token objects and never leave the token.
+//
+// Supported key types are ECDSA P-256 and P-384 (DER and IEEE P1363 signature formats) and Ed25519, if the token
+// implements the PKCS#11 v3.0 EdDSA mechanisms. Keys are created with their key ID as CKA_ID and their kms.KeyType as
+// CKA_LABEL. Their signatures are computed with crypto/pkcs11crypto.
+package pkcs11kms
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	p11 "github.com/miekg/pkcs11"
+
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/jwkkid"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/pkcs11"
+)
+
+var (
+	errUnsupportedKeyType   = errors.New("key type is not supported by pkcs11KMS")
+	errMetadataNotSupported = errors.New("pkcs11KMS does not support key labels and usage")
+
+	oidP256    = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	oidP384    = asn1.ObjectIdentifier{1, 3, 132, 0, 34}
+	oidEd25519 = asn1.ObjectIdentifier{1, 3, 101, 112}
+
+	keyTypeOIDs = map[kmsapi.KeyType]asn1.ObjectIdentifier{
+		kmsapi.ECDSAP256TypeDER:       oidP256,
+		kmsapi.ECDSAP256TypeIEEEP1363: oidP256,
+		kmsapi.ECDSAP384TypeDER:       oidP384,
+		kmsapi.ECDSAP384TypeIEEEP1363: oidP384,
+		kmsapi.ED25519Type:            oidEd25519,
+	}
+)
+
+// KeyHandle is the handle of the keys managed by PKCS11KMS. It references a key pair held by the token, or only
+// carries a public key when returned by PubKeyBytesToHandle (KeyID is then empty).
+type KeyHandle struct {
+	KeyID   string
+	KeyType kmsapi.KeyType
+	// PublicKey in the format returned by ExportPubKeyBytes.
+	PublicKey []byte
+}
+
+// Public returns the public key of the handle.
+func (h *KeyHandle) Public() (crypto.PublicKey, error) {
+	switch h.KeyType {
+	case kmsapi.ED25519Type:
+		if len(h.PublicKey) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 public key")
+		}
+
+		return ed25519.PublicKey(h.PublicKey), nil
+	case kmsapi.ECDSAP256TypeDER, kmsapi.ECDSAP384TypeDER:
+		pubKey, err := x509.ParsePKIXPublicKey(h.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ECDSA public key: %w", err)
+		}
+
+		ecPubKey, ok := pubKey.(*ecdsa.PublicKey)
+		if !ok {
+			return nil, errors.New("invalid ECDSA public key: not an EC key")
+		}
+
+		return ecPubKey, nil
+	case kmsapi.ECDSAP256TypeIEEEP1363, kmsapi.ECDSAP384TypeIEEEP1363:
+		curve := Curve(h.KeyType)
+
+		x, y := elliptic.Unmarshal(curve, h.PublicKey)
+		if x == nil {
+			return nil, errors.New("invalid ECDSA public key")
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("'%s': %w", h.KeyType, errUnsupportedKeyType)
+	}
+}
+
+// Curve returns the elliptic curve of the ECDSA key type kt, or nil.
+func Curve(kt kmsapi.KeyType) elliptic.Curve {
+	switch keyTypeOIDs[kt].String() {
+	case oidP256.String():
+		return elliptic.P256()
+	case oidP384.String():
+		return elliptic.P384()
+	default:
+		return nil
+	}
+}
+
+// PKCS11KMS implements kms.KeyManager to manage keys held by a PKCS#11 token.
+type PKCS11KMS struct {
+	token *pkcs11.Token
+}
+
+var _ kmsapi.KeyManager = (*PKCS11KMS)(nil)
+
+// New creates a new PKCS#11 KMS service managing the keys of token.
+func New(token *pkcs11.Token) *PKCS11KMS {
+	return &PKCS11KMS{token: token}
+}
+
+// Create a new key pair of type kt in the token.
+// Returns:
+//   - KeyID of the new key, the JWK thumbprint of its public key
+//   - *KeyHandle of the new key
+//   - error if failure
+func (k *PKCS11KMS) Create(kt kmsapi.KeyType, _ ...kmsapi.KeyOpts) (string, interface{}, error) {
+	kh, err := k.create(kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("create: %w", err)
+	}
+
+	return kh.KeyID, kh, nil
+}
+
+func (k *PKCS11KMS) create(kt kmsapi.KeyType) (*KeyHandle, error) {
+	oid, ok := keyTypeOIDs[kt]
+	if !ok {
+		return nil, fmt.Errorf("'%s': %w", kt, errUnsupportedKeyType)
+	}
+
+	ecParams, err := asn1.Marshal(oid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal EC params: %w", err)
+	}
+
+	var (
+		mechanism uint = p11.CKM_EC_KEY_PAIR_GEN
+		keyType   uint = p11.CKK_EC
+		kh        *KeyHandle
+	)
+
+	if kt == kmsapi.ED25519Type {
+		mechanism, keyType = pkcs11.MechanismECEdwardsKeyPairGen, pkcs11.KeyTypeECEdwards
+	}
+
+	err = k.token.Do(func(s *pkcs11.Session) error {
+		pub, priv, e := s.Ctx.GenerateKeyPair(s.Handle, []*p11.Mechanism{p11.NewMechanism(mechanism, nil)},
+			publicKeyTemplate(keyType, kt, ecParams), privateKeyTemplate(keyType, kt, nil))
+		if e != nil {
+			return fmt.Errorf("failed to generate key pair: %w", e)
+		}
+
+		kh, e = setKeyID(s, kt, pub, priv)
+		if e != nil {
+			destroyObjects(s, pub, priv)
+
+			return e
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return kh, nil
+}
+
+// setKeyID sets the JWK thumbprint of the public key of a new key pair as its CKA_ID.
+func setKeyID(s *pkcs11.Session, kt kmsapi.KeyType, pub, priv p11.ObjectHandle) (*KeyHandle, error) {
+	pubKey, err := readPublicKey(s, pub, kt)
+	if err != nil {
+		return nil, err
+	}
+
+	kid, err := jwkkid.CreateKID(pubKey, kt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate kid: %w", err)
+	}
+
+	for _, object := range []p11.ObjectHandle{pub, priv} {
+		err = s.Ctx.SetAttributeValue(s.Handle, object, []*p11.Attribute{p11.NewAttribute(p11.CKA_ID, kid)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to set key ID: %w", err)
+		}
+	}
+
+	return &KeyHandle{KeyID: kid, KeyType: kt, PublicKey: pubKey}, nil
+}
+
+// Get the handle of the key pair referenced by keyID.
+// Returns:
+//   - *KeyHandle of the key
+//   - error if failure, kms.ErrKeyDisabled if the key was disabled
+func (k *PKCS11KMS) Get(keyID string) (interface{}, error) {
+	var kh *KeyHandle
+
+	err := k.token.Do(func(s *pkcs11.Session) error {
+		metadata, _, err := keyMetadata(s, keyID)
+		if err != nil {
+			return err
+		}
+
+		if metadata.State == kmsapi.KeyStateDisabled {
+			return fmt.Errorf("key '%s': %w", keyID, kms.ErrKeyDisabled)
+		}
+
+		kh, err = keyHandle(s, keyID, metadata.KeyType)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+
+	return kh, nil
+}
+
+// Rotate is not implemented in pkcs11KMS.
+func (k *PKCS11KMS) Rotate(kmsapi.KeyType, string, ...kmsapi.KeyOpts) (string, interface{}, error) {
+	return "", nil, errors.New("function Rotate is not implemented in pkcs11KMS")
+}
+
+// ExportPubKeyBytes returns the public key of the key pair referenced by keyID, in the format of its key type:
+// x509 PKIX DER for ECDSA DER keys, uncompressed point for ECDSA IEEE P1363 keys and raw bytes for Ed25519 keys.
+func (k *PKCS11KMS) ExportPubKeyBytes(keyID string) ([]byte, kmsapi.KeyType, error) {
+	var kh *KeyHandle
+
+	err := k.token.Do(func(s *pkcs11.Session) error {
+		metadata, _, err := keyMetadata(s, keyID)
+		if err != nil {
+			return err
+		}
+
+		kh, err = keyHandle(s, keyID, metadata.KeyType)
+
+		return err
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("exportPubKeyBytes: %w", err)
+	}
+
+	return kh.PublicKey, kh.KeyType, nil
+}
+
+// CreateAndExportPubKeyBytes creates a new key pair of type kt in the token and returns its KeyID and public key.
+func (k *PKCS11KMS) CreateAndExportPubKeyBytes(kt kmsapi.KeyType, _ ...kmsapi.KeyOpts) (string, []byte, error) {
+	kh, err := k.create(kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("createAndExportPubKeyBytes: %w", err)
+	}
+
+	return kh.KeyID, kh.PublicKey, nil
+}
+
+// PubKeyBytesToHandle returns a *KeyHandle carrying pubKey, in the format returned by ExportPubKeyBytes, to verify
+// signatures with crypto/pkcs11crypto.
+func (k *PKCS11KMS) PubKeyBytesToHandle(pubKey []byte, kt kmsapi.KeyType, _ ...kmsapi.KeyOpts) (interface{}, error) {
+	kh := &KeyHandle{KeyType: kt, PublicKey: pubKey}
+
+	_, err := kh.Public()
+	if err != nil {
+		return nil, fmt.Errorf("pubKeyBytesToHandle: %w", err)
+	}
+
+	return kh, nil
+}
+
+// ImportPrivateKey imports privKey in the token as a non extractable key of type kt.
+// 'privKey' possible types are: *ecdsa.PrivateKey and ed25519.PrivateKey
+// 'opts' allows setting the KeyID of the imported key using WithKeyID() option, it defaults to the JWK thumbprint of
+// its public key. An error is returned if the KeyID is already used.
+// Returns:
+//   - KeyID of the handle
+//   - *KeyHandle of the imported key
+//   - error if import failure
+func (k *PKCS11KMS) ImportPrivateKey(privKey interface{}, kt kmsapi.KeyType,
+	opts ...kmsapi.PrivateKeyOpts) (string, interface{}, error) {
+	kh, value, point, err := importedKey(privKey, kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("importPrivateKey: %w", err)
+	}
+
+	pOpts := kmsapi.NewOpt()
+
+	for _, opt := range opts {
+		opt(pOpts)
+	}
+
+	kh.KeyID = pOpts.KsID()
+	if kh.KeyID == "" {
+		kh.KeyID, err = jwkkid.CreateKID(kh.PublicKey, kt)
+		if err != nil {
+			return "", nil, fmt.Errorf("importPrivateKey: failed to generate kid: %w", err)
+		}
+	}
+
+	err = k.token.Do(func(s *pkcs11.Session) error {
+		return createKeyObjects(s, kh, value, point)
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("importPrivateKey: %w", err)
+	}
+
+	return kh.KeyID, kh, nil
+}
+
+// importedKey returns the handle (without KeyID) of privKey, its CKA_VALUE and its CKA_EC_POINT.
+func importedKey(privKey interface{}, kt kmsapi.KeyType) (*KeyHandle, []byte, []byte, error) {
+	switch key := privKey.(type) {
+	case *ecdsa.PrivateKey:
+		curve := Curve(kt)
+		if curve == nil || curve.Params().Name != key.Curve.Params().Name {
+			return nil, nil, nil, fmt.Errorf("key type '%s' does not match the private key curve", kt)
+		}
+
+		point := elliptic.Marshal(curve, key.X, key.Y)
+
+		pubKey, err := marshalPublicKey(point, kt)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		return &KeyHandle{KeyType: kt, PublicKey: pubKey}, key.D.FillBytes(make([]byte, (curve.Params().BitSize+7)/8)),
+			point, nil
+	case ed25519.PrivateKey:
+		if kt != kmsapi.ED25519Type {
+			return nil, nil, nil, fmt.Errorf("key type '%s' does not match the Ed25519 private key", kt)
+		}
+
+		pubKey := []byte(key.Public().(ed25519.PublicKey))
+
+		return &KeyHandle{KeyType: kt, PublicKey: pubKey}, key.Seed(), pubKey, nil
+	default:
+		return nil, nil, nil, errors.New("import private key does not support this key type or key is public")
+	}
+}
+
+func createKeyObjects(s *pkcs11.Session, kh *KeyHandle, value, point []byte) error {
+	_, _, err := findKeyPair(s, kh.KeyID)
+	if err == nil {
+		return fmt.Errorf("key ID '%s' already exists", kh.KeyID)
+	}
+
+	if !errors.Is(err, kms.ErrKeyNotFound) {
+		return err
+	}
+
+	ecParams, err := asn1.Marshal(keyTypeOIDs[kh.KeyType])
+	if err != nil {
+		return fmt.Errorf("failed to marshal EC params: %w", err)
+	}
+
+	ecPoint, err := asn1.Marshal(point)
+	if err != nil {
+		return fmt.Errorf("failed to marshal EC point: %w", err)
+	}
+
+	var keyType uint = p11.CKK_EC
+
+	if kh.KeyType == kmsapi.ED25519Type {
+		keyType = pkcs11.KeyTypeECEdwards
+	}
+
+	id := p11.NewAttribute(p11.CKA_ID, kh.KeyID)
+
+	pub, err := s.Ctx.CreateObject(s.Handle, append(publicKeyTemplate(keyType, kh.KeyType, ecParams), id,
+		p11.NewAttribute(p11.CKA_CLASS, p11.CKO_PUBLIC_KEY), p11.NewAttribute(p11.CKA_EC_POINT, ecPoint)))
+	if err != nil {
+		return fmt.Errorf("failed to create public key: %w", err)
+	}
+
+	_, err = s.Ctx.CreateObject(s.Handle, append(privateKeyTemplate(keyType, kh.KeyType, ecParams), id,
+		p11.NewAttribute(p11.CKA_CLASS, p11.CKO_PRIVATE_KEY), p11.NewAttribute(p11.CKA_VALUE, value)))
+	if err != nil {
+		destroyObjects(s, pub)
+
+		return fmt.Errorf("failed to create private key: %w", err)
+	}
+
+	return nil
+}
+
+func publicKeyTemplate(keyType uint, kt kmsapi.KeyType, ecParams []byte) []*p11.Attribute {
+	return []*p11.Attribute{
+		p11.NewAttribute(p11.CKA_KEY_TYPE, keyType),
+		p11.NewAttribute(p11.CKA_LABEL, string(kt)),
+		p11.NewAttribute(p11.CKA_TOKEN, true),
+		p11.NewAttribute(p11.CKA_VERIFY, true),
+		p11.NewAttribute(p11.CKA_EC_PARAMS, ecParams),
+	}
+}
+
+func privateKeyTemplate(keyType uint, kt kmsapi.KeyType, ecParams []byte) []*p11.Attribute {
+	attrs := []*p11.Attribute{
+		p11.NewAttribute(p11.CKA_KEY_TYPE, keyType),
+		p11.NewAttribute(p11.CKA_LABEL, string(kt)),
+		p11.NewAttribute(p11.CKA_TOKEN, true),
+		p11.NewAttribute(p11.CKA_PRIVATE, true),
+		p11.NewAttribute(p11.CKA_SENSITIVE, true),
+		p11.NewAttribute(p11.CKA_EXTRACTABLE, false),
+		p11.NewAttribute(p11.CKA_SIGN, true),
+	}
+
+	// key pair generation takes the EC params from the public key template only.
+	if ecParams != nil {
+		attrs = append(attrs, p11.NewAttribute(p11.CKA_EC_PARAMS, ecParams))
+	}
+
+	return attrs
+}
+
+// keyHandle returns the handle of the key pair keyID, reading its public key from the token.
+func keyHandle(s *pkcs11.Session, keyID string, kt kmsapi.KeyType) (*KeyHandle, error) {
+	pub, err := s.FindObject([]*p11.Attribute{
+		p11.NewAttribute(p11.CKA_CLASS, p11.CKO_PUBLIC_KEY),
+		p11.NewAttribute(p11.CKA_ID, keyID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("public key of '%s': %w", keyID, err)
+	}
+
+	pubKey, err := readPublicKey(s, pub, kt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyHandle{KeyID: keyID, KeyType: kt, PublicKey: pubKey}, nil
+}
+
+// readPublicKey reads the CKA_EC_POINT of the public key object pub and marshals it in the format of kt.
+func readPublicKey(s *pkcs11.Session, pub p11.ObjectHandle, kt kmsapi.KeyType) ([]byte, error) {
+	ecPoint, err := s.Attribute(pub, p11.CKA_EC_POINT)
+	if err != nil {
+		return nil, err
+	}
+
+	// CKA_EC_POINT is a DER encoded octet string, some tokens return the raw point instead.
+	var point []byte
+
+	rest, err := asn1.Unmarshal(ecPoint, &point)
+	if err != nil || len(rest) > 0 {
+		point = ecPoint
+	}
+
+	return marshalPublicKey(point, kt)
+}
+
+func marshalPublicKey(point []byte, kt kmsapi.KeyType) ([]byte, error) {
+	switch kt {
+	case kmsapi.ED25519Type:
+		if len(point) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 public key")
+		}
+
+		return point, nil
+	case kmsapi.ECDSAP256TypeIEEEP1363, kmsapi.ECDSAP384TypeIEEEP1363:
+		return point, nil
+	case kmsapi.ECDSAP256TypeDER, kmsapi.ECDSAP384TypeDER:
+		curve := Curve(kt)
+
+		x, y := elliptic.Unmarshal(curve, point)
+		if x == nil {
+			return nil, errors.New("invalid EC point")
+		}
+
+		pubKey, err := x509.MarshalPKIXPublicKey(&ecdsa.PublicKey{Curve: curve, X: x, Y: y})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal public key: %w", err)
+		}
+
+		return pubKey, nil
+	default:
+		return nil, fmt.Errorf("'%s': %w", kt, errUnsupportedKeyType)
+	}
+}
+
+// findKeyPair returns the private and public key objects of keyID, or kms.ErrKeyNotFound.
+func findKeyPair(s *pkcs11.Session, keyID string) (p11.ObjectHandle, p11.ObjectHandle, error) {
+	objects := make([]p11.ObjectHandle, 2) // nolint:gomnd
+
+	for i, class := range []uint{p11.CKO_PRIVATE_KEY, p11.CKO_PUBLIC_KEY} {
+		object, err := s.FindObject([]*p11.Attribute{
+			p11.NewAttribute(p11.CKA_CLASS, class),
+			p11.NewAttribute(p11.CKA_ID, keyID),
+		})
+		if errors.Is(err, pkcs11.ErrNotFound) {
+			return 0, 0, fmt.Errorf("key '%s': %w", keyID, kms.ErrKeyNotFound)
+		}
+
+		if err != nil {
+			return 0, 0, err
+		}
+
+		objects[i] = object
+	}
+
+	return objects[0], objects[1], nil
+}
+
+func destroyObjects(s *pkcs11.Session, objects ...p11.ObjectHandle) {
+	for _, object := range objects {
+		_ = s.Ctx.DestroyObject(s.Handle, object) // nolint:errcheck
+	}
+}
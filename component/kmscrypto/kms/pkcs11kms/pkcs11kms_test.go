@@ -0,0 +1,182 @@
+//go:build pkcs11
+// +build pkcs11
+
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkcs11kms
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/internal/pkcs11test"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms"
+)
+
+var supportedKeyTypes = []kmsapi.KeyType{
+	kmsapi.ECDSAP256TypeDER,
+	kmsapi.ECDSAP256TypeIEEEP1363,
+	kmsapi.ECDSAP384TypeDER,
+	kmsapi.ECDSAP384TypeIEEEP1363,
+	kmsapi.ED25519Type,
+}
+
+func TestPKCS11KMS_Create(t *testing.T) {
+	k := New(pkcs11test.OpenToken(t))
+
+	for _, kt := range supportedKeyTypes {
+		kt := kt
+
+		t.Run(string(kt), func(t *testing.T) {
+			keyID, kh, err := k.Create(kt)
+			require.NoError(t, err)
+			require.NotEmpty(t, keyID)
+
+			defer func() {
+				require.NoError(t, k.Delete(keyID))
+			}()
+
+			keyHandle, ok := kh.(*KeyHandle)
+			require.True(t, ok)
+			require.Equal(t, keyID, keyHandle.KeyID)
+			require.Equal(t, kt, keyHandle.KeyType)
+
+			_, err = keyHandle.Public()
+			require.NoError(t, err)
+
+			pubKey, pubKT, err := k.ExportPubKeyBytes(keyID)
+			require.NoError(t, err)
+			require.Equal(t, kt, pubKT)
+			require.Equal(t, keyHandle.PublicKey, pubKey)
+
+			got, err := k.Get(keyID)
+			require.NoError(t, err)
+			require.Equal(t, keyHandle, got)
+
+			pkh, err := k.PubKeyBytesToHandle(pubKey, kt)
+			require.NoError(t, err)
+			require.Equal(t, &KeyHandle{KeyType: kt, PublicKey: pubKey}, pkh)
+		})
+	}
+
+	t.Run("unsupported key type", func(t *testing.T) {
+		_, _, err := k.Create(kmsapi.AES256GCMType)
+		require.True(t, errors.Is(err, errUnsupportedKeyType))
+
+		_, _, err = k.CreateAndExportPubKeyBytes(kmsapi.BLS12381G2Type)
+		require.True(t, errors.Is(err, errUnsupportedKeyType))
+	})
+
+	t.Run("key not found", func(t *testing.T) {
+		_, err := k.Get("unknown key")
+		require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+
+		_, _, err = k.ExportPubKeyBytes("unknown key")
+		require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+	})
+
+	t.Run("rotate is not supported", func(t *testing.T) {
+		_, _, err := k.Rotate(kmsapi.ED25519Type, "keyID")
+		require.EqualError(t, err, "function Rotate is not implemented in pkcs11KMS")
+	})
+}
+
+func TestPKCS11KMS_ImportPrivateKey(t *testing.T) {
+	k := New(pkcs11test.OpenToken(t))
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("import ECDSA key", func(t *testing.T) {
+		keyID, kh, err := k.ImportPrivateKey(ecKey, kmsapi.ECDSAP384TypeIEEEP1363)
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, k.Delete(keyID))
+		}()
+
+		pubKey, err := kh.(*KeyHandle).Public()
+		require.NoError(t, err)
+		require.True(t, ecKey.PublicKey.Equal(pubKey))
+
+		_, _, err = k.ImportPrivateKey(ecKey, kmsapi.ECDSAP384TypeIEEEP1363, kmsapi.WithKeyID(keyID))
+		require.Error(t, err)
+	})
+
+	t.Run("import Ed25519 key with key ID", func(t *testing.T) {
+		keyID, kh, err := k.ImportPrivateKey(edKey, kmsapi.ED25519Type, kmsapi.WithKeyID("imported-ed25519"))
+		require.NoError(t, err)
+		require.Equal(t, "imported-ed25519", keyID)
+
+		defer func() {
+			require.NoError(t, k.Delete(keyID))
+		}()
+
+		pubKey, err := kh.(*KeyHandle).Public()
+		require.NoError(t, err)
+		require.Equal(t, edKey.Public(), pubKey)
+	})
+
+	t.Run("key type mismatch", func(t *testing.T) {
+		_, _, err := k.ImportPrivateKey(ecKey, kmsapi.ECDSAP256TypeDER)
+		require.Error(t, err)
+
+		_, _, err = k.ImportPrivateKey(edKey, kmsapi.ECDSAP256TypeDER)
+		require.Error(t, err)
+	})
+}
+
+func TestPKCS11KMS_Lifecycle(t *testing.T) {
+	k := New(pkcs11test.OpenToken(t))
+
+	keyID, _, err := k.Create(kmsapi.ECDSAP256TypeDER)
+	require.NoError(t, err)
+
+	metadata, err := k.GetMetadata(keyID)
+	require.NoError(t, err)
+	require.Equal(t, kmsapi.KeyStateEnabled, metadata.State)
+	require.Equal(t, kmsapi.ECDSAP256TypeDER, metadata.KeyType)
+
+	require.NoError(t, k.Disable(keyID))
+
+	_, err = k.Get(keyID)
+	require.True(t, errors.Is(err, kms.ErrKeyDisabled))
+
+	disabled, err := k.List(kmsapi.WithStateFilter(kmsapi.KeyStateDisabled))
+	require.NoError(t, err)
+	require.Contains(t, disabled, &kmsapi.KeyMetadata{
+		KeyID:   keyID,
+		KeyType: kmsapi.ECDSAP256TypeDER,
+		State:   kmsapi.KeyStateDisabled,
+	})
+
+	require.NoError(t, k.Enable(keyID))
+
+	_, err = k.Get(keyID)
+	require.NoError(t, err)
+
+	err = k.UpdateMetadata(keyID, kmsapi.WithLabels(map[string]string{"env": "test"}))
+	require.True(t, errors.Is(err, errMetadataNotSupported))
+
+	require.NoError(t, k.Destroy(keyID))
+
+	_, err = k.GetMetadata(keyID)
+	require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+
+	require.NoError(t, k.Delete(keyID))
+}
@@ -0,0 +1,256 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package thresholdkms
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms"
+)
+
+var errMetadataNotSupported = errors.New("kms store does not support key metadata")
+
+// Delete removes the key referenced by keyID along with its metadata, and asks the participants to delete their
+// shares. Deleting a non-existent key does not return an error.
+func (k *ThresholdKMS) Delete(keyID string) error {
+	err := k.deleteShares(keyID, k.participants)
+	if err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	err = k.store.Delete(keyID)
+	if err != nil {
+		return fmt.Errorf("delete: failed to delete key '%s': %w", keyID, err)
+	}
+
+	if ms, ok := k.store.(kmsapi.MetadataStore); ok {
+		err = ms.DeleteMetadata(keyID)
+		if err != nil {
+			return fmt.Errorf("delete: failed to delete metadata of key '%s': %w", keyID, err)
+		}
+	}
+
+	return nil
+}
+
+// Disable marks the key referenced by keyID as disabled. A disabled key is kept but cannot be fetched or exported
+// until it is enabled again.
+func (k *ThresholdKMS) Disable(keyID string) error {
+	err := k.setState(keyID, kmsapi.KeyStateDisabled)
+	if err != nil {
+		return fmt.Errorf("disable: %w", err)
+	}
+
+	return nil
+}
+
+// Enable restores a key previously disabled with Disable.
+func (k *ThresholdKMS) Enable(keyID string) error {
+	err := k.setState(keyID, kmsapi.KeyStateEnabled)
+	if err != nil {
+		return fmt.Errorf("enable: %w", err)
+	}
+
+	return nil
+}
+
+// Destroy asks the participants to erase their shares of the key referenced by keyID and keeps its metadata with the
+// destroyed state. Destruction is irreversible.
+func (k *ThresholdKMS) Destroy(keyID string) error {
+	ms, metadata, err := k.metadata(keyID)
+	if err != nil {
+		return fmt.Errorf("destroy: %w", err)
+	}
+
+	if metadata.State == kmsapi.KeyStateDestroyed {
+		return nil
+	}
+
+	err = k.deleteShares(keyID, k.participants)
+	if err != nil {
+		return fmt.Errorf("destroy: %w", err)
+	}
+
+	err = k.store.Delete(keyID)
+	if err != nil {
+		return fmt.Errorf("destroy: failed to delete key '%s': %w", keyID, err)
+	}
+
+	metadata.State = kmsapi.KeyStateDestroyed
+
+	err = putMetadata(ms, metadata)
+	if err != nil {
+		return fmt.Errorf("destroy: %w", err)
+	}
+
+	return nil
+}
+
+// GetMetadata returns the metadata of the key referenced by keyID.
+func (k *ThresholdKMS) GetMetadata(keyID string) (*kmsapi.KeyMetadata, error) {
+	_, metadata, err := k.metadata(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("getMetadata: %w", err)
+	}
+
+	return metadata, nil
+}
+
+// UpdateMetadata sets the labels and usage of the key referenced by keyID described in `opts`.
+func (k *ThresholdKMS) UpdateMetadata(keyID string, opts ...kmsapi.MetadataOpts) error {
+	metadataOpts := kmsapi.NewMetadataOpt()
+
+	for _, opt := range opts {
+		opt(metadataOpts)
+	}
+
+	ms, metadata, err := k.metadata(keyID)
+	if err != nil {
+		return fmt.Errorf("updateMetadata: %w", err)
+	}
+
+	if metadata.State == kmsapi.KeyStateDestroyed {
+		return fmt.Errorf("updateMetadata: %w", kms.ErrKeyDestroyed)
+	}
+
+	if metadataOpts.Labels() != nil {
+		metadata.Labels = metadataOpts.Labels()
+	}
+
+	if metadataOpts.Usage() != nil {
+		metadata.Usage = metadataOpts.Usage()
+	}
+
+	err = putMetadata(ms, metadata)
+	if err != nil {
+		return fmt.Errorf("updateMetadata: %w", err)
+	}
+
+	return nil
+}
+
+// List returns the metadata of the keys matching the filters described in `opts`.
+func (k *ThresholdKMS) List(opts ...kmsapi.ListOpts) ([]*kmsapi.KeyMetadata, error) {
+	ms, ok := k.store.(kmsapi.MetadataStore)
+	if !ok {
+		return nil, fmt.Errorf("list: %w", errMetadataNotSupported)
+	}
+
+	result, err := ms.QueryMetadata(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("list: %w", err)
+	}
+
+	return result, nil
+}
+
+// metadata returns the metadata of keyID. Keys stored without metadata get a new enabled one.
+func (k *ThresholdKMS) metadata(keyID string) (kmsapi.MetadataStore, *kmsapi.KeyMetadata, error) {
+	ms, ok := k.store.(kmsapi.MetadataStore)
+	if !ok {
+		return nil, nil, errMetadataNotSupported
+	}
+
+	metadata, err := ms.GetMetadata(keyID)
+	if err == nil {
+		return ms, metadata, nil
+	}
+
+	if !errors.Is(err, kms.ErrKeyNotFound) {
+		return nil, nil, fmt.Errorf("failed to get metadata of key '%s': %w", keyID, err)
+	}
+
+	_, err = k.keyHandle(keyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ms, &kmsapi.KeyMetadata{
+		KeyID:   keyID,
+		KeyType: kmsapi.ED25519Type,
+		State:   kmsapi.KeyStateEnabled,
+	}, nil
+}
+
+func (k *ThresholdKMS) setState(keyID string, state kmsapi.KeyState) error {
+	ms, metadata, err := k.metadata(keyID)
+	if err != nil {
+		return err
+	}
+
+	if metadata.State == kmsapi.KeyStateDestroyed {
+		return kms.ErrKeyDestroyed
+	}
+
+	metadata.State = state
+
+	return putMetadata(ms, metadata)
+}
+
+// checkUsable returns an error if the metadata of keyID forbids its use. Keys without metadata are usable.
+func (k *ThresholdKMS) checkUsable(keyID string) error {
+	ms, ok := k.store.(kmsapi.MetadataStore)
+	if !ok {
+		return nil
+	}
+
+	metadata, err := ms.GetMetadata(keyID)
+	if errors.Is(err, kms.ErrKeyNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to get metadata of key '%s': %w", keyID, err)
+	}
+
+	switch metadata.State {
+	case kmsapi.KeyStateDisabled:
+		return fmt.Errorf("key '%s': %w", keyID, kms.ErrKeyDisabled)
+	case kmsapi.KeyStateDestroyed:
+		return fmt.Errorf("key '%s': %w", keyID, kms.ErrKeyDestroyed)
+	default:
+		return nil
+	}
+}
+
+// createMetadata saves the metadata of a new key.
+func (k *ThresholdKMS) createMetadata(keyID string, kt kmsapi.KeyType) error {
+	ms, ok := k.store.(kmsapi.MetadataStore)
+	if !ok {
+		return nil
+	}
+
+	now := time.Now().UTC()
+
+	err := ms.PutMetadata(&kmsapi.KeyMetadata{
+		KeyID:   keyID,
+		KeyType: kt,
+		State:   kmsapi.KeyStateEnabled,
+		Created: &now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store metadata of key '%s': %w", keyID, err)
+	}
+
+	return nil
+}
+
+func putMetadata(ms kmsapi.MetadataStore, metadata *kmsapi.KeyMetadata) error {
+	now := time.Now().UTC()
+	metadata.Updated = &now
+
+	err := ms.PutMetadata(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to store metadata of key '%s': %w", metadata.KeyID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,272 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package thresholdkms
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+	"github.com/hyperledger/aries-framework-go/spi/secretlock"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/frost"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms"
+)
+
+// DefaultSessionTimeout is the default time a participant keeps the nonces of a signing session it committed to.
+const DefaultSessionTimeout = 5 * time.Minute
+
+// Participant is a signer holding shares of the keys of a ThresholdKMS. Its shares are stored encrypted by its secret
+// lock. The nonces of its signing sessions are only kept in memory and used once: they are removed when signing, when
+// the coordinator aborts the session or when the session times out.
+type Participant struct {
+	id             uint16
+	keyURI         string
+	store          kmsapi.Store
+	secretLock     secretlock.Service
+	sessionTimeout time.Duration
+	now            func() time.Time
+
+	mutex    sync.Mutex
+	sessions map[string]*session
+}
+
+type session struct {
+	nonces     *frost.Nonces
+	commitment *frost.Commitment
+	expiry     time.Time
+}
+
+// ParticipantOpt is an option of NewParticipant.
+type ParticipantOpt func(p *Participant)
+
+// WithSessionTimeout sets the time the participant keeps the nonces of a signing session it committed to, the
+// default being DefaultSessionTimeout.
+func WithSessionTimeout(timeout time.Duration) ParticipantOpt {
+	return func(p *Participant) {
+		p.sessionTimeout = timeout
+	}
+}
+
+// NewParticipant creates a new Participant identified by id, storing its key shares in the storage of p encrypted
+// with the key keyURI of the secret lock of p.
+func NewParticipant(id uint16, keyURI string, p kmsapi.Provider, opts ...ParticipantOpt) *Participant {
+	participant := &Participant{
+		id:             id,
+		keyURI:         keyURI,
+		store:          p.StorageProvider(),
+		secretLock:     p.SecretLock(),
+		sessionTimeout: DefaultSessionTimeout,
+		now:            time.Now,
+		sessions:       map[string]*session{},
+	}
+
+	for _, opt := range opts {
+		opt(participant)
+	}
+
+	return participant
+}
+
+// ID returns the identifier of the participant.
+func (p *Participant) ID() uint16 {
+	return p.id
+}
+
+// Handle processes a request of the coordinator.
+func (p *Participant) Handle(req *Request) (*Response, error) {
+	switch req.Type {
+	case StoreShareRequest:
+		return &Response{}, p.storeShare(req.KeyID, req.Share)
+	case CommitRequest:
+		return p.commit(req.KeyID, req.SessionID)
+	case SignRequest:
+		return p.sign(req.KeyID, req.SessionID, req.Message, req.Commitments)
+	case AbortRequest:
+		p.abort(req.KeyID, req.SessionID)
+
+		return &Response{}, nil
+	case DeleteShareRequest:
+		return &Response{}, p.deleteShare(req.KeyID)
+	default:
+		return nil, fmt.Errorf("participant %d: unsupported request type '%s'", p.id, req.Type)
+	}
+}
+
+func (p *Participant) storeShare(keyID string, share *frost.KeyShare) error {
+	if share == nil || share.ID != p.id {
+		return fmt.Errorf("participant %d: invalid share of key '%s'", p.id, keyID)
+	}
+
+	_, err := p.store.Get(keyID)
+	if err == nil {
+		return fmt.Errorf("participant %d: share of key '%s' already exists", p.id, keyID)
+	}
+
+	if !errors.Is(err, kms.ErrKeyNotFound) {
+		return fmt.Errorf("participant %d: %w", p.id, err)
+	}
+
+	shareBytes, err := json.Marshal(share)
+	if err != nil {
+		return fmt.Errorf("participant %d: failed to marshal share: %w", p.id, err)
+	}
+
+	resp, err := p.secretLock.Encrypt(p.keyURI, &secretlock.EncryptRequest{
+		Plaintext: base64.URLEncoding.EncodeToString(shareBytes),
+	})
+	if err != nil {
+		return fmt.Errorf("participant %d: failed to encrypt share: %w", p.id, err)
+	}
+
+	err = p.store.Put(keyID, []byte(resp.Ciphertext))
+	if err != nil {
+		return fmt.Errorf("participant %d: failed to store share: %w", p.id, err)
+	}
+
+	return nil
+}
+
+func (p *Participant) share(keyID string) (*frost.KeyShare, error) {
+	ct, err := p.store.Get(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share of key '%s': %w", keyID, err)
+	}
+
+	resp, err := p.secretLock.Decrypt(p.keyURI, &secretlock.DecryptRequest{Ciphertext: string(ct)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt share: %w", err)
+	}
+
+	shareBytes, err := base64.URLEncoding.DecodeString(resp.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode share: %w", err)
+	}
+
+	share := &frost.KeyShare{}
+
+	err = json.Unmarshal(shareBytes, share)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal share: %w", err)
+	}
+
+	return share, nil
+}
+
+func (p *Participant) commit(keyID, sessionID string) (*Response, error) {
+	if sessionID == "" {
+		return nil, fmt.Errorf("participant %d: missing session ID", p.id)
+	}
+
+	share, err := p.share(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("participant %d: %w", p.id, err)
+	}
+
+	nonces, commitment, err := share.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("participant %d: %w", p.id, err)
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := p.now()
+
+	// the sessions the coordinator abandoned without aborting them are removed once expired.
+	for id, s := range p.sessions {
+		if !now.Before(s.expiry) {
+			delete(p.sessions, id)
+		}
+	}
+
+	if _, ok := p.sessions[keyID+"/"+sessionID]; ok {
+		return nil, fmt.Errorf("participant %d: session '%s' already exists", p.id, sessionID)
+	}
+
+	p.sessions[keyID+"/"+sessionID] = &session{
+		nonces:     nonces,
+		commitment: commitment,
+		expiry:     now.Add(p.sessionTimeout),
+	}
+
+	return &Response{Commitment: commitment}, nil
+}
+
+func (p *Participant) sign(keyID, sessionID string, msg []byte, commitments []*frost.Commitment) (*Response, error) {
+	// the nonces of a session are removed before signing, never to be used twice.
+	p.mutex.Lock()
+	s, ok := p.sessions[keyID+"/"+sessionID]
+	delete(p.sessions, keyID+"/"+sessionID)
+	p.mutex.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("participant %d: unknown session '%s'", p.id, sessionID)
+	}
+
+	if !p.now().Before(s.expiry) {
+		return nil, fmt.Errorf("participant %d: session '%s' expired", p.id, sessionID)
+	}
+
+	if !hasCommitment(commitments, s.commitment) {
+		return nil, fmt.Errorf("participant %d: commitment of session '%s' is missing", p.id, sessionID)
+	}
+
+	share, err := p.share(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("participant %d: %w", p.id, err)
+	}
+
+	sigShare, err := share.Sign(s.nonces, msg, commitments)
+	if err != nil {
+		return nil, fmt.Errorf("participant %d: %w", p.id, err)
+	}
+
+	return &Response{SignatureShare: sigShare}, nil
+}
+
+// abort removes the nonces of the session, unknown sessions are ignored.
+func (p *Participant) abort(keyID, sessionID string) {
+	p.mutex.Lock()
+	delete(p.sessions, keyID+"/"+sessionID)
+	p.mutex.Unlock()
+}
+
+func hasCommitment(commitments []*frost.Commitment, commitment *frost.Commitment) bool {
+	for _, c := range commitments {
+		if c != nil && c.ID == commitment.ID {
+			return bytes.Equal(c.Hiding, commitment.Hiding) && bytes.Equal(c.Binding, commitment.Binding)
+		}
+	}
+
+	return false
+}
+
+func (p *Participant) deleteShare(keyID string) error {
+	p.mutex.Lock()
+
+	for id := range p.sessions {
+		if strings.HasPrefix(id, keyID+"/") {
+			delete(p.sessions, id)
+		}
+	}
+
+	p.mutex.Unlock()
+
+	err := p.store.Delete(keyID)
+	if err != nil {
+		return fmt.Errorf("participant %d: failed to delete share of key '%s': %w", p.id, keyID, err)
+	}
+
+	return nil
+}
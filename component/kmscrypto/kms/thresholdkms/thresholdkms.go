@@ -0,0 +1,310 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package thresholdkms provides a KeyManager whose Ed25519 keys are split between participants with FROST
+// (RFC 9591), so that t of the n participants must cooperate to sign and no single party holds a private key.
+//
+// ThresholdKMS acts as the coordinator: it generates each key, hands its shares to the participants over a
+// Transport and forgets the private key. It only stores the public key and the verifying shares of the participants.
+// Signatures are computed by crypto/thresholdcrypto with the participants and are standard Ed25519 signatures, so
+// that verifiers do not need to know a key is shared.
+package thresholdkms
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/frost"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/jwkkid"
+)
+
+var errUnsupportedKeyType = errors.New("key type is not supported by thresholdKMS")
+
+// KeyHandle is the handle of the keys managed by ThresholdKMS. It carries the group public key of the key and the
+// verifying shares of the participants, or only a public key when returned by PubKeyBytesToHandle (KeyID is then
+// empty).
+type KeyHandle struct {
+	KeyID     string
+	PublicKey ed25519.PublicKey
+	// Threshold is the number of participants required to sign.
+	Threshold int
+	// VerifyingShares are the public keys of the participant shares, indexed by participant identifiers.
+	VerifyingShares map[uint16][]byte
+}
+
+// Participants returns the sorted identifiers of the participants holding shares of the key.
+func (h *KeyHandle) Participants() []uint16 {
+	ids := make([]uint16, 0, len(h.VerifyingShares))
+
+	for id := range h.VerifyingShares {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+// keyRecord is the stored public part of a key.
+type keyRecord struct {
+	PublicKey       []byte            `json:"publicKey"`
+	Threshold       int               `json:"threshold"`
+	VerifyingShares map[uint16][]byte `json:"verifyingShares"`
+}
+
+// ThresholdKMS implements kms.KeyManager to manage Ed25519 keys shared by threshold participants.
+type ThresholdKMS struct {
+	store        kmsapi.Store
+	transport    Transport
+	threshold    int
+	participants []uint16
+}
+
+var _ kmsapi.KeyManager = (*ThresholdKMS)(nil)
+
+// New creates a new ThresholdKMS splitting its keys between participants, any threshold of them being able to sign.
+// Key records are saved in the storage of p and shares are sent to the participants with transport.
+func New(p kmsapi.Provider, transport Transport, threshold int, participants []uint16) (*ThresholdKMS, error) {
+	if threshold < 2 || threshold > len(participants) {
+		return nil, fmt.Errorf("new: invalid threshold %d for %d participants", threshold, len(participants))
+	}
+
+	return &ThresholdKMS{
+		store:        p.StorageProvider(),
+		transport:    transport,
+		threshold:    threshold,
+		participants: participants,
+	}, nil
+}
+
+// Create a new key of type kt, which must be kms.ED25519Type, and distributes its shares to the participants.
+// Returns:
+//   - KeyID of the new key, the JWK thumbprint of its public key
+//   - *KeyHandle of the new key
+//   - error if failure
+func (k *ThresholdKMS) Create(kt kmsapi.KeyType, _ ...kmsapi.KeyOpts) (string, interface{}, error) {
+	kh, err := k.create(kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("create: %w", err)
+	}
+
+	return kh.KeyID, kh, nil
+}
+
+func (k *ThresholdKMS) create(kt kmsapi.KeyType) (*KeyHandle, error) {
+	if kt != kmsapi.ED25519Type {
+		return nil, fmt.Errorf("'%s': %w", kt, errUnsupportedKeyType)
+	}
+
+	groupKey, err := frost.GenerateKey(k.threshold, k.participants)
+	if err != nil {
+		return nil, err
+	}
+
+	kid, err := jwkkid.CreateKID(groupKey.PublicKey, kt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate kid: %w", err)
+	}
+
+	return k.distribute(kid, groupKey)
+}
+
+// distribute sends the shares of groupKey to the participants and stores its record under kid. Shares already sent
+// are deleted if a participant fails.
+func (k *ThresholdKMS) distribute(kid string, groupKey *frost.GroupKey) (*KeyHandle, error) {
+	_, err := k.store.Get(kid)
+	if err == nil {
+		return nil, fmt.Errorf("key ID '%s' already exists", kid)
+	}
+
+	var sent []uint16
+
+	for _, share := range groupKey.Shares {
+		_, err = k.transport.Send(share.ID, &Request{Type: StoreShareRequest, KeyID: kid, Share: share})
+		if err != nil {
+			k.deleteShares(kid, sent) // nolint:errcheck,gosec // the send error is returned
+
+			return nil, fmt.Errorf("failed to send share to participant %d: %w", share.ID, err)
+		}
+
+		sent = append(sent, share.ID)
+	}
+
+	kh := &KeyHandle{
+		KeyID:           kid,
+		PublicKey:       groupKey.PublicKey,
+		Threshold:       k.threshold,
+		VerifyingShares: groupKey.VerifyingShares,
+	}
+
+	recordBytes, err := json.Marshal(&keyRecord{
+		PublicKey:       kh.PublicKey,
+		Threshold:       kh.Threshold,
+		VerifyingShares: kh.VerifyingShares,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal key record: %w", err)
+	}
+
+	err = k.store.Put(kid, recordBytes)
+	if err != nil {
+		k.deleteShares(kid, sent) // nolint:errcheck,gosec // the store error is returned
+
+		return nil, fmt.Errorf("failed to store key record: %w", err)
+	}
+
+	err = k.createMetadata(kid, kmsapi.ED25519Type)
+	if err != nil {
+		return nil, err
+	}
+
+	return kh, nil
+}
+
+// deleteShares asks participants to delete their share of keyID and returns the first error.
+func (k *ThresholdKMS) deleteShares(keyID string, participants []uint16) error {
+	var firstErr error
+
+	for _, id := range participants {
+		_, err := k.transport.Send(id, &Request{Type: DeleteShareRequest, KeyID: keyID})
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to delete share of participant %d: %w", id, err)
+		}
+	}
+
+	return firstErr
+}
+
+func (k *ThresholdKMS) keyHandle(keyID string) (*KeyHandle, error) {
+	recordBytes, err := k.store.Get(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key '%s': %w", keyID, err)
+	}
+
+	record := &keyRecord{}
+
+	err = json.Unmarshal(recordBytes, record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal key record: %w", err)
+	}
+
+	return &KeyHandle{
+		KeyID:           keyID,
+		PublicKey:       record.PublicKey,
+		Threshold:       record.Threshold,
+		VerifyingShares: record.VerifyingShares,
+	}, nil
+}
+
+// Get the handle of the key referenced by keyID.
+// Returns:
+//   - *KeyHandle of the key
+//   - error if failure, kms.ErrKeyDisabled if the key was disabled
+func (k *ThresholdKMS) Get(keyID string) (interface{}, error) {
+	err := k.checkUsable(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+
+	kh, err := k.keyHandle(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("get: %w", err)
+	}
+
+	return kh, nil
+}
+
+// Rotate is not implemented in thresholdKMS.
+func (k *ThresholdKMS) Rotate(kmsapi.KeyType, string, ...kmsapi.KeyOpts) (string, interface{}, error) {
+	return "", nil, errors.New("function Rotate is not implemented in thresholdKMS")
+}
+
+// ExportPubKeyBytes returns the raw Ed25519 group public key of the key referenced by keyID.
+func (k *ThresholdKMS) ExportPubKeyBytes(keyID string) ([]byte, kmsapi.KeyType, error) {
+	err := k.checkUsable(keyID)
+	if err != nil {
+		return nil, "", fmt.Errorf("exportPubKeyBytes: %w", err)
+	}
+
+	kh, err := k.keyHandle(keyID)
+	if err != nil {
+		return nil, "", fmt.Errorf("exportPubKeyBytes: %w", err)
+	}
+
+	return kh.PublicKey, kmsapi.ED25519Type, nil
+}
+
+// CreateAndExportPubKeyBytes creates a new key of type kt and returns its KeyID and raw Ed25519 public key.
+func (k *ThresholdKMS) CreateAndExportPubKeyBytes(kt kmsapi.KeyType, _ ...kmsapi.KeyOpts) (string, []byte, error) {
+	kh, err := k.create(kt)
+	if err != nil {
+		return "", nil, fmt.Errorf("createAndExportPubKeyBytes: %w", err)
+	}
+
+	return kh.KeyID, kh.PublicKey, nil
+}
+
+// PubKeyBytesToHandle returns a *KeyHandle carrying the raw Ed25519 pubKey, to verify signatures with
+// crypto/thresholdcrypto.
+func (k *ThresholdKMS) PubKeyBytesToHandle(pubKey []byte, kt kmsapi.KeyType, _ ...kmsapi.KeyOpts) (interface{},
+	error) {
+	if kt != kmsapi.ED25519Type {
+		return nil, fmt.Errorf("pubKeyBytesToHandle: '%s': %w", kt, errUnsupportedKeyType)
+	}
+
+	if len(pubKey) != ed25519.PublicKeySize {
+		return nil, errors.New("pubKeyBytesToHandle: invalid Ed25519 public key")
+	}
+
+	return &KeyHandle{PublicKey: pubKey}, nil
+}
+
+// ImportPrivateKey splits privKey, an ed25519.PrivateKey, between the participants. The private key itself is not
+// stored.
+// 'opts' allows setting the KeyID of the imported key using WithKeyID() option, it defaults to the JWK thumbprint of
+// its public key. An error is returned if the KeyID is already used.
+// Returns:
+//   - KeyID of the handle
+//   - *KeyHandle of the imported key
+//   - error if import failure
+func (k *ThresholdKMS) ImportPrivateKey(privKey interface{}, kt kmsapi.KeyType,
+	opts ...kmsapi.PrivateKeyOpts) (string, interface{}, error) {
+	edKey, ok := privKey.(ed25519.PrivateKey)
+	if !ok || kt != kmsapi.ED25519Type {
+		return "", nil, fmt.Errorf("importPrivateKey: '%s': %w", kt, errUnsupportedKeyType)
+	}
+
+	groupKey, err := frost.SplitEd25519(edKey, k.threshold, k.participants)
+	if err != nil {
+		return "", nil, fmt.Errorf("importPrivateKey: %w", err)
+	}
+
+	pOpts := kmsapi.NewOpt()
+
+	for _, opt := range opts {
+		opt(pOpts)
+	}
+
+	kid := pOpts.KsID()
+	if kid == "" {
+		kid, err = jwkkid.CreateKID(groupKey.PublicKey, kt)
+		if err != nil {
+			return "", nil, fmt.Errorf("importPrivateKey: failed to generate kid: %w", err)
+		}
+	}
+
+	kh, err := k.distribute(kid, groupKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("importPrivateKey: %w", err)
+	}
+
+	return kid, kh, nil
+}
@@ -0,0 +1,295 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package thresholdkms
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	mockstorage "github.com/hyperledger/aries-framework-go/component/storageutil/mock/storage"
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/frost"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms"
+	mockkms "github.com/hyperledger/aries-framework-go/component/kmscrypto/mock/kms"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/secretlock/noop"
+)
+
+const testKeyURI = "local-lock://test/key/uri"
+
+func newProvider(t *testing.T) kmsapi.Provider {
+	t.Helper()
+
+	p, err := mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{})
+	require.NoError(t, err)
+
+	return p
+}
+
+func newTestKMS(t *testing.T, threshold int, ids ...uint16) (*ThresholdKMS, []*Participant) {
+	t.Helper()
+
+	participants := make([]*Participant, len(ids))
+
+	for i, id := range ids {
+		participants[i] = NewParticipant(id, testKeyURI, newProvider(t))
+	}
+
+	k, err := New(newProvider(t), NewInProcessTransport(participants...), threshold, ids)
+	require.NoError(t, err)
+
+	return k, participants
+}
+
+// sign runs the two FROST rounds with participants, as crypto/thresholdcrypto does.
+func sign(t *testing.T, kh *KeyHandle, participants []*Participant, msg []byte) ([]byte, error) {
+	t.Helper()
+
+	commitments := make([]*frost.Commitment, len(participants))
+
+	for i, p := range participants {
+		resp, err := p.Handle(&Request{Type: CommitRequest, KeyID: kh.KeyID, SessionID: "session"})
+		if err != nil {
+			return nil, err
+		}
+
+		commitments[i] = resp.Commitment
+	}
+
+	sigShares := map[uint16][]byte{}
+
+	for _, p := range participants {
+		resp, err := p.Handle(&Request{
+			Type:        SignRequest,
+			KeyID:       kh.KeyID,
+			SessionID:   "session",
+			Message:     msg,
+			Commitments: commitments,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		sigShares[p.ID()] = resp.SignatureShare
+	}
+
+	return frost.Aggregate(kh.PublicKey, msg, commitments, kh.VerifyingShares, sigShares)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(newProvider(t), NewInProcessTransport(), 1, []uint16{1, 2})
+	require.EqualError(t, err, "new: invalid threshold 1 for 2 participants")
+
+	_, err = New(newProvider(t), NewInProcessTransport(), 3, []uint16{1, 2})
+	require.EqualError(t, err, "new: invalid threshold 3 for 2 participants")
+}
+
+func TestThresholdKMS_CreateAndSign(t *testing.T) {
+	k, participants := newTestKMS(t, 2, 1, 2, 3)
+
+	keyID, h, err := k.Create(kmsapi.ED25519Type)
+	require.NoError(t, err)
+	require.NotEmpty(t, keyID)
+
+	kh, ok := h.(*KeyHandle)
+	require.True(t, ok)
+	require.Equal(t, keyID, kh.KeyID)
+	require.Equal(t, 2, kh.Threshold)
+	require.Equal(t, []uint16{1, 2, 3}, kh.Participants())
+
+	pubKey, kt, err := k.ExportPubKeyBytes(keyID)
+	require.NoError(t, err)
+	require.Equal(t, kmsapi.ED25519Type, kt)
+	require.Equal(t, []byte(kh.PublicKey), pubKey)
+
+	got, err := k.Get(keyID)
+	require.NoError(t, err)
+	require.Equal(t, kh, got)
+
+	msg := []byte("test message")
+
+	for _, signers := range [][]*Participant{participants[:2], participants[1:], participants} {
+		sig, e := sign(t, kh, signers, msg)
+		require.NoError(t, e)
+		require.True(t, ed25519.Verify(pubKey, msg, sig))
+	}
+
+	t.Run("nonces are used once", func(t *testing.T) {
+		_, err = participants[0].Handle(&Request{Type: CommitRequest, KeyID: keyID, SessionID: "once"})
+		require.NoError(t, err)
+
+		_, err = participants[0].Handle(&Request{Type: CommitRequest, KeyID: keyID, SessionID: "once"})
+		require.EqualError(t, err, "participant 1: session 'once' already exists")
+
+		_, err = participants[0].Handle(&Request{Type: SignRequest, KeyID: keyID, SessionID: "once", Message: msg})
+		require.EqualError(t, err, "participant 1: commitment of session 'once' is missing")
+
+		_, err = participants[0].Handle(&Request{Type: SignRequest, KeyID: keyID, SessionID: "once", Message: msg})
+		require.EqualError(t, err, "participant 1: unknown session 'once'")
+	})
+
+	t.Run("aborted and expired sessions are removed", func(t *testing.T) {
+		p := participants[0]
+		now := time.Now()
+		p.now = func() time.Time { return now }
+
+		defer func() { p.now = time.Now }()
+
+		_, err = p.Handle(&Request{Type: CommitRequest, KeyID: keyID, SessionID: "aborted"})
+		require.NoError(t, err)
+
+		_, err = p.Handle(&Request{Type: AbortRequest, KeyID: keyID, SessionID: "aborted"})
+		require.NoError(t, err)
+		require.Empty(t, p.sessions)
+
+		_, err = p.Handle(&Request{Type: SignRequest, KeyID: keyID, SessionID: "aborted", Message: msg})
+		require.EqualError(t, err, "participant 1: unknown session 'aborted'")
+
+		resp, err := p.Handle(&Request{Type: CommitRequest, KeyID: keyID, SessionID: "expired"})
+		require.NoError(t, err)
+
+		now = now.Add(DefaultSessionTimeout)
+
+		_, err = p.Handle(&Request{
+			Type:        SignRequest,
+			KeyID:       keyID,
+			SessionID:   "expired",
+			Message:     msg,
+			Commitments: []*frost.Commitment{resp.Commitment},
+		})
+		require.EqualError(t, err, "participant 1: session 'expired' expired")
+
+		_, err = p.Handle(&Request{Type: CommitRequest, KeyID: keyID, SessionID: "abandoned"})
+		require.NoError(t, err)
+
+		now = now.Add(DefaultSessionTimeout)
+
+		// committing to a new session removes the expired ones.
+		_, err = p.Handle(&Request{Type: CommitRequest, KeyID: keyID, SessionID: "new"})
+		require.NoError(t, err)
+		require.Len(t, p.sessions, 1)
+		require.Contains(t, p.sessions, keyID+"/new")
+
+		short := NewParticipant(1, testKeyURI, newProvider(t), WithSessionTimeout(time.Second))
+		require.Equal(t, time.Second, short.sessionTimeout)
+	})
+
+	t.Run("create and export", func(t *testing.T) {
+		kid, pub, e := k.CreateAndExportPubKeyBytes(kmsapi.ED25519Type)
+		require.NoError(t, e)
+		require.NotEqual(t, keyID, kid)
+		require.Len(t, pub, ed25519.PublicKeySize)
+	})
+
+	t.Run("unsupported key type", func(t *testing.T) {
+		_, _, err = k.Create(kmsapi.ECDSAP256TypeDER)
+		require.ErrorIs(t, err, errUnsupportedKeyType)
+	})
+
+	t.Run("participant failure rolls back the shares", func(t *testing.T) {
+		failing, _ := newTestKMS(t, 2, 1, 2)
+		failing.participants = []uint16{1, 2, 3}
+		failing.transport = NewInProcessTransport(participants[0], participants[1])
+
+		_, _, err = failing.Create(kmsapi.ED25519Type)
+		require.EqualError(t, err, "create: failed to send share to participant 3: unknown participant 3")
+	})
+}
+
+func TestThresholdKMS_ImportPrivateKey(t *testing.T) {
+	k, participants := newTestKMS(t, 2, 1, 2, 3)
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	keyID, h, err := k.ImportPrivateKey(privKey, kmsapi.ED25519Type, kmsapi.WithKeyID("imported"))
+	require.NoError(t, err)
+	require.Equal(t, "imported", keyID)
+
+	kh, ok := h.(*KeyHandle)
+	require.True(t, ok)
+	require.Equal(t, pubKey, kh.PublicKey)
+
+	msg := []byte("test message")
+
+	sig, err := sign(t, kh, participants[1:], msg)
+	require.NoError(t, err)
+	require.True(t, ed25519.Verify(pubKey, msg, sig))
+
+	_, _, err = k.ImportPrivateKey(privKey, kmsapi.ED25519Type, kmsapi.WithKeyID("imported"))
+	require.EqualError(t, err, "importPrivateKey: key ID 'imported' already exists")
+
+	_, _, err = k.ImportPrivateKey(pubKey, kmsapi.ED25519Type)
+	require.ErrorIs(t, err, errUnsupportedKeyType)
+
+	vh, err := k.PubKeyBytesToHandle(pubKey, kmsapi.ED25519Type)
+	require.NoError(t, err)
+	require.Equal(t, &KeyHandle{PublicKey: pubKey}, vh)
+
+	_, err = k.PubKeyBytesToHandle(pubKey[:10], kmsapi.ED25519Type)
+	require.EqualError(t, err, "pubKeyBytesToHandle: invalid Ed25519 public key")
+
+	_, _, err = k.Rotate(kmsapi.ED25519Type, keyID)
+	require.EqualError(t, err, "function Rotate is not implemented in thresholdKMS")
+}
+
+func TestThresholdKMS_Lifecycle(t *testing.T) {
+	k, participants := newTestKMS(t, 2, 1, 2, 3)
+
+	keyID, h, err := k.Create(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	metadata, err := k.GetMetadata(keyID)
+	require.NoError(t, err)
+	require.Equal(t, kmsapi.ED25519Type, metadata.KeyType)
+	require.Equal(t, kmsapi.KeyStateEnabled, metadata.State)
+	require.NotNil(t, metadata.Created)
+
+	require.NoError(t, k.UpdateMetadata(keyID, kmsapi.WithLabels(map[string]string{"role": "issuer"})))
+
+	list, err := k.List(kmsapi.WithLabelFilter("role", "issuer"))
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	require.NoError(t, k.Disable(keyID))
+
+	_, err = k.Get(keyID)
+	require.ErrorIs(t, err, kms.ErrKeyDisabled)
+
+	_, _, err = k.ExportPubKeyBytes(keyID)
+	require.ErrorIs(t, err, kms.ErrKeyDisabled)
+
+	require.NoError(t, k.Enable(keyID))
+
+	_, err = k.Get(keyID)
+	require.NoError(t, err)
+
+	require.NoError(t, k.Destroy(keyID))
+	require.NoError(t, k.Destroy(keyID))
+
+	_, err = k.Get(keyID)
+	require.ErrorIs(t, err, kms.ErrKeyDestroyed)
+
+	require.ErrorIs(t, k.Enable(keyID), kms.ErrKeyDestroyed)
+
+	_, err = sign(t, h.(*KeyHandle), participants[:2], []byte("test message"))
+	require.Error(t, err)
+	require.True(t, errors.Is(err, kms.ErrKeyNotFound))
+
+	keyID, _, err = k.Create(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	require.NoError(t, k.Delete(keyID))
+
+	_, err = k.GetMetadata(keyID)
+	require.ErrorIs(t, err, kms.ErrKeyNotFound)
+}
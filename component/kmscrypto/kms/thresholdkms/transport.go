@@ -0,0 +1,83 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package thresholdkms
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/frost"
+)
+
+// RequestType is the type of the requests sent to participants.
+type RequestType string
+
+const (
+	// StoreShareRequest asks a participant to store its share of a new key.
+	StoreShareRequest RequestType = "storeShare"
+	// CommitRequest asks a participant for the nonce commitment of a new signing session.
+	CommitRequest RequestType = "commit"
+	// SignRequest asks a participant for its signature share in a signing session it committed to.
+	SignRequest RequestType = "sign"
+	// AbortRequest asks a participant to discard the nonces of a signing session it committed to.
+	AbortRequest RequestType = "abort"
+	// DeleteShareRequest asks a participant to delete its share of a key.
+	DeleteShareRequest RequestType = "deleteShare"
+)
+
+// Request is a request sent by the coordinator to a participant.
+type Request struct {
+	Type  RequestType `json:"type"`
+	KeyID string      `json:"keyID"`
+	// Share of the key, for StoreShareRequest.
+	Share *frost.KeyShare `json:"share,omitempty"`
+	// SessionID of the signing session, for CommitRequest, SignRequest and AbortRequest.
+	SessionID string `json:"sessionID,omitempty"`
+	// Message to sign and Commitments of the signers, for SignRequest.
+	Message     []byte              `json:"message,omitempty"`
+	Commitments []*frost.Commitment `json:"commitments,omitempty"`
+}
+
+// Response is the response of a participant to a Request.
+type Response struct {
+	// Commitment of the participant, for CommitRequest.
+	Commitment *frost.Commitment `json:"commitment,omitempty"`
+	// SignatureShare of the participant, for SignRequest.
+	SignatureShare []byte `json:"signatureShare,omitempty"`
+}
+
+// Transport delivers the requests of the coordinator to the participants. Implementations must authenticate the
+// participants and protect the confidentiality of the requests, StoreShareRequest carrying secret key shares.
+type Transport interface {
+	// Send delivers req to the participant identified by id and returns its response.
+	Send(id uint16, req *Request) (*Response, error)
+}
+
+// InProcessTransport is a Transport delivering the requests to participants running in the same process.
+type InProcessTransport struct {
+	participants map[uint16]*Participant
+}
+
+// NewInProcessTransport creates a new InProcessTransport delivering the requests to participants.
+func NewInProcessTransport(participants ...*Participant) *InProcessTransport {
+	t := &InProcessTransport{participants: map[uint16]*Participant{}}
+
+	for _, p := range participants {
+		t.participants[p.ID()] = p
+	}
+
+	return t
+}
+
+// Send delivers req to the participant identified by id.
+func (t *InProcessTransport) Send(id uint16, req *Request) (*Response, error) {
+	p, ok := t.participants[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown participant %d", id)
+	}
+
+	return p.Handle(req)
+}
@@ -89,7 +89,7 @@ func NewCryptoBox(w kms.KeyManager) (*CryptoBox, error) {
 // theirPub is used as a public key, while myPub is used to identify the private key that should be used.
 func (b *CryptoBox) Easy(payload, nonce, theirPub []byte, myKID string) ([]byte, error) {
 	easyStart := time.Now()
-	keyURL := b.km.buildKIDURL(myKID)
+	keyURL := b.km.keyURL(myKID)
 
 	destination := keyURL + wrapURL
 
@@ -275,7 +275,7 @@ func (b *CryptoBox) buildUnwrapURL(myPub []byte) (string, error) {
 		return "", err
 	}
 
-	keyURL := b.km.buildKIDURL(kid)
+	keyURL := b.km.keyURL(kid)
 
 	return keyURL + unwrapURL, nil
 }
@@ -9,6 +9,7 @@ package webkms
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/bluele/gcache"
 )
@@ -21,6 +22,10 @@ type Opts struct {
 	HeadersFunc     AddHeaders
 	ComputeMACCache gcache.Cache
 	marshal         MarshalFunc
+	maxRetries      int
+	retryBackoff    time.Duration
+	keyCache        gcache.Cache
+	httpSigner      *httpSigner
 }
 
 // NewOpt creates a new empty option.
@@ -54,3 +59,38 @@ func WithMarshalFn(fn MarshalFunc) Opt {
 		opts.marshal = fn
 	}
 }
+
+// WithRetry option retries up to maxRetries times the requests failing with a network error or with a 429, 502, 503
+// or 504 http status, waiting backoff before the first retry and doubling it before each next one. The POST and PUT
+// requests are sent with an 'Idempotency-Key' header, set to the same random value for all attempts, for the key
+// server to perform a request only once.
+func WithRetry(maxRetries int, backoff time.Duration) Opt {
+	return func(opts *Opts) {
+		opts.maxRetries = maxRetries
+		opts.retryBackoff = backoff
+	}
+}
+
+// WithKeyCache option caches in memory, for up to ttl (no expiration if zero), the key handles and the exported public
+// keys of up to cacheSize keys used by remoteKMS to save a request to the key server for each ExportPubKeyBytes call.
+func WithKeyCache(cacheSize int, ttl time.Duration) Opt {
+	return func(opts *Opts) {
+		cb := gcache.New(cacheSize).LRU()
+
+		if ttl > 0 {
+			cb = cb.Expiration(ttl)
+		}
+
+		opts.keyCache = cb.Build()
+	}
+}
+
+// WithHTTPSignature option authenticates the requests sent to the key server with an HTTP signature (see
+// https://datatracker.ietf.org/doc/html/draft-cavage-http-signatures-12) computed by sign with the key keyID of
+// algorithm alg (eg: "ed25519" or "ecdsa-sha256"). The signature covers the request target, 'Host', 'Date' and, for
+// requests with a body, 'Digest' headers, as well as the 'Idempotency-Key' header of retried requests.
+func WithHTTPSignature(keyID, alg string, sign SignFunc) Opt {
+	return func(opts *Opts) {
+		opts.httpSigner = &httpSigner{keyID: keyID, alg: alg, sign: sign}
+	}
+}
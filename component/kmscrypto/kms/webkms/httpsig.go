@@ -0,0 +1,198 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webkms
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureHeader is the http header carrying the HTTP signature of a request.
+	SignatureHeader = "Signature"
+
+	digestHeader       = "Digest"
+	digestPrefix       = "SHA-256="
+	requestTargetField = "(request-target)"
+
+	// maxClockSkew is the maximum difference accepted between the 'Date' header of a signed request and the time it
+	// is verified.
+	maxClockSkew = 5 * time.Minute
+)
+
+// SignFunc signs data for the HTTP signature of a request (eg: with a Sign call of a crypto.Crypto and a private key
+// handle).
+type SignFunc func(data []byte) ([]byte, error)
+
+// VerifyFunc verifies signature of data for the HTTP signature of a request with the key keyID of algorithm alg.
+type VerifyFunc func(keyID, alg string, signature, data []byte) error
+
+type httpSigner struct {
+	keyID string
+	alg   string
+	sign  SignFunc
+}
+
+// signRequest sets the 'Date', 'Digest' (if body is not empty) and 'Signature' headers of req. The signature also
+// covers the 'Idempotency-Key' header, if set.
+func (s *httpSigner) signRequest(req *http.Request, body []byte) error {
+	if req.Header.Get("Date") == "" {
+		req.Header.Set("Date", time.Now().UTC().Format(http.TimeFormat))
+	}
+
+	headers := []string{requestTargetField, "host", "date"}
+
+	if len(body) > 0 {
+		req.Header.Set(digestHeader, bodyDigest(body))
+
+		headers = append(headers, "digest")
+	}
+
+	if req.Header.Get(IdempotencyKeyHeader) != "" {
+		headers = append(headers, strings.ToLower(IdempotencyKeyHeader))
+	}
+
+	sig, err := s.sign([]byte(signingString(req, headers)))
+	if err != nil {
+		return fmt.Errorf("sign request: %w", err)
+	}
+
+	req.Header.Set(SignatureHeader, fmt.Sprintf(`keyId="%s",algorithm="%s",headers="%s",signature="%s"`,
+		s.keyID, s.alg, strings.Join(headers, " "), base64.StdEncoding.EncodeToString(sig)))
+
+	return nil
+}
+
+// VerifyHTTPSignature verifies the HTTP signature of req, as set by the WithHTTPSignature option, with verify. body
+// is the content of req's body, read by the caller. It is meant for key servers (eg: webkmstest.Server) authenticating
+// remoteKMS requests.
+// Returns:
+//   - the keyID of the signature (if successful)
+//   - error (if the signature is missing, invalid or the request was modified)
+func VerifyHTTPSignature(req *http.Request, body []byte, verify VerifyFunc) (string, error) {
+	params, err := parseSignatureHeader(req.Header.Get(SignatureHeader))
+	if err != nil {
+		return "", err
+	}
+
+	headers := strings.Fields(params["headers"])
+	if !containsAll(headers, requestTargetField, "host", "date") {
+		return "", errors.New("http signature must cover the request target, host and date headers")
+	}
+
+	date, err := http.ParseTime(req.Header.Get("Date"))
+	if err != nil {
+		return "", fmt.Errorf("invalid date header: %w", err)
+	}
+
+	if skew := time.Since(date); skew > maxClockSkew || skew < -maxClockSkew {
+		return "", errors.New("http signature date is out of range")
+	}
+
+	if req.Header.Get(IdempotencyKeyHeader) != "" && !containsAll(headers, strings.ToLower(IdempotencyKeyHeader)) {
+		return "", errors.New("http signature must cover the idempotency key header")
+	}
+
+	if len(body) > 0 || req.Header.Get(digestHeader) != "" {
+		if !containsAll(headers, "digest") || req.Header.Get(digestHeader) != bodyDigest(body) {
+			return "", errors.New("invalid digest header")
+		}
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(params["signature"])
+	if err != nil {
+		return "", fmt.Errorf("invalid http signature: %w", err)
+	}
+
+	err = verify(params["keyId"], params["algorithm"], sig, []byte(signingString(req, headers)))
+	if err != nil {
+		return "", fmt.Errorf("verify http signature: %w", err)
+	}
+
+	return params["keyId"], nil
+}
+
+// signingString builds the string signed for the listed headers of req.
+func signingString(req *http.Request, headers []string) string {
+	lines := make([]string, len(headers))
+
+	for i, h := range headers {
+		var value string
+
+		switch h {
+		case requestTargetField:
+			value = strings.ToLower(req.Method) + " " + req.URL.RequestURI()
+		case "host":
+			value = req.Host
+			if value == "" {
+				value = req.URL.Host
+			}
+		default:
+			value = req.Header.Get(h)
+		}
+
+		lines[i] = h + ": " + value
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func parseSignatureHeader(header string) (map[string]string, error) {
+	if header == "" {
+		return nil, errors.New("missing http signature")
+	}
+
+	params := map[string]string{}
+
+	for _, param := range strings.Split(header, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid http signature parameter '%s'", param)
+		}
+
+		params[name] = strings.Trim(value, `"`)
+	}
+
+	for _, name := range []string{"keyId", "headers", "signature"} {
+		if params[name] == "" {
+			return nil, fmt.Errorf("missing http signature parameter '%s'", name)
+		}
+	}
+
+	return params, nil
+}
+
+func bodyDigest(body []byte) string {
+	d := sha256.Sum256(body)
+
+	return digestPrefix + base64.StdEncoding.EncodeToString(d[:])
+}
+
+func containsAll(values []string, expected ...string) bool {
+	for _, e := range expected {
+		found := false
+
+		for _, v := range values {
+			if v == e {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
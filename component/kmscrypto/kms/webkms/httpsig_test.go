@@ -0,0 +1,141 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webkms
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyHTTPSignature(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer := &httpSigner{keyID: "client-key", alg: "ed25519", sign: func(data []byte) ([]byte, error) {
+		return ed25519.Sign(privKey, data), nil
+	}}
+
+	verify := func(keyID, alg string, signature, data []byte) error {
+		if !ed25519.Verify(pubKey, data, signature) {
+			return errors.New("invalid signature")
+		}
+
+		return nil
+	}
+
+	body := []byte(`{"key_type":"ED25519"}`)
+
+	newSignedRequest := func(t *testing.T, method string, body []byte) *http.Request {
+		t.Helper()
+
+		req, e := http.NewRequest(method, "https://localhost:8080/v1/keystores/123/keys?a=b", nil)
+		require.NoError(t, e)
+
+		require.NoError(t, signer.signRequest(req, body))
+
+		return req
+	}
+
+	t.Run("success", func(t *testing.T) {
+		req := newSignedRequest(t, http.MethodPost, body)
+		require.Contains(t, req.Header.Get(SignatureHeader),
+			`headers="(request-target) host date digest"`)
+
+		keyID, e := VerifyHTTPSignature(req, body, verify)
+		require.NoError(t, e)
+		require.Equal(t, "client-key", keyID)
+
+		req = newSignedRequest(t, http.MethodGet, nil)
+		require.Empty(t, req.Header.Get(digestHeader))
+
+		_, e = VerifyHTTPSignature(req, nil, verify)
+		require.NoError(t, e)
+	})
+
+	t.Run("success with idempotency key", func(t *testing.T) {
+		req, e := http.NewRequest(http.MethodPost, "https://localhost:8080/v1/keystores/123/keys", nil)
+		require.NoError(t, e)
+
+		req.Header.Set(IdempotencyKeyHeader, "abc")
+
+		require.NoError(t, signer.signRequest(req, body))
+		require.Contains(t, req.Header.Get(SignatureHeader),
+			`headers="(request-target) host date digest idempotency-key"`)
+
+		_, e = VerifyHTTPSignature(req, body, verify)
+		require.NoError(t, e)
+
+		req.Header.Set(IdempotencyKeyHeader, "def")
+
+		_, e = VerifyHTTPSignature(req, body, verify)
+		require.EqualError(t, e, "verify http signature: invalid signature")
+
+		req = newSignedRequest(t, http.MethodPost, body)
+		req.Header.Set(IdempotencyKeyHeader, "abc")
+
+		_, e = VerifyHTTPSignature(req, body, verify)
+		require.EqualError(t, e, "http signature must cover the idempotency key header")
+	})
+
+	t.Run("fail with modified request", func(t *testing.T) {
+		req := newSignedRequest(t, http.MethodPost, body)
+
+		_, e := VerifyHTTPSignature(req, []byte(`{"key_type":"AES256GCM"}`), verify)
+		require.EqualError(t, e, "invalid digest header")
+
+		req.URL.Path = "/v1/keystores/456/keys"
+
+		_, e = VerifyHTTPSignature(req, body, verify)
+		require.EqualError(t, e, "verify http signature: invalid signature")
+
+		req = newSignedRequest(t, http.MethodGet, nil)
+
+		_, e = VerifyHTTPSignature(req, body, verify)
+		require.EqualError(t, e, "invalid digest header")
+	})
+
+	t.Run("fail with invalid date", func(t *testing.T) {
+		req := newSignedRequest(t, http.MethodPost, body)
+		req.Header.Set("Date", "yesterday")
+
+		_, e := VerifyHTTPSignature(req, body, verify)
+		require.Error(t, e)
+		require.Contains(t, e.Error(), "invalid date header")
+
+		req.Header.Set("Date", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+
+		_, e = VerifyHTTPSignature(req, body, verify)
+		require.EqualError(t, e, "http signature date is out of range")
+	})
+
+	t.Run("fail with invalid signature header", func(t *testing.T) {
+		req := newSignedRequest(t, http.MethodPost, body)
+		sigHeader := req.Header.Get(SignatureHeader)
+
+		for header, errMsg := range map[string]string{
+			"":                        "missing http signature",
+			"keyId":                   "invalid http signature parameter 'keyId'",
+			`keyId="a",signature="b"`: "missing http signature parameter 'headers'",
+			`keyId="a",headers="date",signature="b"`: "http signature must cover the request target, host and " +
+				"date headers",
+			strings.Replace(sigHeader, `signature="`, `signature="!`, 1): "invalid http signature: illegal " +
+				"base64 data at input byte 0",
+		} {
+			req.Header.Set(SignatureHeader, header)
+
+			_, e := VerifyHTTPSignature(req, body, verify)
+			require.EqualError(t, e, errMsg)
+		}
+	})
+}
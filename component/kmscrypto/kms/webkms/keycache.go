@@ -0,0 +1,70 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webkms
+
+import (
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+// cachedKey is the entry of a key in the WithKeyCache cache. pubKey is empty until the public key is known.
+type cachedKey struct {
+	keyURL  string
+	pubKey  []byte
+	keyType kms.KeyType
+}
+
+// getCachedKey returns the cached entry of keyID or nil if it is not cached (or caching is disabled).
+func (r *RemoteKMS) getCachedKey(keyID string) *cachedKey {
+	if r.opts.keyCache == nil {
+		return nil
+	}
+
+	v, err := r.opts.keyCache.Get(keyID)
+	if err != nil {
+		return nil
+	}
+
+	ck, ok := v.(*cachedKey)
+	if !ok {
+		return nil
+	}
+
+	return ck
+}
+
+// cacheKey stores the key handle (keyURL) and public key of keyID, if caching is enabled. An empty keyURL keeps the
+// cached handle of keyID (if any).
+func (r *RemoteKMS) cacheKey(keyID, keyURL string, pubKey []byte, kt kms.KeyType) {
+	if r.opts.keyCache == nil {
+		return
+	}
+
+	if keyURL == "" {
+		keyURL = r.keyURL(keyID)
+	}
+
+	err := r.opts.keyCache.Set(keyID, &cachedKey{keyURL: keyURL, pubKey: pubKey, keyType: kt})
+	if err != nil {
+		logger.Warnf("failed to cache key %s: %s", keyID, err)
+	}
+}
+
+// evictKey removes keyID from the cache, if caching is enabled.
+func (r *RemoteKMS) evictKey(keyID string) {
+	if r.opts.keyCache != nil {
+		r.opts.keyCache.Remove(keyID)
+	}
+}
+
+// keyURL returns the handle of keyID, the key URL returned by the key server if the key is cached.
+func (r *RemoteKMS) keyURL(keyID string) string {
+	if ck := r.getCachedKey(keyID); ck != nil {
+		return ck.keyURL
+	}
+
+	return r.buildKIDURL(keyID)
+}
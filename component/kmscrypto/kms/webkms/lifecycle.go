@@ -0,0 +1,220 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webkms
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+type keyMetadataResp struct {
+	KeyID   string            `json:"key_id"`
+	KeyType string            `json:"key_type,omitempty"`
+	State   string            `json:"state"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Usage   []string          `json:"usage,omitempty"`
+	Created *time.Time        `json:"created,omitempty"`
+	Updated *time.Time        `json:"updated,omitempty"`
+}
+
+type updateMetadataReq struct {
+	Labels map[string]string `json:"labels,omitempty"`
+	Usage  []string          `json:"usage,omitempty"`
+}
+
+type listKeysResp struct {
+	Keys []keyMetadataResp `json:"keys"`
+}
+
+func (r *RemoteKMS) deleteHTTPRequest(destination string) (*http.Response, error) {
+	return r.doHTTPRequest(http.MethodDelete, destination, nil)
+}
+
+// Delete remotely removes the key referenced by keyID along with its metadata from the key server.
+func (r *RemoteKMS) Delete(keyID string) error {
+	destination := r.keyURL(keyID)
+
+	r.evictKey(keyID)
+
+	resp, err := r.deleteHTTPRequest(destination)
+	if err != nil {
+		return fmt.Errorf("posting DELETE Delete key failed [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "Delete")
+
+	err = checkError(resp)
+	if err != nil {
+		return fmt.Errorf("delete key failed [%s, %w]", destination, err)
+	}
+
+	return nil
+}
+
+// Disable remotely marks the key referenced by keyID as disabled.
+func (r *RemoteKMS) Disable(keyID string) error {
+	r.evictKey(keyID)
+
+	return r.postKeyAction(keyID, "disable")
+}
+
+// Enable remotely restores a key previously disabled with Disable.
+func (r *RemoteKMS) Enable(keyID string) error {
+	return r.postKeyAction(keyID, "enable")
+}
+
+// Destroy remotely erases the key material referenced by keyID. The key server keeps its metadata with the
+// destroyed state.
+func (r *RemoteKMS) Destroy(keyID string) error {
+	r.evictKey(keyID)
+
+	return r.postKeyAction(keyID, "destroy")
+}
+
+func (r *RemoteKMS) postKeyAction(keyID, action string) error {
+	destination := r.keyURL(keyID) + "/" + action
+
+	resp, err := r.postHTTPRequest(destination, []byte("{}"))
+	if err != nil {
+		return fmt.Errorf("posting %s key failed [%s, %w]", action, destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, action)
+
+	err = checkError(resp)
+	if err != nil {
+		return fmt.Errorf("%s key failed [%s, %w]", action, destination, err)
+	}
+
+	return nil
+}
+
+// GetMetadata remotely fetches the metadata of the key referenced by keyID.
+func (r *RemoteKMS) GetMetadata(keyID string) (*kms.KeyMetadata, error) {
+	destination := r.keyURL(keyID) + "/metadata"
+
+	resp, err := r.getHTTPRequest(destination)
+	if err != nil {
+		return nil, fmt.Errorf("posting GET GetMetadata key failed [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "GetMetadata")
+
+	httpResp := &keyMetadataResp{}
+
+	err = readResponse(resp, httpResp, r.unmarshalFunc)
+	if err != nil {
+		return nil, fmt.Errorf("get key metadata failed [%s, %w]", destination, err)
+	}
+
+	return httpResp.toKeyMetadata(), nil
+}
+
+// UpdateMetadata remotely sets the labels and usage of the key referenced by keyID described in `opts`.
+func (r *RemoteKMS) UpdateMetadata(keyID string, opts ...kms.MetadataOpts) error {
+	metadataOpts := kms.NewMetadataOpt()
+
+	for _, opt := range opts {
+		opt(metadataOpts)
+	}
+
+	destination := r.keyURL(keyID) + "/metadata"
+
+	marshaledReq, err := r.marshalFunc(&updateMetadataReq{
+		Labels: metadataOpts.Labels(),
+		Usage:  metadataOpts.Usage(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal UpdateMetadata request [%s, %w]", destination, err)
+	}
+
+	resp, err := r.putHTTPRequest(destination, marshaledReq)
+	if err != nil {
+		return fmt.Errorf("posting PUT UpdateMetadata key failed [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "UpdateMetadata")
+
+	err = checkError(resp)
+	if err != nil {
+		return fmt.Errorf("update key metadata failed [%s, %w]", destination, err)
+	}
+
+	return nil
+}
+
+// List remotely fetches the metadata of the keys matching the filters described in `opts`. Filters are sent as the
+// 'key_type', 'state' and (repeated) 'label' ("name:value") query parameters.
+func (r *RemoteKMS) List(opts ...kms.ListOpts) ([]*kms.KeyMetadata, error) {
+	listOpts := kms.NewListOpt()
+
+	for _, opt := range opts {
+		opt(listOpts)
+	}
+
+	query := url.Values{}
+
+	if listOpts.KeyType() != "" {
+		query.Set("key_type", string(listOpts.KeyType()))
+	}
+
+	if listOpts.State() != "" {
+		query.Set("state", string(listOpts.State()))
+	}
+
+	for name, value := range listOpts.Labels() {
+		query.Add("label", name+":"+value)
+	}
+
+	destination := r.keystoreURL + "/keys"
+	if len(query) > 0 {
+		destination += "?" + query.Encode()
+	}
+
+	resp, err := r.getHTTPRequest(destination)
+	if err != nil {
+		return nil, fmt.Errorf("posting GET List keys failed [%s, %w]", destination, err)
+	}
+
+	// handle response
+	defer closeResponseBody(resp.Body, logger, "List")
+
+	httpResp := &listKeysResp{}
+
+	err = readResponse(resp, httpResp, r.unmarshalFunc)
+	if err != nil {
+		return nil, fmt.Errorf("list keys failed [%s, %w]", destination, err)
+	}
+
+	result := make([]*kms.KeyMetadata, len(httpResp.Keys))
+
+	for i := range httpResp.Keys {
+		result[i] = httpResp.Keys[i].toKeyMetadata()
+	}
+
+	return result, nil
+}
+
+func (k *keyMetadataResp) toKeyMetadata() *kms.KeyMetadata {
+	return &kms.KeyMetadata{
+		KeyID:   k.KeyID,
+		KeyType: kms.KeyType(k.KeyType),
+		State:   kms.KeyState(k.State),
+		Labels:  k.Labels,
+		Usage:   k.Usage,
+		Created: k.Created,
+		Updated: k.Updated,
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webkms
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+func TestRemoteKMS_Lifecycle(t *testing.T) {
+	var (
+		method, path, query string
+		body                []byte
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, query = r.Method, r.URL.Path, r.URL.RawQuery
+
+		var err error
+
+		body, err = io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		switch {
+		case r.URL.Path == "/v1/keystores/"+defaultKeyStoreID+"/keys/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, err = w.Write([]byte(`{"errMessage":"key not found"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/keystores/"+defaultKeyStoreID+"/keys":
+			err = json.NewEncoder(w).Encode(&listKeysResp{Keys: []keyMetadataResp{
+				{KeyID: defaultKID, KeyType: kmsapi.ED25519, State: "enabled"},
+			}})
+		case r.Method == http.MethodGet:
+			err = json.NewEncoder(w).Encode(&keyMetadataResp{
+				KeyID: defaultKID, KeyType: kmsapi.ED25519, State: "disabled", Labels: map[string]string{"a": "b"},
+			})
+		}
+
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	keystoreURL := server.URL + "/v1/keystores/" + defaultKeyStoreID
+	remoteKMS := New(keystoreURL, server.Client())
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, remoteKMS.Delete(defaultKID))
+		require.Equal(t, http.MethodDelete, method)
+		require.Equal(t, "/v1/keystores/"+defaultKeyStoreID+"/keys/"+defaultKID, path)
+
+		err := remoteKMS.Delete("missing")
+		require.EqualError(t, err, "delete key failed ["+keystoreURL+"/keys/missing, key not found]")
+	})
+
+	t.Run("state changes", func(t *testing.T) {
+		for action, fn := range map[string]func(string) error{
+			"disable": remoteKMS.Disable,
+			"enable":  remoteKMS.Enable,
+			"destroy": remoteKMS.Destroy,
+		} {
+			require.NoError(t, fn(defaultKID))
+			require.Equal(t, http.MethodPost, method)
+			require.Equal(t, "/v1/keystores/"+defaultKeyStoreID+"/keys/"+defaultKID+"/"+action, path)
+		}
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		metadata, err := remoteKMS.GetMetadata(defaultKID)
+		require.NoError(t, err)
+		require.Equal(t, &kmsapi.KeyMetadata{
+			KeyID:   defaultKID,
+			KeyType: kmsapi.ED25519Type,
+			State:   kmsapi.KeyStateDisabled,
+			Labels:  map[string]string{"a": "b"},
+		}, metadata)
+
+		err = remoteKMS.UpdateMetadata(defaultKID, kmsapi.WithUsage("sign"))
+		require.NoError(t, err)
+		require.Equal(t, http.MethodPut, method)
+		require.Equal(t, "/v1/keystores/"+defaultKeyStoreID+"/keys/"+defaultKID+"/metadata", path)
+		require.JSONEq(t, `{"usage":["sign"]}`, string(body))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		keys, err := remoteKMS.List(kmsapi.WithKeyTypeFilter(kmsapi.ED25519Type),
+			kmsapi.WithLabelFilter("team", "issuance"))
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		require.Equal(t, defaultKID, keys[0].KeyID)
+		require.Equal(t, "key_type=ED25519&label=team%3Aissuance", query)
+	})
+}
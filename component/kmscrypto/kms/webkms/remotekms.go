@@ -154,20 +154,13 @@ func CreateKeyStore(httpClient HTTPClient, keyserverURL, controller, vaultURL st
 
 	httpReq.Header.Set("Content-Type", ContentType)
 
-	if kmsOpts.HeadersFunc != nil {
-		httpHeaders, e := kmsOpts.HeadersFunc(httpReq)
-		if e != nil {
-			return "", nil, fmt.Errorf("add optional request headers error: %w", e)
-		}
+	start := time.Now()
 
-		if httpHeaders != nil {
-			httpReq.Header = httpHeaders.Clone()
-		}
+	resp, err := DoHTTPRequest(httpClient, httpReq, mReq, kmsOpts)
+	if isRequestError(err) {
+		return "", nil, err
 	}
 
-	start := time.Now()
-
-	resp, err := httpClient.Do(httpReq)
 	if err != nil {
 		return "", nil, fmt.Errorf("posting Create keystore failed [%s, %w]", destination, err)
 	}
@@ -241,18 +234,7 @@ func (r *RemoteKMS) doHTTPRequest(method, destination string, mReq []byte) (*htt
 		httpReq.Header.Set("Content-Type", ContentType)
 	}
 
-	if r.opts.HeadersFunc != nil {
-		httpHeaders, e := r.opts.HeadersFunc(httpReq)
-		if e != nil {
-			return nil, fmt.Errorf("add optional request headers error: %w", e)
-		}
-
-		if httpHeaders != nil {
-			httpReq.Header = httpHeaders.Clone()
-		}
-	}
-
-	resp, err := r.httpClient.Do(httpReq)
+	resp, err := DoHTTPRequest(r.httpClient, httpReq, mReq, r.opts)
 
 	logger.Debugf("  HTTP %s %s call duration: %s", method, destination, time.Since(start))
 
@@ -267,13 +249,15 @@ func (r *RemoteKMS) doHTTPRequest(method, destination string, mReq []byte) (*htt
 func (r *RemoteKMS) Create(kt kms.KeyType, opts ...kms.KeyOpts) (string, interface{}, error) {
 	startCreate := time.Now()
 
-	keyURL, _, err := r.createKey(kt, opts...)
+	keyURL, keyBytes, err := r.createKey(kt, opts...)
 	if err != nil {
 		return "", nil, err
 	}
 
 	kid := keyURL[strings.LastIndex(keyURL, "/")+1:]
 
+	r.cacheKey(kid, keyURL, keyBytes, kt)
+
 	logger.Debugf("overall Create key duration: %s", time.Since(startCreate))
 
 	return kid, keyURL, nil
@@ -338,12 +322,13 @@ func (r *RemoteKMS) HealthCheck() error {
 	return nil
 }
 
-// Get key handle for the given KeyID remotely
+// Get key handle for the given KeyID remotely (the key URL returned by the key server if the key is cached with the
+// WithKeyCache option)
 // Returns:
 //   - handle instance representing a remote keystore URL including KeyID
 //   - error if failure
 func (r *RemoteKMS) Get(keyID string) (interface{}, error) {
-	return r.buildKIDURL(keyID), nil
+	return r.keyURL(keyID), nil
 }
 
 func (r *RemoteKMS) buildKIDURL(keyID string) string {
@@ -366,8 +351,12 @@ func (r *RemoteKMS) Rotate(kt kms.KeyType, keyID string, opts ...kms.KeyOpts) (s
 //   - marshalled public key []byte
 //   - error if it fails to export the public key bytes
 func (r *RemoteKMS) ExportPubKeyBytes(keyID string) ([]byte, kms.KeyType, error) {
+	if ck := r.getCachedKey(keyID); ck != nil && len(ck.pubKey) > 0 {
+		return ck.pubKey, ck.keyType, nil
+	}
+
 	startExport := time.Now()
-	keyURL := r.buildKIDURL(keyID)
+	keyURL := r.keyURL(keyID)
 
 	destination := keyURL + "/export"
 
@@ -386,6 +375,8 @@ func (r *RemoteKMS) ExportPubKeyBytes(keyID string) ([]byte, kms.KeyType, error)
 		return nil, "", fmt.Errorf("export pub key bytes failed [%s, %w]", destination, err)
 	}
 
+	r.cacheKey(keyID, "", httpResp.PublicKey, kms.KeyType(httpResp.KeyType))
+
 	logger.Debugf("overall ExportPubKeyBytes duration: %s", time.Since(startExport))
 
 	return httpResp.PublicKey, kms.KeyType(httpResp.KeyType), nil
@@ -407,6 +398,8 @@ func (r *RemoteKMS) CreateAndExportPubKeyBytes(kt kms.KeyType, opts ...kms.KeyOp
 
 	kid := keyURL[strings.LastIndex(keyURL, "/")+1:]
 
+	r.cacheKey(kid, keyURL, keyBytes, kt)
+
 	logger.Debugf("overall CreateAndExportPubKeyBytes duration: %s", time.Since(start))
 
 	return kid, keyBytes, nil
@@ -472,6 +465,8 @@ func (r *RemoteKMS) ImportPrivateKey(privKey interface{}, kt kms.KeyType,
 
 	kid := keyURL[strings.LastIndex(keyURL, "/")+1:]
 
+	r.cacheKey(kid, keyURL, nil, kt)
+
 	return kid, keyURL, nil
 }
 
@@ -0,0 +1,159 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webkms
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// IdempotencyKeyHeader is the http header set to the same value for all attempts of a retried request.
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	idempotencyKeySize = 16
+)
+
+// requestError is a failure to prepare a request, as opposed to a failure to send it.
+type requestError struct {
+	err error
+}
+
+func (e *requestError) Error() string {
+	return e.err.Error()
+}
+
+func (e *requestError) Unwrap() error {
+	return e.err
+}
+
+// isRequestError reports whether err is a failure to prepare a request, e.g. from the optional headers function.
+func isRequestError(err error) bool {
+	var reqErr *requestError
+
+	return errors.As(err, &reqErr)
+}
+
+// DoHTTPRequest sends httpReq, with body mReq (if not nil), using httpClient. The optional headers, retries and HTTP
+// signature set in opts are applied to each attempt. Retries stop when the context of httpReq is done.
+// Not to be used directly. It's intended for implementations of remoteKMS.
+func DoHTTPRequest(httpClient HTTPClient, httpReq *http.Request, mReq []byte, opts *Opts) (*http.Response, error) {
+	var idempotencyKey string
+
+	if opts.maxRetries > 0 && httpReq.Method != http.MethodGet {
+		k := make([]byte, idempotencyKeySize)
+
+		_, err := rand.Read(k)
+		if err != nil {
+			return nil, fmt.Errorf("create idempotency key error: %w", err)
+		}
+
+		idempotencyKey = hex.EncodeToString(k)
+	}
+
+	backoff := opts.retryBackoff
+
+	for attempt := 0; ; attempt++ {
+		req, err := prepareHTTPRequest(httpReq, mReq, idempotencyKey, opts)
+		if err != nil {
+			return nil, &requestError{err: err}
+		}
+
+		resp, err := httpClient.Do(req)
+		if attempt >= opts.maxRetries || !isRetryable(resp, err) {
+			return resp, err
+		}
+
+		if err != nil {
+			logger.Debugf("retrying HTTP %s %s after error: %s", req.Method, req.URL, err)
+		} else {
+			logger.Debugf("retrying HTTP %s %s after status: %s", req.Method, req.URL, resp.Status)
+
+			closeResponseBody(resp.Body, logger, "retried request")
+		}
+
+		select {
+		case <-httpReq.Context().Done():
+			return nil, fmt.Errorf("retry HTTP request: %w", httpReq.Context().Err())
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+// prepareHTTPRequest returns a copy of httpReq, with a new reader of mReq as body, to be sent as an attempt.
+func prepareHTTPRequest(httpReq *http.Request, mReq []byte, idempotencyKey string, opts *Opts) (*http.Request, error) {
+	req := httpReq.Clone(httpReq.Context())
+
+	if mReq != nil {
+		req.Body = io.NopCloser(bytes.NewReader(mReq))
+		req.ContentLength = int64(len(mReq))
+	}
+
+	if opts.HeadersFunc != nil {
+		httpHeaders, e := opts.HeadersFunc(req)
+		if e != nil {
+			return nil, fmt.Errorf("add optional request headers error: %w", e)
+		}
+
+		if httpHeaders != nil {
+			req.Header = httpHeaders.Clone()
+		}
+	}
+
+	if idempotencyKey != "" {
+		req.Header.Set(IdempotencyKeyHeader, idempotencyKey)
+	}
+
+	// the HTTP signature must be set last as it covers the other headers.
+	if opts.httpSigner != nil {
+		err := opts.httpSigner.signRequest(req, mReq)
+		if err != nil {
+			return nil, fmt.Errorf("add http signature error: %w", err)
+		}
+	}
+
+	return req, nil
+}
+
+func isRetryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewMutualTLSClient creates an http client authenticating to the key server with the clientCert certificate (mutual
+// TLS) and trusting the key server certificates issued by rootCAs (the system's roots if nil).
+func NewMutualTLSClient(clientCert tls.Certificate, rootCAs *x509.CertPool, timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				Certificates: []tls.Certificate{clientCert},
+				RootCAs:      rootCAs,
+				MinVersion:   tls.VersionTLS12,
+			},
+		},
+	}
+}
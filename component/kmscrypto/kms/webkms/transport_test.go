@@ -0,0 +1,319 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webkms_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/webkms"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/webkms/webkmstest"
+)
+
+const (
+	clientTimeout = 5 * time.Second
+	retryBackoff  = time.Millisecond
+)
+
+func TestRemoteKMS_WithRetry(t *testing.T) {
+	server, err := webkmstest.NewServer()
+	require.NoError(t, err)
+
+	defer server.Close()
+
+	t.Run("retry failed requests with the same idempotency key", func(t *testing.T) {
+		remoteKMS := newRemoteKMS(t, server, server.Client(), webkms.WithRetry(2, retryBackoff))
+
+		server.FailNext(2)
+
+		requestCount := server.RequestCount()
+
+		kid, pubKey, err := remoteKMS.CreateAndExportPubKeyBytes(kmsapi.ED25519Type)
+		require.NoError(t, err)
+		require.NotEmpty(t, pubKey)
+		require.Equal(t, requestCount+3, server.RequestCount())
+
+		// the key was created once by the key server.
+		keys, err := remoteKMS.List()
+		require.NoError(t, err)
+		require.Len(t, keys, 1)
+		require.Equal(t, kid, keys[0].KeyID)
+	})
+
+	t.Run("fail when retries are exhausted", func(t *testing.T) {
+		remoteKMS := newRemoteKMS(t, server, server.Client(), webkms.WithRetry(1, retryBackoff))
+
+		server.FailNext(2)
+
+		_, _, err := remoteKMS.Create(kmsapi.ED25519Type)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service unavailable")
+	})
+
+	t.Run("fail without retry", func(t *testing.T) {
+		remoteKMS := newRemoteKMS(t, server, server.Client())
+
+		server.FailNext(1)
+
+		_, _, err := remoteKMS.Create(kmsapi.ED25519Type)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "service unavailable")
+	})
+
+	t.Run("retry network errors", func(t *testing.T) {
+		client := &flakyHTTPClient{client: server.Client()}
+		remoteKMS := newRemoteKMS(t, server, client, webkms.WithRetry(1, retryBackoff))
+
+		client.failures = 1
+		client.idempotencyKeys = nil
+
+		_, _, err := remoteKMS.Create(kmsapi.ED25519Type)
+		require.NoError(t, err)
+		require.Len(t, client.idempotencyKeys, 2)
+		require.NotEmpty(t, client.idempotencyKeys[0])
+		require.Equal(t, client.idempotencyKeys[0], client.idempotencyKeys[1])
+	})
+}
+
+func TestDoHTTPRequest_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://localhost:8080/v1/keystores", nil)
+	require.NoError(t, err)
+
+	opts := webkms.NewOpt()
+	webkms.WithRetry(3, time.Hour)(opts)
+
+	client := &flakyHTTPClient{failures: 1}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err = webkms.DoHTTPRequest(client, req, nil, opts)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRemoteKMS_WithKeyCache(t *testing.T) {
+	server, err := webkmstest.NewServer()
+	require.NoError(t, err)
+
+	defer server.Close()
+
+	remoteKMS := newRemoteKMS(t, server, server.Client(), webkms.WithKeyCache(10, time.Minute))
+
+	kid, keyURL, err := remoteKMS.Create(kmsapi.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	requestCount := server.RequestCount()
+
+	for i := 0; i < 3; i++ {
+		pubKey, kt, e := remoteKMS.ExportPubKeyBytes(kid)
+		require.NoError(t, e)
+		require.NotEmpty(t, pubKey)
+		require.Equal(t, kmsapi.ECDSAP256TypeIEEEP1363, kt)
+
+		kh, e := remoteKMS.Get(kid)
+		require.NoError(t, e)
+		require.Equal(t, keyURL, kh)
+	}
+
+	require.Equal(t, requestCount, server.RequestCount())
+
+	// public keys exported once are cached.
+	importedKID, _, err := remoteKMS.ImportPrivateKey(newED25519Key(t), kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	pubKey, _, err := remoteKMS.ExportPubKeyBytes(importedKID)
+	require.NoError(t, err)
+
+	requestCount = server.RequestCount()
+
+	cachedPubKey, _, err := remoteKMS.ExportPubKeyBytes(importedKID)
+	require.NoError(t, err)
+	require.Equal(t, pubKey, cachedPubKey)
+	require.Equal(t, requestCount, server.RequestCount())
+
+	// disabled and deleted keys are evicted.
+	require.NoError(t, remoteKMS.Disable(importedKID))
+
+	_, _, err = remoteKMS.ExportPubKeyBytes(importedKID)
+	require.Error(t, err)
+
+	require.NoError(t, remoteKMS.Delete(kid))
+
+	_, _, err = remoteKMS.ExportPubKeyBytes(kid)
+	require.Error(t, err)
+}
+
+func TestRemoteKMS_WithMutualTLS(t *testing.T) {
+	clientCert, caPool := newClientCertificate(t)
+
+	server, err := webkmstest.NewServer(webkmstest.WithMutualTLS(caPool))
+	require.NoError(t, err)
+
+	defer server.Close()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(server.Certificate())
+
+	client := webkms.NewMutualTLSClient(clientCert, rootCAs, clientTimeout)
+
+	remoteKMS := newRemoteKMS(t, server, client)
+
+	_, _, err = remoteKMS.CreateAndExportPubKeyBytes(kmsapi.ED25519Type)
+	require.NoError(t, err)
+	require.NoError(t, remoteKMS.HealthCheck())
+
+	otherCert, _ := newClientCertificate(t)
+
+	_, _, err = webkms.CreateKeyStore(webkms.NewMutualTLSClient(otherCert, rootCAs, clientTimeout), server.URL,
+		"controller", "", nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "posting Create keystore failed")
+}
+
+func TestRemoteKMS_WithHTTPSignature(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	server, err := webkmstest.NewServer(webkmstest.WithHTTPSignatureVerifier(
+		func(keyID, alg string, signature, data []byte) error {
+			if keyID != "client-key" || alg != "ed25519" || !ed25519.Verify(pubKey, data, signature) {
+				return errors.New("invalid signature")
+			}
+
+			return nil
+		}))
+	require.NoError(t, err)
+
+	defer server.Close()
+
+	signer := webkms.WithHTTPSignature("client-key", "ed25519", func(data []byte) ([]byte, error) {
+		return ed25519.Sign(privKey, data), nil
+	})
+
+	remoteKMS := newRemoteKMS(t, server, server.Client(), signer)
+
+	kid, _, err := remoteKMS.Create(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	_, _, err = remoteKMS.ExportPubKeyBytes(kid)
+	require.NoError(t, err)
+
+	t.Run("fail without http signature", func(t *testing.T) {
+		_, _, err = webkms.CreateKeyStore(server.Client(), server.URL, "controller", "", nil)
+		require.EqualError(t, err, "create keystore failed ["+server.URL+"/v1/keystores, missing http signature]")
+	})
+
+	t.Run("fail with http signature of another key", func(t *testing.T) {
+		_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		_, _, err = webkms.CreateKeyStore(server.Client(), server.URL, "controller", "", nil,
+			webkms.WithHTTPSignature("client-key", "ed25519", func(data []byte) ([]byte, error) {
+				return ed25519.Sign(otherKey, data), nil
+			}))
+		require.EqualError(t, err, "create keystore failed ["+server.URL+"/v1/keystores, verify http "+
+			"signature: invalid signature]")
+	})
+
+	t.Run("fail with http signature error", func(t *testing.T) {
+		_, _, err = webkms.CreateKeyStore(server.Client(), server.URL, "controller", "", nil,
+			webkms.WithHTTPSignature("client-key", "ed25519", func([]byte) ([]byte, error) {
+				return nil, errors.New("sign error")
+			}))
+		require.EqualError(t, err, "add http signature error: sign request: sign error")
+	})
+}
+
+func newRemoteKMS(t *testing.T, server *webkmstest.Server, client webkms.HTTPClient,
+	opts ...webkms.Opt) *webkms.RemoteKMS {
+	t.Helper()
+
+	keystoreURL, _, err := webkms.CreateKeyStore(client, server.URL, "controller", "", nil, opts...)
+	require.NoError(t, err)
+
+	return webkms.New(keystoreURL, client, opts...)
+}
+
+func newED25519Key(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+
+	_, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return privKey
+}
+
+// newClientCertificate creates a self-signed client certificate and a pool trusting it.
+func newClientCertificate(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	t.Helper()
+
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "webkms client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &privKey.PublicKey, privKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(certDER)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	return tls.Certificate{Certificate: [][]byte{certDER}, PrivateKey: privKey, Leaf: cert}, pool
+}
+
+// flakyHTTPClient fails the first failures requests with a network error and records the idempotency keys of all
+// requests.
+type flakyHTTPClient struct {
+	client          webkms.HTTPClient
+	failures        int
+	idempotencyKeys []string
+}
+
+func (f *flakyHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPost {
+		f.idempotencyKeys = append(f.idempotencyKeys, req.Header.Get(webkms.IdempotencyKeyHeader))
+	}
+
+	if f.failures > 0 {
+		f.failures--
+
+		return nil, errors.New("connection reset")
+	}
+
+	return f.client.Do(req)
+}
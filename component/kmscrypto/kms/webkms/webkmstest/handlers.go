@@ -0,0 +1,370 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package webkmstest
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/spi/crypto"
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto"
+)
+
+type errMessage struct {
+	Error string `json:"errMessage"`
+}
+
+type createKeystoreResp struct {
+	KeyStoreURL string `json:"key_store_url"`
+}
+
+type createKeyReq struct {
+	KeyType kmsapi.KeyType `json:"key_type"`
+	Attrs   []string       `json:"attrs,omitempty"`
+}
+
+type createKeyResp struct {
+	KeyURL    string `json:"key_url"`
+	PublicKey []byte `json:"public_key,omitempty"`
+}
+
+type importKeyReq struct {
+	Key     []byte         `json:"key"`
+	KeyType kmsapi.KeyType `json:"key_type"`
+	KeyID   string         `json:"key_id,omitempty"`
+}
+
+type exportKeyResp struct {
+	PublicKey []byte `json:"public_key"`
+	KeyType   string `json:"key_type"`
+}
+
+type keyMetadata struct {
+	KeyID   string            `json:"key_id"`
+	KeyType string            `json:"key_type,omitempty"`
+	State   string            `json:"state"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Usage   []string          `json:"usage,omitempty"`
+	Created *time.Time        `json:"created,omitempty"`
+	Updated *time.Time        `json:"updated,omitempty"`
+}
+
+type listKeysResp struct {
+	Keys []*keyMetadata `json:"keys"`
+}
+
+// operationReq is the union of the fields of the key operation requests.
+type operationReq struct {
+	Message          []byte                           `json:"message"`
+	Messages         [][]byte                         `json:"messages"`
+	Signature        []byte                           `json:"signature"`
+	Signatures       [][]byte                         `json:"signatures"`
+	AssociatedData   []byte                           `json:"associated_data"`
+	Ciphertext       []byte                           `json:"ciphertext"`
+	Nonce            []byte                           `json:"nonce"`
+	Data             []byte                           `json:"data"`
+	MAC              []byte                           `json:"mac"`
+	Labels           map[string]string                `json:"labels"`
+	Usage            []string                         `json:"usage"`
+	CEK              []byte                           `json:"cek"`
+	APU              []byte                           `json:"apu"`
+	APV              []byte                           `json:"apv"`
+	RecipientPubKey  *cryptoapi.PublicKey             `json:"recipient_pub_key"`
+	RecipientPubKeys []*cryptoapi.PublicKey           `json:"recipient_pub_keys"`
+	Tag              []byte                           `json:"tag"`
+	WrappedKey       *cryptoapi.RecipientWrappedKey   `json:"wrapped_key"`
+	WrappedKeys      []*cryptoapi.RecipientWrappedKey `json:"wrapped_keys"`
+	SenderPubKey     *cryptoapi.PublicKey             `json:"sender_pub_key"`
+}
+
+type keystoreHandler struct {
+	km          kmsapi.KeyManager
+	crypto      *tinkcrypto.Crypto
+	keystoreURL string
+}
+
+// handleKeys handles the create (POST), import (PUT) and list (GET) keys requests.
+func (h *keystoreHandler) handleKeys(r *http.Request, body []byte) (interface{}, error) {
+	switch r.Method {
+	case http.MethodPost:
+		req := &createKeyReq{}
+
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, err
+		}
+
+		kid, _, err := h.km.Create(req.KeyType, kmsapi.WithAttrs(req.Attrs))
+		if err != nil {
+			return nil, err
+		}
+
+		// symmetric keys have no public key.
+		pubKey, _, _ := h.km.ExportPubKeyBytes(kid) // nolint:errcheck
+
+		return &createKeyResp{KeyURL: h.keystoreURL + "/keys/" + kid, PublicKey: pubKey}, nil
+	case http.MethodPut:
+		req := &importKeyReq{}
+
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, err
+		}
+
+		privKey, err := x509.ParsePKCS8PrivateKey(req.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		var opts []kmsapi.PrivateKeyOpts
+
+		if req.KeyID != "" {
+			opts = append(opts, kmsapi.WithKeyID(req.KeyID))
+		}
+
+		kid, _, err := h.km.ImportPrivateKey(privKey, req.KeyType, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		return &createKeyResp{KeyURL: h.keystoreURL + "/keys/" + kid}, nil
+	case http.MethodGet:
+		return h.listKeys(r)
+	default:
+		return nil, errNotFound
+	}
+}
+
+func (h *keystoreHandler) listKeys(r *http.Request) (interface{}, error) {
+	var opts []kmsapi.ListOpts
+
+	query := r.URL.Query()
+
+	if kt := query.Get("key_type"); kt != "" {
+		opts = append(opts, kmsapi.WithKeyTypeFilter(kmsapi.KeyType(kt)))
+	}
+
+	if state := query.Get("state"); state != "" {
+		opts = append(opts, kmsapi.WithStateFilter(kmsapi.KeyState(state)))
+	}
+
+	for _, label := range query["label"] {
+		name, value, _ := strings.Cut(label, ":")
+
+		opts = append(opts, kmsapi.WithLabelFilter(name, value))
+	}
+
+	keys, err := h.km.List(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &listKeysResp{Keys: make([]*keyMetadata, len(keys))}
+
+	for i, k := range keys {
+		resp.Keys[i] = toKeyMetadata(k)
+	}
+
+	return resp, nil
+}
+
+// handleKeyOperation handles the requests of operation op with the key kid.
+func (h *keystoreHandler) handleKeyOperation(r *http.Request, kid, op string, // nolint:funlen,gocyclo
+	body []byte) (interface{}, error) {
+	switch {
+	case op == "export" && r.Method == http.MethodGet:
+		pubKey, kt, err := h.km.ExportPubKeyBytes(kid)
+		if err != nil {
+			return nil, err
+		}
+
+		return &exportKeyResp{PublicKey: pubKey, KeyType: string(kt)}, nil
+	case op == "metadata" && r.Method == http.MethodGet:
+		md, err := h.km.GetMetadata(kid)
+		if err != nil {
+			return nil, err
+		}
+
+		return toKeyMetadata(md), nil
+	case r.Method != http.MethodPost && !(op == "metadata" && r.Method == http.MethodPut):
+		return nil, errNotFound
+	}
+
+	req := &operationReq{}
+
+	if err := json.Unmarshal(body, req); err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "metadata":
+		return nil, h.km.UpdateMetadata(kid, kmsapi.WithLabels(req.Labels), kmsapi.WithUsage(req.Usage...))
+	case "disable":
+		return nil, h.km.Disable(kid)
+	case "enable":
+		return nil, h.km.Enable(kid)
+	case "destroy":
+		return nil, h.km.Destroy(kid)
+	}
+
+	kh, err := h.km.Get(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "sign":
+		sig, e := h.crypto.Sign(req.Message, kh)
+
+		return map[string][]byte{"signature": sig}, e
+	case "signbatch":
+		return h.signBatch(req.Messages, kh)
+	case "verify", "verifybatch":
+		return nil, h.verify(op, req, kh)
+	case "encrypt":
+		ct, nonce, e := h.crypto.Encrypt(req.Message, req.AssociatedData, kh)
+
+		return map[string][]byte{"ciphertext": ct, "nonce": nonce}, e
+	case "decrypt":
+		pt, e := h.crypto.Decrypt(req.Ciphertext, req.AssociatedData, req.Nonce, kh)
+
+		return map[string][]byte{"plaintext": pt}, e
+	case "computemac":
+		mac, e := h.crypto.ComputeMAC(req.Data, kh)
+
+		return map[string][]byte{"mac": mac}, e
+	case "verifymac":
+		return nil, h.crypto.VerifyMAC(req.MAC, req.Data, kh)
+	case "wrap", "wrapbatch":
+		return h.handleWrap(op, kh, body)
+	case "unwrap", "unwrapbatch":
+		return h.unwrap(op, req, kh)
+	default:
+		return nil, errNotFound
+	}
+}
+
+func (h *keystoreHandler) signBatch(msgs [][]byte, kh interface{}) (interface{}, error) {
+	sigs := make([][]byte, len(msgs))
+
+	for i, msg := range msgs {
+		sig, err := h.crypto.Sign(msg, kh)
+		if err != nil {
+			return nil, fmt.Errorf("sign message %d: %w", i, err)
+		}
+
+		sigs[i] = sig
+	}
+
+	return map[string][][]byte{"signatures": sigs}, nil
+}
+
+func (h *keystoreHandler) verify(op string, req *operationReq, kh interface{}) error {
+	pubKH, err := publicKeyHandle(kh)
+	if err != nil {
+		return err
+	}
+
+	if op == "verify" {
+		return h.crypto.Verify(req.Signature, req.Message, pubKH)
+	}
+
+	if len(req.Signatures) != len(req.Messages) {
+		return fmt.Errorf("%d signatures for %d messages", len(req.Signatures), len(req.Messages))
+	}
+
+	for i := range req.Signatures {
+		err = h.crypto.Verify(req.Signatures[i], req.Messages[i], pubKH)
+		if err != nil {
+			return fmt.Errorf("verify signature %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// handleWrap handles the wrap and wrapbatch requests, with the sender key senderKH for ECDH-1PU key wrapping (if not
+// nil).
+func (h *keystoreHandler) handleWrap(op string, senderKH interface{}, body []byte) (interface{}, error) {
+	req := &operationReq{}
+
+	if err := json.Unmarshal(body, req); err != nil {
+		return nil, err
+	}
+
+	opts := wrapKeyOpts(senderKH, req.Tag)
+
+	switch op {
+	case "wrap":
+		return h.crypto.WrapKey(req.CEK, req.APU, req.APV, req.RecipientPubKey, opts...)
+	case "wrapbatch":
+		wks := make([]*cryptoapi.RecipientWrappedKey, len(req.RecipientPubKeys))
+
+		for i, recPubKey := range req.RecipientPubKeys {
+			wk, err := h.crypto.WrapKey(req.CEK, req.APU, req.APV, recPubKey, opts...)
+			if err != nil {
+				return nil, fmt.Errorf("wrap key for recipient %d: %w", i, err)
+			}
+
+			wks[i] = wk
+		}
+
+		return map[string][]*cryptoapi.RecipientWrappedKey{"wrapped_keys": wks}, nil
+	default:
+		return nil, errNotFound
+	}
+}
+
+func (h *keystoreHandler) unwrap(op string, req *operationReq, kh interface{}) (interface{}, error) {
+	var senderKey interface{}
+
+	if req.SenderPubKey != nil {
+		senderKey = req.SenderPubKey
+	}
+
+	opts := wrapKeyOpts(senderKey, req.Tag)
+
+	if op == "unwrap" {
+		if req.WrappedKey == nil {
+			return nil, errors.New("missing wrapped key")
+		}
+
+		key, err := h.crypto.UnwrapKey(req.WrappedKey, kh, opts...)
+
+		return map[string][]byte{"key": key}, err
+	}
+
+	keys := make([][]byte, len(req.WrappedKeys))
+
+	for i, wk := range req.WrappedKeys {
+		key, err := h.crypto.UnwrapKey(wk, kh, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("unwrap key %d: %w", i, err)
+		}
+
+		keys[i] = key
+	}
+
+	return map[string][][]byte{"keys": keys}, nil
+}
+
+func toKeyMetadata(md *kmsapi.KeyMetadata) *keyMetadata {
+	return &keyMetadata{
+		KeyID:   md.KeyID,
+		KeyType: string(md.KeyType),
+		State:   string(md.State),
+		Labels:  md.Labels,
+		Usage:   md.Usage,
+		Created: md.Created,
+		Updated: md.Updated,
+	}
+}
@@ -0,0 +1,342 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package webkmstest provides a fake key server for testing the webkms RemoteKMS and RemoteCrypto clients.
+package webkmstest
+
+import (
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/google/tink/go/keyset"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/spi/crypto"
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+	"github.com/hyperledger/aries-framework-go/spi/secretlock"
+
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mem"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/webkms"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/secretlock/noop"
+)
+
+const (
+	keystoresPath = "/v1/keystores"
+	masterKeyURI  = "local-lock://webkmstest/"
+	keystoreIDLen = 8
+)
+
+// Server is a fake key server implementing the REST API called by the webkms RemoteKMS and RemoteCrypto clients
+// (including the batch endpoints) with in-memory localkms keystores and tinkcrypto. It replays the response of
+// requests sent again with the same 'Idempotency-Key' header and can authenticate requests with mutual TLS or HTTP
+// signatures. It must be closed after use.
+type Server struct {
+	*httptest.Server
+
+	crypto *tinkcrypto.Crypto
+	verify webkms.VerifyFunc
+
+	mu           sync.Mutex
+	keystores    map[string]kmsapi.KeyManager
+	responses    map[string]*httptest.ResponseRecorder
+	failNext     int
+	requestCount int
+}
+
+type options struct {
+	verify    webkms.VerifyFunc
+	clientCAs *x509.CertPool
+}
+
+// Opt is an option of NewServer.
+type Opt func(opts *options)
+
+// WithHTTPSignatureVerifier option rejects the requests without a valid HTTP signature (as set by the webkms
+// WithHTTPSignature option) verified by verify.
+func WithHTTPSignatureVerifier(verify webkms.VerifyFunc) Opt {
+	return func(opts *options) {
+		opts.verify = verify
+	}
+}
+
+// WithMutualTLS option starts the server with TLS, requiring client certificates issued by clientCAs. Clients must
+// trust the server's certificate (eg: Server.Certificate()).
+func WithMutualTLS(clientCAs *x509.CertPool) Opt {
+	return func(opts *options) {
+		opts.clientCAs = clientCAs
+	}
+}
+
+// NewServer starts a new fake key server. Its keystores are created with webkms.CreateKeyStore at Server.URL.
+func NewServer(opts ...Opt) (*Server, error) {
+	sOpts := &options{}
+
+	for _, opt := range opts {
+		opt(sOpts)
+	}
+
+	c, err := tinkcrypto.New()
+	if err != nil {
+		return nil, fmt.Errorf("webkmstest: create crypto: %w", err)
+	}
+
+	s := &Server{
+		crypto:    c,
+		verify:    sOpts.verify,
+		keystores: map[string]kmsapi.KeyManager{},
+		responses: map[string]*httptest.ResponseRecorder{},
+	}
+
+	s.Server = httptest.NewUnstartedServer(s)
+
+	if sOpts.clientCAs != nil {
+		s.Server.TLS = &tls.Config{
+			ClientAuth: tls.RequireAndVerifyClientCert,
+			ClientCAs:  sOpts.clientCAs,
+			MinVersion: tls.VersionTLS12,
+		}
+
+		s.Server.StartTLS()
+	} else {
+		s.Server.Start()
+	}
+
+	return s, nil
+}
+
+// FailNext makes the server answer the next n requests with a 503 Service Unavailable status after processing them,
+// as if their response was lost.
+func (s *Server) FailNext(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failNext = n
+}
+
+// RequestCount returns the number of requests received by the server.
+func (s *Server) RequestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requestCount
+}
+
+// ServeHTTP handles the key server requests.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requestCount++
+	s.mu.Unlock()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	if s.verify != nil {
+		if _, err = webkms.VerifyHTTPSignature(r, body, s.verify); err != nil {
+			writeError(w, http.StatusUnauthorized, err)
+
+			return
+		}
+	}
+
+	idempotencyKey := r.Header.Get(webkms.IdempotencyKeyHeader)
+
+	s.mu.Lock()
+	rec, replay := s.responses[idempotencyKey]
+	s.mu.Unlock()
+
+	if !replay {
+		rec = httptest.NewRecorder()
+
+		s.route(rec, r, body)
+	}
+
+	s.mu.Lock()
+
+	if idempotencyKey != "" {
+		s.responses[idempotencyKey] = rec
+	}
+
+	fail := s.failNext > 0
+	if fail {
+		s.failNext--
+	}
+
+	s.mu.Unlock()
+
+	if fail {
+		writeError(w, http.StatusServiceUnavailable, errors.New("service unavailable"))
+
+		return
+	}
+
+	for k, v := range rec.Header() {
+		w.Header()[k] = v
+	}
+
+	w.WriteHeader(rec.Code)
+
+	_, _ = w.Write(rec.Body.Bytes()) // nolint:errcheck // the client is gone if the write fails.
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request, body []byte) {
+	switch {
+	case r.URL.Path == "/healthcheck":
+		w.WriteHeader(http.StatusOK)
+
+		return
+	case r.URL.Path == keystoresPath && r.Method == http.MethodPost:
+		s.createKeystore(w)
+
+		return
+	case !strings.HasPrefix(r.URL.Path, keystoresPath+"/"):
+		writeError(w, http.StatusNotFound, fmt.Errorf("invalid path %s", r.URL.Path))
+
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, keystoresPath+"/"), "/")
+
+	s.mu.Lock()
+	km, ok := s.keystores[parts[0]]
+	s.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("keystore %s not found", parts[0]))
+
+		return
+	}
+
+	h := &keystoreHandler{km: km, crypto: s.crypto, keystoreURL: s.URL + keystoresPath + "/" + parts[0]}
+
+	var (
+		resp interface{}
+		err  error
+	)
+
+	switch {
+	case len(parts) == 2 && parts[1] == "keys": // nolint:gomnd
+		resp, err = h.handleKeys(r, body)
+	case len(parts) == 2: // nolint:gomnd
+		resp, err = h.handleWrap(parts[1], nil, body)
+	case len(parts) == 3 && r.Method == http.MethodDelete: // nolint:gomnd
+		err = km.Delete(parts[2])
+	case len(parts) == 4: // nolint:gomnd
+		resp, err = h.handleKeyOperation(r, parts[2], parts[3], body)
+	default:
+		err = errNotFound
+	}
+
+	writeResponse(w, resp, err)
+}
+
+func (s *Server) createKeystore(w http.ResponseWriter) {
+	id := make([]byte, keystoreIDLen)
+
+	_, err := rand.Read(id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	store, err := kms.NewAriesProviderWrapper(mem.NewProvider())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	km, err := localkms.New(masterKeyURI, &kmsProvider{store: store})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	keystoreID := hex.EncodeToString(id)
+
+	s.mu.Lock()
+	s.keystores[keystoreID] = km
+	s.mu.Unlock()
+
+	writeResponse(w, &createKeystoreResp{KeyStoreURL: s.URL + keystoresPath + "/" + keystoreID}, nil)
+}
+
+var errNotFound = errors.New("not found")
+
+func writeResponse(w http.ResponseWriter, resp interface{}, err error) {
+	switch {
+	case errors.Is(err, errNotFound):
+		writeError(w, http.StatusNotFound, err)
+	case err != nil:
+		writeError(w, http.StatusBadRequest, err)
+	case resp == nil:
+		w.WriteHeader(http.StatusOK)
+	default:
+		w.Header().Set("Content-Type", webkms.ContentType)
+
+		_ = json.NewEncoder(w).Encode(resp) // nolint:errcheck // the client is gone if the write fails.
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", webkms.ContentType)
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(&errMessage{Error: err.Error()}) // nolint:errcheck // the client is gone.
+}
+
+type kmsProvider struct {
+	store kmsapi.Store
+}
+
+func (k *kmsProvider) StorageProvider() kmsapi.Store {
+	return k.store
+}
+
+func (k *kmsProvider) SecretLock() secretlock.Service {
+	return &noop.NoLock{}
+}
+
+func publicKeyHandle(kh interface{}) (*keyset.Handle, error) {
+	h, ok := kh.(*keyset.Handle)
+	if !ok {
+		return nil, errors.New("invalid key handle")
+	}
+
+	return h.Public()
+}
+
+func wrapKeyOpts(senderKH interface{}, tag []byte) []cryptoapi.WrapKeyOpts {
+	var opts []cryptoapi.WrapKeyOpts
+
+	if senderKH != nil {
+		opts = append(opts, cryptoapi.WithSender(senderKH))
+	}
+
+	if len(tag) > 0 {
+		opts = append(opts, cryptoapi.WithTag(tag))
+	}
+
+	return opts
+}
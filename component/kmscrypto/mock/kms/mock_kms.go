@@ -43,6 +43,15 @@ type KeyManager struct {
 	ImportPrivateKeyErr      error
 	ImportPrivateKeyID       string
 	ImportPrivateKeyValue    *keyset.Handle
+	DeleteErr                error
+	DisableErr               error
+	EnableErr                error
+	DestroyErr               error
+	GetMetadataValue         *kms.KeyMetadata
+	GetMetadataErr           error
+	UpdateMetadataErr        error
+	ListValue                []*kms.KeyMetadata
+	ListErr                  error
 }
 
 // Create a new mock ey/keyset/key handle for the type kt.
@@ -116,6 +125,49 @@ func (k *KeyManager) ImportPrivateKey(privKey interface{}, keyType kms.KeyType,
 	return k.ImportPrivateKeyID, k.ImportPrivateKeyValue, nil
 }
 
+// Delete will emulate deleting a key.
+func (k *KeyManager) Delete(keyID string) error {
+	return k.DeleteErr
+}
+
+// Disable will emulate disabling a key.
+func (k *KeyManager) Disable(keyID string) error {
+	return k.DisableErr
+}
+
+// Enable will emulate enabling a key.
+func (k *KeyManager) Enable(keyID string) error {
+	return k.EnableErr
+}
+
+// Destroy will emulate destroying a key.
+func (k *KeyManager) Destroy(keyID string) error {
+	return k.DestroyErr
+}
+
+// GetMetadata returns a mocked key metadata.
+func (k *KeyManager) GetMetadata(keyID string) (*kms.KeyMetadata, error) {
+	if k.GetMetadataErr != nil {
+		return nil, k.GetMetadataErr
+	}
+
+	return k.GetMetadataValue, nil
+}
+
+// UpdateMetadata will emulate updating the metadata of a key.
+func (k *KeyManager) UpdateMetadata(keyID string, opts ...kms.MetadataOpts) error {
+	return k.UpdateMetadataErr
+}
+
+// List returns a mocked list of key metadata.
+func (k *KeyManager) List(opts ...kms.ListOpts) ([]*kms.KeyMetadata, error) {
+	if k.ListErr != nil {
+		return nil, k.ListErr
+	}
+
+	return k.ListValue, nil
+}
+
 func createMockKeyHandle(ks *tinkpb.Keyset) (*keyset.Handle, error) {
 	primaryKey := ks.Key[0]
 
@@ -0,0 +1,211 @@
+//go:build pkcs11
+// +build pkcs11
+
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package pkcs11 opens the PKCS#11 tokens used by the PKCS#11 secret lock (secretlock/pkcs11lock), KMS
+// (kms/pkcs11kms) and Crypto (crypto/pkcs11crypto) implementations.
+//
+// These packages use cgo to load the PKCS#11 module of the token and are only built with the 'pkcs11' build tag.
+// Their tests run against a SoftHSM2 token when the PKCS11_MODULE environment variable is set to the path of the
+// SoftHSM2 library, e.g.:
+//
+//	softhsm2-util --init-token --free --label aries --pin 1234 --so-pin 5678
+//	PKCS11_MODULE=/usr/lib/softhsm/libsofthsm2.so go test -tags pkcs11 ./...
+//
+// PKCS11_TOKEN_LABEL and PKCS11_PIN override the default 'aries' token label and '1234' user pin.
+package pkcs11
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	p11 "github.com/miekg/pkcs11"
+)
+
+// PKCS#11 v3.0 EdDSA values, not defined by github.com/miekg/pkcs11.
+const (
+	// KeyTypeECEdwards is the CKK_EC_EDWARDS key type.
+	KeyTypeECEdwards = 0x00000040
+	// MechanismECEdwardsKeyPairGen is the CKM_EC_EDWARDS_KEY_PAIR_GEN mechanism.
+	MechanismECEdwardsKeyPairGen = 0x00001055
+	// MechanismEdDSA is the CKM_EDDSA mechanism.
+	MechanismEdDSA = 0x00001057
+)
+
+// ErrNotFound is returned when no token or object matches a search.
+var ErrNotFound = errors.New("not found")
+
+// Token is a session logged into a PKCS#11 token. Operations on the session are serialized, so a Token is safe for
+// concurrent use.
+type Token struct {
+	ctx     *p11.Ctx
+	session p11.SessionHandle
+	mu      sync.Mutex
+}
+
+// Session is the token session given to the functions run by Token.Do.
+type Session struct {
+	Ctx    *p11.Ctx
+	Handle p11.SessionHandle
+}
+
+// Open loads the PKCS#11 module found at modulePath and logs into the token labeled tokenLabel with the user pin.
+func Open(modulePath, tokenLabel, pin string) (*Token, error) {
+	ctx := p11.New(modulePath)
+	if ctx == nil {
+		return nil, fmt.Errorf("open: failed to load PKCS#11 module '%s'", modulePath)
+	}
+
+	err := ctx.Initialize()
+	if err != nil && !errors.Is(err, p11.Error(p11.CKR_CRYPTOKI_ALREADY_INITIALIZED)) {
+		ctx.Destroy()
+
+		return nil, fmt.Errorf("open: failed to initialize PKCS#11 module: %w", err)
+	}
+
+	token, err := openSession(ctx, tokenLabel, pin)
+	if err != nil {
+		finalize(ctx)
+
+		return nil, fmt.Errorf("open: %w", err)
+	}
+
+	return token, nil
+}
+
+func openSession(ctx *p11.Ctx, tokenLabel, pin string) (*Token, error) {
+	slot, err := findSlot(ctx, tokenLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := ctx.OpenSession(slot, p11.CKF_SERIAL_SESSION|p11.CKF_RW_SESSION)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open session on token '%s': %w", tokenLabel, err)
+	}
+
+	err = ctx.Login(session, p11.CKU_USER, pin)
+	if err != nil && !errors.Is(err, p11.Error(p11.CKR_USER_ALREADY_LOGGED_IN)) {
+		_ = ctx.CloseSession(session) // nolint:errcheck
+
+		return nil, fmt.Errorf("failed to log into token '%s': %w", tokenLabel, err)
+	}
+
+	return &Token{ctx: ctx, session: session}, nil
+}
+
+func findSlot(ctx *p11.Ctx, tokenLabel string) (uint, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list slots: %w", err)
+	}
+
+	for _, slot := range slots {
+		info, err := ctx.GetTokenInfo(slot)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get token info of slot %d: %w", slot, err)
+		}
+
+		if info.Label == tokenLabel {
+			return slot, nil
+		}
+	}
+
+	return 0, fmt.Errorf("token '%s': %w", tokenLabel, ErrNotFound)
+}
+
+// Close logs out of the token and unloads the PKCS#11 module. Other tokens opened with the same module must be
+// closed beforehand.
+func (t *Token) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	err := t.ctx.Logout(t.session)
+	if err != nil && !errors.Is(err, p11.Error(p11.CKR_USER_NOT_LOGGED_IN)) {
+		return fmt.Errorf("close: failed to log out: %w", err)
+	}
+
+	err = t.ctx.CloseSession(t.session)
+	if err != nil {
+		return fmt.Errorf("close: failed to close session: %w", err)
+	}
+
+	finalize(t.ctx)
+
+	return nil
+}
+
+func finalize(ctx *p11.Ctx) {
+	_ = ctx.Finalize() // nolint:errcheck
+	ctx.Destroy()
+}
+
+// Do runs fn with the token session, fn must not call Do.
+func (t *Token) Do(fn func(s *Session) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return fn(&Session{Ctx: t.ctx, Handle: t.session})
+}
+
+// FindObjects returns the objects matching template.
+func (s *Session) FindObjects(template []*p11.Attribute) ([]p11.ObjectHandle, error) {
+	err := s.Ctx.FindObjectsInit(s.Handle, template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize objects search: %w", err)
+	}
+
+	var result []p11.ObjectHandle
+
+	for {
+		objects, _, err := s.Ctx.FindObjects(s.Handle, 100) // nolint:gomnd
+		if err != nil {
+			_ = s.Ctx.FindObjectsFinal(s.Handle) // nolint:errcheck
+
+			return nil, fmt.Errorf("failed to search objects: %w", err)
+		}
+
+		if len(objects) == 0 {
+			break
+		}
+
+		result = append(result, objects...)
+	}
+
+	err = s.Ctx.FindObjectsFinal(s.Handle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to finalize objects search: %w", err)
+	}
+
+	return result, nil
+}
+
+// FindObject returns the first object matching template, or ErrNotFound.
+func (s *Session) FindObject(template []*p11.Attribute) (p11.ObjectHandle, error) {
+	objects, err := s.FindObjects(template)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(objects) == 0 {
+		return 0, ErrNotFound
+	}
+
+	return objects[0], nil
+}
+
+// Attribute returns the value of the attribute typ of object.
+func (s *Session) Attribute(object p11.ObjectHandle, typ uint) ([]byte, error) {
+	attrs, err := s.Ctx.GetAttributeValue(s.Handle, object, []*p11.Attribute{p11.NewAttribute(typ, nil)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attribute 0x%x: %w", typ, err)
+	}
+
+	return attrs[0].Value, nil
+}
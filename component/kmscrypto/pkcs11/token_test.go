@@ -0,0 +1,76 @@
+//go:build pkcs11
+// +build pkcs11
+
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkcs11_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	p11 "github.com/miekg/pkcs11"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/internal/pkcs11test"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/pkcs11"
+)
+
+func TestOpen(t *testing.T) {
+	t.Run("fail to load module", func(t *testing.T) {
+		_, err := pkcs11.Open("/bad/module/path.so", "aries", "1234")
+		require.EqualError(t, err, "open: failed to load PKCS#11 module '/bad/module/path.so'")
+	})
+
+	t.Run("token not found", func(t *testing.T) {
+		modulePath := os.Getenv("PKCS11_MODULE")
+		if modulePath == "" {
+			t.Skip("PKCS11_MODULE is not set, skipping PKCS#11 test")
+		}
+
+		_, err := pkcs11.Open(modulePath, "unknown token label", "1234")
+		require.Error(t, err)
+		require.True(t, errors.Is(err, pkcs11.ErrNotFound))
+	})
+}
+
+func TestSession(t *testing.T) {
+	token := pkcs11test.OpenToken(t)
+
+	err := token.Do(func(s *pkcs11.Session) error {
+		_, err := s.FindObject([]*p11.Attribute{
+			p11.NewAttribute(p11.CKA_LABEL, "object not in the token"),
+		})
+		require.True(t, errors.Is(err, pkcs11.ErrNotFound))
+
+		data, err := s.Ctx.CreateObject(s.Handle, []*p11.Attribute{
+			p11.NewAttribute(p11.CKA_CLASS, p11.CKO_DATA),
+			p11.NewAttribute(p11.CKA_TOKEN, false),
+			p11.NewAttribute(p11.CKA_LABEL, "session test data"),
+			p11.NewAttribute(p11.CKA_VALUE, []byte("data")),
+		})
+		require.NoError(t, err)
+
+		defer func() {
+			require.NoError(t, s.Ctx.DestroyObject(s.Handle, data))
+		}()
+
+		found, err := s.FindObject([]*p11.Attribute{
+			p11.NewAttribute(p11.CKA_LABEL, "session test data"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, data, found)
+
+		value, err := s.Attribute(found, p11.CKA_VALUE)
+		require.NoError(t, err)
+		require.Equal(t, []byte("data"), value)
+
+		return nil
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,185 @@
+//go:build pkcs11
+// +build pkcs11
+
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package pkcs11lock provides a secret lock service wrapping keys with an AES-256 master key held by a PKCS#11 token.
+// The master key never leaves the token: keys are encrypted and decrypted by the token using AES-GCM.
+//
+// The master key is the secret key labeled as the keyURI of the requests, ie. the primary key URI given to
+// localkms.New without its 'prefix://' scheme. It can be generated with CreateMasterKey:
+//
+//	token, err := pkcs11.Open(modulePath, tokenLabel, pin)
+//	err = pkcs11lock.CreateMasterKey(token, "aries/master/key")
+//	kms, err := localkms.New("pkcs11://aries/master/key", provider) // provider.SecretLock() = pkcs11lock.New(token, "")
+package pkcs11lock
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/google/tink/go/subtle/random"
+	p11 "github.com/miekg/pkcs11"
+
+	"github.com/hyperledger/aries-framework-go/spi/secretlock"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/pkcs11"
+)
+
+const (
+	masterKeyLen = 32
+	nonceLen     = 12
+	tagBits      = 128
+)
+
+// Lock is a secret lock service responsible for encrypting keys using a master key held by a PKCS#11 token.
+type Lock struct {
+	token           *pkcs11.Token
+	defaultKeyLabel string
+}
+
+// New creates a new instance of PKCS#11 secret lock service using the master keys of token. Requests with an empty
+// keyURI, such as the ones sent by local.NewService to decrypt a protected local master key, use the master key
+// labeled defaultKeyLabel.
+func New(token *pkcs11.Token, defaultKeyLabel string) *Lock {
+	return &Lock{token: token, defaultKeyLabel: defaultKeyLabel}
+}
+
+// CreateMasterKey generates a non extractable AES-256 master key labeled keyLabel in token.
+func CreateMasterKey(token *pkcs11.Token, keyLabel string) error {
+	return token.Do(func(s *pkcs11.Session) error {
+		_, err := findMasterKey(s, keyLabel)
+		if err == nil {
+			return fmt.Errorf("createMasterKey: master key '%s' already exists", keyLabel)
+		}
+
+		if !errors.Is(err, pkcs11.ErrNotFound) {
+			return fmt.Errorf("createMasterKey: %w", err)
+		}
+
+		_, err = s.Ctx.GenerateKey(s.Handle, []*p11.Mechanism{p11.NewMechanism(p11.CKM_AES_KEY_GEN, nil)},
+			[]*p11.Attribute{
+				p11.NewAttribute(p11.CKA_CLASS, p11.CKO_SECRET_KEY),
+				p11.NewAttribute(p11.CKA_KEY_TYPE, p11.CKK_AES),
+				p11.NewAttribute(p11.CKA_VALUE_LEN, masterKeyLen),
+				p11.NewAttribute(p11.CKA_LABEL, keyLabel),
+				p11.NewAttribute(p11.CKA_TOKEN, true),
+				p11.NewAttribute(p11.CKA_PRIVATE, true),
+				p11.NewAttribute(p11.CKA_SENSITIVE, true),
+				p11.NewAttribute(p11.CKA_EXTRACTABLE, false),
+				p11.NewAttribute(p11.CKA_ENCRYPT, true),
+				p11.NewAttribute(p11.CKA_DECRYPT, true),
+			})
+		if err != nil {
+			return fmt.Errorf("createMasterKey: failed to generate master key '%s': %w", keyLabel, err)
+		}
+
+		return nil
+	})
+}
+
+// Encrypt a key in req using the master key labeled keyURI in the token.
+func (s *Lock) Encrypt(keyURI string, req *secretlock.EncryptRequest) (*secretlock.EncryptResponse, error) {
+	nonce := random.GetRandomBytes(nonceLen)
+
+	var ct []byte
+
+	err := s.token.Do(func(session *pkcs11.Session) error {
+		key, err := findMasterKey(session, s.keyLabel(keyURI))
+		if err != nil {
+			return err
+		}
+
+		params := p11.NewGCMParams(nonce, []byte(req.AdditionalAuthenticatedData), tagBits)
+		defer params.Free()
+
+		err = session.Ctx.EncryptInit(session.Handle,
+			[]*p11.Mechanism{p11.NewMechanism(p11.CKM_AES_GCM, params)}, key)
+		if err != nil {
+			return fmt.Errorf("failed to initialize encryption: %w", err)
+		}
+
+		ct, err = session.Ctx.Encrypt(session.Handle, []byte(req.Plaintext))
+		if err != nil {
+			return fmt.Errorf("failed to encrypt: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encrypt: %w", err)
+	}
+
+	return &secretlock.EncryptResponse{
+		Ciphertext: base64.URLEncoding.EncodeToString(append(nonce, ct...)),
+	}, nil
+}
+
+// Decrypt a key in req using the master key labeled keyURI in the token.
+func (s *Lock) Decrypt(keyURI string, req *secretlock.DecryptRequest) (*secretlock.DecryptResponse, error) {
+	ct, err := base64.URLEncoding.DecodeString(req.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt: %w", err)
+	}
+
+	// ensure ciphertext contains more than nonce+ciphertext (result from Encrypt())
+	if len(ct) <= nonceLen {
+		return nil, errors.New("decrypt: invalid request")
+	}
+
+	var pt []byte
+
+	err = s.token.Do(func(session *pkcs11.Session) error {
+		key, e := findMasterKey(session, s.keyLabel(keyURI))
+		if e != nil {
+			return e
+		}
+
+		params := p11.NewGCMParams(ct[:nonceLen], []byte(req.AdditionalAuthenticatedData), tagBits)
+		defer params.Free()
+
+		e = session.Ctx.DecryptInit(session.Handle,
+			[]*p11.Mechanism{p11.NewMechanism(p11.CKM_AES_GCM, params)}, key)
+		if e != nil {
+			return fmt.Errorf("failed to initialize decryption: %w", e)
+		}
+
+		pt, e = session.Ctx.Decrypt(session.Handle, ct[nonceLen:])
+		if e != nil {
+			return fmt.Errorf("failed to decrypt: %w", e)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("decrypt: %w", err)
+	}
+
+	return &secretlock.DecryptResponse{Plaintext: string(pt)}, nil
+}
+
+func (s *Lock) keyLabel(keyURI string) string {
+	if keyURI == "" {
+		return s.defaultKeyLabel
+	}
+
+	return keyURI
+}
+
+func findMasterKey(s *pkcs11.Session, keyLabel string) (p11.ObjectHandle, error) {
+	key, err := s.FindObject([]*p11.Attribute{
+		p11.NewAttribute(p11.CKA_CLASS, p11.CKO_SECRET_KEY),
+		p11.NewAttribute(p11.CKA_KEY_TYPE, p11.CKK_AES),
+		p11.NewAttribute(p11.CKA_LABEL, keyLabel),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("master key '%s': %w", keyLabel, err)
+	}
+
+	return key, nil
+}
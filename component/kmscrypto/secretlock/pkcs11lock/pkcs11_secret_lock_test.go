@@ -0,0 +1,97 @@
+//go:build pkcs11
+// +build pkcs11
+
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pkcs11lock
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/google/tink/go/subtle/random"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/spi/secretlock"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/internal/pkcs11test"
+)
+
+func newKeyLabel() string {
+	return "pkcs11lock/test/" + base64.RawURLEncoding.EncodeToString(random.GetRandomBytes(8))
+}
+
+func TestCreateMasterKey(t *testing.T) {
+	token := pkcs11test.OpenToken(t)
+	keyLabel := newKeyLabel()
+
+	require.NoError(t, CreateMasterKey(token, keyLabel))
+
+	err := CreateMasterKey(token, keyLabel)
+	require.EqualError(t, err, "createMasterKey: master key '"+keyLabel+"' already exists")
+}
+
+func TestLock(t *testing.T) {
+	token := pkcs11test.OpenToken(t)
+	keyLabel := newKeyLabel()
+	otherKeyLabel := newKeyLabel()
+
+	require.NoError(t, CreateMasterKey(token, keyLabel))
+	require.NoError(t, CreateMasterKey(token, otherKeyLabel))
+
+	lock := New(token, keyLabel)
+
+	encReq := &secretlock.EncryptRequest{
+		Plaintext:                   "secret key",
+		AdditionalAuthenticatedData: "aad",
+	}
+
+	t.Run("encrypt and decrypt", func(t *testing.T) {
+		for _, keyURI := range []string{keyLabel, otherKeyLabel, ""} {
+			encResp, err := lock.Encrypt(keyURI, encReq)
+			require.NoError(t, err)
+			require.NotEqual(t, encReq.Plaintext, encResp.Ciphertext)
+
+			decResp, err := lock.Decrypt(keyURI, &secretlock.DecryptRequest{
+				Ciphertext:                  encResp.Ciphertext,
+				AdditionalAuthenticatedData: encReq.AdditionalAuthenticatedData,
+			})
+			require.NoError(t, err)
+			require.Equal(t, encReq.Plaintext, decResp.Plaintext)
+		}
+	})
+
+	t.Run("decrypt failures", func(t *testing.T) {
+		encResp, err := lock.Encrypt(keyLabel, encReq)
+		require.NoError(t, err)
+
+		_, err = lock.Decrypt(otherKeyLabel, &secretlock.DecryptRequest{
+			Ciphertext:                  encResp.Ciphertext,
+			AdditionalAuthenticatedData: encReq.AdditionalAuthenticatedData,
+		})
+		require.Error(t, err)
+
+		_, err = lock.Decrypt(keyLabel, &secretlock.DecryptRequest{
+			Ciphertext:                  encResp.Ciphertext,
+			AdditionalAuthenticatedData: "bad aad",
+		})
+		require.Error(t, err)
+
+		_, err = lock.Decrypt(keyLabel, &secretlock.DecryptRequest{Ciphertext: "!bad base64"})
+		require.Error(t, err)
+
+		_, err = lock.Decrypt(keyLabel, &secretlock.DecryptRequest{
+			Ciphertext: base64.URLEncoding.EncodeToString([]byte("short")),
+		})
+		require.EqualError(t, err, "decrypt: invalid request")
+	})
+
+	t.Run("unknown master key", func(t *testing.T) {
+		_, err := lock.Encrypt("unknown/master/key", encReq)
+		require.EqualError(t, err, "encrypt: master key 'unknown/master/key': not found")
+	})
+}
@@ -4,7 +4,7 @@
 
 module github.com/hyperledger/aries-framework-go/component/log
 
-go 1.20
+go 1.22.0
 
 require (
 	github.com/hyperledger/aries-framework-go/spi v0.0.0-20221025204933-b807371b6f1e
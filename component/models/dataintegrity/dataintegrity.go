@@ -7,8 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package dataintegrity
 
 import (
+	"encoding/json"
 	"errors"
 
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+
 	"github.com/hyperledger/aries-framework-go/component/models/did"
 	spivdr "github.com/hyperledger/aries-framework-go/spi/vdr"
 )
@@ -24,6 +28,10 @@ var (
 	// ErrVMResolution is returned when a Signer or Verifier needs to resolve a
 	// verification method but this fails.
 	ErrVMResolution = errors.New("failed to resolve verification method")
+	// ErrPreviousProofNotFound is returned when a Signer is asked to chain a new
+	// proof to a previous proof, or a Verifier is given a proof chained to a
+	// previous proof, and the previous proof is not in the document's proof set.
+	ErrPreviousProofNotFound = errors.New("data integrity previous proof not found")
 )
 
 type didResolver interface {
@@ -34,3 +42,48 @@ type didResolver interface {
 type Options struct {
 	DIDResolver didResolver
 }
+
+// proofSet returns the raw JSON of each proof in the document's proof set, in
+// document order. A document with a single proof object has a proof set of one.
+func proofSet(doc []byte) ([]json.RawMessage, bool) {
+	proofRaw := gjson.GetBytes(doc, proofPath)
+
+	switch {
+	case !proofRaw.Exists():
+		return nil, false
+	case proofRaw.IsArray():
+		var proofs []json.RawMessage
+
+		proofRaw.ForEach(func(_, value gjson.Result) bool {
+			proofs = append(proofs, json.RawMessage(value.Raw))
+
+			return true
+		})
+
+		return proofs, true
+	default:
+		return []json.RawMessage{json.RawMessage(proofRaw.Raw)}, true
+	}
+}
+
+// unsecuredDocument returns the document without its proof set. If
+// previousProof is set, the proof with that id is kept as the document's only
+// proof, so that a chained proof secures the proof it is chained to.
+func unsecuredDocument(doc []byte, proofs []json.RawMessage, previousProof string) ([]byte, error) {
+	out, err := sjson.DeleteBytes(doc, proofPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if previousProof == "" {
+		return out, nil
+	}
+
+	for _, proof := range proofs {
+		if gjson.GetBytes(proof, "id").String() == previousProof {
+			return sjson.SetRawBytes(out, proofPath, proof)
+		}
+	}
+
+	return nil, ErrPreviousProofNotFound
+}
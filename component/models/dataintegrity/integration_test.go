@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto"
@@ -26,6 +27,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/models/ld/documentloader"
 	mockldstore "github.com/hyperledger/aries-framework-go/component/models/ld/mock"
 	"github.com/hyperledger/aries-framework-go/component/models/ld/store"
+	"github.com/hyperledger/aries-framework-go/component/models/ld/testutil"
 	mockstorage "github.com/hyperledger/aries-framework-go/component/storageutil/mock/storage"
 	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
 )
@@ -160,6 +162,147 @@ func TestIntegration(t *testing.T) {
 		})
 	})
 
+	t.Run("proof set", func(t *testing.T) {
+		// proofs in a set are verified against their own verification method, so it must be absolute
+		issuerVM, err := did.NewVerificationMethodFromJWK(mockKID, "JsonWebKey2020", mockDID, p256JWK)
+		require.NoError(t, err)
+
+		endorserVM, err := did.NewVerificationMethodFromJWK(mockKID2, "JsonWebKey2020", mockDID2, p384JWK)
+		require.NoError(t, err)
+
+		signedCred, err := signer.AddProof(validCredential, &models.ProofOptions{
+			VerificationMethod:       issuerVM,
+			VerificationMethodID:     issuerVM.ID,
+			SuiteType:                ecdsa2019.SuiteType,
+			Purpose:                  "assertionMethod",
+			VerificationRelationship: "assertionMethod",
+			ProofType:                models.DataIntegrityProof,
+			Created:                  time.Now(),
+			ProofID:                  "urn:uuid:issuer-proof",
+		})
+		require.NoError(t, err)
+
+		t.Run("two independent proofs", func(t *testing.T) {
+			endorsedCred, err := signer.AddProof(signedCred, &models.ProofOptions{
+				VerificationMethod:       endorserVM,
+				VerificationMethodID:     endorserVM.ID,
+				SuiteType:                ecdsa2019.SuiteType,
+				Purpose:                  "assertionMethod",
+				VerificationRelationship: "assertionMethod",
+				ProofType:                models.DataIntegrityProof,
+				Created:                  time.Now(),
+			})
+			require.NoError(t, err)
+			require.Len(t, gjson.GetBytes(endorsedCred, "proof").Array(), 2)
+
+			verifyOpts := &models.ProofOptions{
+				Purpose:   "assertionMethod",
+				ProofType: models.DataIntegrityProof,
+			}
+
+			require.NoError(t, verifier.VerifyProof(endorsedCred, verifyOpts))
+
+			results, err := verifier.VerifyProofSet(endorsedCred, verifyOpts)
+			require.NoError(t, err)
+			require.Equal(t, []error{nil, nil}, results)
+
+			tampered, err := sjson.SetBytes(endorsedCred, "proof.1.proofValue", "z123")
+			require.NoError(t, err)
+
+			require.Error(t, verifier.VerifyProof(tampered, verifyOpts))
+
+			results, err = verifier.VerifyProofSet(tampered, verifyOpts)
+			require.NoError(t, err)
+			require.Len(t, results, 2)
+			require.NoError(t, results[0])
+			require.Error(t, results[1])
+		})
+
+		t.Run("proof chain", func(t *testing.T) {
+			// the data integrity context defines the proof terms, so proofs are secured by proofs chained to them
+			chainLoader, err := testutil.DocumentLoader()
+			require.NoError(t, err)
+
+			chainSigner, err := NewSigner(&Options{DIDResolver: resolver},
+				ecdsa2019.NewSignerInitializer(&ecdsa2019.SignerInitializerOptions{
+					LDDocumentLoader: chainLoader,
+					SignerGetter:     ecdsa2019.WithLocalKMSSigner(kms, cr),
+				}))
+			require.NoError(t, err)
+
+			chainVerifier, err := NewVerifier(&Options{DIDResolver: resolver},
+				ecdsa2019.NewVerifierInitializer(&ecdsa2019.VerifierInitializerOptions{
+					LDDocumentLoader: chainLoader,
+				}))
+			require.NoError(t, err)
+
+			cred, err := sjson.SetBytes(validCredential, "@context.-1", "https://w3id.org/security/data-integrity/v1")
+			require.NoError(t, err)
+
+			issuedCred, err := chainSigner.AddProof(cred, &models.ProofOptions{
+				VerificationMethod:       issuerVM,
+				VerificationMethodID:     issuerVM.ID,
+				SuiteType:                ecdsa2019.SuiteType,
+				Purpose:                  "assertionMethod",
+				VerificationRelationship: "assertionMethod",
+				ProofType:                models.DataIntegrityProof,
+				Created:                  time.Now(),
+				ProofID:                  "urn:uuid:issuer-proof",
+			})
+			require.NoError(t, err)
+
+			endorsedCred, err := chainSigner.AddProof(issuedCred, &models.ProofOptions{
+				VerificationMethod:       endorserVM,
+				VerificationMethodID:     endorserVM.ID,
+				SuiteType:                ecdsa2019.SuiteType,
+				Purpose:                  "assertionMethod",
+				VerificationRelationship: "assertionMethod",
+				ProofType:                models.DataIntegrityProof,
+				Created:                  time.Now(),
+				PreviousProof:            "urn:uuid:issuer-proof",
+			})
+			require.NoError(t, err)
+			require.Equal(t, "urn:uuid:issuer-proof", gjson.GetBytes(endorsedCred, "proof.1.previousProof").String())
+
+			verifyOpts := &models.ProofOptions{
+				Purpose:   "assertionMethod",
+				ProofType: models.DataIntegrityProof,
+			}
+
+			require.NoError(t, chainVerifier.VerifyProof(endorsedCred, verifyOpts))
+
+			// the chained proof secures the previous proof, so it breaks if the previous proof changes
+			tampered, err := sjson.SetBytes(endorsedCred, "proof.0.created", "2000-01-01T00:00:00Z")
+			require.NoError(t, err)
+
+			results, err := chainVerifier.VerifyProofSet(tampered, verifyOpts)
+			require.NoError(t, err)
+			require.Len(t, results, 2)
+			require.Error(t, results[0])
+			require.Error(t, results[1])
+
+			withoutPrevious, err := sjson.DeleteBytes(endorsedCred, "proof.0")
+			require.NoError(t, err)
+
+			err = chainVerifier.VerifyProof(withoutPrevious, verifyOpts)
+			require.ErrorIs(t, err, ErrPreviousProofNotFound)
+		})
+
+		t.Run("previous proof not found", func(t *testing.T) {
+			_, err := signer.AddProof(signedCred, &models.ProofOptions{
+				VerificationMethod:       endorserVM,
+				VerificationMethodID:     endorserVM.ID,
+				SuiteType:                ecdsa2019.SuiteType,
+				Purpose:                  "assertionMethod",
+				VerificationRelationship: "assertionMethod",
+				ProofType:                models.DataIntegrityProof,
+				Created:                  time.Now(),
+				PreviousProof:            "urn:uuid:unknown",
+			})
+			require.ErrorIs(t, err, ErrPreviousProofNotFound)
+		})
+	})
+
 	t.Run("failure", func(t *testing.T) {
 		t.Run("wrong key", func(t *testing.T) {
 			signOpts := &models.ProofOptions{
@@ -59,6 +59,11 @@ type ProofOptions struct {
 	Created                  time.Time
 	MaxAge                   int64
 	CustomFields             map[string]interface{}
+	// ProofID is the id given to a created proof, so later proofs can be chained to it.
+	ProofID string
+	// PreviousProof is the id of an existing proof in the document's proof set
+	// that a created proof is chained to.
+	PreviousProof string
 }
 
 // DateTimeFormat is the date-time format used by the data integrity
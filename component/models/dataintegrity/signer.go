@@ -64,6 +64,10 @@ var (
 // AddProof returns the provided JSON doc, with a top-level "proof" field added,
 // signed using the provided options.
 //
+// If the doc already has proofs, the new proof is added to the proof set. The
+// existing proofs are not secured by the new proof, unless the options name
+// one of them as PreviousProof, creating a proof chain.
+//
 // If the provided options request a cryptographic suite that this Signer does
 // not support, AddProof returns ErrUnsupportedSuite.
 //
@@ -80,7 +84,14 @@ func (s *Signer) AddProof(doc []byte, opts *models.ProofOptions) ([]byte, error)
 		return nil, err
 	}
 
-	proof, err := signerSuite.CreateProof(doc, opts)
+	proofs, _ := proofSet(doc)
+
+	unsecuredDoc, err := unsecuredDocument(doc, proofs, opts.PreviousProof)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := signerSuite.CreateProof(unsecuredDoc, opts)
 	if err != nil {
 		return nil, ErrProofGeneration
 	}
@@ -101,12 +112,29 @@ func (s *Signer) AddProof(doc []byte, opts *models.ProofOptions) ([]byte, error)
 		return nil, ErrProofGeneration
 	}
 
+	if opts.ProofID != "" {
+		proof.ID = opts.ProofID
+	}
+
+	proof.PreviousProof = opts.PreviousProof
+
 	proofRaw, err := json.Marshal(proof)
 	if err != nil {
 		return nil, ErrProofGeneration
 	}
 
-	out, err := sjson.SetRawBytes(doc, proofPath, proofRaw)
+	var proofSetRaw []byte
+
+	if len(proofs) == 0 {
+		proofSetRaw = proofRaw
+	} else {
+		proofSetRaw, err = json.Marshal(append(proofs, proofRaw))
+		if err != nil {
+			return nil, ErrProofGeneration
+		}
+	}
+
+	out, err := sjson.SetRawBytes(doc, proofPath, proofSetRaw)
 	if err != nil {
 		return nil, ErrProofGeneration
 	}
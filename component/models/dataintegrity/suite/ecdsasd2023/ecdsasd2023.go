@@ -0,0 +1,619 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdsasd2023
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/models"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite/ecdsa2019"
+	"github.com/hyperledger/aries-framework-go/component/models/ld/processor"
+	signatureverifier "github.com/hyperledger/aries-framework-go/component/models/signature/verifier"
+)
+
+const (
+	// SuiteType "ecdsa-sd-2023" is the data integrity Type identifier for the
+	// suite implementing selective disclosure ecdsa signatures as per this
+	// spec: https://www.w3.org/TR/vc-di-ecdsa/#ecdsa-sd-2023
+	SuiteType = "ecdsa-sd-2023"
+
+	// MandatoryPointersField is the models.ProofOptions.CustomFields key for a
+	// []string of JSON pointers to the parts of the document that the holder
+	// must always disclose. It overrides the suite's default mandatory pointers.
+	MandatoryPointersField = "mandatoryPointers"
+)
+
+// SignerGetter returns a Signer, which must sign with the private key matching
+// the public key provided in models.ProofOptions.VerificationMethod.
+type SignerGetter = ecdsa2019.SignerGetter
+
+// A Signer is able to sign messages.
+type Signer = ecdsa2019.Signer
+
+// A KMSSigner is able to sign messages.
+type KMSSigner = ecdsa2019.KMSSigner
+
+// A Verifier is able to verify messages.
+type Verifier = ecdsa2019.Verifier
+
+// WithStaticSigner sets the Suite to use a fixed Signer, with externally-chosen signing key.
+//
+// Use when a signing Suite is initialized for a single signature, then thrown away.
+func WithStaticSigner(signer Signer) SignerGetter {
+	return ecdsa2019.WithStaticSigner(signer)
+}
+
+// WithLocalKMSSigner returns a SignerGetter that will sign using the given localkms, using the private key matching
+// the given public key.
+func WithLocalKMSSigner(kms models.KeyManager, kmsSigner KMSSigner) SignerGetter {
+	return ecdsa2019.WithLocalKMSSigner(kms, kmsSigner)
+}
+
+// Suite implements the ecdsa-sd-2023 data integrity cryptographic suite.
+//
+// Signing creates a base proof, which the holder turns into a derived proof
+// using DeriveProof. Verification only accepts derived proofs.
+type Suite struct {
+	ldLoader          ld.DocumentLoader
+	p256Verifier      Verifier
+	signerGetter      SignerGetter
+	mandatoryPointers []string
+}
+
+// Options provides initialization options for Suite.
+type Options struct {
+	LDDocumentLoader  ld.DocumentLoader
+	P256Verifier      Verifier
+	SignerGetter      SignerGetter
+	MandatoryPointers []string
+}
+
+// SuiteInitializer is the initializer for Suite.
+type SuiteInitializer func() (suite.Suite, error)
+
+// New constructs an initializer for Suite.
+func New(options *Options) SuiteInitializer {
+	return func() (suite.Suite, error) {
+		return &Suite{
+			ldLoader:          options.LDDocumentLoader,
+			p256Verifier:      options.P256Verifier,
+			signerGetter:      options.SignerGetter,
+			mandatoryPointers: options.MandatoryPointers,
+		}, nil
+	}
+}
+
+type initializer SuiteInitializer
+
+// Signer private, implements suite.SignerInitializer.
+func (i initializer) Signer() (suite.Signer, error) {
+	return i()
+}
+
+// Verifier private, implements suite.VerifierInitializer.
+func (i initializer) Verifier() (suite.Verifier, error) {
+	return i()
+}
+
+// Type private, implements suite.SignerInitializer and
+// suite.VerifierInitializer.
+func (i initializer) Type() string {
+	return SuiteType
+}
+
+// SignerInitializerOptions provides options for a SignerInitializer.
+type SignerInitializerOptions struct {
+	LDDocumentLoader  ld.DocumentLoader
+	SignerGetter      SignerGetter
+	MandatoryPointers []string // optional
+}
+
+// NewSignerInitializer returns a suite.SignerInitializer that initializes an
+// ecdsa-sd-2023 signing Suite with the given SignerInitializerOptions.
+func NewSignerInitializer(options *SignerInitializerOptions) suite.SignerInitializer {
+	return initializer(New(&Options{
+		LDDocumentLoader:  options.LDDocumentLoader,
+		SignerGetter:      options.SignerGetter,
+		MandatoryPointers: options.MandatoryPointers,
+	}))
+}
+
+// VerifierInitializerOptions provides options for a VerifierInitializer.
+type VerifierInitializerOptions struct {
+	LDDocumentLoader ld.DocumentLoader // required
+	P256Verifier     Verifier          // optional
+}
+
+// NewVerifierInitializer returns a suite.VerifierInitializer that initializes an
+// ecdsa-sd-2023 verification Suite with the given VerifierInitializerOptions.
+func NewVerifierInitializer(options *VerifierInitializerOptions) suite.VerifierInitializer {
+	p256Verifier := options.P256Verifier
+
+	if p256Verifier == nil {
+		p256Verifier = signatureverifier.NewECDSAES256SignatureVerifier()
+	}
+
+	return initializer(New(&Options{
+		LDDocumentLoader: options.LDDocumentLoader,
+		P256Verifier:     p256Verifier,
+	}))
+}
+
+const (
+	ldCtxKey      = "@context"
+	proofKey      = "proof"
+	hmacKeyLength = 32
+
+	mandatoryGroup = "mandatory"
+	selectiveGroup = "selective"
+	combinedGroup  = "combined"
+)
+
+// multikeyP256Header is the multicodec varint prefix for a compressed P-256
+// public key, as used in the Multikey encoding.
+var multikeyP256Header = []byte{0x80, 0x24}
+
+// CreateProof implements the ecdsa-sd-2023 cryptographic suite for Add Base
+// Proof: https://www.w3.org/TR/vc-di-ecdsa/#add-base-proof-ecdsa-sd-2023
+func (s *Suite) CreateProof(doc []byte, opts *models.ProofOptions) (*models.Proof, error) { // nolint:funlen
+	docData, vmKey, err := s.prepare(doc, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	proofHash, err := s.proofHash(docData[ldCtxKey], opts)
+	if err != nil {
+		return nil, err
+	}
+
+	mandatoryPointers, err := s.getMandatoryPointers(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	hmacKey := make([]byte, hmacKeyLength)
+
+	if _, err = rand.Read(hmacKey); err != nil {
+		return nil, err
+	}
+
+	proc := &rdfProcessor{loader: s.ldLoader}
+
+	groups, err := proc.canonicalizeAndGroup(docData, hmacLabelMapper(hmacKey), map[string][]string{
+		mandatoryGroup: mandatoryPointers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mandatory := groups.groups[mandatoryGroup]
+
+	ephemeralKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey := append(append([]byte{}, multikeyP256Header...),
+		elliptic.MarshalCompressed(elliptic.P256(), ephemeralKey.X, ephemeralKey.Y)...)
+
+	var signatures [][]byte
+
+	for _, statement := range mandatory.nonMatchingStatements() {
+		sig, e := signP1363(ephemeralKey, []byte(statement))
+		if e != nil {
+			return nil, e
+		}
+
+		signatures = append(signatures, sig)
+	}
+
+	toSign := signatureBase(proofHash, publicKey, hashStatements(mandatory.matchingStatements()))
+
+	signer, err := s.signerGetter(vmKey)
+	if err != nil {
+		return nil, err
+	}
+
+	baseSignature, err := signer.Sign(toSign)
+	if err != nil {
+		return nil, err
+	}
+
+	proofValue, err := serializeBaseProofValue(&baseProofValue{
+		BaseSignature:     baseSignature,
+		PublicKey:         publicKey,
+		HMACKey:           hmacKey,
+		Signatures:        signatures,
+		MandatoryPointers: mandatoryPointers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &models.Proof{
+		Type:               models.DataIntegrityProof,
+		CryptoSuite:        SuiteType,
+		ProofPurpose:       opts.Purpose,
+		Domain:             opts.Domain,
+		Challenge:          opts.Challenge,
+		VerificationMethod: opts.VerificationMethod.ID,
+		ProofValue:         proofValue,
+		Created:            opts.Created.Format(models.DateTimeFormat),
+	}
+
+	return p, nil
+}
+
+// VerifyProof implements the ecdsa-sd-2023 cryptographic suite for Verify
+// Derived Proof: https://www.w3.org/TR/vc-di-ecdsa/#verify-derived-proof-ecdsa-sd-2023
+func (s *Suite) VerifyProof(doc []byte, proof *models.Proof, opts *models.ProofOptions) error {
+	docData, vmKey, err := s.prepare(doc, opts)
+	if err != nil {
+		return err
+	}
+
+	proofHash, err := s.proofHash(docData[ldCtxKey], opts)
+	if err != nil {
+		return err
+	}
+
+	derived, err := parseDerivedProofValue(proof.ProofValue)
+	if err != nil {
+		return err
+	}
+
+	labels, err := decompressLabelMap(derived.LabelMap)
+	if err != nil {
+		return err
+	}
+
+	proc := &rdfProcessor{loader: s.ldLoader}
+
+	nquads, err := proc.toNQuads(docData)
+	if err != nil {
+		return err
+	}
+
+	relabeled, _, err := proc.labelReplacementCanonicalize(nquads, staticLabelMapper(labels))
+	if err != nil {
+		return err
+	}
+
+	mandatory, nonMandatory, err := splitByIndexes(relabeled, derived.MandatoryIndexes)
+	if err != nil {
+		return err
+	}
+
+	if len(derived.Signatures) != len(nonMandatory) {
+		return fmt.Errorf("failed to verify ecdsa-sd-2023 DI proof: %w", suite.ErrInvalidProof)
+	}
+
+	toVerify := signatureBase(proofHash, derived.PublicKey, hashStatements(mandatory))
+
+	err = s.p256Verifier.Verify(&signatureverifier.PublicKey{JWK: vmKey}, toVerify, derived.BaseSignature)
+	if err != nil {
+		return fmt.Errorf("failed to verify ecdsa-sd-2023 DI proof: %w", err)
+	}
+
+	ephemeralKey, err := parseMultikeyP256(derived.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	for i, statement := range nonMandatory {
+		if !verifyP1363(ephemeralKey, []byte(statement), derived.Signatures[i]) {
+			return fmt.Errorf("failed to verify ecdsa-sd-2023 DI proof: %w", suite.ErrInvalidProof)
+		}
+	}
+
+	return nil
+}
+
+// RequiresCreated returns false, as the ecdsa-sd-2023 cryptographic suite does
+// not require the use of the models.Proof.Created field.
+func (s *Suite) RequiresCreated() bool {
+	return false
+}
+
+// DeriveProof implements the ecdsa-sd-2023 cryptographic suite for Add Derived
+// Proof: https://www.w3.org/TR/vc-di-ecdsa/#add-derived-proof-ecdsa-sd-2023
+//
+// The given document must be secured with an ecdsa-sd-2023 base proof.
+// DeriveProof returns a reveal document, which contains the statements selected
+// by the base proof's mandatory pointers and the given selective pointers,
+// secured with a derived proof.
+func DeriveProof(doc []byte, selectivePointers []string, loader ld.DocumentLoader) ([]byte, error) { // nolint:funlen
+	docData := make(map[string]interface{})
+
+	err := json.Unmarshal(doc, &docData)
+	if err != nil {
+		return nil, fmt.Errorf("ecdsa-sd-2023 suite expects JSON-LD payload: %w", err)
+	}
+
+	proof, err := popProof(docData)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := parseBaseProofValue(proof.ProofValue)
+	if err != nil {
+		return nil, err
+	}
+
+	combinedPointers := append(append([]string{}, base.MandatoryPointers...), selectivePointers...)
+	if len(combinedPointers) == 0 {
+		return nil, errors.New("ecdsa-sd-2023 derived proof needs at least one JSON pointer to disclose")
+	}
+
+	proc := &rdfProcessor{loader: loader}
+
+	groups, err := proc.canonicalizeAndGroup(docData, hmacLabelMapper(base.HMACKey), map[string][]string{
+		mandatoryGroup: base.MandatoryPointers,
+		selectiveGroup: selectivePointers,
+		combinedGroup:  combinedPointers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mandatory, selective, combined := groups.groups[mandatoryGroup],
+		groups.groups[selectiveGroup], groups.groups[combinedGroup]
+
+	var mandatoryIndexes []uint64
+
+	for relativeIdx, absoluteIdx := range combined.matchingIndexes() {
+		if _, ok := mandatory.matching[absoluteIdx]; ok {
+			mandatoryIndexes = append(mandatoryIndexes, uint64(relativeIdx))
+		}
+	}
+
+	nonMandatoryIndexes := mandatory.nonMatchingIndexes()
+	if len(nonMandatoryIndexes) != len(base.Signatures) {
+		return nil, errors.New("ecdsa-sd-2023 base proof signatures do not match document")
+	}
+
+	filteredSignatures := [][]byte{}
+
+	for i, absoluteIdx := range nonMandatoryIndexes {
+		if _, ok := selective.matching[absoluteIdx]; ok {
+			filteredSignatures = append(filteredSignatures, base.Signatures[i])
+		}
+	}
+
+	revealDoc, err := selectJSONLD(combinedPointers, docData)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map the blank nodes of the reveal document, as canonicalized by the
+	// verifier, to the HMAC labels of the corresponding issuer blank nodes.
+	_, canonicalIDs, err := proc.canonicalize(combined.deskolemized)
+	if err != nil {
+		return nil, err
+	}
+
+	verifierLabels := make(map[string]string, len(canonicalIDs))
+
+	for input, canonicalID := range canonicalIDs {
+		verifierLabels[canonicalID] = groups.labels[input]
+	}
+
+	labelMap, err := compressLabelMap(verifierLabels)
+	if err != nil {
+		return nil, err
+	}
+
+	proof.ProofValue, err = serializeDerivedProofValue(&derivedProofValue{
+		BaseSignature:    base.BaseSignature,
+		PublicKey:        base.PublicKey,
+		Signatures:       filteredSignatures,
+		LabelMap:         labelMap,
+		MandatoryIndexes: mandatoryIndexes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	revealDoc[proofKey] = proof
+
+	return json.Marshal(revealDoc)
+}
+
+func (s *Suite) prepare(doc []byte, opts *models.ProofOptions) (map[string]interface{}, *jwk.JWK, error) {
+	docData := make(map[string]interface{})
+
+	err := json.Unmarshal(doc, &docData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ecdsa-sd-2023 suite expects JSON-LD payload: %w", err)
+	}
+
+	vmKey := opts.VerificationMethod.JSONWebKey()
+	if vmKey == nil {
+		return nil, nil, errors.New("verification method needs JWK")
+	}
+
+	if vmKey.Crv != elliptic.P256().Params().Name {
+		return nil, nil, errors.New("unsupported ECDSA curve")
+	}
+
+	if opts.ProofType != models.DataIntegrityProof || opts.SuiteType != SuiteType {
+		return nil, nil, suite.ErrProofTransformation
+	}
+
+	return docData, vmKey, nil
+}
+
+func (s *Suite) getMandatoryPointers(opts *models.ProofOptions) ([]string, error) {
+	raw, ok := opts.CustomFields[MandatoryPointersField]
+	if !ok {
+		return s.mandatoryPointers, nil
+	}
+
+	switch pointers := raw.(type) {
+	case []string:
+		return pointers, nil
+	case []interface{}:
+		out := make([]string, 0, len(pointers))
+
+		for _, p := range pointers {
+			str, ok := p.(string)
+			if !ok {
+				return nil, errors.New("mandatory pointers must be strings")
+			}
+
+			out = append(out, str)
+		}
+
+		return out, nil
+	default:
+		return nil, errors.New("mandatory pointers must be a list of strings")
+	}
+}
+
+func (s *Suite) proofHash(docCtx interface{}, opts *models.ProofOptions) ([]byte, error) {
+	if opts.Purpose != opts.VerificationRelationship {
+		return nil, errors.New("verification method is not suitable for purpose")
+	}
+
+	conf := map[string]interface{}{
+		ldCtxKey:             docCtx,
+		"type":               models.DataIntegrityProof,
+		"cryptosuite":        SuiteType,
+		"verificationMethod": opts.VerificationMethodID,
+		"created":            opts.Created.Format(models.DateTimeFormat),
+		"proofPurpose":       opts.Purpose,
+	}
+
+	canonConf, err := processor.Default().GetCanonicalDocument(conf, processor.WithDocumentLoader(s.ldLoader))
+	if err != nil {
+		return nil, fmt.Errorf("canonicalizing signature base data: %w", err)
+	}
+
+	h := sha256.Sum256(canonConf)
+
+	return h[:], nil
+}
+
+func popProof(docData map[string]interface{}) (*models.Proof, error) {
+	rawProof, ok := docData[proofKey]
+	if !ok {
+		return nil, errors.New("document has no ecdsa-sd-2023 base proof")
+	}
+
+	delete(docData, proofKey)
+
+	proofBytes, err := json.Marshal(rawProof)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &models.Proof{}
+
+	err = json.Unmarshal(proofBytes, proof)
+	if err != nil {
+		return nil, fmt.Errorf("document proof is not a data integrity proof: %w", err)
+	}
+
+	if proof.Type != models.DataIntegrityProof || proof.CryptoSuite != SuiteType {
+		return nil, errors.New("document proof is not an ecdsa-sd-2023 proof")
+	}
+
+	return proof, nil
+}
+
+func splitByIndexes(nquads []string, mandatoryIndexes []uint64) ([]string, []string, error) {
+	isMandatory := make(map[int]bool, len(mandatoryIndexes))
+
+	for _, idx := range mandatoryIndexes {
+		if idx >= uint64(len(nquads)) {
+			return nil, nil, fmt.Errorf("failed to verify ecdsa-sd-2023 DI proof: %w", suite.ErrInvalidProof)
+		}
+
+		isMandatory[int(idx)] = true
+	}
+
+	var mandatory, nonMandatory []string
+
+	for i, nquad := range nquads {
+		if isMandatory[i] {
+			mandatory = append(mandatory, nquad)
+		} else {
+			nonMandatory = append(nonMandatory, nquad)
+		}
+	}
+
+	return mandatory, nonMandatory, nil
+}
+
+func hashStatements(statements []string) []byte {
+	h := sha256.Sum256([]byte(strings.Join(statements, "")))
+
+	return h[:]
+}
+
+func signatureBase(proofHash, publicKey, mandatoryHash []byte) []byte {
+	out := make([]byte, 0, len(proofHash)+len(publicKey)+len(mandatoryHash))
+	out = append(out, proofHash...)
+	out = append(out, publicKey...)
+
+	return append(out, mandatoryHash...)
+}
+
+const p256ScalarSize = 32
+
+func signP1363(key *ecdsa.PrivateKey, msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	sig := make([]byte, 2*p256ScalarSize)
+	r.FillBytes(sig[:p256ScalarSize])
+	s.FillBytes(sig[p256ScalarSize:])
+
+	return sig, nil
+}
+
+func verifyP1363(key *ecdsa.PublicKey, msg, sig []byte) bool {
+	if len(sig) != 2*p256ScalarSize {
+		return false
+	}
+
+	digest := sha256.Sum256(msg)
+
+	r := new(big.Int).SetBytes(sig[:p256ScalarSize])
+	s := new(big.Int).SetBytes(sig[p256ScalarSize:])
+
+	return ecdsa.Verify(key, digest[:], r, s)
+}
+
+func parseMultikeyP256(publicKey []byte) (*ecdsa.PublicKey, error) {
+	if len(publicKey) <= len(multikeyP256Header) || string(publicKey[:2]) != string(multikeyP256Header) {
+		return nil, errors.New("ecdsa-sd-2023 proof has invalid ephemeral public key")
+	}
+
+	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), publicKey[len(multikeyP256Header):])
+	if x == nil {
+		return nil, errors.New("ecdsa-sd-2023 proof has invalid ephemeral public key")
+	}
+
+	return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+}
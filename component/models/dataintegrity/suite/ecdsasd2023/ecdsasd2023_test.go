@@ -0,0 +1,411 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdsasd2023
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/models"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite"
+	"github.com/hyperledger/aries-framework-go/component/models/did"
+	"github.com/hyperledger/aries-framework-go/component/models/ld/documentloader"
+	mockldstore "github.com/hyperledger/aries-framework-go/component/models/ld/mock"
+	"github.com/hyperledger/aries-framework-go/component/models/ld/store"
+)
+
+var (
+	//go:embed testdata/valid_credential.jsonld
+	validCredential []byte
+)
+
+func TestNew(t *testing.T) {
+	docLoader, err := documentloader.NewDocumentLoader(createMockProvider())
+	require.NoError(t, err)
+
+	t.Run("signer success", func(t *testing.T) {
+		sigInit := NewSignerInitializer(&SignerInitializerOptions{
+			LDDocumentLoader: docLoader,
+			SignerGetter:     WithStaticSigner(&testSigner{}),
+		})
+
+		require.Equal(t, SuiteType, sigInit.Type())
+
+		signer, err := sigInit.Signer()
+		require.NoError(t, err)
+		require.NotNil(t, signer)
+		require.False(t, signer.RequiresCreated())
+	})
+
+	t.Run("verifier success", func(t *testing.T) {
+		verInit := NewVerifierInitializer(&VerifierInitializerOptions{
+			LDDocumentLoader: docLoader,
+		})
+
+		require.Equal(t, SuiteType, verInit.Type())
+
+		verifier, err := verInit.Verifier()
+		require.NoError(t, err)
+		require.NotNil(t, verifier)
+		require.False(t, verifier.RequiresCreated())
+	})
+}
+
+type testSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *testSigner) Sign(msg []byte) ([]byte, error) {
+	return signP1363(s.key, msg)
+}
+
+type testCase struct {
+	docLoader *documentloader.DocumentLoader
+	signer    suite.Signer
+	verifier  suite.Verifier
+	proofOpts *models.ProofOptions
+}
+
+func setup(t *testing.T, mandatoryPointers ...string) *testCase {
+	t.Helper()
+
+	docLoader, err := documentloader.NewDocumentLoader(createMockProvider())
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	pubJWK, err := jwksupport.JWKFromKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	vm, err := did.NewVerificationMethodFromJWK("#key-1", "JsonWebKey2020", "did:foo:bar", pubJWK)
+	require.NoError(t, err)
+
+	signer, err := NewSignerInitializer(&SignerInitializerOptions{
+		LDDocumentLoader:  docLoader,
+		SignerGetter:      WithStaticSigner(&testSigner{key: key}),
+		MandatoryPointers: mandatoryPointers,
+	}).Signer()
+	require.NoError(t, err)
+
+	verifier, err := NewVerifierInitializer(&VerifierInitializerOptions{
+		LDDocumentLoader: docLoader,
+	}).Verifier()
+	require.NoError(t, err)
+
+	return &testCase{
+		docLoader: docLoader,
+		signer:    signer,
+		verifier:  verifier,
+		proofOpts: &models.ProofOptions{
+			VerificationMethod:       vm,
+			VerificationMethodID:     vm.ID,
+			SuiteType:                SuiteType,
+			Purpose:                  "assertionMethod",
+			VerificationRelationship: "assertionMethod",
+			ProofType:                models.DataIntegrityProof,
+			Created:                  time.Now(),
+		},
+	}
+}
+
+func (tc *testCase) signedDoc(t *testing.T, doc []byte) []byte {
+	t.Helper()
+
+	proof, err := tc.signer.CreateProof(doc, tc.proofOpts)
+	require.NoError(t, err)
+
+	docData := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(doc, &docData))
+
+	docData[proofKey] = proof
+
+	signed, err := json.Marshal(docData)
+	require.NoError(t, err)
+
+	return signed
+}
+
+func (tc *testCase) verify(t *testing.T, revealed []byte) error {
+	t.Helper()
+
+	docData := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(revealed, &docData))
+
+	proofBytes, err := json.Marshal(docData[proofKey])
+	require.NoError(t, err)
+
+	proof := &models.Proof{}
+	require.NoError(t, json.Unmarshal(proofBytes, proof))
+
+	delete(docData, proofKey)
+
+	unsecured, err := json.Marshal(docData)
+	require.NoError(t, err)
+
+	return tc.verifier.VerifyProof(unsecured, proof, tc.proofOpts)
+}
+
+func TestSuite_DeriveAndVerify(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tc := setup(t, "/issuer", "/issuanceDate")
+
+		signed := tc.signedDoc(t, validCredential)
+
+		revealed, err := DeriveProof(signed,
+			[]string{"/credentialSubject/driversLicense/class", "/credentialSubject/nationalities/1"}, tc.docLoader)
+		require.NoError(t, err)
+
+		require.NoError(t, tc.verify(t, revealed))
+
+		revealedData := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(revealed, &revealedData))
+
+		require.Equal(t, "did:example:76e12ec712ebc6f1c221ebfeb1f", revealedData["issuer"])
+		require.Equal(t, "http://example.gov/credentials/3732", revealedData["id"])
+
+		subject, ok := revealedData["credentialSubject"].(map[string]interface{})
+		require.True(t, ok)
+		require.Equal(t, "did:example:ebfeb1f712ebc6f1c276e12ec21", subject["id"])
+		require.NotContains(t, subject, "name")
+		require.NotContains(t, subject, "birthDate")
+
+		license, ok := subject["driversLicense"].(map[string]interface{})
+		require.True(t, ok)
+		require.Equal(t, map[string]interface{}{"class": "C"}, license)
+
+		nationalities, ok := subject["nationalities"].([]interface{})
+		require.True(t, ok)
+		require.Equal(t, []interface{}{map[string]interface{}{"country": "FR"}}, nationalities)
+	})
+
+	t.Run("success with mandatory pointers only", func(t *testing.T) {
+		tc := setup(t, "/credentialSubject/birthDate")
+
+		revealed, err := DeriveProof(tc.signedDoc(t, validCredential), nil, tc.docLoader)
+		require.NoError(t, err)
+
+		require.NoError(t, tc.verify(t, revealed))
+	})
+
+	t.Run("success with mandatory pointers from proof options", func(t *testing.T) {
+		tc := setup(t)
+		tc.proofOpts.CustomFields = map[string]interface{}{
+			MandatoryPointersField: []interface{}{"/credentialSubject/name"},
+		}
+
+		revealed, err := DeriveProof(tc.signedDoc(t, validCredential),
+			[]string{"/credentialSubject/driversLicense"}, tc.docLoader)
+		require.NoError(t, err)
+
+		require.NoError(t, tc.verify(t, revealed))
+		require.Contains(t, string(revealed), "Jayden Doe")
+		require.Contains(t, string(revealed), "DL-1234567")
+	})
+
+	t.Run("failure: tampered reveal document", func(t *testing.T) {
+		tc := setup(t, "/issuer")
+
+		revealed, err := DeriveProof(tc.signedDoc(t, validCredential),
+			[]string{"/credentialSubject/birthDate"}, tc.docLoader)
+		require.NoError(t, err)
+
+		tampered := []byte(strings.Replace(string(revealed), "1999-04-16", "1989-04-16", 1))
+
+		err = tc.verify(t, tampered)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, suite.ErrInvalidProof))
+	})
+
+	t.Run("failure: mandatory statement removed", func(t *testing.T) {
+		tc := setup(t, "/issuer", "/credentialSubject/name")
+
+		revealed, err := DeriveProof(tc.signedDoc(t, validCredential),
+			[]string{"/credentialSubject/birthDate"}, tc.docLoader)
+		require.NoError(t, err)
+
+		revealedData := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(revealed, &revealedData))
+
+		subject, ok := revealedData["credentialSubject"].(map[string]interface{})
+		require.True(t, ok)
+		require.Contains(t, subject, "name")
+
+		delete(subject, "name")
+
+		tampered, err := json.Marshal(revealedData)
+		require.NoError(t, err)
+
+		err = tc.verify(t, tampered)
+		require.Error(t, err)
+	})
+
+	t.Run("failure: wrong issuer key", func(t *testing.T) {
+		tc := setup(t, "/issuer")
+
+		revealed, err := DeriveProof(tc.signedDoc(t, validCredential),
+			[]string{"/credentialSubject/name"}, tc.docLoader)
+		require.NoError(t, err)
+
+		tc.proofOpts.VerificationMethod = setup(t).proofOpts.VerificationMethod
+
+		err = tc.verify(t, revealed)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to verify ecdsa-sd-2023 DI proof")
+	})
+
+	t.Run("failure: base proof can't be verified", func(t *testing.T) {
+		tc := setup(t, "/issuer")
+
+		err := tc.verify(t, tc.signedDoc(t, validCredential))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parsing ecdsa-sd-2023 derived proof")
+	})
+
+	t.Run("failure: pointer does not match document", func(t *testing.T) {
+		tc := setup(t, "/issuer")
+
+		_, err := DeriveProof(tc.signedDoc(t, validCredential),
+			[]string{"/credentialSubject/unknown"}, tc.docLoader)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "JSON pointer does not match document")
+	})
+
+	t.Run("failure: nothing to disclose", func(t *testing.T) {
+		tc := setup(t)
+
+		_, err := DeriveProof(tc.signedDoc(t, validCredential), nil, tc.docLoader)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "needs at least one JSON pointer")
+	})
+
+	t.Run("failure: document has no base proof", func(t *testing.T) {
+		tc := setup(t)
+
+		_, err := DeriveProof(validCredential, []string{"/issuer"}, tc.docLoader)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "has no ecdsa-sd-2023 base proof")
+	})
+}
+
+func TestSuite_CreateProof(t *testing.T) {
+	t.Run("failure: unsupported curve", func(t *testing.T) {
+		tc := setup(t)
+
+		key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err)
+
+		pubJWK, err := jwksupport.JWKFromKey(&key.PublicKey)
+		require.NoError(t, err)
+
+		tc.proofOpts.VerificationMethod, err = did.NewVerificationMethodFromJWK(
+			"#key-2", "JsonWebKey2020", "did:foo:bar", pubJWK)
+		require.NoError(t, err)
+
+		_, err = tc.signer.CreateProof(validCredential, tc.proofOpts)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported ECDSA curve")
+	})
+
+	t.Run("failure: wrong suite type", func(t *testing.T) {
+		tc := setup(t)
+		tc.proofOpts.SuiteType = "ecdsa-2019"
+
+		_, err := tc.signer.CreateProof(validCredential, tc.proofOpts)
+		require.ErrorIs(t, err, suite.ErrProofTransformation)
+	})
+
+	t.Run("failure: invalid mandatory pointers option", func(t *testing.T) {
+		tc := setup(t)
+		tc.proofOpts.CustomFields = map[string]interface{}{MandatoryPointersField: "/issuer"}
+
+		_, err := tc.signer.CreateProof(validCredential, tc.proofOpts)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "mandatory pointers must be a list of strings")
+	})
+
+	t.Run("failure: invalid JSON", func(t *testing.T) {
+		tc := setup(t)
+
+		_, err := tc.signer.CreateProof([]byte("not json"), tc.proofOpts)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "expects JSON-LD payload")
+	})
+}
+
+func TestSelectJSONLD(t *testing.T) {
+	doc := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(validCredential, &doc))
+
+	selection, err := selectJSONLD([]string{"/credentialSubject/nationalities/0/country"}, doc)
+	require.NoError(t, err)
+
+	require.Equal(t, doc["@context"], selection["@context"])
+	require.Equal(t, doc["id"], selection["id"])
+	require.Equal(t, map[string]interface{}{
+		"id":            "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		"nationalities": []interface{}{map[string]interface{}{"country": "US"}},
+	}, selection["credentialSubject"])
+
+	_, err = selectJSONLD([]string{"credentialSubject"}, doc)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid JSON pointer")
+}
+
+func TestProofValue(t *testing.T) {
+	value, err := serializeBaseProofValue(&baseProofValue{
+		BaseSignature:     []byte("sig"),
+		PublicKey:         []byte("key"),
+		HMACKey:           []byte("hmac"),
+		Signatures:        [][]byte{[]byte("a"), []byte("b")},
+		MandatoryPointers: []string{"/issuer"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, byte('u'), value[0])
+
+	parsed, err := parseBaseProofValue(value)
+	require.NoError(t, err)
+	require.Equal(t, []string{"/issuer"}, parsed.MandatoryPointers)
+	require.Len(t, parsed.Signatures, 2)
+
+	_, err = parseDerivedProofValue(value)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "wrong header")
+
+	_, err = parseBaseProofValue("zabc")
+	require.Error(t, err)
+}
+
+type provider struct {
+	ContextStore        store.ContextStore
+	RemoteProviderStore store.RemoteProviderStore
+}
+
+func (p *provider) JSONLDContextStore() store.ContextStore {
+	return p.ContextStore
+}
+
+func (p *provider) JSONLDRemoteProviderStore() store.RemoteProviderStore {
+	return p.RemoteProviderStore
+}
+
+func createMockProvider() *provider {
+	return &provider{
+		ContextStore:        mockldstore.NewMockContextStore(),
+		RemoteProviderStore: mockldstore.NewMockRemoteProviderStore(),
+	}
+}
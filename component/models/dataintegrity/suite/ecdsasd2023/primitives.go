@@ -0,0 +1,635 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdsasd2023
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/piprate/json-gold/ld"
+)
+
+const (
+	nquadsFormat = "application/n-quads"
+	bnidURN      = "urn:bnid:"
+	idKey        = "id"
+	typeKey      = "type"
+	ldIDKey      = "@id"
+	ldValueKey   = "@value"
+	ldListKey    = "@list"
+	ldSetKey     = "@set"
+)
+
+var (
+	skolemIRIRegex = regexp.MustCompile(`<` + bnidURN + `([^>]+)>`)
+	blankLabelRe   = regexp.MustCompile(`_:([A-Za-z0-9_\-]+)`)
+)
+
+// labelMapper maps the canonical identifiers of blank nodes (without the "_:"
+// prefix, eg "c14n0") to the labels that replace them.
+type labelMapper func(canonicalID string) (string, error)
+
+// hmacLabelMapper returns a labelMapper that replaces each canonical blank node
+// identifier with "u" + base64url(HMAC-SHA256(key, canonical identifier)).
+func hmacLabelMapper(key []byte) labelMapper {
+	return func(canonicalID string) (string, error) {
+		h := hmac.New(sha256.New, key)
+		h.Write([]byte(canonicalID))
+
+		return "u" + base64.RawURLEncoding.EncodeToString(h.Sum(nil)), nil
+	}
+}
+
+// staticLabelMapper returns a labelMapper that looks up replacement labels in
+// the given map.
+func staticLabelMapper(labels map[string]string) labelMapper {
+	return func(canonicalID string) (string, error) {
+		label, ok := labels[canonicalID]
+		if !ok {
+			return "", fmt.Errorf("missing blank node label for %s", canonicalID)
+		}
+
+		return label, nil
+	}
+}
+
+// skolemizedDoc holds a JSON-LD document in which every blank node has been
+// given a temporary urn:bnid: identifier, so that selections made from it keep
+// track of which blank node each selected statement belongs to.
+type skolemizedDoc struct {
+	compact map[string]interface{}
+	nquads  []string
+}
+
+type rdfProcessor struct {
+	loader ld.DocumentLoader
+}
+
+func (p *rdfProcessor) options() *ld.JsonLdOptions {
+	opts := ld.NewJsonLdOptions("")
+	opts.DocumentLoader = p.loader
+	opts.ProcessingMode = ld.JsonLd_1_1
+	opts.Format = nquadsFormat
+	opts.ProduceGeneralizedRdf = true
+	opts.Algorithm = ld.AlgorithmURDNA2015
+
+	return opts
+}
+
+func (p *rdfProcessor) skolemize(doc map[string]interface{}) (*skolemizedDoc, error) {
+	proc := ld.NewJsonLdProcessor()
+
+	expanded, err := proc.Expand(doc, p.options())
+	if err != nil {
+		return nil, fmt.Errorf("expanding document: %w", err)
+	}
+
+	prefix := uuid.New().String()
+	count := 0
+
+	skolemizeExpanded(expanded, prefix, &count)
+
+	compact, err := proc.Compact(expanded, map[string]interface{}{"@context": doc["@context"]}, p.options())
+	if err != nil {
+		return nil, fmt.Errorf("compacting skolemized document: %w", err)
+	}
+
+	nquads, err := p.toDeskolemizedNQuads(expanded)
+	if err != nil {
+		return nil, err
+	}
+
+	return &skolemizedDoc{compact: compact, nquads: nquads}, nil
+}
+
+func skolemizeExpanded(element interface{}, prefix string, count *int) {
+	switch e := element.(type) {
+	case []interface{}:
+		for _, item := range e {
+			skolemizeExpanded(item, prefix, count)
+		}
+	case map[string]interface{}:
+		if _, ok := e[ldValueKey]; ok {
+			return
+		}
+
+		_, isList := e[ldListKey]
+		_, isSet := e[ldSetKey]
+
+		if !isList && !isSet {
+			id, ok := e[ldIDKey].(string)
+			if !ok {
+				e[ldIDKey] = fmt.Sprintf("%s%s_%d", bnidURN, prefix, *count)
+				*count++
+			} else if strings.HasPrefix(id, "_:") {
+				e[ldIDKey] = bnidURN + id[2:]
+			}
+		}
+
+		for key, value := range e {
+			if key == ldIDKey {
+				continue
+			}
+
+			skolemizeExpanded(value, prefix, count)
+		}
+	}
+}
+
+// toDeskolemizedNQuads converts a skolemized document to N-Quads, replacing
+// urn:bnid: identifiers with the blank node labels they stand for.
+func (p *rdfProcessor) toDeskolemizedNQuads(doc interface{}) ([]string, error) {
+	view, err := ld.NewJsonLdProcessor().ToRDF(doc, p.options())
+	if err != nil {
+		return nil, fmt.Errorf("converting document to RDF: %w", err)
+	}
+
+	nquads, ok := view.(string)
+	if !ok {
+		return nil, errors.New("unexpected RDF conversion result")
+	}
+
+	return splitNQuads(skolemIRIRegex.ReplaceAllString(nquads, "_:$1")), nil
+}
+
+func (p *rdfProcessor) toNQuads(doc map[string]interface{}) ([]string, error) {
+	view, err := ld.NewJsonLdProcessor().ToRDF(doc, p.options())
+	if err != nil {
+		return nil, fmt.Errorf("converting document to RDF: %w", err)
+	}
+
+	nquads, ok := view.(string)
+	if !ok {
+		return nil, errors.New("unexpected RDF conversion result")
+	}
+
+	return splitNQuads(nquads), nil
+}
+
+func splitNQuads(nquads string) []string {
+	var out []string
+
+	for _, line := range strings.SplitAfter(nquads, "\n") {
+		if strings.TrimSpace(line) != "" {
+			out = append(out, line)
+		}
+	}
+
+	return out
+}
+
+// canonicalize runs RDF dataset canonicalization over the given N-Quads,
+// returning the sorted canonical N-Quads and a map from each input blank node
+// label to its canonical identifier (both without the "_:" prefix).
+func (p *rdfProcessor) canonicalize(nquads []string) ([]string, map[string]string, error) {
+	dataset, err := ld.ParseNQuads(strings.Join(nquads, ""))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing N-Quads: %w", err)
+	}
+
+	// The normalisation algorithm relabels blank nodes in place, so keep each
+	// node's input label to recover the input -> canonical identifier map.
+	inputLabels := map[*ld.BlankNode]string{}
+
+	for _, quads := range dataset.Graphs {
+		for _, quad := range quads {
+			for _, node := range []ld.Node{quad.Subject, quad.Object, quad.Graph} {
+				if bn, ok := node.(*ld.BlankNode); ok {
+					inputLabels[bn] = bn.Attribute
+				}
+			}
+		}
+	}
+
+	view, err := ld.NewNormalisationAlgorithm(ld.AlgorithmURDNA2015).Main(dataset, p.options())
+	if err != nil {
+		return nil, nil, fmt.Errorf("canonicalizing N-Quads: %w", err)
+	}
+
+	canonical, ok := view.(string)
+	if !ok {
+		return nil, nil, errors.New("unexpected canonicalization result")
+	}
+
+	canonicalIDs := make(map[string]string, len(inputLabels))
+
+	for bn, input := range inputLabels {
+		canonicalIDs[strings.TrimPrefix(input, "_:")] = strings.TrimPrefix(bn.Attribute, "_:")
+	}
+
+	return splitNQuads(canonical), canonicalIDs, nil
+}
+
+// relabelNQuads replaces blank node labels in the given N-Quads using the given
+// label map, then sorts the result.
+func relabelNQuads(nquads []string, labels map[string]string) ([]string, error) {
+	out := make([]string, len(nquads))
+
+	var missing error
+
+	for i, nquad := range nquads {
+		out[i] = blankLabelRe.ReplaceAllStringFunc(nquad, func(match string) string {
+			label, ok := labels[match[2:]]
+			if !ok {
+				missing = fmt.Errorf("missing blank node label for %s", match)
+
+				return match
+			}
+
+			return "_:" + label
+		})
+	}
+
+	if missing != nil {
+		return nil, missing
+	}
+
+	sort.Strings(out)
+
+	return out, nil
+}
+
+// labelReplacementCanonicalize canonicalizes the given N-Quads, and replaces
+// canonical blank node identifiers using the given labelMapper. It returns the
+// sorted, relabeled N-Quads, and a map from input blank node labels to their
+// replacement labels.
+func (p *rdfProcessor) labelReplacementCanonicalize(
+	nquads []string,
+	mapper labelMapper,
+) ([]string, map[string]string, error) {
+	canonical, canonicalIDs, err := p.canonicalize(nquads)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	canonicalLabels := make(map[string]string, len(canonicalIDs))
+	inputLabels := make(map[string]string, len(canonicalIDs))
+
+	for input, canonicalID := range canonicalIDs {
+		label, err := mapper(canonicalID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		canonicalLabels[canonicalID] = label
+		inputLabels[input] = label
+	}
+
+	relabeled, err := relabelNQuads(canonical, canonicalLabels)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return relabeled, inputLabels, nil
+}
+
+// group is the result of selecting the statements matching a set of JSON
+// pointers from a canonicalized document. Matching and nonMatching are keyed by
+// statement index within the full list of canonical statements.
+type group struct {
+	matching     map[int]string
+	nonMatching  map[int]string
+	deskolemized []string
+}
+
+func (g *group) matchingIndexes() []int {
+	return sortedKeys(g.matching)
+}
+
+func (g *group) nonMatchingIndexes() []int {
+	return sortedKeys(g.nonMatching)
+}
+
+func (g *group) matchingStatements() []string {
+	return sortedValues(g.matching)
+}
+
+func (g *group) nonMatchingStatements() []string {
+	return sortedValues(g.nonMatching)
+}
+
+func sortedValues(m map[int]string) []string {
+	keys := sortedKeys(m)
+	values := make([]string, len(keys))
+
+	for i, k := range keys {
+		values[i] = m[k]
+	}
+
+	return values
+}
+
+func sortedKeys(m map[int]string) []int {
+	keys := make([]int, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Ints(keys)
+
+	return keys
+}
+
+type canonicalGroups struct {
+	nquads     []string
+	labels     map[string]string
+	groups     map[string]*group
+	skolemized *skolemizedDoc
+}
+
+// canonicalizeAndGroup canonicalizes the document, relabels its blank nodes
+// using the given labelMapper, and splits the resulting statements into groups
+// according to the given sets of JSON pointers.
+func (p *rdfProcessor) canonicalizeAndGroup(
+	doc map[string]interface{},
+	mapper labelMapper,
+	pointerGroups map[string][]string,
+) (*canonicalGroups, error) {
+	skolemized, err := p.skolemize(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	nquads, labels, err := p.labelReplacementCanonicalize(skolemized.nquads, mapper)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &canonicalGroups{
+		nquads:     nquads,
+		labels:     labels,
+		groups:     map[string]*group{},
+		skolemized: skolemized,
+	}
+
+	for name, pointers := range pointerGroups {
+		g, err := p.selectGroup(skolemized.compact, pointers, nquads, labels)
+		if err != nil {
+			return nil, err
+		}
+
+		result.groups[name] = g
+	}
+
+	return result, nil
+}
+
+func (p *rdfProcessor) selectGroup(
+	skolemizedCompact map[string]interface{},
+	pointers []string,
+	nquads []string,
+	labels map[string]string,
+) (*group, error) {
+	g := &group{
+		matching:    map[int]string{},
+		nonMatching: map[int]string{},
+	}
+
+	selected := map[string]bool{}
+
+	if len(pointers) > 0 {
+		selection, err := selectJSONLD(pointers, skolemizedCompact)
+		if err != nil {
+			return nil, err
+		}
+
+		g.deskolemized, err = p.toDeskolemizedNQuads(selection)
+		if err != nil {
+			return nil, err
+		}
+
+		relabeled, err := relabelNQuads(g.deskolemized, labels)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, nquad := range relabeled {
+			selected[nquad] = true
+		}
+	}
+
+	for i, nquad := range nquads {
+		if selected[nquad] {
+			g.matching[i] = nquad
+		} else {
+			g.nonMatching[i] = nquad
+		}
+	}
+
+	return g, nil
+}
+
+// sparseArray collects selected array elements by their index in the source
+// array, so they can be compacted into a dense array once selection is done.
+type sparseArray map[int]interface{}
+
+// selectJSONLD creates a JSON-LD document containing only the values selected
+// by the given JSON pointers, along with the @context of the document and the
+// id and type of every object on the path to each selected value.
+func selectJSONLD(pointers []string, doc map[string]interface{}) (map[string]interface{}, error) {
+	selection := initialSelection(doc)
+
+	if ctx, ok := doc["@context"]; ok {
+		selection["@context"] = ctx
+	}
+
+	for _, pointer := range pointers {
+		paths, err := parsePointer(pointer)
+		if err != nil {
+			return nil, err
+		}
+
+		err = selectPaths(doc, paths, selection)
+		if err != nil {
+			return nil, fmt.Errorf("selecting %q: %w", pointer, err)
+		}
+	}
+
+	out, ok := densify(selection).(map[string]interface{})
+	if !ok {
+		return nil, errors.New("unexpected selection result")
+	}
+
+	return out, nil
+}
+
+func initialSelection(value interface{}) map[string]interface{} {
+	selection := map[string]interface{}{}
+
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return selection
+	}
+
+	if id, ok := obj[idKey].(string); ok && !strings.HasPrefix(id, "_:") {
+		selection[idKey] = id
+	}
+
+	if t, ok := obj[typeKey]; ok {
+		selection[typeKey] = t
+	}
+
+	return selection
+}
+
+func parsePointer(pointer string) ([]string, error) {
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+
+	paths := strings.Split(pointer[1:], "/")
+
+	for i, p := range paths {
+		paths[i] = strings.ReplaceAll(strings.ReplaceAll(p, "~1", "/"), "~0", "~")
+	}
+
+	return paths, nil
+}
+
+func getChild(parent interface{}, path string) (interface{}, bool, error) {
+	switch v := parent.(type) {
+	case map[string]interface{}:
+		child, ok := v[path]
+
+		return child, ok, nil
+	case []interface{}:
+		idx, err := strconv.Atoi(path)
+		if err != nil || idx < 0 || idx >= len(v) {
+			return nil, false, nil
+		}
+
+		return v[idx], true, nil
+	case sparseArray:
+		idx, err := strconv.Atoi(path)
+		if err != nil {
+			return nil, false, nil
+		}
+
+		child, ok := v[idx]
+
+		return child, ok, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+func setChild(parent interface{}, path string, value interface{}) error {
+	switch v := parent.(type) {
+	case map[string]interface{}:
+		v[path] = value
+	case sparseArray:
+		idx, err := strconv.Atoi(path)
+		if err != nil {
+			return fmt.Errorf("invalid array index %q", path)
+		}
+
+		v[idx] = value
+	default:
+		return errors.New("JSON pointer does not match document")
+	}
+
+	return nil
+}
+
+func selectPaths(doc interface{}, paths []string, selection map[string]interface{}) error {
+	var (
+		value          = doc
+		selectedParent interface{}
+		selectedValue  interface{} = selection
+	)
+
+	for _, path := range paths {
+		selectedParent = selectedValue
+
+		child, ok, err := getChild(value, path)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return errors.New("JSON pointer does not match document")
+		}
+
+		value = child
+
+		selectedValue, ok, err = getChild(selectedParent, path)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			if _, isArray := value.([]interface{}); isArray {
+				selectedValue = sparseArray{}
+			} else {
+				selectedValue = initialSelection(value)
+			}
+
+			if err = setChild(selectedParent, path, selectedValue); err != nil {
+				return err
+			}
+		}
+	}
+
+	last := paths[len(paths)-1]
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		merged := map[string]interface{}{}
+
+		if sel, ok := selectedValue.(map[string]interface{}); ok {
+			for k, val := range sel {
+				merged[k] = val
+			}
+		}
+
+		for k, val := range v {
+			merged[k] = val
+		}
+
+		return setChild(selectedParent, last, merged)
+	default:
+		return setChild(selectedParent, last, v)
+	}
+}
+
+func densify(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = densify(child)
+		}
+
+		return v
+	case sparseArray:
+		indexes := make([]int, 0, len(v))
+
+		for idx := range v {
+			indexes = append(indexes, idx)
+		}
+
+		sort.Ints(indexes)
+
+		out := make([]interface{}, 0, len(indexes))
+
+		for _, idx := range indexes {
+			out = append(out, densify(v[idx]))
+		}
+
+		return out
+	default:
+		return v
+	}
+}
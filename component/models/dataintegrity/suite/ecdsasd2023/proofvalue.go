@@ -0,0 +1,140 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ecdsasd2023
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/multiformats/go-multibase"
+)
+
+var (
+	baseProofHeader    = []byte{0xd9, 0x5d, 0x00}
+	derivedProofHeader = []byte{0xd9, 0x5d, 0x01}
+)
+
+const canonicalIDPrefix = "c14n"
+
+// baseProofValue holds the components of an ecdsa-sd-2023 base proof, created
+// by the issuer and only ever shared with the holder.
+type baseProofValue struct {
+	_                 struct{} `cbor:",toarray"`
+	BaseSignature     []byte
+	PublicKey         []byte
+	HMACKey           []byte
+	Signatures        [][]byte
+	MandatoryPointers []string
+}
+
+// derivedProofValue holds the components of an ecdsa-sd-2023 derived proof,
+// created by the holder and shared with verifiers.
+type derivedProofValue struct {
+	_                struct{} `cbor:",toarray"`
+	BaseSignature    []byte
+	PublicKey        []byte
+	Signatures       [][]byte
+	LabelMap         map[uint64][]byte
+	MandatoryIndexes []uint64
+}
+
+func serializeBaseProofValue(value *baseProofValue) (string, error) {
+	return serializeProofValue(baseProofHeader, value)
+}
+
+func serializeDerivedProofValue(value *derivedProofValue) (string, error) {
+	return serializeProofValue(derivedProofHeader, value)
+}
+
+func serializeProofValue(header []byte, value interface{}) (string, error) {
+	payload, err := cbor.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("encoding proof value: %w", err)
+	}
+
+	return multibase.Encode(multibase.Base64url, append(append([]byte{}, header...), payload...))
+}
+
+func parseBaseProofValue(proofValue string) (*baseProofValue, error) {
+	value := &baseProofValue{}
+
+	err := parseProofValue(proofValue, baseProofHeader, value)
+	if err != nil {
+		return nil, fmt.Errorf("parsing ecdsa-sd-2023 base proof: %w", err)
+	}
+
+	return value, nil
+}
+
+func parseDerivedProofValue(proofValue string) (*derivedProofValue, error) {
+	value := &derivedProofValue{}
+
+	err := parseProofValue(proofValue, derivedProofHeader, value)
+	if err != nil {
+		return nil, fmt.Errorf("parsing ecdsa-sd-2023 derived proof: %w", err)
+	}
+
+	return value, nil
+}
+
+func parseProofValue(proofValue string, header []byte, value interface{}) error {
+	enc, data, err := multibase.Decode(proofValue)
+	if err != nil {
+		return err
+	}
+
+	if enc != multibase.Base64url {
+		return errors.New("proof value must be base64url multibase encoded")
+	}
+
+	if !bytes.HasPrefix(data, header) {
+		return errors.New("proof value has wrong header")
+	}
+
+	return cbor.Unmarshal(data[len(header):], value)
+}
+
+// compressLabelMap converts a map of canonical blank node identifiers (eg
+// "c14n0") to HMAC labels (eg "u<base64url digest>") into the compact CBOR form.
+func compressLabelMap(labels map[string]string) (map[uint64][]byte, error) {
+	out := make(map[uint64][]byte, len(labels))
+
+	for canonicalID, label := range labels {
+		idx, err := strconv.ParseUint(strings.TrimPrefix(canonicalID, canonicalIDPrefix), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid canonical blank node identifier %q", canonicalID)
+		}
+
+		_, digest, err := multibase.Decode(label)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blank node label %q: %w", label, err)
+		}
+
+		out[idx] = digest
+	}
+
+	return out, nil
+}
+
+func decompressLabelMap(compressed map[uint64][]byte) (map[string]string, error) {
+	out := make(map[string]string, len(compressed))
+
+	for idx, digest := range compressed {
+		label, err := multibase.Encode(multibase.Base64url, digest)
+		if err != nil {
+			return nil, err
+		}
+
+		out[canonicalIDPrefix+strconv.FormatUint(idx, 10)] = label
+	}
+
+	return out, nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mldsa2024
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/jwkkid"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/component/kmscrypto/mock/kms"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/models"
+	"github.com/hyperledger/aries-framework-go/component/models/did"
+	"github.com/hyperledger/aries-framework-go/component/models/ld/documentloader"
+	mockstorage "github.com/hyperledger/aries-framework-go/component/storageutil/mock/storage"
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+func TestIntegration(t *testing.T) {
+	docLoader, err := documentloader.NewDocumentLoader(createMockProvider())
+	require.NoError(t, err)
+
+	storeProv := mockstorage.NewMockStoreProvider()
+
+	kmsProv, err := mockkms.NewProviderForKMS(storeProv, &noop.NoLock{})
+	require.NoError(t, err)
+
+	kms, err := localkms.New("local-lock://custom/master/key/", kmsProv)
+	require.NoError(t, err)
+
+	cr, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	signerInit := NewSignerInitializer(&SignerInitializerOptions{
+		LDDocumentLoader: docLoader,
+		SignerGetter:     WithLocalKMSSigner(kms, cr),
+	})
+
+	signer, err := signerInit.Signer()
+	require.NoError(t, err)
+
+	verifierInit := NewVerifierInitializer(&VerifierInitializerOptions{
+		LDDocumentLoader: docLoader,
+	})
+
+	verifier, err := verifierInit.Verifier()
+	require.NoError(t, err)
+
+	keyTypes := []kmsapi.KeyType{
+		kmsapi.MLDSA44Type, kmsapi.MLDSA65Type, kmsapi.MLDSA87Type, kmsapi.MLDSA65ED25519Type,
+	}
+
+	vms := make(map[kmsapi.KeyType]*did.VerificationMethod, len(keyTypes))
+
+	for i, keyType := range keyTypes {
+		_, pubKeyBytes, err := kms.CreateAndExportPubKeyBytes(keyType)
+		require.NoError(t, err)
+
+		pubJWK, err := jwkkid.BuildJWK(pubKeyBytes, keyType)
+		require.NoError(t, err)
+
+		vms[keyType], err = did.NewVerificationMethodFromJWK(fmt.Sprintf("#key-%d", i+1), "JsonWebKey2020",
+			"did:foo:bar", pubJWK)
+		require.NoError(t, err)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		for _, keyType := range keyTypes {
+			vm := vms[keyType]
+
+			t.Run(string(keyType), func(t *testing.T) {
+				proofOpts := &models.ProofOptions{
+					VerificationMethod:       vm,
+					VerificationMethodID:     vm.ID,
+					SuiteType:                SuiteType,
+					Purpose:                  "assertionMethod",
+					VerificationRelationship: "assertionMethod",
+					ProofType:                models.DataIntegrityProof,
+					Created:                  time.Now(),
+					MaxAge:                   100,
+				}
+
+				proof, err := signer.CreateProof(validCredential, proofOpts)
+				require.NoError(t, err)
+
+				err = verifier.VerifyProof(validCredential, proof, proofOpts)
+				require.NoError(t, err)
+			})
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		t.Run("wrong key", func(t *testing.T) {
+			signOpts := &models.ProofOptions{
+				VerificationMethod:       vms[kmsapi.MLDSA65Type],
+				VerificationMethodID:     vms[kmsapi.MLDSA65Type].ID,
+				SuiteType:                SuiteType,
+				Purpose:                  "assertionMethod",
+				VerificationRelationship: "assertionMethod",
+				ProofType:                models.DataIntegrityProof,
+				Created:                  time.Now(),
+			}
+
+			verifyOpts := &models.ProofOptions{
+				VerificationMethod:       vms[kmsapi.MLDSA65ED25519Type],
+				VerificationMethodID:     vms[kmsapi.MLDSA65ED25519Type].ID,
+				SuiteType:                SuiteType,
+				Purpose:                  "assertionMethod",
+				VerificationRelationship: "assertionMethod",
+				ProofType:                models.DataIntegrityProof,
+				MaxAge:                   100,
+			}
+
+			proof, err := signer.CreateProof(validCredential, signOpts)
+			require.NoError(t, err)
+
+			err = verifier.VerifyProof(validCredential, proof, verifyOpts)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "failed to verify mldsa-rdfc-2024 DI proof")
+		})
+	})
+}
@@ -0,0 +1,435 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mldsa2024
+
+import (
+	_ "embed"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsa"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsaed25519"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk/jwksupport"
+	mockcrypto "github.com/hyperledger/aries-framework-go/component/kmscrypto/mock/crypto"
+	mockkms "github.com/hyperledger/aries-framework-go/component/kmscrypto/mock/kms"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/models"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite"
+	"github.com/hyperledger/aries-framework-go/component/models/did"
+	"github.com/hyperledger/aries-framework-go/component/models/ld/documentloader"
+	mockldstore "github.com/hyperledger/aries-framework-go/component/models/ld/mock"
+	"github.com/hyperledger/aries-framework-go/component/models/ld/store"
+	signatureverifier "github.com/hyperledger/aries-framework-go/component/models/signature/verifier"
+)
+
+var (
+	//go:embed testdata/valid_credential.jsonld
+	validCredential []byte
+	//go:embed testdata/invalid_jsonld.jsonld
+	invalidJSONLD []byte
+)
+
+const (
+	fooBar = "foo bar"
+)
+
+func TestNew(t *testing.T) {
+	docLoader, err := documentloader.NewDocumentLoader(createMockProvider())
+	require.NoError(t, err)
+
+	cryp := &mockcrypto.Crypto{}
+	kms := &mockkms.KeyManager{}
+
+	signerGetter := WithLocalKMSSigner(kms, cryp)
+
+	t.Run("signer success", func(t *testing.T) {
+		sigInit := NewSignerInitializer(&SignerInitializerOptions{
+			LDDocumentLoader: docLoader,
+			SignerGetter:     signerGetter,
+		})
+
+		signer, err := sigInit.Signer()
+		require.NoError(t, err)
+		require.NotNil(t, signer)
+		require.False(t, signer.RequiresCreated())
+	})
+
+	t.Run("verifier success", func(t *testing.T) {
+		verInit := NewVerifierInitializer(&VerifierInitializerOptions{
+			LDDocumentLoader: docLoader,
+		})
+
+		verifier, err := verInit.Verifier()
+		require.NoError(t, err)
+		require.NotNil(t, verifier)
+		require.False(t, verifier.RequiresCreated())
+	})
+}
+
+type testCase struct {
+	crypto    *mockcrypto.Crypto
+	kms       *mockkms.KeyManager
+	docLoader *documentloader.DocumentLoader
+	proofOpts *models.ProofOptions
+	proof     *models.Proof
+	verifier  Verifier
+	document  []byte
+	errIs     error
+	errStr    string
+}
+
+func successCase(t *testing.T) *testCase {
+	t.Helper()
+
+	_, mockVM := getVMWithJWK(t)
+
+	docLoader, err := documentloader.NewDocumentLoader(createMockProvider())
+	require.NoError(t, err)
+
+	cryp := &mockcrypto.Crypto{}
+	keyManager := &mockkms.KeyManager{}
+
+	proofCreated := time.Now()
+
+	proofOpts := &models.ProofOptions{
+		VerificationMethod:       mockVM,
+		VerificationMethodID:     mockVM.ID,
+		SuiteType:                SuiteType,
+		Purpose:                  "assertionMethod",
+		VerificationRelationship: "assertionMethod",
+		ProofType:                models.DataIntegrityProof,
+		Created:                  proofCreated,
+		MaxAge:                   100,
+	}
+
+	mockSig, err := multibase.Encode(multibase.Base58BTC, []byte("mock signature"))
+	require.NoError(t, err)
+
+	proof := &models.Proof{
+		Type:               models.DataIntegrityProof,
+		CryptoSuite:        SuiteType,
+		ProofPurpose:       "assertionMethod",
+		VerificationMethod: mockVM.ID,
+		Created:            proofCreated.Format(models.DateTimeFormat),
+		ProofValue:         mockSig,
+	}
+
+	return &testCase{
+		crypto:    cryp,
+		kms:       keyManager,
+		docLoader: docLoader,
+		proofOpts: proofOpts,
+		proof:     proof,
+		document:  validCredential,
+		errIs:     nil,
+		errStr:    "",
+	}
+}
+
+func testSign(t *testing.T, tc *testCase) {
+	sigInit := NewSignerInitializer(&SignerInitializerOptions{
+		LDDocumentLoader: tc.docLoader,
+		SignerGetter:     WithLocalKMSSigner(tc.kms, tc.crypto),
+	})
+
+	signer, err := sigInit.Signer()
+	require.NoError(t, err)
+
+	proof, err := signer.CreateProof(tc.document, tc.proofOpts)
+
+	if tc.errStr == "" && tc.errIs == nil {
+		require.NoError(t, err)
+		require.NotNil(t, proof)
+	} else {
+		require.Error(t, err)
+		require.Nil(t, proof)
+
+		if tc.errStr != "" {
+			require.Contains(t, err.Error(), tc.errStr)
+		}
+
+		if tc.errIs != nil {
+			require.ErrorIs(t, err, tc.errIs)
+		}
+	}
+}
+
+type mockVerifier struct {
+	err error
+}
+
+func (mv *mockVerifier) Verify(_ *signatureverifier.PublicKey, _, _ []byte) error {
+	return mv.err
+}
+
+func testVerify(t *testing.T, tc *testCase) {
+	verInit := NewVerifierInitializer(&VerifierInitializerOptions{
+		LDDocumentLoader:       tc.docLoader,
+		MLDSA44Verifier:        tc.verifier,
+		MLDSA65Verifier:        tc.verifier,
+		MLDSA87Verifier:        tc.verifier,
+		MLDSA65Ed25519Verifier: tc.verifier,
+	})
+
+	verifier, err := verInit.Verifier()
+	require.NoError(t, err)
+
+	err = verifier.VerifyProof(tc.document, tc.proof, tc.proofOpts)
+
+	if tc.errStr == "" && tc.errIs == nil {
+		require.NoError(t, err)
+	} else {
+		require.Error(t, err)
+
+		if tc.errStr != "" {
+			require.Contains(t, err.Error(), tc.errStr)
+		}
+
+		if tc.errIs != nil {
+			require.ErrorIs(t, err, tc.errIs)
+		}
+	}
+}
+
+func TestSuite_CreateProof(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		for _, params := range []mldsa.Parameters{mldsa.MLDSA44(), mldsa.MLDSA65(), mldsa.MLDSA87()} {
+			t.Run(params.String()+" key", func(t *testing.T) {
+				tc := successCase(t)
+
+				tc.proofOpts.VerificationMethod = getMLDSAVM(t, params)
+
+				testSign(t, tc)
+			})
+		}
+
+		t.Run("ML-DSA-65-Ed25519 key", func(t *testing.T) {
+			tc := successCase(t)
+
+			tc.proofOpts.VerificationMethod = getMLDSAEd25519VM(t)
+
+			testSign(t, tc)
+		})
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		t.Run("compute KMS KID", func(t *testing.T) {
+			tc := successCase(t)
+
+			badKey, vm := getVMWithJWK(t)
+
+			badKey.Key = fooBar
+
+			tc.proofOpts.VerificationMethod = vm
+			tc.errStr = "computing thumbprint for kms kid"
+
+			testSign(t, tc)
+		})
+
+		t.Run("kms key handle error", func(t *testing.T) {
+			tc := successCase(t)
+
+			errExpected := errors.New("expected error")
+
+			tc.kms.GetKeyErr = errExpected
+			tc.errIs = errExpected
+
+			testSign(t, tc)
+		})
+
+		t.Run("crypto sign error", func(t *testing.T) {
+			tc := successCase(t)
+
+			errExpected := errors.New("expected error")
+
+			tc.crypto.SignErr = errExpected
+			tc.errIs = errExpected
+
+			testSign(t, tc)
+		})
+	})
+}
+
+func TestSuite_VerifyProof(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		t.Run("ML-DSA-44 key", func(t *testing.T) {
+			tc := successCase(t)
+
+			tc.verifier = &mockVerifier{}
+
+			testVerify(t, tc)
+		})
+
+		t.Run("ML-DSA-65-Ed25519 key", func(t *testing.T) {
+			tc := successCase(t)
+
+			tc.proofOpts.VerificationMethod = getMLDSAEd25519VM(t)
+			tc.verifier = &mockVerifier{}
+
+			testVerify(t, tc)
+		})
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		t.Run("decode proof signature", func(t *testing.T) {
+			tc := successCase(t)
+
+			tc.proof.ProofValue = "!%^@^@#%&#%#@"
+			tc.errStr = "decoding proofValue"
+
+			testVerify(t, tc)
+		})
+
+		t.Run("crypto verify", func(t *testing.T) {
+			tc := successCase(t)
+
+			errExpected := errors.New("expected error")
+
+			tc.verifier = &mockVerifier{err: errExpected}
+			tc.errIs = errExpected
+
+			testVerify(t, tc)
+		})
+	})
+}
+
+func TestSharedFailures(t *testing.T) {
+	t.Run("unmarshal doc", func(t *testing.T) {
+		tc := successCase(t)
+
+		tc.document = []byte("not JSON!")
+		tc.errStr = "expects JSON-LD payload"
+
+		testSign(t, tc)
+	})
+
+	t.Run("no jwk in vm", func(t *testing.T) {
+		tc := successCase(t)
+
+		tc.proofOpts.VerificationMethod = &did.VerificationMethod{
+			ID:    tc.proofOpts.VerificationMethodID,
+			Value: []byte(fooBar),
+		}
+		tc.errStr = "verification method needs JWK"
+
+		testSign(t, tc)
+	})
+
+	t.Run("unsupported ML-DSA algorithm", func(t *testing.T) {
+		tc := successCase(t)
+
+		badKey, vm := getVMWithJWK(t)
+
+		badKey.Algorithm = fooBar
+
+		tc.proofOpts.VerificationMethod = vm
+		tc.errStr = "unsupported ML-DSA algorithm"
+
+		testVerify(t, tc)
+	})
+
+	t.Run("wrong purpose", func(t *testing.T) {
+		tc := successCase(t)
+
+		tc.proofOpts.Purpose = fooBar
+		tc.errStr = "verification method is not suitable for purpose"
+
+		testSign(t, tc)
+	})
+
+	t.Run("invalid proof/suite type", func(t *testing.T) {
+		tc := successCase(t)
+
+		tc.proofOpts.ProofType = fooBar
+		tc.errIs = suite.ErrProofTransformation
+
+		testSign(t, tc)
+
+		tc.proofOpts.ProofType = models.DataIntegrityProof
+		tc.proofOpts.SuiteType = fooBar
+
+		testSign(t, tc)
+	})
+
+	t.Run("canonicalize doc", func(t *testing.T) {
+		tc := successCase(t)
+
+		tc.document = invalidJSONLD
+		tc.errStr = "canonicalizing signature base data"
+
+		testSign(t, tc)
+	})
+}
+
+func getVMWithJWK(t *testing.T) (*jwk.JWK, *models.VerificationMethod) {
+	t.Helper()
+
+	priv, err := mldsa.GenerateKey(mldsa.MLDSA44())
+	require.NoError(t, err)
+
+	jwkPub, err := jwksupport.JWKFromKey(priv.PublicKey())
+	require.NoError(t, err)
+
+	mockVM, err := did.NewVerificationMethodFromJWK("#key-1", "JsonWebKey2020", "did:foo:bar", jwkPub)
+	require.NoError(t, err)
+
+	return jwkPub, mockVM
+}
+
+func getMLDSAVM(t *testing.T, params mldsa.Parameters) *models.VerificationMethod {
+	t.Helper()
+
+	priv, err := mldsa.GenerateKey(params)
+	require.NoError(t, err)
+
+	return newVM(t, priv.PublicKey())
+}
+
+func getMLDSAEd25519VM(t *testing.T) *models.VerificationMethod {
+	t.Helper()
+
+	priv, err := mldsaed25519.GenerateKey()
+	require.NoError(t, err)
+
+	return newVM(t, priv.PublicKey())
+}
+
+func newVM(t *testing.T, pubKey interface{}) *models.VerificationMethod {
+	t.Helper()
+
+	jwkPub, err := jwksupport.JWKFromKey(pubKey)
+	require.NoError(t, err)
+
+	mockVM, err := did.NewVerificationMethodFromJWK("#key-1", "JsonWebKey2020", "did:foo:bar", jwkPub)
+	require.NoError(t, err)
+
+	return mockVM
+}
+
+type provider struct {
+	ContextStore        store.ContextStore
+	RemoteProviderStore store.RemoteProviderStore
+}
+
+func (p *provider) JSONLDContextStore() store.ContextStore {
+	return p.ContextStore
+}
+
+func (p *provider) JSONLDRemoteProviderStore() store.RemoteProviderStore {
+	return p.RemoteProviderStore
+}
+
+func createMockProvider() *provider {
+	return &provider{
+		ContextStore:        mockldstore.NewMockContextStore(),
+		RemoteProviderStore: mockldstore.NewMockRemoteProviderStore(),
+	}
+}
@@ -11,9 +11,6 @@ import (
 	"errors"
 	"time"
 
-	"github.com/tidwall/gjson"
-	"github.com/tidwall/sjson"
-
 	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/models"
 	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite"
 )
@@ -88,16 +85,60 @@ var (
 // VerifyProof verifies the data integrity proof on the given JSON document,
 // returning an error if proof verification fails, and nil if verification
 // succeeds.
-func (v *Verifier) VerifyProof(doc []byte, opts *models.ProofOptions) error { // nolint:funlen,gocyclo
-	proofRaw := gjson.GetBytes(doc, proofPath)
-
-	if !proofRaw.Exists() {
+//
+// If the document has a proof set, every proof in the set must be valid. Use
+// VerifyProofSet to get the outcome of each proof.
+func (v *Verifier) VerifyProof(doc []byte, opts *models.ProofOptions) error {
+	proofs, ok := proofSet(doc)
+	if !ok {
 		return ErrMissingProof
 	}
 
+	if len(proofs) == 1 {
+		return v.verifyProof(doc, proofs, proofs[0], opts)
+	}
+
+	for _, proofRaw := range proofs {
+		proofOpts := *opts
+
+		err := v.verifyProof(doc, proofs, proofRaw, &proofOpts)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// VerifyProofSet verifies each proof in the proof set of the given JSON
+// document independently, returning one error per proof in document order,
+// where a nil error means the proof is valid. A proof chained to a previous
+// proof is verified together with the previous proof it secures.
+//
+// VerifyProofSet returns ErrMissingProof if the document has no proof.
+func (v *Verifier) VerifyProofSet(doc []byte, opts *models.ProofOptions) ([]error, error) {
+	proofs, ok := proofSet(doc)
+	if !ok {
+		return nil, ErrMissingProof
+	}
+
+	results := make([]error, len(proofs))
+
+	for i, proofRaw := range proofs {
+		proofOpts := *opts
+
+		results[i] = v.verifyProof(doc, proofs, proofRaw, &proofOpts)
+	}
+
+	return results, nil
+}
+
+// nolint:funlen,gocyclo
+func (v *Verifier) verifyProof(doc []byte, proofs []json.RawMessage, proofRaw json.RawMessage,
+	opts *models.ProofOptions) error {
 	proof := &models.Proof{}
 
-	err := json.Unmarshal([]byte(proofRaw.Raw), proof)
+	err := json.Unmarshal(proofRaw, proof)
 	if err != nil {
 		return ErrMalformedProof
 	}
@@ -138,8 +179,10 @@ func (v *Verifier) VerifyProof(doc []byte, opts *models.ProofOptions) error { //
 		return ErrMismatchedPurpose
 	}
 
-	unsecuredDoc, err := sjson.DeleteBytes(doc, proofPath)
-	if err != nil {
+	unsecuredDoc, err := unsecuredDocument(doc, proofs, proof.PreviousProof)
+	if errors.Is(err, ErrPreviousProofNotFound) {
+		return err
+	} else if err != nil {
 		return ErrMalformedProof
 	}
 
@@ -159,6 +159,8 @@ func buildJWKKey(ka *did.Verification) (*crypto.PublicKey, error) {
 		kt = kms.NISTP521ECDHKWType
 	case "X25519":
 		kt = kms.X25519ECDHKWType
+	case "X25519MLKEM768":
+		kt = kms.X25519MLKEM768ECDHKWType
 	}
 
 	mPubKey, err := json.Marshal(pubKey)
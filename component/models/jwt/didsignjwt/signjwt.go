@@ -92,7 +92,7 @@ func (s *defaultSigner) Sign(msg []byte) ([]byte, error) {
 // SignJWT signs a JWT using a key in the given KMS, identified by an owned DID.
 //
 //	Args:
-//		- Headers to include in the created JWT.
+//		- Headers to include in the created JWT, with typ defaulting to JWT.
 //		- Claims for the created JWT.
 //		- The ID of the key to use for signing, as a DID, either with a fragment identifier to specify a verification
 //		  method, or without, in which case the first Authentication or Assertion verification method is used.
@@ -128,7 +128,10 @@ func SignJWT( // nolint: funlen,gocyclo
 		claims = map[string]interface{}{}
 	}
 
-	headers[jose.HeaderType] = "JWT"
+	if _, ok := headers[jose.HeaderType]; !ok {
+		headers[jose.HeaderType] = "JWT"
+	}
+
 	headers[jose.HeaderAlgorithm] = kmssigner.KeyTypeToJWA(keyType)
 	headers["crv"] = crv
 	headers[jose.HeaderKeyID] = vmID
@@ -73,7 +73,8 @@ func CreateVerifyHash(suite signatureSuite, jsonldDoc, proofOptions map[string]i
 
 	proofOptionsDigest := suite.GetDigest(canonicalProofOptions)
 
-	canonicalDoc, err := prepareCanonicalDocument(suite, jsonldDoc, opts...)
+	canonicalDoc, err := prepareCanonicalDocument(suite, jsonldDoc,
+		stringEntry(proofOptions[jsonldPreviousProof]), opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -112,10 +113,13 @@ func prepareCanonicalProofOptions(suite signatureSuite, proofOptions map[string]
 	return suite.GetCanonicalDocument(proofOptionsCopy, opts...)
 }
 
-func prepareCanonicalDocument(suite signatureSuite, jsonldObject map[string]interface{},
+func prepareCanonicalDocument(suite signatureSuite, jsonldObject map[string]interface{}, previousProof string,
 	opts ...processor.Opts) ([]byte, error) {
-	// copy document object without proof
-	docCopy := GetCopyWithoutProof(jsonldObject)
+	// copy document object without proof, keeping the previous proof if the proof is chained
+	docCopy, err := GetCopyWithPreviousProof(jsonldObject, previousProof)
+	if err != nil {
+		return nil, err
+	}
 
 	// build canonical document
 	return suite.GetCanonicalDocument(docCopy, opts...)
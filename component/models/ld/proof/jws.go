@@ -77,7 +77,7 @@ func createVerifyJWS(suite signatureSuite, jsonldDoc map[string]interface{}, p *
 
 	proofOptionsDigest := suite.GetDigest(canonicalProofOptions)
 
-	canonicalDoc, err := prepareDocumentForJWS(suite, jsonldDoc, opts...)
+	canonicalDoc, err := prepareDocumentForJWS(suite, jsonldDoc, p.PreviousProof, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -110,18 +110,19 @@ func prepareJWSProof(suite signatureSuite, proofOptions map[string]interface{},
 	return suite.GetCanonicalDocument(proofOptionsCopy, opts...)
 }
 
-func prepareDocumentForJWS(suite signatureSuite, jsonldObject map[string]interface{},
+func prepareDocumentForJWS(suite signatureSuite, jsonldObject map[string]interface{}, previousProof string,
 	opts ...processor.Opts) ([]byte, error) {
-	// copy document object without proof
-	doc := GetCopyWithoutProof(jsonldObject)
+	// copy document object without proof, keeping the previous proof if the proof is chained
+	doc, err := GetCopyWithPreviousProof(jsonldObject, previousProof)
+	if err != nil {
+		return nil, err
+	}
 
 	if suite.CompactProof() {
-		docCompacted, err := getCompactedWithSecuritySchema(doc, opts...)
+		doc, err = getCompactedWithSecuritySchema(doc, opts...)
 		if err != nil {
 			return nil, err
 		}
-
-		doc = docCompacted
 	}
 
 	// build canonical document
@@ -38,12 +38,17 @@ const (
 	jsonldChallenge = "challenge"
 	// jsonldCapabilityChain is a key for capabilityChain.
 	jsonldCapabilityChain = "capabilityChain"
+	// jsonldID is a key for proof ID.
+	jsonldID = "id"
+	// jsonldPreviousProof is a key for the ID of the proof this proof is chained to.
+	jsonldPreviousProof = "previousProof"
 
 	ed25519Signature2020 = "Ed25519Signature2020"
 )
 
 // Proof is cryptographic proof of the integrity of the DID Document.
 type Proof struct {
+	ID                      string
 	Type                    string
 	Created                 *afgotime.TimeWrapper
 	Creator                 string
@@ -57,6 +62,9 @@ type Proof struct {
 	SignatureRepresentation SignatureRepresentation
 	// CapabilityChain must be an array. Each element is either a string or an object.
 	CapabilityChain []interface{}
+	// PreviousProof is the ID of an earlier proof of the same document which this proof
+	// is chained to. The earlier proof is included in the signed data.
+	PreviousProof string
 }
 
 // NewProof creates new proof.
@@ -101,6 +109,7 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 	}
 
 	return &Proof{
+		ID:                      stringEntry(emap[jsonldID]),
 		Type:                    stringEntry(emap[jsonldType]),
 		Created:                 timeValue,
 		Creator:                 stringEntry(emap[jsonldCreator]),
@@ -113,6 +122,7 @@ func NewProof(emap map[string]interface{}) (*Proof, error) {
 		Nonce:                   nonce,
 		Challenge:               stringEntry(emap[jsonldChallenge]),
 		CapabilityChain:         capabilityChain,
+		PreviousProof:           stringEntry(emap[jsonldPreviousProof]),
 	}, nil
 }
 
@@ -179,6 +189,10 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 	emap := make(map[string]interface{})
 	emap[jsonldType] = p.Type
 
+	if p.ID != "" {
+		emap[jsonldID] = p.ID
+	}
+
 	if p.Creator != "" {
 		emap[jsonldCreator] = p.Creator
 	}
@@ -219,6 +233,10 @@ func (p *Proof) JSONLdObject() map[string]interface{} { // nolint:gocyclo
 		emap[jsonldCapabilityChain] = p.CapabilityChain
 	}
 
+	if p.PreviousProof != "" {
+		emap[jsonldPreviousProof] = p.PreviousProof
+	}
+
 	return emap
 }
 
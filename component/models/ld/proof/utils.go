@@ -87,5 +87,40 @@ func GetCopyWithoutProof(jsonLdObject map[string]interface{}) map[string]interfa
 	return dest
 }
 
+// GetCopyWithPreviousProof gets copy of JSON LD Object without proofs, except for the proof with
+// the given ID, which is kept as the only proof of the copy. It is used to build the data signed by
+// a proof which is chained to an earlier one. If previousProof is empty, no proof is kept.
+func GetCopyWithPreviousProof(jsonLdObject map[string]interface{}, previousProof string) (map[string]interface{}, error) {
+	dest := GetCopyWithoutProof(jsonLdObject)
+
+	if previousProof == "" {
+		return dest, nil
+	}
+
+	var proofs []interface{}
+
+	switch p := jsonLdObject[jsonldProof].(type) {
+	case []interface{}:
+		proofs = p
+	case map[string]interface{}:
+		proofs = []interface{}{p}
+	}
+
+	for _, p := range proofs {
+		proofMap, ok := p.(map[string]interface{})
+		if ok && stringEntry(proofMap[jsonldID]) == previousProof {
+			dest[jsonldProof] = proofMap
+
+			return dest, nil
+		}
+	}
+
+	return nil, ErrPreviousProofNotFound
+}
+
 // ErrProofNotFound is returned when proof is not found.
 var ErrProofNotFound = errors.New("proof not found")
+
+// ErrPreviousProofNotFound is returned when a proof references a previous proof which is not
+// present in the document.
+var ErrPreviousProofNotFound = errors.New("previous proof not found")
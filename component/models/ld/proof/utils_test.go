@@ -67,6 +67,52 @@ func TestGetCopyWithoutProof(t *testing.T) {
 	require.True(t, reflect.DeepEqual(docCopy, getDefaultDoc()))
 }
 
+func TestGetCopyWithPreviousProof(t *testing.T) {
+	proof1 := map[string]interface{}{
+		"id":         "urn:uuid:proof-1",
+		"type":       "Ed25519Signature2018",
+		"created":    "2011-09-23T20:21:34Z",
+		"proofValue": "ABC",
+	}
+	proof2 := map[string]interface{}{
+		"id":            "urn:uuid:proof-2",
+		"type":          "Ed25519Signature2018",
+		"created":       "2011-09-23T20:21:34Z",
+		"proofValue":    "DEF",
+		"previousProof": "urn:uuid:proof-1",
+	}
+
+	doc := map[string]interface{}{
+		"test":  "test",
+		"proof": []interface{}{proof1, proof2},
+	}
+
+	t.Run("no previous proof", func(t *testing.T) {
+		docCopy, err := GetCopyWithPreviousProof(doc, "")
+		require.NoError(t, err)
+		require.Equal(t, GetCopyWithoutProof(doc), docCopy)
+	})
+
+	t.Run("previous proof is kept", func(t *testing.T) {
+		docCopy, err := GetCopyWithPreviousProof(doc, "urn:uuid:proof-1")
+		require.NoError(t, err)
+		require.Equal(t, proof1, docCopy["proof"])
+		require.Equal(t, "test", docCopy["test"])
+
+		proofs, err := GetProofs(doc)
+		require.NoError(t, err)
+		require.Len(t, proofs, 2)
+		require.Equal(t, "urn:uuid:proof-2", proofs[1].ID)
+		require.Equal(t, "urn:uuid:proof-1", proofs[1].PreviousProof)
+	})
+
+	t.Run("previous proof is missing", func(t *testing.T) {
+		docCopy, err := GetCopyWithPreviousProof(doc, "urn:uuid:proof-3")
+		require.ErrorIs(t, err, ErrPreviousProofNotFound)
+		require.Nil(t, docCopy)
+	})
+}
+
 func TestAddSingleProof(t *testing.T) {
 	doc := map[string]interface{}{
 		"test": "test",
@@ -0,0 +1,448 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mdoc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// COSE algorithm identifiers, https://www.iana.org/assignments/cose/cose.xhtml#algorithms.
+const (
+	AlgES256 = -7
+	AlgES384 = -35
+	AlgES512 = -36
+	AlgEdDSA = -8
+)
+
+const (
+	headerAlgorithm = 1
+	headerX5Chain   = 33
+
+	tagSign1 = 18
+
+	sign1Context = "Signature1"
+
+	keyTypeOKP = 1
+	keyTypeEC2 = 2
+
+	curveP256    = 1
+	curveP384    = 2
+	curveP521    = 3
+	curveEd25519 = 6
+)
+
+// Sign1 is a COSE_Sign1 structure, https://www.rfc-editor.org/rfc/rfc9052#section-4.2.
+type Sign1 struct {
+	_ struct{} `cbor:",toarray"`
+	// Protected is the encoded map of protected headers.
+	Protected []byte
+	// Unprotected holds the unprotected headers.
+	Unprotected map[int]interface{}
+	// Payload is nil if the payload is detached.
+	Payload   []byte
+	Signature []byte
+}
+
+// UnmarshalCBOR decodes a COSE_Sign1 structure, tagged or not.
+func (s *Sign1) UnmarshalCBOR(data []byte) error {
+	type rawSign1 Sign1
+
+	var tag cbor.RawTag
+
+	if decMode.Unmarshal(data, &tag) == nil {
+		if tag.Number != tagSign1 {
+			return fmt.Errorf("decode COSE_Sign1: unexpected tag %d", tag.Number)
+		}
+
+		data = tag.Content
+	}
+
+	return decMode.Unmarshal(data, (*rawSign1)(s))
+}
+
+// Algorithm returns the COSE algorithm of the signature, from the protected headers.
+func (s *Sign1) Algorithm() (int, error) {
+	headers, err := s.ProtectedHeaders()
+	if err != nil {
+		return 0, err
+	}
+
+	switch alg := headers[headerAlgorithm].(type) {
+	case int64:
+		return int(alg), nil
+	case uint64:
+		return int(alg), nil
+	default:
+		return 0, errors.New("algorithm is missing from protected headers")
+	}
+}
+
+// X5Chain returns the X.509 certificate chain of the unprotected x5chain header, leaf certificate first.
+func (s *Sign1) X5Chain() ([]*x509.Certificate, error) {
+	var rawCerts [][]byte
+
+	switch chain := s.Unprotected[headerX5Chain].(type) {
+	case []byte:
+		rawCerts = [][]byte{chain}
+	case [][]byte:
+		rawCerts = chain
+	case []interface{}:
+		for _, c := range chain {
+			cert, ok := c.([]byte)
+			if !ok {
+				return nil, errors.New("x5chain header holds an invalid certificate")
+			}
+
+			rawCerts = append(rawCerts, cert)
+		}
+	default:
+		return nil, errors.New("x5chain header is missing")
+	}
+
+	certs := make([]*x509.Certificate, len(rawCerts))
+
+	for i, rawCert := range rawCerts {
+		cert, err := x509.ParseCertificate(rawCert)
+		if err != nil {
+			return nil, fmt.Errorf("parse x5chain certificate: %w", err)
+		}
+
+		certs[i] = cert
+	}
+
+	return certs, nil
+}
+
+// NewSign1 creates a COSE_Sign1 signature over the payload, with the algorithm of the signer key.
+// The algorithm is added to the protected headers.
+func NewSign1(signer crypto.Signer, protected, unprotected map[int]interface{}, payload []byte) (*Sign1, error) {
+	alg, err := AlgorithmForKey(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	return signWithHeaders(signer, alg, protected, unprotected, payload, false)
+}
+
+// Verify checks the COSE_Sign1 signature with the public key.
+func (s *Sign1) Verify(pubKey crypto.PublicKey) error {
+	return s.verify(pubKey, nil)
+}
+
+// ProtectedHeaders decodes the protected headers.
+func (s *Sign1) ProtectedHeaders() (map[int]interface{}, error) {
+	headers := map[int]interface{}{}
+
+	if len(s.Protected) > 0 {
+		if err := decMode.Unmarshal(s.Protected, &headers); err != nil {
+			return nil, fmt.Errorf("decode protected headers: %w", err)
+		}
+	}
+
+	return headers, nil
+}
+
+// sign creates a COSE_Sign1 signature over the payload. If detached is true, the payload is not included.
+func sign(signer crypto.Signer, alg int, unprotected map[int]interface{}, payload []byte,
+	detached bool) (*Sign1, error) {
+	return signWithHeaders(signer, alg, nil, unprotected, payload, detached)
+}
+
+func signWithHeaders(signer crypto.Signer, alg int, protectedHeaders, unprotected map[int]interface{},
+	payload []byte, detached bool) (*Sign1, error) {
+	headers := map[int]interface{}{headerAlgorithm: alg}
+
+	for label, value := range protectedHeaders {
+		if label != headerAlgorithm {
+			headers[label] = value
+		}
+	}
+
+	protected, err := encMode.Marshal(headers)
+	if err != nil {
+		return nil, fmt.Errorf("encode protected headers: %w", err)
+	}
+
+	if unprotected == nil {
+		unprotected = map[int]interface{}{}
+	}
+
+	s := &Sign1{
+		Protected:   protected,
+		Unprotected: unprotected,
+		Payload:     payload,
+	}
+
+	toBeSigned, err := s.toBeSigned(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Signature, err = signBytes(signer, alg, toBeSigned)
+	if err != nil {
+		return nil, fmt.Errorf("sign COSE_Sign1: %w", err)
+	}
+
+	if detached {
+		s.Payload = nil
+	}
+
+	return s, nil
+}
+
+// verify checks the COSE_Sign1 signature with the public key. The detached payload is used
+// if the COSE_Sign1 has no payload.
+func (s *Sign1) verify(pubKey crypto.PublicKey, detachedPayload []byte) error {
+	alg, err := s.Algorithm()
+	if err != nil {
+		return err
+	}
+
+	payload := s.Payload
+	if payload == nil {
+		payload = detachedPayload
+	}
+
+	toBeSigned, err := s.toBeSigned(payload)
+	if err != nil {
+		return err
+	}
+
+	return verifyBytes(pubKey, alg, toBeSigned, s.Signature)
+}
+
+func (s *Sign1) toBeSigned(payload []byte) ([]byte, error) {
+	protected := s.Protected
+	if protected == nil {
+		protected = []byte{}
+	}
+
+	sigStructure, err := encMode.Marshal([]interface{}{sign1Context, protected, []byte{}, payload})
+	if err != nil {
+		return nil, fmt.Errorf("encode COSE Sig_structure: %w", err)
+	}
+
+	return sigStructure, nil
+}
+
+func hashForAlg(alg int) (crypto.Hash, error) {
+	switch alg {
+	case AlgES256:
+		return crypto.SHA256, nil
+	case AlgES384:
+		return crypto.SHA384, nil
+	case AlgES512:
+		return crypto.SHA512, nil
+	case AlgEdDSA:
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("unsupported COSE algorithm %d", alg)
+	}
+}
+
+// AlgorithmName returns the name of the COSE algorithm, as used in JOSE and in presentation exchange
+// mso_mdoc formats, or an empty string if the algorithm is not supported.
+func AlgorithmName(alg int) string {
+	switch alg {
+	case AlgES256:
+		return "ES256"
+	case AlgES384:
+		return "ES384"
+	case AlgES512:
+		return "ES512"
+	case AlgEdDSA:
+		return "EdDSA"
+	default:
+		return ""
+	}
+}
+
+// AlgorithmForKey returns the COSE algorithm used to sign with the public key.
+func AlgorithmForKey(pubKey crypto.PublicKey) (int, error) {
+	switch key := pubKey.(type) {
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return AlgES256, nil
+		case elliptic.P384():
+			return AlgES384, nil
+		case elliptic.P521():
+			return AlgES512, nil
+		}
+	case ed25519.PublicKey:
+		return AlgEdDSA, nil
+	}
+
+	return 0, fmt.Errorf("unsupported key type %T", pubKey)
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+func signBytes(signer crypto.Signer, alg int, data []byte) ([]byte, error) {
+	hashAlg, err := hashForAlg(alg)
+	if err != nil {
+		return nil, err
+	}
+
+	if alg == AlgEdDSA {
+		return signer.Sign(rand.Reader, data, crypto.Hash(0))
+	}
+
+	h := hashAlg.New()
+	h.Write(data)
+
+	der, err := signer.Sign(rand.Reader, h.Sum(nil), hashAlg)
+	if err != nil {
+		return nil, err
+	}
+
+	// COSE ECDSA signatures are the concatenation of R and S, each of the size of the curve.
+	var sig ecdsaSignature
+
+	if _, err = asn1.Unmarshal(der, &sig); err != nil {
+		return nil, fmt.Errorf("decode ECDSA signature: %w", err)
+	}
+
+	pubKey, ok := signer.Public().(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("signer is not an ECDSA signer")
+	}
+
+	size := (pubKey.Curve.Params().BitSize + 7) / 8 // nolint:gomnd
+
+	return append(sig.R.FillBytes(make([]byte, size)), sig.S.FillBytes(make([]byte, size))...), nil
+}
+
+func verifyBytes(pubKey crypto.PublicKey, alg int, data, signature []byte) error {
+	hashAlg, err := hashForAlg(alg)
+	if err != nil {
+		return err
+	}
+
+	switch key := pubKey.(type) {
+	case ed25519.PublicKey:
+		if alg != AlgEdDSA || !ed25519.Verify(key, data, signature) {
+			return ErrInvalidSignature
+		}
+
+		return nil
+	case *ecdsa.PublicKey:
+		keyAlg, e := AlgorithmForKey(key)
+		if e != nil {
+			return fmt.Errorf("unsupported curve %s", key.Curve.Params().Name)
+		}
+
+		// the algorithm is bound to the curve, whose size sets the size of R and S.
+		size := (key.Curve.Params().BitSize + 7) / 8 // nolint:gomnd
+
+		if alg != keyAlg || len(signature) != 2*size {
+			return ErrInvalidSignature
+		}
+
+		h := hashAlg.New()
+		h.Write(data)
+
+		r := new(big.Int).SetBytes(signature[:len(signature)/2])
+		s := new(big.Int).SetBytes(signature[len(signature)/2:])
+
+		if !ecdsa.Verify(key, h.Sum(nil), r, s) {
+			return ErrInvalidSignature
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unsupported key type %T", pubKey)
+	}
+}
+
+// COSEKey is a public key encoded as COSE_Key, https://www.rfc-editor.org/rfc/rfc9052#section-7.
+type COSEKey struct {
+	KeyType int    `cbor:"1,keyasint"`
+	Curve   int    `cbor:"-1,keyasint"`
+	X       []byte `cbor:"-2,keyasint"`
+	Y       []byte `cbor:"-3,keyasint,omitempty"`
+}
+
+// NewCOSEKey encodes an ECDSA (P-256, P-384 or P-521) or Ed25519 public key as COSE_Key.
+func NewCOSEKey(pubKey crypto.PublicKey) (*COSEKey, error) {
+	switch key := pubKey.(type) {
+	case ed25519.PublicKey:
+		return &COSEKey{KeyType: keyTypeOKP, Curve: curveEd25519, X: key}, nil
+	case *ecdsa.PublicKey:
+		var curve int
+
+		switch key.Curve {
+		case elliptic.P256():
+			curve = curveP256
+		case elliptic.P384():
+			curve = curveP384
+		case elliptic.P521():
+			curve = curveP521
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", key.Curve.Params().Name)
+		}
+
+		size := (key.Curve.Params().BitSize + 7) / 8 // nolint:gomnd
+
+		return &COSEKey{
+			KeyType: keyTypeEC2,
+			Curve:   curve,
+			X:       key.X.FillBytes(make([]byte, size)),
+			Y:       key.Y.FillBytes(make([]byte, size)),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", pubKey)
+	}
+}
+
+// PublicKey decodes the COSE_Key.
+func (k *COSEKey) PublicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case keyTypeOKP:
+		if k.Curve != curveEd25519 || len(k.X) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid OKP COSE key")
+		}
+
+		return ed25519.PublicKey(k.X), nil
+	case keyTypeEC2:
+		var curve elliptic.Curve
+
+		switch k.Curve {
+		case curveP256:
+			curve = elliptic.P256()
+		case curveP384:
+			curve = elliptic.P384()
+		case curveP521:
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported EC2 COSE key curve %d", k.Curve)
+		}
+
+		pubKey := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(k.X), Y: new(big.Int).SetBytes(k.Y)}
+
+		if !curve.IsOnCurve(pubKey.X, pubKey.Y) {
+			return nil, errors.New("invalid EC2 COSE key: point is not on curve")
+		}
+
+		return pubKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported COSE key type %d", k.KeyType)
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mdoc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCOSEKey(t *testing.T) {
+	for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P384(), elliptic.P521()} {
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		require.NoError(t, err)
+
+		coseKey, err := NewCOSEKey(&key.PublicKey)
+		require.NoError(t, err)
+
+		pubKey, err := coseKey.PublicKey()
+		require.NoError(t, err)
+		require.True(t, key.PublicKey.Equal(pubKey))
+	}
+
+	edPubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	coseKey, err := NewCOSEKey(edPubKey)
+	require.NoError(t, err)
+
+	pubKey, err := coseKey.PublicKey()
+	require.NoError(t, err)
+	require.Equal(t, edPubKey, pubKey)
+
+	t.Run("error - invalid keys", func(t *testing.T) {
+		_, err := (&COSEKey{KeyType: keyTypeOKP, Curve: curveEd25519, X: []byte{1}}).PublicKey()
+		require.EqualError(t, err, "invalid OKP COSE key")
+
+		_, err = (&COSEKey{KeyType: keyTypeEC2, Curve: 42}).PublicKey()
+		require.EqualError(t, err, "unsupported EC2 COSE key curve 42")
+
+		_, err = (&COSEKey{KeyType: keyTypeEC2, Curve: curveP256, X: []byte{1}, Y: []byte{2}}).PublicKey()
+		require.EqualError(t, err, "invalid EC2 COSE key: point is not on curve")
+
+		_, err = (&COSEKey{KeyType: 4}).PublicKey()
+		require.EqualError(t, err, "unsupported COSE key type 4")
+
+		_, err = NewCOSEKey("key")
+		require.EqualError(t, err, "unsupported key type string")
+	})
+}
+
+func TestSign1(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	s, err := sign(key, AlgES384, nil, []byte("payload"), false)
+	require.NoError(t, err)
+
+	t.Run("decode tagged COSE_Sign1", func(t *testing.T) {
+		untagged, err := encMode.Marshal(s)
+		require.NoError(t, err)
+
+		tagged, err := encMode.Marshal(cbor.RawTag{Number: tagSign1, Content: untagged})
+		require.NoError(t, err)
+
+		decoded := &Sign1{}
+		require.NoError(t, decMode.Unmarshal(tagged, decoded))
+		require.NoError(t, decoded.verify(&key.PublicKey, nil))
+
+		wrongTag, err := encMode.Marshal(cbor.RawTag{Number: 98, Content: untagged})
+		require.NoError(t, err)
+
+		require.EqualError(t, decMode.Unmarshal(wrongTag, decoded), "decode COSE_Sign1: unexpected tag 98")
+	})
+
+	t.Run("detached payload", func(t *testing.T) {
+		detached, err := sign(key, AlgES384, nil, []byte("payload"), true)
+		require.NoError(t, err)
+		require.Nil(t, detached.Payload)
+
+		require.NoError(t, detached.verify(&key.PublicKey, []byte("payload")))
+		require.ErrorIs(t, detached.verify(&key.PublicKey, []byte("other")), ErrInvalidSignature)
+	})
+
+	t.Run("error - algorithm mismatch", func(t *testing.T) {
+		edPubKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, s.verify(edPubKey, nil), ErrInvalidSignature)
+	})
+
+	t.Run("error - algorithm not matching the curve", func(t *testing.T) {
+		for _, curve := range []elliptic.Curve{elliptic.P256(), elliptic.P521()} {
+			otherKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+			require.NoError(t, err)
+
+			otherAlg, err := AlgorithmForKey(&otherKey.PublicKey)
+			require.NoError(t, err)
+
+			// an ES384 signature of a P-384 key announced with the algorithm of another curve.
+			mismatched, err := sign(key, otherAlg, nil, []byte("payload"), false)
+			require.NoError(t, err)
+			require.ErrorIs(t, mismatched.verify(&key.PublicKey, nil), ErrInvalidSignature)
+
+			// an ES384 signature verified with the key of another curve.
+			require.ErrorIs(t, s.verify(&otherKey.PublicKey, nil), ErrInvalidSignature)
+		}
+	})
+
+	t.Run("error - signature size not matching the curve", func(t *testing.T) {
+		pubKey := &key.PublicKey
+
+		toBeSigned, err := s.toBeSigned(s.Payload)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, verifyBytes(pubKey, AlgES384, toBeSigned, s.Signature[1:]), ErrInvalidSignature)
+
+		// R and S padded to a larger size.
+		size := len(s.Signature) / 2
+		padded := append(append([]byte{0}, s.Signature[:size]...), append([]byte{0}, s.Signature[size:]...)...)
+		require.ErrorIs(t, verifyBytes(pubKey, AlgES384, toBeSigned, padded), ErrInvalidSignature)
+	})
+
+	t.Run("error - unsupported curve", func(t *testing.T) {
+		otherKey, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+		require.NoError(t, err)
+
+		require.EqualError(t, s.verify(&otherKey.PublicKey, nil), "unsupported curve P-224")
+	})
+
+	t.Run("error - algorithm is missing", func(t *testing.T) {
+		_, err := (&Sign1{}).Algorithm()
+		require.EqualError(t, err, "algorithm is missing from protected headers")
+	})
+
+	t.Run("error - unsupported algorithm", func(t *testing.T) {
+		_, err := sign(key, -257, nil, []byte("payload"), false)
+		require.ErrorContains(t, err, "unsupported COSE algorithm -257")
+	})
+
+	t.Run("NewSign1 with protected headers", func(t *testing.T) {
+		_, edPrivKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		s, err := NewSign1(edPrivKey, map[int]interface{}{16: "application/example", headerAlgorithm: AlgES256},
+			nil, []byte("payload"))
+		require.NoError(t, err)
+		require.NoError(t, s.Verify(edPrivKey.Public()))
+
+		headers, err := s.ProtectedHeaders()
+		require.NoError(t, err)
+		require.Equal(t, "application/example", headers[16])
+		require.EqualValues(t, AlgEdDSA, headers[headerAlgorithm])
+
+		_, err = NewSign1(&unsupportedSigner{}, nil, nil, []byte("payload"))
+		require.EqualError(t, err, "unsupported key type <nil>")
+
+		_, err = (&Sign1{Protected: []byte{0xff}}).ProtectedHeaders()
+		require.ErrorContains(t, err, "decode protected headers")
+	})
+}
+
+type unsupportedSigner struct {
+	crypto.Signer
+}
+
+func (s *unsupportedSigner) Public() crypto.PublicKey {
+	return nil
+}
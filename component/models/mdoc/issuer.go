@@ -0,0 +1,221 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mdoc
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	randomSize = 16
+
+	defaultValidity = 365 * 24 * time.Hour
+)
+
+type issueOpts struct {
+	digestAlgorithm string
+	signed          time.Time
+	validFrom       time.Time
+	validUntil      time.Time
+	expectedUpdate  *time.Time
+	randomFnc       func() ([]byte, error)
+}
+
+// IssueOpt is an option for Issue.
+type IssueOpt func(opts *issueOpts)
+
+// WithDigestAlgorithm sets the digest algorithm of the MSO. SHA-256 is used by default.
+func WithDigestAlgorithm(digestAlgorithm string) IssueOpt {
+	return func(opts *issueOpts) {
+		opts.digestAlgorithm = digestAlgorithm
+	}
+}
+
+// WithValidity sets the validity period of the MSO. By default, the MSO is valid for a year from now.
+func WithValidity(validFrom, validUntil time.Time) IssueOpt {
+	return func(opts *issueOpts) {
+		opts.validFrom = validFrom
+		opts.validUntil = validUntil
+	}
+}
+
+// WithExpectedUpdate sets the time at which the issuer expects to update the MSO.
+func WithExpectedUpdate(expectedUpdate time.Time) IssueOpt {
+	return func(opts *issueOpts) {
+		opts.expectedUpdate = &expectedUpdate
+	}
+}
+
+// WithRandomFnc sets the function creating the random salt of each IssuerSignedItem.
+func WithRandomFnc(fnc func() ([]byte, error)) IssueOpt {
+	return func(opts *issueOpts) {
+		opts.randomFnc = fnc
+	}
+}
+
+// Issue creates a mdoc of the given document type, holding the data elements of nameSpaces, bound to the device key
+// of the holder. The MSO is signed by the issuer signer, whose X.509 certificate chain (leaf certificate first)
+// is included into the issuer signature.
+func Issue(docType string, nameSpaces map[string]map[string]interface{}, deviceKey crypto.PublicKey,
+	signer crypto.Signer, certChain []*x509.Certificate, opts ...IssueOpt) (*Document, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+
+	iOpts := &issueOpts{
+		digestAlgorithm: DigestAlgorithmSHA256,
+		signed:          now,
+		validFrom:       now,
+		validUntil:      now.Add(defaultValidity),
+		randomFnc:       generateRandom,
+	}
+
+	for _, opt := range opts {
+		opt(iOpts)
+	}
+
+	if len(certChain) == 0 {
+		return nil, errors.New("issuer certificate chain is empty")
+	}
+
+	deviceCOSEKey, err := NewCOSEKey(deviceKey)
+	if err != nil {
+		return nil, fmt.Errorf("encode device key: %w", err)
+	}
+
+	issuerNameSpaces, valueDigests, err := createIssuerNameSpaces(nameSpaces, iOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	mso := &MobileSecurityObject{
+		Version:         Version,
+		DigestAlgorithm: iOpts.digestAlgorithm,
+		ValueDigests:    valueDigests,
+		DeviceKeyInfo:   DeviceKeyInfo{DeviceKey: deviceCOSEKey},
+		DocType:         docType,
+		ValidityInfo: ValidityInfo{
+			Signed:         iOpts.signed.UTC().Truncate(time.Second),
+			ValidFrom:      iOpts.validFrom.UTC().Truncate(time.Second),
+			ValidUntil:     iOpts.validUntil.UTC().Truncate(time.Second),
+			ExpectedUpdate: iOpts.expectedUpdate,
+		},
+	}
+
+	issuerAuth, err := signMSO(mso, signer, certChain)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Document{
+		DocType: docType,
+		IssuerSigned: IssuerSigned{
+			NameSpaces: issuerNameSpaces,
+			IssuerAuth: issuerAuth,
+		},
+	}, nil
+}
+
+func createIssuerNameSpaces(nameSpaces map[string]map[string]interface{},
+	iOpts *issueOpts) (map[string][]EncodedCBOR, map[string]map[uint][]byte, error) {
+	issuerNameSpaces := make(map[string][]EncodedCBOR, len(nameSpaces))
+	valueDigests := make(map[string]map[uint][]byte, len(nameSpaces))
+
+	var digestID uint
+
+	for nameSpace, elements := range nameSpaces {
+		valueDigests[nameSpace] = make(map[uint][]byte, len(elements))
+
+		// sort element identifiers, so that digest IDs are stable
+		identifiers := make([]string, 0, len(elements))
+		for identifier := range elements {
+			identifiers = append(identifiers, identifier)
+		}
+
+		sort.Strings(identifiers)
+
+		for _, identifier := range identifiers {
+			random, err := iOpts.randomFnc()
+			if err != nil {
+				return nil, nil, fmt.Errorf("generate random: %w", err)
+			}
+
+			item, err := newEncodedCBOR(&IssuerSignedItem{
+				DigestID:          digestID,
+				Random:            random,
+				ElementIdentifier: identifier,
+				ElementValue:      elements[identifier],
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("encode issuer signed item %s: %w", identifier, err)
+			}
+
+			itemDigest, err := digest(iOpts.digestAlgorithm, item)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			issuerNameSpaces[nameSpace] = append(issuerNameSpaces[nameSpace], item)
+			valueDigests[nameSpace][digestID] = itemDigest
+
+			digestID++
+		}
+	}
+
+	return issuerNameSpaces, valueDigests, nil
+}
+
+func signMSO(mso *MobileSecurityObject, signer crypto.Signer, certChain []*x509.Certificate) (*Sign1, error) {
+	encodedMSO, err := newEncodedCBOR(mso)
+	if err != nil {
+		return nil, fmt.Errorf("encode MSO: %w", err)
+	}
+
+	payload, err := encodedMSO.MarshalCBOR()
+	if err != nil {
+		return nil, fmt.Errorf("encode MSO: %w", err)
+	}
+
+	alg, err := AlgorithmForKey(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	var x5chain interface{}
+
+	if len(certChain) == 1 {
+		x5chain = certChain[0].Raw
+	} else {
+		rawCerts := make([][]byte, len(certChain))
+		for i, cert := range certChain {
+			rawCerts[i] = cert.Raw
+		}
+
+		x5chain = rawCerts
+	}
+
+	issuerAuth, err := sign(signer, alg, map[int]interface{}{headerX5Chain: x5chain}, payload, false)
+	if err != nil {
+		return nil, fmt.Errorf("sign MSO: %w", err)
+	}
+
+	return issuerAuth, nil
+}
+
+func generateRandom() ([]byte, error) {
+	random := make([]byte, randomSize)
+
+	if _, err := rand.Read(random); err != nil {
+		return nil, err
+	}
+
+	return random, nil
+}
@@ -0,0 +1,323 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package mdoc implements the ISO/IEC 18013-5 mobile document (mdoc) credential format, used for
+// mobile driving licences (mDL) and other government issued documents.
+//
+// An mdoc is issued as IssuerSigned data: the data elements of the document, grouped by name space,
+// each one salted and encoded as an IssuerSignedItem, and a COSE_Sign1 issuer signature over
+// the Mobile Security Object (MSO), which holds a digest of every IssuerSignedItem and the device key
+// of the holder. The holder discloses a subset of the data elements in a DeviceResponse,
+// signed with the device key over the session transcript.
+package mdoc
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"hash"
+	"reflect"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	// DocTypeMDL is the document type of a mobile driving licence.
+	DocTypeMDL = "org.iso.18013.5.1.mDL"
+	// NameSpaceMDL is the name space of the mobile driving licence data elements.
+	NameSpaceMDL = "org.iso.18013.5.1"
+
+	// Version of the MSO and device response structures.
+	Version = "1.0"
+
+	// DigestAlgorithmSHA256 is the SHA-256 digest algorithm of the MSO.
+	DigestAlgorithmSHA256 = "SHA-256"
+	// DigestAlgorithmSHA384 is the SHA-384 digest algorithm of the MSO.
+	DigestAlgorithmSHA384 = "SHA-384"
+	// DigestAlgorithmSHA512 is the SHA-512 digest algorithm of the MSO.
+	DigestAlgorithmSHA512 = "SHA-512"
+
+	// StatusOK is the status of a device response returned without errors.
+	StatusOK uint = 0
+
+	// tagEncodedCBOR is the tag of a byte string holding embedded CBOR data.
+	tagEncodedCBOR = 24
+)
+
+var (
+	// ErrDigestMismatch is returned when the digest of an IssuerSignedItem does not match its value in the MSO.
+	ErrDigestMismatch = errors.New("digest of issuer signed item does not match the MSO")
+	// ErrInvalidSignature is returned when a COSE_Sign1 signature is invalid.
+	ErrInvalidSignature = errors.New("invalid COSE_Sign1 signature")
+	// ErrInvalidValidity is returned when the MSO is not valid at the time of verification.
+	ErrInvalidValidity = errors.New("MSO is not valid at the time of verification")
+)
+
+// nolint:gochecknoglobals
+var (
+	encMode = mustEncMode()
+	decMode = mustDecMode()
+)
+
+func mustEncMode() cbor.EncMode {
+	mode, err := cbor.EncOptions{
+		Sort:    cbor.SortCoreDeterministic,
+		Time:    cbor.TimeRFC3339,
+		TimeTag: cbor.EncTagRequired,
+	}.EncMode()
+	if err != nil {
+		panic(err)
+	}
+
+	return mode
+}
+
+func mustDecMode() cbor.DecMode {
+	mode, err := cbor.DecOptions{
+		DefaultMapType: reflect.TypeOf(map[string]interface{}{}),
+	}.DecMode()
+	if err != nil {
+		panic(err)
+	}
+
+	return mode
+}
+
+// EncodedCBOR holds CBOR data embedded into a tagged byte string, ie #6.24(bstr .cbor data).
+// The embedded data is kept as is, so that digests and signatures computed over it can be checked.
+type EncodedCBOR []byte
+
+// newEncodedCBOR encodes v as the CBOR data embedded into EncodedCBOR.
+func newEncodedCBOR(v interface{}) (EncodedCBOR, error) {
+	data, err := encMode.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// MarshalCBOR encodes the embedded data as a byte string tagged with 24.
+func (e EncodedCBOR) MarshalCBOR() ([]byte, error) {
+	return encMode.Marshal(cbor.Tag{Number: tagEncodedCBOR, Content: []byte(e)})
+}
+
+// UnmarshalCBOR decodes a byte string tagged with 24.
+func (e *EncodedCBOR) UnmarshalCBOR(data []byte) error {
+	var tag cbor.RawTag
+
+	if err := decMode.Unmarshal(data, &tag); err != nil {
+		return fmt.Errorf("decode encoded CBOR: %w", err)
+	}
+
+	if tag.Number != tagEncodedCBOR {
+		return fmt.Errorf("decode encoded CBOR: unexpected tag %d", tag.Number)
+	}
+
+	var content []byte
+
+	if err := decMode.Unmarshal(tag.Content, &content); err != nil {
+		return fmt.Errorf("decode encoded CBOR: %w", err)
+	}
+
+	*e = content
+
+	return nil
+}
+
+// decode decodes the embedded data into v.
+func (e EncodedCBOR) decode(v interface{}) error {
+	return decMode.Unmarshal(e, v)
+}
+
+// IssuerSignedItem is a single data element of the document, salted with random bytes.
+type IssuerSignedItem struct {
+	DigestID          uint        `cbor:"digestID"`
+	Random            []byte      `cbor:"random"`
+	ElementIdentifier string      `cbor:"elementIdentifier"`
+	ElementValue      interface{} `cbor:"elementValue"`
+}
+
+// IssuerSigned holds the data elements of a document, grouped by name space, and the issuer signature over them.
+type IssuerSigned struct {
+	// NameSpaces maps a name space to its IssuerSignedItems, each one kept in its encoded form.
+	NameSpaces map[string][]EncodedCBOR `cbor:"nameSpaces,omitempty"`
+	// IssuerAuth is the COSE_Sign1 signature of the issuer over the MSO.
+	IssuerAuth *Sign1 `cbor:"issuerAuth"`
+}
+
+// MobileSecurityObject (MSO) holds the digests of the IssuerSignedItems and the device key of the holder.
+type MobileSecurityObject struct {
+	Version         string                     `cbor:"version"`
+	DigestAlgorithm string                     `cbor:"digestAlgorithm"`
+	ValueDigests    map[string]map[uint][]byte `cbor:"valueDigests"`
+	DeviceKeyInfo   DeviceKeyInfo              `cbor:"deviceKeyInfo"`
+	DocType         string                     `cbor:"docType"`
+	ValidityInfo    ValidityInfo               `cbor:"validityInfo"`
+}
+
+// DeviceKeyInfo holds the device key of the holder, as COSE_Key.
+type DeviceKeyInfo struct {
+	DeviceKey *COSEKey `cbor:"deviceKey"`
+}
+
+// ValidityInfo holds the validity period of the MSO.
+type ValidityInfo struct {
+	Signed         time.Time  `cbor:"signed"`
+	ValidFrom      time.Time  `cbor:"validFrom"`
+	ValidUntil     time.Time  `cbor:"validUntil"`
+	ExpectedUpdate *time.Time `cbor:"expectedUpdate,omitempty"`
+}
+
+// Document is a single mdoc, as issued to the holder or as presented in a DeviceResponse.
+type Document struct {
+	DocType      string        `cbor:"docType"`
+	IssuerSigned IssuerSigned  `cbor:"issuerSigned"`
+	DeviceSigned *DeviceSigned `cbor:"deviceSigned,omitempty"`
+}
+
+// DeviceSigned holds the data elements signed by the holder and the device signature.
+type DeviceSigned struct {
+	// NameSpaces is the encoded map of device signed data elements, by name space.
+	NameSpaces EncodedCBOR `cbor:"nameSpaces"`
+	DeviceAuth DeviceAuth  `cbor:"deviceAuth"`
+}
+
+// DeviceAuth holds the device signature over the DeviceAuthentication structure.
+type DeviceAuth struct {
+	DeviceSignature *Sign1 `cbor:"deviceSignature,omitempty"`
+}
+
+// DeviceResponse is the response of the holder device to a mdoc request.
+type DeviceResponse struct {
+	Version   string      `cbor:"version"`
+	Documents []*Document `cbor:"documents,omitempty"`
+	Status    uint        `cbor:"status"`
+}
+
+// ParseDocument decodes a CBOR encoded Document.
+func ParseDocument(data []byte) (*Document, error) {
+	doc := &Document{}
+
+	if err := decMode.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("decode mdoc document: %w", err)
+	}
+
+	return doc, nil
+}
+
+// ParseDeviceResponse decodes a CBOR encoded DeviceResponse.
+func ParseDeviceResponse(data []byte) (*DeviceResponse, error) {
+	resp := &DeviceResponse{}
+
+	if err := decMode.Unmarshal(data, resp); err != nil {
+		return nil, fmt.Errorf("decode mdoc device response: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Marshal encodes the Document as CBOR.
+func (d *Document) Marshal() ([]byte, error) {
+	return encMode.Marshal(d)
+}
+
+// Marshal encodes the DeviceResponse as CBOR.
+func (r *DeviceResponse) Marshal() ([]byte, error) {
+	return encMode.Marshal(r)
+}
+
+// Items decodes the IssuerSignedItems of the document, by name space.
+func (d *Document) Items() (map[string][]*IssuerSignedItem, error) {
+	items := make(map[string][]*IssuerSignedItem, len(d.IssuerSigned.NameSpaces))
+
+	for nameSpace, encodedItems := range d.IssuerSigned.NameSpaces {
+		for _, encodedItem := range encodedItems {
+			item := &IssuerSignedItem{}
+
+			if err := encodedItem.decode(item); err != nil {
+				return nil, fmt.Errorf("decode issuer signed item of name space %s: %w", nameSpace, err)
+			}
+
+			items[nameSpace] = append(items[nameSpace], item)
+		}
+	}
+
+	return items, nil
+}
+
+// Claims returns the values of the data elements of the document, by name space and element identifier.
+func (d *Document) Claims() (map[string]map[string]interface{}, error) {
+	items, err := d.Items()
+	if err != nil {
+		return nil, err
+	}
+
+	claims := make(map[string]map[string]interface{}, len(items))
+
+	for nameSpace, nsItems := range items {
+		claims[nameSpace] = make(map[string]interface{}, len(nsItems))
+
+		for _, item := range nsItems {
+			claims[nameSpace][item.ElementIdentifier] = item.ElementValue
+		}
+	}
+
+	return claims, nil
+}
+
+// MSO decodes the Mobile Security Object signed by the issuer. The issuer signature is not checked.
+func (d *Document) MSO() (*MobileSecurityObject, error) {
+	if d.IssuerSigned.IssuerAuth == nil {
+		return nil, errors.New("issuer auth is missing")
+	}
+
+	var encodedMSO EncodedCBOR
+
+	if err := decMode.Unmarshal(d.IssuerSigned.IssuerAuth.Payload, &encodedMSO); err != nil {
+		return nil, fmt.Errorf("decode MSO: %w", err)
+	}
+
+	mso := &MobileSecurityObject{}
+
+	if err := encodedMSO.decode(mso); err != nil {
+		return nil, fmt.Errorf("decode MSO: %w", err)
+	}
+
+	return mso, nil
+}
+
+func newDigestHash(digestAlgorithm string) (hash.Hash, error) {
+	switch digestAlgorithm {
+	case DigestAlgorithmSHA256:
+		return sha256.New(), nil
+	case DigestAlgorithmSHA384:
+		return sha512.New384(), nil
+	case DigestAlgorithmSHA512:
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported digest algorithm %q", digestAlgorithm)
+	}
+}
+
+// digest computes the digest of an IssuerSignedItem, over its tagged encoding.
+func digest(digestAlgorithm string, item EncodedCBOR) ([]byte, error) {
+	h, err := newDigestHash(digestAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	itemBytes, err := item.MarshalCBOR()
+	if err != nil {
+		return nil, err
+	}
+
+	h.Write(itemBytes)
+
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,275 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mdoc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssue(t *testing.T) {
+	issuerKey, certChain, roots := createIssuer(t)
+
+	deviceKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("issue and verify", func(t *testing.T) {
+		doc, err := Issue(DocTypeMDL, testNameSpaces(), &deviceKey.PublicKey, issuerKey, certChain)
+		require.NoError(t, err)
+
+		docBytes, err := doc.Marshal()
+		require.NoError(t, err)
+
+		parsed, err := ParseDocument(docBytes)
+		require.NoError(t, err)
+
+		mso, err := parsed.VerifyIssuerSigned(WithRootCertificates(roots))
+		require.NoError(t, err)
+		require.Equal(t, DocTypeMDL, mso.DocType)
+		require.Equal(t, DigestAlgorithmSHA256, mso.DigestAlgorithm)
+		require.Len(t, mso.ValueDigests[NameSpaceMDL], 4)
+
+		devicePubKey, err := mso.DeviceKeyInfo.DeviceKey.PublicKey()
+		require.NoError(t, err)
+		require.True(t, deviceKey.PublicKey.Equal(devicePubKey))
+
+		claims, err := parsed.Claims()
+		require.NoError(t, err)
+		require.Equal(t, "Doe", claims[NameSpaceMDL]["family_name"])
+		require.Equal(t, true, claims[NameSpaceMDL]["age_over_18"])
+		require.EqualValues(t, 1, claims[NameSpaceMDL]["driving_privileges"].([]interface{})[0].(map[string]interface{})["level"]) // nolint:lll
+	})
+
+	t.Run("issue with Ed25519 keys and SHA-512 digests", func(t *testing.T) {
+		edPubKey, edPrivKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		edCert := createCertificate(t, edPubKey, edPrivKey, nil, nil)
+
+		edRoots := x509.NewCertPool()
+		edRoots.AddCert(edCert)
+
+		doc, err := Issue(DocTypeMDL, testNameSpaces(), edPubKey, edPrivKey, []*x509.Certificate{edCert},
+			WithDigestAlgorithm(DigestAlgorithmSHA512))
+		require.NoError(t, err)
+
+		alg, err := doc.IssuerSigned.IssuerAuth.Algorithm()
+		require.NoError(t, err)
+		require.Equal(t, AlgEdDSA, alg)
+
+		_, err = doc.VerifyIssuerSigned(WithRootCertificates(edRoots))
+		require.NoError(t, err)
+	})
+
+	t.Run("error - empty certificate chain", func(t *testing.T) {
+		_, err := Issue(DocTypeMDL, testNameSpaces(), &deviceKey.PublicKey, issuerKey, nil)
+		require.EqualError(t, err, "issuer certificate chain is empty")
+	})
+
+	t.Run("error - unsupported device key", func(t *testing.T) {
+		_, err := Issue(DocTypeMDL, testNameSpaces(), "key", issuerKey, certChain)
+		require.ErrorContains(t, err, "encode device key")
+	})
+
+	t.Run("error - unsupported digest algorithm", func(t *testing.T) {
+		_, err := Issue(DocTypeMDL, testNameSpaces(), &deviceKey.PublicKey, issuerKey, certChain,
+			WithDigestAlgorithm("MD5"))
+		require.ErrorContains(t, err, "unsupported digest algorithm")
+	})
+
+	t.Run("error - random generation", func(t *testing.T) {
+		_, err := Issue(DocTypeMDL, testNameSpaces(), &deviceKey.PublicKey, issuerKey, certChain,
+			WithRandomFnc(func() ([]byte, error) {
+				return nil, errors.New("random error")
+			}))
+		require.ErrorContains(t, err, "random error")
+	})
+}
+
+func TestDocument_VerifyIssuerSigned(t *testing.T) {
+	issuerKey, certChain, roots := createIssuer(t)
+
+	deviceKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	issue := func(t *testing.T, opts ...IssueOpt) *Document {
+		t.Helper()
+
+		doc, err := Issue(DocTypeMDL, testNameSpaces(), &deviceKey.PublicKey, issuerKey, certChain, opts...)
+		require.NoError(t, err)
+
+		return doc
+	}
+
+	t.Run("error - root certificates are not set", func(t *testing.T) {
+		_, err := issue(t).VerifyIssuerSigned()
+		require.EqualError(t, err, "root certificates are not set")
+	})
+
+	t.Run("error - untrusted issuer", func(t *testing.T) {
+		_, _, otherRoots := createIssuer(t)
+
+		_, err := issue(t).VerifyIssuerSigned(WithRootCertificates(otherRoots))
+		require.ErrorContains(t, err, "verify issuer certificate chain")
+	})
+
+	t.Run("error - invalid signature", func(t *testing.T) {
+		doc := issue(t)
+		doc.IssuerSigned.IssuerAuth.Signature[0] ^= 0xff
+
+		_, err := doc.VerifyIssuerSigned(WithRootCertificates(roots))
+		require.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("error - doc type mismatch", func(t *testing.T) {
+		doc := issue(t)
+		doc.DocType = "org.example.doc"
+
+		_, err := doc.VerifyIssuerSigned(WithRootCertificates(roots))
+		require.ErrorContains(t, err, "does not match document doc type")
+	})
+
+	t.Run("error - MSO is expired", func(t *testing.T) {
+		doc := issue(t, WithValidity(time.Now().Add(-2*time.Hour), time.Now().Add(-time.Hour)))
+
+		_, err := doc.VerifyIssuerSigned(WithRootCertificates(roots))
+		require.ErrorIs(t, err, ErrInvalidValidity)
+	})
+
+	t.Run("error - MSO is not yet valid", func(t *testing.T) {
+		doc := issue(t)
+
+		_, err := doc.VerifyIssuerSigned(WithRootCertificates(roots), WithCurrentTime(time.Now().Add(-time.Hour)))
+		require.ErrorIs(t, err, ErrInvalidValidity)
+	})
+
+	t.Run("error - tampered data element", func(t *testing.T) {
+		doc := issue(t)
+		other := issue(t)
+
+		// swap in an item of another document, with the same digest ID but a different salt
+		doc.IssuerSigned.NameSpaces[NameSpaceMDL][0] = other.IssuerSigned.NameSpaces[NameSpaceMDL][0]
+
+		_, err := doc.VerifyIssuerSigned(WithRootCertificates(roots))
+		require.ErrorIs(t, err, ErrDigestMismatch)
+	})
+
+	t.Run("error - data element missing from MSO", func(t *testing.T) {
+		doc := issue(t)
+
+		item, err := newEncodedCBOR(&IssuerSignedItem{DigestID: 100, ElementIdentifier: "extra", ElementValue: "x"})
+		require.NoError(t, err)
+
+		doc.IssuerSigned.NameSpaces[NameSpaceMDL] = append(doc.IssuerSigned.NameSpaces[NameSpaceMDL], item)
+
+		_, err = doc.VerifyIssuerSigned(WithRootCertificates(roots))
+		require.ErrorIs(t, err, ErrDigestMismatch)
+	})
+
+	t.Run("error - issuer auth is missing", func(t *testing.T) {
+		doc := issue(t)
+		doc.IssuerSigned.IssuerAuth = nil
+
+		_, err := doc.VerifyIssuerSigned(WithRootCertificates(roots))
+		require.EqualError(t, err, "issuer auth is missing")
+	})
+
+	t.Run("error - x5chain is missing", func(t *testing.T) {
+		doc := issue(t)
+		doc.IssuerSigned.IssuerAuth.Unprotected = map[int]interface{}{}
+
+		_, err := doc.VerifyIssuerSigned(WithRootCertificates(roots))
+		require.EqualError(t, err, "x5chain header is missing")
+	})
+}
+
+func TestParseDocument(t *testing.T) {
+	_, err := ParseDocument([]byte("invalid"))
+	require.ErrorContains(t, err, "decode mdoc document")
+
+	_, err = ParseDeviceResponse([]byte("invalid"))
+	require.ErrorContains(t, err, "decode mdoc device response")
+}
+
+func testNameSpaces() map[string]map[string]interface{} {
+	return map[string]map[string]interface{}{
+		NameSpaceMDL: {
+			"family_name": "Doe",
+			"given_name":  "John",
+			"age_over_18": true,
+			"driving_privileges": []interface{}{
+				map[string]interface{}{"vehicle_category_code": "A", "level": 1},
+			},
+		},
+	}
+}
+
+// createIssuer creates an issuer signer, its certificate chain (document signer certificate issued by
+// an IACA root certificate) and the pool holding the root certificate.
+func createIssuer(t *testing.T) (crypto.Signer, []*x509.Certificate, *x509.CertPool) {
+	t.Helper()
+
+	rootKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	rootCert := createCertificate(t, &rootKey.PublicKey, rootKey, nil, nil)
+
+	issuerKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	issuerCert := createCertificate(t, &issuerKey.PublicKey, rootKey, rootCert, nil)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(rootCert)
+
+	return issuerKey, []*x509.Certificate{issuerCert, rootCert}, roots
+}
+
+func createCertificate(t *testing.T, pubKey crypto.PublicKey, signer crypto.Signer, parent *x509.Certificate,
+	serial *big.Int) *x509.Certificate {
+	t.Helper()
+
+	if serial == nil {
+		var err error
+
+		serial, err = rand.Int(rand.Reader, big.NewInt(1<<62))
+		require.NoError(t, err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "mdoc test " + serial.String()},
+		NotBefore:             time.Now().Add(-24 * time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+	}
+
+	if parent == nil {
+		parent = template
+	}
+
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, parent, pubKey, signer)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(certBytes)
+	require.NoError(t, err)
+
+	return cert
+}
@@ -0,0 +1,169 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mdoc
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const deviceAuthenticationContext = "DeviceAuthentication"
+
+// Disclose returns a copy of the document holding only the requested data elements, by name space.
+// The issuer signature is kept, so that the disclosed data elements can still be verified against the MSO.
+func (d *Document) Disclose(nameSpaces map[string][]string) (*Document, error) {
+	disclosed := make(map[string][]EncodedCBOR, len(nameSpaces))
+
+	for nameSpace, identifiers := range nameSpaces {
+		for _, identifier := range identifiers {
+			encodedItem, err := d.findItem(nameSpace, identifier)
+			if err != nil {
+				return nil, err
+			}
+
+			disclosed[nameSpace] = append(disclosed[nameSpace], encodedItem)
+		}
+	}
+
+	return &Document{
+		DocType: d.DocType,
+		IssuerSigned: IssuerSigned{
+			NameSpaces: disclosed,
+			IssuerAuth: d.IssuerSigned.IssuerAuth,
+		},
+	}, nil
+}
+
+func (d *Document) findItem(nameSpace, identifier string) (EncodedCBOR, error) {
+	for _, encodedItem := range d.IssuerSigned.NameSpaces[nameSpace] {
+		item := &IssuerSignedItem{}
+
+		if err := encodedItem.decode(item); err != nil {
+			return nil, fmt.Errorf("decode issuer signed item of name space %s: %w", nameSpace, err)
+		}
+
+		if item.ElementIdentifier == identifier {
+			return encodedItem, nil
+		}
+	}
+
+	return nil, fmt.Errorf("data element %s/%s not found", nameSpace, identifier)
+}
+
+// NewDeviceResponse creates a DeviceResponse presenting the documents, each one signed with the device key
+// over the CBOR encoded session transcript. Documents are expected to be disclosed beforehand with Disclose.
+func NewDeviceResponse(docs []*Document, sessionTranscript []byte,
+	deviceSigner crypto.Signer) (*DeviceResponse, error) {
+	alg, err := AlgorithmForKey(deviceSigner.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &DeviceResponse{
+		Version: Version,
+		Status:  StatusOK,
+	}
+
+	for _, doc := range docs {
+		deviceNameSpaces, err := newEncodedCBOR(map[string]interface{}{})
+		if err != nil {
+			return nil, fmt.Errorf("encode device name spaces: %w", err)
+		}
+
+		payload, err := deviceAuthenticationBytes(sessionTranscript, doc.DocType, deviceNameSpaces)
+		if err != nil {
+			return nil, err
+		}
+
+		deviceSignature, err := sign(deviceSigner, alg, nil, payload, true)
+		if err != nil {
+			return nil, fmt.Errorf("sign device authentication: %w", err)
+		}
+
+		resp.Documents = append(resp.Documents, &Document{
+			DocType:      doc.DocType,
+			IssuerSigned: doc.IssuerSigned,
+			DeviceSigned: &DeviceSigned{
+				NameSpaces: deviceNameSpaces,
+				DeviceAuth: DeviceAuth{DeviceSignature: deviceSignature},
+			},
+		})
+	}
+
+	return resp, nil
+}
+
+// Verify verifies every document of the DeviceResponse: the issuer signed data, see Document.VerifyIssuerSigned,
+// and the device signature over the CBOR encoded session transcript, with the device key of the MSO.
+func (r *DeviceResponse) Verify(sessionTranscript []byte, opts ...VerifyOpt) error {
+	if r.Status != StatusOK {
+		return fmt.Errorf("device response status %d", r.Status)
+	}
+
+	for _, doc := range r.Documents {
+		if err := doc.verifyDeviceResponseDocument(sessionTranscript, opts); err != nil {
+			return fmt.Errorf("verify document %s: %w", doc.DocType, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *Document) verifyDeviceResponseDocument(sessionTranscript []byte, opts []VerifyOpt) error {
+	mso, err := d.VerifyIssuerSigned(opts...)
+	if err != nil {
+		return err
+	}
+
+	if d.DeviceSigned == nil || d.DeviceSigned.DeviceAuth.DeviceSignature == nil {
+		return errors.New("device signature is missing")
+	}
+
+	if mso.DeviceKeyInfo.DeviceKey == nil {
+		return errors.New("MSO device key is missing")
+	}
+
+	deviceKey, err := mso.DeviceKeyInfo.DeviceKey.PublicKey()
+	if err != nil {
+		return fmt.Errorf("decode device key: %w", err)
+	}
+
+	payload, err := deviceAuthenticationBytes(sessionTranscript, d.DocType, d.DeviceSigned.NameSpaces)
+	if err != nil {
+		return err
+	}
+
+	if err = d.DeviceSigned.DeviceAuth.DeviceSignature.verify(deviceKey, payload); err != nil {
+		return fmt.Errorf("verify device signature: %w", err)
+	}
+
+	return nil
+}
+
+// deviceAuthenticationBytes encodes the DeviceAuthentication structure signed by the device key, as
+// #6.24(bstr .cbor ["DeviceAuthentication", SessionTranscript, DocType, DeviceNameSpacesBytes]).
+func deviceAuthenticationBytes(sessionTranscript []byte, docType string,
+	deviceNameSpaces EncodedCBOR) ([]byte, error) {
+	if len(sessionTranscript) == 0 {
+		return nil, errors.New("session transcript is empty")
+	}
+
+	deviceAuthentication, err := newEncodedCBOR([]interface{}{
+		deviceAuthenticationContext,
+		cbor.RawMessage(sessionTranscript),
+		docType,
+		deviceNameSpaces,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encode device authentication: %w", err)
+	}
+
+	return deviceAuthentication.MarshalCBOR()
+}
@@ -0,0 +1,100 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mdoc
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeviceResponse(t *testing.T) {
+	issuerKey, certChain, roots := createIssuer(t)
+
+	deviceKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	doc, err := Issue(DocTypeMDL, testNameSpaces(), &deviceKey.PublicKey, issuerKey, certChain)
+	require.NoError(t, err)
+
+	sessionTranscript, err := encMode.Marshal([]interface{}{nil, nil, "handover"})
+	require.NoError(t, err)
+
+	t.Run("selective disclosure", func(t *testing.T) {
+		disclosed, err := doc.Disclose(map[string][]string{NameSpaceMDL: {"family_name", "age_over_18"}})
+		require.NoError(t, err)
+
+		resp, err := NewDeviceResponse([]*Document{disclosed}, sessionTranscript, deviceKey)
+		require.NoError(t, err)
+
+		respBytes, err := resp.Marshal()
+		require.NoError(t, err)
+
+		parsed, err := ParseDeviceResponse(respBytes)
+		require.NoError(t, err)
+		require.Equal(t, Version, parsed.Version)
+		require.Len(t, parsed.Documents, 1)
+
+		require.NoError(t, parsed.Verify(sessionTranscript, WithRootCertificates(roots)))
+
+		claims, err := parsed.Documents[0].Claims()
+		require.NoError(t, err)
+		require.Len(t, claims[NameSpaceMDL], 2)
+		require.Equal(t, "Doe", claims[NameSpaceMDL]["family_name"])
+		require.Equal(t, true, claims[NameSpaceMDL]["age_over_18"])
+	})
+
+	t.Run("error - data element not found", func(t *testing.T) {
+		_, err := doc.Disclose(map[string][]string{NameSpaceMDL: {"portrait"}})
+		require.EqualError(t, err, "data element org.iso.18013.5.1/portrait not found")
+	})
+
+	t.Run("error - session transcript mismatch", func(t *testing.T) {
+		resp, err := NewDeviceResponse([]*Document{doc}, sessionTranscript, deviceKey)
+		require.NoError(t, err)
+
+		otherTranscript, err := encMode.Marshal([]interface{}{nil, nil, "other"})
+		require.NoError(t, err)
+
+		err = resp.Verify(otherTranscript, WithRootCertificates(roots))
+		require.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("error - signed with another device key", func(t *testing.T) {
+		_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		resp, err := NewDeviceResponse([]*Document{doc}, sessionTranscript, otherKey)
+		require.NoError(t, err)
+
+		err = resp.Verify(sessionTranscript, WithRootCertificates(roots))
+		require.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("error - device signature is missing", func(t *testing.T) {
+		resp := &DeviceResponse{Version: Version, Documents: []*Document{doc}}
+
+		err := resp.Verify(sessionTranscript, WithRootCertificates(roots))
+		require.ErrorContains(t, err, "device signature is missing")
+	})
+
+	t.Run("error - status", func(t *testing.T) {
+		resp := &DeviceResponse{Version: Version, Status: 10}
+
+		err := resp.Verify(sessionTranscript, WithRootCertificates(roots))
+		require.EqualError(t, err, "device response status 10")
+	})
+
+	t.Run("error - empty session transcript", func(t *testing.T) {
+		_, err := NewDeviceResponse([]*Document{doc}, nil, deviceKey)
+		require.EqualError(t, err, "session transcript is empty")
+	})
+}
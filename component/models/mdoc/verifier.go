@@ -0,0 +1,144 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package mdoc
+
+import (
+	"bytes"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type verifyOpts struct {
+	roots       *x509.CertPool
+	currentTime time.Time
+}
+
+// VerifyOpt is an option for mdoc verification.
+type VerifyOpt func(opts *verifyOpts)
+
+// WithRootCertificates sets the trusted root certificates (IACA certificates) the issuer certificate chain
+// is verified against.
+func WithRootCertificates(roots *x509.CertPool) VerifyOpt {
+	return func(opts *verifyOpts) {
+		opts.roots = roots
+	}
+}
+
+// WithCurrentTime sets the time at which the certificate chain and the MSO validity are checked.
+// The current time is used by default.
+func WithCurrentTime(currentTime time.Time) VerifyOpt {
+	return func(opts *verifyOpts) {
+		opts.currentTime = currentTime
+	}
+}
+
+func getVerifyOpts(opts []VerifyOpt) *verifyOpts {
+	vOpts := &verifyOpts{currentTime: time.Now()}
+
+	for _, opt := range opts {
+		opt(vOpts)
+	}
+
+	return vOpts
+}
+
+// VerifyIssuerSigned verifies the issuer signed data of the document: the certificate chain of the issuer
+// against the trusted root certificates, the issuer signature over the MSO, the validity of the MSO
+// and the digest of every IssuerSignedItem. It returns the verified MSO.
+func (d *Document) VerifyIssuerSigned(opts ...VerifyOpt) (*MobileSecurityObject, error) {
+	vOpts := getVerifyOpts(opts)
+
+	if vOpts.roots == nil {
+		return nil, errors.New("root certificates are not set")
+	}
+
+	if d.IssuerSigned.IssuerAuth == nil {
+		return nil, errors.New("issuer auth is missing")
+	}
+
+	certChain, err := d.IssuerSigned.IssuerAuth.X5Chain()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = verifyCertChain(certChain, vOpts); err != nil {
+		return nil, err
+	}
+
+	if err = d.IssuerSigned.IssuerAuth.verify(certChain[0].PublicKey, nil); err != nil {
+		return nil, fmt.Errorf("verify issuer auth: %w", err)
+	}
+
+	mso, err := d.MSO()
+	if err != nil {
+		return nil, err
+	}
+
+	if mso.DocType != d.DocType {
+		return nil, fmt.Errorf("MSO doc type %q does not match document doc type %q", mso.DocType, d.DocType)
+	}
+
+	if vOpts.currentTime.Before(mso.ValidityInfo.ValidFrom) || vOpts.currentTime.After(mso.ValidityInfo.ValidUntil) {
+		return nil, ErrInvalidValidity
+	}
+
+	if err = checkDigests(d.IssuerSigned.NameSpaces, mso); err != nil {
+		return nil, err
+	}
+
+	return mso, nil
+}
+
+func verifyCertChain(certChain []*x509.Certificate, vOpts *verifyOpts) error {
+	intermediates := x509.NewCertPool()
+
+	for _, cert := range certChain[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := certChain[0].Verify(x509.VerifyOptions{
+		Roots:         vOpts.roots,
+		Intermediates: intermediates,
+		CurrentTime:   vOpts.currentTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("verify issuer certificate chain: %w", err)
+	}
+
+	return nil
+}
+
+func checkDigests(nameSpaces map[string][]EncodedCBOR, mso *MobileSecurityObject) error {
+	for nameSpace, encodedItems := range nameSpaces {
+		for _, encodedItem := range encodedItems {
+			item := &IssuerSignedItem{}
+
+			if err := encodedItem.decode(item); err != nil {
+				return fmt.Errorf("decode issuer signed item of name space %s: %w", nameSpace, err)
+			}
+
+			expected, ok := mso.ValueDigests[nameSpace][item.DigestID]
+			if !ok {
+				return fmt.Errorf("%w: no digest for %s/%s", ErrDigestMismatch, nameSpace, item.ElementIdentifier)
+			}
+
+			actual, err := digest(mso.DigestAlgorithm, encodedItem)
+			if err != nil {
+				return err
+			}
+
+			if !bytes.Equal(expected, actual) {
+				return fmt.Errorf("%w: %s/%s", ErrDigestMismatch, nameSpace, item.ElementIdentifier)
+			}
+		}
+	}
+
+	return nil
+}
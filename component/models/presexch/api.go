@@ -110,6 +110,9 @@ func WithMergedSubmissionMap(submissionMap map[string]interface{}) MatchOption {
 }
 
 // Match returns the credentials matched against the InputDescriptors ids.
+//
+// The is_holder and same_subject relational constraints of the InputDescriptors are evaluated against
+// the matched credentials, and the holder of the presentation holding each of them.
 func (pd *PresentationDefinition) Match(vpList []*verifiable.Presentation,
 	contextLoader ld.DocumentLoader, options ...MatchOption) (map[string]MatchValue, error) {
 	opts := &MatchOptions{}
@@ -138,6 +141,7 @@ func getMatchedCreds( //nolint:gocyclo,funlen
 	opts *MatchOptions,
 ) (map[string]MatchValue, error) {
 	result := make(map[string]MatchValue)
+	matchedCreds := make(map[string][]*credWrapper)
 
 	descriptorIDs := descriptorIDs(pd.InputDescriptors)
 
@@ -219,22 +223,33 @@ func getMatchedCreds( //nolint:gocyclo,funlen
 
 			inputDescriptor := pd.inputDescriptor(mapping.ID)
 
+			// Validate schema only for v1
 			passed := filterSchema(inputDescriptor.Schema, []*verifiable.Credential{vc}, contextLoader)
-			if len(passed) == 0 && !opts.DisableSchemaValidation {
+			if inputDescriptor.Schema != nil && len(passed) == 0 && !opts.DisableSchemaValidation {
 				return nil, fmt.Errorf(
 					"input descriptor id [%s] requires schemas %+v which do not match vc with @context [%+v] and types [%+v] selected by path [%s]", // nolint:lll
 					inputDescriptor.ID, inputDescriptor.Schema, vc.Context, vc.Types, mapping.Path)
 			}
 
+			if err := checkFormat(pd.descriptorFormat(inputDescriptor), mapping, vc); err != nil {
+				return nil, fmt.Errorf("input descriptor id [%s]: %w", inputDescriptor.ID, err)
+			}
+
 			// TODO add support for constraints: https://github.com/hyperledger/aries-framework-go/issues/2108
 
 			result[mapping.ID] = MatchValue{
 				PresentationID: vp.ID,
 				Credential:     vc,
 			}
+
+			matchedCreds[mapping.ID] = []*credWrapper{{uniqueID: vc.ID, vc: vc, presented: true, holder: vp.Holder}}
 		}
 	}
 
+	if err := evalRelationalConstraints(pd.InputDescriptors, matchedCreds); err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
 
@@ -275,6 +290,36 @@ func selectVC(typelessVerifiable interface{},
 	return vc, nil
 }
 
+// checkFormat checks the format of the descriptor mapping against the format of the input descriptor, if any.
+// The format of the credential (the innermost mapping) or, failing that, of the presentation holding it
+// must be allowed by the input descriptor, and the credential must satisfy it.
+func checkFormat(format *Format, mapping *InputDescriptorMapping, vc *verifiable.Credential) error {
+	if !format.notNil() {
+		return nil
+	}
+
+	formats := []string{mapping.Format}
+
+	for nested := mapping.PathNested; nested != nil; nested = nested.PathNested {
+		formats = append([]string{nested.Format}, formats...)
+	}
+
+	for _, name := range formats {
+		allowed := format.only(name)
+		if allowed == nil {
+			continue
+		}
+
+		if _, matched := filterFormat(allowed, []*verifiable.Credential{vc}); len(matched) == 0 {
+			return fmt.Errorf("credential selected by path [%s] does not satisfy format %s", mapping.Path, name)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("descriptor map format %v is not allowed by the input descriptor", formats)
+}
+
 // Ensures the matched credentials meet the submission requirements.
 func (pd *PresentationDefinition) evalSubmissionRequirements(matched map[string]MatchValue) error {
 	// TODO support submission requirement rules: https://github.com/hyperledger/aries-framework-go/issues/2109
@@ -28,6 +28,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/log"
 	"github.com/hyperledger/aries-framework-go/component/models/jwt"
 	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/common"
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/holder"
 	"github.com/hyperledger/aries-framework-go/component/models/verifiable"
 )
 
@@ -47,6 +48,9 @@ const (
 
 	credentialSchema = "credentialSchema"
 
+	// contextV2 is the base context of the Verifiable Credentials Data Model 2.0.
+	contextV2 = "https://www.w3.org/ns/credentials/v2"
+
 	// FormatJWT presentation exchange format.
 	FormatJWT = "jwt"
 	// FormatJWTVC presentation exchange format.
@@ -59,6 +63,14 @@ const (
 	FormatLDPVC = "ldp_vc"
 	// FormatLDPVP presentation exchange format.
 	FormatLDPVP = "ldp_vp"
+	// FormatJWTVCJSON presentation exchange format of JWT credentials, as registered by OpenID4VP.
+	FormatJWTVCJSON = "jwt_vc_json"
+	// FormatJWTVPJSON presentation exchange format of JWT presentations, as registered by OpenID4VP.
+	FormatJWTVPJSON = "jwt_vp_json"
+	// FormatSDJWTVC presentation exchange format of SD-JWT credentials.
+	FormatSDJWTVC = "vc+sd-jwt"
+	// FormatMsoMdoc presentation exchange format of ISO 18013-5 mdocs.
+	FormatMsoMdoc = "mso_mdoc"
 )
 
 var errPathNotApplicable = errors.New("path not applicable")
@@ -84,17 +96,61 @@ func (v *Preference) isRequired() bool {
 
 // Format describes PresentationDefinition`s Format field.
 type Format struct {
-	Jwt   *JwtType `json:"jwt,omitempty"`
-	JwtVC *JwtType `json:"jwt_vc,omitempty"`
-	JwtVP *JwtType `json:"jwt_vp,omitempty"`
-	Ldp   *LdpType `json:"ldp,omitempty"`
-	LdpVC *LdpType `json:"ldp_vc,omitempty"`
-	LdpVP *LdpType `json:"ldp_vp,omitempty"`
+	Jwt       *JwtType `json:"jwt,omitempty"`
+	JwtVC     *JwtType `json:"jwt_vc,omitempty"`
+	JwtVP     *JwtType `json:"jwt_vp,omitempty"`
+	JwtVCJSON *JwtType `json:"jwt_vc_json,omitempty"`
+	JwtVPJSON *JwtType `json:"jwt_vp_json,omitempty"`
+	Ldp       *LdpType `json:"ldp,omitempty"`
+	LdpVC     *LdpType `json:"ldp_vc,omitempty"`
+	LdpVP     *LdpType `json:"ldp_vp,omitempty"`
+
+	SDJWTVC *SDJWTType   `json:"vc+sd-jwt,omitempty"`
+	MsoMdoc *MsoMdocType `json:"mso_mdoc,omitempty"`
 }
 
 func (f *Format) notNil() bool {
 	return f != nil &&
-		(f.Jwt != nil || f.JwtVC != nil || f.JwtVP != nil || f.Ldp != nil || f.LdpVC != nil || f.LdpVP != nil)
+		(f.Jwt != nil || f.JwtVC != nil || f.JwtVP != nil || f.JwtVCJSON != nil || f.JwtVPJSON != nil ||
+			f.Ldp != nil || f.LdpVC != nil || f.LdpVP != nil || f.SDJWTVC != nil || f.MsoMdoc != nil)
+}
+
+// only returns a Format holding only the entry of the named format, or nil if it is not set.
+func (f *Format) only(name string) *Format {
+	if f == nil {
+		return nil
+	}
+
+	var only *Format
+
+	switch name {
+	case FormatJWT:
+		only = &Format{Jwt: f.Jwt}
+	case FormatJWTVC:
+		only = &Format{JwtVC: f.JwtVC}
+	case FormatJWTVP:
+		only = &Format{JwtVP: f.JwtVP}
+	case FormatJWTVCJSON:
+		only = &Format{JwtVCJSON: f.JwtVCJSON}
+	case FormatJWTVPJSON:
+		only = &Format{JwtVPJSON: f.JwtVPJSON}
+	case FormatLDP:
+		only = &Format{Ldp: f.Ldp}
+	case FormatLDPVC:
+		only = &Format{LdpVC: f.LdpVC}
+	case FormatLDPVP:
+		only = &Format{LdpVP: f.LdpVP}
+	case FormatSDJWTVC:
+		only = &Format{SDJWTVC: f.SDJWTVC}
+	case FormatMsoMdoc:
+		only = &Format{MsoMdoc: f.MsoMdoc}
+	}
+
+	if !only.notNil() {
+		return nil
+	}
+
+	return only
 }
 
 // JwtType contains alg.
@@ -102,9 +158,21 @@ type JwtType struct {
 	Alg []string `json:"alg,omitempty"`
 }
 
-// LdpType contains proof_type.
+// MsoMdocType contains the alg of the mdoc issuer signature.
+type MsoMdocType struct {
+	Alg []string `json:"alg,omitempty"`
+}
+
+// LdpType contains proof_type, and the cryptosuite of DataIntegrityProof proofs.
 type LdpType struct {
-	ProofType []string `json:"proof_type,omitempty"`
+	ProofType   []string `json:"proof_type,omitempty"`
+	Cryptosuite []string `json:"cryptosuite,omitempty"`
+}
+
+// SDJWTType contains the algorithms of the SD-JWT issuer signature and of the key binding JWT.
+type SDJWTType struct {
+	SDJWTAlgValues []string `json:"sd-jwt_alg_values,omitempty"`
+	KBJWTAlgValues []string `json:"kb-jwt_alg_values,omitempty"`
 }
 
 // PresentationDefinition presentation definitions (https://identity.foundation/presentation-exchange/).
@@ -158,7 +226,8 @@ type Schema struct {
 	Required bool   `json:"required,omitempty"`
 }
 
-// Holder describes Constraints`s  holder object.
+// Holder describes Constraints`s is_holder and same_subject objects. FieldID references the IDs of the
+// constraint fields, possibly of other input descriptors, matching the credentials the relation applies to.
 type Holder struct {
 	FieldID   []string    `json:"field_id,omitempty"`
 	Directive *Preference `json:"directive,omitempty"`
@@ -169,6 +238,7 @@ type Constraints struct {
 	LimitDisclosure *Preference `json:"limit_disclosure,omitempty"`
 	SubjectIsIssuer *Preference `json:"subject_is_issuer,omitempty"`
 	IsHolder        []*Holder   `json:"is_holder,omitempty"`
+	SameSubject     []*Holder   `json:"same_subject,omitempty"`
 	Fields          []*Field    `json:"fields,omitempty"`
 }
 
@@ -218,11 +288,15 @@ type MatchedInputDescriptor struct {
 	Name       string
 	Purpose    string
 	MatchedVCs []*verifiable.Credential
+	// Explanations explain why each credential matches the input descriptor or not,
+	// in the order of the credentials. Set only with the WithExplanation option.
+	Explanations []*CredentialExplanation
 }
 
 // matchRequirementsOpts holds options for the MatchSubmissionRequirement.
 type matchRequirementsOpts struct {
 	applySelectiveDisclosure bool
+	explain                  bool
 	credOpts                 []verifiable.CredentialOpt
 }
 
@@ -236,6 +310,14 @@ func WithSelectiveDisclosureApply() MatchRequirementsOpt {
 	}
 }
 
+// WithExplanation explains, for every input descriptor, why each credential matches it or not,
+// see MatchedInputDescriptor.Explanations.
+func WithExplanation() MatchRequirementsOpt {
+	return func(opts *matchRequirementsOpts) {
+		opts.explain = true
+	}
+}
+
 // WithSDCredentialOptions used when applying selective disclosure.
 func WithSDCredentialOptions(options ...verifiable.CredentialOpt) MatchRequirementsOpt {
 	return func(opts *matchRequirementsOpts) {
@@ -443,6 +525,9 @@ func makeRequirement(requirements []*SubmissionRequirement, descriptors []*Input
 }
 
 // CreateVP creates verifiable presentation.
+//
+// The credentials are framed with the Frame of the definition, if any, and filtered with the constraints of
+// the input descriptors, including the is_holder and same_subject relational constraints evaluated across them.
 func (pd *PresentationDefinition) CreateVP(credentials []*verifiable.Credential,
 	documentLoader ld.DocumentLoader, opts ...verifiable.CredentialOpt) (*verifiable.Presentation, error) {
 	applicableCredentials, submission, err := presentationData(pd, credentials, documentLoader, false, opts...)
@@ -588,6 +673,12 @@ func (pd *PresentationDefinition) MatchSubmissionRequirement(credentials []*veri
 		matchedReqs = append(matchedReqs, matched)
 	}
 
+	evalMatchedRelationalConstraints(pd.InputDescriptors, matchedReqs)
+
+	if matchOpts.explain {
+		explainRelationalConstraints(pd.InputDescriptors, matchedReqs)
+	}
+
 	return matchedReqs, nil
 }
 
@@ -643,12 +734,21 @@ func (pd *PresentationDefinition) matchRequirement(req *requirement, creds []*ve
 			}
 		}
 
-		matchedReq.Descriptors = append(matchedReq.Descriptors, &MatchedInputDescriptor{
+		matchedDesc := &MatchedInputDescriptor{
 			ID:         descriptor.ID,
 			Name:       descriptor.Name,
 			Purpose:    descriptor.Purpose,
 			MatchedVCs: matchedVCs,
-		})
+		}
+
+		if opts.explain {
+			matchedDesc.Explanations, err = pd.explainDescriptor(descriptor, creds, documentLoader)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		matchedReq.Descriptors = append(matchedReq.Descriptors, matchedDesc)
 	}
 
 	for _, nestedReq := range req.Nested {
@@ -666,6 +766,12 @@ func (pd *PresentationDefinition) matchRequirement(req *requirement, creds []*ve
 type credWrapper struct {
 	uniqueID string
 	vc       *verifiable.Credential
+	// presented is set for credentials received in a presentation, whose holder must satisfy is_holder constraints.
+	presented bool
+	// holder of the presentation holding the credential.
+	holder string
+	// format of the credential in the descriptor map, if known.
+	format string
 }
 
 func (pd *PresentationDefinition) applyRequirement( // nolint:funlen,gocyclo
@@ -726,6 +832,10 @@ func (pd *PresentationDefinition) applyRequirement( // nolint:funlen,gocyclo
 				return "", nil, err
 			}
 
+			for _, credWrap := range filteredCreds {
+				credWrap.format = credentialFormat(descFormat, credWrap.vc)
+			}
+
 			if len(filteredCreds) != 0 {
 				descriptorMatches[descriptor.ID] = &descriptorMatch{
 					format: descFormat,
@@ -741,6 +851,18 @@ func (pd *PresentationDefinition) applyRequirement( // nolint:funlen,gocyclo
 		}
 
 		if solved {
+			solution := make(map[string][]*credWrapper, len(sol))
+
+			for _, descID := range sol {
+				solution[descID] = descriptorMatches[descID].creds
+			}
+
+			if err := evalRelationalConstraints(pd.InputDescriptors, solution); err != nil {
+				logger.Debugf("solution %v rejected: %s", sol, err)
+
+				continue
+			}
+
 			result := make(map[string][]*credWrapper)
 
 			// assume LDPVP format if pd.Format is not set.
@@ -749,7 +871,7 @@ func (pd *PresentationDefinition) applyRequirement( // nolint:funlen,gocyclo
 			vpFormat := FormatLDPVP
 
 			for _, descID := range sol {
-				result[descID] = descriptorMatches[descID].creds
+				result[descID] = solution[descID]
 
 				if format := descriptorMatches[descID].format; format != "" {
 					vpFormat = format
@@ -771,10 +893,7 @@ type descriptorMatch struct {
 func (pd *PresentationDefinition) filterCredentialsThatMatchDescriptor(creds []*verifiable.Credential,
 	descriptor *InputDescriptor,
 	documentLoader ld.DocumentLoader) (string, []constraintsFilterResult, error) {
-	format := pd.Format
-	if descriptor.Format.notNil() {
-		format = descriptor.Format
-	}
+	format := pd.descriptorFormat(descriptor)
 
 	vpFormat := ""
 	filtered := creds
@@ -796,6 +915,15 @@ func (pd *PresentationDefinition) filterCredentialsThatMatchDescriptor(creds []*
 	return vpFormat, filteredByConstraints, nil
 }
 
+// descriptorFormat returns the format of the input descriptor, which defaults to the format of the definition.
+func (pd *PresentationDefinition) descriptorFormat(descriptor *InputDescriptor) *Format {
+	if descriptor.Format.notNil() {
+		return descriptor.Format
+	}
+
+	return pd.Format
+}
+
 func getSubjectIDs(subject interface{}) []string { // nolint: gocyclo
 	switch s := subject.(type) {
 	case string:
@@ -874,35 +1002,13 @@ func filterConstraints(constraints *Constraints, creds []*verifiable.Credential)
 			continue
 		}
 
-		var applicable bool
-
-		var err error
-
-		credJWT := credential.JWT
-
-		credentialWithFieldValues := credential
-
-		if isSDJWTCredential(credential) {
-			credentialWithFieldValues, err = credential.CreateDisplayCredential(verifiable.DisplayAllDisclosures())
-			if err != nil {
-				continue
-			}
-		}
-
-		// if credential.JWT is set, credential will marshal to a JSON string.
-		// temporarily clear credential.JWT to avoid this.
-		credentialWithFieldValues.JWT = ""
+		applicable := true
 
-		credentialSrc, err := json.Marshal(credentialWithFieldValues)
-		if err != nil {
+		credentialSrc, credentialMap, err := credentialFieldValues(credential)
+		if errors.Is(err, errNoFieldValues) {
 			continue
 		}
 
-		credentialWithFieldValues.JWT = credJWT
-
-		var credentialMap map[string]interface{}
-
-		err = json.Unmarshal(credentialSrc, &credentialMap)
 		if err != nil {
 			return nil, err
 		}
@@ -918,8 +1024,6 @@ func filterConstraints(constraints *Constraints, creds []*verifiable.Credential)
 			if err != nil {
 				return nil, fmt.Errorf("filter field.%d: %w", i, err)
 			}
-
-			applicable = true
 		}
 
 		if !applicable {
@@ -938,6 +1042,47 @@ func filterConstraints(constraints *Constraints, creds []*verifiable.Credential)
 	return result, nil
 }
 
+// errNoFieldValues is returned when the claims of a credential cannot be read.
+var errNoFieldValues = errors.New("credential claims cannot be read")
+
+// credentialFieldValues returns the credential source and its JSON object, against which constraint fields
+// are evaluated. The claims of SD-JWT credentials are evaluated with all disclosures applied.
+func credentialFieldValues(credential *verifiable.Credential) ([]byte, map[string]interface{}, error) {
+	var err error
+
+	credJWT := credential.JWT
+
+	credentialWithFieldValues := credential
+
+	if isSDJWTCredential(credential) {
+		credentialWithFieldValues, err = credential.CreateDisplayCredential(verifiable.DisplayAllDisclosures())
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %s", errNoFieldValues, err)
+		}
+	}
+
+	// if credential.JWT is set, credential will marshal to a JSON string.
+	// temporarily clear credential.JWT to avoid this.
+	credentialWithFieldValues.JWT = ""
+
+	credentialSrc, err := json.Marshal(credentialWithFieldValues)
+
+	credentialWithFieldValues.JWT = credJWT
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", errNoFieldValues, err)
+	}
+
+	var credentialMap map[string]interface{}
+
+	err = json.Unmarshal(credentialSrc, &credentialMap)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return credentialSrc, credentialMap, nil
+}
+
 // nolint: gocyclo, funlen
 func limitDisclosure(filterResults []constraintsFilterResult,
 	opts ...verifiable.CredentialOpt) ([]*credWrapper, error) {
@@ -956,7 +1101,7 @@ func limitDisclosure(filterResults []constraintsFilterResult,
 		var predicate bool
 
 		for _, field := range constraints.Fields {
-			if field.Predicate.isRequired() {
+			if usePredicate(field, credential) {
 				predicate = true
 			}
 		}
@@ -1043,7 +1188,8 @@ func limitDisclosure(filterResults []constraintsFilterResult,
 	return result, nil
 }
 
-// nolint: gocyclo,funlen,gocognit
+// getLimitedDisclosures selects the disclosures of the SD-JWT credential revealing the constrained fields,
+// along with the disclosures of their parents and nested claims.
 func getLimitedDisclosures(constraints *Constraints, displaySrc []byte, credential *verifiable.Credential) ([]*common.DisclosureClaim, error) { // nolint:lll
 	hash, err := common.GetCryptoHash(credential.SDJWTHashAlg)
 	if err != nil {
@@ -1061,7 +1207,13 @@ func getLimitedDisclosures(constraints *Constraints, displaySrc []byte, credenti
 	// revert JWT to original value
 	credential.JWT = vcJWT
 
-	var limitedDisclosures []*common.DisclosureClaim
+	var claims map[string]interface{}
+
+	if err = json.Unmarshal(credentialSrc, &claims); err != nil {
+		return nil, err
+	}
+
+	var paths []string
 
 	for _, f := range constraints.Fields {
 		jPaths, err := compactArrayPaths(f.Path, displaySrc)
@@ -1074,37 +1226,38 @@ func getLimitedDisclosures(constraints *Constraints, displaySrc []byte, credenti
 				continue
 			}
 
-			parentPath, key := splitLast(path.oldPath, ".")
+			paths = append(paths, "$."+path.oldPath)
+		}
+	}
 
-			parentObj, ok := gjson.GetBytes(credentialSrc, parentPath).Value().(map[string]interface{})
-			if !ok {
-				// no selective disclosures at this level, so nothing to add to limited disclosures
-				continue
-			}
+	disclosures := make([]string, len(credential.SDJWTDisclosures))
 
-			digests, err := common.GetDisclosureDigests(parentObj)
-			if err != nil {
-				return nil, err
-			}
+	for i, dc := range credential.SDJWTDisclosures {
+		disclosures[i] = dc.Disclosure
+	}
 
-			for _, dc := range credential.SDJWTDisclosures {
-				if dc.Name == key {
-					digest, err := common.GetHash(hash, dc.Disclosure)
-					if err != nil {
-						return nil, err
-					}
+	// fields missing from the credential are already handled by the constraints filter.
+	selection, err := holder.SelectDisclosuresFromClaims(claims, disclosures, hash, paths)
+	if err != nil {
+		return nil, err
+	}
 
-					if _, ok := digests[digest]; ok {
-						limitedDisclosures = append(limitedDisclosures, dc)
-					}
-				}
-			}
+	selected := common.SliceToMap(selection.Disclosures)
+
+	var limitedDisclosures []*common.DisclosureClaim
+
+	for _, dc := range credential.SDJWTDisclosures {
+		if selected[dc.Disclosure] {
+			limitedDisclosures = append(limitedDisclosures, dc)
 		}
 	}
 
 	return limitedDisclosures, nil
 }
 
+// frameCreds applies the JSON-LD frame to the credentials signed with BBS+, deriving a selective disclosure
+// credential from each of them. Other credentials can not be framed without invalidating their proof,
+// so they are excluded from the matches.
 func frameCreds(frame map[string]interface{}, creds []*verifiable.Credential,
 	opts ...verifiable.CredentialOpt) ([]*verifiable.Credential, error) {
 	if frame == nil {
@@ -1114,6 +1267,10 @@ func frameCreds(frame map[string]interface{}, creds []*verifiable.Credential,
 	var result []*verifiable.Credential
 
 	for _, credential := range creds {
+		if !hasBBS(credential) {
+			continue
+		}
+
 		bbsVC, err := credential.GenerateBBSSelectiveDisclosure(frame, nil, opts...)
 		if err != nil {
 			return nil, err
@@ -1143,8 +1300,10 @@ func createNewCredential(constraints *Constraints, src, limitedCred []byte,
 	credential *verifiable.Credential, opts ...verifiable.CredentialOpt) (*verifiable.Credential, error) {
 	var (
 		doBBS               = hasBBS(credential) && constraints.LimitDisclosure.isRequired()
+		doECDSASD           = credential.HasECDSASDProof() && constraints.LimitDisclosure.isRequired()
 		modifiedByPredicate bool
 		explicitPaths       = make(map[string]bool)
+		selectivePointers   []string
 	)
 
 	for _, f := range constraints.Fields {
@@ -1160,7 +1319,7 @@ func createNewCredential(constraints *Constraints, src, limitedCred []byte,
 
 			var val interface{} = true
 
-			if f.Predicate.isRequired() {
+			if usePredicate(f, credential) {
 				modifiedByPredicate = true
 			} else {
 				val = gjson.GetBytes(src, path.oldPath).Value()
@@ -1171,6 +1330,10 @@ func createNewCredential(constraints *Constraints, src, limitedCred []byte,
 				explicitPaths[explicitPath] = true
 			}
 
+			if doECDSASD {
+				selectivePointers = append(selectivePointers, toJSONPointer(path.oldPath))
+			}
+
 			limitedCred, err = sjson.SetBytes(limitedCred, path.newPath, val)
 			if err != nil {
 				return nil, err
@@ -1178,6 +1341,16 @@ func createNewCredential(constraints *Constraints, src, limitedCred []byte,
 		}
 	}
 
+	if doECDSASD && !modifiedByPredicate {
+		for _, key := range mandatoryRevealFields(credential) {
+			if gjson.GetBytes(src, key).Exists() {
+				selectivePointers = append(selectivePointers, "/"+key)
+			}
+		}
+
+		return credential.GenerateECDSASDSelectiveDisclosure(selectivePointers, opts...)
+	}
+
 	if !doBBS || modifiedByPredicate {
 		opts = append(opts, verifiable.WithDisabledProofCheck())
 		return verifiable.ParseCredential(limitedCred, opts...)
@@ -1196,6 +1369,28 @@ func createNewCredential(constraints *Constraints, src, limitedCred []byte,
 	return credential.GenerateBBSSelectiveDisclosure(doc, []byte(uuid.New().String()), opts...)
 }
 
+// mandatoryRevealFields returns the top-level fields an ECDSA-SD derived credential always reveals,
+// depending on the data model version of the credential's base context.
+func mandatoryRevealFields(credential *verifiable.Credential) []string {
+	if len(credential.Context) > 0 && credential.Context[0] == contextV2 {
+		return []string{"issuer", "validFrom", "validUntil"}
+	}
+
+	return []string{"issuer", "issuanceDate", "expirationDate"}
+}
+
+// toJSONPointer converts a dot-separated path, eg `foo.1.bar`, into a JSON pointer, eg `/foo/1/bar`.
+func toJSONPointer(path string) string {
+	var pointer strings.Builder
+
+	for _, key := range strings.Split(path, ".") {
+		pointer.WriteString("/")
+		pointer.WriteString(strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1"))
+	}
+
+	return pointer.String()
+}
+
 // splitLast finds the final occurrence of split in text, and returns (everything before, everything after).
 // If split is not found in text, then splitLast returns ("", text).
 func splitLast(text, split string) (string, string) {
@@ -1323,9 +1518,25 @@ func isSDJWTCredential(credential *verifiable.Credential) bool {
 }
 
 func supportsSelectiveDisclosure(credential *verifiable.Credential) bool {
-	return isSDJWTCredential(credential) || hasBBS(credential)
+	return isSDJWTCredential(credential) || hasBBS(credential) || credential.HasECDSASDProof()
 }
 
+// fieldMismatch is the error of filterField when a credential does not satisfy a field.
+type fieldMismatch struct {
+	reason  MismatchReason
+	details string
+}
+
+func (m *fieldMismatch) Error() string {
+	return fmt.Sprintf("%s: %s", errPathNotApplicable, m.details)
+}
+
+func (m *fieldMismatch) Unwrap() error {
+	return errPathNotApplicable
+}
+
+// filterField checks that the credential satisfies the field. If not, it returns a *fieldMismatch error with the
+// reason (the field is missing or its values do not satisfy the filter).
 func filterField(f *Field, credential map[string]interface{}) error {
 	var schema gojsonschema.JSONLoader
 
@@ -1333,45 +1544,82 @@ func filterField(f *Field, credential map[string]interface{}) error {
 		schema = gojsonschema.NewGoLoader(*f.Filter)
 	}
 
-	var lastErr error
+	var (
+		found   bool
+		details []string
+	)
 
 	for _, path := range f.Path {
 		patch, err := jsonpath.Get(path, credential)
-		if err == nil {
-			// TODO: refactor this + selective disclosure so that the accepted path for a constraint field
-			//  is the only path revealed, instead of revealing all paths for the field.
-			err = validatePatch(schema, patch)
-			if err == nil {
-				return nil
-			}
+		if err != nil {
+			continue
+		}
+
+		found = true
 
-			lastErr = err
-		} else if f.Optional {
+		// TODO: refactor this + selective disclosure so that the accepted path for a constraint field
+		//  is the only path revealed, instead of revealing all paths for the field.
+		errs, err := validatePatch(schema, patch)
+		if err != nil {
+			return err
+		}
+
+		if len(errs) == 0 {
 			return nil
-		} else {
-			lastErr = errPathNotApplicable
+		}
+
+		details = append(details, fmt.Sprintf("%s: %s", path, strings.Join(errs, "; ")))
+	}
+
+	if !found {
+		// an optional field does not make the credential inapplicable if none of its paths is present.
+		if f.Optional {
+			return nil
+		}
+
+		return &fieldMismatch{
+			reason:  MismatchFieldMissing,
+			details: fmt.Sprintf("credential lacks %s", strings.Join(f.Path, " or ")),
 		}
 	}
 
-	return lastErr
+	return &fieldMismatch{reason: MismatchFieldFilter, details: strings.Join(details, ", ")}
 }
 
-func validatePatch(schema gojsonschema.JSONLoader, patch interface{}) error {
+// usePredicate checks if the holder should disclose the boolean result of the field, instead of its value.
+// A required predicate is always disclosed as a boolean. A preferred predicate is disclosed as a boolean unless
+// the actual value can be selectively disclosed without invalidating the credential proof.
+func usePredicate(f *Field, credential *verifiable.Credential) bool {
+	if f.Predicate == nil {
+		return false
+	}
+
+	return f.Predicate.isRequired() || (*f.Predicate == Preferred && !supportsSelectiveDisclosure(credential))
+}
+
+// validatePatch returns the descriptions of the errors of the value patch against the field filter schema.
+func validatePatch(schema gojsonschema.JSONLoader, patch interface{}) ([]string, error) {
 	if schema == nil {
-		return nil
+		return nil, nil
 	}
 
 	raw, err := json.Marshal(patch)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	result, err := gojsonschema.Validate(schema, gojsonschema.NewBytesLoader(raw))
-	if err != nil || !result.Valid() {
-		return errPathNotApplicable
+	if err != nil {
+		return []string{err.Error()}, nil
 	}
 
-	return nil
+	var errs []string
+
+	for _, resultErr := range result.Errors() {
+		errs = append(errs, resultErr.Description())
+	}
+
+	return errs, nil
 }
 
 type pathTransform struct {
@@ -1438,9 +1686,9 @@ func merge(
 				setOfCreds[credWrap.uniqueID] = len(result) - 1
 			}
 
-			vcFormat := FormatLDPVC
-			if credential.JWT != "" {
-				vcFormat = FormatJWTVC
+			vcFormat := credWrap.format
+			if vcFormat == "" {
+				vcFormat = credentialFormat("", credential)
 			}
 
 			desc := &InputDescriptorMapping{
@@ -1469,27 +1717,61 @@ func merge(
 	return result, descriptors
 }
 
+// credentialFormat returns the format of the credential in the descriptor map, given the format entry
+// of the input descriptor it was matched with. Without format entry, the format is derived from the credential.
+func credentialFormat(matchedFormat string, credential *verifiable.Credential) string {
+	switch matchedFormat {
+	case FormatSDJWTVC:
+		return FormatSDJWTVC
+	case FormatJWTVCJSON, FormatJWTVPJSON:
+		return FormatJWTVCJSON
+	case FormatJWT, FormatJWTVC, FormatJWTVP:
+		return FormatJWTVC
+	case FormatLDP, FormatLDPVC, FormatLDPVP:
+		return FormatLDPVC
+	}
+
+	if isSDJWTCredential(credential) || len(credential.SDJWTDisclosures) > 0 {
+		return FormatSDJWTVC
+	}
+
+	if credential.JWT != "" {
+		return FormatJWTVC
+	}
+
+	return FormatLDPVC
+}
+
 type byID []*InputDescriptorMapping
 
 func (a byID) Len() int           { return len(a) }
 func (a byID) Less(i, j int) bool { return a[i].ID < a[j].ID }
 func (a byID) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 
+// credentialFormats are the formats of verifiable credentials, in the order they are matched by filterFormat.
+var credentialFormats = []string{ // nolint:gochecknoglobals
+	FormatLDP, FormatLDPVC, FormatLDPVP, FormatSDJWTVC,
+	FormatJWT, FormatJWTVC, FormatJWTVP, FormatJWTVCJSON, FormatJWTVPJSON,
+}
+
+// filterFormat returns the credentials matching the format, and the name of the matched format entry.
+// If several entries of the format are matched, the first one in the order below is returned.
+//
 //nolint:funlen,gocyclo
 func filterFormat(format *Format, credentials []*verifiable.Credential) (string, []*verifiable.Credential) {
-	var ldpCreds, ldpvcCreds, ldpvpCreds, jwtCreds, jwtvcCreds, jwtvpCreds []*verifiable.Credential
+	matched := map[string][]*verifiable.Credential{}
 
 	for _, credential := range credentials {
 		if credByProof(credential, format.Ldp) {
-			ldpCreds = append(ldpCreds, credential)
+			matched[FormatLDP] = append(matched[FormatLDP], credential)
 		}
 
 		if credByProof(credential, format.LdpVC) {
-			ldpvcCreds = append(ldpvcCreds, credential)
+			matched[FormatLDPVC] = append(matched[FormatLDPVC], credential)
 		}
 
 		if credByProof(credential, format.LdpVP) {
-			ldpvpCreds = append(ldpvpCreds, credential)
+			matched[FormatLDPVP] = append(matched[FormatLDPVP], credential)
 		}
 
 		var (
@@ -1508,41 +1790,31 @@ func filterFormat(format *Format, credentials []*verifiable.Credential) (string,
 			alg, hasAlg = pJWT.Headers.Algorithm()
 		}
 
-		if hasAlg && algMatch(alg, format.Jwt) {
-			jwtCreds = append(jwtCreds, credential)
+		if !hasAlg {
+			continue
 		}
 
-		if hasAlg && algMatch(alg, format.JwtVC) {
-			jwtvcCreds = append(jwtvcCreds, credential)
+		if isSDJWTCredential(credential) && sdJWTMatch(alg, credential, format.SDJWTVC) {
+			matched[FormatSDJWTVC] = append(matched[FormatSDJWTVC], credential)
 		}
 
-		if hasAlg && algMatch(alg, format.JwtVP) {
-			jwtvpCreds = append(jwtvpCreds, credential)
+		for name, jwtType := range map[string]*JwtType{
+			FormatJWT:       format.Jwt,
+			FormatJWTVC:     format.JwtVC,
+			FormatJWTVP:     format.JwtVP,
+			FormatJWTVCJSON: format.JwtVCJSON,
+			FormatJWTVPJSON: format.JwtVPJSON,
+		} {
+			if algMatch(alg, jwtType) {
+				matched[name] = append(matched[name], credential)
+			}
 		}
 	}
 
-	if len(ldpCreds) > 0 {
-		return FormatLDP, ldpCreds
-	}
-
-	if len(ldpvcCreds) > 0 {
-		return FormatLDPVC, ldpvcCreds
-	}
-
-	if len(ldpvpCreds) > 0 {
-		return FormatLDPVP, ldpvpCreds
-	}
-
-	if len(jwtCreds) > 0 {
-		return FormatJWT, jwtCreds
-	}
-
-	if len(jwtvcCreds) > 0 {
-		return FormatJWTVC, jwtvcCreds
-	}
-
-	if len(jwtvpCreds) > 0 {
-		return FormatJWTVP, jwtvpCreds
+	for _, name := range credentialFormats {
+		if len(matched[name]) > 0 {
+			return name, matched[name]
+		}
 	}
 
 	return "", nil
@@ -1561,8 +1833,12 @@ func algMatch(credAlg string, jwtType *JwtType) bool {
 		return false
 	}
 
-	for _, b := range jwtType.Alg {
-		if strings.EqualFold(credAlg, b) {
+	return algIn(credAlg, jwtType.Alg)
+}
+
+func algIn(alg string, algs []string) bool {
+	for _, b := range algs {
+		if strings.EqualFold(alg, b) {
 			return true
 		}
 	}
@@ -1570,15 +1846,51 @@ func algMatch(credAlg string, jwtType *JwtType) bool {
 	return false
 }
 
+// sdJWTMatch checks the alg of the SD-JWT issuer signature and, if the credential holds a key binding JWT,
+// the alg of the key binding JWT. Any alg is accepted if the corresponding alg values are not set.
+func sdJWTMatch(credAlg string, credential *verifiable.Credential, sdJWTType *SDJWTType) bool {
+	if sdJWTType == nil {
+		return false
+	}
+
+	if len(sdJWTType.SDJWTAlgValues) > 0 && !algIn(credAlg, sdJWTType.SDJWTAlgValues) {
+		return false
+	}
+
+	if len(sdJWTType.KBJWTAlgValues) == 0 || credential.SDHolderBinding == "" {
+		return true
+	}
+
+	kbJWT, _, err := jwt.Parse(credential.SDHolderBinding, jwt.WithSignatureVerifier(&noVerifier{}))
+	if err != nil {
+		return false
+	}
+
+	kbAlg, ok := kbJWT.Headers.Algorithm()
+
+	return ok && algIn(kbAlg, sdJWTType.KBJWTAlgValues)
+}
+
+// credByProof checks if the credential has a proof of one of the proof types and, for DataIntegrityProof
+// proofs, of one of the cryptosuites. Either list may be omitted, but not both.
 func credByProof(c *verifiable.Credential, ldp *LdpType) bool {
-	if ldp == nil {
+	if ldp == nil || (len(ldp.ProofType) == 0 && len(ldp.Cryptosuite) == 0) {
 		return false
 	}
 
-	for _, proofType := range ldp.ProofType {
-		if hasProofWithType(c, proofType) {
-			return true
+	for _, proof := range c.Proofs {
+		proofType, _ := proof["type"].(string)          //nolint:errcheck
+		cryptosuite, _ := proof["cryptosuite"].(string) //nolint:errcheck
+
+		if len(ldp.ProofType) > 0 && !contains(ldp.ProofType, proofType) {
+			continue
+		}
+
+		if len(ldp.Cryptosuite) > 0 && !contains(ldp.Cryptosuite, cryptosuite) {
+			continue
 		}
+
+		return true
 	}
 
 	return false
@@ -26,16 +26,22 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/fingerprint"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/jwkkid"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/localkms"
 	mockkms "github.com/hyperledger/aries-framework-go/component/kmscrypto/mock/kms"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/secretlock/noop"
 	"github.com/hyperledger/aries-framework-go/component/storageutil/mock/storage"
 	"github.com/hyperledger/aries-framework-go/spi/kms"
+	vdrapi "github.com/hyperledger/aries-framework-go/spi/vdr"
 
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite/ecdsasd2023"
+	"github.com/hyperledger/aries-framework-go/component/models/did"
 	lddocloader "github.com/hyperledger/aries-framework-go/component/models/ld/documentloader"
 	ldprocessor "github.com/hyperledger/aries-framework-go/component/models/ld/processor"
 	ldtestutil "github.com/hyperledger/aries-framework-go/component/models/ld/testutil"
 	. "github.com/hyperledger/aries-framework-go/component/models/presexch"
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/common"
 	"github.com/hyperledger/aries-framework-go/component/models/signature/suite"
 	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/bbsblssignature2020"
 	sigutil "github.com/hyperledger/aries-framework-go/component/models/signature/util"
@@ -56,7 +62,10 @@ var (
 )
 
 func TestPresentationDefinition_IsValid(t *testing.T) {
-	samples := []string{"sample_1.json", "sample_2.json", "sample_3.json"}
+	samples := []string{
+		"sample_1.json", "sample_2.json", "sample_3.json",
+		"v2/pd_relational.json", "v2/pd_predicate.json", "v2/pd_optional.json", "v2/pd_frame.json",
+	}
 
 	for _, sample := range samples {
 		file := sample
@@ -761,6 +770,52 @@ func TestPresentationDefinition_CreateVP(t *testing.T) {
 		checkVP(t, vp)
 	})
 
+	t.Run("SD-JWT: Limit Disclosure + recursive SD claim paths", func(t *testing.T) {
+		required := Required
+
+		pd := &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				ID: uuid.New().String(),
+				Schema: []*Schema{{
+					URI: fmt.Sprintf("%s#%s", verifiable.ContextID, verifiable.VCType),
+				}},
+				Constraints: &Constraints{
+					LimitDisclosure: &required,
+					Fields: []*Field{{
+						Path: []string{
+							"$.credentialSubject.given_name",
+							"$.credentialSubject.address.country",
+						},
+					}},
+				},
+			}},
+		}
+
+		ed25519Signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		sdJwtVC := newSdJwtVC(t, getTestVC(), ed25519Signer,
+			verifiable.MakeSDJWTWithVersion(common.SDJWTVersionV5),
+			verifiable.MakeSDJWTWithRecursiveClaimsObjects([]string{"address"}))
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{sdJwtVC},
+			lddl, verifiable.WithJSONLDDocumentLoader(createTestJSONLDDocumentLoader(t)))
+		require.NoError(t, err)
+
+		vc, ok := vp.Credentials()[0].(*verifiable.Credential)
+		require.True(t, ok)
+
+		// given_name, address as the parent of country, and country.
+		var names []string
+
+		for _, dc := range vc.SDJWTDisclosures {
+			names = append(names, dc.Name)
+		}
+
+		require.ElementsMatch(t, []string{"given_name", "address", "country"}, names)
+	})
+
 	t.Run("SD-JWT: Limit Disclosure + SD Claim paths + additional filter", func(t *testing.T) {
 		required := Required
 
@@ -1242,6 +1297,109 @@ func TestPresentationDefinition_CreateVP(t *testing.T) {
 		checkVP(t, vp)
 	})
 
+	t.Run("Limit disclosure ECDSA-SD", func(t *testing.T) {
+		required := Required
+
+		pd := &PresentationDefinition{
+			ID: uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{{
+				Schema: []*Schema{{
+					URI: fmt.Sprintf("%s#%s", verifiable.ContextID, verifiable.VCType),
+				}},
+				ID: uuid.New().String(),
+				Constraints: &Constraints{
+					LimitDisclosure: &required,
+					Fields: []*Field{{
+						Path:   []string{"$.credentialSubject.degree.degreeSchool"},
+						Filter: &Filter{Type: &strFilterType},
+					}},
+				},
+			}},
+		}
+
+		vc := &verifiable.Credential{
+			ID: "https://issuer.oidp.uscis.gov/credentials/83627465",
+			Context: []string{
+				verifiable.ContextURI,
+				"https://www.w3.org/2018/credentials/examples/v1",
+				"https://w3id.org/security/data-integrity/v1",
+			},
+			Types: []string{
+				"VerifiableCredential",
+				"UniversityDegreeCredential",
+			},
+			Subject: verifiable.Subject{
+				ID: "did:example:b34ca6cd37bbf23",
+				CustomFields: map[string]interface{}{
+					"name":   "Jayden Doe",
+					"spouse": "did:example:c276e12ec21ebfeb1f712ebc6f1",
+					"degree": map[string]interface{}{
+						"degree":       "MIT",
+						"degreeSchool": "MIT school",
+						"type":         "BachelorDegree",
+					},
+				},
+			},
+			Issued: &utiltime.TimeWrapper{
+				Time: time.Now(),
+			},
+			Expired: &utiltime.TimeWrapper{
+				Time: time.Now().Add(time.Hour),
+			},
+			Issuer: verifiable.Issuer{
+				ID: "did:example:489398593",
+			},
+		}
+
+		signer, verifier := newECDSASDSignerAndVerifier(t)
+
+		require.NoError(t, vc.AddDataIntegrityProof(&verifiable.DataIntegrityProofContext{
+			SigningKeyID: "did:example:489398593#key-1",
+			CryptoSuite:  ecdsasd2023.SuiteType,
+		}, signer))
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{vc}, lddl,
+			verifiable.WithJSONLDDocumentLoader(createTestJSONLDDocumentLoader(t)),
+		)
+		require.NoError(t, err)
+		require.NotNil(t, vp)
+		require.Equal(t, 1, len(vp.Credentials()))
+
+		vc, ok := vp.Credentials()[0].(*verifiable.Credential)
+		require.True(t, ok)
+
+		subject := vc.Subject.([]verifiable.Subject)[0]
+		degree := subject.CustomFields["degree"]
+		require.NotNil(t, degree)
+
+		degreeMap, ok := degree.(map[string]interface{})
+		require.True(t, ok)
+
+		require.Equal(t, "MIT school", degreeMap["degreeSchool"])
+		require.Equal(t, "BachelorDegree", degreeMap["type"])
+		require.Empty(t, degreeMap["degree"])
+		require.Equal(t, "did:example:b34ca6cd37bbf23", subject.ID)
+		require.Empty(t, subject.CustomFields["spouse"])
+		require.Empty(t, subject.CustomFields["name"])
+
+		require.NotNil(t, vc.Issued)
+		require.NotNil(t, vc.Expired)
+		require.Equal(t, "did:example:489398593", vc.Issuer.ID)
+
+		require.NotEmpty(t, vc.Proofs)
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		_, err = verifiable.ParseCredential(vcBytes,
+			verifiable.WithJSONLDDocumentLoader(createTestJSONLDDocumentLoader(t)),
+			verifiable.WithDataIntegrityVerifier(verifier))
+		require.NoError(t, err)
+
+		checkSubmission(t, vp, pd)
+		checkVP(t, vp)
+	})
+
 	t.Run("Predicate and limit disclosure BBS+ (no proof)", func(t *testing.T) {
 		required := Required
 
@@ -2208,6 +2366,127 @@ func TestPresentationDefinition_CreateVP(t *testing.T) {
 	})
 }
 
+func TestPresentationDefinition_V2Features(t *testing.T) {
+	lddl := createTestJSONLDDocumentLoader(t)
+
+	t.Run("preferred predicate - boolean result", func(t *testing.T) {
+		var pd *PresentationDefinition
+		parseJSONFile(t, "testdata/v2/pd_predicate.json", &pd)
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{
+			newSubjectVC(holderDID, map[string]interface{}{"age": 30, "name": "John"}),
+		}, lddl, verifiable.WithJSONLDDocumentLoader(lddl))
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+
+		vc, ok := vp.Credentials()[0].(*verifiable.Credential)
+		require.True(t, ok)
+
+		subject := vc.Subject.([]verifiable.Subject)[0]
+		require.Equal(t, true, subject.CustomFields["age"])
+		require.NotContains(t, subject.CustomFields, "name")
+
+		checkSubmission(t, vp, pd)
+		checkVP(t, vp)
+	})
+
+	t.Run("preferred predicate - SD-JWT discloses the value", func(t *testing.T) {
+		var pd *PresentationDefinition
+		parseJSONFile(t, "testdata/v2/pd_predicate.json", &pd)
+
+		testVC := getTestVC()
+		testVC.Subject.(map[string]interface{})["age"] = 30
+
+		ed25519Signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{newSdJwtVC(t, testVC, ed25519Signer)}, lddl,
+			verifiable.WithJSONLDDocumentLoader(lddl))
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+
+		vc, ok := vp.Credentials()[0].(*verifiable.Credential)
+		require.True(t, ok)
+		require.Len(t, vc.SDJWTDisclosures, 1)
+
+		displayVC, err := vc.CreateDisplayCredential(verifiable.DisplayAllDisclosures())
+		require.NoError(t, err)
+
+		subject := displayVC.Subject.([]verifiable.Subject)[0]
+		require.EqualValues(t, 30, subject.CustomFields["age"])
+		require.Nil(t, subject.CustomFields["given_name"])
+	})
+
+	t.Run("predicate - filter not satisfied", func(t *testing.T) {
+		var pd *PresentationDefinition
+		parseJSONFile(t, "testdata/v2/pd_predicate.json", &pd)
+
+		_, err := pd.CreateVP([]*verifiable.Credential{
+			newSubjectVC(holderDID, map[string]interface{}{"age": 16}),
+		}, lddl, verifiable.WithJSONLDDocumentLoader(lddl))
+		require.ErrorIs(t, err, ErrNoCredentials)
+	})
+
+	t.Run("optional fields", func(t *testing.T) {
+		var pd *PresentationDefinition
+		parseJSONFile(t, "testdata/v2/pd_optional.json", &pd)
+
+		tests := []struct {
+			name    string
+			claims  map[string]interface{}
+			matches bool
+		}{
+			{
+				name:    "optional field missing",
+				claims:  map[string]interface{}{"employer": "ACME"},
+				matches: true,
+			},
+			{
+				name:    "optional field present at its second path",
+				claims:  map[string]interface{}{"employer": "ACME", "role": "Engineer"},
+				matches: true,
+			},
+			{
+				name:    "optional field present but not satisfying its filter",
+				claims:  map[string]interface{}{"employer": "ACME", "title": 42},
+				matches: false,
+			},
+			{
+				name:    "required field missing",
+				claims:  map[string]interface{}{"title": "Engineer"},
+				matches: false,
+			},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := pd.CreateVP([]*verifiable.Credential{newSubjectVC(holderDID, tc.claims)}, lddl,
+					verifiable.WithJSONLDDocumentLoader(lddl))
+				if tc.matches {
+					require.NoError(t, err)
+				} else {
+					require.ErrorIs(t, err, ErrNoCredentials)
+				}
+			})
+		}
+	})
+
+	t.Run("frame - credentials without BBS+ proof are not matched", func(t *testing.T) {
+		var pd *PresentationDefinition
+		parseJSONFile(t, "testdata/v2/pd_frame.json", &pd)
+
+		vc := newSubjectVC(holderDID, map[string]interface{}{"birthCountry": "Bahamas", "name": "John"})
+
+		_, err := pd.CreateVP([]*verifiable.Credential{vc}, lddl, verifiable.WithJSONLDDocumentLoader(lddl))
+		require.ErrorIs(t, err, ErrNoCredentials)
+
+		matched, err := pd.MatchSubmissionRequirement([]*verifiable.Credential{vc}, lddl,
+			WithSelectiveDisclosureApply(), WithSDCredentialOptions(verifiable.WithJSONLDDocumentLoader(lddl)))
+		require.NoError(t, err)
+		require.Empty(t, matched[0].Descriptors[0].MatchedVCs)
+	})
+}
+
 func TestPresentationDefinition_CreateVPArray(t *testing.T) {
 	lddl := createTestJSONLDDocumentLoader(t)
 
@@ -2313,6 +2592,7 @@ func newSdJwtVC(
 	t *testing.T,
 	vc *verifiable.Credential,
 	signer sigutil.Signer,
+	opts ...verifiable.MakeSDJWTOption,
 ) *verifiable.Credential {
 	t.Helper()
 
@@ -2329,7 +2609,7 @@ func newSdJwtVC(
 	require.NoError(t, err)
 
 	combinedFormatForIssuance, err := vc.MakeSDJWT(
-		verifiable.GetJWTSigner(signer, algName), verMethod)
+		verifiable.GetJWTSigner(signer, algName), verMethod, opts...)
 	require.NoError(t, err)
 
 	parsed, err := verifiable.ParseCredential([]byte(combinedFormatForIssuance),
@@ -2357,6 +2637,55 @@ func createKMS() (*localkms.LocalKMS, error) {
 	return localkms.New("local-lock://custom/master/key/", p)
 }
 
+func newECDSASDSignerAndVerifier(t *testing.T) (*dataintegrity.Signer, *dataintegrity.Verifier) {
+	t.Helper()
+
+	localKMS, err := createKMS()
+	require.NoError(t, err)
+
+	tinkCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	_, pubKeyBytes, err := localKMS.CreateAndExportPubKeyBytes(kms.ECDSAP256IEEEP1363)
+	require.NoError(t, err)
+
+	pubJWK, err := jwkkid.BuildJWK(pubKeyBytes, kms.ECDSAP256IEEEP1363)
+	require.NoError(t, err)
+
+	const signingDID = "did:example:489398593"
+
+	vm, err := did.NewVerificationMethodFromJWK(signingDID+"#key-1", "JsonWebKey2020", signingDID, pubJWK)
+	require.NoError(t, err)
+
+	resolver := resolveFunc(func(string) (*did.DocResolution, error) {
+		return &did.DocResolution{DIDDocument: &did.Doc{
+			ID:              signingDID,
+			AssertionMethod: []did.Verification{{VerificationMethod: *vm, Relationship: did.AssertionMethod}},
+		}}, nil
+	})
+
+	signer, err := dataintegrity.NewSigner(&dataintegrity.Options{DIDResolver: resolver},
+		ecdsasd2023.NewSignerInitializer(&ecdsasd2023.SignerInitializerOptions{
+			LDDocumentLoader: createTestJSONLDDocumentLoader(t),
+			SignerGetter:     ecdsasd2023.WithLocalKMSSigner(localKMS, tinkCrypto),
+		}))
+	require.NoError(t, err)
+
+	verifier, err := dataintegrity.NewVerifier(&dataintegrity.Options{DIDResolver: resolver},
+		ecdsasd2023.NewVerifierInitializer(&ecdsasd2023.VerifierInitializerOptions{
+			LDDocumentLoader: createTestJSONLDDocumentLoader(t),
+		}))
+	require.NoError(t, err)
+
+	return signer, verifier
+}
+
+type resolveFunc func(id string) (*did.DocResolution, error)
+
+func (f resolveFunc) Resolve(id string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	return f(id)
+}
+
 func newCryptoSigner(keyType kms.KeyType) (sigutil.Signer, error) { // nolint:unparam
 	localKMS, err := createKMS()
 	if err != nil {
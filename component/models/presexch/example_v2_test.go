@@ -948,7 +948,7 @@ func ExamplePresentationDefinition_CreateVP_limitedDisclosureSkipsNonSDVCs() {
 	//				"path": "$",
 	//				"path_nested": {
 	//					"id": "mauve_alert",
-	//					"format": "jwt_vc",
+	//					"format": "vc+sd-jwt",
 	//					"path": "$.verifiableCredential[0]"
 	//				}
 	//			}
@@ -0,0 +1,219 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/component/models/verifiable"
+)
+
+// MismatchReason is the reason a credential does not match an input descriptor.
+type MismatchReason string
+
+const (
+	// MismatchFormat means the credential does not satisfy any format of the input descriptor.
+	MismatchFormat MismatchReason = "format"
+	// MismatchSchema means the credential does not satisfy the schema of the input descriptor.
+	MismatchSchema MismatchReason = "schema"
+	// MismatchSubjectIsIssuer means the subject of the credential is not its issuer, as required.
+	MismatchSubjectIsIssuer MismatchReason = "subject_is_issuer"
+	// MismatchFieldMissing means none of the paths of a constraint field is present in the credential.
+	MismatchFieldMissing MismatchReason = "field_missing"
+	// MismatchFieldFilter means the values of a constraint field do not satisfy its filter.
+	MismatchFieldFilter MismatchReason = "field_filter"
+	// MismatchClaims means the claims of the credential cannot be read, eg invalid SD-JWT disclosures.
+	MismatchClaims MismatchReason = "claims"
+	// MismatchRelational means the credential does not satisfy an is_holder or same_subject constraint.
+	MismatchRelational MismatchReason = "relational"
+)
+
+// Mismatch describes why a credential does not match an input descriptor.
+type Mismatch struct {
+	Reason MismatchReason
+	// FieldID is the ID of the constraint field, for field mismatches.
+	FieldID string
+	// Path holds the paths of the constraint field, for field mismatches.
+	Path []string
+	// Expected holds the expected formats, for format mismatches, or schema URIs, for schema mismatches.
+	Expected []string
+	// Details is a human readable description of the mismatch.
+	Details string
+}
+
+// CredentialExplanation explains why a credential matches an input descriptor or not.
+type CredentialExplanation struct {
+	Credential *verifiable.Credential
+	Matched    bool
+	// Mismatches holds every reason the credential does not match the input descriptor.
+	Mismatches []*Mismatch
+}
+
+// explainDescriptor evaluates each credential against the format, schema and constraints of the descriptor,
+// collecting every mismatch instead of stopping at the first one.
+func (pd *PresentationDefinition) explainDescriptor(descriptor *InputDescriptor, creds []*verifiable.Credential,
+	documentLoader ld.DocumentLoader) ([]*CredentialExplanation, error) {
+	format := pd.descriptorFormat(descriptor)
+
+	explanations := make([]*CredentialExplanation, len(creds))
+
+	for i, credential := range creds {
+		var mismatches []*Mismatch
+
+		if format.notNil() {
+			if _, matched := filterFormat(format, []*verifiable.Credential{credential}); len(matched) == 0 {
+				expected := formatNames(format)
+
+				mismatches = append(mismatches, &Mismatch{
+					Reason:   MismatchFormat,
+					Expected: expected,
+					Details:  fmt.Sprintf("credential does not satisfy any of the formats %v", expected),
+				})
+			}
+		}
+
+		if descriptor.Schema != nil && len(filterSchema(descriptor.Schema, []*verifiable.Credential{credential},
+			documentLoader)) == 0 {
+			var expected []string
+
+			for _, schema := range descriptor.Schema {
+				expected = append(expected, schema.URI)
+			}
+
+			mismatches = append(mismatches, &Mismatch{
+				Reason:   MismatchSchema,
+				Expected: expected,
+				Details:  fmt.Sprintf("credential does not satisfy the schemas %v", expected),
+			})
+		}
+
+		constraintMismatches, err := explainConstraints(descriptor.Constraints, credential)
+		if err != nil {
+			return nil, fmt.Errorf("explain input descriptor %s: %w", descriptor.ID, err)
+		}
+
+		mismatches = append(mismatches, constraintMismatches...)
+
+		explanations[i] = &CredentialExplanation{
+			Credential: credential,
+			Matched:    len(mismatches) == 0,
+			Mismatches: mismatches,
+		}
+	}
+
+	return explanations, nil
+}
+
+func explainConstraints(constraints *Constraints, credential *verifiable.Credential) ([]*Mismatch, error) {
+	if constraints == nil {
+		return nil, nil
+	}
+
+	var mismatches []*Mismatch
+
+	if constraints.SubjectIsIssuer.isRequired() && !subjectIsIssuer(credential) {
+		mismatches = append(mismatches, &Mismatch{
+			Reason:  MismatchSubjectIsIssuer,
+			Details: "credential subject is not the issuer",
+		})
+	}
+
+	if len(constraints.Fields) == 0 {
+		return mismatches, nil
+	}
+
+	_, credentialMap, err := credentialFieldValues(credential)
+	if errors.Is(err, errNoFieldValues) {
+		return append(mismatches, &Mismatch{Reason: MismatchClaims, Details: err.Error()}), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, field := range constraints.Fields {
+		var mismatch *fieldMismatch
+
+		err = filterField(field, credentialMap)
+		if errors.As(err, &mismatch) {
+			mismatches = append(mismatches, &Mismatch{
+				Reason:  mismatch.reason,
+				FieldID: field.ID,
+				Path:    field.Path,
+				Details: mismatch.details,
+			})
+
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("filter field.%d: %w", i, err)
+		}
+	}
+
+	return mismatches, nil
+}
+
+// formatNames returns the names of the entries of the format.
+func formatNames(format *Format) []string {
+	var names []string
+
+	for _, name := range credentialFormats {
+		if format.only(name) != nil {
+			names = append(names, name)
+		}
+	}
+
+	if format.MsoMdoc != nil {
+		names = append(names, FormatMsoMdoc)
+	}
+
+	return names
+}
+
+// explainRelationalConstraints evaluates the is_holder and same_subject constraints against the credentials
+// explained as matching, and explains the credentials that do not satisfy them.
+func explainRelationalConstraints(descriptors []*InputDescriptor, reqs []*MatchedSubmissionRequirement) {
+	matchedDescs := matchedDescriptors(reqs)
+
+	matched := make(map[string][]*credWrapper, len(matchedDescs))
+
+	for _, desc := range matchedDescs {
+		for _, explanation := range desc.Explanations {
+			if explanation.Matched {
+				matched[desc.ID] = append(matched[desc.ID], &credWrapper{vc: explanation.Credential})
+			}
+		}
+	}
+
+	if err := evalRelationalConstraints(descriptors, matched); err != nil {
+		logger.Debugf("explained credentials filtered out: %s", err)
+	}
+
+	for _, desc := range matchedDescs {
+		kept := map[*verifiable.Credential]bool{}
+
+		for _, cred := range matched[desc.ID] {
+			kept[cred.vc] = true
+		}
+
+		for _, explanation := range desc.Explanations {
+			if !explanation.Matched || kept[explanation.Credential] {
+				continue
+			}
+
+			explanation.Matched = false
+			explanation.Mismatches = append(explanation.Mismatches, &Mismatch{
+				Reason:  MismatchRelational,
+				Details: "credential subject does not satisfy the is_holder or same_subject constraints",
+			})
+		}
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/hyperledger/aries-framework-go/component/models/presexch"
+	"github.com/hyperledger/aries-framework-go/component/models/verifiable"
+)
+
+func TestPresentationDefinition_MatchSubmissionRequirement_Explanation(t *testing.T) {
+	lddl := createTestJSONLDDocumentLoader(t)
+
+	intFilterType := "integer"
+
+	license := newSubjectVC(holderDID, map[string]interface{}{"license_number": "123-456", "age": 16})
+	passport := newSubjectVC(holderDID, map[string]interface{}{"birth_date": "1990-01-01", "age": 33})
+
+	t.Run("field missing and field filter", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID: "age-check",
+			InputDescriptors: []*InputDescriptor{{
+				ID: "adult",
+				Constraints: &Constraints{Fields: []*Field{
+					{
+						ID:   "birth_date",
+						Path: []string{"$.credentialSubject.birth_date"},
+					},
+					{
+						ID:     "age",
+						Path:   []string{"$.credentialSubject.age"},
+						Filter: &Filter{Type: &intFilterType, Minimum: 18},
+					},
+					{
+						ID:       "portrait",
+						Path:     []string{"$.credentialSubject.portrait"},
+						Optional: true,
+					},
+				}},
+			}},
+		}
+
+		matched, err := pd.MatchSubmissionRequirement([]*verifiable.Credential{license, passport}, lddl,
+			WithExplanation())
+		require.NoError(t, err)
+		require.Len(t, matched, 1)
+		require.Len(t, matched[0].Descriptors, 1)
+
+		desc := matched[0].Descriptors[0]
+		require.Equal(t, []*verifiable.Credential{passport}, desc.MatchedVCs)
+		require.Len(t, desc.Explanations, 2)
+
+		licenseExpl := desc.Explanations[0]
+		require.Equal(t, license, licenseExpl.Credential)
+		require.False(t, licenseExpl.Matched)
+		require.Len(t, licenseExpl.Mismatches, 2)
+
+		require.Equal(t, MismatchFieldMissing, licenseExpl.Mismatches[0].Reason)
+		require.Equal(t, "birth_date", licenseExpl.Mismatches[0].FieldID)
+		require.Equal(t, "credential lacks $.credentialSubject.birth_date", licenseExpl.Mismatches[0].Details)
+
+		require.Equal(t, MismatchFieldFilter, licenseExpl.Mismatches[1].Reason)
+		require.Equal(t, "age", licenseExpl.Mismatches[1].FieldID)
+		require.Equal(t, []string{"$.credentialSubject.age"}, licenseExpl.Mismatches[1].Path)
+		require.Contains(t, licenseExpl.Mismatches[1].Details, "greater than or equal to 18")
+
+		require.Equal(t, &CredentialExplanation{Credential: passport, Matched: true}, desc.Explanations[1])
+	})
+
+	t.Run("format and subject is issuer", func(t *testing.T) {
+		required := Required
+
+		pd := &PresentationDefinition{
+			ID: "jwt-only",
+			InputDescriptors: []*InputDescriptor{{
+				ID:     "jwt",
+				Format: &Format{JwtVC: &JwtType{Alg: []string{"EdDSA"}}, SDJWTVC: &SDJWTType{}},
+				Constraints: &Constraints{
+					SubjectIsIssuer: &required,
+				},
+			}},
+		}
+
+		matched, err := pd.MatchSubmissionRequirement([]*verifiable.Credential{license}, lddl, WithExplanation())
+		require.NoError(t, err)
+
+		expl := matched[0].Descriptors[0].Explanations[0]
+		require.False(t, expl.Matched)
+		require.Len(t, expl.Mismatches, 2)
+		require.Equal(t, MismatchFormat, expl.Mismatches[0].Reason)
+		require.Equal(t, []string{FormatSDJWTVC, FormatJWTVC}, expl.Mismatches[0].Expected)
+		require.Equal(t, MismatchSubjectIsIssuer, expl.Mismatches[1].Reason)
+	})
+
+	t.Run("schema", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID: "schema",
+			InputDescriptors: []*InputDescriptor{{
+				ID:     "schema",
+				Schema: []*Schema{{URI: "https://example.org/examples#UniversityDegreeCredential", Required: true}},
+			}},
+		}
+
+		matched, err := pd.MatchSubmissionRequirement([]*verifiable.Credential{license}, lddl, WithExplanation())
+		require.NoError(t, err)
+
+		expl := matched[0].Descriptors[0].Explanations[0]
+		require.False(t, expl.Matched)
+		require.Len(t, expl.Mismatches, 1)
+		require.Equal(t, MismatchSchema, expl.Mismatches[0].Reason)
+		require.Equal(t, []string{"https://example.org/examples#UniversityDegreeCredential"},
+			expl.Mismatches[0].Expected)
+
+		pd.InputDescriptors[0].Schema[0].URI = fmt.Sprintf("%s#%s", verifiable.ContextID, verifiable.VCType)
+
+		matched, err = pd.MatchSubmissionRequirement([]*verifiable.Credential{license}, lddl, WithExplanation())
+		require.NoError(t, err)
+		require.True(t, matched[0].Descriptors[0].Explanations[0].Matched)
+	})
+
+	t.Run("relational constraints", func(t *testing.T) {
+		var pd *PresentationDefinition
+		parseJSONFile(t, "testdata/v2/pd_relational.json", &pd)
+
+		otherPassport := newSubjectVC(otherDID, map[string]interface{}{"birth_date": "1980-01-01"})
+
+		matched, err := pd.MatchSubmissionRequirement([]*verifiable.Credential{license, otherPassport}, lddl,
+			WithExplanation())
+		require.NoError(t, err)
+		require.Len(t, matched[0].Descriptors, 2)
+
+		for _, desc := range matched[0].Descriptors {
+			require.Empty(t, desc.MatchedVCs)
+
+			for _, expl := range desc.Explanations {
+				require.False(t, expl.Matched)
+			}
+		}
+
+		licenseExpl := matched[0].Descriptors[0].Explanations[0]
+		require.Len(t, licenseExpl.Mismatches, 1)
+		require.Equal(t, MismatchRelational, licenseExpl.Mismatches[0].Reason)
+
+		passportExpl := matched[0].Descriptors[1].Explanations[1]
+		require.Equal(t, otherPassport, passportExpl.Credential)
+		require.Len(t, passportExpl.Mismatches, 1)
+		require.Equal(t, MismatchRelational, passportExpl.Mismatches[0].Reason)
+
+		matched, err = pd.MatchSubmissionRequirement([]*verifiable.Credential{license, passport}, lddl,
+			WithExplanation())
+		require.NoError(t, err)
+
+		for _, desc := range matched[0].Descriptors {
+			require.Len(t, desc.MatchedVCs, 1)
+			require.Len(t, desc.Explanations, 2)
+
+			for _, expl := range desc.Explanations {
+				require.Equal(t, expl.Credential == desc.MatchedVCs[0], expl.Matched)
+			}
+		}
+	})
+
+	t.Run("no explanation by default", func(t *testing.T) {
+		var pd *PresentationDefinition
+		parseJSONFile(t, "testdata/v2/pd_relational.json", &pd)
+
+		matched, err := pd.MatchSubmissionRequirement([]*verifiable.Credential{license, passport}, lddl)
+		require.NoError(t, err)
+
+		for _, desc := range matched[0].Descriptors {
+			require.Nil(t, desc.Explanations)
+		}
+	})
+}
@@ -0,0 +1,195 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/fingerprint"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite/ecdsasd2023"
+	. "github.com/hyperledger/aries-framework-go/component/models/presexch"
+	utiltime "github.com/hyperledger/aries-framework-go/component/models/util/time"
+	"github.com/hyperledger/aries-framework-go/component/models/verifiable"
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+func TestPresentationDefinition_Formats(t *testing.T) {
+	lddl := createTestJSONLDDocumentLoader(t)
+
+	ed25519Signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	sdJwtVC := newSdJwtVC(t, getTestVC(), ed25519Signer)
+
+	jwtVC := getTestVC()
+	jwtVC.Issuer.ID, _ = fingerprint.CreateDIDKeyByCode(fingerprint.ED25519PubKeyMultiCodec,
+		ed25519Signer.PublicKeyBytes())
+	jwtVC.JWT = createEdDSAJWS(t, jwtVC, ed25519Signer, "1", true)
+
+	ecdsaSDVC := newSubjectVC(holderDID, map[string]interface{}{"given_name": "John"})
+	ecdsaSDVC.Issuer.ID = "did:example:489398593"
+	ecdsaSDVC.Issued = utiltime.NewTime(time.Now())
+
+	signer, _ := newECDSASDSignerAndVerifier(t)
+
+	require.NoError(t, ecdsaSDVC.AddDataIntegrityProof(&verifiable.DataIntegrityProofContext{
+		SigningKeyID: "did:example:489398593#key-1",
+		CryptoSuite:  ecdsasd2023.SuiteType,
+	}, signer))
+
+	credOpts := WithCredentialOptions(verifiable.WithJSONLDDocumentLoader(lddl), verifiable.WithDisabledProofCheck())
+
+	tests := []struct {
+		name      string
+		format    *Format
+		cred      *verifiable.Credential
+		vpFormat  string
+		vcFormat  string
+		noMatches []*Format
+	}{
+		{
+			name:     "vc+sd-jwt",
+			format:   &Format{SDJWTVC: &SDJWTType{SDJWTAlgValues: []string{"EdDSA"}}},
+			cred:     sdJwtVC,
+			vpFormat: FormatSDJWTVC,
+			vcFormat: FormatSDJWTVC,
+			noMatches: []*Format{
+				{SDJWTVC: &SDJWTType{SDJWTAlgValues: []string{"ES256"}}},
+				{JwtVCJSON: &JwtType{Alg: []string{"ES256"}}},
+			},
+		},
+		{
+			name:     "vc+sd-jwt without format",
+			cred:     sdJwtVC,
+			vpFormat: FormatLDPVP,
+			vcFormat: FormatSDJWTVC,
+		},
+		{
+			name:     "jwt_vc_json",
+			format:   &Format{JwtVCJSON: &JwtType{Alg: []string{"EdDSA"}}},
+			cred:     jwtVC,
+			vpFormat: FormatJWTVCJSON,
+			vcFormat: FormatJWTVCJSON,
+			noMatches: []*Format{
+				{JwtVCJSON: &JwtType{Alg: []string{"ES256"}}},
+				{SDJWTVC: &SDJWTType{}},
+			},
+		},
+		{
+			name:     "ldp_vc with cryptosuite",
+			format:   &Format{LdpVC: &LdpType{Cryptosuite: []string{ecdsasd2023.SuiteType}}},
+			cred:     ecdsaSDVC,
+			vpFormat: FormatLDPVC,
+			vcFormat: FormatLDPVC,
+			noMatches: []*Format{
+				{LdpVC: &LdpType{Cryptosuite: []string{"eddsa-rdfc-2022"}}},
+				{LdpVC: &LdpType{ProofType: []string{"Ed25519Signature2018"}}},
+				{LdpVC: &LdpType{}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pd := &PresentationDefinition{
+				ID: uuid.NewString(),
+				InputDescriptors: []*InputDescriptor{{
+					ID:     uuid.NewString(),
+					Format: tc.format,
+				}},
+			}
+
+			vp, err := pd.CreateVP([]*verifiable.Credential{tc.cred}, lddl,
+				verifiable.WithJSONLDDocumentLoader(lddl))
+			require.NoError(t, err)
+
+			ps, ok := vp.CustomFields["presentation_submission"].(*PresentationSubmission)
+			require.True(t, ok)
+			require.Len(t, ps.DescriptorMap, 1)
+			require.Equal(t, tc.vpFormat, ps.DescriptorMap[0].Format)
+			require.NotNil(t, ps.DescriptorMap[0].PathNested)
+			require.Equal(t, tc.vcFormat, ps.DescriptorMap[0].PathNested.Format)
+			require.Equal(t, "$.verifiableCredential[0]", ps.DescriptorMap[0].PathNested.Path)
+
+			matched, err := pd.Match([]*verifiable.Presentation{vp}, lddl, credOpts)
+			require.NoError(t, err)
+			require.Len(t, matched, 1)
+
+			for _, format := range tc.noMatches {
+				pd.InputDescriptors[0].Format = format
+
+				_, err = pd.CreateVP([]*verifiable.Credential{tc.cred}, lddl,
+					verifiable.WithJSONLDDocumentLoader(lddl))
+				require.ErrorIs(t, err, ErrNoCredentials)
+
+				_, err = pd.Match([]*verifiable.Presentation{vp}, lddl, credOpts)
+				require.Error(t, err)
+			}
+		})
+	}
+
+	t.Run("Match - format not allowed", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID: uuid.NewString(),
+			InputDescriptors: []*InputDescriptor{{
+				ID:     uuid.NewString(),
+				Format: &Format{SDJWTVC: &SDJWTType{}},
+			}},
+		}
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{sdJwtVC}, lddl, verifiable.WithJSONLDDocumentLoader(lddl))
+		require.NoError(t, err)
+
+		pd.InputDescriptors[0].Format = &Format{JwtVCJSON: &JwtType{Alg: []string{"EdDSA"}}}
+
+		_, err = pd.Match([]*verifiable.Presentation{vp}, lddl, credOpts)
+		require.ErrorContains(t, err, "descriptor map format [vc+sd-jwt vc+sd-jwt] is not allowed")
+	})
+
+	t.Run("Match - credential does not satisfy the format", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID: uuid.NewString(),
+			InputDescriptors: []*InputDescriptor{{
+				ID:     uuid.NewString(),
+				Format: &Format{SDJWTVC: &SDJWTType{}},
+			}},
+		}
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{sdJwtVC}, lddl, verifiable.WithJSONLDDocumentLoader(lddl))
+		require.NoError(t, err)
+
+		pd.Format = &Format{SDJWTVC: &SDJWTType{KBJWTAlgValues: []string{"ES256"}}}
+		pd.InputDescriptors[0].Format = &Format{SDJWTVC: &SDJWTType{SDJWTAlgValues: []string{"ES384"}}}
+
+		_, err = pd.Match([]*verifiable.Presentation{vp}, lddl, credOpts)
+		require.ErrorContains(t, err, "does not satisfy format vc+sd-jwt")
+	})
+
+	t.Run("schema", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID: uuid.NewString(),
+			Format: &Format{
+				SDJWTVC:   &SDJWTType{SDJWTAlgValues: []string{"EdDSA"}, KBJWTAlgValues: []string{"ES256"}},
+				JwtVCJSON: &JwtType{Alg: []string{"EdDSA"}},
+				JwtVPJSON: &JwtType{Alg: []string{"EdDSA"}},
+				LdpVC:     &LdpType{Cryptosuite: []string{ecdsasd2023.SuiteType}},
+				MsoMdoc:   &MsoMdocType{Alg: []string{"ES256"}},
+			},
+			InputDescriptors: []*InputDescriptor{{ID: uuid.NewString()}},
+		}
+
+		require.NoError(t, pd.ValidateSchema())
+
+		pd.InputDescriptors[0].Schema = []*Schema{{URI: verifiable.ContextURI}}
+
+		require.NoError(t, pd.ValidateSchema())
+	})
+}
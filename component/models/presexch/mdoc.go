@@ -0,0 +1,145 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hyperledger/aries-framework-go/component/models/mdoc"
+)
+
+// mdocPathRegex matches the paths of mdoc data elements, ie $['<name space>']['<element identifier>'].
+var mdocPathRegex = regexp.MustCompile(`^\$\['([^']+)'\]\['([^']+)'\]$`)
+
+// MdocMatch is a mdoc matching an InputDescriptor.
+type MdocMatch struct {
+	// DescriptorID is the ID of the matched InputDescriptor.
+	DescriptorID string
+	// Document is the matched mdoc, disclosing only the data elements requested by the InputDescriptor.
+	Document *mdoc.Document
+}
+
+// MatchMdoc matches the mdocs against the InputDescriptors with the mso_mdoc format. The ID of an mso_mdoc
+// InputDescriptor is the doc type of the requested mdoc, and its constraint fields reference data elements
+// by name space and element identifier, eg $['org.iso.18013.5.1']['family_name'].
+//
+// Every mdoc of the requested doc type, signed with one of the requested algorithms and satisfying
+// the constraints, is returned with only the requested data elements disclosed.
+func (pd *PresentationDefinition) MatchMdoc(docs []*mdoc.Document) ([]*MdocMatch, error) {
+	var result []*MdocMatch
+
+	for _, descriptor := range pd.InputDescriptors {
+		format := pd.descriptorFormat(descriptor)
+		if format == nil || format.MsoMdoc == nil {
+			continue
+		}
+
+		for _, doc := range docs {
+			if doc.DocType != descriptor.ID || !mdocAlgMatches(doc, format.MsoMdoc) {
+				continue
+			}
+
+			disclosed, err := matchMdocConstraints(doc, descriptor.Constraints)
+			if err != nil {
+				return nil, fmt.Errorf("match mdoc against input descriptor %s: %w", descriptor.ID, err)
+			}
+
+			if disclosed != nil {
+				result = append(result, &MdocMatch{DescriptorID: descriptor.ID, Document: disclosed})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func mdocAlgMatches(doc *mdoc.Document, format *MsoMdocType) bool {
+	if len(format.Alg) == 0 {
+		return true
+	}
+
+	if doc.IssuerSigned.IssuerAuth == nil {
+		return false
+	}
+
+	alg, err := doc.IssuerSigned.IssuerAuth.Algorithm()
+	if err != nil {
+		return false
+	}
+
+	return contains(format.Alg, mdoc.AlgorithmName(alg))
+}
+
+// matchMdocConstraints returns the mdoc disclosing the data elements requested by the constraints,
+// or nil if the mdoc does not satisfy them.
+func matchMdocConstraints(doc *mdoc.Document, constraints *Constraints) (*mdoc.Document, error) {
+	if constraints == nil || len(constraints.Fields) == 0 {
+		return doc, nil
+	}
+
+	claims, err := doc.Claims()
+	if err != nil {
+		return nil, err
+	}
+
+	claimsMap := make(map[string]interface{}, len(claims))
+	for nameSpace, elements := range claims {
+		claimsMap[nameSpace] = elements
+	}
+
+	disclose := map[string][]string{}
+
+	for _, field := range constraints.Fields {
+		elements, err := mdocFieldElements(field)
+		if err != nil {
+			return nil, err
+		}
+
+		// jsonpath does not support single quoted names, so the field is evaluated with double quoted paths.
+		mdocField := *field
+		mdocField.Path = make([]string, len(elements))
+
+		for i, element := range elements {
+			mdocField.Path[i] = fmt.Sprintf("$[%q][%q]", element[0], element[1])
+		}
+
+		if err = filterField(&mdocField, claimsMap); err != nil {
+			return nil, nil //nolint:nilerr // the mdoc does not satisfy the constraints.
+		}
+
+		for _, element := range elements {
+			nameSpace, identifier := element[0], element[1]
+
+			if _, ok := claims[nameSpace][identifier]; ok {
+				if !contains(disclose[nameSpace], identifier) {
+					disclose[nameSpace] = append(disclose[nameSpace], identifier)
+				}
+
+				break
+			}
+		}
+	}
+
+	return doc.Disclose(disclose)
+}
+
+// mdocFieldElements returns the name space and element identifier referenced by each path of the field.
+func mdocFieldElements(field *Field) ([][2]string, error) {
+	elements := make([][2]string, len(field.Path))
+
+	for i, path := range field.Path {
+		matches := mdocPathRegex.FindStringSubmatch(path)
+		if matches == nil {
+			return nil, fmt.Errorf("invalid mdoc data element path %s", path)
+		}
+
+		elements[i] = [2]string{matches[1], matches[2]}
+	}
+
+	return elements, nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/models/mdoc"
+	. "github.com/hyperledger/aries-framework-go/component/models/presexch"
+)
+
+const mdocPresentationDefinition = `{
+  "id": "mdl-request",
+  "input_descriptors": [
+    {
+      "id": "org.iso.18013.5.1.mDL",
+      "format": {
+        "mso_mdoc": {
+          "alg": ["ES256", "EdDSA"]
+        }
+      },
+      "constraints": {
+        "limit_disclosure": "required",
+        "fields": [
+          {
+            "path": ["$['org.iso.18013.5.1']['family_name']"],
+            "intent_to_retain": false
+          },
+          {
+            "path": ["$['org.iso.18013.5.1']['age_over_18']"],
+            "filter": {"type": "boolean", "const": true}
+          },
+          {
+            "path": ["$['org.iso.18013.5.1']['portrait']"],
+            "optional": true
+          }
+        ]
+      }
+    }
+  ]
+}`
+
+func TestPresentationDefinition_MatchMdoc(t *testing.T) {
+	mdl := issueTestMdoc(t, mdoc.DocTypeMDL, map[string]interface{}{
+		"family_name": "Doe",
+		"given_name":  "John",
+		"age_over_18": true,
+	})
+
+	other := issueTestMdoc(t, "org.example.doc", map[string]interface{}{"family_name": "Doe"})
+
+	t.Run("match and disclose requested data elements", func(t *testing.T) {
+		pd := &PresentationDefinition{}
+		require.NoError(t, json.Unmarshal([]byte(mdocPresentationDefinition), pd))
+		require.NoError(t, pd.ValidateSchema())
+
+		matches, err := pd.MatchMdoc([]*mdoc.Document{other, mdl})
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+		require.Equal(t, mdoc.DocTypeMDL, matches[0].DescriptorID)
+
+		claims, err := matches[0].Document.Claims()
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"family_name": "Doe", "age_over_18": true}, claims[mdoc.NameSpaceMDL])
+	})
+
+	t.Run("format set on the presentation definition", func(t *testing.T) {
+		pd := &PresentationDefinition{
+			ID:     "mdl-request",
+			Format: &Format{MsoMdoc: &MsoMdocType{Alg: []string{"ES256"}}},
+			InputDescriptors: []*InputDescriptor{{
+				ID: mdoc.DocTypeMDL,
+			}},
+		}
+
+		matches, err := pd.MatchMdoc([]*mdoc.Document{mdl})
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+		require.Equal(t, mdl, matches[0].Document)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		tests := []struct {
+			name string
+			pd   *PresentationDefinition
+		}{
+			{
+				name: "not an mso_mdoc input descriptor",
+				pd: &PresentationDefinition{InputDescriptors: []*InputDescriptor{{
+					ID:     mdoc.DocTypeMDL,
+					Format: &Format{JwtVC: &JwtType{Alg: []string{"ES256"}}},
+				}}},
+			},
+			{
+				name: "algorithm mismatch",
+				pd: &PresentationDefinition{InputDescriptors: []*InputDescriptor{{
+					ID:     mdoc.DocTypeMDL,
+					Format: &Format{MsoMdoc: &MsoMdocType{Alg: []string{"ES384"}}},
+				}}},
+			},
+			{
+				name: "constraints not satisfied",
+				pd: &PresentationDefinition{InputDescriptors: []*InputDescriptor{{
+					ID:     mdoc.DocTypeMDL,
+					Format: &Format{MsoMdoc: &MsoMdocType{}},
+					Constraints: &Constraints{Fields: []*Field{{
+						Path: []string{"$['org.iso.18013.5.1']['birth_date']"},
+					}}},
+				}}},
+			},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				matches, err := tc.pd.MatchMdoc([]*mdoc.Document{mdl})
+				require.NoError(t, err)
+				require.Empty(t, matches)
+			})
+		}
+	})
+
+	t.Run("error - invalid data element path", func(t *testing.T) {
+		pd := &PresentationDefinition{InputDescriptors: []*InputDescriptor{{
+			ID:     mdoc.DocTypeMDL,
+			Format: &Format{MsoMdoc: &MsoMdocType{}},
+			Constraints: &Constraints{Fields: []*Field{{
+				Path: []string{"$.family_name"},
+			}}},
+		}}}
+
+		_, err := pd.MatchMdoc([]*mdoc.Document{mdl})
+		require.ErrorContains(t, err, "invalid mdoc data element path $.family_name")
+	})
+}
+
+func issueTestMdoc(t *testing.T, docType string, elements map[string]interface{}) *mdoc.Document {
+	t.Helper()
+
+	issuerKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "mdoc issuer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &issuerKey.PublicKey, issuerKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(certBytes)
+	require.NoError(t, err)
+
+	deviceKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	doc, err := mdoc.Issue(docType, map[string]map[string]interface{}{mdoc.NameSpaceMDL: elements},
+		&deviceKey.PublicKey, issuerKey, []*x509.Certificate{cert})
+	require.NoError(t, err)
+
+	return doc
+}
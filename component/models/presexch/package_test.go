@@ -105,3 +105,15 @@ func Test_mDLNestedCtx(t *testing.T) {
 		require.Len(t, matched, 0)
 	})
 }
+
+func Test_mandatoryRevealFields(t *testing.T) {
+	t.Run("VC 1.1", func(t *testing.T) {
+		fields := mandatoryRevealFields(&verifiable.Credential{Context: []string{verifiable.ContextURI}})
+		require.Equal(t, []string{"issuer", "issuanceDate", "expirationDate"}, fields)
+	})
+
+	t.Run("VC 2.0", func(t *testing.T) {
+		fields := mandatoryRevealFields(&verifiable.Credential{Context: []string{contextV2}})
+		require.Equal(t, []string{"issuer", "validFrom", "validUntil"}, fields)
+	})
+}
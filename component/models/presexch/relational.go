@@ -0,0 +1,208 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/component/models/verifiable"
+)
+
+// errRelationalConstraint is returned when a required is_holder or same_subject constraint is not satisfied.
+var errRelationalConstraint = errors.New("relational constraint is not satisfied")
+
+// evalRelationalConstraints evaluates the is_holder and same_subject constraints of the descriptors against
+// the credentials matched by each descriptor, keyed by descriptor ID. Constraint fields are referenced by ID,
+// so a constraint of one descriptor may apply to the credentials matched by other descriptors.
+//
+// Credentials that do not satisfy a constraint are removed from the matches. If a required constraint cannot
+// be satisfied, the descriptors it applies to are left without credentials and the first such failure is
+// returned, wrapping errRelationalConstraint. A preferred constraint is applied only if it can be satisfied.
+func evalRelationalConstraints(descriptors []*InputDescriptor, matched map[string][]*credWrapper) error {
+	var firstErr error
+
+	for _, descriptor := range descriptors {
+		if descriptor.Constraints == nil {
+			continue
+		}
+
+		for _, isHolder := range descriptor.Constraints.IsHolder {
+			err := applyRelationalConstraint(descriptors, matched, isHolder, true)
+			if err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("is_holder of input descriptor %s: %w", descriptor.ID, err)
+			}
+		}
+
+		for _, sameSubject := range descriptor.Constraints.SameSubject {
+			err := applyRelationalConstraint(descriptors, matched, sameSubject, false)
+			if err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("same_subject of input descriptor %s: %w", descriptor.ID, err)
+			}
+		}
+	}
+
+	return firstErr
+}
+
+// applyRelationalConstraint keeps the credentials, matched by the descriptors holding the fields of the constraint,
+// whose subject is common to all of them. With isHolder, the subject of a presented credential must also be the
+// holder of the presentation holding it.
+func applyRelationalConstraint(descriptors []*InputDescriptor, matched map[string][]*credWrapper,
+	constraint *Holder, isHolder bool) error {
+	descIDs := descriptorsWithFields(descriptors, constraint.FieldID)
+
+	var common map[string]bool
+
+	for _, descID := range descIDs {
+		creds := matched[descID]
+		if len(creds) == 0 {
+			continue
+		}
+
+		subjects := map[string]bool{}
+
+		for _, cred := range creds {
+			for _, subject := range credSubjects(cred, isHolder) {
+				if common == nil || common[subject] {
+					subjects[subject] = true
+				}
+			}
+		}
+
+		common = subjects
+	}
+
+	if common == nil {
+		return nil
+	}
+
+	filtered := make(map[string][]*credWrapper, len(descIDs))
+
+	for _, descID := range descIDs {
+		creds := matched[descID]
+		if len(creds) == 0 {
+			continue
+		}
+
+		for _, cred := range creds {
+			for _, subject := range credSubjects(cred, isHolder) {
+				if common[subject] {
+					filtered[descID] = append(filtered[descID], cred)
+
+					break
+				}
+			}
+		}
+
+		if len(filtered[descID]) == 0 {
+			if constraint.Directive.isRequired() {
+				for _, id := range descIDs {
+					delete(matched, id)
+				}
+
+				return errRelationalConstraint
+			}
+
+			// preferred constraint cannot be satisfied, so matches are kept as is.
+			return nil
+		}
+	}
+
+	for descID, creds := range filtered {
+		matched[descID] = creds
+	}
+
+	return nil
+}
+
+// credSubjects returns the subject IDs of the credential. With isHolder, only the subject that is the holder
+// of the presentation is returned for a presented credential, none if the presentation has no holder.
+func credSubjects(cred *credWrapper, isHolder bool) []string {
+	subjects := getSubjectIDs(cred.vc.Subject)
+
+	if !isHolder || !cred.presented {
+		return subjects
+	}
+
+	if cred.holder == "" {
+		return nil
+	}
+
+	for _, subject := range subjects {
+		if subject == cred.holder {
+			return []string{subject}
+		}
+	}
+
+	return nil
+}
+
+// descriptorsWithFields returns the IDs of the descriptors holding a constraint field with one of the field IDs.
+func descriptorsWithFields(descriptors []*InputDescriptor, fieldIDs []string) []string {
+	var descIDs []string
+
+	for _, descriptor := range descriptors {
+		if descriptor.Constraints == nil {
+			continue
+		}
+
+		for _, field := range descriptor.Constraints.Fields {
+			if field.ID != "" && contains(fieldIDs, field.ID) {
+				descIDs = append(descIDs, descriptor.ID)
+
+				break
+			}
+		}
+	}
+
+	return descIDs
+}
+
+// evalMatchedRelationalConstraints evaluates the is_holder and same_subject constraints against the credentials
+// matched by the descriptors of the submission requirements, see evalRelationalConstraints.
+func evalMatchedRelationalConstraints(descriptors []*InputDescriptor, reqs []*MatchedSubmissionRequirement) {
+	matchedDescs := matchedDescriptors(reqs)
+
+	matched := make(map[string][]*credWrapper, len(matchedDescs))
+
+	for _, desc := range matchedDescs {
+		creds := make([]*credWrapper, len(desc.MatchedVCs))
+
+		for i, vc := range desc.MatchedVCs {
+			creds[i] = &credWrapper{uniqueID: vc.ID, vc: vc}
+		}
+
+		matched[desc.ID] = creds
+	}
+
+	if err := evalRelationalConstraints(descriptors, matched); err != nil {
+		logger.Debugf("matched credentials filtered out: %s", err)
+	}
+
+	for _, desc := range matchedDescs {
+		var vcs []*verifiable.Credential
+
+		for _, cred := range matched[desc.ID] {
+			vcs = append(vcs, cred.vc)
+		}
+
+		desc.MatchedVCs = vcs
+	}
+}
+
+// matchedDescriptors returns the matched descriptors of the submission requirements and of their nested requirements.
+func matchedDescriptors(reqs []*MatchedSubmissionRequirement) []*MatchedInputDescriptor {
+	var matchedDescs []*MatchedInputDescriptor
+
+	for _, req := range reqs {
+		matchedDescs = append(matchedDescs, req.Descriptors...)
+		matchedDescs = append(matchedDescs, matchedDescriptors(req.Nested)...)
+	}
+
+	return matchedDescs
+}
@@ -0,0 +1,206 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/hyperledger/aries-framework-go/component/models/presexch"
+	utiltime "github.com/hyperledger/aries-framework-go/component/models/util/time"
+	"github.com/hyperledger/aries-framework-go/component/models/verifiable"
+)
+
+const (
+	holderDID = "did:example:holder"
+	otherDID  = "did:example:other"
+)
+
+func TestPresentationDefinition_RelationalConstraints(t *testing.T) {
+	lddl := createTestJSONLDDocumentLoader(t)
+
+	license := newSubjectVC(holderDID, map[string]interface{}{"license_number": "123-456"})
+	passport := newSubjectVC(holderDID, map[string]interface{}{"birth_date": "1990-01-01"})
+	otherPassport := newSubjectVC(otherDID, map[string]interface{}{"birth_date": "1980-01-01"})
+
+	t.Run("CreateVP - credentials of the same subject", func(t *testing.T) {
+		var pd *PresentationDefinition
+		parseJSONFile(t, "testdata/v2/pd_relational.json", &pd)
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{license, otherPassport, passport}, lddl,
+			verifiable.WithJSONLDDocumentLoader(lddl))
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{license.ID, passport.ID}, credentialIDs(vp))
+
+		checkSubmission(t, vp, pd)
+		checkVP(t, vp)
+	})
+
+	t.Run("CreateVP - required same_subject not satisfied", func(t *testing.T) {
+		var pd *PresentationDefinition
+		parseJSONFile(t, "testdata/v2/pd_relational.json", &pd)
+
+		_, err := pd.CreateVP([]*verifiable.Credential{license, otherPassport}, lddl,
+			verifiable.WithJSONLDDocumentLoader(lddl))
+		require.ErrorIs(t, err, ErrNoCredentials)
+	})
+
+	t.Run("CreateVP - preferred same_subject not satisfied", func(t *testing.T) {
+		var pd *PresentationDefinition
+		parseJSONFile(t, "testdata/v2/pd_relational.json", &pd)
+
+		preferred := Preferred
+		pd.InputDescriptors[0].Constraints.IsHolder[0].Directive = &preferred
+		pd.InputDescriptors[0].Constraints.SameSubject[0].Directive = &preferred
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{license, otherPassport}, lddl,
+			verifiable.WithJSONLDDocumentLoader(lddl))
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{license.ID, otherPassport.ID}, credentialIDs(vp))
+	})
+
+	t.Run("MatchSubmissionRequirement", func(t *testing.T) {
+		var pd *PresentationDefinition
+		parseJSONFile(t, "testdata/v2/pd_relational.json", &pd)
+
+		matched, err := pd.MatchSubmissionRequirement([]*verifiable.Credential{license, otherPassport, passport}, lddl)
+		require.NoError(t, err)
+		require.Len(t, matched, 1)
+		require.Len(t, matched[0].Descriptors, 2)
+
+		for _, desc := range matched[0].Descriptors {
+			require.Len(t, desc.MatchedVCs, 1)
+			require.Equal(t, holderDID, desc.MatchedVCs[0].Subject.([]verifiable.Subject)[0].ID)
+		}
+
+		matched, err = pd.MatchSubmissionRequirement([]*verifiable.Credential{license, otherPassport}, lddl)
+		require.NoError(t, err)
+		require.Len(t, matched, 1)
+
+		for _, desc := range matched[0].Descriptors {
+			require.Empty(t, desc.MatchedVCs)
+		}
+	})
+
+	t.Run("Match - is_holder", func(t *testing.T) {
+		for _, tc := range []struct {
+			name      string
+			directive Preference
+			holder    string
+			err       string
+		}{
+			{name: "required - holder is subject", directive: Required, holder: holderDID},
+			{name: "required - holder is not subject", directive: Required, holder: otherDID,
+				err: "is_holder of input descriptor drivers_license_input"},
+			{name: "required - no holder", directive: Required,
+				err: "is_holder of input descriptor drivers_license_input"},
+			{name: "preferred - holder is not subject", directive: Preferred, holder: otherDID},
+			{name: "preferred - no holder", directive: Preferred},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				var pd *PresentationDefinition
+				parseJSONFile(t, "testdata/v2/pd_relational.json", &pd)
+
+				directive := tc.directive
+				pd.InputDescriptors[0].Constraints.IsHolder[0].Directive = &directive
+
+				vp, err := pd.CreateVP([]*verifiable.Credential{license, passport}, lddl,
+					verifiable.WithJSONLDDocumentLoader(lddl))
+				require.NoError(t, err)
+
+				vp.Holder = tc.holder
+
+				matched, err := pd.Match([]*verifiable.Presentation{vp}, lddl, WithCredentialOptions(
+					verifiable.WithJSONLDDocumentLoader(lddl), verifiable.WithDisabledProofCheck()))
+				if tc.err != "" {
+					require.ErrorContains(t, err, tc.err)
+
+					return
+				}
+
+				require.NoError(t, err)
+				require.Len(t, matched, 2)
+			})
+		}
+	})
+
+	t.Run("Match - same_subject", func(t *testing.T) {
+		var pd *PresentationDefinition
+		parseJSONFile(t, "testdata/v2/pd_relational.json", &pd)
+
+		pd.InputDescriptors[0].Constraints.IsHolder = nil
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{license, otherPassport}, lddl,
+			verifiable.WithJSONLDDocumentLoader(lddl))
+		require.ErrorIs(t, err, ErrNoCredentials)
+		require.Nil(t, vp)
+
+		preferred := Preferred
+		pd.InputDescriptors[0].Constraints.SameSubject[0].Directive = &preferred
+
+		vp, err = pd.CreateVP([]*verifiable.Credential{license, otherPassport}, lddl,
+			verifiable.WithJSONLDDocumentLoader(lddl))
+		require.NoError(t, err)
+
+		required := Required
+		pd.InputDescriptors[0].Constraints.SameSubject[0].Directive = &required
+
+		_, err = pd.Match([]*verifiable.Presentation{vp}, lddl, WithCredentialOptions(
+			verifiable.WithJSONLDDocumentLoader(lddl), verifiable.WithDisabledProofCheck()))
+		require.ErrorContains(t, err, "same_subject of input descriptor drivers_license_input")
+	})
+
+	t.Run("CreateVP - constraints without fields", func(t *testing.T) {
+		subjectIsIssuer := Preferred
+
+		pd := &PresentationDefinition{
+			ID: uuid.NewString(),
+			InputDescriptors: []*InputDescriptor{{
+				ID:          uuid.NewString(),
+				Constraints: &Constraints{SubjectIsIssuer: &subjectIsIssuer},
+			}},
+		}
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{license, passport}, lddl,
+			verifiable.WithJSONLDDocumentLoader(lddl))
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{license.ID, passport.ID}, credentialIDs(vp))
+
+		required := Required
+		pd.InputDescriptors[0].Constraints.SubjectIsIssuer = &required
+
+		_, err = pd.CreateVP([]*verifiable.Credential{license, passport}, lddl,
+			verifiable.WithJSONLDDocumentLoader(lddl))
+		require.ErrorIs(t, err, ErrNoCredentials)
+	})
+}
+
+func newSubjectVC(subjectID string, claims map[string]interface{}) *verifiable.Credential {
+	subject := verifiable.Subject{ID: subjectID, CustomFields: claims}
+
+	return &verifiable.Credential{
+		Context: []string{verifiable.ContextURI},
+		Types:   []string{verifiable.VCType},
+		ID:      "http://example.edu/credentials/" + uuid.NewString(),
+		Issued:  utiltime.NewTime(time.Now()),
+		Issuer:  verifiable.Issuer{ID: "did:example:issuer"},
+		Subject: []verifiable.Subject{subject},
+	}
+}
+
+func credentialIDs(vp *verifiable.Presentation) []string {
+	var ids []string
+
+	for _, cred := range vp.Credentials() {
+		ids = append(ids, cred.(*verifiable.Credential).ID)
+	}
+
+	return ids
+}
@@ -99,7 +99,23 @@ const DefinitionJSONSchemaV1 = `
       "format":{
          "type":"object",
          "patternProperties":{
-            "^jwt$|^jwt_vc$|^jwt_vp$":{
+            "^jwt$|^jwt_vc$|^jwt_vp$|^jwt_vc_json$|^jwt_vp_json$":{
+               "type":"object",
+               "properties":{
+                  "alg":{
+                     "type":"array",
+                     "minItems":1,
+                     "items":{
+                        "type":"string"
+                     }
+                  }
+               },
+               "required":[
+                  "alg"
+               ],
+               "additionalProperties":false
+            },
+            "^mso_mdoc$":{
                "type":"object",
                "properties":{
                   "alg":{
@@ -124,13 +140,49 @@ const DefinitionJSONSchemaV1 = `
                      "items":{
                         "type":"string"
                      }
+                  },
+                  "cryptosuite":{
+                     "type":"array",
+                     "minItems":1,
+                     "items":{
+                        "type":"string"
+                     }
                   }
                },
-               "required":[
-                  "proof_type"
+               "anyOf":[
+                  {
+                     "required":[
+                        "proof_type"
+                     ]
+                  },
+                  {
+                     "required":[
+                        "cryptosuite"
+                     ]
+                  }
                ],
                "additionalProperties":false
             },
+            "^vc\\+sd-jwt$":{
+               "type":"object",
+               "properties":{
+                  "sd-jwt_alg_values":{
+                     "type":"array",
+                     "minItems":1,
+                     "items":{
+                        "type":"string"
+                     }
+                  },
+                  "kb-jwt_alg_values":{
+                     "type":"array",
+                     "minItems":1,
+                     "items":{
+                        "type":"string"
+                     }
+                  }
+               },
+               "additionalProperties":false
+            },
             "additionalProperties":false
          },
          "additionalProperties":false
@@ -544,7 +596,18 @@ const DefinitionJSONSchemaV2 = `
 		  "type": "object",
 		  "additionalProperties": false,
 		  "patternProperties": {
-			"^jwt$|^jwt_vc$|^jwt_vp$": {
+			"^jwt$|^jwt_vc$|^jwt_vp$|^jwt_vc_json$|^jwt_vp_json$": {
+			  "type": "object",
+			  "additionalProperties": false,
+			  "properties": {
+				"alg": {
+				  "type": "array",
+				  "minItems": 1,
+				  "items": { "type": "string" }
+				}
+			  }
+			},
+			"^mso_mdoc$": {
 			  "type": "object",
 			  "additionalProperties": false,
 			  "properties": {
@@ -563,6 +626,27 @@ const DefinitionJSONSchemaV2 = `
 				  "type": "array",
 				  "minItems": 1,
 				  "items": { "type": "string" }
+				},
+				"cryptosuite": {
+				  "type": "array",
+				  "minItems": 1,
+				  "items": { "type": "string" }
+				}
+			  }
+			},
+			"^vc\\+sd-jwt$": {
+			  "type": "object",
+			  "additionalProperties": false,
+			  "properties": {
+				"sd-jwt_alg_values": {
+				  "type": "array",
+				  "minItems": 1,
+				  "items": { "type": "string" }
+				},
+				"kb-jwt_alg_values": {
+				  "type": "array",
+				  "minItems": 1,
+				  "items": { "type": "string" }
 				}
 			  }
 			}
@@ -684,7 +768,18 @@ const DefinitionJSONSchemaV2 = `
 		  "type": "object",
 		  "additionalProperties": false,
 		  "patternProperties": {
-			"^jwt$|^jwt_vc$|^jwt_vp$": {
+			"^jwt$|^jwt_vc$|^jwt_vp$|^jwt_vc_json$|^jwt_vp_json$": {
+			  "type": "object",
+			  "additionalProperties": false,
+			  "properties": {
+				"alg": {
+				  "type": "array",
+				  "minItems": 1,
+				  "items": { "type": "string" }
+				}
+			  }
+			},
+			"^mso_mdoc$": {
 			  "type": "object",
 			  "additionalProperties": false,
 			  "properties": {
@@ -703,6 +798,27 @@ const DefinitionJSONSchemaV2 = `
 				  "type": "array",
 				  "minItems": 1,
 				  "items": { "type": "string" }
+				},
+				"cryptosuite": {
+				  "type": "array",
+				  "minItems": 1,
+				  "items": { "type": "string" }
+				}
+			  }
+			},
+			"^vc\\+sd-jwt$": {
+			  "type": "object",
+			  "additionalProperties": false,
+			  "properties": {
+				"sd-jwt_alg_values": {
+				  "type": "array",
+				  "minItems": 1,
+				  "items": { "type": "string" }
+				},
+				"kb-jwt_alg_values": {
+				  "type": "array",
+				  "minItems": 1,
+				  "items": { "type": "string" }
 				}
 			  }
 			}
@@ -313,6 +313,12 @@ func getDisclosureClaims(disclosures []string, hash crypto.Hash) (map[string]*Di
 	return wrappedClaims, nil
 }
 
+// ParseDisclosures decodes the disclosures, keyed by digest. Unlike GetDisclosureClaims, the digests of
+// nested disclosures are kept in the disclosure values.
+func ParseDisclosures(disclosures []string, hash crypto.Hash) (map[string]*DisclosureClaim, error) {
+	return getDisclosureClaims(disclosures, hash)
+}
+
 // getDisclosureClaim parses disclosure and returns *DisclosureClaim.
 func getDisclosureClaim(disclosure string, hash crypto.Hash) (*DisclosureClaim, error) {
 	decoded, err := base64.RawURLEncoding.DecodeString(disclosure)
@@ -0,0 +1,312 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package holder
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	afgjwt "github.com/hyperledger/aries-framework-go/component/models/jwt"
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/common"
+)
+
+// DisclosureSelection is the minimal set of disclosures revealing the claims at the requested JSON paths.
+type DisclosureSelection struct {
+	// Disclosures are the selected disclosures, in the order of the combined format for issuance.
+	Disclosures []string
+	// Undisclosable are the requested paths that match no claim, even with all the disclosures.
+	Undisclosable []string
+}
+
+// SelectDisclosures selects the disclosures of the combined format for issuance needed to reveal the claims
+// at the JSON paths, eg $.address.street or $.nationalities[1]. Selecting a claim selects the disclosures
+// of its parents, for recursively disclosable claims, and the disclosures nested in its value.
+// Array indices refer to the array as revealed with all the disclosures, and * selects all object members
+// or array elements.
+//
+// The selected disclosures can be passed to CreatePresentation.
+func SelectDisclosures(combinedFormatForIssuance string, paths []string) (*DisclosureSelection, error) {
+	cfi := common.ParseCombinedFormatForIssuance(combinedFormatForIssuance)
+
+	// the SD-JWT is expected to be verified by Parse already.
+	signedJWT, _, err := afgjwt.Parse(cfi.SDJWT, afgjwt.WithSignatureVerifier(&NoopSignatureVerifier{}))
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := common.GetCryptoHashFromClaims(signedJWT.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return SelectDisclosuresFromClaims(signedJWT.Payload, cfi.Disclosures, hash, paths)
+}
+
+// SelectDisclosuresFromClaims is SelectDisclosures with the paths resolved against claims holding
+// the digests of the disclosures, eg the SD-JWT payload or a credential decoded from it.
+func SelectDisclosuresFromClaims(claims map[string]interface{}, disclosures []string, hash crypto.Hash,
+	paths []string) (*DisclosureSelection, error) {
+	disclosureClaims, err := common.ParseDisclosures(disclosures, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &selector{disclosures: disclosureClaims, selected: map[string]bool{}}
+
+	result := &DisclosureSelection{}
+
+	for _, path := range paths {
+		segments, err := parseJSONPath(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !s.resolve(claims, segments, nil) {
+			result.Undisclosable = append(result.Undisclosable, path)
+		}
+	}
+
+	for _, disclosure := range disclosures {
+		digest, err := common.GetHash(hash, disclosure)
+		if err != nil {
+			return nil, err
+		}
+
+		if s.selected[digest] {
+			result.Disclosures = append(result.Disclosures, disclosure)
+		}
+	}
+
+	return result, nil
+}
+
+type selector struct {
+	disclosures map[string]*common.DisclosureClaim
+	selected    map[string]bool
+}
+
+// member is an object member or array element, along with the digest of its disclosure if disclosed.
+type member struct {
+	name   string
+	value  interface{}
+	digest string
+}
+
+// resolve selects the disclosures revealing the claims at the path segments from the node, with the digests
+// of the disclosures required to reach the node. It returns false if no claim matches.
+func (s *selector) resolve(node interface{}, segments []pathSegment, required []string) bool {
+	if len(segments) == 0 {
+		for _, digest := range required {
+			s.selected[digest] = true
+		}
+
+		s.selectAll(node)
+
+		return true
+	}
+
+	segment := segments[0]
+	matched := false
+
+	for i, m := range s.members(node) {
+		if !segment.matches(m.name, i, isArray(node)) {
+			continue
+		}
+
+		next := required[:len(required):len(required)]
+
+		if m.digest != "" {
+			next = append(next, m.digest)
+		}
+
+		if s.resolve(m.value, segments[1:], next) {
+			matched = true
+		}
+	}
+
+	return matched
+}
+
+// selectAll selects the disclosures nested in the value.
+func (s *selector) selectAll(node interface{}) {
+	for _, m := range s.members(node) {
+		if m.digest != "" {
+			s.selected[m.digest] = true
+		}
+
+		s.selectAll(m.value)
+	}
+}
+
+// members returns the members of an object or the elements of an array, as revealed with all the disclosures.
+// Digests without disclosure, eg decoys, are skipped.
+func (s *selector) members(node interface{}) []*member {
+	var members []*member
+
+	switch t := node.(type) {
+	case map[string]interface{}:
+		for name, value := range t {
+			if name != common.SDKey {
+				members = append(members, &member{name: name, value: value})
+			}
+		}
+
+		digests, err := common.GetDisclosureDigests(map[string]interface{}{common.SDKey: t[common.SDKey]})
+		if err != nil {
+			return members
+		}
+
+		for digest := range digests {
+			if dc, ok := s.disclosures[digest]; ok && dc.Type != common.DisclosureClaimTypeArrayElement {
+				members = append(members, &member{name: dc.Name, value: dc.Value, digest: digest})
+			}
+		}
+	case []interface{}:
+		for _, element := range t {
+			digest, ok := arrayElementDigest(element)
+			if !ok {
+				members = append(members, &member{value: element})
+
+				continue
+			}
+
+			if dc, ok := s.disclosures[digest]; ok && dc.Type == common.DisclosureClaimTypeArrayElement {
+				members = append(members, &member{value: dc.Value, digest: digest})
+			}
+		}
+	}
+
+	return members
+}
+
+func arrayElementDigest(element interface{}) (string, bool) {
+	obj, ok := element.(map[string]interface{})
+	if !ok || len(obj) != 1 {
+		return "", false
+	}
+
+	digest, ok := obj[common.ArrayElementDigestKey].(string)
+
+	return digest, ok
+}
+
+func isArray(node interface{}) bool {
+	_, ok := node.([]interface{})
+
+	return ok
+}
+
+// pathSegment is a member name, an array index or a wildcard of a JSON path.
+type pathSegment struct {
+	name     string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// matches tells if the segment matches the object member name or the array element index. Numeric names
+// also match array indices, as in dot-separated paths.
+func (p pathSegment) matches(name string, index int, inArray bool) bool {
+	switch {
+	case p.wildcard:
+		return true
+	case inArray && p.isIndex:
+		return p.index == index
+	case inArray:
+		i, err := strconv.Atoi(p.name)
+
+		return err == nil && i == index
+	case p.isIndex:
+		return name == strconv.Itoa(p.index)
+	default:
+		return name == p.name
+	}
+}
+
+// parseJSONPath parses JSON paths made of member names, array indices and wildcards,
+// eg $.address.street, $['address']['street'], $.nationalities[1] or $.nationalities[*].
+func parseJSONPath(path string) ([]pathSegment, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("invalid JSON path %s: must start with $", path)
+	}
+
+	var segments []pathSegment
+
+	rest := path[1:]
+
+	for rest != "" {
+		var (
+			segment pathSegment
+			err     error
+		)
+
+		switch rest[0] {
+		case '.':
+			segment, rest, err = parseDotSegment(rest[1:])
+		case '[':
+			segment, rest, err = parseBracketSegment(rest[1:])
+		default:
+			err = fmt.Errorf("unexpected character %q", rest[0])
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON path %s: %w", path, err)
+		}
+
+		segments = append(segments, segment)
+	}
+
+	return segments, nil
+}
+
+func parseDotSegment(rest string) (pathSegment, string, error) {
+	end := strings.IndexAny(rest, ".[")
+	if end < 0 {
+		end = len(rest)
+	}
+
+	name := rest[:end]
+
+	switch name {
+	case "":
+		return pathSegment{}, "", errors.New("empty member name, recursive descent is not supported")
+	case "*":
+		return pathSegment{wildcard: true}, rest[end:], nil
+	default:
+		return pathSegment{name: name}, rest[end:], nil
+	}
+}
+
+func parseBracketSegment(rest string) (pathSegment, string, error) {
+	if rest != "" && (rest[0] == '\'' || rest[0] == '"') {
+		end := strings.IndexByte(rest[1:], rest[0]) + 1
+		if end == 0 || len(rest) <= end+1 || rest[end+1] != ']' {
+			return pathSegment{}, "", errors.New("unterminated quoted member name")
+		}
+
+		return pathSegment{name: rest[1:end]}, rest[end+2:], nil
+	}
+
+	end := strings.IndexByte(rest, ']')
+	if end < 0 {
+		return pathSegment{}, "", errors.New("unterminated bracket")
+	}
+
+	if rest[:end] == "*" {
+		return pathSegment{wildcard: true}, rest[end+1:], nil
+	}
+
+	index, err := strconv.Atoi(rest[:end])
+	if err != nil || index < 0 {
+		return pathSegment{}, "", fmt.Errorf("invalid array index %s", rest[:end])
+	}
+
+	return pathSegment{index: index, isIndex: true}, rest[end+1:], nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package holder
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	afjwt "github.com/hyperledger/aries-framework-go/component/models/jwt"
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/common"
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/issuer"
+)
+
+func TestSelectDisclosures(t *testing.T) {
+	_, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	claims := map[string]interface{}{
+		"given_name": "John",
+		"address": map[string]interface{}{
+			"street":  "123 Main St",
+			"country": "US",
+		},
+		"nationalities": []interface{}{"US", "DE"},
+	}
+
+	token, err := issuer.New(testIssuer, claims, nil, afjwt.NewEd25519Signer(privKey),
+		issuer.WithSDJWTVersion(common.SDJWTVersionV5),
+		issuer.WithRecursiveClaimsObjects([]string{"address"}))
+	require.NoError(t, err)
+
+	combinedFormatForIssuance, err := token.Serialize(false)
+	require.NoError(t, err)
+
+	cfi := common.ParseCombinedFormatForIssuance(combinedFormatForIssuance)
+
+	disclosed := func(t *testing.T, disclosures []string) map[string]interface{} {
+		t.Helper()
+
+		disclosureClaims, err := common.ParseDisclosures(disclosures, crypto.SHA256)
+		require.NoError(t, err)
+
+		values := map[string]interface{}{}
+
+		for _, dc := range disclosureClaims {
+			if dc.Type == common.DisclosureClaimTypeArrayElement {
+				values["[]"+dc.Value.(string)] = dc.Value
+			} else {
+				values[dc.Name] = dc.Value
+			}
+		}
+
+		return values
+	}
+
+	t.Run("nested claim of a recursive disclosure", func(t *testing.T) {
+		selection, err := SelectDisclosures(combinedFormatForIssuance, []string{"$.address.street"})
+		require.NoError(t, err)
+		require.Empty(t, selection.Undisclosable)
+		require.Len(t, selection.Disclosures, 2)
+
+		values := disclosed(t, selection.Disclosures)
+		require.Contains(t, values, "address")
+		require.Equal(t, "123 Main St", values["street"])
+		require.NotContains(t, values, "country")
+
+		presentation, err := CreatePresentation(combinedFormatForIssuance, selection.Disclosures)
+		require.NoError(t, err)
+		require.Len(t, common.ParseCombinedFormatForPresentation(presentation).Disclosures, 2)
+	})
+
+	t.Run("whole object and array element", func(t *testing.T) {
+		selection, err := SelectDisclosures(combinedFormatForIssuance,
+			[]string{"$['address']", "$.nationalities[1]"})
+		require.NoError(t, err)
+		require.Empty(t, selection.Undisclosable)
+
+		values := disclosed(t, selection.Disclosures)
+		require.Len(t, values, 5)
+		require.Contains(t, values, "street")
+		require.Contains(t, values, "country")
+		require.Contains(t, values, "[]DE")
+		require.NotContains(t, values, "[]US")
+
+		// disclosures keep the order of the combined format for issuance.
+		var ordered []string
+
+		for _, disclosure := range cfi.Disclosures {
+			for _, selected := range selection.Disclosures {
+				if disclosure == selected {
+					ordered = append(ordered, disclosure)
+				}
+			}
+		}
+
+		require.Equal(t, ordered, selection.Disclosures)
+	})
+
+	t.Run("wildcards and undisclosable paths", func(t *testing.T) {
+		selection, err := SelectDisclosures(combinedFormatForIssuance, []string{
+			"$.nationalities[*]", "$.iss", "$.family_name", "$.nationalities[2]", "$.address.street.name",
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"$.family_name", "$.nationalities[2]", "$.address.street.name"},
+			selection.Undisclosable)
+
+		values := disclosed(t, selection.Disclosures)
+		require.Len(t, values, 3)
+		require.Contains(t, values, "nationalities")
+		require.Contains(t, values, "[]US")
+		require.Contains(t, values, "[]DE")
+
+		selection, err = SelectDisclosures(combinedFormatForIssuance, []string{"$.*"})
+		require.NoError(t, err)
+		require.Len(t, selection.Disclosures, len(cfi.Disclosures))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		for _, path := range []string{
+			"address", "$..street", "$.address[", "$.nationalities[x]", "$['address", "$a",
+		} {
+			_, err := SelectDisclosures(combinedFormatForIssuance, []string{path})
+			require.ErrorContains(t, err, "invalid JSON path", path)
+		}
+
+		_, err := SelectDisclosures("invalid", nil)
+		require.Error(t, err)
+
+		_, err = SelectDisclosuresFromClaims(map[string]interface{}{}, []string{"!"}, crypto.SHA256, nil)
+		require.ErrorContains(t, err, "failed to decode disclosure")
+	})
+}
+
+func TestParseJSONPath(t *testing.T) {
+	segments, err := parseJSONPath(`$.a["b.c"][2].*['d'][*]`)
+	require.NoError(t, err)
+	require.Equal(t, []pathSegment{
+		{name: "a"},
+		{name: "b.c"},
+		{index: 2, isIndex: true},
+		{wildcard: true},
+		{name: "d"},
+		{wildcard: true},
+	}, segments)
+}
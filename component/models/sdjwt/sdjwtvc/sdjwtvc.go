@@ -0,0 +1,147 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+/*
+Package sdjwtvc implements the SD-JWT VC profile (https://datatracker.ietf.org/doc/draft-ietf-oauth-sd-jwt-vc/)
+on top of the SD-JWT issuer and verifier: the vct claim, the vc+sd-jwt and dc+sd-jwt typ headers,
+the discovery of issuer keys through the JWT VC Issuer Metadata (.well-known/jwt-vc-issuer),
+type metadata and status list references.
+*/
+package sdjwtvc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose"
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/common"
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/issuer"
+)
+
+const (
+	// TypVCSDJWT is the typ header of SD-JWT VCs.
+	TypVCSDJWT = "vc+sd-jwt"
+	// TypDCSDJWT is the typ header of SD-JWT VCs, as renamed by later drafts of the profile.
+	TypDCSDJWT = "dc+sd-jwt"
+
+	// VCTClaim is the claim holding the type of the credential.
+	VCTClaim = "vct"
+	// StatusClaim is the claim holding the status mechanism of the credential.
+	StatusClaim = "status"
+
+	// WellKnownJWTVCIssuer is the well-known path segment of the JWT VC Issuer Metadata.
+	WellKnownJWTVCIssuer = "/.well-known/jwt-vc-issuer"
+)
+
+// Status is the status claim of an SD-JWT VC.
+type Status struct {
+	StatusList *StatusListReference `json:"status_list,omitempty"`
+}
+
+// StatusListReference references the status of the credential in a Token Status List.
+type StatusListReference struct {
+	Index int    `json:"idx"`
+	URI   string `json:"uri"`
+}
+
+// issueOpts holds options for the SD-JWT VC issuance.
+type issueOpts struct {
+	typ         string
+	keyID       string
+	status      *Status
+	nonSDClaims []string
+	sdJWTOpts   []issuer.NewOpt
+}
+
+// IssueOpt is the SD-JWT VC issuance option.
+type IssueOpt func(opts *issueOpts)
+
+// WithTyp sets the typ header, vc+sd-jwt by default.
+func WithTyp(typ string) IssueOpt {
+	return func(opts *issueOpts) {
+		opts.typ = typ
+	}
+}
+
+// WithKeyID sets the kid header, used by verifiers to select the issuer key from the JWT VC Issuer Metadata.
+func WithKeyID(keyID string) IssueOpt {
+	return func(opts *issueOpts) {
+		opts.keyID = keyID
+	}
+}
+
+// WithStatusList references the status of the credential at the index of the Token Status List at the URI.
+func WithStatusList(uri string, index int) IssueOpt {
+	return func(opts *issueOpts) {
+		opts.status = &Status{StatusList: &StatusListReference{Index: index, URI: uri}}
+	}
+}
+
+// WithNonSelectivelyDisclosableClaims sets the claims that are always disclosed, in addition to vct and status.
+// See issuer.WithNonSelectivelyDisclosableClaims.
+func WithNonSelectivelyDisclosableClaims(claims []string) IssueOpt {
+	return func(opts *issueOpts) {
+		opts.nonSDClaims = claims
+	}
+}
+
+// WithSDJWTOptions passes options to the SD-JWT issuer, eg issuer.WithHolderPublicKey for key binding
+// or issuer.WithExpiry.
+func WithSDJWTOptions(sdJWTOpts ...issuer.NewOpt) IssueOpt {
+	return func(opts *issueOpts) {
+		opts.sdJWTOpts = append(opts.sdJWTOpts, sdJWTOpts...)
+	}
+}
+
+// Issue issues an SD-JWT VC of the type vct, with the claims selectively disclosable except the claims
+// set as non selectively disclosable. The vct and status claims are always disclosed.
+func Issue(issuerID, vct string, claims map[string]interface{}, signer jose.Signer,
+	opts ...IssueOpt) (*issuer.SelectiveDisclosureJWT, error) {
+	iOpts := &issueOpts{typ: TypVCSDJWT}
+
+	for _, opt := range opts {
+		opt(iOpts)
+	}
+
+	if vct == "" {
+		return nil, errors.New("vct is required")
+	}
+
+	for _, claim := range []string{VCTClaim, StatusClaim} {
+		if _, ok := claims[claim]; ok {
+			return nil, fmt.Errorf("claim %s is set by the issuer and cannot be present in the claims", claim)
+		}
+	}
+
+	vcClaims := make(map[string]interface{}, len(claims)+2)
+
+	for k, v := range claims {
+		vcClaims[k] = v
+	}
+
+	vcClaims[VCTClaim] = vct
+
+	if iOpts.status != nil {
+		vcClaims[StatusClaim] = iOpts.status
+	}
+
+	headers := jose.Headers{jose.HeaderType: iOpts.typ}
+
+	if iOpts.keyID != "" {
+		headers[jose.HeaderKeyID] = iOpts.keyID
+	}
+
+	sdJWTOpts := append([]issuer.NewOpt{issuer.WithSDJWTVersion(common.SDJWTVersionV5)}, iOpts.sdJWTOpts...)
+	sdJWTOpts = append(sdJWTOpts, issuer.WithNonSelectivelyDisclosableClaims(
+		append([]string{VCTClaim, StatusClaim}, iOpts.nonSDClaims...)))
+
+	sdJWT, err := issuer.New(issuerID, vcClaims, headers, signer, sdJWTOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("issue SD-JWT VC: %w", err)
+	}
+
+	return sdJWT, nil
+}
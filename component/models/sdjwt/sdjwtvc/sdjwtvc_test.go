@@ -0,0 +1,304 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sdjwtvc
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk/jwksupport"
+	afjwt "github.com/hyperledger/aries-framework-go/component/models/jwt"
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/common"
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/holder"
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/issuer"
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/verifier"
+)
+
+const (
+	issuerPath = "/tenant/1234"
+	testKeyID  = "key-1"
+	testVCT    = "/vct/identity_credential"
+)
+
+type testIssuer struct {
+	server   *httptest.Server
+	id       string
+	signer   jose.Signer
+	metadata map[string]interface{}
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	pubJWK, err := jwksupport.JWKFromKey(pubKey)
+	require.NoError(t, err)
+
+	pubJWK.KeyID = testKeyID
+
+	jwks := map[string]interface{}{"keys": []*jwk.JWK{pubJWK}}
+
+	ti := &testIssuer{signer: afjwt.NewEd25519Signer(privKey)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(WellKnownJWTVCIssuer+issuerPath, func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, ti.metadata)
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, jwks)
+	})
+	mux.HandleFunc(testVCT, func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(t, w, map[string]interface{}{
+			"vct":         ti.server.URL + testVCT,
+			"name":        "Identity Credential",
+			"description": "Identity credential of a natural person",
+		})
+	})
+
+	ti.server = httptest.NewTLSServer(mux)
+	t.Cleanup(ti.server.Close)
+
+	ti.id = ti.server.URL + issuerPath
+	ti.metadata = map[string]interface{}{"issuer": ti.id, "jwks": jwks}
+
+	return ti
+}
+
+// presentAll creates a presentation disclosing all the claims, without key binding.
+func presentAll(t *testing.T, combinedFormatForIssuance string) string {
+	t.Helper()
+
+	cfi := common.ParseCombinedFormatForIssuance(combinedFormatForIssuance)
+
+	presentation, err := holder.CreatePresentation(combinedFormatForIssuance, cfi.Disclosures)
+	require.NoError(t, err)
+
+	return presentation
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, v interface{}) {
+	t.Helper()
+
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(t, json.NewEncoder(w).Encode(v))
+}
+
+func TestIssueAndVerify(t *testing.T) {
+	ti := newTestIssuer(t)
+
+	claims := map[string]interface{}{
+		"given_name":  "John",
+		"family_name": "Doe",
+		"birthdate":   "1940-01-01",
+	}
+
+	vct := ti.server.URL + testVCT
+
+	t.Run("success", func(t *testing.T) {
+		sdJWT, err := Issue(ti.id, vct, claims, ti.signer,
+			WithKeyID(testKeyID),
+			WithStatusList("https://example.com/statuslists/1", 42),
+			WithNonSelectivelyDisclosableClaims([]string{"birthdate"}),
+			WithSDJWTOptions(issuer.WithIssuedAt(jwt.NewNumericDate(time.Now()))))
+		require.NoError(t, err)
+
+		require.Equal(t, TypVCSDJWT, sdJWT.LookupStringHeader("typ"))
+		require.Equal(t, testKeyID, sdJWT.LookupStringHeader("kid"))
+		require.Len(t, sdJWT.Disclosures, 2)
+
+		combinedFormatForIssuance, err := sdJWT.Serialize(false)
+		require.NoError(t, err)
+
+		cred, err := NewVerifier(WithHTTPClient(ti.server.Client()), WithExpectedVCTs(vct)).
+			Verify(presentAll(t, combinedFormatForIssuance))
+		require.NoError(t, err)
+
+		require.Equal(t, ti.id, cred.Issuer)
+		require.Equal(t, vct, cred.VCT)
+		require.Equal(t, TypVCSDJWT, cred.Typ)
+		require.Equal(t, &Status{StatusList: &StatusListReference{
+			Index: 42,
+			URI:   "https://example.com/statuslists/1",
+		}}, cred.Status)
+		require.Equal(t, "John", cred.Claims["given_name"])
+		require.Equal(t, "Doe", cred.Claims["family_name"])
+		require.Equal(t, "1940-01-01", cred.Claims["birthdate"])
+	})
+
+	t.Run("success - dc+sd-jwt presentation with key binding and keys by reference", func(t *testing.T) {
+		ti.metadata = map[string]interface{}{"issuer": ti.id, "jwks_uri": ti.server.URL + "/jwks"}
+
+		holderPublicKey, holderPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		holderPublicJWK, err := jwksupport.JWKFromKey(holderPublicKey)
+		require.NoError(t, err)
+
+		sdJWT, err := Issue(ti.id, vct, claims, ti.signer,
+			WithTyp(TypDCSDJWT),
+			WithSDJWTOptions(issuer.WithHolderPublicKey(holderPublicJWK)))
+		require.NoError(t, err)
+
+		combinedFormatForIssuance, err := sdJWT.Serialize(false)
+		require.NoError(t, err)
+
+		disclosures, err := holder.Parse(combinedFormatForIssuance, holder.WithSignatureVerifier(&noVerifier{}))
+		require.NoError(t, err)
+
+		var selected []string
+
+		for _, disclosure := range disclosures {
+			if disclosure.Name == "given_name" {
+				selected = append(selected, disclosure.Disclosure)
+			}
+		}
+
+		presentation, err := holder.CreatePresentation(combinedFormatForIssuance, selected,
+			holder.WithHolderVerification(&holder.BindingInfo{
+				Payload: holder.BindingPayload{
+					Nonce:    "nonce",
+					Audience: "https://verifier.example.com",
+					IssuedAt: jwt.NewNumericDate(time.Now()),
+				},
+				Signer: afjwt.NewEd25519Signer(holderPrivateKey),
+			}))
+		require.NoError(t, err)
+
+		cred, err := NewVerifier(WithHTTPClient(ti.server.Client()), WithParseOptions(
+			verifier.WithHolderVerificationRequired(true),
+			verifier.WithExpectedNonceForHolderVerification("nonce"),
+			verifier.WithExpectedAudienceForHolderVerification("https://verifier.example.com"),
+		)).Verify(presentation)
+		require.NoError(t, err)
+
+		require.Equal(t, TypDCSDJWT, cred.Typ)
+		require.Nil(t, cred.Status)
+		require.Equal(t, "John", cred.Claims["given_name"])
+		require.NotContains(t, cred.Claims, "family_name")
+	})
+
+	t.Run("type metadata", func(t *testing.T) {
+		metadata, err := NewVerifier(WithHTTPClient(ti.server.Client())).TypeMetadata(vct)
+		require.NoError(t, err)
+		require.Equal(t, "Identity Credential", metadata.Name)
+
+		_, err = NewVerifier(WithHTTPClient(ti.server.Client())).TypeMetadata("urn:example:identity")
+		require.ErrorContains(t, err, "is not an https URL")
+
+		_, err = NewVerifier(WithHTTPClient(ti.server.Client())).TypeMetadata(ti.server.URL + "/vct/unknown")
+		require.ErrorContains(t, err, "returned status 404")
+	})
+
+	t.Run("error - issuance", func(t *testing.T) {
+		_, err := Issue(ti.id, "", claims, ti.signer)
+		require.EqualError(t, err, "vct is required")
+
+		_, err = Issue(ti.id, vct, map[string]interface{}{VCTClaim: "other"}, ti.signer)
+		require.ErrorContains(t, err, "claim vct is set by the issuer")
+	})
+
+	t.Run("error - verification", func(t *testing.T) {
+		issue := func(issuerID string, opts ...IssueOpt) string {
+			sdJWT, err := Issue(issuerID, vct, claims, ti.signer, opts...)
+			require.NoError(t, err)
+
+			combinedFormatForIssuance, err := sdJWT.Serialize(false)
+			require.NoError(t, err)
+
+			return presentAll(t, combinedFormatForIssuance)
+		}
+
+		ti.metadata = map[string]interface{}{"issuer": ti.id, "jwks": map[string]interface{}{
+			"keys": []interface{}{},
+		}}
+
+		v := NewVerifier(WithHTTPClient(ti.server.Client()))
+
+		_, err := v.Verify(issue(ti.id, WithKeyID(testKeyID)))
+		require.ErrorContains(t, err, "key key-1 of issuer")
+
+		_, err = v.Verify(issue(ti.id))
+		require.ErrorContains(t, err, "kid is required")
+
+		ti.metadata = map[string]interface{}{"issuer": "https://other.example.com"}
+
+		_, err = v.Verify(issue(ti.id))
+		require.ErrorContains(t, err, "issuer metadata is for issuer https://other.example.com")
+
+		ti.metadata = map[string]interface{}{"issuer": ti.id}
+
+		_, err = v.Verify(issue(ti.id))
+		require.ErrorContains(t, err, "holds no keys")
+
+		_, err = v.Verify(issue("did:example:issuer"))
+		require.ErrorContains(t, err, "is not an https URL")
+
+		_, err = v.Verify(issue(ti.id + "/unknown"))
+		require.ErrorContains(t, err, "returned status 404")
+
+		_, err = v.Verify(issue(ti.id, WithTyp("JWT")))
+		require.EqualError(t, err, "unexpected typ \"JWT\"")
+
+		_, err = v.Verify(issue(""))
+		require.EqualError(t, err, "iss claim is required")
+
+		_, err = v.Verify("invalid")
+		require.ErrorContains(t, err, "parse SD-JWT VC")
+	})
+
+	t.Run("error - signature and vct", func(t *testing.T) {
+		ti.metadata = map[string]interface{}{"issuer": ti.id, "jwks_uri": ti.server.URL + "/jwks"}
+
+		sdJWT, err := Issue(ti.id, vct, claims, afjwt.NewEd25519Signer(ed25519.NewKeyFromSeed(make([]byte, 32))))
+		require.NoError(t, err)
+
+		combinedFormatForIssuance, err := sdJWT.Serialize(false)
+		require.NoError(t, err)
+
+		_, err = NewVerifier(WithHTTPClient(ti.server.Client())).Verify(presentAll(t, combinedFormatForIssuance))
+		require.ErrorContains(t, err, "verify SD-JWT VC")
+
+		sdJWT, err = Issue(ti.id, vct, claims, ti.signer)
+		require.NoError(t, err)
+
+		combinedFormatForIssuance, err = sdJWT.Serialize(false)
+		require.NoError(t, err)
+
+		_, err = NewVerifier(WithHTTPClient(ti.server.Client()), WithExpectedVCTs("https://example.com/other")).
+			Verify(presentAll(t, combinedFormatForIssuance))
+		require.ErrorContains(t, err, "unexpected vct")
+
+		// the live HTTP client does not trust the test server.
+		_, err = NewVerifier().Verify(presentAll(t, combinedFormatForIssuance))
+		require.ErrorContains(t, err, "httpClient do")
+	})
+}
+
+func TestCredential(t *testing.T) {
+	v := NewVerifier()
+
+	_, err := v.credential("https://example.com", TypVCSDJWT, map[string]interface{}{})
+	require.EqualError(t, err, "vct claim is required")
+
+	_, err = v.credential("https://example.com", TypVCSDJWT, map[string]interface{}{
+		VCTClaim:    "https://example.com/vct",
+		StatusClaim: "invalid",
+	})
+	require.ErrorContains(t, err, "decode status")
+}
@@ -0,0 +1,335 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sdjwtvc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/component/log"
+	afgjwt "github.com/hyperledger/aries-framework-go/component/models/jwt"
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/common"
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/verifier"
+	sigverifier "github.com/hyperledger/aries-framework-go/component/models/signature/verifier"
+)
+
+const defaultTimeout = time.Minute
+
+var logger = log.New("aries-framework/sdjwtvc")
+
+// HTTPClient represents an HTTP client.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// IssuerMetadata is the JWT VC Issuer Metadata, holding the keys of the issuer either by value or by reference.
+type IssuerMetadata struct {
+	Issuer  string          `json:"issuer"`
+	JWKSURI string          `json:"jwks_uri,omitempty"`
+	JWKS    json.RawMessage `json:"jwks,omitempty"`
+}
+
+// TypeMetadata is the type metadata of an SD-JWT VC, retrieved from the vct URL.
+type TypeMetadata struct {
+	VCT         string                 `json:"vct"`
+	Name        string                 `json:"name,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Extends     string                 `json:"extends,omitempty"`
+	Schema      map[string]interface{} `json:"schema,omitempty"`
+	SchemaURI   string                 `json:"schema_uri,omitempty"`
+}
+
+// Credential is a verified SD-JWT VC.
+type Credential struct {
+	Issuer string
+	VCT    string
+	Typ    string
+	Status *Status
+	// Claims are the claims of the SD-JWT VC, including the disclosed ones.
+	Claims map[string]interface{}
+}
+
+// Verifier verifies SD-JWT VCs, with the issuer keys discovered from the JWT VC Issuer Metadata.
+type Verifier struct {
+	httpClient   HTTPClient
+	expectedVCTs []string
+	parseOpts    []verifier.ParseOpt
+}
+
+// VerifierOpt configures the Verifier.
+type VerifierOpt func(v *Verifier)
+
+// WithHTTPClient sets the HTTP client fetching the issuer metadata, the issuer keys and the type metadata.
+func WithHTTPClient(client HTTPClient) VerifierOpt {
+	return func(v *Verifier) {
+		v.httpClient = client
+	}
+}
+
+// WithExpectedVCTs restricts the accepted credential types.
+func WithExpectedVCTs(vcts ...string) VerifierOpt {
+	return func(v *Verifier) {
+		v.expectedVCTs = vcts
+	}
+}
+
+// WithParseOptions passes options to the SD-JWT verifier, eg verifier.WithHolderVerificationRequired.
+func WithParseOptions(parseOpts ...verifier.ParseOpt) VerifierOpt {
+	return func(v *Verifier) {
+		v.parseOpts = append(v.parseOpts, parseOpts...)
+	}
+}
+
+// NewVerifier returns a new SD-JWT VC verifier.
+func NewVerifier(opts ...VerifierOpt) *Verifier {
+	v := &Verifier{
+		httpClient: &http.Client{Timeout: defaultTimeout},
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
+}
+
+// Verify verifies the SD-JWT VC in the combined format for presentation: the typ header,
+// the vct claim and the SD-JWT, with the issuer key from the JWT VC Issuer Metadata of the iss claim.
+func (v *Verifier) Verify(sdJWTVC string) (*Credential, error) {
+	cfp := common.ParseCombinedFormatForPresentation(sdJWTVC)
+
+	unverified, _, err := afgjwt.Parse(cfp.SDJWT, afgjwt.WithSignatureVerifier(&noVerifier{}))
+	if err != nil {
+		return nil, fmt.Errorf("parse SD-JWT VC: %w", err)
+	}
+
+	typ, _ := unverified.Headers.Type() //nolint:errcheck
+	if typ != TypVCSDJWT && typ != TypDCSDJWT {
+		return nil, fmt.Errorf("unexpected typ \"%s\"", typ)
+	}
+
+	issuerID, _ := unverified.Payload["iss"].(string) //nolint:errcheck
+	if issuerID == "" {
+		return nil, errors.New("iss claim is required")
+	}
+
+	keyID, _ := unverified.Headers.KeyID() //nolint:errcheck
+
+	issuerKey, err := v.IssuerKey(issuerID, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	sigVerifier, err := afgjwt.GetVerifier(&sigverifier.PublicKey{JWK: issuerKey})
+	if err != nil {
+		return nil, fmt.Errorf("get verifier from issuer key: %w", err)
+	}
+
+	parseOpts := append([]verifier.ParseOpt{
+		verifier.WithSignatureVerifier(sigVerifier),
+		verifier.WithIssuerSigningAlgorithms([]string{"EdDSA", "ES256", "ES384", "ES521", "PS256", "RS256"}),
+		verifier.WithExpectedTypHeader(typ),
+	}, v.parseOpts...)
+
+	claims, err := verifier.Parse(sdJWTVC, parseOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("verify SD-JWT VC: %w", err)
+	}
+
+	return v.credential(issuerID, typ, claims)
+}
+
+func (v *Verifier) credential(issuerID, typ string, claims map[string]interface{}) (*Credential, error) {
+	vct, _ := claims[VCTClaim].(string) //nolint:errcheck
+	if vct == "" {
+		return nil, errors.New("vct claim is required")
+	}
+
+	if len(v.expectedVCTs) > 0 && !contains(v.expectedVCTs, vct) {
+		return nil, fmt.Errorf("unexpected vct \"%s\"", vct)
+	}
+
+	cred := &Credential{
+		Issuer: issuerID,
+		VCT:    vct,
+		Typ:    typ,
+		Claims: claims,
+	}
+
+	if statusObj, ok := claims[StatusClaim]; ok {
+		// nested claims may hold numbers as strings, so the status is decoded with weakly typed input.
+		d, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			Result:           &cred.Status,
+			TagName:          "json",
+			WeaklyTypedInput: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("new status decoder: %w", err)
+		}
+
+		if err = d.Decode(statusObj); err != nil {
+			return nil, fmt.Errorf("decode status: %w", err)
+		}
+	}
+
+	return cred, nil
+}
+
+// IssuerMetadata fetches the JWT VC Issuer Metadata of the issuer, which must be an https URL.
+// The metadata is at the well-known path inserted between the host and the path of the issuer URL.
+func (v *Verifier) IssuerMetadata(issuerID string) (*IssuerMetadata, error) {
+	issuerURL, err := url.Parse(issuerID)
+	if err != nil || issuerURL.Scheme != "https" || issuerURL.Host == "" {
+		return nil, fmt.Errorf("issuer %s is not an https URL", issuerID)
+	}
+
+	metadataURL := *issuerURL
+	metadataURL.Path = WellKnownJWTVCIssuer + strings.TrimSuffix(issuerURL.Path, "/")
+	metadataURL.RawPath = ""
+
+	var metadata IssuerMetadata
+
+	if err = v.getJSON(metadataURL.String(), &metadata); err != nil {
+		return nil, fmt.Errorf("fetch issuer metadata: %w", err)
+	}
+
+	if metadata.Issuer != issuerID {
+		return nil, fmt.Errorf("issuer metadata is for issuer %s, not %s", metadata.Issuer, issuerID)
+	}
+
+	return &metadata, nil
+}
+
+// IssuerKey returns the issuer key with the key ID from the JWT VC Issuer Metadata. Without key ID,
+// the issuer must have a single key.
+func (v *Verifier) IssuerKey(issuerID, keyID string) (*jwk.JWK, error) {
+	metadata, err := v.IssuerMetadata(issuerID)
+	if err != nil {
+		return nil, err
+	}
+
+	jwksBytes := []byte(metadata.JWKS)
+
+	switch {
+	case len(metadata.JWKS) > 0:
+	case metadata.JWKSURI != "":
+		var raw json.RawMessage
+
+		if err = v.getJSON(metadata.JWKSURI, &raw); err != nil {
+			return nil, fmt.Errorf("fetch issuer jwks: %w", err)
+		}
+
+		jwksBytes = raw
+	default:
+		return nil, fmt.Errorf("issuer metadata of %s holds no keys", issuerID)
+	}
+
+	var jwks struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+
+	if err = json.Unmarshal(jwksBytes, &jwks); err != nil {
+		return nil, fmt.Errorf("unmarshal issuer jwks: %w", err)
+	}
+
+	if keyID == "" && len(jwks.Keys) != 1 {
+		return nil, fmt.Errorf("kid is required to select one of the %d keys of issuer %s", len(jwks.Keys), issuerID)
+	}
+
+	for _, raw := range jwks.Keys {
+		key := &jwk.JWK{}
+
+		if err = key.UnmarshalJSON(raw); err != nil {
+			return nil, fmt.Errorf("unmarshal issuer jwk: %w", err)
+		}
+
+		if keyID == "" || key.KeyID == keyID {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("key %s of issuer %s not found", keyID, issuerID)
+}
+
+// TypeMetadata fetches the type metadata of the credential type, which must be an https URL.
+func (v *Verifier) TypeMetadata(vct string) (*TypeMetadata, error) {
+	vctURL, err := url.Parse(vct)
+	if err != nil || vctURL.Scheme != "https" {
+		return nil, fmt.Errorf("vct %s is not an https URL", vct)
+	}
+
+	var metadata TypeMetadata
+
+	if err = v.getJSON(vct, &metadata); err != nil {
+		return nil, fmt.Errorf("fetch type metadata: %w", err)
+	}
+
+	if metadata.VCT != vct {
+		return nil, fmt.Errorf("type metadata is for vct %s, not %s", metadata.VCT, vct)
+	}
+
+	return &metadata, nil
+}
+
+func (v *Verifier) getJSON(endpoint string, result interface{}) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("httpClient do: %w", err)
+	}
+
+	defer closeResponseBody(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("endpoint %s returned status %d", endpoint, resp.StatusCode)
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
+
+func closeResponseBody(respBody io.Closer) {
+	e := respBody.Close()
+	if e != nil {
+		logger.Warnf("failed to close response body: %v", e)
+	}
+}
+
+func contains(values []string, val string) bool {
+	for _, v := range values {
+		if v == val {
+			return true
+		}
+	}
+
+	return false
+}
+
+// noVerifier is used to read the issuer and key ID of the SD-JWT before its signature is verified.
+type noVerifier struct{}
+
+func (v noVerifier) Verify(_ jose.Headers, _, _, _ []byte) error {
+	return nil
+}
@@ -41,6 +41,13 @@ type parseOpts struct {
 	leewayForClaimsValidation time.Duration
 
 	expectedTypHeader string
+
+	statusChecker StatusChecker
+}
+
+// StatusChecker checks the status claim of a credential, eg against a Token Status List.
+type StatusChecker interface {
+	CheckStatus(status map[string]interface{}) error
 }
 
 // ParseOpt is the SD-JWT Parser option.
@@ -131,6 +138,14 @@ func WithExpectedTypHeader(typ string) ParseOpt {
 	}
 }
 
+// WithStatusChecker option is for checking the status claim of the SD-JWT, if present,
+// eg with statuslist.Checker.
+func WithStatusChecker(checker StatusChecker) ParseOpt {
+	return func(opts *parseOpts) {
+		opts.statusChecker = checker
+	}
+}
+
 // Parse parses combined format for presentation and returns verified claims.
 // The Verifier has to verify that all disclosed claim values were part of the original, Issuer-signed SD-JWT.
 //
@@ -194,7 +209,20 @@ func Parse(combinedFormatForPresentation string, opts ...ParseOpt) (map[string]i
 	// Process the Disclosures.
 	// Section: https://www.ietf.org/archive/id/draft-ietf-oauth-selective-disclosure-jwt-02.html#section-6.2-4.5.1
 	// Section: https://www.ietf.org/archive/id/draft-ietf-oauth-selective-disclosure-jwt-05.html#section-6.1-3
-	return getDisclosedClaims(cfp.Disclosures, signedJWT, cryptoHash)
+	claims, err := getDisclosedClaims(cfp.Disclosures, signedJWT, cryptoHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if pOpts.statusChecker != nil {
+		if status, ok := claims["status"].(map[string]interface{}); ok {
+			if err = pOpts.statusChecker.CheckStatus(status); err != nil {
+				return nil, fmt.Errorf("check status: %w", err)
+			}
+		}
+	}
+
+	return claims, nil
 }
 
 func validateIssuerSignedSDJWT(sdjwt string, disclosures []string, pOpts *parseOpts) (*afgjwt.JSONWebToken, error) {
@@ -14,6 +14,7 @@ import (
 	"crypto/rsa"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -819,6 +820,61 @@ func TestWithJWTDetachedPayload(t *testing.T) {
 	require.Equal(t, []byte("payload"), opts.detachedPayload)
 }
 
+func TestWithStatusChecker(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	sigVerifier, err := afjwt.NewEd25519Verifier(pubKey)
+	require.NoError(t, err)
+
+	issue := func(claims map[string]interface{}) string {
+		token, e := issuer.New(testIssuer, claims, nil, afjwt.NewEd25519Signer(privKey),
+			issuer.WithNonSelectivelyDisclosableClaims([]string{"status"}))
+		require.NoError(t, e)
+
+		combinedFormatForIssuance, e := token.Serialize(false)
+		require.NoError(t, e)
+
+		return combinedFormatForIssuance + common.CombinedFormatSeparator
+	}
+
+	status := map[string]interface{}{
+		"status_list": map[string]interface{}{"idx": 3, "uri": "https://example.com/statuslists/1"},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		checker := &mockStatusChecker{}
+
+		claims, err := Parse(issue(map[string]interface{}{"status": status}),
+			WithSignatureVerifier(sigVerifier), WithStatusChecker(checker))
+		require.NoError(t, err)
+		require.Contains(t, claims, "status")
+		require.Len(t, checker.checked, 1)
+
+		_, err = Parse(issue(map[string]interface{}{"given_name": "Albert"}),
+			WithSignatureVerifier(sigVerifier), WithStatusChecker(checker))
+		require.NoError(t, err)
+		require.Len(t, checker.checked, 1)
+	})
+
+	t.Run("error - status check failed", func(t *testing.T) {
+		_, err := Parse(issue(map[string]interface{}{"status": status}),
+			WithSignatureVerifier(sigVerifier), WithStatusChecker(&mockStatusChecker{err: errors.New("revoked")}))
+		require.EqualError(t, err, "check status: revoked")
+	})
+}
+
+type mockStatusChecker struct {
+	checked []map[string]interface{}
+	err     error
+}
+
+func (c *mockStatusChecker) CheckStatus(status map[string]interface{}) error {
+	c.checked = append(c.checked, status)
+
+	return c.err
+}
+
 func buildJWS(signer afjose.Signer, claims interface{}) (string, error) {
 	claimsBytes, err := json.Marshal(claims)
 	if err != nil {
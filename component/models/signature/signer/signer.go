@@ -56,6 +56,8 @@ type Context struct {
 	Challenge               string                        // optional
 	Purpose                 string                        // optional
 	CapabilityChain         []interface{}                 // optional
+	ID                      string                        // optional
+	PreviousProof           string                        // optional
 }
 
 // New returns new instance of document verifier.
@@ -118,6 +120,8 @@ func (signer *DocumentSigner) signObject(context *Context, jsonLdObject map[stri
 		Challenge:               context.Challenge,
 		ProofPurpose:            context.Purpose,
 		CapabilityChain:         context.CapabilityChain,
+		ID:                      context.ID,
+		PreviousProof:           context.PreviousProof,
 	}
 
 	// TODO support custom proof purpose
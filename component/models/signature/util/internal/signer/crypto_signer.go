@@ -16,10 +16,15 @@ import (
 
 	"github.com/btcsuite/btcd/btcec"
 
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsa"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsaed25519"
+
 	cryptoapi "github.com/hyperledger/aries-framework-go/spi/crypto"
 	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
 )
 
+const mldsaEd25519Alg = "ML-DSA-65-Ed25519"
+
 // CryptoSigner defines signer based on crypto.
 type CryptoSigner struct {
 	PubKeyBytes []byte
@@ -67,6 +72,10 @@ func (s *CryptoSigner) Alg() string {
 		}
 	case ed25519.PublicKey:
 		return alg
+	case *mldsa.PublicKey:
+		return pubKey.Parameters().String()
+	case *mldsaed25519.PublicKey:
+		return mldsaEd25519Alg
 	}
 
 	return ""
@@ -143,7 +152,33 @@ func getPublicKey(keyType kmsapi.KeyType, pubKeyBytes []byte) (interface{}, erro
 	case kmsapi.ED25519Type:
 		return ed25519.PublicKey(pubKeyBytes), nil
 
+	case kmsapi.MLDSA44Type:
+		return parseMLDSAPublicKey(mldsa.MLDSA44(), pubKeyBytes)
+
+	case kmsapi.MLDSA65Type:
+		return parseMLDSAPublicKey(mldsa.MLDSA65(), pubKeyBytes)
+
+	case kmsapi.MLDSA87Type:
+		return parseMLDSAPublicKey(mldsa.MLDSA87(), pubKeyBytes)
+
+	case kmsapi.MLDSA65ED25519Type:
+		pubKey, err := mldsaed25519.NewPublicKey(pubKeyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse ML-DSA-65-Ed25519 public key: %w", err)
+		}
+
+		return pubKey, nil
+
 	default:
 		return nil, errors.New("unsupported key type")
 	}
 }
+
+func parseMLDSAPublicKey(params mldsa.Parameters, pubKeyBytes []byte) (*mldsa.PublicKey, error) {
+	pubKey, err := mldsa.NewPublicKey(params, pubKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s public key: %w", params, err)
+	}
+
+	return pubKey, nil
+}
@@ -17,6 +17,8 @@ import (
 	"github.com/google/tink/go/keyset"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsa"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsaed25519"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/localkms"
 	mockkms "github.com/hyperledger/aries-framework-go/component/kmscrypto/mock/kms"
@@ -44,6 +46,10 @@ func TestNewCryptoSigner(t *testing.T) {
 		{kmsapi.ECDSAP256TypeIEEEP1363, &ecdsa.PublicKey{}, p256Alg},
 		{kmsapi.ECDSAP384TypeIEEEP1363, &ecdsa.PublicKey{}, p384Alg},
 		{kmsapi.ECDSAP521TypeIEEEP1363, &ecdsa.PublicKey{}, p521Alg},
+		{kmsapi.MLDSA44Type, &mldsa.PublicKey{}, "ML-DSA-44"},
+		{kmsapi.MLDSA65Type, &mldsa.PublicKey{}, "ML-DSA-65"},
+		{kmsapi.MLDSA87Type, &mldsa.PublicKey{}, "ML-DSA-87"},
+		{kmsapi.MLDSA65ED25519Type, &mldsaed25519.PublicKey{}, mldsaEd25519Alg},
 	}
 
 	for _, test := range tests {
@@ -108,6 +114,19 @@ func TestNewCryptoSigner(t *testing.T) {
 		require.EqualError(t, err, "unexpected type of ecdsa public key")
 		require.Nil(t, signer)
 
+		kms = &mockkms.KeyManager{
+			ExportPubKeyBytesValue: []byte("not a public key"),
+		}
+		signer, err = NewCryptoSigner(tinkCrypto, kms, kmsapi.MLDSA44Type)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse ML-DSA-44 public key")
+		require.Nil(t, signer)
+
+		signer, err = NewCryptoSigner(tinkCrypto, kms, kmsapi.MLDSA65ED25519Type)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse ML-DSA-65-Ed25519 public key")
+		require.Nil(t, signer)
+
 		kms = &mockkms.KeyManager{}
 		signer, err = NewCryptoSigner(tinkCrypto, kms, kmsapi.ChaCha20Poly1305Type)
 		require.Error(t, err)
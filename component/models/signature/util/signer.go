@@ -25,7 +25,7 @@ func NewCryptoSigner(crypto cryptoapi.Crypto, kms kmsapi.KeyManager, keyType kms
 	case kmsapi.ECDSAP256TypeDER, kmsapi.ECDSAP256TypeIEEEP1363,
 		kmsapi.ECDSAP384TypeDER, kmsapi.ECDSAP384TypeIEEEP1363,
 		kmsapi.ECDSAP521TypeDER, kmsapi.ECDSAP521TypeIEEEP1363,
-		kmsapi.ED25519Type:
+		kmsapi.ED25519Type, kmsapi.MLDSA44Type, kmsapi.MLDSA65Type, kmsapi.MLDSA87Type, kmsapi.MLDSA65ED25519Type:
 		return signer.NewCryptoSigner(crypto, kms, keyType)
 
 	case kmsapi.ECDSASecp256k1DER, kmsapi.ECDSASecp256k1TypeIEEEP1363:
@@ -22,8 +22,13 @@ import (
 	"github.com/btcsuite/btcd/btcec"
 	gojose "github.com/go-jose/go-jose/v3"
 
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/bbs12381g2pub"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsa"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/mldsaed25519"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk/jwksupport"
 )
 
 // PublicKeyVerifier makes signature verification using the public key
@@ -420,6 +425,85 @@ func NewECDSAES521SignatureVerifier() *ECDSASignatureVerifier {
 	}
 }
 
+// MLDSASignatureVerifier verifies ML-DSA (FIPS 204) and composite ML-DSA-65 and Ed25519 signatures.
+type MLDSASignatureVerifier struct {
+	baseSignatureVerifier
+
+	kmsKeyType kms.KeyType
+}
+
+// Verify verifies the signature.
+func (sv *MLDSASignatureVerifier) Verify(pubKey *PublicKey, msg, signature []byte) error {
+	pubKeyJWK := pubKey.JWK
+	if pubKeyJWK == nil {
+		j, err := jwksupport.PubKeyBytesToJWK(pubKey.Value, sv.kmsKeyType)
+		if err != nil {
+			return fmt.Errorf("mldsa: create JWK from public key bytes: %w", err)
+		}
+
+		pubKeyJWK = j
+	}
+
+	var err error
+
+	switch key := pubKeyJWK.Key.(type) {
+	case *mldsa.PublicKey:
+		if key.Parameters().String() != sv.algorithm {
+			return errors.New("mldsa: invalid public key type")
+		}
+
+		err = mldsa.Verify(key, msg, signature, nil)
+	case *mldsaed25519.PublicKey:
+		if sv.kmsKeyType != kms.MLDSA65ED25519Type {
+			return errors.New("mldsa: invalid public key type")
+		}
+
+		err = mldsaed25519.Verify(key, msg, signature)
+	default:
+		return errors.New("mldsa: invalid public key type")
+	}
+
+	if err != nil {
+		return errors.New("mldsa: invalid signature")
+	}
+
+	return nil
+}
+
+func newMLDSASignatureVerifier(algorithm string, kmsKeyType kms.KeyType) *MLDSASignatureVerifier {
+	return &MLDSASignatureVerifier{
+		baseSignatureVerifier: baseSignatureVerifier{
+			keyType:   "AKP",
+			algorithm: algorithm,
+		},
+		kmsKeyType: kmsKeyType,
+	}
+}
+
+// NewMLDSA44SignatureVerifier creates a new signature verifier that verifies a ML-DSA-44 signature
+// taking public key bytes and JSON Web Key as input.
+func NewMLDSA44SignatureVerifier() *MLDSASignatureVerifier {
+	return newMLDSASignatureVerifier(jwk.MLDSA44Alg, kms.MLDSA44Type)
+}
+
+// NewMLDSA65SignatureVerifier creates a new signature verifier that verifies a ML-DSA-65 signature
+// taking public key bytes and JSON Web Key as input.
+func NewMLDSA65SignatureVerifier() *MLDSASignatureVerifier {
+	return newMLDSASignatureVerifier(jwk.MLDSA65Alg, kms.MLDSA65Type)
+}
+
+// NewMLDSA87SignatureVerifier creates a new signature verifier that verifies a ML-DSA-87 signature
+// taking public key bytes and JSON Web Key as input.
+func NewMLDSA87SignatureVerifier() *MLDSASignatureVerifier {
+	return newMLDSASignatureVerifier(jwk.MLDSA87Alg, kms.MLDSA87Type)
+}
+
+// NewMLDSA65Ed25519SignatureVerifier creates a new signature verifier that verifies a composite ML-DSA-65 and
+// Ed25519 signature taking public key bytes and JSON Web Key as input.
+func NewMLDSA65Ed25519SignatureVerifier() *MLDSASignatureVerifier {
+	return newMLDSASignatureVerifier(jwk.MLDSA65Ed25519Alg, kms.MLDSA65ED25519Type)
+}
+
 // NewBBSG2SignatureVerifier creates a new BBSG2SignatureVerifier.
 func NewBBSG2SignatureVerifier() *BBSG2SignatureVerifier {
 	return &BBSG2SignatureVerifier{
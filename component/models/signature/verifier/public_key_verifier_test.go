@@ -21,6 +21,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk/jwksupport"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/localkms"
 	mockkms "github.com/hyperledger/aries-framework-go/component/kmscrypto/mock/kms"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/secretlock/noop"
@@ -186,6 +187,70 @@ func TestNewEd25519SignatureVerifier(t *testing.T) {
 	require.EqualError(t, err, "ed25519: invalid signature")
 }
 
+func TestNewMLDSASignatureVerifier(t *testing.T) {
+	tests := []struct {
+		keyType  kmsapi.KeyType
+		verifier *MLDSASignatureVerifier
+	}{
+		{kmsapi.MLDSA44Type, NewMLDSA44SignatureVerifier()},
+		{kmsapi.MLDSA65Type, NewMLDSA65SignatureVerifier()},
+		{kmsapi.MLDSA87Type, NewMLDSA87SignatureVerifier()},
+		{kmsapi.MLDSA65ED25519Type, NewMLDSA65Ed25519SignatureVerifier()},
+	}
+
+	msg := []byte("test message")
+
+	for _, tc := range tests {
+		tt := tc
+
+		t.Run(string(tt.keyType), func(t *testing.T) {
+			v := tt.verifier
+
+			signer, err := newCryptoSigner(tt.keyType)
+			require.NoError(t, err)
+
+			msgSig, err := signer.Sign(msg)
+			require.NoError(t, err)
+
+			pubKey := &PublicKey{
+				Type:  "JsonWebKey2020",
+				Value: signer.PublicKeyBytes(),
+			}
+
+			err = v.Verify(pubKey, msg, msgSig)
+			require.NoError(t, err)
+
+			j, err := jwksupport.PubKeyBytesToJWK(signer.PublicKeyBytes(), tt.keyType)
+			require.NoError(t, err)
+
+			err = NewPublicKeyVerifier(v).Verify(&PublicKey{Type: "JsonWebKey2020", JWK: j}, msg, msgSig)
+			require.NoError(t, err)
+
+			// invalid signature
+			err = v.Verify(pubKey, []byte("other message"), msgSig)
+			require.EqualError(t, err, "mldsa: invalid signature")
+
+			// invalid public key
+			err = v.Verify(&PublicKey{Value: []byte("invalid-key")}, msg, msgSig)
+			require.ErrorContains(t, err, "mldsa: create JWK from public key bytes")
+		})
+	}
+
+	t.Run("verifier does not match key", func(t *testing.T) {
+		signer, err := newCryptoSigner(kmsapi.MLDSA44Type)
+		require.NoError(t, err)
+
+		j, err := jwksupport.PubKeyBytesToJWK(signer.PublicKeyBytes(), kmsapi.MLDSA44Type)
+		require.NoError(t, err)
+
+		err = NewMLDSA65SignatureVerifier().Verify(&PublicKey{JWK: j}, nil, nil)
+		require.EqualError(t, err, "mldsa: invalid public key type")
+
+		err = NewPublicKeyVerifier(NewMLDSA65SignatureVerifier()).Verify(&PublicKey{JWK: j}, nil, nil)
+		require.EqualError(t, err, "verifier does not match JSON Web Key")
+	})
+}
+
 func TestNewRSAPS256SignatureVerifier(t *testing.T) {
 	v := NewRSAPS256SignatureVerifier()
 	require.NotNil(t, v)
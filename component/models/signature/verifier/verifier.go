@@ -68,38 +68,56 @@ func (dv *DocumentVerifier) VerifyObject(jsonLdObject map[string]interface{}, op
 	}
 
 	for _, p := range proofs {
-		publicKeyID, err := p.PublicKeyID()
+		err = dv.verifyProof(jsonLdObject, p, opts...)
 		if err != nil {
 			return err
 		}
+	}
 
-		publicKey, err := dv.pkResolver.Resolve(publicKeyID)
-		if err != nil {
-			return err
-		}
+	return nil
+}
 
-		suite, err := dv.getSignatureSuite(p.Type)
-		if err != nil {
-			return err
-		}
+// VerifyProof will verify a single proof of JSON LD object. The proof does not need to be embedded
+// in the object, but if it references a previous proof through "previousProof", the previous proof
+// must be embedded in the object.
+func (dv *DocumentVerifier) VerifyProof(jsonLdObject, proofObject map[string]interface{},
+	opts ...processor.Opts) error {
+	p, err := proof.NewProof(proofObject)
+	if err != nil {
+		return err
+	}
 
-		message, err := proof.CreateVerifyData(suite, jsonLdObject, p, opts...)
-		if err != nil {
-			return err
-		}
+	return dv.verifyProof(jsonLdObject, p, opts...)
+}
 
-		signature, err := getProofVerifyValue(p)
-		if err != nil {
-			return err
-		}
+func (dv *DocumentVerifier) verifyProof(jsonLdObject map[string]interface{}, p *proof.Proof,
+	opts ...processor.Opts) error {
+	publicKeyID, err := p.PublicKeyID()
+	if err != nil {
+		return err
+	}
 
-		err = suite.Verify(publicKey, message, signature)
-		if err != nil {
-			return err
-		}
+	publicKey, err := dv.pkResolver.Resolve(publicKeyID)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	suite, err := dv.getSignatureSuite(p.Type)
+	if err != nil {
+		return err
+	}
+
+	message, err := proof.CreateVerifyData(suite, jsonLdObject, p, opts...)
+	if err != nil {
+		return err
+	}
+
+	signature, err := getProofVerifyValue(p)
+	if err != nil {
+		return err
+	}
+
+	return suite.Verify(publicKey, message, signature)
 }
 
 // getSignatureSuite returns signature suite based on signature type.
@@ -113,6 +113,58 @@ func TestVerify(t *testing.T) {
 	require.Nil(t, v)
 }
 
+func TestVerifyProof(t *testing.T) {
+	v, err := New(&testKeyResolver{
+		publicKey: &api.PublicKey{
+			Type:  kms.ED25519,
+			Value: []byte("signature"),
+		},
+	}, &testSignatureSuite{accept: true})
+	require.NoError(t, err)
+
+	var doc map[string]interface{}
+	err = json.Unmarshal([]byte(validDoc), &doc)
+	require.NoError(t, err)
+
+	firstProof, ok := doc["proof"].(map[string]interface{})
+	require.True(t, ok)
+
+	firstProof["id"] = "urn:uuid:first"
+
+	chainedProof := make(map[string]interface{}, len(firstProof))
+	for k, val := range firstProof {
+		chainedProof[k] = val
+	}
+
+	chainedProof["id"] = "urn:uuid:second"
+	chainedProof["previousProof"] = "urn:uuid:first"
+
+	doc["proof"] = []interface{}{firstProof, chainedProof}
+
+	t.Run("success", func(t *testing.T) {
+		require.NoError(t, v.VerifyProof(doc, firstProof))
+		require.NoError(t, v.VerifyProof(doc, chainedProof))
+		require.NoError(t, v.VerifyObject(doc))
+	})
+
+	t.Run("previous proof not found", func(t *testing.T) {
+		docWithoutFirst := map[string]interface{}{}
+		for k, val := range doc {
+			docWithoutFirst[k] = val
+		}
+
+		docWithoutFirst["proof"] = chainedProof
+
+		err = v.VerifyProof(docWithoutFirst, chainedProof)
+		require.ErrorIs(t, err, proof.ErrPreviousProofNotFound)
+	})
+
+	t.Run("invalid proof", func(t *testing.T) {
+		err = v.VerifyProof(doc, map[string]interface{}{"type": "Ed25519Signature2018"})
+		require.Error(t, err)
+	})
+}
+
 func Test_getProofVerifyValue(t *testing.T) {
 	jwsSignature := base64.RawURLEncoding.EncodeToString([]byte("signature"))
 
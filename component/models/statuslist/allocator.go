@@ -0,0 +1,89 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statuslist
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// ErrListFull is returned when all the indices of the status list are allocated.
+var ErrListFull = errors.New("all status list indices are allocated")
+
+// IndexAllocator allocates the status list indices of issued credentials. Indices are allocated at random,
+// so that the index of a credential does not reveal its issuance order.
+type IndexAllocator struct {
+	mu        sync.Mutex
+	size      int
+	allocated map[int]struct{}
+}
+
+// NewIndexAllocator returns an allocator of the indices of a status list of the size, excluding the indices
+// already allocated.
+func NewIndexAllocator(size int, allocated ...int) (*IndexAllocator, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid status list size %d", size)
+	}
+
+	a := &IndexAllocator{size: size, allocated: make(map[int]struct{}, len(allocated))}
+
+	for _, index := range allocated {
+		if index < 0 || index >= size {
+			return nil, fmt.Errorf("index %d is out of the status list of size %d", index, size)
+		}
+
+		a.allocated[index] = struct{}{}
+	}
+
+	return a, nil
+}
+
+// Allocate returns an unused index, or ErrListFull.
+func (a *IndexAllocator) Allocate() (int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	free := a.size - len(a.allocated)
+	if free == 0 {
+		return 0, ErrListFull
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(free)))
+	if err != nil {
+		return 0, fmt.Errorf("random index: %w", err)
+	}
+
+	// pick the n-th free index.
+	skip := int(n.Int64())
+
+	for index := 0; index < a.size; index++ {
+		if _, ok := a.allocated[index]; ok {
+			continue
+		}
+
+		if skip == 0 {
+			a.allocated[index] = struct{}{}
+
+			return index, nil
+		}
+
+		skip--
+	}
+
+	return 0, ErrListFull
+}
+
+// Allocated returns the number of allocated indices.
+func (a *IndexAllocator) Allocated() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return len(a.allocated)
+}
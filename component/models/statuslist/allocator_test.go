@@ -0,0 +1,44 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statuslist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexAllocator(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		a, err := NewIndexAllocator(10, 0, 5)
+		require.NoError(t, err)
+		require.Equal(t, 2, a.Allocated())
+
+		seen := map[int]bool{0: true, 5: true}
+
+		for i := 0; i < 8; i++ {
+			index, err := a.Allocate()
+			require.NoError(t, err)
+			require.False(t, seen[index])
+
+			seen[index] = true
+		}
+
+		require.Equal(t, 10, a.Allocated())
+
+		_, err = a.Allocate()
+		require.ErrorIs(t, err, ErrListFull)
+	})
+
+	t.Run("error - invalid allocator", func(t *testing.T) {
+		_, err := NewIndexAllocator(0)
+		require.EqualError(t, err, "invalid status list size 0")
+
+		_, err = NewIndexAllocator(10, 10)
+		require.EqualError(t, err, "index 10 is out of the status list of size 10")
+	})
+}
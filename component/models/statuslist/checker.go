@@ -0,0 +1,273 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statuslist
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose"
+	"github.com/hyperledger/aries-framework-go/component/log"
+)
+
+const (
+	defaultTimeout  = time.Minute
+	defaultCacheTTL = 5 * time.Minute
+
+	// maxTokenSize is the maximum size of fetched status list tokens: the base64 encoding of a status list of
+	// MaxSize 8 bits statuses, that zlib does not compress, with room for the token claims and signature.
+	maxTokenSize = (MaxSize+MaxSize/64)/3*4 + 1<<16
+)
+
+var logger = log.New("aries-framework/statuslist")
+
+var (
+	// ErrRevoked is returned when the credential status is invalid.
+	ErrRevoked = errors.New("credential is revoked")
+	// ErrSuspended is returned when the credential status is suspended.
+	ErrSuspended = errors.New("credential is suspended")
+)
+
+// HTTPClient represents an HTTP client.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type cachedToken struct {
+	token   *Token
+	expires time.Time
+}
+
+// Checker checks credential statuses against the status list tokens fetched from their URIs,
+// caching the tokens until their ttl or expiry.
+type Checker struct {
+	httpClient  HTTPClient
+	jwtVerifier jose.SignatureVerifier
+	cwtKey      crypto.PublicKey
+	cacheTTL    time.Duration
+	now         func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]*cachedToken
+}
+
+// CheckerOpt configures the Checker.
+type CheckerOpt func(c *Checker)
+
+// WithHTTPClient sets the HTTP client fetching the status list tokens.
+func WithHTTPClient(client HTTPClient) CheckerOpt {
+	return func(c *Checker) {
+		c.httpClient = client
+	}
+}
+
+// WithJWTVerifier sets the verifier of the status list JWTs.
+func WithJWTVerifier(verifier jose.SignatureVerifier) CheckerOpt {
+	return func(c *Checker) {
+		c.jwtVerifier = verifier
+	}
+}
+
+// WithCWTPublicKey sets the public key verifying the status list CWTs.
+func WithCWTPublicKey(pubKey crypto.PublicKey) CheckerOpt {
+	return func(c *Checker) {
+		c.cwtKey = pubKey
+	}
+}
+
+// WithCacheTTL sets how long tokens without ttl are cached, 5 minutes by default. Tokens are never
+// cached beyond their expiry.
+func WithCacheTTL(ttl time.Duration) CheckerOpt {
+	return func(c *Checker) {
+		c.cacheTTL = ttl
+	}
+}
+
+// NewChecker returns a new status list checker. A JWT verifier or a CWT public key is required
+// to accept the corresponding token format.
+func NewChecker(opts ...CheckerOpt) *Checker {
+	c := &Checker{
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		cacheTTL:   defaultCacheTTL,
+		now:        time.Now,
+		cache:      map[string]*cachedToken{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// CheckStatus checks the status claim of a credential, {"status_list": {"idx": ..., "uri": ...}}.
+// It returns ErrRevoked or ErrSuspended for credentials that are not valid.
+func (c *Checker) CheckStatus(status map[string]interface{}) error {
+	var ref struct {
+		StatusList *struct {
+			Index int    `json:"idx"`
+			URI   string `json:"uri"`
+		} `json:"status_list"`
+	}
+
+	// nested claims may hold numbers as strings, so the status is decoded with weakly typed input.
+	d, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           &ref,
+		TagName:          "json",
+		WeaklyTypedInput: true,
+	})
+	if err != nil {
+		return fmt.Errorf("new status decoder: %w", err)
+	}
+
+	if err = d.Decode(status); err != nil {
+		return fmt.Errorf("decode status: %w", err)
+	}
+
+	if ref.StatusList == nil || ref.StatusList.URI == "" {
+		return errors.New("status_list reference is required")
+	}
+
+	value, err := c.Status(ref.StatusList.URI, ref.StatusList.Index)
+	if err != nil {
+		return err
+	}
+
+	switch value {
+	case StatusValid:
+		return nil
+	case StatusInvalid:
+		return ErrRevoked
+	case StatusSuspended:
+		return ErrSuspended
+	default:
+		return fmt.Errorf("credential has status 0x%02x", value)
+	}
+}
+
+// Status returns the status at the index of the status list token at the URI.
+func (c *Checker) Status(uri string, index int) (byte, error) {
+	token, err := c.Token(uri)
+	if err != nil {
+		return 0, err
+	}
+
+	return token.StatusList.Get(index)
+}
+
+// Token returns the status list token at the URI, from the cache or fetched.
+func (c *Checker) Token(uri string) (*Token, error) {
+	now := c.now()
+
+	c.mu.Lock()
+	cached, ok := c.cache[uri]
+	c.mu.Unlock()
+
+	if ok && now.Before(cached.expires) {
+		return cached.token, nil
+	}
+
+	token, err := c.fetch(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.Subject != uri {
+		return nil, fmt.Errorf("status list token subject %s does not match uri %s", token.Subject, uri)
+	}
+
+	if !token.ExpiresAt.IsZero() && !now.Before(token.ExpiresAt) {
+		return nil, fmt.Errorf("status list token %s is expired", uri)
+	}
+
+	ttl := c.cacheTTL
+	if token.TTL > 0 {
+		ttl = token.TTL
+	}
+
+	expires := now.Add(ttl)
+	if !token.ExpiresAt.IsZero() && token.ExpiresAt.Before(expires) {
+		expires = token.ExpiresAt
+	}
+
+	c.mu.Lock()
+	c.cache[uri] = &cachedToken{token: token, expires: expires}
+	c.mu.Unlock()
+
+	return token, nil
+}
+
+func (c *Checker) fetch(uri string) (*Token, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+
+	var accept []string
+
+	if c.jwtVerifier != nil {
+		accept = append(accept, MediaTypeJWT)
+	}
+
+	if c.cwtKey != nil {
+		accept = append(accept, MediaTypeCWT)
+	}
+
+	if len(accept) == 0 {
+		return nil, errors.New("a JWT verifier or a CWT public key is required to check statuses")
+	}
+
+	req.Header.Set("Accept", strings.Join(accept, ", "))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("httpClient do: %w", err)
+	}
+
+	defer closeResponseBody(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("endpoint %s returned status %d", uri, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxTokenSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	if len(body) > maxTokenSize {
+		return nil, fmt.Errorf("status list token exceeds the maximum size of %d bytes", maxTokenSize)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")) //nolint:errcheck
+
+	switch {
+	case mediaType == MediaTypeJWT && c.jwtVerifier != nil:
+		return ParseJWT(strings.TrimSpace(string(body)), c.jwtVerifier)
+	case mediaType == MediaTypeCWT && c.cwtKey != nil:
+		return ParseCWT(body, c.cwtKey)
+	default:
+		return nil, fmt.Errorf("unsupported status list token content type \"%s\"", mediaType)
+	}
+}
+
+func closeResponseBody(respBody io.Closer) {
+	e := respBody.Close()
+	if e != nil {
+		logger.Warnf("failed to close response body: %v", e)
+	}
+}
@@ -0,0 +1,246 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statuslist
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	afjwt "github.com/hyperledger/aries-framework-go/component/models/jwt"
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/common"
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/issuer"
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/verifier"
+)
+
+type testStatusServer struct {
+	server      *httptest.Server
+	contentType string
+	body        []byte
+	requests    int
+}
+
+func newTestStatusServer(t *testing.T) *testStatusServer {
+	t.Helper()
+
+	ts := &testStatusServer{}
+
+	ts.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ts.requests++
+
+		if r.URL.Path != "/statuslists/1" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", ts.contentType)
+		_, err := w.Write(ts.body)
+		require.NoError(t, err)
+	}))
+	t.Cleanup(ts.server.Close)
+
+	return ts
+}
+
+func (ts *testStatusServer) uri() string {
+	return ts.server.URL + "/statuslists/1"
+}
+
+func TestChecker(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	sigVerifier, err := afjwt.NewEd25519Verifier(pubKey)
+	require.NoError(t, err)
+
+	cwtKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	ts := newTestStatusServer(t)
+
+	l, err := New(2, 100)
+	require.NoError(t, err)
+
+	require.NoError(t, l.Set(1, StatusInvalid))
+	require.NoError(t, l.Set(2, StatusSuspended))
+	require.NoError(t, l.Set(3, 3))
+
+	now := time.Now()
+	token := &Token{Subject: ts.uri(), IssuedAt: now, StatusList: l}
+
+	statusOf := func(index interface{}) map[string]interface{} {
+		return map[string]interface{}{"status_list": map[string]interface{}{"idx": index, "uri": ts.uri()}}
+	}
+
+	t.Run("success - JWT", func(t *testing.T) {
+		jwt, err := token.SignJWT(afjwt.NewEd25519Signer(privKey))
+		require.NoError(t, err)
+
+		ts.contentType, ts.body = MediaTypeJWT, []byte(jwt)
+
+		c := NewChecker(WithHTTPClient(ts.server.Client()), WithJWTVerifier(sigVerifier))
+
+		require.NoError(t, c.CheckStatus(statusOf(0)))
+		require.ErrorIs(t, c.CheckStatus(statusOf("1")), ErrRevoked)
+		require.ErrorIs(t, c.CheckStatus(statusOf(2)), ErrSuspended)
+		require.EqualError(t, c.CheckStatus(statusOf(3)), "credential has status 0x03")
+		require.EqualError(t, c.CheckStatus(statusOf(100)), "index 100 is out of the status list of size 100")
+	})
+
+	t.Run("success - CWT", func(t *testing.T) {
+		cwt, err := token.SignCWT(cwtKey)
+		require.NoError(t, err)
+
+		ts.contentType, ts.body = MediaTypeCWT, cwt
+
+		c := NewChecker(WithHTTPClient(ts.server.Client()), WithCWTPublicKey(&cwtKey.PublicKey))
+
+		require.NoError(t, c.CheckStatus(statusOf(0)))
+		require.ErrorIs(t, c.CheckStatus(statusOf(1)), ErrRevoked)
+	})
+
+	t.Run("caching", func(t *testing.T) {
+		cached := *token
+		cached.TTL = time.Hour
+
+		jwt, err := cached.SignJWT(afjwt.NewEd25519Signer(privKey))
+		require.NoError(t, err)
+
+		ts.contentType, ts.body = MediaTypeJWT, []byte(jwt)
+		ts.requests = 0
+
+		c := NewChecker(WithHTTPClient(ts.server.Client()), WithJWTVerifier(sigVerifier), WithCacheTTL(time.Minute))
+		c.now = func() time.Time { return now }
+
+		require.NoError(t, c.CheckStatus(statusOf(0)))
+		require.NoError(t, c.CheckStatus(statusOf(0)))
+		require.Equal(t, 1, ts.requests)
+
+		// within the token ttl, beyond the checker cache ttl.
+		c.now = func() time.Time { return now.Add(30 * time.Minute) }
+
+		require.NoError(t, c.CheckStatus(statusOf(0)))
+		require.Equal(t, 1, ts.requests)
+
+		c.now = func() time.Time { return now.Add(2 * time.Hour) }
+
+		require.NoError(t, c.CheckStatus(statusOf(0)))
+		require.Equal(t, 2, ts.requests)
+	})
+
+	t.Run("error - token", func(t *testing.T) {
+		c := NewChecker(WithHTTPClient(ts.server.Client()), WithJWTVerifier(sigVerifier))
+
+		expired := *token
+		expired.ExpiresAt = now.Add(-time.Minute)
+
+		jwt, err := expired.SignJWT(afjwt.NewEd25519Signer(privKey))
+		require.NoError(t, err)
+
+		ts.contentType, ts.body = MediaTypeJWT, []byte(jwt)
+
+		require.ErrorContains(t, c.CheckStatus(statusOf(0)), "is expired")
+
+		other := *token
+		other.Subject = "https://example.com/statuslists/2"
+
+		jwt, err = other.SignJWT(afjwt.NewEd25519Signer(privKey))
+		require.NoError(t, err)
+
+		ts.body = []byte(jwt)
+
+		require.ErrorContains(t, c.CheckStatus(statusOf(0)), "does not match uri")
+
+		ts.body = make([]byte, maxTokenSize+1)
+
+		require.ErrorContains(t, c.CheckStatus(statusOf(0)), "status list token exceeds the maximum size")
+
+		ts.body = []byte(jwt)
+
+		ts.contentType = "application/json"
+
+		require.EqualError(t, c.CheckStatus(statusOf(0)),
+			"unsupported status list token content type \"application/json\"")
+
+		_, err = c.Status(ts.server.URL+"/unknown", 0)
+		require.ErrorContains(t, err, "returned status 404")
+
+		_, err = NewChecker(WithJWTVerifier(sigVerifier)).Status(ts.uri(), 0)
+		require.ErrorContains(t, err, "httpClient do")
+
+		_, err = NewChecker().Status(ts.uri(), 0)
+		require.EqualError(t, err, "a JWT verifier or a CWT public key is required to check statuses")
+	})
+
+	t.Run("error - status claim", func(t *testing.T) {
+		c := NewChecker()
+
+		require.ErrorContains(t, c.CheckStatus(map[string]interface{}{"status_list": "invalid"}), "decode status")
+		require.EqualError(t, c.CheckStatus(map[string]interface{}{}), "status_list reference is required")
+	})
+}
+
+func TestChecker_SDJWT(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	sigVerifier, err := afjwt.NewEd25519Verifier(pubKey)
+	require.NoError(t, err)
+
+	ts := newTestStatusServer(t)
+
+	l, err := New(1, 16)
+	require.NoError(t, err)
+
+	allocator, err := NewIndexAllocator(l.Size())
+	require.NoError(t, err)
+
+	issue := func() (string, int) {
+		index, e := allocator.Allocate()
+		require.NoError(t, e)
+
+		sdJWT, e := issuer.New("https://example.com/issuer", map[string]interface{}{
+			"given_name": "John",
+			"status": map[string]interface{}{
+				"status_list": map[string]interface{}{"idx": index, "uri": ts.uri()},
+			},
+		}, nil, afjwt.NewEd25519Signer(privKey), issuer.WithNonSelectivelyDisclosableClaims([]string{"status"}))
+		require.NoError(t, e)
+
+		combinedFormatForIssuance, e := sdJWT.Serialize(false)
+		require.NoError(t, e)
+
+		return combinedFormatForIssuance + common.CombinedFormatSeparator, index
+	}
+
+	valid, _ := issue()
+	revoked, revokedIndex := issue()
+
+	require.NoError(t, l.Set(revokedIndex, StatusInvalid))
+
+	jwt, err := (&Token{Subject: ts.uri(), IssuedAt: time.Now(), StatusList: l}).SignJWT(afjwt.NewEd25519Signer(privKey))
+	require.NoError(t, err)
+
+	ts.contentType, ts.body = MediaTypeJWT, []byte(jwt)
+
+	checker := NewChecker(WithHTTPClient(ts.server.Client()), WithJWTVerifier(sigVerifier))
+
+	claims, err := verifier.Parse(valid, verifier.WithSignatureVerifier(sigVerifier), verifier.WithStatusChecker(checker))
+	require.NoError(t, err)
+	require.Equal(t, "John", claims["given_name"])
+
+	_, err = verifier.Parse(revoked, verifier.WithSignatureVerifier(sigVerifier), verifier.WithStatusChecker(checker))
+	require.ErrorIs(t, err, ErrRevoked)
+}
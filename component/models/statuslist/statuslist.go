@@ -0,0 +1,237 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+/*
+Package statuslist implements the Token Status List (https://datatracker.ietf.org/doc/draft-ietf-oauth-status-list/):
+status lists of 1, 2, 4 or 8 bits per credential, their zlib compressed encoding in status list tokens
+signed as JWT or CWT, the allocation of status list indices on the issuer side, and the checking of
+credential statuses on the verifier side.
+*/
+package statuslist
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Status values, https://datatracker.ietf.org/doc/html/draft-ietf-oauth-status-list#name-status-types-values.
+const (
+	// StatusValid means the credential is valid.
+	StatusValid byte = 0x00
+	// StatusInvalid means the credential is revoked.
+	StatusInvalid byte = 0x01
+	// StatusSuspended means the credential is temporarily invalid.
+	StatusSuspended byte = 0x02
+)
+
+const bitsPerByte = 8
+
+// MaxSize is the maximum number of statuses of the status lists decoded from status list tokens.
+const MaxSize = 1 << 24
+
+// StatusList is a list of credential statuses of 1, 2, 4 or 8 bits each, indexed from 0.
+type StatusList struct {
+	bits int
+	size int
+	lst  []byte
+}
+
+// New creates a status list of the size, with the number of bits per status, all valid.
+func New(bits, size int) (*StatusList, error) {
+	if err := checkBits(bits); err != nil {
+		return nil, err
+	}
+
+	if size <= 0 {
+		return nil, fmt.Errorf("invalid status list size %d", size)
+	}
+
+	return &StatusList{
+		bits: bits,
+		size: size,
+		lst:  make([]byte, (size*bits+bitsPerByte-1)/bitsPerByte),
+	}, nil
+}
+
+func checkBits(bits int) error {
+	switch bits {
+	case 1, 2, 4, 8: // nolint:gomnd
+		return nil
+	default:
+		return fmt.Errorf("invalid number of bits per status %d, must be 1, 2, 4 or 8", bits)
+	}
+}
+
+// Bits returns the number of bits per status.
+func (l *StatusList) Bits() int {
+	return l.bits
+}
+
+// Size returns the number of statuses of the list.
+func (l *StatusList) Size() int {
+	return l.size
+}
+
+// Get returns the status at the index.
+func (l *StatusList) Get(index int) (byte, error) {
+	if index < 0 || index >= l.size {
+		return 0, fmt.Errorf("index %d is out of the status list of size %d", index, l.size)
+	}
+
+	pos := index * l.bits
+
+	return (l.lst[pos/bitsPerByte] >> (pos % bitsPerByte)) & l.mask(), nil
+}
+
+// Set sets the status at the index.
+func (l *StatusList) Set(index int, status byte) error {
+	if index < 0 || index >= l.size {
+		return fmt.Errorf("index %d is out of the status list of size %d", index, l.size)
+	}
+
+	if status > l.mask() {
+		return fmt.Errorf("status %d does not fit in %d bits", status, l.bits)
+	}
+
+	pos := index * l.bits
+	shift := pos % bitsPerByte
+
+	l.lst[pos/bitsPerByte] = l.lst[pos/bitsPerByte]&^(l.mask()<<shift) | status<<shift
+
+	return nil
+}
+
+func (l *StatusList) mask() byte {
+	return byte(1<<l.bits - 1)
+}
+
+// compress returns the zlib compressed statuses.
+func (l *StatusList) compress() ([]byte, error) {
+	var buf bytes.Buffer
+
+	w, err := zlib.NewWriterLevel(&buf, zlib.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = w.Write(l.lst); err != nil {
+		return nil, err
+	}
+
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// decompress creates a status list from zlib compressed statuses. Its size is the number of statuses
+// held by the decompressed bytes, up to MaxSize.
+func decompress(bits int, compressed []byte) (*StatusList, error) {
+	if err := checkBits(bits); err != nil {
+		return nil, err
+	}
+
+	r, err := zlib.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("decompress status list: %w", err)
+	}
+
+	maxLen := MaxSize * bits / bitsPerByte
+
+	lst, err := io.ReadAll(io.LimitReader(r, int64(maxLen)+1))
+	if err != nil {
+		return nil, fmt.Errorf("decompress status list: %w", err)
+	}
+
+	if len(lst) > maxLen {
+		return nil, fmt.Errorf("status list exceeds the maximum size of %d statuses", MaxSize)
+	}
+
+	if len(lst) == 0 {
+		return nil, errors.New("status list is empty")
+	}
+
+	return &StatusList{bits: bits, size: len(lst) * bitsPerByte / bits, lst: lst}, nil
+}
+
+// jsonStatusList is the status list of status list JWTs, with the compressed statuses base64url encoded.
+type jsonStatusList struct {
+	Bits int    `json:"bits"`
+	Lst  string `json:"lst"`
+}
+
+// MarshalJSON encodes the status list as in status list JWTs.
+func (l *StatusList) MarshalJSON() ([]byte, error) {
+	compressed, err := l.compress()
+	if err != nil {
+		return nil, fmt.Errorf("compress status list: %w", err)
+	}
+
+	return json.Marshal(&jsonStatusList{Bits: l.bits, Lst: base64.RawURLEncoding.EncodeToString(compressed)})
+}
+
+// UnmarshalJSON decodes the status list of status list JWTs.
+func (l *StatusList) UnmarshalJSON(data []byte) error {
+	var raw jsonStatusList
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	compressed, err := base64.RawURLEncoding.DecodeString(raw.Lst)
+	if err != nil {
+		return fmt.Errorf("decode status list: %w", err)
+	}
+
+	decoded, err := decompress(raw.Bits, compressed)
+	if err != nil {
+		return err
+	}
+
+	*l = *decoded
+
+	return nil
+}
+
+// cborStatusList is the status list of status list CWTs, with the compressed statuses as a byte string.
+type cborStatusList struct {
+	Bits int    `cbor:"bits"`
+	Lst  []byte `cbor:"lst"`
+}
+
+// MarshalCBOR encodes the status list as in status list CWTs.
+func (l *StatusList) MarshalCBOR() ([]byte, error) {
+	compressed, err := l.compress()
+	if err != nil {
+		return nil, fmt.Errorf("compress status list: %w", err)
+	}
+
+	return encMode.Marshal(&cborStatusList{Bits: l.bits, Lst: compressed})
+}
+
+// UnmarshalCBOR decodes the status list of status list CWTs.
+func (l *StatusList) UnmarshalCBOR(data []byte) error {
+	var raw cborStatusList
+
+	if err := decMode.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	decoded, err := decompress(raw.Bits, raw.Lst)
+	if err != nil {
+		return err
+	}
+
+	*l = *decoded
+
+	return nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statuslist
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusList(t *testing.T) {
+	t.Run("bits packing", func(t *testing.T) {
+		// examples of https://datatracker.ietf.org/doc/html/draft-ietf-oauth-status-list#name-status-list
+		l, err := New(1, 16)
+		require.NoError(t, err)
+
+		for _, index := range []int{0, 3, 4, 5, 7, 8, 9, 13, 15} {
+			require.NoError(t, l.Set(index, StatusInvalid))
+		}
+
+		require.Equal(t, []byte{0xB9, 0xA3}, l.lst)
+
+		l, err = New(2, 12)
+		require.NoError(t, err)
+
+		for index, status := range []byte{1, 2, 0, 3, 0, 1, 0, 1, 1, 2, 3, 3} {
+			require.NoError(t, l.Set(index, status))
+		}
+
+		require.Equal(t, []byte{0xC9, 0x44, 0xF9}, l.lst)
+
+		status, err := l.Get(10)
+		require.NoError(t, err)
+		require.Equal(t, byte(3), status)
+
+		require.NoError(t, l.Set(10, StatusValid))
+
+		status, err = l.Get(10)
+		require.NoError(t, err)
+		require.Equal(t, StatusValid, status)
+
+		status, err = l.Get(11)
+		require.NoError(t, err)
+		require.Equal(t, byte(3), status)
+	})
+
+	t.Run("all bit sizes", func(t *testing.T) {
+		for _, bits := range []int{1, 2, 4, 8} {
+			l, err := New(bits, 100)
+			require.NoError(t, err)
+			require.Equal(t, bits, l.Bits())
+			require.Equal(t, 100, l.Size())
+
+			require.NoError(t, l.Set(99, StatusInvalid))
+
+			status, err := l.Get(99)
+			require.NoError(t, err)
+			require.Equal(t, StatusInvalid, status)
+
+			status, err = l.Get(98)
+			require.NoError(t, err)
+			require.Equal(t, StatusValid, status)
+		}
+	})
+
+	t.Run("JSON and CBOR round trip", func(t *testing.T) {
+		l, err := New(2, 1000)
+		require.NoError(t, err)
+
+		require.NoError(t, l.Set(1, StatusInvalid))
+		require.NoError(t, l.Set(999, StatusSuspended))
+
+		data, err := json.Marshal(l)
+		require.NoError(t, err)
+
+		var raw map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(data, &raw))
+		require.EqualValues(t, 2, raw["bits"])
+
+		var fromJSON StatusList
+
+		require.NoError(t, json.Unmarshal(data, &fromJSON))
+		require.Equal(t, l.lst, fromJSON.lst)
+		require.Equal(t, 1000, fromJSON.Size())
+
+		data, err = encMode.Marshal(l)
+		require.NoError(t, err)
+
+		var fromCBOR StatusList
+
+		require.NoError(t, decMode.Unmarshal(data, &fromCBOR))
+		require.Equal(t, l.lst, fromCBOR.lst)
+
+		status, err := fromCBOR.Get(999)
+		require.NoError(t, err)
+		require.Equal(t, StatusSuspended, status)
+	})
+
+	t.Run("error - invalid list", func(t *testing.T) {
+		_, err := New(3, 10)
+		require.EqualError(t, err, "invalid number of bits per status 3, must be 1, 2, 4 or 8")
+
+		_, err = New(1, 0)
+		require.EqualError(t, err, "invalid status list size 0")
+
+		l, err := New(1, 10)
+		require.NoError(t, err)
+
+		_, err = l.Get(10)
+		require.EqualError(t, err, "index 10 is out of the status list of size 10")
+
+		require.EqualError(t, l.Set(-1, StatusInvalid), "index -1 is out of the status list of size 10")
+		require.EqualError(t, l.Set(0, StatusSuspended), "status 2 does not fit in 1 bits")
+	})
+
+	t.Run("error - invalid encoding", func(t *testing.T) {
+		var l StatusList
+
+		require.Error(t, json.Unmarshal([]byte(`{"bits":1,"lst":"!"}`), &l))
+		require.ErrorContains(t, json.Unmarshal([]byte(`{"bits":1,"lst":"AAAA"}`), &l), "decompress status list")
+		require.ErrorContains(t, json.Unmarshal([]byte(`{"bits":5,"lst":"AAAA"}`), &l), "invalid number of bits")
+		require.Error(t, json.Unmarshal([]byte(`[]`), &l))
+
+		empty, err := (&StatusList{bits: 1}).compress()
+		require.NoError(t, err)
+
+		require.EqualError(t, json.Unmarshal([]byte(`{"bits":1,"lst":"`+
+			base64.RawURLEncoding.EncodeToString(empty)+`"}`), &l), "status list is empty")
+
+		tooLarge, err := (&StatusList{bits: 1, lst: make([]byte, MaxSize/bitsPerByte+1)}).compress()
+		require.NoError(t, err)
+
+		require.EqualError(t, json.Unmarshal([]byte(`{"bits":1,"lst":"`+
+			base64.RawURLEncoding.EncodeToString(tooLarge)+`"}`), &l),
+			"status list exceeds the maximum size of 16777216 statuses")
+
+		maxSize, err := (&StatusList{bits: 2, lst: make([]byte, MaxSize*2/bitsPerByte)}).compress()
+		require.NoError(t, err)
+
+		require.NoError(t, json.Unmarshal([]byte(`{"bits":2,"lst":"`+
+			base64.RawURLEncoding.EncodeToString(maxSize)+`"}`), &l))
+		require.Equal(t, MaxSize, l.Size())
+
+		require.Error(t, l.UnmarshalCBOR([]byte{0xff}))
+	})
+}
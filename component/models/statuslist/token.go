@@ -0,0 +1,266 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statuslist
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose"
+	afgjwt "github.com/hyperledger/aries-framework-go/component/models/jwt"
+	"github.com/hyperledger/aries-framework-go/component/models/mdoc"
+)
+
+const (
+	// TypJWT is the typ header of status list JWTs.
+	TypJWT = "statuslist+jwt"
+	// TypCWT is the type header of status list CWTs.
+	TypCWT = "application/statuslist+cwt"
+
+	// MediaTypeJWT is the media type of status list JWTs.
+	MediaTypeJWT = "application/statuslist+jwt"
+	// MediaTypeCWT is the media type of status list CWTs.
+	MediaTypeCWT = TypCWT
+
+	coseHeaderKeyID = 4
+	coseHeaderType  = 16
+	tagCOSESign1    = 18
+)
+
+// nolint:gochecknoglobals
+var (
+	encMode = mustEncMode()
+	decMode = mustDecMode()
+)
+
+func mustEncMode() cbor.EncMode {
+	mode, err := cbor.EncOptions{Sort: cbor.SortCoreDeterministic}.EncMode()
+	if err != nil {
+		panic(err)
+	}
+
+	return mode
+}
+
+func mustDecMode() cbor.DecMode {
+	mode, err := cbor.DecOptions{
+		DefaultMapType: reflect.TypeOf(map[string]interface{}{}),
+	}.DecMode()
+	if err != nil {
+		panic(err)
+	}
+
+	return mode
+}
+
+// Token is a status list token, published by the issuer at the URI referenced by the credentials.
+type Token struct {
+	// Subject is the URI of the status list token.
+	Subject  string
+	IssuedAt time.Time
+	// ExpiresAt is the zero time if the token does not expire.
+	ExpiresAt time.Time
+	// TTL is the maximum time the token may be cached by verifiers, or 0.
+	TTL        time.Duration
+	StatusList *StatusList
+}
+
+type jwtClaims struct {
+	Subject    string      `json:"sub"`
+	IssuedAt   int64       `json:"iat"`
+	ExpiresAt  int64       `json:"exp,omitempty"`
+	TTL        int64       `json:"ttl,omitempty"`
+	StatusList *StatusList `json:"status_list"`
+}
+
+type cwtClaims struct {
+	Subject    string      `cbor:"2,keyasint"`
+	ExpiresAt  int64       `cbor:"4,keyasint,omitempty"`
+	IssuedAt   int64       `cbor:"6,keyasint"`
+	StatusList *StatusList `cbor:"65533,keyasint"`
+	TTL        int64       `cbor:"65534,keyasint,omitempty"`
+}
+
+// signOpts holds options for signing status list tokens.
+type signOpts struct {
+	keyID string
+}
+
+// SignOpt is the status list token signing option.
+type SignOpt func(opts *signOpts)
+
+// WithKeyID sets the key ID header of the token.
+func WithKeyID(keyID string) SignOpt {
+	return func(opts *signOpts) {
+		opts.keyID = keyID
+	}
+}
+
+func (t *Token) check() error {
+	if t.Subject == "" {
+		return errors.New("status list token subject is required")
+	}
+
+	if t.StatusList == nil {
+		return errors.New("status list is required")
+	}
+
+	return nil
+}
+
+// SignJWT signs the token as a status list JWT.
+func (t *Token) SignJWT(signer jose.Signer, opts ...SignOpt) (string, error) {
+	sOpts := &signOpts{}
+
+	for _, opt := range opts {
+		opt(sOpts)
+	}
+
+	if err := t.check(); err != nil {
+		return "", err
+	}
+
+	headers := jose.Headers{jose.HeaderType: TypJWT}
+
+	if sOpts.keyID != "" {
+		headers[jose.HeaderKeyID] = sOpts.keyID
+	}
+
+	claims := &jwtClaims{
+		Subject:    t.Subject,
+		IssuedAt:   t.IssuedAt.Unix(),
+		TTL:        int64(t.TTL / time.Second),
+		StatusList: t.StatusList,
+	}
+
+	if !t.ExpiresAt.IsZero() {
+		claims.ExpiresAt = t.ExpiresAt.Unix()
+	}
+
+	token, err := afgjwt.NewSigned(claims, headers, signer)
+	if err != nil {
+		return "", fmt.Errorf("sign status list JWT: %w", err)
+	}
+
+	return token.Serialize(false)
+}
+
+// SignCWT signs the token as a status list CWT, a tagged COSE_Sign1.
+func (t *Token) SignCWT(signer crypto.Signer, opts ...SignOpt) ([]byte, error) {
+	sOpts := &signOpts{}
+
+	for _, opt := range opts {
+		opt(sOpts)
+	}
+
+	if err := t.check(); err != nil {
+		return nil, err
+	}
+
+	claims := &cwtClaims{
+		Subject:    t.Subject,
+		IssuedAt:   t.IssuedAt.Unix(),
+		TTL:        int64(t.TTL / time.Second),
+		StatusList: t.StatusList,
+	}
+
+	if !t.ExpiresAt.IsZero() {
+		claims.ExpiresAt = t.ExpiresAt.Unix()
+	}
+
+	payload, err := encMode.Marshal(claims)
+	if err != nil {
+		return nil, fmt.Errorf("encode status list CWT claims: %w", err)
+	}
+
+	protected := map[int]interface{}{coseHeaderType: TypCWT}
+
+	if sOpts.keyID != "" {
+		protected[coseHeaderKeyID] = []byte(sOpts.keyID)
+	}
+
+	sign1, err := mdoc.NewSign1(signer, protected, nil, payload)
+	if err != nil {
+		return nil, fmt.Errorf("sign status list CWT: %w", err)
+	}
+
+	return encMode.Marshal(cbor.Tag{Number: tagCOSESign1, Content: sign1})
+}
+
+// ParseJWT verifies the status list JWT and returns its token.
+func ParseJWT(token string, verifier jose.SignatureVerifier) (*Token, error) {
+	jwt, _, err := afgjwt.Parse(token, afgjwt.WithSignatureVerifier(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("parse status list JWT: %w", err)
+	}
+
+	if typ, _ := jwt.Headers.Type(); typ != TypJWT { //nolint:errcheck
+		return nil, fmt.Errorf("unexpected status list JWT typ \"%s\"", typ)
+	}
+
+	var claims jwtClaims
+
+	if err = jwt.DecodeClaims(&claims); err != nil {
+		return nil, fmt.Errorf("decode status list JWT claims: %w", err)
+	}
+
+	return newToken(claims.Subject, claims.IssuedAt, claims.ExpiresAt, claims.TTL, claims.StatusList)
+}
+
+// ParseCWT verifies the status list CWT with the public key of the issuer and returns its token.
+func ParseCWT(data []byte, pubKey crypto.PublicKey) (*Token, error) {
+	var sign1 mdoc.Sign1
+
+	if err := decMode.Unmarshal(data, &sign1); err != nil {
+		return nil, fmt.Errorf("parse status list CWT: %w", err)
+	}
+
+	if err := sign1.Verify(pubKey); err != nil {
+		return nil, fmt.Errorf("verify status list CWT: %w", err)
+	}
+
+	headers, err := sign1.ProtectedHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	if typ, _ := headers[coseHeaderType].(string); typ != TypCWT { //nolint:errcheck
+		return nil, fmt.Errorf("unexpected status list CWT type \"%v\"", headers[coseHeaderType])
+	}
+
+	var claims cwtClaims
+
+	if err = decMode.Unmarshal(sign1.Payload, &claims); err != nil {
+		return nil, fmt.Errorf("decode status list CWT claims: %w", err)
+	}
+
+	return newToken(claims.Subject, claims.IssuedAt, claims.ExpiresAt, claims.TTL, claims.StatusList)
+}
+
+func newToken(subject string, issuedAt, expiresAt, ttl int64, statusList *StatusList) (*Token, error) {
+	token := &Token{
+		Subject:    subject,
+		IssuedAt:   time.Unix(issuedAt, 0),
+		TTL:        time.Duration(ttl) * time.Second,
+		StatusList: statusList,
+	}
+
+	if expiresAt != 0 {
+		token.ExpiresAt = time.Unix(expiresAt, 0)
+	}
+
+	if err := token.check(); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
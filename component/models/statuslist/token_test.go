@@ -0,0 +1,164 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statuslist
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/require"
+
+	afjwt "github.com/hyperledger/aries-framework-go/component/models/jwt"
+	"github.com/hyperledger/aries-framework-go/component/models/mdoc"
+)
+
+const testURI = "https://example.com/statuslists/1"
+
+func newTestToken(t *testing.T) *Token {
+	t.Helper()
+
+	l, err := New(1, 1024)
+	require.NoError(t, err)
+
+	require.NoError(t, l.Set(7, StatusInvalid))
+
+	return &Token{
+		Subject:    testURI,
+		IssuedAt:   time.Unix(1686920170, 0),
+		ExpiresAt:  time.Unix(2291720170, 0),
+		TTL:        12 * time.Hour,
+		StatusList: l,
+	}
+}
+
+func TestToken_JWT(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	sigVerifier, err := afjwt.NewEd25519Verifier(pubKey)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		token := newTestToken(t)
+
+		jwt, err := token.SignJWT(afjwt.NewEd25519Signer(privKey), WithKeyID("key-1"))
+		require.NoError(t, err)
+
+		unverified, _, err := afjwt.Parse(jwt, afjwt.WithSignatureVerifier(sigVerifier))
+		require.NoError(t, err)
+
+		kid, _ := unverified.Headers.KeyID()
+		require.Equal(t, "key-1", kid)
+
+		parsed, err := ParseJWT(jwt, sigVerifier)
+		require.NoError(t, err)
+		require.Equal(t, token.Subject, parsed.Subject)
+		require.True(t, token.IssuedAt.Equal(parsed.IssuedAt))
+		require.True(t, token.ExpiresAt.Equal(parsed.ExpiresAt))
+		require.Equal(t, token.TTL, parsed.TTL)
+
+		status, err := parsed.StatusList.Get(7)
+		require.NoError(t, err)
+		require.Equal(t, StatusInvalid, status)
+	})
+
+	t.Run("success - without expiry", func(t *testing.T) {
+		token := newTestToken(t)
+		token.ExpiresAt = time.Time{}
+
+		jwt, err := token.SignJWT(afjwt.NewEd25519Signer(privKey))
+		require.NoError(t, err)
+
+		parsed, err := ParseJWT(jwt, sigVerifier)
+		require.NoError(t, err)
+		require.True(t, parsed.ExpiresAt.IsZero())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := (&Token{}).SignJWT(afjwt.NewEd25519Signer(privKey))
+		require.EqualError(t, err, "status list token subject is required")
+
+		_, err = (&Token{Subject: testURI}).SignJWT(afjwt.NewEd25519Signer(privKey))
+		require.EqualError(t, err, "status list is required")
+
+		_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		jwt, err := newTestToken(t).SignJWT(afjwt.NewEd25519Signer(otherKey))
+		require.NoError(t, err)
+
+		_, err = ParseJWT(jwt, sigVerifier)
+		require.ErrorContains(t, err, "parse status list JWT")
+
+		untyped, err := afjwt.NewSigned(map[string]interface{}{"sub": testURI}, nil, afjwt.NewEd25519Signer(privKey))
+		require.NoError(t, err)
+
+		serialized, err := untyped.Serialize(false)
+		require.NoError(t, err)
+
+		_, err = ParseJWT(serialized, sigVerifier)
+		require.ErrorContains(t, err, "unexpected status list JWT typ")
+	})
+}
+
+func TestToken_CWT(t *testing.T) {
+	privKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		token := newTestToken(t)
+
+		cwt, err := token.SignCWT(privKey, WithKeyID("key-1"))
+		require.NoError(t, err)
+
+		var tag cbor.RawTag
+
+		require.NoError(t, decMode.Unmarshal(cwt, &tag))
+		require.EqualValues(t, tagCOSESign1, tag.Number)
+
+		parsed, err := ParseCWT(cwt, &privKey.PublicKey)
+		require.NoError(t, err)
+		require.Equal(t, token.Subject, parsed.Subject)
+		require.True(t, token.ExpiresAt.Equal(parsed.ExpiresAt))
+		require.Equal(t, token.TTL, parsed.TTL)
+
+		status, err := parsed.StatusList.Get(7)
+		require.NoError(t, err)
+		require.Equal(t, StatusInvalid, status)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := (&Token{}).SignCWT(privKey)
+		require.EqualError(t, err, "status list token subject is required")
+
+		_, err = ParseCWT([]byte{0xff}, &privKey.PublicKey)
+		require.ErrorContains(t, err, "parse status list CWT")
+
+		cwt, err := newTestToken(t).SignCWT(privKey)
+		require.NoError(t, err)
+
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		_, err = ParseCWT(cwt, &otherKey.PublicKey)
+		require.ErrorContains(t, err, "verify status list CWT")
+
+		sign1, err := mdoc.NewSign1(privKey, nil, nil, []byte{0xa0})
+		require.NoError(t, err)
+
+		cwt, err = encMode.Marshal(sign1)
+		require.NoError(t, err)
+
+		_, err = ParseCWT(cwt, &privKey.PublicKey)
+		require.ErrorContains(t, err, "unexpected status list CWT type")
+	})
+}
@@ -590,11 +590,14 @@ type credentialOpts struct {
 	allowedCustomContexts map[string]bool
 	allowedCustomTypes    map[string]bool
 	disabledProofCheck    bool
+	proofCheckPolicy      ProofCheckPolicy
 	strictValidation      bool
 	ldpSuites             []verifier.SignatureSuite
 	defaultSchema         string
 	disableValidation     bool
 	verifyDataIntegrity   *verifyDataIntegrityOpts
+	statusChecker         CredentialStatusChecker
+	issuerResolver        didResolver
 
 	jsonldCredentialOpts
 }
@@ -609,6 +612,30 @@ func WithDisabledProofCheck() CredentialOpt {
 	}
 }
 
+// WithProofCheckPolicy option defines which proofs of a proof set must be valid for the proof check to pass.
+// By default, all proofs must be valid.
+func WithProofCheckPolicy(policy ProofCheckPolicy) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.proofCheckPolicy = policy
+	}
+}
+
+// WithStatusChecker sets the checker used by VerifyCredential to check the status of a credential
+// which defines credentialStatus. The status check is skipped if no checker is set.
+func WithStatusChecker(checker CredentialStatusChecker) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.statusChecker = checker
+	}
+}
+
+// WithIssuerDIDResolver sets the resolver used by VerifyCredential to resolve the DID of the credential issuer.
+// The issuer check is skipped if no resolver is set.
+func WithIssuerDIDResolver(resolver didResolver) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.issuerResolver = resolver
+	}
+}
+
 // WithCredDisableValidation options for disabling of JSON-LD and json-schema validation.
 func WithCredDisableValidation() CredentialOpt {
 	return func(opts *credentialOpts) {
@@ -1272,6 +1299,7 @@ func getEmbeddedProofCheckOpts(vcOpts *credentialOpts) *embeddedProofCheckOpts {
 	return &embeddedProofCheckOpts{
 		publicKeyFetcher:     vcOpts.publicKeyFetcher,
 		disabledProofCheck:   vcOpts.disabledProofCheck,
+		proofCheckPolicy:     vcOpts.proofCheckPolicy,
 		ldpSuites:            vcOpts.ldpSuites,
 		jsonldCredentialOpts: vcOpts.jsonldCredentialOpts,
 		dataIntegrityOpts:    vcOpts.verifyDataIntegrity,
@@ -0,0 +1,62 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/models"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite/ecdsasd2023"
+)
+
+// GenerateECDSASDSelectiveDisclosure generates an ecdsa-sd-2023 selective disclosure from the credential's
+// ecdsa-sd-2023 base proof. The derived credential reveals the statements selected by the issuer's mandatory
+// pointers and by the given JSON pointers (eg "/credentialSubject/birthDate").
+func (vc *Credential) GenerateECDSASDSelectiveDisclosure(selectivePointers []string,
+	opts ...CredentialOpt) (*Credential, error) {
+	baseProof := vc.ecdsaSDProof()
+	if baseProof == nil {
+		return nil, errors.New("expected an ecdsa-sd-2023 base proof")
+	}
+
+	vcOpts := getCredentialOpts(opts)
+
+	// Derive from a copy secured only by the base proof, in case other proofs are present.
+	vcCopy := *vc
+	vcCopy.JWT = ""
+	vcCopy.Proofs = []Proof{baseProof}
+
+	vcBytes, err := vcCopy.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	derived, err := ecdsasd2023.DeriveProof(vcBytes, selectivePointers, vcOpts.jsonldCredentialOpts.jsonldDocumentLoader)
+	if err != nil {
+		return nil, fmt.Errorf("create VC selective disclosure: %w", err)
+	}
+
+	opts = append(opts, WithDisabledProofCheck())
+
+	return ParseCredential(derived, opts...)
+}
+
+// HasECDSASDProof reports whether the credential is secured with an ecdsa-sd-2023 Data Integrity proof.
+func (vc *Credential) HasECDSASDProof() bool {
+	return vc.ecdsaSDProof() != nil
+}
+
+func (vc *Credential) ecdsaSDProof() Proof {
+	for _, proof := range vc.Proofs {
+		if proof["type"] == models.DataIntegrityProof && proof["cryptosuite"] == ecdsasd2023.SuiteType {
+			return proof
+		}
+	}
+
+	return nil
+}
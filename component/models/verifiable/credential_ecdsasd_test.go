@@ -0,0 +1,131 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/jwkkid"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite/ecdsasd2023"
+	"github.com/hyperledger/aries-framework-go/component/models/did"
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+func TestCredential_GenerateECDSASDSelectiveDisclosure(t *testing.T) {
+	vcJSON := `
+{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "https://www.w3.org/2018/credentials/examples/v1",
+    "https://w3id.org/security/data-integrity/v1"
+  ],
+  "id": "https://example.com/credentials/1872",
+  "type": [
+    "VerifiableCredential",
+    "UniversityDegreeCredential"
+  ],
+  "issuer": "did:key:z6Mkj7of2aaooXhTJvJ5oCL9ZVcAS472ZBuSjYyXDa4bWT32",
+  "issuanceDate": "2020-01-17T15:14:09.724Z",
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+    "degree": {
+      "type": "BachelorDegree",
+      "name": "Bachelor of Science and Arts"
+    },
+    "name": "Jayden Doe",
+    "spouse": "did:example:c276e12ec21ebfeb1f712ebc6f1"
+  }
+}
+`
+
+	kms, err := createKMS()
+	require.NoError(t, err)
+
+	cr, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	docLoader := createTestDocumentLoader(t)
+
+	_, keyBytes, err := kms.CreateAndExportPubKeyBytes(kmsapi.ECDSAP256IEEEP1363)
+	require.NoError(t, err)
+
+	key, err := jwkkid.BuildJWK(keyBytes, kmsapi.ECDSAP256IEEEP1363)
+	require.NoError(t, err)
+
+	const signingDID = "did:foo:bar"
+
+	const vmID = "#key-1"
+
+	vm, err := did.NewVerificationMethodFromJWK(signingDID+vmID, "JsonWebKey2020", signingDID, key)
+	require.NoError(t, err)
+
+	resolver := resolveFunc(func(id string) (*did.DocResolution, error) {
+		return makeMockDIDResolution(signingDID, vm, did.AssertionMethod), nil
+	})
+
+	signer, err := dataintegrity.NewSigner(&dataintegrity.Options{
+		DIDResolver: resolver,
+	}, ecdsasd2023.NewSignerInitializer(&ecdsasd2023.SignerInitializerOptions{
+		SignerGetter:     ecdsasd2023.WithLocalKMSSigner(kms, cr),
+		LDDocumentLoader: docLoader,
+	}))
+	require.NoError(t, err)
+
+	verifier, err := dataintegrity.NewVerifier(&dataintegrity.Options{
+		DIDResolver: resolver,
+	}, ecdsasd2023.NewVerifierInitializer(&ecdsasd2023.VerifierInitializerOptions{
+		LDDocumentLoader: docLoader,
+	}))
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(vcJSON), WithDisabledProofCheck())
+	require.NoError(t, err)
+	require.False(t, vc.HasECDSASDProof())
+
+	err = vc.AddDataIntegrityProof(&DataIntegrityProofContext{
+		SigningKeyID:      signingDID + vmID,
+		CryptoSuite:       ecdsasd2023.SuiteType,
+		MandatoryPointers: []string{"/issuer", "/issuanceDate"},
+	}, signer)
+	require.NoError(t, err)
+	require.True(t, vc.HasECDSASDProof())
+
+	t.Run("success", func(t *testing.T) {
+		derived, e := vc.GenerateECDSASDSelectiveDisclosure(
+			[]string{"/credentialSubject/degree/name"}, WithJSONLDDocumentLoader(docLoader))
+		require.NoError(t, e)
+
+		derivedBytes, e := derived.MarshalJSON()
+		require.NoError(t, e)
+
+		require.Contains(t, string(derivedBytes), "Bachelor of Science and Arts")
+		require.NotContains(t, string(derivedBytes), "Jayden Doe")
+
+		_, e = parseTestCredential(t, derivedBytes, WithDataIntegrityVerifier(verifier))
+		require.NoError(t, e)
+	})
+
+	t.Run("failure: invalid pointer", func(t *testing.T) {
+		_, e := vc.GenerateECDSASDSelectiveDisclosure(
+			[]string{"/credentialSubject/unknown"}, WithJSONLDDocumentLoader(docLoader))
+		require.Error(t, e)
+		require.Contains(t, e.Error(), "create VC selective disclosure")
+	})
+
+	t.Run("failure: no base proof", func(t *testing.T) {
+		noProofVC, e := parseTestCredential(t, []byte(vcJSON), WithDisabledProofCheck())
+		require.NoError(t, e)
+
+		_, e = noProofVC.GenerateECDSASDSelectiveDisclosure(nil, WithJSONLDDocumentLoader(docLoader))
+		require.Error(t, e)
+		require.Contains(t, e.Error(), "expected an ecdsa-sd-2023 base proof")
+	})
+}
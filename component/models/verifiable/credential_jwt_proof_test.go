@@ -14,6 +14,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/kmssigner"
 	"github.com/hyperledger/aries-framework-go/component/models/did"
 	"github.com/hyperledger/aries-framework-go/component/models/did/endpoint"
 	"github.com/hyperledger/aries-framework-go/component/models/signature/verifier"
@@ -153,6 +154,35 @@ func TestParseCredentialFromJWS_EdDSA(t *testing.T) {
 	require.Equal(t, vc, vcFromJWS)
 }
 
+func TestParseCredentialFromJWS_ThresholdSigner(t *testing.T) {
+	vcBytes := []byte(jwtTestCredential)
+
+	thresholdKMS, thresholdCrypto := newThresholdKMS(t)
+
+	keyID, kh, err := thresholdKMS.Create(kms.ED25519Type)
+	require.NoError(t, err)
+
+	pubKey, _, err := thresholdKMS.ExportPubKeyBytes(keyID)
+	require.NoError(t, err)
+
+	signer := &kmssigner.KMSSigner{KeyType: kms.ED25519Type, KeyHandle: kh, Crypto: thresholdCrypto}
+
+	vc, err := parseTestCredential(t, vcBytes)
+	require.NoError(t, err)
+
+	vcJWSStr := createEdDSAJWS(t, vcBytes, signer, false)
+
+	vcFromJWS, err := parseTestCredential(t,
+		vcJWSStr,
+		WithPublicKeyFetcher(SingleKey(pubKey, kms.ED25519)))
+	require.NoError(t, err)
+
+	require.NotEqual(t, "", vcFromJWS.JWT)
+	vcFromJWS.JWT = ""
+
+	require.Equal(t, vc, vcFromJWS)
+}
+
 func TestParseCredentialFromUnsecuredJWT(t *testing.T) {
 	testCred := []byte(jwtTestCredential)
 
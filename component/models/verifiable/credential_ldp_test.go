@@ -33,6 +33,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/ed25519signature2020"
 	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/jsonwebsignature2020"
+	sigutil "github.com/hyperledger/aries-framework-go/component/models/signature/util"
 	sigverifier "github.com/hyperledger/aries-framework-go/component/models/signature/verifier"
 	jsonutil "github.com/hyperledger/aries-framework-go/component/models/util/json"
 	"github.com/hyperledger/aries-framework-go/spi/kms"
@@ -104,6 +105,41 @@ func TestParseCredentialFromLinkedDataProof_Ed25519Signature2020(t *testing.T) {
 	r.Equal(vc, vcWithLdp)
 }
 
+func TestParseCredentialFromLinkedDataProof_ThresholdSigner(t *testing.T) {
+	r := require.New(t)
+
+	thresholdKMS, thresholdCrypto := newThresholdKMS(t)
+
+	signer, err := sigutil.NewCryptoSigner(thresholdCrypto, thresholdKMS, kms.ED25519Type)
+	r.NoError(err)
+
+	sigSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	ldpContext := &LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   sigSuite,
+		VerificationMethod:      "did:example:123456#key1",
+	}
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	r.NoError(err)
+
+	err = vc.AddLinkedDataProof(ldpContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+	r.NoError(err)
+
+	vcBytes, err := json.Marshal(vc)
+	r.NoError(err)
+
+	vcWithLdp, err := parseTestCredential(t, vcBytes,
+		WithEmbeddedSignatureSuites(sigSuite),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+	r.NoError(err)
+	r.Equal(vc, vcWithLdp)
+}
+
 //nolint:lll
 func TestParseCredentialFromLinkedDataProof_JSONLD_Validation(t *testing.T) {
 	r := require.New(t)
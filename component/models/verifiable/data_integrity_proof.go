@@ -13,6 +13,7 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity"
 	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/models"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite/ecdsasd2023"
 )
 
 // DataIntegrityProofContext holds parameters for creating or validating a Data Integrity Proof.
@@ -23,6 +24,14 @@ type DataIntegrityProofContext struct {
 	Created      *time.Time //
 	Domain       string     //
 	Challenge    string     //
+	// MandatoryPointers lists JSON pointers that the holder must always disclose,
+	// for selective disclosure suites like ecdsa-sd-2023.
+	MandatoryPointers []string
+	// ID identifies the proof, so that later proofs can be chained to it.
+	ID string
+	// PreviousProof is the ID of an existing proof that the new proof is chained to,
+	// eg to endorse the issuer's proof. The existing proof is secured by the new one.
+	PreviousProof string
 }
 
 // AddDataIntegrityProof adds a Data Integrity Proof to the Credential.
@@ -79,6 +88,14 @@ func addDataIntegrityProof(
 		context.ProofPurpose = assertionMethod
 	}
 
+	var customFields map[string]interface{}
+
+	if len(context.MandatoryPointers) > 0 {
+		customFields = map[string]interface{}{
+			ecdsasd2023.MandatoryPointersField: context.MandatoryPointers,
+		}
+	}
+
 	signed, err := signer.AddProof(ldBytes, &models.ProofOptions{
 		Purpose:              context.ProofPurpose,
 		VerificationMethodID: context.SigningKeyID,
@@ -87,6 +104,9 @@ func addDataIntegrityProof(
 		Domain:               context.Domain,
 		Challenge:            context.Challenge,
 		Created:              createdTime,
+		CustomFields:         customFields,
+		ProofID:              context.ID,
+		PreviousProof:        context.PreviousProof,
 	})
 	if err != nil {
 		return nil, err
@@ -115,16 +135,18 @@ type verifyDataIntegrityOpts struct {
 	Challenge string
 }
 
-func checkDataIntegrityProof(ldBytes []byte, opts *verifyDataIntegrityOpts) error {
+// checkDataIntegrityProofs checks each proof of the document's proof set, returning one error per proof in
+// document order. Proofs of other types get an error, and are expected to be checked separately.
+func checkDataIntegrityProofs(ldBytes []byte, opts *verifyDataIntegrityOpts) ([]error, error) {
 	if opts == nil || opts.Verifier == nil {
-		return fmt.Errorf("data integrity proof needs data integrity verifier")
+		return nil, fmt.Errorf("data integrity proof needs data integrity verifier")
 	}
 
 	if opts.Purpose == "" {
 		opts.Purpose = assertionMethod
 	}
 
-	return opts.Verifier.VerifyProof(ldBytes, &models.ProofOptions{
+	return opts.Verifier.VerifyProofSet(ldBytes, &models.ProofOptions{
 		Purpose:   opts.Purpose,
 		ProofType: models.DataIntegrityProof,
 		Domain:    opts.Domain,
@@ -51,6 +51,7 @@ func getProofType(proofMap map[string]interface{}) (string, error) {
 type embeddedProofCheckOpts struct {
 	publicKeyFetcher   PublicKeyFetcher
 	disabledProofCheck bool
+	proofCheckPolicy   ProofCheckPolicy
 
 	ldpSuites []verifier.SignatureSuite
 
@@ -59,15 +60,26 @@ type embeddedProofCheckOpts struct {
 	jsonldCredentialOpts
 }
 
-func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) error { // nolint:gocyclo
+func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) error {
 	if opts.disabledProofCheck {
 		return nil
 	}
 
+	results, err := checkEmbeddedProofs(docBytes, opts)
+	if err != nil {
+		return err
+	}
+
+	return opts.proofCheckPolicy.check(results)
+}
+
+// checkEmbeddedProofs checks every embedded proof of the document independently. An error is returned only
+// if the document or its proof set is malformed; the outcome of each proof is reported in its result.
+func checkEmbeddedProofs(docBytes []byte, opts *embeddedProofCheckOpts) ([]*ProofCheckResult, error) {
 	var jsonldDoc map[string]interface{}
 
 	if err := json.Unmarshal(docBytes, &jsonldDoc); err != nil {
-		return fmt.Errorf("embedded proof is not JSON: %w", err)
+		return nil, fmt.Errorf("embedded proof is not JSON: %w", err)
 	}
 
 	delete(jsonldDoc, "jwt")
@@ -75,12 +87,12 @@ func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) error { /
 	proofElement, ok := jsonldDoc["proof"]
 	if !ok || proofElement == nil {
 		// do not make a check if there is no proof defined as proof presence is not mandatory
-		return nil
+		return nil, nil
 	}
 
 	proofs, err := getProofs(proofElement)
 	if err != nil {
-		return fmt.Errorf("check embedded proof: %w", err)
+		return nil, fmt.Errorf("check embedded proof: %w", err)
 	}
 
 	if len(opts.externalContext) > 0 {
@@ -88,19 +100,53 @@ func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) error { /
 		jsonldDoc["@context"] = jsonld.AppendExternalContexts(jsonldDoc["@context"], opts.externalContext...)
 	}
 
-	if len(proofs) > 0 {
-		typeStr, ok := proofs[0]["type"]
-		if ok && typeStr == models.DataIntegrityProof {
-			docBytes, err = json.Marshal(jsonldDoc)
-			if err != nil {
-				return err
+	var dataIntegrityErrs []error
+
+	if hasDataIntegrityProof(proofs) {
+		docBytes, err = json.Marshal(jsonldDoc)
+		if err != nil {
+			return nil, err
+		}
+
+		dataIntegrityErrs, err = checkDataIntegrityProofs(docBytes, opts.dataIntegrityOpts)
+		if err != nil {
+			// the proof set can't be checked at all, so every data integrity proof fails with the same error
+			dataIntegrityErrs = make([]error, len(proofs))
+			for i := range dataIntegrityErrs {
+				dataIntegrityErrs[i] = err
 			}
+		}
+	}
+
+	results := make([]*ProofCheckResult, len(proofs))
+
+	for i, proof := range proofs {
+		results[i] = newProofCheckResult(proof)
 
-			return checkDataIntegrityProof(docBytes, opts.dataIntegrityOpts)
+		if results[i].Type == models.DataIntegrityProof {
+			results[i].Err = dataIntegrityErrs[i]
+
+			continue
 		}
+
+		results[i].Err = checkEmbeddedLinkedDataProof(jsonldDoc, proof, opts)
 	}
 
-	ldpSuites, err := getSuites(proofs, opts)
+	return results, nil
+}
+
+func hasDataIntegrityProof(proofs []map[string]interface{}) bool {
+	for _, proof := range proofs {
+		if safeStringValue(proof["type"]) == models.DataIntegrityProof {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkEmbeddedLinkedDataProof(jsonldDoc, proof map[string]interface{}, opts *embeddedProofCheckOpts) error {
+	ldpSuites, err := getSuites([]map[string]interface{}{proof}, opts)
 	if err != nil {
 		return err
 	}
@@ -109,7 +155,7 @@ func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) error { /
 		return errors.New("public key fetcher is not defined")
 	}
 
-	err = checkLinkedDataProof(jsonldDoc, ldpSuites, opts.publicKeyFetcher, &opts.jsonldCredentialOpts)
+	err = checkLinkedDataProof(jsonldDoc, proof, ldpSuites, opts.publicKeyFetcher, &opts.jsonldCredentialOpts)
 	if err != nil {
 		return fmt.Errorf("check embedded proof: %w", err)
 	}
@@ -64,9 +64,14 @@ type LinkedDataProofContext struct {
 	Purpose                 string                  // optional
 	// CapabilityChain must be an array. Each element is either a string or an object.
 	CapabilityChain []interface{}
+	// ID identifies the proof, so that later proofs can be chained to it.
+	ID string // optional
+	// PreviousProof is the ID of an existing proof that the new proof is chained to,
+	// eg to endorse the issuer's proof. The existing proof is secured by the new one.
+	PreviousProof string // optional
 }
 
-func checkLinkedDataProof(jsonldBytes map[string]interface{}, suites []verifier.SignatureSuite,
+func checkLinkedDataProof(jsonldBytes, proof map[string]interface{}, suites []verifier.SignatureSuite,
 	pubKeyFetcher PublicKeyFetcher, jsonldOpts *jsonldCredentialOpts) error {
 	documentVerifier, err := verifier.New(&keyResolverAdapter{pubKeyFetcher}, suites...)
 	if err != nil {
@@ -75,7 +80,7 @@ func checkLinkedDataProof(jsonldBytes map[string]interface{}, suites []verifier.
 
 	processorOpts := mapJSONLDProcessorOpts(jsonldOpts)
 
-	err = documentVerifier.VerifyObject(jsonldBytes, processorOpts...)
+	err = documentVerifier.VerifyProof(jsonldBytes, proof, processorOpts...)
 	if err != nil {
 		return fmt.Errorf("check linked data proof: %w", err)
 	}
@@ -140,5 +145,7 @@ func mapContext(context *LinkedDataProofContext) *signer.Context {
 		Domain:                  context.Domain,
 		Purpose:                 context.Purpose,
 		CapabilityChain:         context.CapabilityChain,
+		ID:                      context.ID,
+		PreviousProof:           context.PreviousProof,
 	}
 }
@@ -354,12 +354,14 @@ func (rp *rawPresentation) UnmarshalJSON(data []byte) error {
 type presentationOpts struct {
 	publicKeyFetcher    PublicKeyFetcher
 	disabledProofCheck  bool
+	proofCheckPolicy    ProofCheckPolicy
 	ldpSuites           []verifier.SignatureSuite
 	strictValidation    bool
 	requireVC           bool
 	requireProof        bool
 	disableJSONLDChecks bool
 	verifyDataIntegrity *verifyDataIntegrityOpts
+	credOpts            []CredentialOpt
 
 	jsonldCredentialOpts
 }
@@ -389,6 +391,22 @@ func WithPresDisabledProofCheck() PresentationOpt {
 	}
 }
 
+// WithPresProofCheckPolicy option defines which proofs of a proof set must be valid for the proof check to pass.
+// By default, all proofs must be valid.
+func WithPresProofCheckPolicy(policy ProofCheckPolicy) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.proofCheckPolicy = policy
+	}
+}
+
+// WithPresCredentialOpts sets additional options used by VerifyPresentation to verify
+// the credentials embedded into the presentation.
+func WithPresCredentialOpts(credOpts ...CredentialOpt) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.credOpts = append(opts.credOpts, credOpts...)
+	}
+}
+
 // WithPresStrictValidation enabled strict JSON-LD validation of VP.
 // In case of JSON-LD validation, the comparison of JSON-LD VP document after compaction with original VP one is made.
 // In case of mismatch a validation exception is raised.
@@ -628,13 +646,7 @@ func decodeRawPresentation(vpData []byte, vpOpts *presentationOpts) ([]byte, *ra
 		return vcDataFromJwt, rawCred, vpStr, nil
 	}
 
-	embeddedProofCheckOpts := &embeddedProofCheckOpts{
-		dataIntegrityOpts:    vpOpts.verifyDataIntegrity,
-		publicKeyFetcher:     vpOpts.publicKeyFetcher,
-		disabledProofCheck:   vpOpts.disabledProofCheck,
-		ldpSuites:            vpOpts.ldpSuites,
-		jsonldCredentialOpts: vpOpts.jsonldCredentialOpts,
-	}
+	embeddedProofCheckOpts := getPresEmbeddedProofCheckOpts(vpOpts)
 
 	if jwt.IsJWTUnsecured(vpStr) {
 		rawBytes, rawPres, err := decodeVPFromUnsecuredJWT(vpStr)
@@ -667,6 +679,17 @@ func decodeRawPresentation(vpData []byte, vpOpts *presentationOpts) ([]byte, *ra
 	return vpData, vpRaw, "", err
 }
 
+func getPresEmbeddedProofCheckOpts(vpOpts *presentationOpts) *embeddedProofCheckOpts {
+	return &embeddedProofCheckOpts{
+		dataIntegrityOpts:    vpOpts.verifyDataIntegrity,
+		publicKeyFetcher:     vpOpts.publicKeyFetcher,
+		disabledProofCheck:   vpOpts.disabledProofCheck,
+		proofCheckPolicy:     vpOpts.proofCheckPolicy,
+		ldpSuites:            vpOpts.ldpSuites,
+		jsonldCredentialOpts: vpOpts.jsonldCredentialOpts,
+	}
+}
+
 func decodeVPFromJSON(vpData []byte) (*rawPresentation, error) {
 	// unmarshal VP from JSON
 	raw := new(rawPresentation)
@@ -0,0 +1,115 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ProofCheckPolicy defines which proofs of a proof set must be valid for the embedded proof check to pass.
+type ProofCheckPolicy int
+
+const (
+	// AllProofsValid requires every embedded proof to be valid. This is the default policy.
+	AllProofsValid ProofCheckPolicy = iota
+
+	// AnyProofValid requires at least one embedded proof to be valid.
+	AnyProofValid
+)
+
+// ProofCheckResult holds the outcome of checking a single embedded proof of a credential or presentation.
+type ProofCheckResult struct {
+	// ID of the proof, if the proof has one.
+	ID string
+	// Type of the proof, eg Ed25519Signature2018 or DataIntegrityProof.
+	Type string
+	// CryptoSuite of a DataIntegrityProof, eg ecdsa-2019.
+	CryptoSuite string
+	// VerificationMethod used to create the proof.
+	VerificationMethod string
+	// PreviousProof is the ID of the proof which this proof is chained to, if any.
+	PreviousProof string
+	// Err is the reason the proof is invalid, or nil if the proof is valid.
+	Err error
+}
+
+// Valid returns true if the proof is valid.
+func (r *ProofCheckResult) Valid() bool {
+	return r.Err == nil
+}
+
+func newProofCheckResult(proof map[string]interface{}) *ProofCheckResult {
+	return &ProofCheckResult{
+		ID:                 safeStringValue(proof["id"]),
+		Type:               safeStringValue(proof["type"]),
+		CryptoSuite:        safeStringValue(proof["cryptosuite"]),
+		VerificationMethod: safeStringValue(proof["verificationMethod"]),
+		PreviousProof:      safeStringValue(proof["previousProof"]),
+	}
+}
+
+func (p ProofCheckPolicy) check(results []*ProofCheckResult) error {
+	switch p {
+	case AllProofsValid:
+		for _, result := range results {
+			if result.Err != nil {
+				return result.Err
+			}
+		}
+
+		return nil
+	case AnyProofValid:
+		if len(results) == 0 {
+			return nil
+		}
+
+		for _, result := range results {
+			if result.Err == nil {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("check embedded proof: no valid proof in proof set: %w", results[0].Err)
+	default:
+		return errors.New("check embedded proof: unsupported proof check policy")
+	}
+}
+
+// CheckProofs checks each embedded proof of the Verifiable Credential independently, and returns the outcome
+// of every proof, in the order the proofs appear in the credential. Proofs chained to a previous proof
+// are checked together with the proof they secure. The options are the ones used to parse a credential.
+//
+// An error is returned only if the proofs can't be checked at all, eg if the proof set is malformed.
+func (vc *Credential) CheckProofs(opts ...CredentialOpt) ([]*ProofCheckResult, error) {
+	vcCopy := *vc
+	vcCopy.JWT = ""
+
+	vcBytes, err := vcCopy.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("check proofs of VC: %w", err)
+	}
+
+	return checkEmbeddedProofs(vcBytes, getEmbeddedProofCheckOpts(getCredentialOpts(opts)))
+}
+
+// CheckProofs checks each embedded proof of the Verifiable Presentation independently, and returns the outcome
+// of every proof, in the order the proofs appear in the presentation. Proofs chained to a previous proof
+// are checked together with the proof they secure. The options are the ones used to parse a presentation.
+//
+// An error is returned only if the proofs can't be checked at all, eg if the proof set is malformed.
+func (vp *Presentation) CheckProofs(opts ...PresentationOpt) ([]*ProofCheckResult, error) {
+	vpCopy := *vp
+	vpCopy.JWT = ""
+
+	vpBytes, err := vpCopy.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("check proofs of VP: %w", err)
+	}
+
+	return checkEmbeddedProofs(vpBytes, getPresEmbeddedProofCheckOpts(getPresentationOpts(opts)))
+}
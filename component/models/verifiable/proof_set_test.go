@@ -0,0 +1,354 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/jwkkid"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite/ecdsa2019"
+	"github.com/hyperledger/aries-framework-go/component/models/did"
+	jsonldsig "github.com/hyperledger/aries-framework-go/component/models/ld/processor"
+	"github.com/hyperledger/aries-framework-go/component/models/ld/proof"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/suite"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/verifier"
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+func TestCredential_CheckProofs(t *testing.T) {
+	t.Run("proof set", func(t *testing.T) {
+		vc, publicKeyFetcher := createVCWithTwoLinkedDataProofs(t)
+
+		results, err := vc.CheckProofs(WithPublicKeyFetcher(publicKeyFetcher),
+			WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		require.True(t, results[0].Valid())
+		require.True(t, results[1].Valid())
+		require.Equal(t, "did:123#key1", results[0].VerificationMethod)
+		require.Equal(t, "did:123#key2", results[1].VerificationMethod)
+		require.Equal(t, "Ed25519Signature2018", results[1].Type)
+
+		vc.Proofs[1]["jws"] = vc.Proofs[0]["jws"]
+		vcBytes := vc.byteJSON(t)
+
+		results, err = vc.CheckProofs(WithPublicKeyFetcher(publicKeyFetcher),
+			WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		require.True(t, results[0].Valid())
+		require.False(t, results[1].Valid())
+
+		_, err = parseTestCredential(t, vcBytes, WithPublicKeyFetcher(publicKeyFetcher))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "check embedded proof")
+
+		_, err = parseTestCredential(t, vcBytes, WithPublicKeyFetcher(publicKeyFetcher),
+			WithProofCheckPolicy(AnyProofValid))
+		require.NoError(t, err)
+
+		vc.Proofs[0]["jws"] = vc.Proofs[0]["jws"].(string) + "x"
+
+		_, err = parseTestCredential(t, vc.byteJSON(t), WithPublicKeyFetcher(publicKeyFetcher),
+			WithProofCheckPolicy(AnyProofValid))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no valid proof in proof set")
+	})
+
+	t.Run("linked data proof chain", func(t *testing.T) {
+		vc, publicKeyFetcher := createVCWithLinkedDataProofChain(t)
+
+		checkOpts := []CredentialOpt{
+			WithPublicKeyFetcher(publicKeyFetcher),
+			WithJSONLDDocumentLoader(createTestDocumentLoader(t)),
+		}
+
+		results, err := vc.CheckProofs(checkOpts...)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		require.True(t, results[0].Valid())
+		require.True(t, results[1].Valid())
+		require.Equal(t, "urn:uuid:issuer-proof", results[0].ID)
+		require.Equal(t, "urn:uuid:issuer-proof", results[1].PreviousProof)
+
+		_, err = parseTestCredential(t, vc.byteJSON(t), WithPublicKeyFetcher(publicKeyFetcher))
+		require.NoError(t, err)
+
+		t.Run("endorsement breaks if previous proof changes", func(t *testing.T) {
+			tampered := *vc
+			tampered.Proofs = []Proof{copyProof(vc.Proofs[0]), vc.Proofs[1]}
+			tampered.Proofs[0]["created"] = "2000-01-01T00:00:00Z"
+
+			results, err = tampered.CheckProofs(checkOpts...)
+			require.NoError(t, err)
+			require.Len(t, results, 2)
+			require.False(t, results[0].Valid())
+			require.False(t, results[1].Valid())
+		})
+
+		t.Run("previous proof missing", func(t *testing.T) {
+			withoutPrevious := *vc
+			withoutPrevious.Proofs = []Proof{vc.Proofs[1]}
+
+			results, err = withoutPrevious.CheckProofs(checkOpts...)
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			require.ErrorIs(t, results[0].Err, proof.ErrPreviousProofNotFound)
+		})
+
+		t.Run("add proof chained to missing proof", func(t *testing.T) {
+			signer, err := newCryptoSigner(kmsapi.ED25519Type)
+			require.NoError(t, err)
+
+			err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+				SignatureType:           "Ed25519Signature2018",
+				Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+				SignatureRepresentation: SignatureJWS,
+				VerificationMethod:      "did:123#key3",
+				PreviousProof:           "urn:uuid:unknown",
+			}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+			require.ErrorIs(t, err, proof.ErrPreviousProofNotFound)
+		})
+	})
+
+	t.Run("data integrity proof chain", func(t *testing.T) {
+		signer, diVerifier := newTestDataIntegritySignerVerifier(t)
+
+		vc, err := parseTestCredential(t, []byte(dataIntegrityCredential), WithDisabledProofCheck())
+		require.NoError(t, err)
+
+		err = vc.AddDataIntegrityProof(&DataIntegrityProofContext{
+			SigningKeyID: testDataIntegrityKID,
+			CryptoSuite:  ecdsa2019.SuiteType,
+			ID:           "urn:uuid:issuer-proof",
+		}, signer)
+		require.NoError(t, err)
+
+		err = vc.AddDataIntegrityProof(&DataIntegrityProofContext{
+			SigningKeyID:  testDataIntegrityKID,
+			CryptoSuite:   ecdsa2019.SuiteType,
+			PreviousProof: "urn:uuid:issuer-proof",
+		}, signer)
+		require.NoError(t, err)
+		require.Len(t, vc.Proofs, 2)
+
+		results, err := vc.CheckProofs(WithDataIntegrityVerifier(diVerifier))
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		require.True(t, results[0].Valid())
+		require.True(t, results[1].Valid())
+		require.Equal(t, ecdsa2019.SuiteType, results[1].CryptoSuite)
+		require.Equal(t, "urn:uuid:issuer-proof", results[1].PreviousProof)
+
+		_, err = parseTestCredential(t, vc.byteJSON(t), WithDataIntegrityVerifier(diVerifier))
+		require.NoError(t, err)
+
+		withoutPrevious := *vc
+		withoutPrevious.Proofs = []Proof{vc.Proofs[1]}
+
+		_, err = parseTestCredential(t, withoutPrevious.byteJSON(t), WithDataIntegrityVerifier(diVerifier))
+		require.ErrorIs(t, err, dataintegrity.ErrPreviousProofNotFound)
+	})
+
+	t.Run("no proofs", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential), WithDisabledProofCheck())
+		require.NoError(t, err)
+
+		vc.Proofs = nil
+
+		results, err := vc.CheckProofs()
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
+}
+
+func TestPresentation_CheckProofs(t *testing.T) {
+	signer, diVerifier := newTestDataIntegritySignerVerifier(t)
+
+	vp, err := newTestPresentation(t, []byte(validPresentation), WithPresDisabledProofCheck())
+	require.NoError(t, err)
+
+	err = vp.AddDataIntegrityProof(&DataIntegrityProofContext{
+		SigningKeyID: testDataIntegrityKID,
+		CryptoSuite:  ecdsa2019.SuiteType,
+		Domain:       "other-domain",
+	}, signer)
+	require.NoError(t, err)
+
+	err = vp.AddDataIntegrityProof(&DataIntegrityProofContext{
+		SigningKeyID: testDataIntegrityKID,
+		CryptoSuite:  ecdsa2019.SuiteType,
+		Domain:       "mock-domain",
+	}, signer)
+	require.NoError(t, err)
+
+	vpBytes, err := vp.MarshalJSON()
+	require.NoError(t, err)
+
+	expectedFields := WithPresExpectedDataIntegrityFields("", "mock-domain", "")
+
+	results, err := vp.CheckProofs(WithPresDataIntegrityVerifier(diVerifier), expectedFields)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.False(t, results[0].Valid())
+	require.ErrorIs(t, results[0].Err, dataintegrity.ErrInvalidDomain)
+	require.True(t, results[1].Valid())
+
+	_, err = newTestPresentation(t, vpBytes, WithPresDataIntegrityVerifier(diVerifier), expectedFields)
+	require.ErrorIs(t, err, dataintegrity.ErrInvalidDomain)
+
+	_, err = newTestPresentation(t, vpBytes, WithPresDataIntegrityVerifier(diVerifier), expectedFields,
+		WithPresProofCheckPolicy(AnyProofValid))
+	require.NoError(t, err)
+}
+
+func TestProofCheckPolicy(t *testing.T) {
+	valid := &ProofCheckResult{}
+	invalid := &ProofCheckResult{Err: proof.ErrPreviousProofNotFound}
+
+	require.NoError(t, AllProofsValid.check(nil))
+	require.NoError(t, AllProofsValid.check([]*ProofCheckResult{valid, valid}))
+	require.ErrorIs(t, AllProofsValid.check([]*ProofCheckResult{valid, invalid}), proof.ErrPreviousProofNotFound)
+
+	require.NoError(t, AnyProofValid.check(nil))
+	require.NoError(t, AnyProofValid.check([]*ProofCheckResult{invalid, valid}))
+	require.ErrorIs(t, AnyProofValid.check([]*ProofCheckResult{invalid}), proof.ErrPreviousProofNotFound)
+
+	require.EqualError(t, ProofCheckPolicy(-1).check(nil), "check embedded proof: unsupported proof check policy")
+}
+
+const (
+	testDataIntegrityDID = "did:foo:bar"
+	testDataIntegrityKID = testDataIntegrityDID + "#key-1"
+
+	dataIntegrityCredential = `
+{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    "https://www.w3.org/2018/credentials/examples/v1",
+    "https://w3id.org/security/data-integrity/v1"
+  ],
+  "id": "https://example.com/credentials/1872",
+  "type": [
+    "VerifiableCredential",
+    "UniversityDegreeCredential"
+  ],
+  "issuer": "did:key:z6Mkj7of2aaooXhTJvJ5oCL9ZVcAS472ZBuSjYyXDa4bWT32",
+  "issuanceDate": "2020-01-17T15:14:09.724Z",
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+    "degree": {
+      "type": "BachelorDegree"
+    },
+    "name": "Jayden Doe"
+  }
+}
+`
+)
+
+func newTestDataIntegritySignerVerifier(t *testing.T) (*dataintegrity.Signer, *dataintegrity.Verifier) {
+	t.Helper()
+
+	kms, err := createKMS()
+	require.NoError(t, err)
+
+	cr, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	docLoader := createTestDocumentLoader(t)
+
+	_, keyBytes, err := kms.CreateAndExportPubKeyBytes(kmsapi.ECDSAP256IEEEP1363)
+	require.NoError(t, err)
+
+	key, err := jwkkid.BuildJWK(keyBytes, kmsapi.ECDSAP256IEEEP1363)
+	require.NoError(t, err)
+
+	vm, err := did.NewVerificationMethodFromJWK(testDataIntegrityKID, "JsonWebKey2020", testDataIntegrityDID, key)
+	require.NoError(t, err)
+
+	resolver := resolveFunc(func(id string) (*did.DocResolution, error) {
+		return makeMockDIDResolution(testDataIntegrityDID, vm, did.AssertionMethod), nil
+	})
+
+	signer, err := dataintegrity.NewSigner(&dataintegrity.Options{DIDResolver: resolver},
+		ecdsa2019.NewSignerInitializer(&ecdsa2019.SignerInitializerOptions{
+			SignerGetter:     ecdsa2019.WithLocalKMSSigner(kms, cr),
+			LDDocumentLoader: docLoader,
+		}))
+	require.NoError(t, err)
+
+	diVerifier, err := dataintegrity.NewVerifier(&dataintegrity.Options{DIDResolver: resolver},
+		ecdsa2019.NewVerifierInitializer(&ecdsa2019.VerifierInitializerOptions{
+			LDDocumentLoader: docLoader,
+		}))
+	require.NoError(t, err)
+
+	return signer, diVerifier
+}
+
+func createVCWithLinkedDataProofChain(t *testing.T) (*Credential, PublicKeyFetcher) {
+	t.Helper()
+
+	vc, err := parseTestCredential(t, []byte(validCredential), WithDisabledProofCheck())
+	require.NoError(t, err)
+
+	vc.Proofs = nil
+
+	created := time.Now()
+
+	issuerSigner, err := newCryptoSigner(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		Suite:                   ed25519signature2018.New(suite.WithSigner(issuerSigner)),
+		SignatureRepresentation: SignatureJWS,
+		Created:                 &created,
+		VerificationMethod:      "did:123#key1",
+		ID:                      "urn:uuid:issuer-proof",
+	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+
+	endorserSigner, err := newCryptoSigner(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		Suite:                   ed25519signature2018.New(suite.WithSigner(endorserSigner)),
+		SignatureRepresentation: SignatureProofValue,
+		Created:                 &created,
+		VerificationMethod:      "did:456#key1",
+		PreviousProof:           "urn:uuid:issuer-proof",
+	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+
+	return vc, func(issuerID, keyID string) (*verifier.PublicKey, error) {
+		pubKey := &verifier.PublicKey{Type: "Ed25519Signature2018", Value: issuerSigner.PublicKeyBytes()}
+
+		if issuerID == "did:456" {
+			pubKey.Value = endorserSigner.PublicKeyBytes()
+		}
+
+		return pubKey, nil
+	}
+}
+
+func copyProof(p Proof) Proof {
+	out := make(Proof, len(p))
+
+	for k, v := range p {
+		out[k] = v
+	}
+
+	return out
+}
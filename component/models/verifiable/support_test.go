@@ -13,8 +13,10 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/thresholdcrypto"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/thresholdkms"
 	mockkms "github.com/hyperledger/aries-framework-go/component/kmscrypto/mock/kms"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/secretlock/noop"
 	ldcontext "github.com/hyperledger/aries-framework-go/component/models/ld/context"
@@ -182,6 +184,33 @@ func newCryptoSigner(keyType kmsapi.KeyType) (sigutil.Signer, error) {
 	return sigutil.NewCryptoSigner(tinkCrypto, localKMS, keyType)
 }
 
+// newThresholdKMS creates a thresholdKMS sharing its keys between three in-process participants, any two of them
+// signing with the returned thresholdCrypto.
+func newThresholdKMS(t *testing.T) (*thresholdkms.ThresholdKMS, *thresholdcrypto.Crypto) {
+	t.Helper()
+
+	newProvider := func() kmsapi.Provider {
+		p, err := mockkms.NewProviderForKMS(storage.NewMockStoreProvider(), &noop.NoLock{})
+		require.NoError(t, err)
+
+		return p
+	}
+
+	ids := []uint16{1, 2, 3}
+	participants := make([]*thresholdkms.Participant, len(ids))
+
+	for i, id := range ids {
+		participants[i] = thresholdkms.NewParticipant(id, "local-lock://custom/master/key/", newProvider())
+	}
+
+	transport := thresholdkms.NewInProcessTransport(participants...)
+
+	thresholdKMS, err := thresholdkms.New(newProvider(), transport, 2, ids)
+	require.NoError(t, err)
+
+	return thresholdKMS, thresholdcrypto.New(transport)
+}
+
 func createTestDocumentLoader(t *testing.T, extraContexts ...ldcontext.Document) *lddocloader.DocumentLoader {
 	t.Helper()
 
@@ -0,0 +1,541 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/component/models/jwt"
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/common"
+	sdjwtverifier "github.com/hyperledger/aries-framework-go/component/models/sdjwt/verifier"
+)
+
+// VerificationCheck is the name of a single check made by VerifyCredential or VerifyPresentation.
+type VerificationCheck string
+
+const (
+	// SchemaCheck validates the document against the JSON schema of the data model
+	// and of the credential schemas it refers to.
+	SchemaCheck VerificationCheck = "schema"
+	// JSONLDCheck validates the document as JSON-LD.
+	JSONLDCheck VerificationCheck = "jsonld"
+	// ProofCheck verifies the JWS or the embedded proofs of the document.
+	ProofCheck VerificationCheck = "proof"
+	// ValidityPeriodCheck checks the credential is already issued and not expired.
+	ValidityPeriodCheck VerificationCheck = "validityPeriod"
+	// StatusCheck checks the credential status, eg its revocation.
+	StatusCheck VerificationCheck = "status"
+	// IssuerCheck resolves the DID of the credential issuer.
+	IssuerCheck VerificationCheck = "issuer"
+	// HolderBindingCheck checks the document is presented by its holder.
+	HolderBindingCheck VerificationCheck = "holderBinding"
+)
+
+// CheckOutcome is the outcome of a single verification check.
+type CheckOutcome string
+
+const (
+	// CheckPassed means the check was made and passed.
+	CheckPassed CheckOutcome = "passed"
+	// CheckFailed means the check was made and failed.
+	CheckFailed CheckOutcome = "failed"
+	// CheckSkipped means the check does not apply to the document or the options it needs were not provided.
+	CheckSkipped CheckOutcome = "skipped"
+)
+
+// CredentialStatusChecker checks the status of a credential which defines credentialStatus,
+// and returns an error if the credential is revoked, suspended or its status can't be checked.
+type CredentialStatusChecker func(vc *Credential) error
+
+// CheckResult holds the outcome of a single verification check.
+type CheckResult struct {
+	Check   VerificationCheck
+	Outcome CheckOutcome
+	// Err is the reason the check failed or was skipped.
+	Err error
+}
+
+// VerificationReport holds the outcome of every check made by VerifyCredential or VerifyPresentation.
+type VerificationReport struct {
+	// Checks holds the outcome of each check, in the order the checks were made.
+	Checks []*CheckResult
+	// Proofs holds the outcome of each embedded proof. It's empty for documents secured by JWS.
+	Proofs []*ProofCheckResult
+	// Credentials holds a report for each credential embedded into a presentation.
+	Credentials []*VerificationReport
+}
+
+// Valid returns true if none of the checks failed, including the checks of embedded credentials.
+func (r *VerificationReport) Valid() bool {
+	for _, check := range r.Checks {
+		if check.Outcome == CheckFailed {
+			return false
+		}
+	}
+
+	for _, credReport := range r.Credentials {
+		if !credReport.Valid() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Check returns the outcome of the given check, or nil if the check was not made.
+func (r *VerificationReport) Check(check VerificationCheck) *CheckResult {
+	for _, result := range r.Checks {
+		if result.Check == check {
+			return result
+		}
+	}
+
+	return nil
+}
+
+func (r *VerificationReport) add(check VerificationCheck, err error) {
+	outcome := CheckPassed
+	if err != nil {
+		outcome = CheckFailed
+	}
+
+	r.Checks = append(r.Checks, &CheckResult{Check: check, Outcome: outcome, Err: err})
+}
+
+func (r *VerificationReport) skip(check VerificationCheck, reason string) {
+	r.Checks = append(r.Checks, &CheckResult{Check: check, Outcome: CheckSkipped, Err: errors.New(reason)})
+}
+
+// VerifyCredential verifies the Verifiable Credential and reports the outcome of each check separately.
+// Unlike ParseCredential, it does not stop on the first failed check. The options are the ones used
+// to parse a credential; WithStatusChecker and WithIssuerDIDResolver enable the status and the issuer checks.
+//
+// An error is returned only if the credential can't be decoded at all.
+func VerifyCredential(vcData []byte, opts ...CredentialOpt) (*VerificationReport, error) {
+	vcOpts := getCredentialOpts(opts)
+	report := &VerificationReport{}
+
+	vcStr := unwrapStringVC(vcData)
+
+	isJWT, jwtStr, disclosures, holderBinding := isJWTVC(vcStr)
+
+	var (
+		vcDataDecoded []byte
+		jwsVerified   bool
+	)
+
+	if isJWT {
+		var err error
+
+		_, vcDataDecoded, err = decodeCredJWS(jwtStr, false, nil)
+		if err != nil {
+			return nil, fmt.Errorf("decode new JWT credential: %w", err)
+		}
+
+		jwsVerified = report.checkJWS(vcOpts.disabledProofCheck, func() error {
+			return checkCredentialJWS(jwtStr, vcDataDecoded, disclosures, vcOpts)
+		})
+	} else {
+		vcDataDecoded = vcData
+
+		if jwt.IsJWTUnsecured(vcStr) {
+			var err error
+
+			vcDataDecoded, err = decodeCredJWTUnsecured(vcStr)
+			if err != nil {
+				return nil, fmt.Errorf("unsecured JWT decoding: %w", err)
+			}
+		}
+
+		report.checkEmbeddedProofs(vcDataDecoded, vcOpts.disabledProofCheck, vcOpts.proofCheckPolicy,
+			getEmbeddedProofCheckOpts(vcOpts))
+	}
+
+	vc, err := populateCredential(vcDataDecoded, disclosures, common.SDJWTVersionDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	vc.SDHolderBinding = holderBinding
+
+	report.checkCredentialModel(vc, vcDataDecoded, isJWT, vcOpts)
+	report.add(ValidityPeriodCheck, checkValidityPeriod(vc))
+	report.checkCredentialStatus(vc, vcOpts)
+	report.checkIssuer(vc, vcOpts)
+
+	switch {
+	case holderBinding == "":
+		report.skip(HolderBindingCheck, "credential has no holder binding")
+	case !jwsVerified:
+		report.skip(HolderBindingCheck, "issuer signature of the credential is not verified")
+	default:
+		report.add(HolderBindingCheck, checkSDJWTHolderBinding(vcStr, vcOpts.publicKeyFetcher))
+	}
+
+	return report, nil
+}
+
+// VerifyPresentation verifies the Verifiable Presentation and the credentials embedded into it,
+// and reports the outcome of each check separately. Unlike ParsePresentation, it does not stop
+// on the first failed check. The options are the ones used to parse a presentation;
+// WithPresCredentialOpts sets additional options to verify the embedded credentials.
+//
+// An error is returned only if the presentation can't be decoded at all.
+func VerifyPresentation(vpData []byte, opts ...PresentationOpt) (*VerificationReport, error) {
+	vpOpts := getPresentationOpts(opts)
+	report := &VerificationReport{}
+
+	vpStr := string(unQuote(vpData))
+
+	var (
+		vpDataDecoded []byte
+		vpRaw         *rawPresentation
+		jwsVerified   bool
+		err           error
+	)
+
+	switch {
+	case jwt.IsJWS(vpStr):
+		vpDataDecoded, vpRaw, err = decodeVPFromJWS(vpStr, false, nil)
+		if err != nil {
+			return nil, fmt.Errorf("decoding of Verifiable Presentation from JWS: %w", err)
+		}
+
+		jwsVerified = report.checkJWS(vpOpts.disabledProofCheck, func() error {
+			return checkPresentationJWS(vpStr, vpOpts)
+		})
+	case jwt.IsJWTUnsecured(vpStr):
+		vpDataDecoded, vpRaw, err = decodeVPFromUnsecuredJWT(vpStr)
+		if err != nil {
+			return nil, fmt.Errorf("decoding of Verifiable Presentation from unsecured JWT: %w", err)
+		}
+
+		report.checkEmbeddedProofs(vpDataDecoded, vpOpts.disabledProofCheck, vpOpts.proofCheckPolicy,
+			getPresEmbeddedProofCheckOpts(vpOpts))
+	default:
+		vpRaw, err = decodeVPFromJSON(vpData)
+		if err != nil {
+			return nil, err
+		}
+
+		vpDataDecoded = vpData
+
+		report.checkEmbeddedProofs(vpDataDecoded, vpOpts.disabledProofCheck, vpOpts.proofCheckPolicy,
+			getPresEmbeddedProofCheckOpts(vpOpts))
+	}
+
+	report.add(SchemaCheck, validateVPJSONSchema(vpDataDecoded))
+
+	if vpOpts.disableJSONLDChecks {
+		report.skip(JSONLDCheck, "JSON-LD checks are disabled")
+	} else {
+		report.add(JSONLDCheck, validateVPJSONLD(vpDataDecoded, vpOpts))
+	}
+
+	report.checkPresentationHolder(vpRaw, vpStr, jwsVerified)
+
+	report.Credentials, err = verifyPresentationCredentials(vpRaw.Credential, vpOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// checkJWS adds the outcome of the JWS proof check, skipped if the proof check is disabled,
+// and returns whether the JWS signature is verified.
+func (r *VerificationReport) checkJWS(disabled bool, check func() error) bool {
+	if disabled {
+		r.skip(ProofCheck, "proof check is disabled")
+
+		return false
+	}
+
+	err := check()
+	r.add(ProofCheck, err)
+
+	return err == nil
+}
+
+func checkCredentialJWS(jwtStr string, vcDataDecoded []byte, disclosures []string, vcOpts *credentialOpts) error {
+	if vcOpts.publicKeyFetcher == nil {
+		return errors.New("public key fetcher is not defined")
+	}
+
+	if _, _, err := decodeCredJWS(jwtStr, true, vcOpts.publicKeyFetcher); err != nil {
+		return fmt.Errorf("JWS decoding: %w", err)
+	}
+
+	return validateDisclosures(vcDataDecoded, disclosures)
+}
+
+func checkPresentationJWS(vpStr string, vpOpts *presentationOpts) error {
+	if vpOpts.publicKeyFetcher == nil {
+		return errors.New("public key fetcher is not defined")
+	}
+
+	if _, _, err := decodeVPFromJWS(vpStr, true, vpOpts.publicKeyFetcher); err != nil {
+		return fmt.Errorf("JWS decoding: %w", err)
+	}
+
+	return nil
+}
+
+func (r *VerificationReport) checkEmbeddedProofs(docBytes []byte, disabled bool, policy ProofCheckPolicy,
+	opts *embeddedProofCheckOpts) {
+	if disabled {
+		r.skip(ProofCheck, "proof check is disabled")
+
+		return
+	}
+
+	results, err := checkEmbeddedProofs(docBytes, opts)
+	if err != nil {
+		r.add(ProofCheck, err)
+
+		return
+	}
+
+	if len(results) == 0 {
+		r.add(ProofCheck, errors.New("embedded proof is missing"))
+
+		return
+	}
+
+	r.Proofs = results
+	r.add(ProofCheck, policy.check(results))
+}
+
+func (r *VerificationReport) checkCredentialModel(vc *Credential, vcBytes []byte, isJWT bool,
+	vcOpts *credentialOpts) {
+	switch {
+	case isJWT:
+		r.skip(SchemaCheck, "data model validation is not made for JWT credentials")
+		r.skip(JSONLDCheck, "data model validation is not made for JWT credentials")
+
+		return
+	case vcOpts.disableValidation:
+		r.skip(SchemaCheck, "credential validation is disabled")
+		r.skip(JSONLDCheck, "credential validation is disabled")
+
+		return
+	}
+
+	switch vcOpts.modelValidationMode {
+	case combinedValidation:
+		r.add(SchemaCheck, vc.validateJSONSchema(vcBytes, vcOpts))
+		r.add(JSONLDCheck, vc.validateJSONLD(vcBytes, vcOpts))
+	case jsonldValidation:
+		r.skip(SchemaCheck, "JSON schema validation is disabled")
+		r.add(JSONLDCheck, vc.validateJSONLD(vcBytes, vcOpts))
+	case baseContextValidation:
+		r.add(SchemaCheck, vc.validateBaseContext(vcBytes, vcOpts))
+		r.skip(JSONLDCheck, "JSON-LD validation is disabled")
+	case baseContextExtendedValidation:
+		r.add(SchemaCheck, vc.validateBaseContextWithExtendedValidation(vcOpts, vcBytes))
+		r.skip(JSONLDCheck, "JSON-LD validation is disabled")
+	default:
+		err := fmt.Errorf("unsupported vcModelValidationMode: %v", vcOpts.modelValidationMode)
+
+		r.add(SchemaCheck, err)
+		r.add(JSONLDCheck, err)
+	}
+}
+
+func checkValidityPeriod(vc *Credential) error {
+	now := time.Now()
+
+	if vc.Issued != nil && vc.Issued.Time.After(now) {
+		return fmt.Errorf("credential is not valid before %s", vc.Issued.FormatToString())
+	}
+
+	if vc.Expired != nil && vc.Expired.Time.Before(now) {
+		return fmt.Errorf("credential expired at %s", vc.Expired.FormatToString())
+	}
+
+	return nil
+}
+
+func (r *VerificationReport) checkCredentialStatus(vc *Credential, vcOpts *credentialOpts) {
+	switch {
+	case vc.Status == nil:
+		r.skip(StatusCheck, "credential has no credentialStatus")
+	case vcOpts.statusChecker == nil:
+		r.skip(StatusCheck, "status checker is not defined")
+	default:
+		r.add(StatusCheck, vcOpts.statusChecker(vc))
+	}
+}
+
+func (r *VerificationReport) checkIssuer(vc *Credential, vcOpts *credentialOpts) {
+	if !strings.HasPrefix(vc.Issuer.ID, "did:") {
+		r.skip(IssuerCheck, "issuer is not a DID")
+
+		return
+	}
+
+	if vcOpts.issuerResolver == nil {
+		r.skip(IssuerCheck, "DID resolver is not defined")
+
+		return
+	}
+
+	docResolution, err := vcOpts.issuerResolver.Resolve(vc.Issuer.ID)
+	if err != nil {
+		r.add(IssuerCheck, fmt.Errorf("resolve issuer DID %s: %w", vc.Issuer.ID, err))
+
+		return
+	}
+
+	if docResolution.DocumentMetadata != nil && docResolution.DocumentMetadata.Deactivated {
+		r.add(IssuerCheck, fmt.Errorf("issuer DID %s is deactivated", vc.Issuer.ID))
+
+		return
+	}
+
+	r.add(IssuerCheck, nil)
+}
+
+// checkSDJWTHolderBinding verifies the holder binding JWT of SD-JWT credential against the key
+// the issuer bound the credential to. It must only be called once the issuer signature is verified
+// by the proof check; the SD-JWT is verified again with the same key so that the holder binding is
+// checked against the verified claims.
+func checkSDJWTHolderBinding(combinedFormat string, fetcher PublicKeyFetcher) error {
+	cfp := common.ParseCombinedFormatForPresentation(combinedFormat)
+
+	verifier := jwt.NewVerifier(jwt.KeyResolverFunc(fetcher))
+
+	sdJWT, _, err := jwt.Parse(cfp.SDJWT, jwt.WithSignatureVerifier(verifier), jwt.WithIgnoreClaimsMapDecoding(true))
+	if err != nil {
+		return fmt.Errorf("parse SD-JWT: %w", err)
+	}
+
+	alg, _ := sdJWT.Headers.Algorithm()
+
+	_, err = sdjwtverifier.Parse(combinedFormat,
+		sdjwtverifier.WithSignatureVerifier(verifier),
+		sdjwtverifier.WithIssuerSigningAlgorithms([]string{alg}),
+		sdjwtverifier.WithHolderSigningAlgorithms(holderSigningAlgorithms),
+		sdjwtverifier.WithHolderVerificationRequired(true),
+	)
+	if err != nil {
+		return fmt.Errorf("verify SD-JWT holder binding: %w", err)
+	}
+
+	return nil
+}
+
+// holderSigningAlgorithms are the algorithms accepted for the SD-JWT holder binding JWT.
+var holderSigningAlgorithms = []string{"EdDSA", "ES256", "ES384", "ES521", "ES256K", "RS256", "PS256"} //nolint:gochecknoglobals
+
+// checkPresentationHolder checks the presentation is proven by its holder: the embedded proofs must be
+// created with a verification method of the holder DID. A JWT presentation is bound to its holder
+// by the JWS itself, as the key used to check it is resolved from the issuer of the JWT, ie the holder,
+// so the binding holds only if the JWS signature is verified.
+func (r *VerificationReport) checkPresentationHolder(vpRaw *rawPresentation, vpStr string, jwsVerified bool) {
+	if vpRaw.Holder == "" {
+		r.skip(HolderBindingCheck, "presentation has no holder")
+
+		return
+	}
+
+	if jwt.IsJWS(vpStr) {
+		if !jwsVerified {
+			r.skip(HolderBindingCheck, "JWS signature of the presentation is not verified")
+
+			return
+		}
+
+		r.add(HolderBindingCheck, nil)
+
+		return
+	}
+
+	proofs, err := parseProof(vpRaw.Proof)
+	if err != nil {
+		r.add(HolderBindingCheck, err)
+
+		return
+	}
+
+	if len(proofs) == 0 {
+		r.skip(HolderBindingCheck, "presentation has no proof")
+
+		return
+	}
+
+	for _, proof := range proofs {
+		vm := safeStringValue(proof["verificationMethod"])
+
+		if strings.Split(vm, "#")[0] != vpRaw.Holder {
+			r.add(HolderBindingCheck,
+				fmt.Errorf("verification method %s of presentation proof is not controlled by holder %s", vm, vpRaw.Holder))
+
+			return
+		}
+	}
+
+	r.add(HolderBindingCheck, nil)
+}
+
+func verifyPresentationCredentials(rawCreds interface{}, vpOpts *presentationOpts) ([]*VerificationReport, error) {
+	var creds []interface{}
+
+	switch c := rawCreds.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		creds = c
+	default:
+		creds = []interface{}{c}
+	}
+
+	credOpts := []CredentialOpt{
+		WithPublicKeyFetcher(vpOpts.publicKeyFetcher),
+		WithEmbeddedSignatureSuites(vpOpts.ldpSuites...),
+		WithJSONLDDocumentLoader(vpOpts.jsonldCredentialOpts.jsonldDocumentLoader),
+		WithProofCheckPolicy(vpOpts.proofCheckPolicy),
+	}
+
+	if vpOpts.disabledProofCheck {
+		credOpts = append(credOpts, WithDisabledProofCheck())
+	}
+
+	credOpts = append(credOpts, vpOpts.credOpts...)
+
+	reports := make([]*VerificationReport, len(creds))
+
+	for i, cred := range creds {
+		var credBytes []byte
+
+		if sCred, ok := cred.(string); ok {
+			credBytes = []byte(sCred)
+		} else {
+			var err error
+
+			credBytes, err = json.Marshal(cred)
+			if err != nil {
+				return nil, fmt.Errorf("marshal credential of presentation: %w", err)
+			}
+		}
+
+		credReport, err := VerifyCredential(credBytes, credOpts...)
+		if err != nil {
+			// the credential can't be decoded, so it does not conform to the data model
+			credReport = &VerificationReport{}
+			credReport.add(SchemaCheck, err)
+		}
+
+		reports[i] = credReport
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,418 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/component/models/did"
+	afgojwt "github.com/hyperledger/aries-framework-go/component/models/jwt"
+	ldprocessor "github.com/hyperledger/aries-framework-go/component/models/ld/processor"
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/common"
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/holder"
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/issuer"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/suite"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/ed25519signature2018"
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+	vdrapi "github.com/hyperledger/aries-framework-go/spi/vdr"
+)
+
+func TestVerifyCredential(t *testing.T) {
+	loader := createTestDocumentLoader(t)
+
+	t.Run("valid credential", func(t *testing.T) {
+		vc, fetcher := createVCWithLinkedDataProofNoExpiry(t)
+
+		report, err := VerifyCredential(vc.byteJSON(t),
+			WithJSONLDDocumentLoader(loader),
+			WithPublicKeyFetcher(fetcher),
+			WithIssuerDIDResolver(&mockIssuerResolver{}))
+		require.NoError(t, err)
+		require.True(t, report.Valid())
+		require.Len(t, report.Proofs, 1)
+		require.True(t, report.Proofs[0].Valid())
+
+		requireOutcomes(t, report, map[VerificationCheck]CheckOutcome{
+			ProofCheck:          CheckPassed,
+			SchemaCheck:         CheckPassed,
+			JSONLDCheck:         CheckPassed,
+			ValidityPeriodCheck: CheckPassed,
+			StatusCheck:         CheckSkipped,
+			IssuerCheck:         CheckPassed,
+			HolderBindingCheck:  CheckSkipped,
+		})
+	})
+
+	t.Run("reports every failed check", func(t *testing.T) {
+		vc, fetcher := createVCWithLinkedDataProof(t)
+
+		statusErr := errors.New("credential is revoked")
+
+		report, err := VerifyCredential(vc.byteJSON(t),
+			WithJSONLDDocumentLoader(loader),
+			WithPublicKeyFetcher(fetcher),
+			WithStatusChecker(func(*Credential) error { return statusErr }),
+			WithIssuerDIDResolver(&mockIssuerResolver{deactivated: true}))
+		require.NoError(t, err)
+		require.False(t, report.Valid())
+
+		requireOutcomes(t, report, map[VerificationCheck]CheckOutcome{
+			ProofCheck:          CheckPassed,
+			SchemaCheck:         CheckPassed,
+			JSONLDCheck:         CheckPassed,
+			ValidityPeriodCheck: CheckFailed,
+			StatusCheck:         CheckFailed,
+			IssuerCheck:         CheckFailed,
+			HolderBindingCheck:  CheckSkipped,
+		})
+
+		require.Contains(t, report.Check(ValidityPeriodCheck).Err.Error(), "credential expired")
+		require.ErrorIs(t, report.Check(StatusCheck).Err, statusErr)
+		require.Contains(t, report.Check(IssuerCheck).Err.Error(), "is deactivated")
+	})
+
+	t.Run("tampered credential", func(t *testing.T) {
+		vc, fetcher := createVCWithLinkedDataProofNoExpiry(t)
+
+		vc.Issuer.CustomFields["name"] = "Fake University"
+
+		report, err := VerifyCredential(vc.byteJSON(t),
+			WithJSONLDDocumentLoader(loader),
+			WithPublicKeyFetcher(fetcher),
+			WithIssuerDIDResolver(&mockIssuerResolver{err: errors.New("not found")}))
+		require.NoError(t, err)
+		require.False(t, report.Valid())
+		require.Len(t, report.Proofs, 1)
+		require.False(t, report.Proofs[0].Valid())
+
+		requireOutcomes(t, report, map[VerificationCheck]CheckOutcome{
+			ProofCheck:          CheckFailed,
+			SchemaCheck:         CheckPassed,
+			JSONLDCheck:         CheckPassed,
+			ValidityPeriodCheck: CheckPassed,
+			StatusCheck:         CheckSkipped,
+			IssuerCheck:         CheckFailed,
+			HolderBindingCheck:  CheckSkipped,
+		})
+	})
+
+	t.Run("credential without proof", func(t *testing.T) {
+		report, err := VerifyCredential([]byte(validCredential), WithJSONLDDocumentLoader(loader))
+		require.NoError(t, err)
+		require.False(t, report.Valid())
+		require.Equal(t, CheckFailed, report.Check(ProofCheck).Outcome)
+		require.EqualError(t, report.Check(ProofCheck).Err, "embedded proof is missing")
+
+		report, err = VerifyCredential([]byte(validCredential),
+			WithJSONLDDocumentLoader(loader), WithDisabledProofCheck(), WithCredDisableValidation())
+		require.NoError(t, err)
+		require.Equal(t, CheckSkipped, report.Check(ProofCheck).Outcome)
+		require.Equal(t, CheckSkipped, report.Check(SchemaCheck).Outcome)
+		require.Equal(t, CheckSkipped, report.Check(JSONLDCheck).Outcome)
+	})
+
+	t.Run("JWT credential", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(jwtTestCredential))
+		require.NoError(t, err)
+
+		vc.Expired = nil
+
+		signer, err := newCryptoSigner(kmsapi.ED25519Type)
+		require.NoError(t, err)
+
+		jwtClaims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		vcJWT, err := jwtClaims.MarshalJWS(EdDSA, signer, vc.Issuer.ID+"#keys-1")
+		require.NoError(t, err)
+
+		report, err := VerifyCredential([]byte(vcJWT),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kmsapi.ED25519)))
+		require.NoError(t, err)
+		require.True(t, report.Valid())
+		require.Empty(t, report.Proofs)
+		require.Equal(t, CheckPassed, report.Check(ProofCheck).Outcome)
+		require.Equal(t, CheckSkipped, report.Check(SchemaCheck).Outcome)
+
+		report, err = VerifyCredential([]byte(vcJWT))
+		require.NoError(t, err)
+		require.False(t, report.Valid())
+		require.EqualError(t, report.Check(ProofCheck).Err, "public key fetcher is not defined")
+		require.Equal(t, CheckPassed, report.Check(ValidityPeriodCheck).Outcome)
+	})
+
+	t.Run("SD-JWT credential with holder binding", func(t *testing.T) {
+		issuerPubKey, issuerPrivKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		holderPubKey, holderPrivKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		vcSDJWT := createTestSDJWTCredWithHolderBinding(t, issuerPrivKey, holderPubKey, holderPrivKey)
+		fetcher := WithPublicKeyFetcher(SingleKey(issuerPubKey, kmsapi.ED25519))
+
+		report, err := VerifyCredential([]byte(vcSDJWT), fetcher)
+		require.NoError(t, err)
+		require.True(t, report.Valid())
+		require.Equal(t, CheckPassed, report.Check(ProofCheck).Outcome)
+		require.Equal(t, CheckPassed, report.Check(HolderBindingCheck).Outcome)
+
+		_, otherPrivKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		vcSDJWT = createTestSDJWTCredWithHolderBinding(t, issuerPrivKey, holderPubKey, otherPrivKey)
+
+		report, err = VerifyCredential([]byte(vcSDJWT), fetcher)
+		require.NoError(t, err)
+		require.False(t, report.Valid())
+		require.Equal(t, CheckPassed, report.Check(ProofCheck).Outcome)
+		require.Equal(t, CheckFailed, report.Check(HolderBindingCheck).Outcome)
+
+		report, err = VerifyCredential([]byte(vcSDJWT), fetcher, WithDisabledProofCheck())
+		require.NoError(t, err)
+		require.Equal(t, CheckSkipped, report.Check(ProofCheck).Outcome)
+		require.Equal(t, CheckSkipped, report.Check(HolderBindingCheck).Outcome)
+		require.EqualError(t, report.Check(HolderBindingCheck).Err,
+			"issuer signature of the credential is not verified")
+
+		otherIssuerPubKey, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		report, err = VerifyCredential([]byte(vcSDJWT),
+			WithPublicKeyFetcher(SingleKey(otherIssuerPubKey, kmsapi.ED25519)))
+		require.NoError(t, err)
+		require.Equal(t, CheckFailed, report.Check(ProofCheck).Outcome)
+		require.Equal(t, CheckSkipped, report.Check(HolderBindingCheck).Outcome)
+	})
+
+	t.Run("invalid credential", func(t *testing.T) {
+		report, err := VerifyCredential([]byte("not a credential"))
+		require.Error(t, err)
+		require.Nil(t, report)
+	})
+}
+
+func TestVerifyPresentation(t *testing.T) {
+	loader := createTestDocumentLoader(t)
+
+	signer, err := newCryptoSigner(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	ss := ed25519signature2018.New(suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	createVP := func(verificationMethod string) []byte {
+		vp, e := newTestPresentation(t, []byte(validPresentation), WithPresDisabledProofCheck())
+		require.NoError(t, e)
+
+		e = vp.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ss,
+			VerificationMethod:      verificationMethod,
+		}, ldprocessor.WithDocumentLoader(loader))
+		require.NoError(t, e)
+
+		vpBytes, e := json.Marshal(vp)
+		require.NoError(t, e)
+
+		return vpBytes
+	}
+
+	opts := []PresentationOpt{
+		WithPresJSONLDDocumentLoader(loader),
+		WithPresEmbeddedSignatureSuites(ss),
+		WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kmsapi.ED25519)),
+	}
+
+	t.Run("reports presentation and credential checks", func(t *testing.T) {
+		report, err := VerifyPresentation(createVP("did:example:ebfeb1f712ebc6f1c276e12ec21#key1"), opts...)
+		require.NoError(t, err)
+
+		requireOutcomes(t, report, map[VerificationCheck]CheckOutcome{
+			ProofCheck:         CheckPassed,
+			SchemaCheck:        CheckPassed,
+			JSONLDCheck:        CheckPassed,
+			HolderBindingCheck: CheckPassed,
+		})
+
+		// the embedded credential has an invalid proof
+		require.False(t, report.Valid())
+		require.Len(t, report.Credentials, 1)
+		require.Equal(t, CheckFailed, report.Credentials[0].Check(ProofCheck).Outcome)
+		require.Equal(t, CheckPassed, report.Credentials[0].Check(ValidityPeriodCheck).Outcome)
+		require.Equal(t, CheckSkipped, report.Credentials[0].Check(IssuerCheck).Outcome)
+
+		report, err = VerifyPresentation(createVP("did:example:ebfeb1f712ebc6f1c276e12ec21#key1"),
+			append(opts, WithPresCredentialOpts(WithDisabledProofCheck()))...)
+		require.NoError(t, err)
+		require.True(t, report.Valid())
+		require.Equal(t, CheckSkipped, report.Credentials[0].Check(ProofCheck).Outcome)
+	})
+
+	t.Run("presentation proof is not created by holder", func(t *testing.T) {
+		report, err := VerifyPresentation(createVP("did:example:other#key1"), opts...)
+		require.NoError(t, err)
+		require.False(t, report.Valid())
+		require.Equal(t, CheckPassed, report.Check(ProofCheck).Outcome)
+		require.Equal(t, CheckFailed, report.Check(HolderBindingCheck).Outcome)
+		require.Contains(t, report.Check(HolderBindingCheck).Err.Error(), "is not controlled by holder")
+	})
+
+	t.Run("JWT presentation", func(t *testing.T) {
+		vp, err := newTestPresentation(t, []byte(presentationWithoutCredentials), WithPresDisabledProofCheck())
+		require.NoError(t, err)
+
+		jwtClaims, err := vp.JWTClaims([]string{}, false)
+		require.NoError(t, err)
+
+		vpJWS, err := jwtClaims.MarshalJWS(EdDSA, signer, vp.Holder+"#key1")
+		require.NoError(t, err)
+
+		report, err := VerifyPresentation([]byte(vpJWS), opts...)
+		require.NoError(t, err)
+		require.Equal(t, CheckPassed, report.Check(ProofCheck).Outcome)
+		require.Equal(t, CheckPassed, report.Check(HolderBindingCheck).Outcome)
+
+		report, err = VerifyPresentation([]byte(vpJWS), append(opts, WithPresDisabledProofCheck())...)
+		require.NoError(t, err)
+		require.Equal(t, CheckSkipped, report.Check(ProofCheck).Outcome)
+		require.Equal(t, CheckSkipped, report.Check(HolderBindingCheck).Outcome)
+		require.EqualError(t, report.Check(HolderBindingCheck).Err, "JWS signature of the presentation is not verified")
+
+		otherSigner, err := newCryptoSigner(kmsapi.ED25519Type)
+		require.NoError(t, err)
+
+		report, err = VerifyPresentation([]byte(vpJWS), append(opts,
+			WithPresPublicKeyFetcher(SingleKey(otherSigner.PublicKeyBytes(), kmsapi.ED25519)))...)
+		require.NoError(t, err)
+		require.Equal(t, CheckFailed, report.Check(ProofCheck).Outcome)
+		require.Equal(t, CheckSkipped, report.Check(HolderBindingCheck).Outcome)
+	})
+
+	t.Run("presentation without proof", func(t *testing.T) {
+		report, err := VerifyPresentation([]byte(presentationWithoutCredentials), opts...)
+		require.NoError(t, err)
+		require.False(t, report.Valid())
+		require.Equal(t, CheckFailed, report.Check(ProofCheck).Outcome)
+		require.Equal(t, CheckSkipped, report.Check(HolderBindingCheck).Outcome)
+		require.Empty(t, report.Credentials)
+	})
+
+	t.Run("invalid presentation", func(t *testing.T) {
+		report, err := VerifyPresentation([]byte("not a presentation"), opts...)
+		require.Error(t, err)
+		require.Nil(t, report)
+	})
+}
+
+func requireOutcomes(t *testing.T, report *VerificationReport, expected map[VerificationCheck]CheckOutcome) {
+	t.Helper()
+
+	require.Len(t, report.Checks, len(expected))
+
+	for check, outcome := range expected {
+		result := report.Check(check)
+		require.NotNil(t, result, check)
+		require.Equal(t, outcome, result.Outcome, "%s: %v", check, result.Err)
+	}
+}
+
+func createVCWithLinkedDataProofNoExpiry(t *testing.T) (*Credential, PublicKeyFetcher) {
+	t.Helper()
+
+	vc, err := parseTestCredential(t, []byte(validCredential), WithDisabledProofCheck())
+	require.NoError(t, err)
+
+	vc.Expired = nil
+	vc.Status = nil
+
+	signer, err := newCryptoSigner(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+		SignatureRepresentation: SignatureJWS,
+		VerificationMethod:      "did:123#any",
+	}, ldprocessor.WithDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+
+	return vc, SingleKey(signer.PublicKeyBytes(), kmsapi.ED25519)
+}
+
+func createTestSDJWTCredWithHolderBinding(t *testing.T, issuerPrivKey ed25519.PrivateKey,
+	holderPubKey ed25519.PublicKey, holderPrivKey ed25519.PrivateKey) string {
+	t.Helper()
+
+	vc, err := parseTestCredential(t, []byte(jwtTestCredential))
+	require.NoError(t, err)
+
+	vc.Expired = nil
+
+	claims, err := vc.JWTClaims(false)
+	require.NoError(t, err)
+
+	claimBytes, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	claimMap := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(claimBytes, &claimMap))
+
+	holderJWK, err := jwksupport.JWKFromKey(holderPubKey)
+	require.NoError(t, err)
+
+	sdJWT, err := issuer.NewFromVC(claimMap, jose.Headers{jose.HeaderKeyID: vc.Issuer.ID + "#keys-1"},
+		afgojwt.NewEd25519Signer(issuerPrivKey),
+		issuer.WithStructuredClaims(true),
+		issuer.WithHolderPublicKey(holderJWK))
+	require.NoError(t, err)
+
+	combinedFormatForIssuance, err := sdJWT.Serialize(false)
+	require.NoError(t, err)
+
+	cfi := common.ParseCombinedFormatForIssuance(combinedFormatForIssuance)
+
+	vcSDJWT, err := holder.CreatePresentation(combinedFormatForIssuance, cfi.Disclosures,
+		holder.WithHolderVerification(&holder.BindingInfo{
+			Payload: holder.BindingPayload{
+				Nonce:    "nonce",
+				Audience: "verifier",
+				IssuedAt: jwt.NewNumericDate(time.Now()),
+			},
+			Signer: afgojwt.NewEd25519Signer(holderPrivKey),
+		}))
+	require.NoError(t, err)
+
+	return vcSDJWT
+}
+
+type mockIssuerResolver struct {
+	deactivated bool
+	err         error
+}
+
+func (r *mockIssuerResolver) Resolve(didID string, _ ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return &did.DocResolution{
+		DIDDocument:      &did.Doc{ID: didID},
+		DocumentMetadata: &did.DocumentMetadata{Deactivated: r.deactivated},
+	}, nil
+}
@@ -4,7 +4,7 @@
 
 module github.com/hyperledger/aries-framework-go/component/storageutil
 
-go 1.20
+go 1.22.0
 
 require (
 	github.com/google/uuid v1.1.2
@@ -51,12 +51,7 @@ func (v *VDR) Create(didDoc *did.Doc, opts ...vdrspi.DIDMethodOption) (*did.DocR
 	}
 
 	if !store {
-		docResolution, err := build(didDoc, docOpts)
-		if err != nil {
-			return nil, fmt.Errorf("create peer DID : %w", err)
-		}
-
-		didDoc = docResolution.DIDDocument
+		return v.create(didDoc, docOpts)
 	}
 
 	if err := v.storeDID(didDoc, nil); err != nil {
@@ -66,6 +61,56 @@ func (v *VDR) Create(didDoc *did.Doc, opts ...vdrspi.DIDMethodOption) (*did.DocR
 	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: didDoc}, nil
 }
 
+// create creates the peer DID of didDoc with the numalgo of the NumAlgo option. Only numalgo 1 DIDs and the short
+// form of numalgo 4 DIDs are stored, others are resolved from the DID itself.
+func (v *VDR) create(didDoc *did.Doc, docOpts *vdrspi.DIDMethodOpts) (*did.DocResolution, error) {
+	numAlgoValue := NumAlgo1
+
+	if numAlgoOpt := docOpts.Values[NumAlgo]; numAlgoOpt != nil {
+		var ok bool
+
+		numAlgoValue, ok = numAlgoOpt.(int)
+		if !ok {
+			return nil, fmt.Errorf("numAlgo opt not int")
+		}
+	}
+
+	var (
+		doc, storedDoc *did.Doc
+		err            error
+	)
+
+	switch numAlgoValue {
+	case NumAlgo0:
+		doc, err = createNumAlgo0(didDoc)
+	case NumAlgo1:
+		var docResolution *did.DocResolution
+
+		docResolution, err = build(didDoc, docOpts)
+		if docResolution != nil {
+			doc, storedDoc = docResolution.DIDDocument, docResolution.DIDDocument
+		}
+	case NumAlgo2:
+		doc, err = createNumAlgo2(didDoc, docOpts)
+	case NumAlgo4:
+		doc, storedDoc, err = createNumAlgo4(didDoc, docOpts)
+	default:
+		err = fmt.Errorf("not supported numalgo: %d", numAlgoValue)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("create peer DID : %w", err)
+	}
+
+	if storedDoc != nil {
+		if err = v.storeDID(storedDoc, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+}
+
 // nolint: funlen,gocyclo,gocognit
 func build(didDoc *did.Doc, docOpts *vdrspi.DIDMethodOpts) (*did.DocResolution, error) {
 	if len(didDoc.VerificationMethod) == 0 && len(didDoc.KeyAgreement) == 0 {
@@ -85,54 +130,8 @@ func build(didDoc *did.Doc, docOpts *vdrspi.DIDMethodOpts) (*did.DocResolution,
 			didDoc.Service[i].ID = uuid.New().String()
 		}
 
-		if didDoc.Service[i].Type == "" && docOpts.Values[DefaultServiceType] != nil {
-			v, ok := docOpts.Values[DefaultServiceType].(string)
-			if !ok {
-				return nil, fmt.Errorf("defaultServiceType not string")
-			}
-
-			didDoc.Service[i].Type = v
-		}
-
-		uri, _ := didDoc.Service[i].ServiceEndpoint.URI() // nolint:errcheck
-
-		// nolint:nestif
-		if uri == "" && docOpts.Values[DefaultServiceEndpoint] != nil {
-			switch didDoc.Service[i].Type {
-			case vdrapi.DIDCommServiceType, vdrapi.LegacyServiceType:
-				v, ok := docOpts.Values[DefaultServiceEndpoint].(string)
-				if !ok {
-					return nil, fmt.Errorf("defaultServiceEndpoint not string")
-				}
-
-				didDoc.Service[i].ServiceEndpoint = endpoint.NewDIDCommV1Endpoint(v)
-			case vdrapi.DIDCommV2ServiceType:
-				epArrayEntry := stringArray(docOpts.Values[DefaultServiceEndpoint])
-
-				sp := endpoint.Endpoint{}
-
-				if len(epArrayEntry) == 0 {
-					sp = endpoint.NewDIDCommV2Endpoint([]endpoint.DIDCommV2Endpoint{{}})
-				} else {
-					for _, ep := range epArrayEntry {
-						err = sp.UnmarshalJSON([]byte(ep))
-						if err != nil {
-							if strings.EqualFold(err.Error(), "endpoint data is not supported") {
-								// if unmarshall failed, then use as string.
-								sp = endpoint.NewDIDCommV2Endpoint([]endpoint.DIDCommV2Endpoint{
-									{URI: ep, Accept: []string{didcommV2MediaType}},
-								})
-							}
-
-							continue
-						}
-
-						break
-					}
-				}
-
-				didDoc.Service[i].ServiceEndpoint = sp
-			}
+		if err = applyServiceDefaults(&didDoc.Service[i], docOpts); err != nil {
+			return nil, err
 		}
 
 		applyDIDCommKeys(i, didDoc)
@@ -185,6 +184,63 @@ func build(didDoc *did.Doc, docOpts *vdrspi.DIDMethodOpts) (*did.DocResolution,
 	return &did.DocResolution{DIDDocument: didDoc}, nil
 }
 
+// applyServiceDefaults sets the type and endpoint of svc from the DefaultServiceType and DefaultServiceEndpoint options
+// if they are not set.
+// nolint: gocyclo
+func applyServiceDefaults(svc *did.Service, docOpts *vdrspi.DIDMethodOpts) error {
+	if svc.Type == "" && docOpts.Values[DefaultServiceType] != nil {
+		v, ok := docOpts.Values[DefaultServiceType].(string)
+		if !ok {
+			return fmt.Errorf("defaultServiceType not string")
+		}
+
+		svc.Type = v
+	}
+
+	uri, _ := svc.ServiceEndpoint.URI() // nolint:errcheck
+
+	// nolint:nestif
+	if uri == "" && docOpts.Values[DefaultServiceEndpoint] != nil {
+		switch svc.Type {
+		case vdrapi.DIDCommServiceType, vdrapi.LegacyServiceType:
+			v, ok := docOpts.Values[DefaultServiceEndpoint].(string)
+			if !ok {
+				return fmt.Errorf("defaultServiceEndpoint not string")
+			}
+
+			svc.ServiceEndpoint = endpoint.NewDIDCommV1Endpoint(v)
+		case vdrapi.DIDCommV2ServiceType:
+			epArrayEntry := stringArray(docOpts.Values[DefaultServiceEndpoint])
+
+			sp := endpoint.Endpoint{}
+
+			if len(epArrayEntry) == 0 {
+				sp = endpoint.NewDIDCommV2Endpoint([]endpoint.DIDCommV2Endpoint{{}})
+			} else {
+				for _, ep := range epArrayEntry {
+					err := sp.UnmarshalJSON([]byte(ep))
+					if err != nil {
+						if strings.EqualFold(err.Error(), "endpoint data is not supported") {
+							// if unmarshall failed, then use as string.
+							sp = endpoint.NewDIDCommV2Endpoint([]endpoint.DIDCommV2Endpoint{
+								{URI: ep, Accept: []string{didcommV2MediaType}},
+							})
+						}
+
+						continue
+					}
+
+					break
+				}
+			}
+
+			svc.ServiceEndpoint = sp
+		}
+	}
+
+	return nil
+}
+
 // stringEntry.
 func stringEntry(entry interface{}) string {
 	if entry == nil {
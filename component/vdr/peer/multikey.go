@@ -0,0 +1,82 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/fingerprint"
+	"github.com/hyperledger/aries-framework-go/component/models/did"
+)
+
+const bls12381G2Key2020 = "Bls12381G2Key2020"
+
+// keyFingerprint returns the multibase encoded multicodec fingerprint of vm's public key, as used by did:key and by
+// the numalgo 0 and 2 peer DIDs.
+func keyFingerprint(vm *did.VerificationMethod) (string, error) {
+	switch vm.Type {
+	case ed25519VerificationKey2018:
+		return fingerprint.KeyFingerprint(fingerprint.ED25519PubKeyMultiCodec, vm.Value), nil
+	case x25519KeyAgreementKey2019:
+		return fingerprint.KeyFingerprint(fingerprint.X25519PubKeyMultiCodec, vm.Value), nil
+	case bls12381G2Key2020:
+		return fingerprint.KeyFingerprint(fingerprint.BLS12381g2PubKeyMultiCodec, vm.Value), nil
+	case jsonWebKey2020:
+		didKey, _, err := fingerprint.CreateDIDKeyByJwk(vm.JSONWebKey())
+		if err != nil {
+			return "", fmt.Errorf("key fingerprint of JsonWebKey2020 verification method: %w", err)
+		}
+
+		return strings.TrimPrefix(didKey, "did:key:"), nil
+	default:
+		return "", fmt.Errorf("not supported verification method public key type: %s", vm.Type)
+	}
+}
+
+// verificationMethodFromFingerprint creates the verification method with the given id and controller of the public
+// key encoded in the multibase encoded multicodec fingerprint fp.
+func verificationMethodFromFingerprint(id, controller, fp string) (*did.VerificationMethod, error) {
+	pubKeyBytes, code, err := fingerprint.PubKeyFromFingerprint(fp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key fingerprint '%s': %w", fp, err)
+	}
+
+	var curve elliptic.Curve
+
+	switch code {
+	case fingerprint.ED25519PubKeyMultiCodec:
+		return did.NewVerificationMethodFromBytes(id, ed25519VerificationKey2018, controller, pubKeyBytes), nil
+	case fingerprint.X25519PubKeyMultiCodec:
+		return did.NewVerificationMethodFromBytes(id, x25519KeyAgreementKey2019, controller, pubKeyBytes), nil
+	case fingerprint.BLS12381g2PubKeyMultiCodec, fingerprint.BLS12381g1g2PubKeyMultiCodec:
+		return did.NewVerificationMethodFromBytes(id, bls12381G2Key2020, controller, pubKeyBytes), nil
+	case fingerprint.P256PubKeyMultiCodec:
+		curve = elliptic.P256()
+	case fingerprint.P384PubKeyMultiCodec:
+		curve = elliptic.P384()
+	case fingerprint.P521PubKeyMultiCodec:
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported key multicodec code [0x%x]", code)
+	}
+
+	x, y := elliptic.UnmarshalCompressed(curve, pubKeyBytes)
+	if x == nil {
+		return nil, fmt.Errorf("error unmarshalling key bytes of key fingerprint '%s'", fp)
+	}
+
+	j, err := jwksupport.JWKFromKey(&ecdsa.PublicKey{Curve: curve, X: x, Y: y})
+	if err != nil {
+		return nil, fmt.Errorf("error creating JWK: %w", err)
+	}
+
+	return did.NewVerificationMethodFromJWK(id, jsonWebKey2020, controller, j)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/fingerprint"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/util/cryptoutil"
+	"github.com/hyperledger/aries-framework-go/component/models/did"
+)
+
+// createNumAlgo0 creates the numalgo 0 peer DID of the inception key of didDoc (its first verification method, or its
+// first key agreement if it has none) and returns its resolved doc. Services of didDoc are not part of the DID.
+// Reference: https://identity.foundation/peer-did-method-spec/#generation-method
+func createNumAlgo0(didDoc *did.Doc) (*did.Doc, error) {
+	var inceptionKey *did.VerificationMethod
+
+	switch {
+	case len(didDoc.VerificationMethod) > 0:
+		inceptionKey = &didDoc.VerificationMethod[0]
+	case len(didDoc.KeyAgreement) > 0:
+		inceptionKey = &didDoc.KeyAgreement[0].VerificationMethod
+	default:
+		return nil, fmt.Errorf("verification method and key agreement are empty, at least one should be set")
+	}
+
+	fp, err := keyFingerprint(inceptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveNumAlgo0(peerPrefix + "0" + fp)
+}
+
+// resolveNumAlgo0 resolves the numalgo 0 peer DID didID the same way as the did:key of its inception key: an Ed25519
+// key is used for authentication, assertion and capabilities with its X25519 conversion as key agreement, an X25519
+// key is used for key agreement only and other keys are used for all verification relationships.
+func resolveNumAlgo0(didID string) (*did.Doc, error) {
+	fp := didID[len(peerPrefix)+1:]
+
+	vm, err := verificationMethodFromFingerprint(didID+"#"+fp, didID, fp)
+	if err != nil {
+		return nil, fmt.Errorf("resolve numalgo 0 peer DID: %w", err)
+	}
+
+	doc := &did.Doc{
+		Context: []string{did.ContextV1},
+		ID:      didID,
+	}
+
+	if vm.Type == x25519KeyAgreementKey2019 {
+		doc.KeyAgreement = []did.Verification{*did.NewEmbeddedVerification(vm, did.KeyAgreement)}
+
+		return doc, nil
+	}
+
+	doc.VerificationMethod = []did.VerificationMethod{*vm}
+	doc.Authentication = []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)}
+	doc.AssertionMethod = []did.Verification{*did.NewReferencedVerification(vm, did.AssertionMethod)}
+	doc.CapabilityDelegation = []did.Verification{*did.NewReferencedVerification(vm, did.CapabilityDelegation)}
+	doc.CapabilityInvocation = []did.Verification{*did.NewReferencedVerification(vm, did.CapabilityInvocation)}
+
+	switch vm.Type {
+	case ed25519VerificationKey2018:
+		x25519PubKey, err := cryptoutil.PublicEd25519toCurve25519(vm.Value)
+		if err != nil {
+			return nil, fmt.Errorf("resolve numalgo 0 peer DID: %w", err)
+		}
+
+		kaFP := fingerprint.KeyFingerprint(fingerprint.X25519PubKeyMultiCodec, x25519PubKey)
+		ka := did.NewVerificationMethodFromBytes(didID+"#"+kaFP, x25519KeyAgreementKey2019, didID, x25519PubKey)
+
+		doc.KeyAgreement = []did.Verification{*did.NewEmbeddedVerification(ka, did.KeyAgreement)}
+	case jsonWebKey2020:
+		doc.KeyAgreement = []did.Verification{*did.NewReferencedVerification(vm, did.KeyAgreement)}
+	}
+
+	return doc, nil
+}
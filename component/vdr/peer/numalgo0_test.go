@@ -0,0 +1,106 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/fingerprint"
+	"github.com/hyperledger/aries-framework-go/component/models/did"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mock/storage"
+	vdrspi "github.com/hyperledger/aries-framework-go/spi/vdr"
+)
+
+func TestNumAlgo0(t *testing.T) {
+	sProvider := storage.NewMockStoreProvider()
+	km := newKMS(t, sProvider)
+
+	v, err := New(sProvider)
+	require.NoError(t, err)
+
+	t.Run("create and resolve Ed25519 inception key", func(t *testing.T) {
+		vm := getSigningKey()
+
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{vm}},
+			vdrspi.WithOption(NumAlgo, NumAlgo0))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.True(t, strings.HasPrefix(doc.ID, "did:peer:0z6Mk"))
+		require.Len(t, doc.VerificationMethod, 1)
+		require.Equal(t, vm.Value, doc.VerificationMethod[0].Value)
+		require.Equal(t, ed25519VerificationKey2018, doc.VerificationMethod[0].Type)
+		require.Len(t, doc.Authentication, 1)
+		require.Len(t, doc.KeyAgreement, 1)
+		require.Equal(t, x25519KeyAgreementKey2019, doc.KeyAgreement[0].VerificationMethod.Type)
+
+		// numalgo 0 DIDs are not stored.
+		_, err = v.Get(doc.ID)
+		require.Error(t, err)
+
+		resolved, err := v.Read(doc.ID)
+		require.NoError(t, err)
+		require.Equal(t, doc, resolved.DIDDocument)
+	})
+
+	t.Run("create and resolve JsonWebKey2020 inception key", func(t *testing.T) {
+		vm, _ := getSigningAndKeyAgreementKey(t, true, km)
+
+		docResolution, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{vm}},
+			vdrspi.WithOption(NumAlgo, NumAlgo0))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.True(t, strings.HasPrefix(doc.ID, "did:peer:0zDn"))
+		require.Equal(t, jsonWebKey2020, doc.VerificationMethod[0].Type)
+		require.Equal(t, vm.JSONWebKey().Key, doc.VerificationMethod[0].JSONWebKey().Key)
+		require.Len(t, doc.KeyAgreement, 1)
+	})
+
+	t.Run("create and resolve X25519 inception key", func(t *testing.T) {
+		_, ka := getSigningAndKeyAgreementKey(t, false, km)
+
+		docResolution, err := v.Create(&did.Doc{KeyAgreement: []did.Verification{ka}},
+			vdrspi.WithOption(NumAlgo, NumAlgo0))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.True(t, strings.HasPrefix(doc.ID, "did:peer:0z6LS"))
+		require.Empty(t, doc.VerificationMethod)
+		require.Empty(t, doc.Authentication)
+		require.Len(t, doc.KeyAgreement, 1)
+		require.Equal(t, ka.VerificationMethod.Value, doc.KeyAgreement[0].VerificationMethod.Value)
+	})
+
+	t.Run("fail to create numalgo 0 DID", func(t *testing.T) {
+		_, err := v.Create(&did.Doc{}, vdrspi.WithOption(NumAlgo, NumAlgo0))
+		require.EqualError(t, err, "create peer DID : verification method and key agreement are empty, at "+
+			"least one should be set")
+
+		vm := getSigningKey()
+		vm.Type = "undefined"
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{vm}},
+			vdrspi.WithOption(NumAlgo, NumAlgo0))
+		require.EqualError(t, err, "create peer DID : not supported verification method public key type: undefined")
+	})
+
+	t.Run("fail to resolve numalgo 0 DID", func(t *testing.T) {
+		_, err := v.Read("did:peer:0abc")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve numalgo 0 peer DID: invalid key fingerprint 'abc'")
+
+		secp256k1PubKeyMultiCodec := uint64(0xe7)
+
+		_, err = v.Read("did:peer:0" + fingerprint.KeyFingerprint(secp256k1PubKeyMultiCodec, make([]byte, 33)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve numalgo 0 peer DID: unsupported key multicodec code")
+	})
+}
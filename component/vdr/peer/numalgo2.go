@@ -0,0 +1,337 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/component/models/did"
+	"github.com/hyperledger/aries-framework-go/component/models/did/endpoint"
+	vdrapi "github.com/hyperledger/aries-framework-go/component/vdr/api"
+	vdrspi "github.com/hyperledger/aries-framework-go/spi/vdr"
+)
+
+// Purpose codes of the elements of numalgo 2 peer DIDs.
+// Reference: https://identity.foundation/peer-did-method-spec/#method-2-multiple-inception-key-without-doc
+const (
+	purposeAssertion            = 'A'
+	purposeEncryption           = 'E'
+	purposeVerification         = 'V'
+	purposeCapabilityInvocation = 'I'
+	purposeCapabilityDelegation = 'D'
+	purposeService              = 'S'
+
+	// didCommMessagingAbbreviation is the abbreviation of the DIDCommMessaging service type.
+	didCommMessagingAbbreviation = "dm"
+)
+
+// nolint:gochecknoglobals
+var purposeRelationships = map[byte]did.VerificationRelationship{
+	purposeAssertion:            did.AssertionMethod,
+	purposeEncryption:           did.KeyAgreement,
+	purposeVerification:         did.Authentication,
+	purposeCapabilityInvocation: did.CapabilityInvocation,
+	purposeCapabilityDelegation: did.CapabilityDelegation,
+}
+
+// abbreviatedService is the JSON form of the services of numalgo 2 peer DIDs.
+type abbreviatedService struct {
+	ID              string          `json:"id,omitempty"`
+	Type            interface{}     `json:"t"`
+	ServiceEndpoint json.RawMessage `json:"s"`
+	RoutingKeys     []string        `json:"r,omitempty"`
+	Accept          []string        `json:"a,omitempty"`
+}
+
+// abbreviatedEndpoint is the JSON form of the DIDComm V2 service endpoints of numalgo 2 peer DIDs.
+type abbreviatedEndpoint struct {
+	URI         string   `json:"uri"`
+	Accept      []string `json:"a,omitempty"`
+	RoutingKeys []string `json:"r,omitempty"`
+}
+
+// createNumAlgo2 creates the numalgo 2 peer DID of the keys and services of didDoc and returns its resolved doc.
+// Keys are encoded with the purpose of their verification relationship, verification methods of didDoc without
+// relationship are encoded as authentication keys.
+// Reference: https://identity.foundation/peer-did-method-spec/#generating-a-didpeer2
+func createNumAlgo2(didDoc *did.Doc, docOpts *vdrspi.DIDMethodOpts) (*did.Doc, error) {
+	if len(didDoc.VerificationMethod) == 0 && len(didDoc.KeyAgreement) == 0 {
+		return nil, fmt.Errorf("verification method and key agreement are empty, at least one should be set")
+	}
+
+	var elements []string
+
+	encoded := map[string]bool{}
+
+	for _, relationship := range []struct {
+		purpose       byte
+		verifications []did.Verification
+	}{
+		{purposeVerification, didDoc.Authentication},
+		{purposeAssertion, didDoc.AssertionMethod},
+		{purposeEncryption, didDoc.KeyAgreement},
+		{purposeCapabilityInvocation, didDoc.CapabilityInvocation},
+		{purposeCapabilityDelegation, didDoc.CapabilityDelegation},
+	} {
+		for i := range relationship.verifications {
+			vm := &relationship.verifications[i].VerificationMethod
+
+			fp, err := keyFingerprint(vm)
+			if err != nil {
+				return nil, err
+			}
+
+			elements = append(elements, string(relationship.purpose)+fp)
+			encoded[fp] = true
+		}
+	}
+
+	for i := range didDoc.VerificationMethod {
+		fp, err := keyFingerprint(&didDoc.VerificationMethod[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if encoded[fp] {
+			continue
+		}
+
+		elements = append(elements, string(purposeVerification)+fp)
+	}
+
+	for i := range didDoc.Service {
+		svc := didDoc.Service[i]
+
+		if err := applyServiceDefaults(&svc, docOpts); err != nil {
+			return nil, err
+		}
+
+		element, err := encodeService(&svc)
+		if err != nil {
+			return nil, err
+		}
+
+		elements = append(elements, string(purposeService)+element)
+	}
+
+	return resolveNumAlgo2(peerPrefix + "2." + strings.Join(elements, "."))
+}
+
+// resolveNumAlgo2 resolves the numalgo 2 peer DID didID. Keys get the '#key-N' IDs in the order of the DID, services
+// without ID get '#service' then '#service-N' IDs.
+// Reference: https://identity.foundation/peer-did-method-spec/#resolving-a-didpeer2
+func resolveNumAlgo2(didID string) (*did.Doc, error) {
+	elements := strings.Split(strings.TrimPrefix(didID[len(peerPrefix)+1:], "."), ".")
+
+	doc := &did.Doc{
+		Context: []string{did.ContextV1},
+		ID:      didID,
+	}
+
+	for _, element := range elements {
+		if element == "" {
+			return nil, fmt.Errorf("resolve numalgo 2 peer DID: empty element")
+		}
+
+		if element[0] == purposeService {
+			svc, err := decodeService(element[1:], len(doc.Service))
+			if err != nil {
+				return nil, fmt.Errorf("resolve numalgo 2 peer DID: %w", err)
+			}
+
+			doc.Service = append(doc.Service, *svc)
+
+			continue
+		}
+
+		relationship, ok := purposeRelationships[element[0]]
+		if !ok {
+			return nil, fmt.Errorf("resolve numalgo 2 peer DID: unsupported purpose code '%c'", element[0])
+		}
+
+		vm, err := verificationMethodFromFingerprint(fmt.Sprintf("#key-%d", len(doc.VerificationMethod)+1), didID,
+			element[1:])
+		if err != nil {
+			return nil, fmt.Errorf("resolve numalgo 2 peer DID: %w", err)
+		}
+
+		doc.VerificationMethod = append(doc.VerificationMethod, *vm)
+		verification := did.NewReferencedVerification(vm, relationship)
+
+		switch relationship {
+		case did.Authentication:
+			doc.Authentication = append(doc.Authentication, *verification)
+		case did.AssertionMethod:
+			doc.AssertionMethod = append(doc.AssertionMethod, *verification)
+		case did.KeyAgreement:
+			doc.KeyAgreement = append(doc.KeyAgreement, *verification)
+		case did.CapabilityInvocation:
+			doc.CapabilityInvocation = append(doc.CapabilityInvocation, *verification)
+		case did.CapabilityDelegation:
+			doc.CapabilityDelegation = append(doc.CapabilityDelegation, *verification)
+		}
+	}
+
+	for i := range doc.Service {
+		if len(doc.VerificationMethod) > 0 {
+			applyDIDCommKeys(i, doc)
+		}
+
+		applyDIDCommV2Keys(i, doc)
+	}
+
+	return doc, nil
+}
+
+// encodeService returns the base64url encoded abbreviated JSON form of svc.
+func encodeService(svc *did.Service) (string, error) {
+	abbreviated := &abbreviatedService{
+		ID:          svc.ID,
+		Type:        svc.Type,
+		RoutingKeys: svc.RoutingKeys,
+		Accept:      svc.Accept,
+	}
+
+	if svc.Type == vdrapi.DIDCommV2ServiceType {
+		abbreviated.Type = didCommMessagingAbbreviation
+	}
+
+	epBytes, err := svc.ServiceEndpoint.MarshalJSON()
+	if err != nil {
+		return "", fmt.Errorf("marshal service endpoint: %w", err)
+	}
+
+	if svc.ServiceEndpoint.Type() == endpoint.DIDCommV2 {
+		var endpoints []endpoint.DIDCommV2Endpoint
+
+		if err = json.Unmarshal(epBytes, &endpoints); err != nil {
+			return "", fmt.Errorf("unmarshal DIDComm V2 service endpoint: %w", err)
+		}
+
+		abbreviatedEndpoints := make([]abbreviatedEndpoint, len(endpoints))
+
+		for i, ep := range endpoints {
+			abbreviatedEndpoints[i] = abbreviatedEndpoint{URI: ep.URI, Accept: ep.Accept, RoutingKeys: ep.RoutingKeys}
+		}
+
+		if len(abbreviatedEndpoints) == 1 {
+			epBytes, err = json.Marshal(abbreviatedEndpoints[0])
+		} else {
+			epBytes, err = json.Marshal(abbreviatedEndpoints)
+		}
+
+		if err != nil {
+			return "", fmt.Errorf("marshal abbreviated service endpoint: %w", err)
+		}
+	}
+
+	abbreviated.ServiceEndpoint = epBytes
+
+	svcBytes, err := json.Marshal(abbreviated)
+	if err != nil {
+		return "", fmt.Errorf("marshal abbreviated service: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(svcBytes), nil
+}
+
+// decodeService decodes the base64url encoded abbreviated JSON service element of the index-th service of a numalgo 2
+// peer DID. A DIDCommMessaging service endpoint can either be an abbreviated endpoint object (or array of objects) or
+// a URI, in which case the routing keys and accept of the service apply to it.
+func decodeService(element string, index int) (*did.Service, error) {
+	svcBytes, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(element, "="))
+	if err != nil {
+		return nil, fmt.Errorf("decode service: %w", err)
+	}
+
+	abbreviated := &abbreviatedService{}
+
+	if err = json.Unmarshal(svcBytes, abbreviated); err != nil {
+		return nil, fmt.Errorf("unmarshal service: %w", err)
+	}
+
+	svc := &did.Service{
+		ID:          abbreviated.ID,
+		Type:        abbreviated.Type,
+		RoutingKeys: abbreviated.RoutingKeys,
+		Accept:      abbreviated.Accept,
+	}
+
+	if svc.ID == "" {
+		svc.ID = "#service"
+
+		if index > 0 {
+			svc.ID = fmt.Sprintf("#service-%d", index)
+		}
+	}
+
+	if svc.Type == didCommMessagingAbbreviation {
+		svc.Type = vdrapi.DIDCommV2ServiceType
+	}
+
+	ep := abbreviated.ServiceEndpoint
+
+	switch {
+	case len(ep) == 0 || string(ep) == "null":
+	case ep[0] == '{' || ep[0] == '[':
+		if endpoints, ok := abbreviatedEndpoints(ep); ok {
+			svc.ServiceEndpoint = endpoint.NewDIDCommV2Endpoint(endpoints)
+
+			break
+		}
+
+		if err = svc.ServiceEndpoint.UnmarshalJSON(ep); err != nil {
+			return nil, fmt.Errorf("unmarshal service endpoint: %w", err)
+		}
+	case svc.Type == vdrapi.DIDCommV2ServiceType:
+		var uri string
+
+		if err = json.Unmarshal(ep, &uri); err != nil {
+			return nil, fmt.Errorf("unmarshal service endpoint: %w", err)
+		}
+
+		svc.ServiceEndpoint = endpoint.NewDIDCommV2Endpoint([]endpoint.DIDCommV2Endpoint{
+			{URI: uri, Accept: svc.Accept, RoutingKeys: svc.RoutingKeys},
+		})
+		svc.Accept, svc.RoutingKeys = nil, nil
+	default:
+		if err = svc.ServiceEndpoint.UnmarshalJSON(ep); err != nil {
+			return nil, fmt.Errorf("unmarshal service endpoint: %w", err)
+		}
+	}
+
+	return svc, nil
+}
+
+// abbreviatedEndpoints decodes the abbreviated DIDComm V2 endpoint object or array of objects ep, it returns false if
+// ep is not one.
+func abbreviatedEndpoints(ep json.RawMessage) ([]endpoint.DIDCommV2Endpoint, bool) {
+	var endpoints []abbreviatedEndpoint
+
+	if ep[0] == '{' {
+		ep = append(append([]byte{'['}, ep...), ']')
+	}
+
+	if err := json.Unmarshal(ep, &endpoints); err != nil || len(endpoints) == 0 {
+		return nil, false
+	}
+
+	didCommEndpoints := make([]endpoint.DIDCommV2Endpoint, len(endpoints))
+
+	for i, e := range endpoints {
+		if e.URI == "" {
+			return nil, false
+		}
+
+		didCommEndpoints[i] = endpoint.DIDCommV2Endpoint{URI: e.URI, Accept: e.Accept, RoutingKeys: e.RoutingKeys}
+	}
+
+	return didCommEndpoints, true
+}
@@ -0,0 +1,248 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/fingerprint"
+	"github.com/hyperledger/aries-framework-go/component/models/did"
+	"github.com/hyperledger/aries-framework-go/component/models/did/endpoint"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mock/storage"
+	vdrapi "github.com/hyperledger/aries-framework-go/component/vdr/api"
+	vdrspi "github.com/hyperledger/aries-framework-go/spi/vdr"
+)
+
+func TestNumAlgo2(t *testing.T) {
+	sProvider := storage.NewMockStoreProvider()
+	km := newKMS(t, sProvider)
+
+	v, err := New(sProvider)
+	require.NoError(t, err)
+
+	t.Run("create and resolve DIDComm V2 peer DID", func(t *testing.T) {
+		vm, ka := getSigningAndKeyAgreementKey(t, false, km)
+		vm.ID = "#key-1"
+		ka.VerificationMethod.ID = "#key-2"
+
+		docResolution, err := v.Create(&did.Doc{
+			VerificationMethod: []did.VerificationMethod{vm},
+			Authentication:     []did.Verification{*did.NewReferencedVerification(&vm, did.Authentication)},
+			KeyAgreement:       []did.Verification{ka},
+			Service:            []did.Service{{Type: vdrapi.DIDCommV2ServiceType}},
+		}, vdrspi.WithOption(NumAlgo, NumAlgo2),
+			vdrspi.WithOption(DefaultServiceEndpoint, "https://example.com/didcomm"))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.True(t, strings.HasPrefix(doc.ID, "did:peer:2.Vz6Mk"))
+		require.Contains(t, doc.ID, ".Ez6LS")
+		require.Contains(t, doc.ID, ".S")
+
+		require.Len(t, doc.VerificationMethod, 2)
+		require.Equal(t, "#key-1", doc.Authentication[0].VerificationMethod.ID)
+		require.Equal(t, vm.Value, doc.Authentication[0].VerificationMethod.Value)
+		require.Equal(t, "#key-2", doc.KeyAgreement[0].VerificationMethod.ID)
+		require.Equal(t, ka.VerificationMethod.Value, doc.KeyAgreement[0].VerificationMethod.Value)
+
+		require.Len(t, doc.Service, 1)
+		require.Equal(t, "#service", doc.Service[0].ID)
+		require.Equal(t, vdrapi.DIDCommV2ServiceType, doc.Service[0].Type)
+		require.Equal(t, []string{"#key-2"}, doc.Service[0].RecipientKeys)
+
+		uri, err := doc.Service[0].ServiceEndpoint.URI()
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/didcomm", uri)
+
+		require.Equal(t, endpoint.DIDCommV2, doc.Service[0].ServiceEndpoint.Type())
+
+		// numalgo 2 DIDs are not stored.
+		_, err = v.Get(doc.ID)
+		require.Error(t, err)
+
+		resolved, err := v.Read(doc.ID)
+		require.NoError(t, err)
+		require.Equal(t, doc, resolved.DIDDocument)
+	})
+
+	t.Run("create and resolve peer DID with all purposes", func(t *testing.T) {
+		vm, ka := getSigningAndKeyAgreementKey(t, true, km)
+		unreferencedVM := getSigningKey()
+
+		docResolution, err := v.Create(&did.Doc{
+			VerificationMethod:   []did.VerificationMethod{vm, unreferencedVM},
+			AssertionMethod:      []did.Verification{*did.NewReferencedVerification(&vm, did.AssertionMethod)},
+			CapabilityInvocation: []did.Verification{*did.NewReferencedVerification(&vm, did.CapabilityInvocation)},
+			CapabilityDelegation: []did.Verification{*did.NewReferencedVerification(&vm, did.CapabilityDelegation)},
+			KeyAgreement:         []did.Verification{ka},
+			Service: []did.Service{
+				{
+					ID:              "#didcomm",
+					Type:            vdrapi.DIDCommServiceType,
+					ServiceEndpoint: endpoint.NewDIDCommV1Endpoint("https://example.com/didcomm/v1"),
+					RoutingKeys:     []string{"did:key:z6MkmediatorKey"},
+				},
+				{Type: "LinkedDomains", ServiceEndpoint: endpoint.NewDIDCoreEndpoint([]interface{}{"https://example.com"})},
+			},
+		}, vdrspi.WithOption(NumAlgo, NumAlgo2))
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Len(t, doc.VerificationMethod, 5)
+		require.Len(t, doc.AssertionMethod, 1)
+		require.Len(t, doc.KeyAgreement, 1)
+		require.Len(t, doc.CapabilityInvocation, 1)
+		require.Len(t, doc.CapabilityDelegation, 1)
+		require.Len(t, doc.Authentication, 1)
+		require.Equal(t, "#key-5", doc.Authentication[0].VerificationMethod.ID)
+		require.Equal(t, unreferencedVM.Value, doc.Authentication[0].VerificationMethod.Value)
+		require.Equal(t, vm.JSONWebKey().Key, doc.AssertionMethod[0].VerificationMethod.JSONWebKey().Key)
+
+		require.Len(t, doc.Service, 2)
+		require.Equal(t, "#didcomm", doc.Service[0].ID)
+		require.Equal(t, vdrapi.DIDCommServiceType, doc.Service[0].Type)
+		require.Equal(t, []string{"did:key:z6MkmediatorKey"}, doc.Service[0].RoutingKeys)
+		require.Len(t, doc.Service[0].RecipientKeys, 1)
+
+		uri, err := doc.Service[0].ServiceEndpoint.URI()
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/didcomm/v1", uri)
+
+		require.Equal(t, "#service-1", doc.Service[1].ID)
+		require.Equal(t, "LinkedDomains", doc.Service[1].Type)
+
+		uri, err = doc.Service[1].ServiceEndpoint.URI()
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com", uri)
+	})
+
+	t.Run("resolve peer DID with DIDCommMessaging service URI", func(t *testing.T) {
+		vm := getSigningKey()
+
+		svc := base64.RawURLEncoding.EncodeToString([]byte(`{"t":"dm","s":"https://example.com/endpoint",` +
+			`"r":["did:example:somemediator#somekey"],"a":["didcomm/v2","didcomm/aip2;env=rfc587"]}`))
+
+		didID := "did:peer:2.V" + fingerprint.KeyFingerprint(fingerprint.ED25519PubKeyMultiCodec, vm.Value) +
+			".S" + svc
+
+		docResolution, err := v.Read(didID)
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Len(t, doc.Service, 1)
+		require.Equal(t, vdrapi.DIDCommV2ServiceType, doc.Service[0].Type)
+		require.Empty(t, doc.Service[0].RoutingKeys)
+		require.Empty(t, doc.Service[0].RecipientKeys)
+
+		uri, err := doc.Service[0].ServiceEndpoint.URI()
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/endpoint", uri)
+
+		routingKeys, err := doc.Service[0].ServiceEndpoint.RoutingKeys()
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:example:somemediator#somekey"}, routingKeys)
+
+		accept, err := doc.Service[0].ServiceEndpoint.Accept()
+		require.NoError(t, err)
+		require.Equal(t, []string{"didcomm/v2", "didcomm/aip2;env=rfc587"}, accept)
+	})
+
+	t.Run("resolve peer DID spec example", func(t *testing.T) {
+		// Reference: https://identity.foundation/peer-did-method-spec/#method-2-multiple-inception-key-without-doc
+		didID := "did:peer:2.Vz6Mkj3PUd1WjvaDhNZhhhXQdz5UnZXmS7ehtx8bsPpD47kKc" +
+			".Ez6LSg8zQom395jKLrGiBNruB9MM6V8PWuf2FpEy4uRFiqQBR" +
+			".SeyJ0IjoiZG0iLCJzIjp7InVyaSI6Imh0dHA6Ly9leGFtcGxlLmNvbS9kaWRjb21tIiwiYSI6WyJkaWRjb21tL3YyIl0sInIiOlsiZGlkOmV4" +
+			"YW1wbGU6MTIzNDU2Nzg5YWJjZGVmZ2hpI2tleS0xIl19fQ" +
+			".SeyJ0IjoiZG0iLCJzIjp7InVyaSI6Imh0dHA6Ly9leGFtcGxlLmNvbS9hbm90aGVyIiwiYSI6WyJkaWRjb21tL3YyIl0sInIiOlsiZGlkOmV4" +
+			"YW1wbGU6MTIzNDU2Nzg5YWJjZGVmZ2hpI2tleS0yIl19fQ"
+
+		docResolution, err := v.Read(didID)
+		require.NoError(t, err)
+
+		doc := docResolution.DIDDocument
+		require.Equal(t, didID, doc.ID)
+
+		require.Len(t, doc.VerificationMethod, 2)
+		require.Equal(t, "#key-1", doc.VerificationMethod[0].ID)
+		require.Equal(t, ed25519VerificationKey2018, doc.VerificationMethod[0].Type)
+		require.Equal(t, "z6Mkj3PUd1WjvaDhNZhhhXQdz5UnZXmS7ehtx8bsPpD47kKc",
+			fingerprint.KeyFingerprint(fingerprint.ED25519PubKeyMultiCodec, doc.VerificationMethod[0].Value))
+		require.Equal(t, "#key-2", doc.VerificationMethod[1].ID)
+		require.Equal(t, x25519KeyAgreementKey2019, doc.VerificationMethod[1].Type)
+		require.Equal(t, "z6LSg8zQom395jKLrGiBNruB9MM6V8PWuf2FpEy4uRFiqQBR",
+			fingerprint.KeyFingerprint(fingerprint.X25519PubKeyMultiCodec, doc.VerificationMethod[1].Value))
+
+		require.Len(t, doc.Authentication, 1)
+		require.Equal(t, "#key-1", doc.Authentication[0].VerificationMethod.ID)
+		require.Len(t, doc.KeyAgreement, 1)
+		require.Equal(t, "#key-2", doc.KeyAgreement[0].VerificationMethod.ID)
+		require.Empty(t, doc.AssertionMethod)
+
+		require.Len(t, doc.Service, 2)
+
+		for i, expected := range []struct {
+			id, uri, routingKey string
+		}{
+			{"#service", "http://example.com/didcomm", "did:example:123456789abcdefghi#key-1"},
+			{"#service-1", "http://example.com/another", "did:example:123456789abcdefghi#key-2"},
+		} {
+			require.Equal(t, expected.id, doc.Service[i].ID)
+			require.Equal(t, vdrapi.DIDCommV2ServiceType, doc.Service[i].Type)
+			require.Equal(t, endpoint.DIDCommV2, doc.Service[i].ServiceEndpoint.Type())
+
+			uri, err := doc.Service[i].ServiceEndpoint.URI()
+			require.NoError(t, err)
+			require.Equal(t, expected.uri, uri)
+
+			accept, err := doc.Service[i].ServiceEndpoint.Accept()
+			require.NoError(t, err)
+			require.Equal(t, []string{"didcomm/v2"}, accept)
+
+			routingKeys, err := doc.Service[i].ServiceEndpoint.RoutingKeys()
+			require.NoError(t, err)
+			require.Equal(t, []string{expected.routingKey}, routingKeys)
+		}
+	})
+
+	t.Run("fail to create numalgo 2 DID", func(t *testing.T) {
+		_, err := v.Create(&did.Doc{}, vdrspi.WithOption(NumAlgo, NumAlgo2))
+		require.EqualError(t, err, "create peer DID : verification method and key agreement are empty, at "+
+			"least one should be set")
+
+		vm := getSigningKey()
+		vm.Type = "undefined"
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{vm}},
+			vdrspi.WithOption(NumAlgo, NumAlgo2))
+		require.EqualError(t, err, "create peer DID : not supported verification method public key type: undefined")
+
+		_, err = v.Create(&did.Doc{
+			VerificationMethod: []did.VerificationMethod{getSigningKey()},
+			Service:            []did.Service{{Type: ""}},
+		}, vdrspi.WithOption(NumAlgo, NumAlgo2), vdrspi.WithOption(DefaultServiceType, []byte{}))
+		require.EqualError(t, err, "create peer DID : defaultServiceType not string")
+	})
+
+	t.Run("fail to resolve numalgo 2 DID", func(t *testing.T) {
+		fp := fingerprint.KeyFingerprint(fingerprint.ED25519PubKeyMultiCodec, getSigningKey().Value)
+
+		for didID, errMsg := range map[string]string{
+			"did:peer:2.V" + fp + "..S":   "resolve numalgo 2 peer DID: empty element",
+			"did:peer:2.X" + fp:           "resolve numalgo 2 peer DID: unsupported purpose code 'X'",
+			"did:peer:2.Vabc":             "resolve numalgo 2 peer DID: invalid key fingerprint 'abc': unknown key encoding",
+			"did:peer:2.V" + fp + ".S!":   "resolve numalgo 2 peer DID: decode service: illegal base64 data at input byte 0",
+			"did:peer:2.V" + fp + ".SeyJ": "resolve numalgo 2 peer DID: unmarshal service: unexpected end of JSON input",
+		} {
+			_, err := v.Read(didID)
+			require.EqualError(t, err, errMsg, didID)
+		}
+	})
+}
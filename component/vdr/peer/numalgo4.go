@@ -0,0 +1,193 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/multiformats/go-multihash"
+
+	"github.com/hyperledger/aries-framework-go/component/models/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/component/vdr/api"
+	vdrspi "github.com/hyperledger/aries-framework-go/spi/vdr"
+)
+
+// jsonMultiCodec is the multicodec code of the JSON encoded input doc of numalgo 4 peer DIDs.
+const jsonMultiCodec = 0x0200
+
+// createNumAlgo4 creates the numalgo 4 peer DID of the genesis version of didDoc (built as for numalgo 1, without
+// ID) and returns the resolved docs of its long form and short form.
+// Reference: https://identity.foundation/peer-did-method-spec/#method-4-short-form-and-long-form
+func createNumAlgo4(didDoc *did.Doc, docOpts *vdrspi.DIDMethodOpts) (*did.Doc, *did.Doc, error) {
+	docResolution, err := build(didDoc, docOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inputDoc := docResolution.DIDDocument
+	inputDoc.ID = ""
+
+	docBytes, err := inputDoc.JSONBytes()
+	if err != nil {
+		return nil, nil, fmt.Errorf("JSON marshalling of input doc failed: %w", err)
+	}
+
+	docJSON := map[string]interface{}{}
+
+	if err = json.Unmarshal(docBytes, &docJSON); err != nil {
+		return nil, nil, fmt.Errorf("JSON unmarshalling of input doc failed: %w", err)
+	}
+
+	delete(docJSON, "id")
+
+	docBytes, err = json.Marshal(docJSON)
+	if err != nil {
+		return nil, nil, fmt.Errorf("JSON marshalling of input doc failed: %w", err)
+	}
+
+	buf := make([]byte, binary.MaxVarintLen64)
+	buf = append(buf[:binary.PutUvarint(buf, jsonMultiCodec)], docBytes...)
+
+	encodedDoc, err := multibase.Encode(multibase.Base58BTC, buf)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encode input doc: %w", err)
+	}
+
+	hash, err := numAlgo4Hash(encodedDoc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resolveNumAlgo4(peerPrefix + "4" + hash + ":" + encodedDoc)
+}
+
+// isNumAlgo4LongForm checks if didID is the long form of a numalgo 4 peer DID.
+func isNumAlgo4LongForm(didID string) bool {
+	return strings.HasPrefix(didID, peerPrefix+"4") && strings.Contains(didID[len(peerPrefix):], ":")
+}
+
+// resolveNumAlgo4 resolves the long form numalgo 4 peer DID didID. It returns the resolved doc of the long form and
+// the resolved doc of the short form, each having the other form as alsoKnownAs.
+// Reference: https://identity.foundation/peer-did-method-spec/#resolving-a-did
+func resolveNumAlgo4(didID string) (*did.Doc, *did.Doc, error) {
+	hash, encodedDoc, ok := strings.Cut(didID[len(peerPrefix)+1:], ":")
+	if !ok {
+		return nil, nil, errors.New("resolve numalgo 4 peer DID: not a long form DID")
+	}
+
+	expectedHash, err := numAlgo4Hash(encodedDoc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolve numalgo 4 peer DID: %w", err)
+	}
+
+	if hash != expectedHash {
+		return nil, nil, errors.New("resolve numalgo 4 peer DID: hash of the encoded doc doesnt match the DID hash")
+	}
+
+	_, buf, err := multibase.Decode(encodedDoc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolve numalgo 4 peer DID: decode input doc: %w", err)
+	}
+
+	code, n := binary.Uvarint(buf)
+	if n <= 0 || code != jsonMultiCodec {
+		return nil, nil, errors.New("resolve numalgo 4 peer DID: input doc is not JSON encoded")
+	}
+
+	docJSON := map[string]interface{}{}
+
+	if err = json.Unmarshal(buf[n:], &docJSON); err != nil {
+		return nil, nil, fmt.Errorf("resolve numalgo 4 peer DID: unmarshal input doc: %w", err)
+	}
+
+	if _, ok = docJSON["id"]; ok {
+		return nil, nil, errors.New("resolve numalgo 4 peer DID: input doc must not have an ID")
+	}
+
+	shortFormDID := peerPrefix + "4" + hash
+
+	longForm, err := numAlgo4Doc(docJSON, didID, shortFormDID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shortForm, err := numAlgo4Doc(docJSON, shortFormDID, didID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return longForm, shortForm, nil
+}
+
+// numAlgo4Hash returns the multibase encoded sha2-256 multihash of encodedDoc.
+func numAlgo4Hash(encodedDoc string) (string, error) {
+	hash, err := multihash.Sum([]byte(encodedDoc), multihash.SHA2_256, -1)
+	if err != nil {
+		return "", fmt.Errorf("hash input doc: %w", err)
+	}
+
+	return multibase.Encode(multibase.Base58BTC, hash)
+}
+
+// numAlgo4Doc parses the input doc docJSON with the given id and alsoKnownAs. Verification methods without controller
+// are controlled by id, and DIDCommMessaging service endpoint objects are parsed as single element arrays.
+func numAlgo4Doc(docJSON map[string]interface{}, id, alsoKnownAs string) (*did.Doc, error) {
+	docJSON["id"] = id
+	docJSON["alsoKnownAs"] = []string{alsoKnownAs}
+
+	for _, property := range []string{
+		"verificationMethod", "authentication", "assertionMethod", "keyAgreement", "capabilityInvocation",
+		"capabilityDelegation",
+	} {
+		for _, method := range jsonObjects(docJSON[property]) {
+			if c, ok := method["controller"].(string); !ok || c == "" || c == alsoKnownAs {
+				method["controller"] = id
+			}
+		}
+	}
+
+	for _, svc := range jsonObjects(docJSON["service"]) {
+		if ep, ok := svc["serviceEndpoint"].(map[string]interface{}); ok && svc["type"] == vdrapi.DIDCommV2ServiceType {
+			svc["serviceEndpoint"] = []interface{}{ep}
+		}
+	}
+
+	docBytes, err := json.Marshal(docJSON)
+	if err != nil {
+		return nil, fmt.Errorf("resolve numalgo 4 peer DID: marshal doc: %w", err)
+	}
+
+	doc, err := did.ParseDocument(docBytes)
+	if err != nil {
+		return nil, fmt.Errorf("resolve numalgo 4 peer DID: parse doc: %w", err)
+	}
+
+	return doc, nil
+}
+
+// jsonObjects returns the JSON objects of the JSON array v.
+func jsonObjects(v interface{}) []map[string]interface{} {
+	array, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var objects []map[string]interface{}
+
+	for _, e := range array {
+		if object, ok := e.(map[string]interface{}); ok {
+			objects = append(objects, object)
+		}
+	}
+
+	return objects
+}
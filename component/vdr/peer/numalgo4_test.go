@@ -0,0 +1,204 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/models/did"
+	"github.com/hyperledger/aries-framework-go/component/models/did/endpoint"
+	"github.com/hyperledger/aries-framework-go/component/storageutil/mock/storage"
+	vdrapi "github.com/hyperledger/aries-framework-go/component/vdr/api"
+	vdrspi "github.com/hyperledger/aries-framework-go/spi/vdr"
+)
+
+func TestNumAlgo4(t *testing.T) {
+	sProvider := storage.NewMockStoreProvider()
+	km := newKMS(t, sProvider)
+
+	v, err := New(sProvider)
+	require.NoError(t, err)
+
+	vm, ka := getSigningAndKeyAgreementKey(t, false, km)
+	vm.ID = "#key-1"
+	ka.VerificationMethod.ID = "#key-2"
+
+	docResolution, err := v.Create(&did.Doc{
+		VerificationMethod: []did.VerificationMethod{vm},
+		KeyAgreement:       []did.Verification{ka},
+		Service: []did.Service{{
+			Type:            vdrapi.DIDCommV2ServiceType,
+			ServiceEndpoint: endpoint.NewDIDCommV2Endpoint([]endpoint.DIDCommV2Endpoint{{URI: "https://example.com"}}),
+		}},
+	}, vdrspi.WithOption(NumAlgo, NumAlgo4))
+	require.NoError(t, err)
+
+	longForm := docResolution.DIDDocument
+	require.True(t, strings.HasPrefix(longForm.ID, "did:peer:4zQm"))
+	require.Len(t, longForm.AlsoKnownAs, 1)
+
+	shortFormDID := longForm.AlsoKnownAs[0]
+	require.True(t, strings.HasPrefix(longForm.ID, shortFormDID+":z"))
+
+	t.Run("create numalgo 4 DID", func(t *testing.T) {
+		require.Len(t, longForm.VerificationMethod, 2)
+		require.Equal(t, vm.Value, longForm.VerificationMethod[0].Value)
+		require.Len(t, longForm.Authentication, 1)
+		require.Len(t, longForm.KeyAgreement, 1)
+		require.Equal(t, ka.VerificationMethod.Value, longForm.KeyAgreement[0].VerificationMethod.Value)
+		require.Len(t, longForm.Service, 1)
+		require.Equal(t, []string{longForm.ID + "#key-2"}, longForm.Service[0].RecipientKeys)
+	})
+
+	t.Run("resolve long form and short form", func(t *testing.T) {
+		resolved, err := v.Read(longForm.ID)
+		require.NoError(t, err)
+		require.Equal(t, longForm.ID, resolved.DIDDocument.ID)
+		require.Equal(t, []string{shortFormDID}, resolved.DIDDocument.AlsoKnownAs)
+		require.Equal(t, longForm.VerificationMethod, resolved.DIDDocument.VerificationMethod)
+		require.Equal(t, longForm.ID+"#key-1", resolved.DIDDocument.VerificationMethod[0].ID)
+
+		resolved, err = v.Read(shortFormDID)
+		require.NoError(t, err)
+		require.Equal(t, shortFormDID, resolved.DIDDocument.ID)
+		require.Equal(t, []string{longForm.ID}, resolved.DIDDocument.AlsoKnownAs)
+		require.Len(t, resolved.DIDDocument.VerificationMethod, 2)
+		require.Equal(t, shortFormDID+"#key-1", resolved.DIDDocument.VerificationMethod[0].ID)
+		require.Equal(t, vm.Value, resolved.DIDDocument.VerificationMethod[0].Value)
+	})
+
+	t.Run("resolve short form after resolving long form", func(t *testing.T) {
+		other, err := New(storage.NewMockStoreProvider())
+		require.NoError(t, err)
+
+		_, err = other.Read(shortFormDID)
+		require.Error(t, err)
+
+		_, err = other.Read(longForm.ID)
+		require.NoError(t, err)
+
+		resolved, err := other.Read(shortFormDID)
+		require.NoError(t, err)
+		require.Equal(t, shortFormDID, resolved.DIDDocument.ID)
+	})
+
+	t.Run("fail to resolve numalgo 4 DID", func(t *testing.T) {
+		encodedDoc := longForm.ID[len(shortFormDID)+1:]
+
+		tamperedDoc, err := multibase.Encode(multibase.Base58BTC, []byte{0x80, 0x04, '{', '}'})
+		require.NoError(t, err)
+
+		_, err = v.Read(shortFormDID + ":" + tamperedDoc)
+		require.EqualError(t, err, "resolve numalgo 4 peer DID: hash of the encoded doc doesnt match the DID hash")
+
+		for doc, errMsg := range map[string]string{
+			"z":            "resolve numalgo 4 peer DID: decode input doc",
+			"zbad0":        "resolve numalgo 4 peer DID: decode input doc: Invalid base58 digit ('0')",
+			encodedDoc[:8]: "resolve numalgo 4 peer DID: input doc is not JSON encoded",
+		} {
+			hash, err := numAlgo4Hash(doc)
+			require.NoError(t, err)
+
+			_, err = v.Read("did:peer:4" + hash + ":" + doc)
+			require.Error(t, err, doc)
+			require.Contains(t, err.Error(), errMsg, doc)
+		}
+
+		docWithID, err := multibase.Encode(multibase.Base58BTC, []byte("\x80\x04{\"id\":\"did:example:123\"}"))
+		require.NoError(t, err)
+
+		hash, err := numAlgo4Hash(docWithID)
+		require.NoError(t, err)
+
+		_, err = v.Read("did:peer:4" + hash + ":" + docWithID)
+		require.EqualError(t, err, "resolve numalgo 4 peer DID: input doc must not have an ID")
+	})
+
+	t.Run("fail to create peer DID with invalid numalgo", func(t *testing.T) {
+		_, err := v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{getSigningKey()}},
+			vdrspi.WithOption(NumAlgo, 3))
+		require.EqualError(t, err, "create peer DID : not supported numalgo: 3")
+
+		_, err = v.Create(&did.Doc{VerificationMethod: []did.VerificationMethod{getSigningKey()}},
+			vdrspi.WithOption(NumAlgo, "2"))
+		require.EqualError(t, err, "numAlgo opt not int")
+
+		_, err = v.Create(&did.Doc{}, vdrspi.WithOption(NumAlgo, NumAlgo4))
+		require.EqualError(t, err, "create peer DID : verification method and key agreement are empty, at "+
+			"least one should be set")
+	})
+}
+
+func TestNumAlgo4SpecExample(t *testing.T) {
+	// long form of the numalgo 4 peer DID of the input doc of the spec example, encoded as compact JSON.
+	// Reference: https://identity.foundation/peer-did-method-spec/#method-4-short-form-and-long-form
+	shortFormDID := "did:peer:4zQmUKnNdeRXYfHndWoWJAgW7ZPHxUptodWP9eAoZ1DmXCYe"
+	longFormDID := shortFormDID + ":" +
+		"ztJwdLmuTbhZBmG7bt31oCeurbP1CS4y6P5yLRSENjygCTwxik8wueavELvDF4N2gkdNZMyAzVLACtPPbpNMY6VVGSsp8vfuR3fG" +
+		"QVfirBxNxeDnQYm3PykiheEUz34S4DRea5ipgLfg3vdu79yjJ6epM67EC2bJntxANqCbaMUgBBBJd9dCQTsZYZQvqP1dHf2qoQC3" +
+		"RzuPerhE7zY5TuzQyMbqnCsYT9JCP9rFay5YWNAujYyNJdfwSod4NMLTXxis9iFfYCfCzy2kgko371YfCfCNTpoHL6kXYmPyDiLL" +
+		"dx7VxvW8z3nCsT6j2TgwowpEiCSg8snWXTU2nK2AyzDkqsnuFQ6f9tfZYAPTRAqBR73xxeCgvr6edrLRQhFudgAjYweRwQwT5i2z" +
+		"Z5vkbCXyyCwdQS1BuHNSF4j2KguzTtfa3bTBsfHGn2m1k7Xyc8MzLvctHrJe9WQGRSAra2ztnJYeHUSweAhn486cda8s9yVfRfnA" +
+		"77j6G3ZGi7yDfx9sSzifsbJ41ofBzFYGseiCdYegqsuZE2J9AvHR1buhJNUVvWCi66PPYrE192H6VwGu5ouwJpUYFscEgkBPHNg8" +
+		"vwwbb1debVzdMHtzfyQdGfWcMEs4P6ymuR8F4ZQqnBmZPppiMfPdZaMzS5XieXqDZa57tGZLq1yC7bm2grEdXGeD9E2aVj67n1eZ" +
+		"e6Furn7AcBenSdxKSGtmZxnzwZeDcRS2ab4uDGBdzqTnwWroUU5G9jd5CSyxswUDwN3w6nw3PkbzSGZN4ETmFhHVMhDNFFvGNDp2" +
+		"HFgsBwgoqg3wbBGNHxiDBHkod2FB4btee6cxTYimbuAUzRwK8gcHwHBUempWWwy81tkTWuQ3Zvw1KpPce51miHPoh6cKp8"
+
+	v, err := New(storage.NewMockStoreProvider())
+	require.NoError(t, err)
+
+	docResolution, err := v.Read(longFormDID)
+	require.NoError(t, err)
+
+	doc := docResolution.DIDDocument
+	require.Equal(t, longFormDID, doc.ID)
+	require.Equal(t, []string{shortFormDID}, doc.AlsoKnownAs)
+
+	require.Len(t, doc.VerificationMethod, 2)
+
+	for i, id := range []string{"#6LSqPZfn", "#6MkrCD1c"} {
+		require.Equal(t, longFormDID+id, doc.VerificationMethod[i].ID)
+		require.Equal(t, "Multikey", doc.VerificationMethod[i].Type)
+		require.Equal(t, longFormDID, doc.VerificationMethod[i].Controller)
+	}
+
+	for _, verifications := range [][]did.Verification{
+		doc.Authentication, doc.AssertionMethod, doc.CapabilityInvocation, doc.CapabilityDelegation,
+	} {
+		require.Len(t, verifications, 1)
+		require.Equal(t, longFormDID+"#6MkrCD1c", verifications[0].VerificationMethod.ID)
+	}
+
+	require.Len(t, doc.KeyAgreement, 1)
+	require.Equal(t, longFormDID+"#6LSqPZfn", doc.KeyAgreement[0].VerificationMethod.ID)
+
+	require.Len(t, doc.Service, 1)
+	require.Equal(t, longFormDID+"#didcommmessaging-0", doc.Service[0].ID)
+	require.Equal(t, vdrapi.DIDCommV2ServiceType, doc.Service[0].Type)
+	require.Equal(t, endpoint.DIDCommV2, doc.Service[0].ServiceEndpoint.Type())
+
+	uri, err := doc.Service[0].ServiceEndpoint.URI()
+	require.NoError(t, err)
+	require.Equal(t, "didcomm:transport/queue", uri)
+
+	accept, err := doc.Service[0].ServiceEndpoint.Accept()
+	require.NoError(t, err)
+	require.Equal(t, []string{"didcomm/v2"}, accept)
+
+	docResolution, err = v.Read(shortFormDID)
+	require.NoError(t, err)
+
+	doc = docResolution.DIDDocument
+	require.Equal(t, shortFormDID, doc.ID)
+	require.Equal(t, []string{longFormDID}, doc.AlsoKnownAs)
+	require.Equal(t, shortFormDID+"#6MkrCD1c", doc.Authentication[0].VerificationMethod.ID)
+	require.Equal(t, shortFormDID, doc.VerificationMethod[0].Controller)
+}
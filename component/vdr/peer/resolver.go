@@ -8,6 +8,7 @@ package peer
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/aries-framework-go/component/models/did"
 	vdrapi "github.com/hyperledger/aries-framework-go/component/vdr/api"
@@ -15,7 +16,39 @@ import (
 )
 
 // Read implements didresolver.DidMethod.Read interface (https://w3c-ccg.github.io/did-resolution/#resolving-input)
+// Numalgo 0, 2 and long form numalgo 4 DIDs are resolved from the DID itself, the short form of a resolved long form
+// numalgo 4 DID is stored so it can be resolved afterwards.
 func (v *VDR) Read(didID string, _ ...vdrspi.DIDMethodOption) (*did.DocResolution, error) {
+	var (
+		doc *did.Doc
+		err error
+	)
+
+	switch {
+	case strings.HasPrefix(didID, peerPrefix+"0"):
+		doc, err = resolveNumAlgo0(didID)
+	case strings.HasPrefix(didID, peerPrefix+"2"):
+		doc, err = resolveNumAlgo2(didID)
+	case isNumAlgo4LongForm(didID):
+		var shortForm *did.Doc
+
+		doc, shortForm, err = resolveNumAlgo4(didID)
+		if err == nil {
+			err = v.storeDID(shortForm, nil)
+		}
+	default:
+		return v.readStored(didID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &did.DocResolution{Context: []string{schemaResV1}, DIDDocument: doc}, nil
+}
+
+// readStored reads the numalgo 1 or short form numalgo 4 DID didID from the store.
+func (v *VDR) readStored(didID string) (*did.DocResolution, error) {
 	// get the document from the store
 	doc, err := v.Get(didID)
 	if err != nil {
@@ -494,3 +494,125 @@ func (c *Client) ResolveCredentialManifest(manifest json.RawMessage, resolve wal
 
 	return c.wallet.ResolveCredentialManifest(auth, manifest, resolve)
 }
+
+// ResolveCredentialOffer reads an OpenID4VCI credential offer URI, with the offer by value or by reference.
+//
+// Args:
+// 		- offerURI: credential offer URI.
+// 		- options: options like HTTP client.
+//
+// Returns:
+// 		- credential offer.
+// 		- error if operation fails.
+//
+func (c *Client) ResolveCredentialOffer(offerURI string, options ...wallet.OID4VCIOptions) (*wallet.CredentialOffer, error) { // nolint: lll
+	return c.wallet.ResolveCredentialOffer(offerURI, options...)
+}
+
+// AcceptCredentialOffer requests the credentials of an OpenID4VCI credential offer with its pre-authorized code
+// and saves them into the wallet.
+// Supports: https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html
+//
+// Args:
+// 		- offer: credential offer, resolved from the offer URI by ResolveCredentialOffer.
+// 		- options: options like transaction code, proof key ID etc.
+//
+// Returns:
+// 		- issued credentials and deferred credentials.
+// 		- error if operation fails.
+//
+func (c *Client) AcceptCredentialOffer(offer *wallet.CredentialOffer, options ...wallet.OID4VCIOptions) (*wallet.OID4VCIResult, error) { // nolint: lll
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.AcceptCredentialOffer(auth, offer, options...)
+}
+
+// AuthorizeCredentialOffer starts the OpenID4VCI authorization code flow for the credentials of the offer.
+//
+// Args:
+// 		- offer: credential offer.
+// 		- options: options like client ID and redirect URI.
+//
+// Returns:
+// 		- authorization containing the URL the user has to be redirected to.
+// 		- error if operation fails.
+//
+func (c *Client) AuthorizeCredentialOffer(offer *wallet.CredentialOffer, options ...wallet.OID4VCIOptions) (*wallet.OID4VCIAuthorization, error) { // nolint: lll
+	return c.wallet.AuthorizeCredentialOffer(offer, options...)
+}
+
+// CompleteCredentialAuthorization completes the OpenID4VCI authorization code flow started by
+// AuthorizeCredentialOffer, requests the authorized credentials and saves them into the wallet.
+//
+// Args:
+// 		- authorization: state of the authorization code flow.
+// 		- code, state: authorization code and state received on the redirect URI.
+// 		- options: options like client ID, proof key ID etc.
+//
+// Returns:
+// 		- issued credentials and deferred credentials.
+// 		- error if operation fails.
+//
+func (c *Client) CompleteCredentialAuthorization(authorization *wallet.OID4VCIAuthorization, code, state string, options ...wallet.OID4VCIOptions) (*wallet.OID4VCIResult, error) { // nolint: lll
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.CompleteCredentialAuthorization(auth, authorization, code, state, options...)
+}
+
+// RequestDeferredCredential fetches a deferred OpenID4VCI credential and saves it into the wallet.
+//
+// Returns:
+// 		- issued credential, or wallet.ErrIssuancePending if not issued yet.
+// 		- error if operation fails.
+//
+func (c *Client) RequestDeferredCredential(deferred *wallet.DeferredCredential, options ...wallet.OID4VCIOptions) (*wallet.OID4VCIResult, error) { // nolint: lll
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.RequestDeferredCredential(auth, deferred, options...)
+}
+
+// ResolveAuthorizationRequest reads an OpenID4VP or SIOPv2 authorization request URI, verifying signed
+// request objects according to the client ID scheme of the verifier.
+// Supports: https://openid.net/specs/openid-4-verifiable-presentations-1_0.html
+//
+// Args:
+// 		- requestURI: authorization request URI.
+// 		- options: options like HTTP client, trusted roots etc.
+//
+// Returns:
+// 		- authorization request.
+// 		- error if operation fails.
+//
+func (c *Client) ResolveAuthorizationRequest(requestURI string, options ...wallet.OID4VPOptions) (*wallet.AuthorizationRequest, error) { // nolint: lll
+	return c.wallet.ResolveAuthorizationRequest(requestURI, options...)
+}
+
+// RespondAuthorizationRequest responds to an authorization request with a presentation of the wallet
+// credentials matching its presentation definition, and a self-issued ID token for SIOPv2 requests.
+//
+// Args:
+// 		- request: authorization request resolved by ResolveAuthorizationRequest.
+// 		- proofOptions: proof options, with the DID of the holder as controller.
+// 		- options: options like HTTP client.
+//
+// Returns:
+// 		- authorization response, with the URI the user has to be redirected to.
+// 		- error if operation fails.
+//
+func (c *Client) RespondAuthorizationRequest(request *wallet.AuthorizationRequest, proofOptions *wallet.ProofOptions, options ...wallet.OID4VPOptions) (*wallet.AuthorizationResponse, error) { // nolint: lll
+	auth, err := c.auth()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.wallet.RespondAuthorizationRequest(auth, request, proofOptions, options...)
+}
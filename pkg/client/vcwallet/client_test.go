@@ -12,6 +12,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -1758,6 +1759,67 @@ func TestClient_ResolveCredentialManifest(t *testing.T) {
 	})
 }
 
+func TestClient_OID4VCI(t *testing.T) {
+	sampleUser := uuid.New().String()
+	mockctx := newMockProvider(t)
+
+	err := CreateProfile(sampleUser, mockctx, wallet.WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	vcWallet, err := New(sampleUser, mockctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, vcWallet)
+
+	offer, err := vcWallet.ResolveCredentialOffer(wallet.CredentialOfferScheme + "://?credential_offer=" +
+		url.QueryEscape(`{"credential_issuer":"https://issuer.example.com","credential_configuration_ids":["UDC"]}`))
+	require.NoError(t, err)
+	require.Equal(t, "https://issuer.example.com", offer.CredentialIssuer)
+
+	_, err = vcWallet.AuthorizeCredentialOffer(offer)
+	require.EqualError(t, err, "client ID and redirect URI are required for the authorization code flow")
+
+	t.Run("test failure (closed wallet)", func(t *testing.T) {
+		result, err := vcWallet.AcceptCredentialOffer(offer)
+		require.True(t, errors.Is(err, ErrWalletLocked))
+		require.Empty(t, result)
+
+		result, err = vcWallet.CompleteCredentialAuthorization(&wallet.OID4VCIAuthorization{}, "code", "state")
+		require.True(t, errors.Is(err, ErrWalletLocked))
+		require.Empty(t, result)
+
+		result, err = vcWallet.RequestDeferredCredential(&wallet.DeferredCredential{})
+		require.True(t, errors.Is(err, ErrWalletLocked))
+		require.Empty(t, result)
+	})
+}
+
+func TestClient_OID4VP(t *testing.T) {
+	sampleUser := uuid.New().String()
+	mockctx := newMockProvider(t)
+
+	err := CreateProfile(sampleUser, mockctx, wallet.WithPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	vcWallet, err := New(sampleUser, mockctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, vcWallet)
+
+	request, err := vcWallet.ResolveAuthorizationRequest("openid://?" + url.Values{
+		"client_id":     {"https://rp.example.com/cb"},
+		"redirect_uri":  {"https://rp.example.com/cb"},
+		"response_type": {wallet.ResponseTypeIDToken},
+		"nonce":         {"nonce"},
+	}.Encode())
+	require.NoError(t, err)
+	require.Equal(t, wallet.ClientIDSchemeRedirectURI, request.ClientIDScheme)
+
+	t.Run("test failure (closed wallet)", func(t *testing.T) {
+		response, err := vcWallet.RespondAuthorizationRequest(request, &wallet.ProofOptions{Controller: "did:example:holder"})
+		require.True(t, errors.Is(err, ErrWalletLocked))
+		require.Empty(t, response)
+	})
+}
+
 func newMockProvider(t *testing.T) *mockprovider.Provider {
 	t.Helper()
 
@@ -0,0 +1,51 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package waci
+
+import (
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	storeverifiable "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// ServiceProvider is used to lookup the issuecredential and presentproof services.
+type ServiceProvider interface {
+	Service(name string) (interface{}, error)
+}
+
+// IssueCredentialService defines the API required on the issue-credential protocol service implementation.
+type IssueCredentialService interface {
+	AddMiddleware(...issuecredential.Middleware)
+}
+
+// PresentProofService defines the API required on the present-proof protocol service implementation.
+type PresentProofService interface {
+	AddMiddleware(...presentproof.Middleware)
+}
+
+// TransientStorage provides transient storage.
+type TransientStorage interface {
+	ProtocolStateStorageProvider() storage.Provider
+}
+
+// Provider provides all dependencies.
+//
+// See also: context.Provider.
+type Provider interface {
+	TransientStorage
+	KMS() kms.KeyManager
+	Crypto() crypto.Crypto
+	VDRegistry() vdr.Registry
+	JSONLDDocumentLoader() ld.DocumentLoader
+	VerifiableStore() storeverifiable.Store
+}
@@ -0,0 +1,353 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package waci
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm/issuer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	storeverifiable "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// CredentialSource returns the credentials the Holder selects from when answering a presentation definition.
+type CredentialSource func() ([]*verifiable.Credential, error)
+
+type holderOptions struct {
+	keyID              string
+	verificationMethod string
+	signatureType      string
+	credentials        CredentialSource
+}
+
+// HolderOpt is an option for the Holder.
+type HolderOpt func(opts *holderOptions)
+
+// WithSigningKey sets the ID of the KMS key signing the presentations of the Holder, along with the verification
+// method of the holder DID its public key is published under.
+func WithSigningKey(keyID, verificationMethod string) HolderOpt {
+	return func(opts *holderOptions) {
+		opts.keyID = keyID
+		opts.verificationMethod = verificationMethod
+	}
+}
+
+// WithSignatureType sets the linked data proof type of the presentations, issuer.Ed25519Signature2018 (default) or
+// issuer.JSONWebSignature2020.
+func WithSignatureType(signatureType string) HolderOpt {
+	return func(opts *holderOptions) {
+		opts.signatureType = signatureType
+	}
+}
+
+// WithCredentialSource sets the source of the credentials the Holder presents. It defaults to all the credentials
+// of the verifiable store.
+func WithCredentialSource(source CredentialSource) HolderOpt {
+	return func(opts *holderOptions) {
+		opts.credentials = source
+	}
+}
+
+// Holder runs the holder side of the WACI issuance and presentation flows.
+type Holder struct {
+	documentLoader ld.DocumentLoader
+	store          storage.Store
+	signer         *presentationSigner
+	credentials    CredentialSource
+}
+
+// NewHolder returns a new Holder signing its presentations with the key set by WithSigningKey.
+func NewHolder(p Provider, opts ...HolderOpt) (*Holder, error) {
+	o := &holderOptions{
+		signatureType: issuer.Ed25519Signature2018,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.keyID == "" || o.verificationMethod == "" {
+		return nil, errors.New("waci: missing signing key")
+	}
+
+	if o.credentials == nil {
+		o.credentials = storeCredentials(p.VerifiableStore())
+	}
+
+	s, err := p.ProtocolStateStorageProvider().OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("waci: failed to open store: %w", err)
+	}
+
+	return &Holder{
+		documentLoader: p.JSONLDDocumentLoader(),
+		store:          s,
+		signer: &presentationSigner{
+			km:                 p.KMS(),
+			crypto:             p.Crypto(),
+			documentLoader:     p.JSONLDDocumentLoader(),
+			keyID:              o.keyID,
+			verificationMethod: o.verificationMethod,
+			signatureType:      o.signatureType,
+		},
+		credentials: o.credentials,
+	}, nil
+}
+
+// Apply answers the Credential Manifest attached to the given offer-credential message with the request-credential
+// message holding a signed Credential Application.
+// It returns ErrWACINotApplicable if the message does not hold a Credential Manifest.
+func (h *Holder) Apply(msg service.DIDCommMsg) (*issuecredential.RequestCredentialParams, error) {
+	offer := &issuecredential.OfferCredentialParams{}
+
+	err := msg.Decode(offer)
+	if err != nil {
+		return nil, fmt.Errorf("waci: failed to decode offer-credential message: %w", err)
+	}
+
+	attachment, err := findAttachment(offer.Attachments, cm.CredentialManifestAttachmentFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &ManifestPayload{}
+
+	err = decodeAttachment(attachment, payload)
+	if err != nil {
+		return nil, fmt.Errorf("waci: invalid credential manifest: %w", err)
+	}
+
+	if payload.CredentialManifest == nil || payload.Options == nil {
+		return nil, errors.New("waci: offer is missing the credential manifest or its options")
+	}
+
+	vp, err := h.presentation(payload.CredentialManifest.PresentationDefinition)
+	if err != nil {
+		return nil, fmt.Errorf("waci: %w", err)
+	}
+
+	submission := vp.CustomFields[submissionField]
+
+	application, err := cm.PresentCredentialApplication(payload.CredentialManifest,
+		cm.WithExistingPresentationForPresentCredentialApplication(vp))
+	if err != nil {
+		return nil, fmt.Errorf("waci: failed to create credential application: %w", err)
+	}
+
+	// Keep the submission matching the selected credentials rather than the positional one of the application.
+	if submission != nil {
+		application.CustomFields[submissionField] = submission
+	}
+
+	ca, err := credentialApplication(application)
+	if err != nil {
+		return nil, fmt.Errorf("waci: %w", err)
+	}
+
+	err = h.signer.sign(application, payload.Options.Challenge, payload.Options.Domain)
+	if err != nil {
+		return nil, fmt.Errorf("waci: failed to sign credential application: %w", err)
+	}
+
+	err = saveRecord(h.store, applicationKeyPrefix+ca.ID, &record{ManifestID: payload.CredentialManifest.ID})
+	if err != nil {
+		return nil, fmt.Errorf("waci: %w", err)
+	}
+
+	return &issuecredential.RequestCredentialParams{
+		Type: issuecredential.RequestCredentialMsgTypeV3,
+		Attachments: []decorator.GenericAttachment{
+			jsonAttachment(cm.CredentialApplicationAttachmentFormat, mediaTypeJSONLD, application),
+		},
+	}, nil
+}
+
+// Present answers the presentation definition attached to the given request-presentation message with the
+// presentation message holding a signed presentation submission.
+// It returns ErrWACINotApplicable if the message does not hold a presentation definition.
+func (h *Holder) Present(msg service.DIDCommMsg) (*presentproof.PresentationParams, error) {
+	request := &presentproof.RequestPresentationParams{}
+
+	err := msg.Decode(request)
+	if err != nil {
+		return nil, fmt.Errorf("waci: failed to decode request-presentation message: %w", err)
+	}
+
+	attachment, err := findAttachment(request.Attachments, PresentationDefinitionFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &DefinitionPayload{}
+
+	err = decodeAttachment(attachment, payload)
+	if err != nil {
+		return nil, fmt.Errorf("waci: invalid presentation definition: %w", err)
+	}
+
+	if payload.PresentationDefinition == nil {
+		return nil, errors.New("waci: request is missing the presentation definition")
+	}
+
+	vp, err := h.presentation(payload.PresentationDefinition)
+	if err != nil {
+		return nil, fmt.Errorf("waci: %w", err)
+	}
+
+	err = h.signer.sign(vp, payload.Challenge, payload.Domain)
+	if err != nil {
+		return nil, fmt.Errorf("waci: failed to sign presentation: %w", err)
+	}
+
+	return &presentproof.PresentationParams{
+		Attachments: []decorator.GenericAttachment{
+			jsonAttachment(PresentationSubmissionFormat, mediaTypeJSONLD, vp),
+		},
+	}, nil
+}
+
+// AutoExecute answers the offer-credential messages holding a Credential Manifest with Apply, the request-presentation
+// messages holding a presentation definition with Present, and accepts the issue-credential messages holding a
+// Credential Response. Other actions are passed through to 'next'.
+//
+// The Credential Response is validated and its credentials saved by the issue-credential Middleware, which has to be
+// registered with RegisterMiddleware.
+//
+// Usage:
+//
+//	issueCredential := issuecredential.Client = ...
+//	presentProof := presentproof.Client = ...
+//	events = make(chan service.DIDCommAction)
+//	err := issueCredential.RegisterActionEvent(events)
+//	if err != nil {
+//	    panic(err)
+//	}
+//	err = presentProof.RegisterActionEvent(events)
+//	if err != nil {
+//	    panic(err)
+//	}
+//	next := make(chan service.DIDCommAction)
+//	go holder.AutoExecute(next)(events)
+//	for event := range next {
+//	    // handle events that do not belong to the WACI flows
+//	}
+func (h *Holder) AutoExecute(next chan service.DIDCommAction) func(chan service.DIDCommAction) {
+	return func(events chan service.DIDCommAction) {
+		for event := range events {
+			var (
+				arg interface{}
+				err error
+			)
+
+			switch event.Message.Type() {
+			case issuecredential.OfferCredentialMsgTypeV3:
+				var request *issuecredential.RequestCredentialParams
+
+				request, err = h.Apply(event.Message)
+				arg = issuecredential.WithRequestCredential(request)
+			case issuecredential.IssueCredentialMsgTypeV3:
+				err = hasCredentialResponse(event.Message)
+			case presentproof.RequestPresentationMsgTypeV3:
+				var presentation *presentproof.PresentationParams
+
+				presentation, err = h.Present(event.Message)
+				arg = presentproof.WithPresentation(presentation)
+			default:
+				next <- event
+
+				continue
+			}
+
+			if errors.Is(err, ErrWACINotApplicable) {
+				next <- event
+
+				continue
+			}
+
+			if err != nil {
+				event.Stop(err)
+
+				continue
+			}
+
+			event.Continue(arg)
+		}
+	}
+}
+
+func (h *Holder) presentation(pd *presexch.PresentationDefinition) (*verifiable.Presentation, error) {
+	var (
+		vp  *verifiable.Presentation
+		err error
+	)
+
+	if pd == nil {
+		vp, err = verifiable.NewPresentation()
+	} else {
+		var credentials []*verifiable.Credential
+
+		credentials, err = h.credentials()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load credentials: %w", err)
+		}
+
+		vp, err = pd.CreateVP(credentials, h.documentLoader, verifiable.WithDisabledProofCheck(),
+			verifiable.WithJSONLDDocumentLoader(h.documentLoader))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create presentation: %w", err)
+	}
+
+	vp.Holder = strings.Split(h.signer.verificationMethod, "#")[0]
+
+	return vp, nil
+}
+
+func hasCredentialResponse(msg service.DIDCommMsg) error {
+	issue := &issuecredential.IssueCredentialParams{}
+
+	err := msg.Decode(issue)
+	if err != nil {
+		return fmt.Errorf("waci: failed to decode issue-credential message: %w", err)
+	}
+
+	_, err = findAttachment(issue.Attachments, cm.CredentialResponseAttachmentFormat)
+
+	return err
+}
+
+func storeCredentials(store storeverifiable.Store) CredentialSource {
+	return func() ([]*verifiable.Credential, error) {
+		records, err := store.GetCredentials()
+		if err != nil {
+			return nil, err
+		}
+
+		credentials := make([]*verifiable.Credential, len(records))
+
+		for i, r := range records {
+			credentials[i], err = store.GetCredential(r.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return credentials, nil
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package waci
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm/issuer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// Issuer runs the issuer side of the WACI issuance flow for a Credential Manifest.
+type Issuer struct {
+	manifest       *cm.CredentialManifest
+	engine         *issuer.Issuer
+	vdr            vdrapi.Registry
+	documentLoader ld.DocumentLoader
+	store          storage.Store
+}
+
+// NewIssuer returns a new Issuer offering the given Credential Manifest. The options configure the Credential
+// Manifest issuer fulfilling the applications, they must at least set its signing key and templates.
+//
+// See also: issuer.New.
+func NewIssuer(p Provider, manifest *cm.CredentialManifest, opts ...issuer.Opt) (*Issuer, error) {
+	engine, err := issuer.New(manifest, p, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("waci: %w", err)
+	}
+
+	s, err := p.ProtocolStateStorageProvider().OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("waci: failed to open store: %w", err)
+	}
+
+	return &Issuer{
+		manifest:       manifest,
+		engine:         engine,
+		vdr:            p.VDRegistry(),
+		documentLoader: p.JSONLDDocumentLoader(),
+		store:          s,
+	}, nil
+}
+
+// Offer returns the offer-credential message holding the Credential Manifest, with a new challenge the Credential
+// Application has to be proven with.
+func (i *Issuer) Offer() (*issuecredential.OfferCredentialParams, error) {
+	options := &Options{
+		Challenge: uuid.New().String(),
+		Domain:    i.manifest.Issuer.ID,
+	}
+
+	err := saveRecord(i.store, challengeKeyPrefix+options.Challenge, &record{
+		Domain:     options.Domain,
+		ManifestID: i.manifest.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("waci: %w", err)
+	}
+
+	return &issuecredential.OfferCredentialParams{
+		Type:     issuecredential.OfferCredentialMsgTypeV3,
+		GoalCode: IssuanceGoalCode,
+		Attachments: []decorator.GenericAttachment{
+			jsonAttachment(cm.CredentialManifestAttachmentFormat, mediaTypeJSON, &ManifestPayload{
+				Options:            options,
+				CredentialManifest: i.manifest,
+			}),
+		},
+	}, nil
+}
+
+// Fulfill evaluates the Credential Application attached to the given request-credential message and returns the
+// issue-credential message holding the Credential Response.
+// It returns ErrWACINotApplicable if the message does not hold a Credential Application.
+func (i *Issuer) Fulfill(msg service.DIDCommMsg) (*issuecredential.IssueCredentialParams, error) {
+	request := &issuecredential.RequestCredentialParams{}
+
+	err := msg.Decode(request)
+	if err != nil {
+		return nil, fmt.Errorf("waci: failed to decode request-credential message: %w", err)
+	}
+
+	attachment, err := findAttachment(request.Attachments, cm.CredentialApplicationAttachmentFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	application, err := parsePresentation(attachment, i.vdr, i.documentLoader)
+	if err != nil {
+		return nil, fmt.Errorf("waci: invalid credential application: %w", err)
+	}
+
+	r, err := checkChallenge(i.store, msg, application)
+	if err != nil {
+		return nil, fmt.Errorf("waci: invalid credential application: %w", err)
+	}
+
+	if r.ManifestID != i.manifest.ID {
+		return nil, fmt.Errorf("waci: credential application was not offered for manifest '%s'", i.manifest.ID)
+	}
+
+	options, err := proofOptions(application)
+	if err != nil {
+		return nil, fmt.Errorf("waci: %w", err)
+	}
+
+	fetcher := verifiable.NewVDRKeyResolver(i.vdr).PublicKeyFetcher()
+
+	response, err := i.engine.Respond(application,
+		issuer.WithMatchOptions(presexch.WithCredentialOptions(
+			verifiable.WithPublicKeyFetcher(fetcher),
+			verifiable.WithJSONLDDocumentLoader(i.documentLoader),
+		)),
+		issuer.WithChallenge(options.Challenge, options.Domain),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("waci: %w", err)
+	}
+
+	return &issuecredential.IssueCredentialParams{
+		Type: issuecredential.IssueCredentialMsgTypeV3,
+		Attachments: []decorator.GenericAttachment{
+			jsonAttachment(cm.CredentialResponseAttachmentFormat, mediaTypeJSONLD, response),
+		},
+	}, nil
+}
+
+// AutoExecute answers the propose-credential messages with Offer and the request-credential messages holding
+// a Credential Application with Fulfill. Other actions are passed through to 'next'.
+//
+// Usage:
+//
+//	client := issuecredential.Client = ...
+//	events = make(chan service.DIDCommAction)
+//	err := client.RegisterActionEvent(events)
+//	if err != nil {
+//	    panic(err)
+//	}
+//	next := make(chan service.DIDCommAction)
+//	go issuer.AutoExecute(next)(events)
+//	for event := range next {
+//	    // handle events from issue-credential that do not belong to the WACI flow
+//	}
+func (i *Issuer) AutoExecute(next chan service.DIDCommAction) func(chan service.DIDCommAction) {
+	return func(events chan service.DIDCommAction) {
+		for event := range events {
+			var (
+				arg interface{}
+				err error
+			)
+
+			switch event.Message.Type() {
+			case issuecredential.ProposeCredentialMsgTypeV3:
+				var offer *issuecredential.OfferCredentialParams
+
+				offer, err = i.Offer()
+				arg = issuecredential.WithOfferCredential(offer)
+			case issuecredential.RequestCredentialMsgTypeV3:
+				var issue *issuecredential.IssueCredentialParams
+
+				issue, err = i.Fulfill(event.Message)
+				arg = issuecredential.WithIssueCredential(issue)
+			default:
+				next <- event
+
+				continue
+			}
+
+			if errors.Is(err, ErrWACINotApplicable) {
+				next <- event
+
+				continue
+			}
+
+			if err != nil {
+				event.Stop(err)
+
+				continue
+			}
+
+			event.Continue(arg)
+		}
+	}
+}
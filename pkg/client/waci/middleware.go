@@ -0,0 +1,369 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package waci
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	mdissuecredential "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/middleware/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	storeverifiable "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	stateNameOfferReceived        = "offer-received"
+	stateNameRequestReceived      = "request-received"
+	stateNameCredentialReceived   = "credential-received"
+	stateNamePresentationReceived = "presentation-received"
+
+	myDIDKey    = "myDID"
+	theirDIDKey = "theirDID"
+	namesKey    = "names"
+)
+
+// RegisterMiddleware registers the issue-credential and present-proof Middleware in the services looked up from the
+// ServiceProvider.
+//
+// See also: NewIssueCredentialMiddleware, NewPresentProofMiddleware.
+func RegisterMiddleware(p Provider, sp ServiceProvider) error {
+	issueCredentialMiddleware, err := NewIssueCredentialMiddleware(p)
+	if err != nil {
+		return err
+	}
+
+	presentProofMiddleware, err := NewPresentProofMiddleware(p)
+	if err != nil {
+		return err
+	}
+
+	typelessSvc, err := sp.Service(issuecredential.Name)
+	if err != nil {
+		return fmt.Errorf("waci: failed to lookup issuecredential service: %w", err)
+	}
+
+	issueCredentialSvc, ok := typelessSvc.(IssueCredentialService)
+	if !ok {
+		return errors.New("waci: unable to cast the issuecredential service to the required interface type")
+	}
+
+	typelessSvc, err = sp.Service(presentproof.Name)
+	if err != nil {
+		return fmt.Errorf("waci: failed to lookup presentproof service: %w", err)
+	}
+
+	presentProofSvc, ok := typelessSvc.(PresentProofService)
+	if !ok {
+		return errors.New("waci: unable to cast the presentproof service to the required interface type")
+	}
+
+	issueCredentialSvc.AddMiddleware(issueCredentialMiddleware)
+	presentProofSvc.AddMiddleware(presentProofMiddleware)
+
+	return nil
+}
+
+// NewIssueCredentialMiddleware returns the issuecredential.Middleware validating the WACI issuance messages received
+// by both sides:
+//   - the Credential Manifest of offer-credential messages,
+//   - the proof, challenge and manifest of the Credential Application of request-credential messages,
+//   - the proof and application of the Credential Response of issue-credential messages, whose credentials are then
+//     verified and saved to the verifiable store in place of the default SaveCredentials middleware.
+//
+// Messages without WACI attachments are passed through untouched.
+func NewIssueCredentialMiddleware(p Provider) (issuecredential.Middleware, error) {
+	s, err := p.ProtocolStateStorageProvider().OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("waci: failed to open store: %w", err)
+	}
+
+	v := &validator{
+		store:          s,
+		vdr:            p.VDRegistry(),
+		documentLoader: p.JSONLDDocumentLoader(),
+		credentials:    p.VerifiableStore(),
+	}
+
+	return func(next issuecredential.Handler) issuecredential.Handler {
+		return issuecredential.HandlerFunc(func(md issuecredential.Metadata) error {
+			var err error
+
+			switch md.StateName() {
+			case stateNameOfferReceived:
+				err = v.validateOffer(md.Message())
+			case stateNameRequestReceived:
+				err = v.validateApplication(md.Message())
+			case stateNameCredentialReceived:
+				err = v.saveResponse(md)
+			}
+
+			if err != nil && !errors.Is(err, ErrWACINotApplicable) {
+				return fmt.Errorf("waci: %w", err)
+			}
+
+			return next.Handle(md)
+		})
+	}, nil
+}
+
+// NewPresentProofMiddleware returns the presentproof.Middleware validating the WACI presentation messages received
+// by both sides:
+//   - the presentation definition of request-presentation messages,
+//   - the proof, challenge and presentation definition match of the submission of presentation messages.
+//
+// Messages without WACI attachments are passed through untouched.
+func NewPresentProofMiddleware(p Provider) (presentproof.Middleware, error) {
+	s, err := p.ProtocolStateStorageProvider().OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("waci: failed to open store: %w", err)
+	}
+
+	v := &validator{
+		store:          s,
+		vdr:            p.VDRegistry(),
+		documentLoader: p.JSONLDDocumentLoader(),
+	}
+
+	return func(next presentproof.Handler) presentproof.Handler {
+		return presentproof.HandlerFunc(func(md presentproof.Metadata) error {
+			var err error
+
+			switch md.StateName() {
+			case stateNameRequestReceived:
+				err = v.validateDefinition(md.Message())
+			case stateNamePresentationReceived:
+				err = v.validateSubmission(md.Message())
+			}
+
+			if err != nil && !errors.Is(err, ErrWACINotApplicable) {
+				return fmt.Errorf("waci: %w", err)
+			}
+
+			return next.Handle(md)
+		})
+	}, nil
+}
+
+type validator struct {
+	store          storage.Store
+	vdr            vdrapi.Registry
+	documentLoader ld.DocumentLoader
+	credentials    storeverifiable.Store
+}
+
+func (v *validator) validateOffer(msg service.DIDCommMsg) error {
+	offer := &issuecredential.OfferCredentialParams{}
+
+	attachment, err := decodeAndFind(msg, offer, &offer.Attachments, cm.CredentialManifestAttachmentFormat)
+	if err != nil {
+		return err
+	}
+
+	payload := &ManifestPayload{}
+
+	err = decodeAttachment(attachment, payload)
+	if err != nil {
+		return fmt.Errorf("invalid credential manifest: %w", err)
+	}
+
+	if payload.CredentialManifest == nil {
+		return errors.New("offer is missing the credential manifest")
+	}
+
+	if payload.Options == nil || payload.Options.Challenge == "" {
+		return errors.New("offer is missing the challenge of the credential application")
+	}
+
+	return nil
+}
+
+func (v *validator) validateApplication(msg service.DIDCommMsg) error {
+	request := &issuecredential.RequestCredentialParams{}
+
+	attachment, err := decodeAndFind(msg, request, &request.Attachments, cm.CredentialApplicationAttachmentFormat)
+	if err != nil {
+		return err
+	}
+
+	application, err := parsePresentation(attachment, v.vdr, v.documentLoader)
+	if err != nil {
+		return fmt.Errorf("invalid credential application: %w", err)
+	}
+
+	r, err := checkChallenge(v.store, msg, application)
+	if err != nil {
+		return fmt.Errorf("invalid credential application: %w", err)
+	}
+
+	ca, err := credentialApplication(application)
+	if err != nil {
+		return fmt.Errorf("invalid credential application: %w", err)
+	}
+
+	if ca.ManifestID != r.ManifestID {
+		return fmt.Errorf("credential application was not offered for manifest '%s'", ca.ManifestID)
+	}
+
+	return nil
+}
+
+func (v *validator) saveResponse(md issuecredential.Metadata) error {
+	issue := &issuecredential.IssueCredentialParams{}
+
+	attachment, err := decodeAndFind(md.Message(), issue, &issue.Attachments, cm.CredentialResponseAttachmentFormat)
+	if err != nil {
+		return err
+	}
+
+	credentials, err := v.verifyResponse(attachment)
+	if err != nil {
+		return err
+	}
+
+	properties := md.Properties()
+
+	// nolint: errcheck
+	myDID, _ := properties[myDIDKey].(string)
+	// nolint: errcheck
+	theirDID, _ := properties[theirDIDKey].(string)
+
+	names := make([]string, len(credentials))
+
+	for i := range credentials {
+		names[i] = credentials[i].ID
+		if len(md.CredentialNames()) > i && md.CredentialNames()[i] != "" {
+			names[i] = md.CredentialNames()[i]
+		}
+
+		err = v.credentials.SaveCredential(names[i], &credentials[i],
+			storeverifiable.WithMyDID(myDID),
+			storeverifiable.WithTheirDID(theirDID),
+		)
+		if err != nil {
+			return fmt.Errorf("save credential: %w", err)
+		}
+	}
+
+	properties[mdissuecredential.SkipCredentialSaveKey] = true
+	properties[namesKey] = names
+
+	return nil
+}
+
+func (v *validator) verifyResponse(attachment *decorator.GenericAttachment) ([]verifiable.Credential, error) {
+	response, err := parsePresentation(attachment, v.vdr, v.documentLoader)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credential response: %w", err)
+	}
+
+	cr, err := credentialResponse(response)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credential response: %w", err)
+	}
+
+	r, err := fetchRecord(v.store, applicationKeyPrefix+cr.ApplicationID)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, fmt.Errorf("credential response answers unknown application '%s'", cr.ApplicationID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if r.ManifestID != cr.ManifestID {
+		return nil, fmt.Errorf("credential response was not issued for manifest '%s'", r.ManifestID)
+	}
+
+	raw, err := attachment.Data.Fetch()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch attachment contents: %w", err)
+	}
+
+	var document map[string]interface{}
+
+	err = json.Unmarshal(raw, &document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal credential response: %w", err)
+	}
+
+	credentials, err := cr.ResolveDescriptorMaps(document,
+		verifiable.WithPublicKeyFetcher(verifiable.NewVDRKeyResolver(v.vdr).PublicKeyFetcher()),
+		verifiable.WithJSONLDDocumentLoader(v.documentLoader),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credential response: %w", err)
+	}
+
+	err = v.store.Delete(applicationKeyPrefix + cr.ApplicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete record: %w", err)
+	}
+
+	return credentials, nil
+}
+
+func (v *validator) validateDefinition(msg service.DIDCommMsg) error {
+	request := &presentproof.RequestPresentationParams{}
+
+	attachment, err := decodeAndFind(msg, request, &request.Attachments, PresentationDefinitionFormat)
+	if err != nil {
+		return err
+	}
+
+	payload := &DefinitionPayload{}
+
+	err = decodeAttachment(attachment, payload)
+	if err != nil {
+		return fmt.Errorf("invalid presentation definition: %w", err)
+	}
+
+	if payload.PresentationDefinition == nil {
+		return errors.New("request is missing the presentation definition")
+	}
+
+	err = payload.PresentationDefinition.ValidateSchema()
+	if err != nil {
+		return fmt.Errorf("invalid presentation definition: %w", err)
+	}
+
+	return nil
+}
+
+func (v *validator) validateSubmission(msg service.DIDCommMsg) error {
+	presentation := &presentproof.PresentationParams{}
+
+	attachment, err := decodeAndFind(msg, presentation, &presentation.Attachments, PresentationSubmissionFormat)
+	if err != nil {
+		return err
+	}
+
+	_, err = verifySubmission(msg, attachment, v.store, v.vdr, v.documentLoader)
+
+	return err
+}
+
+// decodeAndFind decodes the message into params and finds the attachment with the given format among the
+// attachments it decoded.
+func decodeAndFind(msg service.DIDCommMsg, params interface{}, attachments *[]decorator.GenericAttachment,
+	format string) (*decorator.GenericAttachment, error) {
+	err := msg.Decode(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode message: %w", err)
+	}
+
+	return findAttachment(*attachments, format)
+}
@@ -0,0 +1,256 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package waci_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/waci"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+)
+
+func TestIssueCredentialMiddleware(t *testing.T) {
+	t.Run("rejects an offer without challenge", func(t *testing.T) {
+		var manifest cm.CredentialManifest
+
+		require.NoError(t, json.Unmarshal(credentialManifest, &manifest))
+
+		offer := &issuecredential.OfferCredentialParams{
+			Type: issuecredential.OfferCredentialMsgTypeV3,
+			Attachments: []decorator.GenericAttachment{{
+				Format: cm.CredentialManifestAttachmentFormat,
+				Data: decorator.AttachmentData{
+					JSON: &waci.ManifestPayload{CredentialManifest: &manifest},
+				},
+			}},
+		}
+
+		err := handle(newIssueCredentialMiddleware(t, newAgent(t)), &mockMetadata{
+			msg:   service.NewDIDCommMsgMap(offer.AsV3()),
+			state: "offer-received",
+		})
+		require.EqualError(t, err, "waci: offer is missing the challenge of the credential application")
+	})
+
+	t.Run("rejects an unsigned credential response", func(t *testing.T) {
+		response, err := verifiable.NewPresentation()
+		require.NoError(t, err)
+
+		issue := &issuecredential.IssueCredentialParams{
+			Type: issuecredential.IssueCredentialMsgTypeV3,
+			Attachments: []decorator.GenericAttachment{{
+				Format: cm.CredentialResponseAttachmentFormat,
+				Data: decorator.AttachmentData{
+					JSON: response,
+				},
+			}},
+		}
+
+		err = handle(newIssueCredentialMiddleware(t, newAgent(t)), &mockMetadata{
+			msg:   service.NewDIDCommMsgMap(issue.AsV3()),
+			state: "credential-received",
+		})
+		require.ErrorContains(t, err, "invalid credential response")
+	})
+
+	t.Run("forwards to next in other states", func(t *testing.T) {
+		err := handle(newIssueCredentialMiddleware(t, newAgent(t)), &mockMetadata{
+			msg:   service.DIDCommMsgMap{"type": issuecredential.ProposeCredentialMsgTypeV3},
+			state: "proposal-received",
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestRegisterMiddleware(t *testing.T) {
+	t.Run("registers middleware", func(t *testing.T) {
+		var issueCredentialMW, presentProofMW int
+
+		sp := &mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				issuecredential.Name: &mockIssueCredentialSvc{
+					addMWFunc: func(middleware ...issuecredential.Middleware) {
+						issueCredentialMW += len(middleware)
+					},
+				},
+				presentproof.Name: &mockPresentProofSvc{
+					addMWFunc: func(middleware ...presentproof.Middleware) {
+						presentProofMW += len(middleware)
+					},
+				},
+			},
+		}
+
+		err := waci.RegisterMiddleware(newAgent(t), sp)
+		require.NoError(t, err)
+		require.Equal(t, 1, issueCredentialMW)
+		require.Equal(t, 1, presentProofMW)
+	})
+
+	t.Run("error if cannot lookup service", func(t *testing.T) {
+		expected := errors.New("test")
+
+		err := waci.RegisterMiddleware(newAgent(t), &mockprovider.Provider{ServiceErr: expected})
+		require.ErrorIs(t, err, expected)
+	})
+
+	t.Run("error if cannot cast service to API dependency", func(t *testing.T) {
+		sp := &mockprovider.Provider{
+			ServiceMap: map[string]interface{}{
+				issuecredential.Name: &mockIssueCredentialSvc{},
+				presentproof.Name:    struct{}{},
+			},
+		}
+
+		err := waci.RegisterMiddleware(newAgent(t), sp)
+		require.EqualError(t, err,
+			"waci: unable to cast the presentproof service to the required interface type")
+	})
+}
+
+func newIssueCredentialMiddleware(t *testing.T, a *agent) issuecredential.Middleware {
+	t.Helper()
+
+	mw, err := waci.NewIssueCredentialMiddleware(a)
+	require.NoError(t, err)
+
+	return mw
+}
+
+func newPresentProofMiddleware(t *testing.T, a *agent) presentproof.Middleware {
+	t.Helper()
+
+	mw, err := waci.NewPresentProofMiddleware(a)
+	require.NoError(t, err)
+
+	return mw
+}
+
+func handle(mw issuecredential.Middleware, md issuecredential.Metadata) error {
+	return mw(issuecredential.HandlerFunc(func(issuecredential.Metadata) error {
+		return nil
+	})).Handle(md)
+}
+
+func handlePresentProof(mw presentproof.Middleware, md presentproof.Metadata) error {
+	return mw(presentproof.HandlerFunc(func(presentproof.Metadata) error {
+		return nil
+	})).Handle(md)
+}
+
+type mockMetadata struct {
+	msg        service.DIDCommMsg
+	state      string
+	properties map[string]interface{}
+}
+
+func (m *mockMetadata) Message() service.DIDCommMsg {
+	return m.msg
+}
+
+func (m *mockMetadata) OfferCredentialV2() *issuecredential.OfferCredentialV2 {
+	panic("implement me")
+}
+
+func (m *mockMetadata) ProposeCredentialV2() *issuecredential.ProposeCredentialV2 {
+	panic("implement me")
+}
+
+func (m *mockMetadata) IssueCredentialV2() *issuecredential.IssueCredentialV2 {
+	panic("implement me")
+}
+
+func (m *mockMetadata) RequestCredentialV2() *issuecredential.RequestCredentialV2 {
+	panic("implement me")
+}
+
+func (m *mockMetadata) CredentialNames() []string {
+	return nil
+}
+
+func (m *mockMetadata) StateName() string {
+	return m.state
+}
+
+func (m *mockMetadata) Properties() map[string]interface{} {
+	return m.properties
+}
+
+type mockPresentProofMetadata struct {
+	msg   service.DIDCommMsg
+	state string
+}
+
+func (m *mockPresentProofMetadata) Message() service.DIDCommMsg {
+	return m.msg
+}
+
+func (m *mockPresentProofMetadata) Presentation() *presentproof.PresentationV2 {
+	panic("implement me")
+}
+
+func (m *mockPresentProofMetadata) ProposePresentation() *presentproof.ProposePresentationV2 {
+	panic("implement me")
+}
+
+func (m *mockPresentProofMetadata) RequestPresentation() *presentproof.RequestPresentationV2 {
+	panic("implement me")
+}
+
+func (m *mockPresentProofMetadata) PresentationV3() *presentproof.PresentationV3 {
+	panic("implement me")
+}
+
+func (m *mockPresentProofMetadata) ProposePresentationV3() *presentproof.ProposePresentationV3 {
+	panic("implement me")
+}
+
+func (m *mockPresentProofMetadata) RequestPresentationV3() *presentproof.RequestPresentationV3 {
+	panic("implement me")
+}
+
+func (m *mockPresentProofMetadata) PresentationNames() []string {
+	return nil
+}
+
+func (m *mockPresentProofMetadata) StateName() string {
+	return m.state
+}
+
+func (m *mockPresentProofMetadata) Properties() map[string]interface{} {
+	return nil
+}
+
+func (m *mockPresentProofMetadata) GetAddProofFn() func(presentation *verifiable.Presentation) error {
+	return nil
+}
+
+type mockIssueCredentialSvc struct {
+	addMWFunc func(...issuecredential.Middleware)
+}
+
+func (m *mockIssueCredentialSvc) AddMiddleware(middleware ...issuecredential.Middleware) {
+	m.addMWFunc(middleware...)
+}
+
+type mockPresentProofSvc struct {
+	addMWFunc func(...presentproof.Middleware)
+}
+
+func (m *mockPresentProofSvc) AddMiddleware(middleware ...presentproof.Middleware) {
+	m.addMWFunc(middleware...)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package waci
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+// Verifier runs the verifier side of the WACI presentation flow for a presentation definition.
+type Verifier struct {
+	definition     *presexch.PresentationDefinition
+	domain         string
+	vdr            vdrapi.Registry
+	documentLoader ld.DocumentLoader
+	store          storage.Store
+}
+
+// NewVerifier returns a new Verifier requesting presentations satisfying the given presentation definition, proven
+// for the given domain.
+func NewVerifier(p Provider, definition *presexch.PresentationDefinition, domain string) (*Verifier, error) {
+	if definition == nil {
+		return nil, errors.New("waci: presentation definition argument cannot be nil")
+	}
+
+	err := definition.ValidateSchema()
+	if err != nil {
+		return nil, fmt.Errorf("waci: invalid presentation definition: %w", err)
+	}
+
+	s, err := p.ProtocolStateStorageProvider().OpenStore(StoreName)
+	if err != nil {
+		return nil, fmt.Errorf("waci: failed to open store: %w", err)
+	}
+
+	return &Verifier{
+		definition:     definition,
+		domain:         domain,
+		vdr:            p.VDRegistry(),
+		documentLoader: p.JSONLDDocumentLoader(),
+		store:          s,
+	}, nil
+}
+
+// RequestPresentation returns the request-presentation message holding the presentation definition, with a new
+// challenge the presentation has to be proven with.
+func (v *Verifier) RequestPresentation() (*presentproof.RequestPresentationParams, error) {
+	challenge := uuid.New().String()
+
+	err := saveRecord(v.store, challengeKeyPrefix+challenge, &record{
+		Domain:                 v.domain,
+		PresentationDefinition: v.definition,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("waci: %w", err)
+	}
+
+	return &presentproof.RequestPresentationParams{
+		GoalCode: PresentationGoalCode,
+		Attachments: []decorator.GenericAttachment{
+			jsonAttachment(PresentationDefinitionFormat, mediaTypeJSON, &DefinitionPayload{
+				Challenge:              challenge,
+				Domain:                 v.domain,
+				PresentationDefinition: v.definition,
+			}),
+		},
+	}, nil
+}
+
+// Verify checks the presentation attached to the given presentation message against the presentation definition
+// it was requested with, and returns the credentials matched with each input descriptor.
+// It returns ErrWACINotApplicable if the message does not hold a presentation submission.
+func (v *Verifier) Verify(msg service.DIDCommMsg) (map[string]presexch.MatchValue, error) {
+	presentation := &presentproof.PresentationParams{}
+
+	err := msg.Decode(presentation)
+	if err != nil {
+		return nil, fmt.Errorf("waci: failed to decode presentation message: %w", err)
+	}
+
+	attachment, err := findAttachment(presentation.Attachments, PresentationSubmissionFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	matched, err := verifySubmission(msg, attachment, v.store, v.vdr, v.documentLoader)
+	if err != nil {
+		return nil, fmt.Errorf("waci: %w", err)
+	}
+
+	return matched, nil
+}
+
+// AutoExecute answers the propose-presentation messages with RequestPresentation and accepts the presentation
+// messages holding a presentation submission that Verify accepts. Other actions are passed through to 'next'.
+//
+// Usage:
+//
+//	client := presentproof.Client = ...
+//	events = make(chan service.DIDCommAction)
+//	err := client.RegisterActionEvent(events)
+//	if err != nil {
+//	    panic(err)
+//	}
+//	next := make(chan service.DIDCommAction)
+//	go verifier.AutoExecute(next)(events)
+//	for event := range next {
+//	    // handle events from present-proof that do not belong to the WACI flow
+//	}
+func (v *Verifier) AutoExecute(next chan service.DIDCommAction) func(chan service.DIDCommAction) {
+	return func(events chan service.DIDCommAction) {
+		for event := range events {
+			var (
+				arg interface{}
+				err error
+			)
+
+			switch event.Message.Type() {
+			case presentproof.ProposePresentationMsgTypeV3:
+				var request *presentproof.RequestPresentationParams
+
+				request, err = v.RequestPresentation()
+				arg = presentproof.WithRequestPresentation(request)
+			case presentproof.PresentationMsgTypeV3:
+				_, err = v.Verify(event.Message)
+			default:
+				next <- event
+
+				continue
+			}
+
+			if errors.Is(err, ErrWACINotApplicable) {
+				next <- event
+
+				continue
+			}
+
+			if err != nil {
+				event.Stop(err)
+
+				continue
+			}
+
+			event.Continue(arg)
+		}
+	}
+}
+
+// verifySubmission checks the proofs of the presentation attached to msg and matches it against the presentation
+// definition saved for its challenge.
+func verifySubmission(msg service.DIDCommMsg, attachment *decorator.GenericAttachment, s storage.Store,
+	vdr vdrapi.Registry, documentLoader ld.DocumentLoader) (map[string]presexch.MatchValue, error) {
+	vp, err := parsePresentation(attachment, vdr, documentLoader)
+	if err != nil {
+		return nil, fmt.Errorf("invalid presentation submission: %w", err)
+	}
+
+	r, err := checkChallenge(s, msg, vp)
+	if err != nil {
+		return nil, fmt.Errorf("invalid presentation submission: %w", err)
+	}
+
+	if r.PresentationDefinition == nil {
+		return nil, errors.New("invalid presentation submission: no presentation definition was requested")
+	}
+
+	fetcher := verifiable.NewVDRKeyResolver(vdr).PublicKeyFetcher()
+
+	matched, err := r.PresentationDefinition.Match([]*verifiable.Presentation{vp}, documentLoader,
+		presexch.WithCredentialOptions(
+			verifiable.WithPublicKeyFetcher(fetcher),
+			verifiable.WithJSONLDDocumentLoader(documentLoader),
+		))
+	if err != nil {
+		return nil, fmt.Errorf("presentation does not satisfy presentation definition: %w", err)
+	}
+
+	return matched, nil
+}
@@ -0,0 +1,394 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package waci runs the Wallet And Credential Interactions (WACI) DIDComm v2 issuance and presentation flows on top
+// of the issue-credential and present-proof protocol services.
+//
+// Issuance follows https://identity.foundation/waci-presentation-exchange/#issuance-2: the holder answers an
+// out-of-band invitation with a propose-credential message, the Issuer offers a Credential Manifest, the Holder
+// applies with a Credential Application and the Issuer fulfills it with a Credential Response.
+//
+// Presentation follows https://identity.foundation/waci-presentation-exchange/#presentation-2: the Verifier requests
+// a presentation with a presentation definition and the Holder submits a presentation satisfying it.
+//
+// Issuer, Holder and Verifier build and evaluate the messages of each side, and their AutoExecute functions answer
+// the protocol actions with them. The Middleware returned by NewIssueCredentialMiddleware and
+// NewPresentProofMiddleware validates every WACI message handled by the protocol services.
+package waci
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/outofbandv2"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	oobv2 "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofbandv2"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm/issuer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/kmssigner"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/spi/storage"
+)
+
+const (
+	// IssuanceGoalCode is the goal code of out-of-band invitations starting the WACI issuance flow.
+	IssuanceGoalCode = "streamlined-vc"
+	// PresentationGoalCode is the goal code of out-of-band invitations starting the WACI presentation flow.
+	PresentationGoalCode = "streamlined-vp"
+	// PresentationDefinitionFormat is the attachment format of the request-presentation message.
+	PresentationDefinitionFormat = "dif/presentation-exchange/definitions@v1.0"
+	// PresentationSubmissionFormat is the attachment format of the presentation message.
+	PresentationSubmissionFormat = "dif/presentation-exchange/submission@v1.0"
+	// StoreName is the name of the transient store holding the state of the WACI flows.
+	StoreName = "WACITransientStore"
+
+	didCommV2       = "didcomm/v2"
+	mediaTypeJSON   = "application/json"
+	mediaTypeJSONLD = "application/ld+json"
+
+	jsonWebSignature2020Context = "https://w3id.org/security/suites/jws-2020/v1"
+
+	credentialApplicationField = "credential_application"
+	credentialResponseField    = "credential_response"
+	submissionField            = "presentation_submission"
+
+	authentication = "authentication"
+
+	challengeKeyPrefix   = "challenge_"
+	applicationKeyPrefix = "application_"
+)
+
+// challengeMutex serializes the consumption of the challenges saved in the transient store.
+var challengeMutex sync.Mutex //nolint:gochecknoglobals
+
+// ErrWACINotApplicable indicates WACI does not apply to the message being handled because it does not contain an
+// attachment with the WACI formats.
+var ErrWACINotApplicable = errors.New("WACI is not applicable")
+
+// ManifestPayload is the attachment payload of the offer-credential message.
+type ManifestPayload struct {
+	Options            *Options               `json:"options"`
+	CredentialManifest *cm.CredentialManifest `json:"credential_manifest"`
+}
+
+// Options holds the challenge and domain the Credential Application is proven with.
+type Options struct {
+	Challenge string `json:"challenge"`
+	Domain    string `json:"domain"`
+}
+
+// DefinitionPayload is the attachment payload of the request-presentation message.
+type DefinitionPayload struct {
+	Challenge              string                           `json:"challenge"`
+	Domain                 string                           `json:"domain"`
+	PresentationDefinition *presexch.PresentationDefinition `json:"presentation_definition"`
+}
+
+// InvitationCreator creates out-of-band v2 invitations.
+//
+// See also: outofbandv2.Client.
+type InvitationCreator interface {
+	CreateInvitation(opts ...outofbandv2.MessageOption) (*oobv2.Invitation, error)
+}
+
+// CreateInvitation creates an out-of-band invitation with the given WACI goal code, IssuanceGoalCode or
+// PresentationGoalCode, accepting DIDComm v2.
+func CreateInvitation(c InvitationCreator, goalCode string,
+	opts ...outofbandv2.MessageOption) (*oobv2.Invitation, error) {
+	opts = append(opts, outofbandv2.WithGoal("", goalCode), outofbandv2.WithAccept(didCommV2))
+
+	invitation, err := c.CreateInvitation(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("waci: failed to create invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// ProposeCredential returns the propose-credential message answering a WACI issuance invitation.
+func ProposeCredential(invitation *oobv2.Invitation) *issuecredential.ProposeCredentialParams {
+	return &issuecredential.ProposeCredentialParams{
+		Type:         issuecredential.ProposeCredentialMsgTypeV3,
+		InvitationID: invitation.ID,
+		GoalCode:     IssuanceGoalCode,
+	}
+}
+
+// ProposePresentation returns the propose-presentation message answering a WACI presentation invitation.
+func ProposePresentation() *presentproof.ProposePresentationParams {
+	return &presentproof.ProposePresentationParams{
+		GoalCode: PresentationGoalCode,
+	}
+}
+
+// record is the state of a flow saved in the transient store.
+type record struct {
+	Domain                 string                           `json:"domain,omitempty"`
+	ManifestID             string                           `json:"manifest_id,omitempty"`
+	PresentationDefinition *presexch.PresentationDefinition `json:"presentation_definition,omitempty"`
+	ThreadID               string                           `json:"thread_id,omitempty"`
+}
+
+func saveRecord(s storage.Store, key string, r *record) error {
+	raw, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to marshal record: %w", err)
+	}
+
+	err = s.Put(key, raw)
+	if err != nil {
+		return fmt.Errorf("failed to save record: %w", err)
+	}
+
+	return nil
+}
+
+func fetchRecord(s storage.Store, key string) (*record, error) {
+	raw, err := s.Get(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch record: %w", err)
+	}
+
+	r := &record{}
+
+	err = json.Unmarshal(raw, r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal record: %w", err)
+	}
+
+	return r, nil
+}
+
+func findAttachment(attachments []decorator.GenericAttachment, format string) (*decorator.GenericAttachment, error) {
+	for i := range attachments {
+		if attachments[i].Format == format {
+			return &attachments[i], nil
+		}
+	}
+
+	return nil, ErrWACINotApplicable
+}
+
+func jsonAttachment(format, mediaType string, payload interface{}) decorator.GenericAttachment {
+	return decorator.GenericAttachment{
+		ID:        uuid.New().String(),
+		MediaType: mediaType,
+		Format:    format,
+		Data: decorator.AttachmentData{
+			JSON: payload,
+		},
+	}
+}
+
+func decodeAttachment(attachment *decorator.GenericAttachment, v interface{}) error {
+	raw, err := attachment.Data.Fetch()
+	if err != nil {
+		return fmt.Errorf("failed to fetch attachment contents: %w", err)
+	}
+
+	err = json.Unmarshal(raw, v)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal attachment contents: %w", err)
+	}
+
+	return nil
+}
+
+// parsePresentation parses the presentation attached with the given attachment, checking its proofs and the proofs
+// of its credentials with the keys resolved through the VDR.
+func parsePresentation(attachment *decorator.GenericAttachment, vdr vdrapi.Registry,
+	documentLoader ld.DocumentLoader) (*verifiable.Presentation, error) {
+	raw, err := attachment.Data.Fetch()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch attachment contents: %w", err)
+	}
+
+	fetcher := verifiable.NewVDRKeyResolver(vdr).PublicKeyFetcher()
+
+	vp, err := verifiable.ParsePresentation(raw,
+		verifiable.WithPresPublicKeyFetcher(fetcher),
+		verifiable.WithPresJSONLDDocumentLoader(documentLoader),
+		verifiable.WithPresCredentialOpts(
+			verifiable.WithPublicKeyFetcher(fetcher),
+			verifiable.WithJSONLDDocumentLoader(documentLoader),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse presentation: %w", err)
+	}
+
+	return vp, nil
+}
+
+// proofOptions returns the challenge and domain of the proof of the given presentation.
+func proofOptions(vp *verifiable.Presentation) (*Options, error) {
+	if len(vp.Proofs) == 0 {
+		return nil, errors.New("presentation is not signed")
+	}
+
+	challenge, ok := vp.Proofs[0]["challenge"].(string)
+	if !ok || challenge == "" {
+		return nil, errors.New("presentation proof is missing the challenge")
+	}
+
+	// nolint: errcheck
+	domain, _ := vp.Proofs[0]["domain"].(string)
+
+	return &Options{Challenge: challenge, Domain: domain}, nil
+}
+
+// checkChallenge looks up the record saved for the challenge of the presentation proof, checks its domain and
+// consumes the challenge: the first presentation accepted binds it to the thread of msg, so that a presentation
+// proven with it is rejected in any other thread. The handlers and middleware of that thread can still check it.
+func checkChallenge(s storage.Store, msg service.DIDCommMsg, vp *verifiable.Presentation) (*record, error) {
+	options, err := proofOptions(vp)
+	if err != nil {
+		return nil, err
+	}
+
+	threadID, err := msg.ThreadID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thread ID: %w", err)
+	}
+
+	challengeMutex.Lock()
+	defer challengeMutex.Unlock()
+
+	r, err := fetchRecord(s, challengeKeyPrefix+options.Challenge)
+	if errors.Is(err, storage.ErrDataNotFound) {
+		return nil, fmt.Errorf("unknown challenge '%s'", options.Challenge)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Domain != options.Domain {
+		return nil, fmt.Errorf("unexpected domain '%s'", options.Domain)
+	}
+
+	if r.ThreadID == threadID {
+		return r, nil
+	}
+
+	if r.ThreadID != "" {
+		return nil, fmt.Errorf("challenge '%s' was already used", options.Challenge)
+	}
+
+	r.ThreadID = threadID
+
+	err = saveRecord(s, challengeKeyPrefix+options.Challenge, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func credentialApplication(vp *verifiable.Presentation) (*cm.CredentialApplication, error) {
+	ca := &cm.CredentialApplication{}
+
+	err := decodeField(vp, credentialApplicationField, ca)
+	if err != nil {
+		return nil, err
+	}
+
+	return ca, nil
+}
+
+func credentialResponse(vp *verifiable.Presentation) (*cm.CredentialResponse, error) {
+	cr := &cm.CredentialResponse{}
+
+	err := decodeField(vp, credentialResponseField, cr)
+	if err != nil {
+		return nil, err
+	}
+
+	return cr, nil
+}
+
+func decodeField(vp *verifiable.Presentation, field string, v interface{}) error {
+	raw, ok := vp.CustomFields[field]
+	if !ok {
+		return fmt.Errorf("presentation is missing '%s'", field)
+	}
+
+	bits, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("failed to marshal '%s': %w", field, err)
+	}
+
+	err = json.Unmarshal(bits, v)
+	if err != nil {
+		return fmt.Errorf("invalid '%s': %w", field, err)
+	}
+
+	return nil
+}
+
+// presentationSigner signs presentations with a key of the KMS.
+type presentationSigner struct {
+	km                 kms.KeyManager
+	crypto             crypto.Crypto
+	documentLoader     ld.DocumentLoader
+	keyID              string
+	verificationMethod string
+	signatureType      string
+}
+
+func (s *presentationSigner) sign(vp *verifiable.Presentation, challenge, domain string) error {
+	kh, err := s.km.Get(s.keyID)
+	if err != nil {
+		return fmt.Errorf("failed to get signing key: %w", err)
+	}
+
+	_, kt, err := s.km.ExportPubKeyBytes(s.keyID)
+	if err != nil {
+		return fmt.Errorf("failed to export signing key: %w", err)
+	}
+
+	ks := &kmssigner.KMSSigner{KeyType: kt, KeyHandle: kh, Crypto: s.crypto}
+
+	var signatureSuite signer.SignatureSuite
+
+	switch s.signatureType {
+	case issuer.Ed25519Signature2018:
+		signatureSuite = ed25519signature2018.New(suite.WithSigner(ks))
+	case issuer.JSONWebSignature2020:
+		vp.Context = append(vp.Context, jsonWebSignature2020Context)
+
+		signatureSuite = jsonwebsignature2020.New(suite.WithSigner(ks))
+	default:
+		return fmt.Errorf("unsupported signature type '%s'", s.signatureType)
+	}
+
+	return vp.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+		SignatureType:           s.signatureType,
+		SignatureRepresentation: verifiable.SignatureJWS,
+		Suite:                   signatureSuite,
+		VerificationMethod:      s.verificationMethod,
+		Purpose:                 authentication,
+		Challenge:               challenge,
+		Domain:                  domain,
+	}, jsonld.WithDocumentLoader(s.documentLoader))
+}
@@ -0,0 +1,607 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package waci_test
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/client/outofbandv2"
+	"github.com/hyperledger/aries-framework-go/pkg/client/waci"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/common/service"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/decorator"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/issuecredential"
+	oobv2 "github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/outofbandv2"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/protocol/presentproof"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm/issuer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	storeverifiable "github.com/hyperledger/aries-framework-go/pkg/store/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
+)
+
+const (
+	outputDescriptorID = "residency_output"
+	citizenshipContext = "https://w3id.org/citizenship/v1"
+	verifierDomain     = "https://verifier.example.com"
+)
+
+var (
+	//go:embed testdata/credential_manifest.json
+	credentialManifest []byte //nolint:gochecknoglobals
+	//go:embed testdata/presentation_definition.json
+	presentationDefinition []byte //nolint:gochecknoglobals
+)
+
+func TestIssuance(t *testing.T) {
+	t.Run("issues credential end to end", func(t *testing.T) {
+		issuerAgent, holderAgent := newAgent(t), newAgent(t)
+		holderAgent.holdPRC(t, newAgent(t))
+
+		i := newIssuer(t, issuerAgent)
+		h := newHolder(t, holderAgent)
+
+		issuerEvents, holderEvents := make(chan service.DIDCommAction), make(chan service.DIDCommAction)
+		go i.AutoExecute(nil)(issuerEvents)
+		go h.AutoExecute(nil)(holderEvents)
+
+		holderMW := newIssueCredentialMiddleware(t, holderAgent)
+		issuerMW := newIssueCredentialMiddleware(t, issuerAgent)
+
+		invitation := &oobv2.Invitation{ID: uuid.New().String()}
+		proposal := waci.ProposeCredential(invitation)
+		require.Equal(t, invitation.ID, proposal.InvitationID)
+
+		arg := sendAction(t, issuerEvents, issuecredential.Name, service.NewDIDCommMsgMap(proposal.AsV3()))
+		offer := issueCredentialMetadata(t, arg).OfferCredentialV3()
+		require.NotNil(t, offer)
+		require.Equal(t, waci.IssuanceGoalCode, offer.Body.GoalCode)
+
+		offerMsg := service.NewDIDCommMsgMap(offer)
+		require.NoError(t, handle(holderMW, &mockMetadata{msg: offerMsg, state: "offer-received"}))
+
+		arg = sendAction(t, holderEvents, issuecredential.Name, offerMsg)
+		request := issueCredentialMetadata(t, arg).RequestCredentialV3()
+		require.NotNil(t, request)
+
+		requestMsg := service.NewDIDCommMsgMap(request)
+		require.NoError(t, handle(issuerMW, &mockMetadata{msg: requestMsg, state: "request-received"}))
+
+		arg = sendAction(t, issuerEvents, issuecredential.Name, requestMsg)
+		issue := issueCredentialMetadata(t, arg).IssueCredentialV3()
+		require.NotNil(t, issue)
+		require.Len(t, issue.Attachments, 1)
+		require.Equal(t, cm.CredentialResponseAttachmentFormat, issue.Attachments[0].Format)
+
+		issueMsg := service.NewDIDCommMsgMap(issue)
+
+		require.Nil(t, sendAction(t, holderEvents, issuecredential.Name, issueMsg))
+
+		md := &mockMetadata{
+			msg:   issueMsg,
+			state: "credential-received",
+			properties: map[string]interface{}{
+				"myDID":    "did:example:holder",
+				"theirDID": "did:example:issuer",
+			},
+		}
+		require.NoError(t, handle(holderMW, md))
+		require.Equal(t, true, md.properties["skip-credential-save"])
+
+		names, ok := md.properties["names"].([]string)
+		require.True(t, ok)
+		require.Len(t, names, 1)
+
+		id, err := holderAgent.store.GetCredentialIDByName(names[0])
+		require.NoError(t, err)
+
+		vc, err := holderAgent.store.GetCredential(id)
+		require.NoError(t, err)
+		require.Equal(t, []string{"VerifiableCredential", "PermanentResidentCard"}, vc.Types)
+		require.Equal(t, "did:example:issuer", vc.Issuer.ID)
+
+		subjects, ok := vc.Subject.([]verifiable.Subject)
+		require.True(t, ok)
+		require.Equal(t, holderAgent.did, subjects[0].ID)
+		require.Equal(t, "Louis", subjects[0].CustomFields["givenName"])
+
+		t.Run("rejects a replayed credential application", func(t *testing.T) {
+			replayMsg := service.NewDIDCommMsgMap(request)
+			replayMsg.SetID(uuid.New().String())
+
+			_, err = i.Fulfill(replayMsg)
+			require.ErrorContains(t, err, "was already used")
+
+			err = handle(issuerMW, &mockMetadata{msg: replayMsg, state: "request-received"})
+			require.ErrorContains(t, err, "was already used")
+		})
+
+		t.Run("rejects a replayed credential response", func(t *testing.T) {
+			err = handle(holderMW, &mockMetadata{msg: issueMsg, state: "credential-received",
+				properties: map[string]interface{}{}})
+			require.ErrorContains(t, err, "credential response answers unknown application")
+		})
+	})
+
+	t.Run("holder stops when it cannot satisfy the manifest", func(t *testing.T) {
+		i := newIssuer(t, newAgent(t))
+		h := newHolder(t, newAgent(t))
+
+		offer, err := i.Offer()
+		require.NoError(t, err)
+
+		_, err = h.Apply(service.NewDIDCommMsgMap(offer.AsV3()))
+		require.ErrorContains(t, err, "failed to create presentation")
+	})
+
+	t.Run("issuer rejects an unknown challenge", func(t *testing.T) {
+		issuerAgent, holderAgent := newAgent(t), newAgent(t)
+		holderAgent.holdPRC(t, newAgent(t))
+
+		i := newIssuer(t, issuerAgent)
+		h := newHolder(t, holderAgent)
+
+		offer, err := newIssuer(t, newAgent(t)).Offer()
+		require.NoError(t, err)
+
+		request, err := h.Apply(service.NewDIDCommMsgMap(offer.AsV3()))
+		require.NoError(t, err)
+
+		_, err = i.Fulfill(service.NewDIDCommMsgMap(request.AsV3()))
+		require.ErrorContains(t, err, "unknown challenge")
+
+		err = handle(newIssueCredentialMiddleware(t, issuerAgent), &mockMetadata{
+			msg:   service.NewDIDCommMsgMap(request.AsV3()),
+			state: "request-received",
+		})
+		require.ErrorContains(t, err, "unknown challenge")
+	})
+
+	t.Run("issuer rejects a tampered application", func(t *testing.T) {
+		issuerAgent, holderAgent := newAgent(t), newAgent(t)
+		holderAgent.holdPRC(t, newAgent(t))
+
+		i := newIssuer(t, issuerAgent)
+		h := newHolder(t, holderAgent)
+
+		offer, err := i.Offer()
+		require.NoError(t, err)
+
+		request, err := h.Apply(service.NewDIDCommMsgMap(offer.AsV3()))
+		require.NoError(t, err)
+
+		application, ok := request.Attachments[0].Data.JSON.(*verifiable.Presentation)
+		require.True(t, ok)
+
+		application.Proofs[0]["created"] = "2006-01-02T15:04:05Z"
+
+		_, err = i.Fulfill(service.NewDIDCommMsgMap(request.AsV3()))
+		require.ErrorContains(t, err, "invalid credential application")
+	})
+
+	t.Run("passes through messages without WACI attachments", func(t *testing.T) {
+		i := newIssuer(t, newAgent(t))
+		h := newHolder(t, newAgent(t))
+
+		issuerEvents, holderEvents := make(chan service.DIDCommAction), make(chan service.DIDCommAction)
+		issuerNext, holderNext := make(chan service.DIDCommAction), make(chan service.DIDCommAction)
+
+		go i.AutoExecute(issuerNext)(issuerEvents)
+		go h.AutoExecute(holderNext)(holderEvents)
+
+		request := service.NewDIDCommMsgMap(&issuecredential.RequestCredentialV3{
+			Type: issuecredential.RequestCredentialMsgTypeV3,
+		})
+		offer := service.NewDIDCommMsgMap(&issuecredential.OfferCredentialV3{
+			Type: issuecredential.OfferCredentialMsgTypeV3,
+		})
+		ack := service.DIDCommMsgMap{"type": issuecredential.AckMsgTypeV3}
+
+		go func() { issuerEvents <- service.DIDCommAction{Message: request} }()
+		require.Equal(t, request, receive(t, issuerNext).Message)
+
+		go func() { issuerEvents <- service.DIDCommAction{Message: ack} }()
+		require.Equal(t, ack, receive(t, issuerNext).Message)
+
+		go func() { holderEvents <- service.DIDCommAction{Message: offer} }()
+		require.Equal(t, offer, receive(t, holderNext).Message)
+
+		require.NoError(t, handle(newIssueCredentialMiddleware(t, newAgent(t)),
+			&mockMetadata{msg: offer, state: "offer-received"}))
+	})
+
+	t.Run("error if the manifest is invalid", func(t *testing.T) {
+		_, err := waci.NewIssuer(newAgent(t), nil)
+		require.ErrorContains(t, err, "credential manifest argument cannot be nil")
+	})
+
+	t.Run("error if the holder has no signing key", func(t *testing.T) {
+		_, err := waci.NewHolder(newAgent(t))
+		require.EqualError(t, err, "waci: missing signing key")
+	})
+}
+
+func TestPresentation(t *testing.T) {
+	t.Run("presents credential end to end", func(t *testing.T) {
+		verifierAgent, holderAgent := newAgent(t), newAgent(t)
+		holderAgent.holdPRC(t, newAgent(t))
+
+		v := newVerifier(t, verifierAgent)
+		h := newHolder(t, holderAgent)
+
+		verifierEvents, holderEvents := make(chan service.DIDCommAction), make(chan service.DIDCommAction)
+		go v.AutoExecute(nil)(verifierEvents)
+		go h.AutoExecute(nil)(holderEvents)
+
+		proposal := waci.ProposePresentation()
+		require.Equal(t, waci.PresentationGoalCode, proposal.GoalCode)
+
+		arg := sendAction(t, verifierEvents, presentproof.Name, service.NewDIDCommMsgMap(proposal.AsV3()))
+		_, ok := arg.(presentproof.Opt)
+		require.True(t, ok)
+
+		request, err := v.RequestPresentation()
+		require.NoError(t, err)
+
+		requestMsg := service.NewDIDCommMsgMap(request.AsV3())
+
+		holderMW := newPresentProofMiddleware(t, holderAgent)
+		require.NoError(t, handlePresentProof(holderMW, &mockPresentProofMetadata{
+			msg: requestMsg, state: "request-received",
+		}))
+
+		arg = sendAction(t, holderEvents, presentproof.Name, requestMsg)
+		_, ok = arg.(presentproof.Opt)
+		require.True(t, ok)
+
+		presentation, err := h.Present(requestMsg)
+		require.NoError(t, err)
+
+		presentationMsg := service.NewDIDCommMsgMap(presentation.AsV3())
+
+		verifierMW := newPresentProofMiddleware(t, verifierAgent)
+		require.NoError(t, handlePresentProof(verifierMW, &mockPresentProofMetadata{
+			msg: presentationMsg, state: "presentation-received",
+		}))
+
+		require.Nil(t, sendAction(t, verifierEvents, presentproof.Name, presentationMsg))
+
+		matched, err := v.Verify(presentationMsg)
+		require.NoError(t, err)
+		require.Contains(t, matched, "prc_input")
+		require.Equal(t, "Louis", matched["prc_input"].Credential.Subject.([]verifiable.Subject)[0].CustomFields["givenName"])
+
+		t.Run("rejects a second submission", func(t *testing.T) {
+			replayMsg := service.NewDIDCommMsgMap(presentation.AsV3())
+
+			_, err = v.Verify(replayMsg)
+			require.ErrorContains(t, err, "was already used")
+
+			err = handlePresentProof(verifierMW, &mockPresentProofMetadata{
+				msg: replayMsg, state: "presentation-received",
+			})
+			require.ErrorContains(t, err, "was already used")
+		})
+	})
+
+	t.Run("verifier rejects a presentation for another domain", func(t *testing.T) {
+		verifierAgent, holderAgent := newAgent(t), newAgent(t)
+		holderAgent.holdPRC(t, newAgent(t))
+
+		v := newVerifier(t, verifierAgent)
+		h := newHolder(t, holderAgent)
+
+		request, err := v.RequestPresentation()
+		require.NoError(t, err)
+
+		payload, ok := request.Attachments[0].Data.JSON.(*waci.DefinitionPayload)
+		require.True(t, ok)
+
+		payload.Domain = "https://attacker.example.com"
+
+		presentation, err := h.Present(service.NewDIDCommMsgMap(request.AsV3()))
+		require.NoError(t, err)
+
+		_, err = v.Verify(service.NewDIDCommMsgMap(presentation.AsV3()))
+		require.ErrorContains(t, err, "unexpected domain")
+	})
+
+	t.Run("verifier rejects a presentation not satisfying the definition", func(t *testing.T) {
+		verifierAgent, holderAgent := newAgent(t), newAgent(t)
+		holderAgent.holdPRC(t, newAgent(t))
+
+		v := newVerifier(t, verifierAgent)
+		h := newHolder(t, holderAgent)
+
+		request, err := v.RequestPresentation()
+		require.NoError(t, err)
+
+		definition := newPresentationDefinition(t)
+		definition.InputDescriptors[0].ID = "other_input"
+
+		payload, ok := request.Attachments[0].Data.JSON.(*waci.DefinitionPayload)
+		require.True(t, ok)
+
+		payload.PresentationDefinition = definition
+
+		presentation, err := h.Present(service.NewDIDCommMsgMap(request.AsV3()))
+		require.NoError(t, err)
+
+		_, err = v.Verify(service.NewDIDCommMsgMap(presentation.AsV3()))
+		require.ErrorContains(t, err, "presentation does not satisfy presentation definition")
+	})
+
+	t.Run("middleware rejects an invalid presentation definition", func(t *testing.T) {
+		request := &presentproof.RequestPresentationParams{
+			Attachments: []decorator.GenericAttachment{{
+				Format: waci.PresentationDefinitionFormat,
+				Data: decorator.AttachmentData{
+					JSON: &waci.DefinitionPayload{Challenge: "challenge"},
+				},
+			}},
+		}
+
+		err := handlePresentProof(newPresentProofMiddleware(t, newAgent(t)), &mockPresentProofMetadata{
+			msg: service.NewDIDCommMsgMap(request.AsV3()), state: "request-received",
+		})
+		require.EqualError(t, err, "waci: request is missing the presentation definition")
+	})
+
+	t.Run("error if the presentation definition is missing", func(t *testing.T) {
+		_, err := waci.NewVerifier(newAgent(t), nil, verifierDomain)
+		require.EqualError(t, err, "waci: presentation definition argument cannot be nil")
+	})
+}
+
+func TestCreateInvitation(t *testing.T) {
+	t.Run("creates WACI invitation", func(t *testing.T) {
+		creator := &mockInvitationCreator{}
+
+		_, err := waci.CreateInvitation(creator, waci.IssuanceGoalCode, outofbandv2.WithLabel("issuer"))
+		require.NoError(t, err)
+		require.Len(t, creator.opts, 3)
+	})
+
+	t.Run("error if invitation cannot be created", func(t *testing.T) {
+		expected := errors.New("test")
+
+		_, err := waci.CreateInvitation(&mockInvitationCreator{err: expected}, waci.PresentationGoalCode)
+		require.ErrorIs(t, err, expected)
+	})
+}
+
+type agent struct {
+	*mockprovider.Provider
+	store storeverifiable.Store
+	kid   string
+	did   string
+	vm    string
+}
+
+func (a *agent) VerifiableStore() storeverifiable.Store {
+	return a.store
+}
+
+// holdPRC saves a Permanent Resident Card issued by the given agent to the holder.
+func (a *agent) holdPRC(t *testing.T, government *agent) {
+	t.Helper()
+
+	vc := &verifiable.Credential{
+		Context: []string{"https://www.w3.org/2018/credentials/v1", citizenshipContext},
+		ID:      "urn:uuid:" + uuid.New().String(),
+		Types:   []string{"VerifiableCredential", "PermanentResidentCard"},
+		Issuer:  verifiable.Issuer{ID: government.did},
+		Issued:  util.NewTime(time.Now().UTC()),
+		Subject: map[string]interface{}{
+			"id":         a.did,
+			"givenName":  "Louis",
+			"familyName": "Pasteur",
+		},
+	}
+
+	kh, err := government.KMS().Get(government.kid)
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+		SignatureType:           ed25519signature2018.SignatureType,
+		SignatureRepresentation: verifiable.SignatureJWS,
+		Suite:                   ed25519signature2018.New(suite.WithSigner(suite.NewCryptoSigner(government.Crypto(), kh))),
+		VerificationMethod:      government.vm,
+	}, jsonld.WithDocumentLoader(a.JSONLDDocumentLoader()))
+	require.NoError(t, err)
+
+	require.NoError(t, a.store.SaveCredential("prc", vc))
+}
+
+func newAgent(t *testing.T) *agent {
+	t.Helper()
+
+	cr, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	kmsProvider, err := mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{})
+	require.NoError(t, err)
+
+	km, err := localkms.New("local-lock://custom/master/key/", kmsProvider)
+	require.NoError(t, err)
+
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	kid, pubKey, err := km.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	didKey, vm := fingerprint.CreateDIDKey(pubKey)
+
+	p := &mockprovider.Provider{
+		KMSValue:                          km,
+		CryptoValue:                       cr,
+		DocumentLoaderValue:               loader,
+		StorageProviderValue:              mockstorage.NewMockStoreProvider(),
+		ProtocolStateStorageProviderValue: mockstorage.NewMockStoreProvider(),
+		VDRegistryValue: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				return key.New().Read(didID)
+			},
+		},
+	}
+
+	store, err := storeverifiable.New(p)
+	require.NoError(t, err)
+
+	return &agent{Provider: p, store: store, kid: kid, did: didKey, vm: vm}
+}
+
+func newIssuer(t *testing.T, a *agent) *waci.Issuer {
+	t.Helper()
+
+	var manifest cm.CredentialManifest
+
+	require.NoError(t, json.Unmarshal(credentialManifest, &manifest))
+
+	i, err := waci.NewIssuer(a, &manifest,
+		issuer.WithSigningKey(a.kid, a.vm),
+		issuer.WithTemplate(outputDescriptorID, &issuer.ClaimMappingTemplate{
+			Context: []string{citizenshipContext},
+			Types:   []string{"PermanentResidentCard"},
+			Claims: map[string]string{
+				"givenName":  "$.credentials.prc_input.credentialSubject.givenName",
+				"familyName": "$.credentials.prc_input.credentialSubject.familyName",
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	return i
+}
+
+func newHolder(t *testing.T, a *agent) *waci.Holder {
+	t.Helper()
+
+	h, err := waci.NewHolder(a, waci.WithSigningKey(a.kid, a.vm))
+	require.NoError(t, err)
+
+	return h
+}
+
+func newPresentationDefinition(t *testing.T) *presexch.PresentationDefinition {
+	t.Helper()
+
+	var definition presexch.PresentationDefinition
+
+	require.NoError(t, json.Unmarshal(presentationDefinition, &definition))
+
+	return &definition
+}
+
+func newVerifier(t *testing.T, a *agent) *waci.Verifier {
+	t.Helper()
+
+	v, err := waci.NewVerifier(a, newPresentationDefinition(t), verifierDomain)
+	require.NoError(t, err)
+
+	return v
+}
+
+// sendAction sends an action for the message to the events and returns the argument it was continued with.
+func sendAction(t *testing.T, events chan service.DIDCommAction, protocol string,
+	msg service.DIDCommMsgMap) interface{} {
+	t.Helper()
+
+	var (
+		arg interface{}
+		err error
+	)
+
+	ready := make(chan struct{})
+
+	go func() {
+		events <- service.DIDCommAction{
+			ProtocolName: protocol,
+			Message:      msg,
+			Continue: func(a interface{}) {
+				arg = a
+				ready <- struct{}{}
+			},
+			Stop: func(e error) {
+				err = e
+				ready <- struct{}{}
+			},
+		}
+	}()
+
+	select {
+	case <-ready:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timeout")
+	}
+
+	require.NoError(t, err)
+
+	return arg
+}
+
+func receive(t *testing.T, next chan service.DIDCommAction) service.DIDCommAction {
+	t.Helper()
+
+	select {
+	case event := <-next:
+		return event
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timeout")
+	}
+
+	return service.DIDCommAction{}
+}
+
+func issueCredentialMetadata(t *testing.T, arg interface{}) *issuecredential.MetaData {
+	t.Helper()
+
+	opt, ok := arg.(issuecredential.Opt)
+	require.True(t, ok)
+
+	md := &issuecredential.MetaData{}
+	md.IsV3 = true
+
+	opt(md)
+
+	return md
+}
+
+type mockInvitationCreator struct {
+	opts []outofbandv2.MessageOption
+	err  error
+}
+
+func (m *mockInvitationCreator) CreateInvitation(opts ...outofbandv2.MessageOption) (*oobv2.Invitation, error) {
+	m.opts = opts
+
+	return &oobv2.Invitation{}, m.err
+}
@@ -8,10 +8,14 @@ package kms
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
+
+	"github.com/google/tink/go/subtle/random"
 
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
@@ -19,6 +23,10 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/logutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
 )
 
 var logger = log.New("aries-framework/command/kms")
@@ -31,6 +39,18 @@ const (
 	CreateKeySetError
 	// ImportKeyError is for failures while importing key.
 	ImportKeyError
+	// DeleteKeyError is for failures while deleting key.
+	DeleteKeyError
+	// UpdateKeyStateError is for failures while disabling, enabling or destroying key.
+	UpdateKeyStateError
+	// GetKeyMetadataError is for failures while getting key metadata.
+	GetKeyMetadataError
+	// UpdateKeyMetadataError is for failures while updating key metadata.
+	UpdateKeyMetadataError
+	// ListKeysError is for failures while listing keys.
+	ListKeysError
+	// RewrapKeysError is for failures while rewrapping keys with a new master key.
+	RewrapKeysError
 )
 
 // constants for KMS commands.
@@ -39,12 +59,25 @@ const (
 	CommandName = "kms"
 
 	// command methods.
-	CreateKeySetCommandMethod = "CreateKeySet"
-	ImportKeyCommandMethod    = "ImportKey"
+	CreateKeySetCommandMethod      = "CreateKeySet"
+	ImportKeyCommandMethod         = "ImportKey"
+	DeleteKeyCommandMethod         = "DeleteKey"
+	DisableKeyCommandMethod        = "DisableKey"
+	EnableKeyCommandMethod         = "EnableKey"
+	DestroyKeyCommandMethod        = "DestroyKey"
+	GetKeyMetadataCommandMethod    = "GetKeyMetadata"
+	UpdateKeyMetadataCommandMethod = "UpdateKeyMetadata"
+	ListKeysCommandMethod          = "ListKeys"
+	RewrapKeysCommandMethod        = "RewrapKeys"
 
 	// error messages.
-	errEmptyKeyType = "key type is mandatory"
-	errEmptyKeyID   = "key id is mandatory"
+	errEmptyKeyType    = "key type is mandatory"
+	errEmptyKeyID      = "key id is mandatory"
+	errEmptyPassphrase = "passphrase is mandatory"
+
+	masterKeySize = 32
+
+	errRewrapNotSupported = "the KMS of the agent does not support rewrapping its keys"
 )
 
 // provider contains dependencies for the kms command and is typically created by using aries.Context().
@@ -52,6 +85,12 @@ type provider interface {
 	KMS() kms.KeyManager
 }
 
+// rewrapper is implemented by the KMS able to rewrap their keys with a new master key, such as localkms.
+type rewrapper interface {
+	Rewrap(to localkms.MasterKey, legacyKeyIDs ...string) (int, error)
+	PrimaryKeyURI() string
+}
+
 // Command contains command operations provided by verifiable credential controller.
 type Command struct {
 	ctx       provider
@@ -75,6 +114,14 @@ func (o *Command) GetHandlers() []command.Handler {
 	return []command.Handler{
 		cmdutil.NewCommandHandler(CommandName, CreateKeySetCommandMethod, o.CreateKeySet),
 		cmdutil.NewCommandHandler(CommandName, ImportKeyCommandMethod, o.ImportKey),
+		cmdutil.NewCommandHandler(CommandName, DeleteKeyCommandMethod, o.DeleteKey),
+		cmdutil.NewCommandHandler(CommandName, DisableKeyCommandMethod, o.DisableKey),
+		cmdutil.NewCommandHandler(CommandName, EnableKeyCommandMethod, o.EnableKey),
+		cmdutil.NewCommandHandler(CommandName, DestroyKeyCommandMethod, o.DestroyKey),
+		cmdutil.NewCommandHandler(CommandName, GetKeyMetadataCommandMethod, o.GetKeyMetadata),
+		cmdutil.NewCommandHandler(CommandName, UpdateKeyMetadataCommandMethod, o.UpdateKeyMetadata),
+		cmdutil.NewCommandHandler(CommandName, ListKeysCommandMethod, o.ListKeys),
+		cmdutil.NewCommandHandler(CommandName, RewrapKeysCommandMethod, o.RewrapKeys),
 	}
 }
 
@@ -160,3 +207,232 @@ func (o *Command) ImportKey(rw io.Writer, req io.Reader) command.Error {
 
 	return nil
 }
+
+// DeleteKey deletes a key along with its metadata.
+func (o *Command) DeleteKey(rw io.Writer, req io.Reader) command.Error {
+	return o.updateKey(rw, req, DeleteKeyCommandMethod, DeleteKeyError, o.ctx.KMS().Delete)
+}
+
+// DisableKey disables a key, which cannot be used until it is enabled again.
+func (o *Command) DisableKey(rw io.Writer, req io.Reader) command.Error {
+	return o.updateKey(rw, req, DisableKeyCommandMethod, UpdateKeyStateError, o.ctx.KMS().Disable)
+}
+
+// EnableKey enables a disabled key.
+func (o *Command) EnableKey(rw io.Writer, req io.Reader) command.Error {
+	return o.updateKey(rw, req, EnableKeyCommandMethod, UpdateKeyStateError, o.ctx.KMS().Enable)
+}
+
+// DestroyKey erases the material of a key, keeping its metadata in the destroyed state.
+func (o *Command) DestroyKey(rw io.Writer, req io.Reader) command.Error {
+	return o.updateKey(rw, req, DestroyKeyCommandMethod, UpdateKeyStateError, o.ctx.KMS().Destroy)
+}
+
+func (o *Command) updateKey(rw io.Writer, req io.Reader, method string, code command.Code,
+	update func(keyID string) error) command.Error {
+	var request KeyIDRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, method, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if request.KeyID == "" {
+		logutil.LogDebug(logger, CommandName, method, errEmptyKeyID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyID))
+	}
+
+	err = update(request.KeyID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, method, err.Error())
+		return command.NewExecuteError(code, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, method, "success")
+
+	return nil
+}
+
+// GetKeyMetadata returns the metadata of a key.
+func (o *Command) GetKeyMetadata(rw io.Writer, req io.Reader) command.Error {
+	var request KeyIDRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, GetKeyMetadataCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if request.KeyID == "" {
+		logutil.LogDebug(logger, CommandName, GetKeyMetadataCommandMethod, errEmptyKeyID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyID))
+	}
+
+	metadata, err := o.ctx.KMS().GetMetadata(request.KeyID)
+	if err != nil {
+		logutil.LogError(logger, CommandName, GetKeyMetadataCommandMethod, err.Error())
+		return command.NewExecuteError(GetKeyMetadataError, err)
+	}
+
+	command.WriteNillableResponse(rw, &KeyMetadataResponse{KeyMetadata: metadata}, logger)
+
+	logutil.LogDebug(logger, CommandName, GetKeyMetadataCommandMethod, "success")
+
+	return nil
+}
+
+// UpdateKeyMetadata sets the labels and usage of a key.
+func (o *Command) UpdateKeyMetadata(rw io.Writer, req io.Reader) command.Error {
+	var request UpdateKeyMetadataRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, UpdateKeyMetadataCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if request.KeyID == "" {
+		logutil.LogDebug(logger, CommandName, UpdateKeyMetadataCommandMethod, errEmptyKeyID)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyKeyID))
+	}
+
+	var opts []kms.MetadataOpts
+
+	if request.Labels != nil {
+		opts = append(opts, kms.WithLabels(request.Labels))
+	}
+
+	if request.Usage != nil {
+		opts = append(opts, kms.WithUsage(request.Usage...))
+	}
+
+	err = o.ctx.KMS().UpdateMetadata(request.KeyID, opts...)
+	if err != nil {
+		logutil.LogError(logger, CommandName, UpdateKeyMetadataCommandMethod, err.Error())
+		return command.NewExecuteError(UpdateKeyMetadataError, err)
+	}
+
+	command.WriteNillableResponse(rw, nil, logger)
+
+	logutil.LogDebug(logger, CommandName, UpdateKeyMetadataCommandMethod, "success")
+
+	return nil
+}
+
+// ListKeys returns the metadata of the keys matching the key type, state and labels of the request.
+func (o *Command) ListKeys(rw io.Writer, req io.Reader) command.Error {
+	var request ListKeysRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, ListKeysCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	var opts []kms.ListOpts
+
+	if request.KeyType != "" {
+		opts = append(opts, kms.WithKeyTypeFilter(kms.KeyType(request.KeyType)))
+	}
+
+	if request.State != "" {
+		opts = append(opts, kms.WithStateFilter(kms.KeyState(request.State)))
+	}
+
+	for name, value := range request.Labels {
+		opts = append(opts, kms.WithLabelFilter(name, value))
+	}
+
+	keys, err := o.ctx.KMS().List(opts...)
+	if err != nil {
+		logutil.LogError(logger, CommandName, ListKeysCommandMethod, err.Error())
+		return command.NewExecuteError(ListKeysError, err)
+	}
+
+	command.WriteNillableResponse(rw, &ListKeysResponse{Keys: keys}, logger)
+
+	logutil.LogDebug(logger, CommandName, ListKeysCommandMethod, "success")
+
+	return nil
+}
+
+// RewrapKeys re-encrypts the keys of the agent's KMS with a new master key of a local secret lock, protected by the
+// passphrase of the request, which the KMS uses from then on. The new master key is taken from the request or
+// generated, and returned encrypted with the passphrase: the agent must be restarted with a local secret lock reading
+// it and an HKDF (SHA-256, no salt) master lock of the passphrase. An interrupted operation resumes where it stopped
+// when called again with the same passphrase and master key cipher, which callers may provide for this purpose.
+func (o *Command) RewrapKeys(rw io.Writer, req io.Reader) command.Error {
+	var request RewrapKeysRequest
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RewrapKeysCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("failed request decode : %w", err))
+	}
+
+	if request.Passphrase == "" {
+		logutil.LogDebug(logger, CommandName, RewrapKeysCommandMethod, errEmptyPassphrase)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyPassphrase))
+	}
+
+	km, ok := o.ctx.KMS().(rewrapper)
+	if !ok {
+		logutil.LogInfo(logger, CommandName, RewrapKeysCommandMethod, errRewrapNotSupported)
+		return command.NewExecuteError(RewrapKeysError, fmt.Errorf(errRewrapNotSupported))
+	}
+
+	masterKeyCipher, secretLock, err := newLocalSecretLock(request.Passphrase, request.MasterKeyCipher)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, RewrapKeysCommandMethod, err.Error())
+		return command.NewValidationError(InvalidRequestErrorCode, err)
+	}
+
+	primaryKeyURI := km.PrimaryKeyURI()
+
+	count, err := km.Rewrap(localkms.MasterKey{PrimaryKeyURI: primaryKeyURI, SecretLock: secretLock},
+		request.LegacyKeyIDs...)
+	if err != nil {
+		logutil.LogError(logger, CommandName, RewrapKeysCommandMethod, err.Error())
+		return command.NewExecuteError(RewrapKeysError, err)
+	}
+
+	command.WriteNillableResponse(rw, &RewrapKeysResponse{
+		Rewrapped:       count,
+		PrimaryKeyURI:   primaryKeyURI,
+		MasterKeyCipher: masterKeyCipher,
+	}, logger)
+
+	logutil.LogDebug(logger, CommandName, RewrapKeysCommandMethod, "success")
+
+	return nil
+}
+
+// newLocalSecretLock returns the master key cipher, encrypted with passphrase and generated if empty, and the local
+// secret lock using it.
+func newLocalSecretLock(passphrase, masterKeyCipher string) (string, secretlock.Service, error) {
+	masterLock, err := hkdf.NewMasterLock(passphrase, sha256.New, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create master lock: %w", err)
+	}
+
+	if masterKeyCipher == "" {
+		masterKey := random.GetRandomBytes(masterKeySize)
+
+		encrypted, e := masterLock.Encrypt("", &secretlock.EncryptRequest{Plaintext: string(masterKey)})
+		if e != nil {
+			return "", nil, fmt.Errorf("failed to encrypt master key: %w", e)
+		}
+
+		masterKeyCipher = encrypted.Ciphertext
+	}
+
+	secretLock, err := local.NewService(strings.NewReader(masterKeyCipher), masterLock)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid master key cipher: %w", err)
+	}
+
+	return masterKeyCipher, secretLock, nil
+}
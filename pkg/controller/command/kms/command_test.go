@@ -12,18 +12,26 @@ import (
 	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/go-jose/go-jose/v3"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/local/masterlock/hkdf"
 )
 
 func TestNew(t *testing.T) {
@@ -34,7 +42,7 @@ func TestNew(t *testing.T) {
 		require.NotNil(t, cmd)
 
 		handlers := cmd.GetHandlers()
-		require.Equal(t, 2, len(handlers))
+		require.Equal(t, 10, len(handlers))
 	})
 
 	t.Run("test new command - error from import key", func(t *testing.T) {
@@ -275,3 +283,281 @@ func TestImportKey(t *testing.T) {
 		require.Contains(t, err.Error(), "failed request decode")
 	})
 }
+
+func TestKeyLifecycle(t *testing.T) {
+	t.Run("test key state changes - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{},
+		})
+
+		for _, exec := range []func(io.Writer, io.Reader) command.Error{
+			cmd.DeleteKey, cmd.DisableKey, cmd.EnableKey, cmd.DestroyKey,
+		} {
+			var rw bytes.Buffer
+			cmdErr := exec(&rw, bytes.NewBufferString(`{"keyID":"k1"}`))
+			require.NoError(t, cmdErr)
+		}
+	})
+
+	t.Run("test key state changes - error", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{
+				DeleteErr:  fmt.Errorf("delete error"),
+				DisableErr: fmt.Errorf("disable error"),
+			},
+		})
+
+		var rw bytes.Buffer
+		cmdErr := cmd.DeleteKey(&rw, bytes.NewBufferString(`{"keyID":"k1"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, DeleteKeyError, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+
+		cmdErr = cmd.DisableKey(&rw, bytes.NewBufferString(`{"keyID":"k1"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, UpdateKeyStateError, cmdErr.Code())
+		require.EqualError(t, cmdErr, "disable error")
+	})
+
+	t.Run("test key state changes - invalid request", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{},
+		})
+
+		var rw bytes.Buffer
+		cmdErr := cmd.DestroyKey(&rw, bytes.NewBufferString(`{}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+		require.EqualError(t, cmdErr, errEmptyKeyID)
+
+		cmdErr = cmd.EnableKey(&rw, bytes.NewBufferString(`[`))
+		require.Error(t, cmdErr)
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+	})
+
+	t.Run("test get key metadata", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{GetMetadataValue: &kms.KeyMetadata{
+				KeyID: "k1", KeyType: kms.ED25519Type, State: kms.KeyStateDisabled,
+			}},
+		})
+
+		var rw bytes.Buffer
+		cmdErr := cmd.GetKeyMetadata(&rw, bytes.NewBufferString(`{"keyID":"k1"}`))
+		require.NoError(t, cmdErr)
+
+		response := KeyMetadataResponse{}
+		require.NoError(t, json.NewDecoder(&rw).Decode(&response))
+		require.Equal(t, "k1", response.KeyID)
+		require.Equal(t, kms.KeyStateDisabled, response.State)
+
+		cmd = New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{GetMetadataErr: fmt.Errorf("get metadata error")},
+		})
+
+		cmdErr = cmd.GetKeyMetadata(&rw, bytes.NewBufferString(`{"keyID":"k1"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, GetKeyMetadataError, cmdErr.Code())
+
+		cmdErr = cmd.GetKeyMetadata(&rw, bytes.NewBufferString(`{}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+	})
+
+	t.Run("test update key metadata", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{},
+		})
+
+		var rw bytes.Buffer
+		cmdErr := cmd.UpdateKeyMetadata(&rw,
+			bytes.NewBufferString(`{"keyID":"k1","labels":{"team":"issuance"},"usage":["sign"]}`))
+		require.NoError(t, cmdErr)
+
+		cmdErr = cmd.UpdateKeyMetadata(&rw, bytes.NewBufferString(`{"usage":["sign"]}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		cmd = New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{UpdateMetadataErr: fmt.Errorf("update metadata error")},
+		})
+
+		cmdErr = cmd.UpdateKeyMetadata(&rw, bytes.NewBufferString(`{"keyID":"k1"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, UpdateKeyMetadataError, cmdErr.Code())
+	})
+
+	t.Run("test list keys", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ListValue: []*kms.KeyMetadata{{KeyID: "k1"}, {KeyID: "k2"}}},
+		})
+
+		var rw bytes.Buffer
+		cmdErr := cmd.ListKeys(&rw,
+			bytes.NewBufferString(`{"keyType":"ED25519","state":"enabled","labels":{"team":"issuance"}}`))
+		require.NoError(t, cmdErr)
+
+		response := ListKeysResponse{}
+		require.NoError(t, json.NewDecoder(&rw).Decode(&response))
+		require.Len(t, response.Keys, 2)
+
+		cmdErr = cmd.ListKeys(&rw, bytes.NewBufferString(`[`))
+		require.Error(t, cmdErr)
+		require.Equal(t, InvalidRequestErrorCode, cmdErr.Code())
+
+		cmd = New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ListErr: fmt.Errorf("list error")},
+		})
+
+		cmdErr = cmd.ListKeys(&rw, bytes.NewBufferString(`{}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, ListKeysError, cmdErr.Code())
+	})
+}
+
+func TestRewrapKeys(t *testing.T) {
+	const (
+		primaryKeyURI = "local-lock://test/master/key/"
+		passphrase    = "new passphrase"
+	)
+
+	setup := func(t *testing.T) (*mockprovider.Provider, string) {
+		t.Helper()
+
+		masterKey := make([]byte, 32)
+		_, err := rand.Read(masterKey)
+		require.NoError(t, err)
+
+		lock, err := local.NewService(bytes.NewBufferString(base64.URLEncoding.EncodeToString(masterKey)), nil)
+		require.NoError(t, err)
+
+		p := &mockprovider.Provider{
+			StorageProviderValue: mockstorage.NewMockStoreProvider(),
+			SecretLockValue:      lock,
+		}
+
+		kmsProvider, err := mockkms.NewProviderForKMS(p.StorageProviderValue, p.SecretLockValue)
+		require.NoError(t, err)
+
+		p.KMSValue, err = localkms.New(primaryKeyURI, kmsProvider)
+		require.NoError(t, err)
+
+		keyID, _, err := p.KMSValue.Create(kms.ED25519Type)
+		require.NoError(t, err)
+
+		return p, keyID
+	}
+
+	// requireRestartable checks the keys can be read by a KMS restarted with the response of the rewrap.
+	requireRestartable := func(t *testing.T, p *mockprovider.Provider, response *RewrapKeysResponse, keyID string) {
+		t.Helper()
+
+		masterLock, err := hkdf.NewMasterLock(passphrase, sha256.New, nil)
+		require.NoError(t, err)
+
+		lock, err := local.NewService(strings.NewReader(response.MasterKeyCipher), masterLock)
+		require.NoError(t, err)
+
+		kmsProvider, err := mockkms.NewProviderForKMS(p.StorageProviderValue, lock)
+		require.NoError(t, err)
+
+		restarted, err := localkms.New(response.PrimaryKeyURI, kmsProvider)
+		require.NoError(t, err)
+
+		_, err = restarted.Get(keyID)
+		require.NoError(t, err)
+	}
+
+	t.Run("test rewrap keys - success", func(t *testing.T) {
+		p, keyID := setup(t)
+		cmd := New(p)
+
+		var rw bytes.Buffer
+		cmdErr := cmd.RewrapKeys(&rw, bytes.NewBufferString(fmt.Sprintf(`{"passphrase":%q}`, passphrase)))
+		require.NoError(t, cmdErr)
+
+		response := RewrapKeysResponse{}
+		require.NoError(t, json.NewDecoder(&rw).Decode(&response))
+		require.Equal(t, 1, response.Rewrapped)
+		require.Equal(t, primaryKeyURI, response.PrimaryKeyURI)
+		require.NotEmpty(t, response.MasterKeyCipher)
+
+		_, err := p.KMSValue.Get(keyID)
+		require.NoError(t, err)
+
+		requireRestartable(t, p, &response, keyID)
+	})
+
+	t.Run("test rewrap keys - success with a master key cipher", func(t *testing.T) {
+		p, keyID := setup(t)
+		cmd := New(p)
+
+		masterKeyCipher, _, err := newLocalSecretLock(passphrase, "")
+		require.NoError(t, err)
+
+		var rw bytes.Buffer
+		cmdErr := cmd.RewrapKeys(&rw, bytes.NewBufferString(
+			fmt.Sprintf(`{"passphrase":%q,"masterKeyCipher":%q}`, passphrase, masterKeyCipher)))
+		require.NoError(t, cmdErr)
+
+		response := RewrapKeysResponse{}
+		require.NoError(t, json.NewDecoder(&rw).Decode(&response))
+		require.Equal(t, 1, response.Rewrapped)
+		require.Equal(t, masterKeyCipher, response.MasterKeyCipher)
+
+		requireRestartable(t, p, &response, keyID)
+	})
+
+	t.Run("test rewrap keys - invalid request", func(t *testing.T) {
+		p, keyID := setup(t)
+		cmd := New(p)
+
+		var rw bytes.Buffer
+		cmdErr := cmd.RewrapKeys(&rw, bytes.NewBufferString(`[`))
+		require.Error(t, cmdErr)
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+
+		cmdErr = cmd.RewrapKeys(&rw, bytes.NewBufferString(`{"legacyKeyIDs":["a"]}`))
+		require.Error(t, cmdErr)
+		require.EqualError(t, cmdErr, errEmptyPassphrase)
+
+		// the master key cipher is not encrypted with the passphrase.
+		masterKeyCipher, _, err := newLocalSecretLock("other passphrase", "")
+		require.NoError(t, err)
+
+		cmdErr = cmd.RewrapKeys(&rw, bytes.NewBufferString(
+			fmt.Sprintf(`{"passphrase":%q,"masterKeyCipher":%q}`, passphrase, masterKeyCipher)))
+		require.Error(t, cmdErr)
+		require.Equal(t, command.ValidationError, cmdErr.Type())
+		require.Contains(t, cmdErr.Error(), "invalid master key cipher")
+
+		_, err = p.KMSValue.Get(keyID)
+		require.NoError(t, err)
+	})
+
+	t.Run("test rewrap keys - KMS not supported", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{KMSValue: &mockkms.KeyManager{}})
+
+		var rw bytes.Buffer
+		cmdErr := cmd.RewrapKeys(&rw, bytes.NewBufferString(`{"passphrase":"passphrase"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, RewrapKeysError, cmdErr.Code())
+		require.EqualError(t, cmdErr, errRewrapNotSupported)
+	})
+
+	t.Run("test rewrap keys - unknown legacy key", func(t *testing.T) {
+		p, keyID := setup(t)
+		cmd := New(p)
+
+		var rw bytes.Buffer
+		cmdErr := cmd.RewrapKeys(&rw, bytes.NewBufferString(
+			fmt.Sprintf(`{"passphrase":%q,"legacyKeyIDs":["unknown"]}`, passphrase)))
+		require.Error(t, cmdErr)
+		require.Equal(t, RewrapKeysError, cmdErr.Code())
+		require.Equal(t, command.ExecuteError, cmdErr.Type())
+
+		// the KMS keeps its master key.
+		_, err := p.KMSValue.Get(keyID)
+		require.NoError(t, err)
+	})
+}
@@ -6,6 +6,10 @@ SPDX-License-Identifier: Apache-2.0
 
 package kms
 
+import (
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
 // CreateKeySetRequest is model for createKeySey request.
 type CreateKeySetRequest struct {
 	KeyType string `json:"keyType,omitempty"`
@@ -30,3 +34,52 @@ type JSONWebKey struct {
 	Y   string `json:"y,omitempty"`
 	D   string `json:"d,omitempty"`
 }
+
+// KeyIDRequest is model for the requests targeting a single key (delete, disable, enable, destroy, get metadata).
+type KeyIDRequest struct {
+	KeyID string `json:"keyID,omitempty"`
+}
+
+// UpdateKeyMetadataRequest is model for updateKeyMetadata request. Fields not set are left unchanged.
+type UpdateKeyMetadataRequest struct {
+	KeyID  string            `json:"keyID,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Usage  []string          `json:"usage,omitempty"`
+}
+
+// KeyMetadataResponse for returning key metadata.
+type KeyMetadataResponse struct {
+	*kms.KeyMetadata
+}
+
+// ListKeysRequest is model for listKeys request. Keys are filtered by all the fields set.
+type ListKeysRequest struct {
+	KeyType string            `json:"keyType,omitempty"`
+	State   string            `json:"state,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+// ListKeysResponse for returning the metadata of the listed keys.
+type ListKeysResponse struct {
+	Keys []*kms.KeyMetadata `json:"keys"`
+}
+
+// RewrapKeysRequest is model for rewrapKeys request.
+type RewrapKeysRequest struct {
+	// passphrase protecting the new master key of the agent's local secret lock.
+	Passphrase string `json:"passphrase,omitempty"`
+	// new master key encrypted with the passphrase, as read from a master key file. A new master key is generated
+	// if empty.
+	MasterKeyCipher string `json:"masterKeyCipher,omitempty"`
+	// keys the KMS store can't list, rewrapped along with the listed keys.
+	LegacyKeyIDs []string `json:"legacyKeyIDs,omitempty"`
+}
+
+// RewrapKeysResponse for returning the number of keys rewrapped and the master key the agent must be restarted with.
+type RewrapKeysResponse struct {
+	Rewrapped int `json:"rewrapped"`
+	// primary key URI of the agent's KMS, unchanged by the rewrap.
+	PrimaryKeyURI string `json:"primaryKeyURI"`
+	// new master key encrypted with the passphrase, to be read by the local secret lock of the restarted agent.
+	MasterKeyCipher string `json:"masterKeyCipher"`
+}
@@ -86,6 +86,12 @@ const (
 
 	// DeriveCredentialErrorCode for derive credential error.
 	DeriveCredentialErrorCode
+
+	// VerifyCredentialErrorCode for verify vc error.
+	VerifyCredentialErrorCode
+
+	// VerifyPresentationErrorCode for verify vp error.
+	VerifyPresentationErrorCode
 )
 
 // constants for the Verifiable protocol.
@@ -107,6 +113,8 @@ const (
 	GeneratePresentationByIDCommandMethod = "GeneratePresentationByID"
 	RemoveCredentialByNameCommandMethod   = "RemoveCredentialByName"
 	RemovePresentationByNameCommandMethod = "RemovePresentationByName"
+	VerifyCredentialCommandMethod         = "VerifyCredential"
+	VerifyPresentationCommandMethod       = "VerifyPresentation"
 
 	// error messages.
 	errEmptyCredentialName   = "credential name is mandatory"
@@ -116,6 +124,7 @@ const (
 	errEmptyDID              = "did is mandatory"
 	errEmptyCredential       = "credential is mandatory is mandatory"
 	errEmptyFrame            = "frame is mandatory is mandatory"
+	errEmptyPresentation     = "presentation is mandatory"
 
 	// log constants.
 	vcID   = "vcID"
@@ -247,6 +256,8 @@ func (o *Command) GetHandlers() []command.Handler {
 		cmdutil.NewCommandHandler(CommandName, GetPresentationsCommandMethod, o.GetPresentations),
 		cmdutil.NewCommandHandler(CommandName, RemoveCredentialByNameCommandMethod, o.RemoveCredentialByName),
 		cmdutil.NewCommandHandler(CommandName, RemovePresentationByNameCommandMethod, o.RemovePresentationByName),
+		cmdutil.NewCommandHandler(CommandName, VerifyCredentialCommandMethod, o.VerifyCredential),
+		cmdutil.NewCommandHandler(CommandName, VerifyPresentationCommandMethod, o.VerifyPresentation),
 	}
 }
 
@@ -278,6 +289,70 @@ func (o *Command) ValidateCredential(rw io.Writer, req io.Reader) command.Error
 	return nil
 }
 
+// VerifyCredential verifies the verifiable credential and reports the outcome of each check.
+func (o *Command) VerifyCredential(rw io.Writer, req io.Reader) command.Error {
+	request := &Credential{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyCredentialCommandMethod, "request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if request.VerifiableCredential == "" {
+		logutil.LogDebug(logger, CommandName, VerifyCredentialCommandMethod, errEmptyCredential)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyCredential))
+	}
+
+	report, err := verifiable.VerifyCredential([]byte(request.VerifiableCredential), o.getVerifyCredentialOpts()...)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyCredentialCommandMethod, "verify vc : "+err.Error())
+
+		return command.NewValidationError(VerifyCredentialErrorCode, fmt.Errorf("verify vc : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, newVerificationReport(report), logger)
+
+	logutil.LogDebug(logger, CommandName, VerifyCredentialCommandMethod, "success")
+
+	return nil
+}
+
+// VerifyPresentation verifies the verifiable presentation and its credentials,
+// and reports the outcome of each check.
+func (o *Command) VerifyPresentation(rw io.Writer, req io.Reader) command.Error {
+	request := &Presentation{}
+
+	err := json.NewDecoder(req).Decode(&request)
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyPresentationCommandMethod, "request decode : "+err.Error())
+
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf("request decode : %w", err))
+	}
+
+	if len(request.VerifiablePresentation) == 0 {
+		logutil.LogDebug(logger, CommandName, VerifyPresentationCommandMethod, errEmptyPresentation)
+		return command.NewValidationError(InvalidRequestErrorCode, fmt.Errorf(errEmptyPresentation))
+	}
+
+	report, err := verifiable.VerifyPresentation(request.VerifiablePresentation,
+		verifiable.WithPresPublicKeyFetcher(verifiable.NewVDRKeyResolver(o.ctx.VDRegistry()).PublicKeyFetcher()),
+		verifiable.WithPresJSONLDDocumentLoader(o.documentLoader),
+		verifiable.WithPresCredentialOpts(o.getVerifyCredentialOpts()...))
+	if err != nil {
+		logutil.LogInfo(logger, CommandName, VerifyPresentationCommandMethod, "verify vp : "+err.Error())
+
+		return command.NewValidationError(VerifyPresentationErrorCode, fmt.Errorf("verify vp : %w", err))
+	}
+
+	command.WriteNillableResponse(rw, newVerificationReport(report), logger)
+
+	logutil.LogDebug(logger, CommandName, VerifyPresentationCommandMethod, "success")
+
+	return nil
+}
+
 // SaveCredential saves the verifiable credential to the store.
 func (o *Command) SaveCredential(rw io.Writer, req io.Reader) command.Error {
 	request := &CredentialExt{}
@@ -975,6 +1050,10 @@ func (o *Command) getCredentialOpts(disableProofCheck bool) []verifiable.Credent
 	}
 }
 
+func (o *Command) getVerifyCredentialOpts() []verifiable.CredentialOpt {
+	return append(o.getCredentialOpts(false), verifiable.WithIssuerDIDResolver(o.ctx.VDRegistry()))
+}
+
 // nolint:funlen,gocyclo
 func prepareOpts(opts *ProofOptions, didDoc *did.Doc, method did.VerificationRelationship) (*ProofOptions, error) {
 	if opts == nil {
@@ -556,7 +556,7 @@ func TestNew(t *testing.T) {
 		require.NoError(t, err)
 
 		handlers := cmd.GetHandlers()
-		require.Equal(t, 14, len(handlers))
+		require.Equal(t, 16, len(handlers))
 	})
 
 	t.Run("test new command - vc store error", func(t *testing.T) {
@@ -626,6 +626,129 @@ func TestValidateVC(t *testing.T) {
 	})
 }
 
+func TestVerifyVC(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		DocumentLoaderValue:  loader,
+		VDRegistryValue: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				return nil, errors.New("DID not found")
+			},
+		},
+	})
+	require.NotNil(t, cmd)
+	require.NoError(t, err)
+
+	t.Run("test verify vc - success", func(t *testing.T) {
+		vcReqBytes, err := json.Marshal(Credential{VerifiableCredential: vc})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		err = cmd.VerifyCredential(&b, bytes.NewBuffer(vcReqBytes))
+		require.NoError(t, err)
+
+		var report VerificationReport
+		require.NoError(t, json.Unmarshal(b.Bytes(), &report))
+		require.False(t, report.Valid)
+
+		outcomes := map[string]string{}
+		for _, check := range report.Checks {
+			outcomes[check.Check] = check.Outcome
+		}
+
+		require.Equal(t, map[string]string{
+			"proof":          "failed",
+			"schema":         "passed",
+			"jsonld":         "passed",
+			"validityPeriod": "passed",
+			"status":         "skipped",
+			"issuer":         "failed",
+			"holderBinding":  "skipped",
+		}, outcomes)
+	})
+
+	t.Run("test verify vc - invalid request", func(t *testing.T) {
+		var b bytes.Buffer
+
+		err = cmd.VerifyCredential(&b, bytes.NewBufferString("--"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "request decode")
+
+		err = cmd.VerifyCredential(&b, bytes.NewBufferString("{}"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), errEmptyCredential)
+	})
+
+	t.Run("test verify vc - invalid credential", func(t *testing.T) {
+		vcReqBytes, err := json.Marshal(Credential{VerifiableCredential: "--"})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+
+		cmdErr := cmd.VerifyCredential(&b, bytes.NewBuffer(vcReqBytes))
+		require.Error(t, cmdErr)
+		require.Equal(t, VerifyCredentialErrorCode, cmdErr.Code())
+	})
+}
+
+func TestVerifyVP(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		DocumentLoaderValue:  loader,
+		VDRegistryValue: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				return nil, errors.New("DID not found")
+			},
+		},
+	})
+	require.NotNil(t, cmd)
+	require.NoError(t, err)
+
+	t.Run("test verify vp - success", func(t *testing.T) {
+		vpReqBytes, err := json.Marshal(Presentation{VerifiablePresentation: stringToJSONRaw(udVerifiablePresentation)})
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		err = cmd.VerifyPresentation(&b, bytes.NewBuffer(vpReqBytes))
+		require.NoError(t, err)
+
+		var report VerificationReport
+		require.NoError(t, json.Unmarshal(b.Bytes(), &report))
+		require.False(t, report.Valid)
+		require.NotEmpty(t, report.Checks)
+		require.Len(t, report.Credentials, 1)
+		require.NotEmpty(t, report.Credentials[0].Checks)
+		require.Len(t, report.Credentials[0].Proofs, 1)
+		require.False(t, report.Credentials[0].Proofs[0].Valid)
+	})
+
+	t.Run("test verify vp - invalid request", func(t *testing.T) {
+		var b bytes.Buffer
+
+		err = cmd.VerifyPresentation(&b, bytes.NewBufferString("--"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "request decode")
+
+		err = cmd.VerifyPresentation(&b, bytes.NewBufferString("{}"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), errEmptyPresentation)
+	})
+
+	t.Run("test verify vp - invalid presentation", func(t *testing.T) {
+		var b bytes.Buffer
+
+		cmdErr := cmd.VerifyPresentation(&b, bytes.NewBufferString(`{"verifiablePresentation":"--"}`))
+		require.Error(t, cmdErr)
+		require.Equal(t, VerifyPresentationErrorCode, cmdErr.Code())
+	})
+}
+
 func TestSaveVC(t *testing.T) {
 	loader, err := ldtestutil.DocumentLoader()
 	require.NoError(t, err)
@@ -134,3 +134,73 @@ type DeriveCredentialRequest struct {
 	// SkipVerify can be used to skip verification of `Credential` provided.
 	SkipVerify bool `json:"skipVerify,omitempty"`
 }
+
+// VerificationReport is model for the outcome of verifying a credential or presentation.
+type VerificationReport struct {
+	// Valid is true if none of the checks failed, including the checks of embedded credentials.
+	Valid bool `json:"valid"`
+	// Checks holds the outcome of each check, eg schema, proof, validityPeriod, status, issuer or holderBinding.
+	Checks []*CheckResult `json:"checks,omitempty"`
+	// Proofs holds the outcome of each embedded proof.
+	Proofs []*ProofResult `json:"proofs,omitempty"`
+	// Credentials holds a report for each credential embedded into a presentation.
+	Credentials []*VerificationReport `json:"credentials,omitempty"`
+}
+
+// CheckResult is model for the outcome of a single verification check.
+type CheckResult struct {
+	Check string `json:"check"`
+	// Outcome is one of passed, failed or skipped.
+	Outcome string `json:"outcome"`
+	// Error is the reason the check failed or was skipped.
+	Error string `json:"error,omitempty"`
+}
+
+// ProofResult is model for the outcome of checking a single embedded proof.
+type ProofResult struct {
+	ID                 string `json:"id,omitempty"`
+	Type               string `json:"type,omitempty"`
+	CryptoSuite        string `json:"cryptosuite,omitempty"`
+	VerificationMethod string `json:"verificationMethod,omitempty"`
+	PreviousProof      string `json:"previousProof,omitempty"`
+	Valid              bool   `json:"valid"`
+	Error              string `json:"error,omitempty"`
+}
+
+func newVerificationReport(report *docverifiable.VerificationReport) *VerificationReport {
+	result := &VerificationReport{Valid: report.Valid()}
+
+	for _, check := range report.Checks {
+		result.Checks = append(result.Checks, &CheckResult{
+			Check:   string(check.Check),
+			Outcome: string(check.Outcome),
+			Error:   errorString(check.Err),
+		})
+	}
+
+	for _, proof := range report.Proofs {
+		result.Proofs = append(result.Proofs, &ProofResult{
+			ID:                 proof.ID,
+			Type:               proof.Type,
+			CryptoSuite:        proof.CryptoSuite,
+			VerificationMethod: proof.VerificationMethod,
+			PreviousProof:      proof.PreviousProof,
+			Valid:              proof.Valid(),
+			Error:              errorString(proof.Err),
+		})
+	}
+
+	for _, credReport := range report.Credentials {
+		result.Credentials = append(result.Credentials, newVerificationReport(credReport))
+	}
+
+	return result
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
@@ -43,3 +43,104 @@ type importKeyReq struct {
 	// in: body
 	kms.JSONWebKey
 }
+
+// keyIDReq model
+//
+// This is used for the requests targeting a single key.
+//
+// swagger:parameters deleteKeyReq disableKeyReq enableKeyReq destroyKeyReq getKeyMetadataReq
+type keyIDReq struct { // nolint: unused,deadcode
+	// Key ID
+	//
+	// in: path
+	// required: true
+	KeyID string `json:"keyID"`
+}
+
+// updateKeyMetadataReq model
+//
+// This is used for updateKeyMetadata request.
+//
+// swagger:parameters updateKeyMetadataReq
+type updateKeyMetadataReq struct { // nolint: unused,deadcode
+	// Key ID
+	//
+	// in: path
+	// required: true
+	KeyID string `json:"keyID"`
+
+	// Params for updateKeyMetadata
+	//
+	// in: body
+	Params struct {
+		Labels map[string]string `json:"labels,omitempty"`
+		Usage  []string          `json:"usage,omitempty"`
+	}
+}
+
+// keyMetadataRes model
+//
+// This is used for returning the metadata of a key
+//
+// swagger:response keyMetadataRes
+type keyMetadataRes struct { // nolint: unused,deadcode
+
+	// in: body
+	kms.KeyMetadataResponse
+}
+
+// listKeysReq model
+//
+// This is used for listKeys request.
+//
+// swagger:parameters listKeysReq
+type listKeysReq struct { // nolint: unused,deadcode
+	// Key type of the listed keys
+	//
+	// in: query
+	KeyType string `json:"keyType"`
+
+	// State of the listed keys
+	//
+	// in: query
+	State string `json:"state"`
+
+	// Labels of the listed keys, formatted as 'name:value'
+	//
+	// in: query
+	Label []string `json:"label"`
+}
+
+// listKeysRes model
+//
+// This is used for returning the metadata of the listed keys
+//
+// swagger:response listKeysRes
+type listKeysRes struct { // nolint: unused,deadcode
+
+	// in: body
+	kms.ListKeysResponse
+}
+
+// rewrapKeysReq model
+//
+// This is used for rewrapKeys request.
+//
+// swagger:parameters rewrapKeysReq
+type rewrapKeysReq struct { // nolint: unused,deadcode
+	// Params for rewrapKeys
+	//
+	// in: body
+	kms.RewrapKeysRequest
+}
+
+// rewrapKeysRes model
+//
+// This is used for returning the number of keys rewrapped and the master key cipher the agent must be restarted with
+//
+// swagger:response rewrapKeysRes
+type rewrapKeysRes struct { // nolint: unused,deadcode
+
+	// in: body
+	kms.RewrapKeysResponse
+}
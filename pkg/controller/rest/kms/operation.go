@@ -7,8 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package kms
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
 
 	"github.com/hyperledger/aries-framework-go/pkg/controller/command"
 	cmdkms "github.com/hyperledger/aries-framework-go/pkg/controller/command/kms"
@@ -22,6 +28,15 @@ const (
 	KmsOperationID   = "/kms"
 	CreateKeySetPath = KmsOperationID + "/keyset"
 	ImportKeyPath    = KmsOperationID + "/import"
+	KeysPath         = KmsOperationID + "/keys"
+	KeyPath          = KeysPath + "/{keyID}"
+	DisableKeyPath   = KeyPath + "/disable"
+	EnableKeyPath    = KeyPath + "/enable"
+	DestroyKeyPath   = KeyPath + "/destroy"
+	KeyMetadataPath  = KeyPath + "/metadata"
+	RewrapKeysPath   = KmsOperationID + "/rewrap"
+
+	labelQueryParam = "label"
 )
 
 // provider contains dependencies for the kms command and is typically created by using aries.Context().
@@ -32,6 +47,14 @@ type provider interface {
 type kmsCommand interface {
 	CreateKeySet(rw io.Writer, req io.Reader) command.Error
 	ImportKey(rw io.Writer, req io.Reader) command.Error
+	DeleteKey(rw io.Writer, req io.Reader) command.Error
+	DisableKey(rw io.Writer, req io.Reader) command.Error
+	EnableKey(rw io.Writer, req io.Reader) command.Error
+	DestroyKey(rw io.Writer, req io.Reader) command.Error
+	GetKeyMetadata(rw io.Writer, req io.Reader) command.Error
+	UpdateKeyMetadata(rw io.Writer, req io.Reader) command.Error
+	ListKeys(rw io.Writer, req io.Reader) command.Error
+	RewrapKeys(rw io.Writer, req io.Reader) command.Error
 }
 
 // Operation contains basic common operations provided by controller REST API.
@@ -60,6 +83,14 @@ func (o *Operation) registerHandler() {
 	o.handlers = []rest.Handler{
 		cmdutil.NewHTTPHandler(CreateKeySetPath, http.MethodPost, o.CreateKeySet),
 		cmdutil.NewHTTPHandler(ImportKeyPath, http.MethodPost, o.ImportKey),
+		cmdutil.NewHTTPHandler(KeysPath, http.MethodGet, o.ListKeys),
+		cmdutil.NewHTTPHandler(KeyPath, http.MethodDelete, o.DeleteKey),
+		cmdutil.NewHTTPHandler(DisableKeyPath, http.MethodPost, o.DisableKey),
+		cmdutil.NewHTTPHandler(EnableKeyPath, http.MethodPost, o.EnableKey),
+		cmdutil.NewHTTPHandler(DestroyKeyPath, http.MethodPost, o.DestroyKey),
+		cmdutil.NewHTTPHandler(KeyMetadataPath, http.MethodGet, o.GetKeyMetadata),
+		cmdutil.NewHTTPHandler(KeyMetadataPath, http.MethodPut, o.UpdateKeyMetadata),
+		cmdutil.NewHTTPHandler(RewrapKeysPath, http.MethodPost, o.RewrapKeys),
 	}
 }
 
@@ -83,3 +114,146 @@ func (o *Operation) CreateKeySet(rw http.ResponseWriter, req *http.Request) {
 func (o *Operation) ImportKey(rw http.ResponseWriter, req *http.Request) {
 	rest.Execute(o.command.ImportKey, rw, req.Body)
 }
+
+// DeleteKey swagger:route DELETE /kms/keys/{keyID} kms deleteKeyReq
+//
+// Deletes a key along with its metadata.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) DeleteKey(rw http.ResponseWriter, req *http.Request) {
+	executeForKey(o.command.DeleteKey, rw, req, &cmdkms.KeyIDRequest{})
+}
+
+// DisableKey swagger:route POST /kms/keys/{keyID}/disable kms disableKeyReq
+//
+// Disables a key.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) DisableKey(rw http.ResponseWriter, req *http.Request) {
+	executeForKey(o.command.DisableKey, rw, req, &cmdkms.KeyIDRequest{})
+}
+
+// EnableKey swagger:route POST /kms/keys/{keyID}/enable kms enableKeyReq
+//
+// Enables a disabled key.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) EnableKey(rw http.ResponseWriter, req *http.Request) {
+	executeForKey(o.command.EnableKey, rw, req, &cmdkms.KeyIDRequest{})
+}
+
+// DestroyKey swagger:route POST /kms/keys/{keyID}/destroy kms destroyKeyReq
+//
+// Erases the material of a key, keeping its metadata in the destroyed state.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) DestroyKey(rw http.ResponseWriter, req *http.Request) {
+	executeForKey(o.command.DestroyKey, rw, req, &cmdkms.KeyIDRequest{})
+}
+
+// GetKeyMetadata swagger:route GET /kms/keys/{keyID}/metadata kms getKeyMetadataReq
+//
+// Retrieves the metadata of a key.
+//
+// Responses:
+//    default: genericError
+//        200: keyMetadataRes
+func (o *Operation) GetKeyMetadata(rw http.ResponseWriter, req *http.Request) {
+	executeForKey(o.command.GetKeyMetadata, rw, req, &cmdkms.KeyIDRequest{})
+}
+
+// UpdateKeyMetadata swagger:route PUT /kms/keys/{keyID}/metadata kms updateKeyMetadataReq
+//
+// Sets the labels and usage of a key.
+//
+// Responses:
+//    default: genericError
+func (o *Operation) UpdateKeyMetadata(rw http.ResponseWriter, req *http.Request) {
+	request := &cmdkms.UpdateKeyMetadataRequest{}
+
+	err := json.NewDecoder(req.Body).Decode(request)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusBadRequest, cmdkms.InvalidRequestErrorCode,
+			fmt.Errorf("failed request decode : %w", err))
+
+		return
+	}
+
+	executeForKey(o.command.UpdateKeyMetadata, rw, req, request)
+}
+
+// ListKeys swagger:route GET /kms/keys kms listKeysReq
+//
+// Lists the metadata of the keys, filtered by key type, state and labels.
+//
+// Responses:
+//    default: genericError
+//        200: listKeysRes
+func (o *Operation) ListKeys(rw http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query()
+
+	request := &cmdkms.ListKeysRequest{
+		KeyType: query.Get("keyType"),
+		State:   query.Get("state"),
+	}
+
+	for _, label := range query[labelQueryParam] {
+		name, value, ok := strings.Cut(label, ":")
+		if !ok {
+			rest.SendHTTPStatusError(rw, http.StatusBadRequest, cmdkms.InvalidRequestErrorCode,
+				fmt.Errorf("invalid label '%s', expected format is 'name:value'", label))
+
+			return
+		}
+
+		if request.Labels == nil {
+			request.Labels = map[string]string{}
+		}
+
+		request.Labels[name] = value
+	}
+
+	execute(o.command.ListKeys, rw, request)
+}
+
+// RewrapKeys swagger:route POST /kms/rewrap kms rewrapKeysReq
+//
+// Re-encrypts the keys with a new master key protected by the passphrase, used by the KMS afterwards. The agent must be
+// restarted with the returned master key cipher and the passphrase.
+//
+// Responses:
+//    default: genericError
+//        200: rewrapKeysRes
+func (o *Operation) RewrapKeys(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.RewrapKeys, rw, req.Body)
+}
+
+// executeForKey executes the command with request, completed with the key ID of the request path.
+func executeForKey(exec command.Exec, rw http.ResponseWriter, req *http.Request, request interface{}) {
+	keyID := mux.Vars(req)["keyID"]
+
+	switch r := request.(type) {
+	case *cmdkms.KeyIDRequest:
+		r.KeyID = keyID
+	case *cmdkms.UpdateKeyMetadataRequest:
+		r.KeyID = keyID
+	}
+
+	execute(exec, rw, request)
+}
+
+func execute(exec command.Exec, rw http.ResponseWriter, request interface{}) {
+	reqBytes, err := json.Marshal(request)
+	if err != nil {
+		rest.SendHTTPStatusError(rw, http.StatusInternalServerError, cmdkms.InvalidRequestErrorCode,
+			fmt.Errorf("failed to marshal request : %w", err))
+
+		return
+	}
+
+	rest.Execute(exec, rw, bytes.NewBuffer(reqBytes))
+}
@@ -13,6 +13,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gorilla/mux"
@@ -23,6 +24,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/controller/rest"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
 	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
 )
 
 func TestNew(t *testing.T) {
@@ -31,7 +33,7 @@ func TestNew(t *testing.T) {
 			KMSValue: &mockkms.KeyManager{},
 		})
 		require.NotNil(t, cmd)
-		require.Equal(t, 2, len(cmd.GetRESTHandlers()))
+		require.Equal(t, 10, len(cmd.GetRESTHandlers()))
 	})
 }
 
@@ -102,14 +104,131 @@ func TestImportKey(t *testing.T) {
 	})
 }
 
+func TestKeyLifecycle(t *testing.T) {
+	t.Run("test key state changes - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{},
+		})
+
+		for path, method := range map[string]string{
+			KeyPath:        http.MethodDelete,
+			DisableKeyPath: http.MethodPost,
+			EnableKeyPath:  http.MethodPost,
+			DestroyKeyPath: http.MethodPost,
+		} {
+			handler := lookupHandlerByMethod(t, cmd, path, method)
+
+			_, code, err := sendRequestToHandler(handler, nil, strings.ReplaceAll(path, "{keyID}", "k1"))
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, code, path)
+		}
+	})
+
+	t.Run("test destroy key - error", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{DestroyErr: fmt.Errorf("failed to destroy key")},
+		})
+
+		handler := lookupHandler(t, cmd, DestroyKeyPath)
+
+		buf, code, err := sendRequestToHandler(handler, nil, KeysPath+"/k1/destroy")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, kms.UpdateKeyStateError, "failed to destroy key", buf.Bytes())
+	})
+
+	t.Run("test get and update key metadata - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{GetMetadataValue: &kmsapi.KeyMetadata{
+				KeyID: "k1", State: kmsapi.KeyStateEnabled,
+			}},
+		})
+
+		handler := lookupHandlerByMethod(t, cmd, KeyMetadataPath, http.MethodGet)
+
+		buf, code, err := sendRequestToHandler(handler, nil, KeysPath+"/k1/metadata")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		response := kms.KeyMetadataResponse{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Equal(t, "k1", response.KeyID)
+
+		handler = lookupHandlerByMethod(t, cmd, KeyMetadataPath, http.MethodPut)
+
+		_, code, err = sendRequestToHandler(handler, bytes.NewBufferString(`{"usage":["sign"]}`),
+			KeysPath+"/k1/metadata")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		buf, code, err = sendRequestToHandler(handler, bytes.NewBufferString(`[`), KeysPath+"/k1/metadata")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, kms.InvalidRequestErrorCode, "failed request decode", buf.Bytes())
+	})
+
+	t.Run("test list keys", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{
+			KMSValue: &mockkms.KeyManager{ListValue: []*kmsapi.KeyMetadata{{KeyID: "k1"}}},
+		})
+
+		handler := lookupHandlerByMethod(t, cmd, KeysPath, http.MethodGet)
+
+		buf, code, err := sendRequestToHandler(handler, nil, KeysPath+"?keyType=ED25519&label=team:issuance")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+
+		response := kms.ListKeysResponse{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &response))
+		require.Len(t, response.Keys, 1)
+
+		buf, code, err = sendRequestToHandler(handler, nil, KeysPath+"?label=team")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, kms.InvalidRequestErrorCode, "invalid label 'team'", buf.Bytes())
+	})
+}
+
+func TestRewrapKeys(t *testing.T) {
+	t.Run("test rewrap keys - success", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{})
+		cmd.command = &mockKMSCommand{}
+
+		handler := lookupHandler(t, cmd, RewrapKeysPath)
+
+		_, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{}`), RewrapKeysPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("test rewrap keys - error", func(t *testing.T) {
+		cmd := New(&mockprovider.Provider{})
+		cmd.command = &mockKMSCommand{rewrapKeysError: command.NewExecuteError(kms.RewrapKeysError,
+			fmt.Errorf("failed to rewrap keys"))}
+
+		handler := lookupHandler(t, cmd, RewrapKeysPath)
+
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{}`), RewrapKeysPath)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusInternalServerError, code)
+		verifyError(t, kms.RewrapKeysError, "failed to rewrap keys", buf.Bytes())
+	})
+}
+
 func lookupHandler(t *testing.T, op *Operation, path string) rest.Handler {
 	t.Helper()
 
+	return lookupHandlerByMethod(t, op, path, http.MethodPost)
+}
+
+func lookupHandlerByMethod(t *testing.T, op *Operation, path, method string) rest.Handler {
+	t.Helper()
+
 	handlers := op.GetRESTHandlers()
 	require.NotEmpty(t, handlers)
 
 	for _, h := range handlers {
-		if h.Path() == path && h.Method() == http.MethodPost {
+		if h.Path() == path && h.Method() == method {
 			return h
 		}
 	}
@@ -175,7 +294,8 @@ func verifyError(t *testing.T, expectedCode command.Code, expectedMsg string, da
 }
 
 type mockKMSCommand struct {
-	importKeyError command.Error
+	importKeyError  command.Error
+	rewrapKeysError command.Error
 }
 
 func (m *mockKMSCommand) CreateKeySet(rw io.Writer, req io.Reader) command.Error {
@@ -185,3 +305,35 @@ func (m *mockKMSCommand) CreateKeySet(rw io.Writer, req io.Reader) command.Error
 func (m *mockKMSCommand) ImportKey(rw io.Writer, req io.Reader) command.Error {
 	return m.importKeyError
 }
+
+func (m *mockKMSCommand) DeleteKey(rw io.Writer, req io.Reader) command.Error {
+	return nil
+}
+
+func (m *mockKMSCommand) DisableKey(rw io.Writer, req io.Reader) command.Error {
+	return nil
+}
+
+func (m *mockKMSCommand) EnableKey(rw io.Writer, req io.Reader) command.Error {
+	return nil
+}
+
+func (m *mockKMSCommand) DestroyKey(rw io.Writer, req io.Reader) command.Error {
+	return nil
+}
+
+func (m *mockKMSCommand) GetKeyMetadata(rw io.Writer, req io.Reader) command.Error {
+	return nil
+}
+
+func (m *mockKMSCommand) UpdateKeyMetadata(rw io.Writer, req io.Reader) command.Error {
+	return nil
+}
+
+func (m *mockKMSCommand) ListKeys(rw io.Writer, req io.Reader) command.Error {
+	return nil
+}
+
+func (m *mockKMSCommand) RewrapKeys(rw io.Writer, req io.Reader) command.Error {
+	return m.rewrapKeysError
+}
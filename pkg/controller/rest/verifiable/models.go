@@ -25,6 +25,41 @@ type validateCredentialReq struct { // nolint: unused,deadcode
 	Params verifiable.Credential
 }
 
+// verifyCredentialReq model
+//
+// This is used to verify the verifiable credential.
+//
+// swagger:parameters verifyCredentialReq
+type verifyCredentialReq struct { // nolint: unused,deadcode
+	// Params for verifying the verifiable credential (pass the vc document as a string)
+	//
+	// in: body
+	Params verifiable.Credential
+}
+
+// verifyPresentationReq model
+//
+// This is used to verify the verifiable presentation.
+//
+// swagger:parameters verifyPresentationReq
+type verifyPresentationReq struct { // nolint: unused,deadcode
+	// Params for verifying the verifiable presentation
+	//
+	// in: body
+	Params verifiable.Presentation
+}
+
+// verificationReportRes model
+//
+// This is used for returning the outcome of each check made to verify a credential or presentation.
+//
+// swagger:response verificationReportRes
+type verificationReportRes struct { // nolint: unused,deadcode
+
+	// in: body
+	verifiable.VerificationReport
+}
+
 // emptyRes model
 //
 // swagger:response emptyRes
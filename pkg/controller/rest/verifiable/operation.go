@@ -33,6 +33,7 @@ const (
 
 	// credential paths.
 	ValidateCredentialPath     = verifiableCredentialPath + "/validate"
+	VerifyCredentialPath       = verifiableCredentialPath + "/verify"
 	SaveCredentialPath         = verifiableCredentialPath
 	GetCredentialPath          = verifiableCredentialPath + "/{id}"
 	GetCredentialByNamePath    = verifiableCredentialPath + "/name" + "/{name}"
@@ -44,6 +45,7 @@ const (
 	// presentation paths.
 	GeneratePresentationPath     = verifiablePresentationPath + "/generate"
 	GeneratePresentationByIDPath = verifiablePresentationPath + "/generatebyid"
+	VerifyPresentationPath       = verifiablePresentationPath + "/verify"
 	SavePresentationPath         = verifiablePresentationPath
 	GetPresentationPath          = verifiablePresentationPath + "/{id}"
 	GetPresentationsPath         = VerifiableOperationID + "/presentations"
@@ -100,6 +102,8 @@ func (o *Operation) registerHandler() {
 		cmdutil.NewHTTPHandler(GetPresentationsPath, http.MethodGet, o.GetPresentations),
 		cmdutil.NewHTTPHandler(RemoveCredentialByNamePath, http.MethodPost, o.RemoveCredentialByName),
 		cmdutil.NewHTTPHandler(RemovePresentationByNamePath, http.MethodPost, o.RemovePresentationByName),
+		cmdutil.NewHTTPHandler(VerifyCredentialPath, http.MethodPost, o.VerifyCredential),
+		cmdutil.NewHTTPHandler(VerifyPresentationPath, http.MethodPost, o.VerifyPresentation),
 	}
 }
 
@@ -114,6 +118,28 @@ func (o *Operation) ValidateCredential(rw http.ResponseWriter, req *http.Request
 	rest.Execute(o.command.ValidateCredential, rw, req.Body)
 }
 
+// VerifyCredential swagger:route POST /verifiable/credential/verify verifiable verifyCredentialReq
+//
+// Verifies the verifiable credential and reports the outcome of each check.
+//
+// Responses:
+//    default: genericError
+//        200: verificationReportRes
+func (o *Operation) VerifyCredential(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.VerifyCredential, rw, req.Body)
+}
+
+// VerifyPresentation swagger:route POST /verifiable/presentation/verify verifiable verifyPresentationReq
+//
+// Verifies the verifiable presentation and its credentials, and reports the outcome of each check.
+//
+// Responses:
+//    default: genericError
+//        200: verificationReportRes
+func (o *Operation) VerifyPresentation(rw http.ResponseWriter, req *http.Request) {
+	rest.Execute(o.command.VerifyPresentation, rw, req.Body)
+}
+
 // SaveCredential swagger:route POST /verifiable/credential verifiable saveCredentialReq
 //
 // Saves the verifiable credential.
@@ -280,7 +280,7 @@ func TestNew(t *testing.T) {
 		})
 		require.NoError(t, err)
 		require.NotNil(t, cmd)
-		require.Equal(t, 14, len(cmd.GetRESTHandlers()))
+		require.Equal(t, 16, len(cmd.GetRESTHandlers()))
 	})
 
 	t.Run("test new command - error", func(t *testing.T) {
@@ -343,6 +343,97 @@ func TestValidateVC(t *testing.T) {
 	})
 }
 
+func TestVerifyVC(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		DocumentLoaderValue:  loader,
+		VDRegistryValue: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				return nil, errors.New("DID not found")
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	t.Run("test verify vc - success", func(t *testing.T) {
+		jsonStr, err := json.Marshal(verifiable.Credential{VerifiableCredential: vc})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, cmd, VerifyCredentialPath, http.MethodPost)
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+		require.NoError(t, err)
+
+		response := verificationReportRes{}
+		err = json.Unmarshal(buf.Bytes(), &response)
+		require.NoError(t, err)
+
+		require.False(t, response.Valid)
+		require.Len(t, response.Checks, 7)
+	})
+
+	t.Run("test verify vc - error", func(t *testing.T) {
+		handler := lookupHandler(t, cmd, VerifyCredentialPath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{"verifiableCredential":"--"}`),
+			handler.Path())
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.VerifyCredentialErrorCode, "verify vc", buf.Bytes())
+	})
+}
+
+func TestVerifyVP(t *testing.T) {
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	cmd, err := New(&mockprovider.Provider{
+		StorageProviderValue: mockstore.NewMockStoreProvider(),
+		DocumentLoaderValue:  loader,
+		VDRegistryValue: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				return nil, errors.New("DID not found")
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+
+	t.Run("test verify vp - success", func(t *testing.T) {
+		jsonStr, err := json.Marshal(verifiable.Presentation{
+			VerifiablePresentation: stringToJSONRaw(udVerifiablePresentation),
+		})
+		require.NoError(t, err)
+
+		handler := lookupHandler(t, cmd, VerifyPresentationPath, http.MethodPost)
+		buf, err := getSuccessResponseFromHandler(handler, bytes.NewBuffer(jsonStr), handler.Path())
+		require.NoError(t, err)
+
+		response := verificationReportRes{}
+		err = json.Unmarshal(buf.Bytes(), &response)
+		require.NoError(t, err)
+
+		require.False(t, response.Valid)
+		require.NotEmpty(t, response.Checks)
+		require.Len(t, response.Credentials, 1)
+	})
+
+	t.Run("test verify vp - error", func(t *testing.T) {
+		handler := lookupHandler(t, cmd, VerifyPresentationPath, http.MethodPost)
+		buf, code, err := sendRequestToHandler(handler, bytes.NewBufferString(`{"verifiablePresentation":"--"}`),
+			handler.Path())
+		require.NoError(t, err)
+		require.NotEmpty(t, buf)
+
+		require.Equal(t, http.StatusBadRequest, code)
+		verifyError(t, verifiable.VerifyPresentationErrorCode, "verify vp", buf.Bytes())
+	})
+}
+
 func TestSaveVC(t *testing.T) {
 	t.Run("test save vc - success", func(t *testing.T) {
 		loader, err := ldtestutil.DocumentLoader()
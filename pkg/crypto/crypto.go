@@ -22,6 +22,9 @@ import (
 // Crypto interface provides all crypto operations needed in the Aries framework.
 type Crypto = cryptoapi.Crypto
 
+// StreamingCrypto is an optional interface of Crypto services encrypting large payloads in segments with bounded memory.
+type StreamingCrypto = cryptoapi.StreamingCrypto
+
 // DefKeySize is the default key size for crypto primitives.
 const DefKeySize = crypto.DefKeySize
 
@@ -27,6 +27,18 @@ const (
 	ECDHESXC20PKWAlg = tinkcrypto.ECDHESXC20PKWAlg
 	// ECDH1PUXC20PKWAlg is the ECDH-1PU with XChacha20Poly1305 key wrapping algorithm.
 	ECDH1PUXC20PKWAlg = tinkcrypto.ECDH1PUXC20PKWAlg
+	// ECDHESX25519MLKEM768A256KWAlg is the ECDH-ES with hybrid X25519 and ML-KEM-768 key agreement and AES-GCM 256 key
+	// wrapping algorithm.
+	ECDHESX25519MLKEM768A256KWAlg = tinkcrypto.ECDHESX25519MLKEM768A256KWAlg
+	// ECDHESX25519MLKEM768XC20PKWAlg is the ECDH-ES with hybrid X25519 and ML-KEM-768 key agreement and
+	// XChacha20Poly1305 key wrapping algorithm.
+	ECDHESX25519MLKEM768XC20PKWAlg = tinkcrypto.ECDHESX25519MLKEM768XC20PKWAlg
+	// ECDH1PUX25519MLKEM768A256KWAlg is the ECDH-1PU with hybrid X25519 and ML-KEM-768 key agreement and AES-GCM 256
+	// key wrapping algorithm.
+	ECDH1PUX25519MLKEM768A256KWAlg = tinkcrypto.ECDH1PUX25519MLKEM768A256KWAlg
+	// ECDH1PUX25519MLKEM768XC20PKWAlg is the ECDH-1PU with hybrid X25519 and ML-KEM-768 key agreement and
+	// XChacha20Poly1305 key wrapping algorithm.
+	ECDH1PUX25519MLKEM768XC20PKWAlg = tinkcrypto.ECDH1PUX25519MLKEM768XC20PKWAlg
 )
 
 // Package tinkcrypto includes the default implementation of pkg/crypto. It uses Tink for executing crypto primitives
@@ -54,8 +54,29 @@ func New(p Provider) *Creator {
 	}
 }
 
+// CreatePeerDIDV2Option options for Creator.CreatePeerDIDV2.
+type CreatePeerDIDV2Option func(opts *createPeerDIDV2Opts)
+
+type createPeerDIDV2Opts struct {
+	numAlgo int
+}
+
+// WithNumAlgo option for creating a peer DID with the given numeric algorithm, one of peer.NumAlgo0, peer.NumAlgo1
+// (default), peer.NumAlgo2 or peer.NumAlgo4. Numalgo 0 DIDs only contain the key agreement key, without service.
+func WithNumAlgo(numAlgo int) CreatePeerDIDV2Option {
+	return func(opts *createPeerDIDV2Opts) {
+		opts.numAlgo = numAlgo
+	}
+}
+
 // CreatePeerDIDV2 create a peer DID suitable for use in DIDComm V2.
-func (s *Creator) CreatePeerDIDV2() (*did.Doc, error) {
+func (s *Creator) CreatePeerDIDV2(opts ...CreatePeerDIDV2Option) (*did.Doc, error) {
+	options := createPeerDIDV2Opts{numAlgo: peer.NumAlgo1}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	// TODO: add routing keys so edge agents can rotate (currently only cloud agents do)
 	newDID := &did.Doc{Service: []did.Service{{Type: vdrapi.DIDCommV2ServiceType}}}
 
@@ -67,7 +88,12 @@ func (s *Creator) CreatePeerDIDV2() (*did.Doc, error) {
 	// set KeyAgreement.ID as RecipientKeys as part of DIDComm V2 service
 	newDID.Service[0].RecipientKeys = []string{newDID.KeyAgreement[0].VerificationMethod.ID}
 
-	myDID, err := s.vdrRegistry.Create(peer.DIDMethod, newDID)
+	if options.numAlgo == peer.NumAlgo0 {
+		// a numalgo 0 peer DID has a single inception key: the key agreement key used by DIDComm V2.
+		newDID = &did.Doc{KeyAgreement: newDID.KeyAgreement}
+	}
+
+	myDID, err := s.vdrRegistry.Create(peer.DIDMethod, newDID, vdrapi.WithOption(peer.NumAlgo, options.numAlgo))
 	if err != nil {
 		return nil, fmt.Errorf("creating new peer DID via VDR failed: %w", err)
 	}
@@ -96,18 +122,19 @@ func (s *Creator) createNewKeyAndVM(didDoc *did.Doc) error {
 
 // nolint:gochecknoglobals
 var vmTypeMap = map[kms.KeyType]string{
-	kms.ED25519Type:            ed25519VerificationKey2018,
-	kms.BLS12381G2Type:         bls12381G2Key2020,
-	kms.ECDSAP256TypeDER:       jsonWebKey2020,
-	kms.ECDSAP256TypeIEEEP1363: jsonWebKey2020,
-	kms.ECDSAP384TypeDER:       jsonWebKey2020,
-	kms.ECDSAP384TypeIEEEP1363: jsonWebKey2020,
-	kms.ECDSAP521TypeDER:       jsonWebKey2020,
-	kms.ECDSAP521TypeIEEEP1363: jsonWebKey2020,
-	kms.X25519ECDHKWType:       x25519KeyAgreementKey2019,
-	kms.NISTP256ECDHKWType:     jsonWebKey2020,
-	kms.NISTP384ECDHKWType:     jsonWebKey2020,
-	kms.NISTP521ECDHKWType:     jsonWebKey2020,
+	kms.ED25519Type:              ed25519VerificationKey2018,
+	kms.BLS12381G2Type:           bls12381G2Key2020,
+	kms.ECDSAP256TypeDER:         jsonWebKey2020,
+	kms.ECDSAP256TypeIEEEP1363:   jsonWebKey2020,
+	kms.ECDSAP384TypeDER:         jsonWebKey2020,
+	kms.ECDSAP384TypeIEEEP1363:   jsonWebKey2020,
+	kms.ECDSAP521TypeDER:         jsonWebKey2020,
+	kms.ECDSAP521TypeIEEEP1363:   jsonWebKey2020,
+	kms.X25519ECDHKWType:         x25519KeyAgreementKey2019,
+	kms.NISTP256ECDHKWType:       jsonWebKey2020,
+	kms.NISTP384ECDHKWType:       jsonWebKey2020,
+	kms.NISTP521ECDHKWType:       jsonWebKey2020,
+	kms.X25519MLKEM768ECDHKWType: jsonWebKey2020,
 }
 
 func getVerMethodType(kt kms.KeyType) string {
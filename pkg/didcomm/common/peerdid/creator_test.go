@@ -0,0 +1,174 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peerdid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockstorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/peer"
+)
+
+const serviceEndpoint = "https://example.com/didcomm"
+
+func TestCreator_CreatePeerDIDV2(t *testing.T) {
+	for _, keyTypes := range []struct {
+		name             string
+		keyType          kms.KeyType
+		keyAgreementType kms.KeyType
+	}{
+		{"ED25519 and X25519", kms.ED25519Type, kms.X25519ECDHKWType},
+		{"P-256", kms.ECDSAP256TypeIEEEP1363, kms.NISTP256ECDHKWType},
+	} {
+		t.Run(keyTypes.name, func(t *testing.T) {
+			p := newProvider(t, keyTypes.keyType, keyTypes.keyAgreementType)
+			creator := New(p)
+
+			t.Run("numalgo 1 (default)", func(t *testing.T) {
+				doc, err := creator.CreatePeerDIDV2()
+				require.NoError(t, err)
+				require.True(t, strings.HasPrefix(doc.ID, "did:peer:1"), doc.ID)
+				requireDIDCommV2Service(t, doc)
+
+				resolved, err := p.VDRegistry().Resolve(doc.ID)
+				require.NoError(t, err)
+				require.Equal(t, doc.ID, resolved.DIDDocument.ID)
+			})
+
+			t.Run("numalgo 0", func(t *testing.T) {
+				doc, err := creator.CreatePeerDIDV2(WithNumAlgo(peer.NumAlgo0))
+				require.NoError(t, err)
+				require.True(t, strings.HasPrefix(doc.ID, "did:peer:0z"), doc.ID)
+				require.Empty(t, doc.Service)
+				require.Len(t, doc.KeyAgreement, 1)
+
+				resolved, err := p.VDRegistry().Resolve(doc.ID)
+				require.NoError(t, err)
+				require.Equal(t, doc.ID, resolved.DIDDocument.ID)
+				require.Empty(t, resolved.DIDDocument.Service)
+			})
+
+			t.Run("numalgo 2", func(t *testing.T) {
+				doc, err := creator.CreatePeerDIDV2(WithNumAlgo(peer.NumAlgo2))
+				require.NoError(t, err)
+				require.True(t, strings.HasPrefix(doc.ID, "did:peer:2."), doc.ID)
+				require.Len(t, doc.Authentication, 1)
+				requireDIDCommV2Service(t, doc)
+
+				resolved, err := p.VDRegistry().Resolve(doc.ID)
+				require.NoError(t, err)
+				require.Equal(t, doc, resolved.DIDDocument)
+			})
+
+			t.Run("numalgo 4", func(t *testing.T) {
+				doc, err := creator.CreatePeerDIDV2(WithNumAlgo(peer.NumAlgo4))
+				require.NoError(t, err)
+				require.True(t, strings.HasPrefix(doc.ID, "did:peer:4zQm"), doc.ID)
+				require.Len(t, doc.AlsoKnownAs, 1)
+				requireDIDCommV2Service(t, doc)
+
+				resolved, err := p.VDRegistry().Resolve(doc.AlsoKnownAs[0])
+				require.NoError(t, err)
+				require.Equal(t, []string{doc.ID}, resolved.DIDDocument.AlsoKnownAs)
+			})
+
+			t.Run("unsupported numalgo", func(t *testing.T) {
+				_, err := creator.CreatePeerDIDV2(WithNumAlgo(3))
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "creating new peer DID via VDR failed")
+			})
+		})
+	}
+
+	t.Run("fail with unsupported key type", func(t *testing.T) {
+		_, err := New(newProvider(t, kms.HMACSHA256Tag256Type, kms.X25519ECDHKWType)).CreatePeerDIDV2()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "creating new keys and VMS for DID document failed")
+	})
+}
+
+func requireDIDCommV2Service(t *testing.T, doc *did.Doc) {
+	t.Helper()
+
+	require.Len(t, doc.Service, 1)
+	require.Equal(t, vdrapi.DIDCommV2ServiceType, doc.Service[0].Type)
+	require.Len(t, doc.Service[0].RecipientKeys, 1)
+	require.Len(t, doc.KeyAgreement, 1)
+	require.True(t, strings.HasSuffix(doc.Service[0].RecipientKeys[0], "#key-2"), doc.Service[0].RecipientKeys[0])
+
+	uri, err := doc.Service[0].ServiceEndpoint.URI()
+	require.NoError(t, err)
+	require.Equal(t, serviceEndpoint, uri)
+}
+
+type provider struct {
+	vdrRegistry      vdrapi.Registry
+	kms              kms.KeyManager
+	keyType          kms.KeyType
+	keyAgreementType kms.KeyType
+}
+
+func newProvider(t *testing.T, keyType, keyAgreementType kms.KeyType) *provider {
+	t.Helper()
+
+	storeProvider := mockstorage.NewMockStoreProvider()
+
+	kmsStore, err := kms.NewAriesProviderWrapper(storeProvider)
+	require.NoError(t, err)
+
+	km, err := localkms.New("local-lock://test/key-uri/", &kmsProvider{kmsStore: kmsStore})
+	require.NoError(t, err)
+
+	peerVDR, err := peer.New(storeProvider)
+	require.NoError(t, err)
+
+	return &provider{
+		vdrRegistry: vdr.New(vdr.WithVDR(peerVDR), vdr.WithDefaultServiceType(vdrapi.DIDCommV2ServiceType),
+			vdr.WithDefaultServiceEndpoint(serviceEndpoint)),
+		kms:              km,
+		keyType:          keyType,
+		keyAgreementType: keyAgreementType,
+	}
+}
+
+func (p *provider) VDRegistry() vdrapi.Registry {
+	return p.vdrRegistry
+}
+
+func (p *provider) KMS() kms.KeyManager {
+	return p.kms
+}
+
+func (p *provider) KeyType() kms.KeyType {
+	return p.keyType
+}
+
+func (p *provider) KeyAgreementType() kms.KeyType {
+	return p.keyAgreementType
+}
+
+type kmsProvider struct {
+	kmsStore kms.Store
+}
+
+func (k *kmsProvider) StorageProvider() kms.Store {
+	return k.kmsStore
+}
+
+func (k *kmsProvider) SecretLock() secretlock.Service {
+	return &noop.NoLock{}
+}
@@ -246,6 +246,10 @@ func getKMSKeyType(keyType, curve string) kms.KeyType {
 			return kms.NISTP521ECDHKWType
 		}
 	case "OKP":
+		if curve == "X25519MLKEM768" {
+			return kms.X25519MLKEM768ECDHKWType
+		}
+
 		return kms.X25519ECDHKWType
 	}
 
@@ -746,6 +746,80 @@ func TestPackager_PackMessage_KeyAgreementID_Failures(t *testing.T) {
 	}
 }
 
+func TestPackager_PackMessage_X25519MLKEM768KeyAgreement(t *testing.T) {
+	cryptoSvc, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	customKMS, err := localkms.New(localKeyURI, newMockKMSProvider(mockstorage.NewMockStoreProvider(), t))
+	require.NoError(t, err)
+
+	// hybrid keys have no did:key representation, they are only resolvable as JsonWebKey2020 DID doc keyAgreements.
+	newDIDDoc := func(name string) *did.Doc {
+		_, pubKey, e := customKMS.CreateAndExportPubKeyBytes(kms.X25519MLKEM768ECDHKWType)
+		require.NoError(t, e)
+
+		j, e := jwkkid.BuildJWK(pubKey, kms.X25519MLKEM768ECDHKWType)
+		require.NoError(t, e)
+
+		didDoc := mockdiddoc.GetMockDIDDocWithDIDCommV2Bloc(t, name)
+		ka, e := did.NewVerificationMethodFromJWK(
+			didDoc.KeyAgreement[0].VerificationMethod.ID, "JsonWebKey2020", didDoc.ID, j)
+		require.NoError(t, e)
+
+		didDoc.KeyAgreement = []did.Verification{{VerificationMethod: *ka}}
+
+		return didDoc
+	}
+
+	fromDID := newDIDDoc("alicedid")
+	toDID := newDIDDoc("bobdid")
+
+	mockedProviders := &mockProvider{
+		kms:    customKMS,
+		crypto: cryptoSvc,
+		vdr: &mockvdr.MockVDRegistry{
+			ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+				switch didID {
+				case toDID.ID:
+					return &did.DocResolution{DIDDocument: toDID}, nil
+				case fromDID.ID:
+					return &did.DocResolution{DIDDocument: fromDID}, nil
+				default:
+					return nil, fmt.Errorf("did not found: %s", didID)
+				}
+			},
+		},
+	}
+
+	testPacker, err := authcrypt.New(mockedProviders, jose.XC20P)
+	require.NoError(t, err)
+
+	mockedProviders.primaryPacker = testPacker
+	mockedProviders.packers = []packer.Packer{testPacker}
+
+	packager, err := New(mockedProviders)
+	require.NoError(t, err)
+
+	packMsg, err := packager.PackMessage(&transport.Envelope{
+		MediaTypeProfile: transport.MediaTypeDIDCommV2Profile,
+		Message:          []byte("msg"),
+		FromKey:          []byte(fromDID.KeyAgreement[0].VerificationMethod.ID),
+		ToKeys:           []string{toDID.KeyAgreement[0].VerificationMethod.ID},
+	})
+	require.NoError(t, err)
+
+	jwe, err := jose.Deserialize(string(packMsg))
+	require.NoError(t, err)
+
+	alg, ok := jwe.ProtectedHeaders.Algorithm()
+	require.True(t, ok)
+	require.Equal(t, tinkcrypto.ECDH1PUX25519MLKEM768XC20PKWAlg, alg)
+
+	unpackedMsg, err := packager.UnpackMessage(packMsg)
+	require.NoError(t, err)
+	require.Equal(t, []byte("msg"), unpackedMsg.Message)
+}
+
 type resolverFunc func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error)
 
 //nolint:lll
@@ -211,6 +211,77 @@ func TestService_Use(t *testing.T) {
 	})
 }
 
+func TestService_AddMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newService := func() *Service {
+		storeProvider := storageMocks.NewMockProvider(ctrl)
+		storeProvider.EXPECT().OpenStore(gomock.Any()).Return(nil, nil).Times(1)
+		storeProvider.EXPECT().SetStoreConfig(Name, gomock.Any()).Return(nil)
+
+		provider := presentproofMocks.NewMockProvider(ctrl)
+		provider.EXPECT().Messenger().Return(nil)
+		provider.EXPECT().StorageProvider().Return(storeProvider).Times(2)
+
+		svc, err := New(provider)
+		require.NoError(t, err)
+		require.NotNil(t, svc)
+
+		return svc
+	}
+
+	named := func(name string, executed *[]string) Middleware {
+		return func(next Handler) Handler {
+			return HandlerFunc(func(metadata Metadata) error {
+				*executed = append(*executed, name)
+
+				return next.Handle(metadata)
+			})
+		}
+	}
+
+	t.Run("Success (runs before the existing middleware)", func(t *testing.T) {
+		svc := newService()
+
+		var executed []string
+
+		svc.Use(named("used", &executed))
+		svc.AddMiddleware(named("first", &executed), named("second", &executed))
+
+		_, _, err := svc.execute(&done{}, &metaData{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"first", "second", "used"}, executed)
+
+		executed = nil
+
+		svc.AddMiddleware(named("third", &executed))
+
+		_, _, err = svc.execute(&done{}, &metaData{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"third", "first", "second", "used"}, executed)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		svc := newService()
+
+		var executed []string
+
+		svc.Use(named("used", &executed))
+
+		const msgErr = "error message"
+		svc.AddMiddleware(func(next Handler) Handler {
+			return HandlerFunc(func(metadata Metadata) error {
+				return errors.New(msgErr)
+			})
+		})
+
+		_, _, err := svc.execute(&done{}, &metaData{})
+		require.EqualError(t, err, "middleware: "+msgErr)
+		require.Empty(t, executed)
+	})
+}
+
 func TestService_ActionContinue(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
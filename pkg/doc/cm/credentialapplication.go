@@ -152,9 +152,9 @@ func (ca *CredentialApplication) ValidateAgainstCredentialManifest(cm *Credentia
 func (ca *CredentialApplication) standardUnmarshal(data []byte) error {
 	// The type alias below is used as to allow the standard json.Unmarshal to be called within a custom unmarshal
 	// function without causing infinite recursion. See https://stackoverflow.com/a/43178272 for more information.
-	type credentialApplicationWithoutMethods *CredentialApplication
+	type credentialApplicationWithoutMethods CredentialApplication
 
-	err := json.Unmarshal(data, credentialApplicationWithoutMethods(ca))
+	err := json.Unmarshal(data, (*credentialApplicationWithoutMethods)(ca))
 	if err != nil {
 		return err
 	}
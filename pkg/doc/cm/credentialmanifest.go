@@ -240,9 +240,9 @@ func (cm *CredentialManifest) hasFormat() bool {
 func (cm *CredentialManifest) standardUnmarshal(data []byte) error {
 	// The type alias below is used as to allow the standard json.Unmarshal to be called within a custom unmarshal
 	// function without causing infinite recursion. See https://stackoverflow.com/a/43178272 for more information.
-	type credentialManifestAliasWithoutMethods *CredentialManifest
+	type credentialManifestAliasWithoutMethods CredentialManifest
 
-	err := json.Unmarshal(data, credentialManifestAliasWithoutMethods(cm))
+	err := json.Unmarshal(data, (*credentialManifestAliasWithoutMethods)(cm))
 	if err != nil {
 		return err
 	}
@@ -94,9 +94,9 @@ func (cf *CredentialResponse) ResolveDescriptorMaps(jsonDataFromAttachment inter
 func (cf *CredentialResponse) standardUnmarshal(data []byte) error {
 	// The type alias below is used as to allow the standard json.Unmarshal to be called within a custom unmarshal
 	// function without causing infinite recursion. See https://stackoverflow.com/a/43178272 for more information.
-	type credentialResponseWithoutMethods *CredentialResponse
+	type credentialResponseWithoutMethods CredentialResponse
 
-	err := json.Unmarshal(data, credentialResponseWithoutMethods(cf))
+	err := json.Unmarshal(data, (*credentialResponseWithoutMethods)(cf))
 	if err != nil {
 		return err
 	}
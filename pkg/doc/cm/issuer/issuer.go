@@ -0,0 +1,294 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/jsonld"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/signer"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/suite/jsonwebsignature2020"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/kmssigner"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+const (
+	// Ed25519Signature2018 is the Ed25519Signature2018 linked data proof type.
+	Ed25519Signature2018 = "Ed25519Signature2018"
+	// JSONWebSignature2020 is the JsonWebSignature2020 linked data proof type.
+	JSONWebSignature2020 = "JsonWebSignature2020"
+
+	jsonWebSignature2020Context = "https://w3id.org/security/suites/jws-2020/v1"
+
+	credentialApplicationField = "credential_application"
+	credentialResponseField    = "credential_response"
+
+	assertionMethod = "assertionMethod"
+	authentication  = "authentication"
+)
+
+// Provider contains dependencies for the Credential Manifest issuer and is typically created by using
+// aries.Context().
+type Provider interface {
+	KMS() kms.KeyManager
+	Crypto() crypto.Crypto
+	JSONLDDocumentLoader() ld.DocumentLoader
+}
+
+// Issuer evaluates Credential Applications submitted against a Credential Manifest and answers them with signed
+// Credential Responses.
+type Issuer struct {
+	manifest       *cm.CredentialManifest
+	km             kms.KeyManager
+	crypto         crypto.Crypto
+	documentLoader ld.DocumentLoader
+	opts           *options
+}
+
+// New returns a new Credential Manifest issuer for the given manifest. The issuer signs credentials and responses
+// with the key set by WithSigningKey and needs a Template for every Output Descriptor of the manifest.
+func New(manifest *cm.CredentialManifest, p Provider, opts ...Opt) (*Issuer, error) {
+	if manifest == nil {
+		return nil, errors.New("credential manifest argument cannot be nil")
+	}
+
+	err := manifest.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid credential manifest: %w", err)
+	}
+
+	o := &options{
+		templates:     map[string]Template{},
+		signatureType: Ed25519Signature2018,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.keyID == "" || o.verificationMethod == "" {
+		return nil, errors.New("missing signing key")
+	}
+
+	for _, descriptor := range manifest.OutputDescriptors {
+		if _, ok := o.templates[descriptor.ID]; !ok {
+			return nil, fmt.Errorf("missing template for output descriptor '%s'", descriptor.ID)
+		}
+	}
+
+	return &Issuer{
+		manifest:       manifest,
+		km:             p.KMS(),
+		crypto:         p.Crypto(),
+		documentLoader: p.JSONLDDocumentLoader(),
+		opts:           o,
+	}, nil
+}
+
+// Respond validates the given Credential Application against the issuer's Credential Manifest, issues a credential
+// for every Output Descriptor by mapping the submitted claims through its Template and returns a signed Credential
+// Response presentation holding them.
+// The proof of the application presentation itself is not checked here, it is expected to be verified when parsing it.
+func (i *Issuer) Respond(application *verifiable.Presentation, opts ...RespondOpt) (*verifiable.Presentation, error) {
+	o := &respondOptions{}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	ca, submission, err := i.evaluate(application, o.matchOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := make([]*verifiable.Credential, len(i.manifest.OutputDescriptors))
+
+	for idx, descriptor := range i.manifest.OutputDescriptors {
+		vc, e := i.issue(descriptor, submission)
+		if e != nil {
+			return nil, fmt.Errorf("failed to issue credential for output descriptor '%s': %w", descriptor.ID, e)
+		}
+
+		credentials[idx] = vc
+	}
+
+	response, err := cm.PresentCredentialResponse(i.manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create credential response: %w", err)
+	}
+
+	credentialResponse, ok := response.CustomFields[credentialResponseField].(cm.CredentialResponse)
+	if !ok {
+		return nil, errors.New("invalid credential response")
+	}
+
+	credentialResponse.ApplicationID = ca.ID
+	response.CustomFields[credentialResponseField] = credentialResponse
+	response.Holder = i.manifest.Issuer.ID
+
+	response.AddCredentials(credentials...)
+
+	err = i.addLinkedDataProof(response, authentication, o.challenge, o.domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign credential response: %w", err)
+	}
+
+	return response, nil
+}
+
+// evaluate validates the application and collects the data its templates are applied to. It mirrors
+// cm.ValidateCredentialApplication, but keeps the credentials matched against the presentation definition.
+func (i *Issuer) evaluate(application *verifiable.Presentation,
+	options ...presexch.MatchOption) (*cm.CredentialApplication, *Submission, error) {
+	if application == nil {
+		return nil, nil, errors.New("credential application argument cannot be nil")
+	}
+
+	caRaw, ok := application.CustomFields[credentialApplicationField]
+	if !ok {
+		return nil, nil, errors.New("invalid credential application, missing 'credential_application'")
+	}
+
+	caBytes, err := json.Marshal(caRaw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal credential application: %w", err)
+	}
+
+	var ca cm.CredentialApplication
+
+	err = json.Unmarshal(caBytes, &ca)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal credential application: %w", err)
+	}
+
+	err = ca.ValidateAgainstCredentialManifest(i.manifest)
+	if err != nil {
+		return nil, nil, fmt.Errorf("credential application does not match credential manifest: %w", err)
+	}
+
+	submission := &Submission{
+		Holder:      application.Holder,
+		Credentials: map[string]*verifiable.Credential{},
+	}
+
+	if i.manifest.PresentationDefinition == nil {
+		return &ca, submission, nil
+	}
+
+	matched, err := i.manifest.PresentationDefinition.Match([]*verifiable.Presentation{application},
+		i.documentLoader, options...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("credential application does not satisfy presentation definition: %w", err)
+	}
+
+	for id, match := range matched {
+		submission.Credentials[id] = match.Credential
+	}
+
+	return &ca, submission, nil
+}
+
+func (i *Issuer) issue(descriptor *cm.OutputDescriptor, submission *Submission) (*verifiable.Credential, error) {
+	vc, err := i.opts.templates[descriptor.ID].Credential(descriptor, submission)
+	if err != nil {
+		return nil, fmt.Errorf("apply template: %w", err)
+	}
+
+	if vc.ID == "" {
+		vc.ID = "urn:uuid:" + uuid.New().String()
+	}
+
+	if vc.Issuer.ID == "" {
+		vc.Issuer.ID = i.manifest.Issuer.ID
+	}
+
+	if vc.Issued == nil {
+		vc.Issued = util.NewTime(time.Now().UTC())
+	}
+
+	err = i.addLinkedDataProof(vc, assertionMethod, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	return vc, nil
+}
+
+type provable interface {
+	AddLinkedDataProof(context *verifiable.LinkedDataProofContext, jsonldOpts ...jsonld.ProcessorOpts) error
+}
+
+func (i *Issuer) addLinkedDataProof(p provable, purpose, challenge, domain string) error {
+	kh, err := i.km.Get(i.opts.keyID)
+	if err != nil {
+		return fmt.Errorf("failed to get signing key: %w", err)
+	}
+
+	_, kt, err := i.km.ExportPubKeyBytes(i.opts.keyID)
+	if err != nil {
+		return fmt.Errorf("failed to export signing key: %w", err)
+	}
+
+	s := &kmssigner.KMSSigner{KeyType: kt, KeyHandle: kh, Crypto: i.crypto}
+
+	var signatureSuite signer.SignatureSuite
+
+	switch i.opts.signatureType {
+	case Ed25519Signature2018:
+		signatureSuite = ed25519signature2018.New(suite.WithSigner(s))
+	case JSONWebSignature2020:
+		addContext(p, jsonWebSignature2020Context)
+
+		signatureSuite = jsonwebsignature2020.New(suite.WithSigner(s))
+	default:
+		return fmt.Errorf("unsupported signature type '%s'", i.opts.signatureType)
+	}
+
+	return p.AddLinkedDataProof(&verifiable.LinkedDataProofContext{
+		SignatureType:           i.opts.signatureType,
+		SignatureRepresentation: verifiable.SignatureJWS,
+		Suite:                   signatureSuite,
+		VerificationMethod:      i.opts.verificationMethod,
+		Purpose:                 purpose,
+		Challenge:               challenge,
+		Domain:                  domain,
+	}, jsonld.WithDocumentLoader(i.documentLoader))
+}
+
+func addContext(p provable, ldcontext string) {
+	var context *[]string
+
+	switch v := p.(type) {
+	case *verifiable.Credential:
+		context = &v.Context
+	case *verifiable.Presentation:
+		context = &v.Context
+	default:
+		return
+	}
+
+	for _, ctx := range *context {
+		if ctx == ldcontext {
+			return
+		}
+	}
+
+	*context = append(*context, ldcontext)
+}
@@ -0,0 +1,322 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuer
+
+import (
+	"crypto/ed25519"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	sigverifier "github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/ldtestutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+const (
+	outputDescriptorID = "driver_license_output"
+	citizenshipContext = "https://w3id.org/citizenship/v1"
+)
+
+var (
+	//go:embed testdata/credential_manifest.json
+	credentialManifest []byte //nolint:gochecknoglobals
+	//go:embed testdata/credential_application.json
+	credentialApplication []byte //nolint:gochecknoglobals
+)
+
+func TestIssuer_Respond(t *testing.T) {
+	p, kid, verificationMethod, pubKey := newProvider(t)
+
+	template := &ClaimMappingTemplate{
+		Context: []string{citizenshipContext},
+		Types:   []string{"PermanentResidentCard"},
+		Claims: map[string]string{
+			"givenName":  "$.credentials.prc_input.credentialSubject.givenName",
+			"familyName": "$.credentials.prc_input.credentialSubject.familyName",
+			"birthDate":  "$.credentials.prc_input.credentialSubject.birthDate",
+		},
+		Validity: 24 * time.Hour,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		for _, signatureType := range []string{Ed25519Signature2018, JSONWebSignature2020} {
+			i, err := New(newManifest(t), p, WithSigningKey(kid, verificationMethod),
+				WithSignatureType(signatureType), WithTemplate(outputDescriptorID, template))
+			require.NoError(t, err)
+
+			response, err := i.Respond(newApplication(t, p), WithMatchOptions(matchOptions(p)...),
+				WithChallenge("challenge", "domain"))
+			require.NoError(t, err)
+
+			responseBytes, err := response.MarshalJSON()
+			require.NoError(t, err)
+
+			fetcher := publicKeyFetcher(t, signatureType, pubKey)
+
+			parsed, err := verifiable.ParsePresentation(responseBytes,
+				verifiable.WithPresPublicKeyFetcher(fetcher),
+				verifiable.WithPresJSONLDDocumentLoader(p.JSONLDDocumentLoader()))
+			require.NoError(t, err)
+			require.Contains(t, parsed.Type, "CredentialResponse")
+			require.Equal(t, "did:example:123?linked-domains=3", parsed.Holder)
+			require.Len(t, parsed.Proofs, 1)
+			require.Equal(t, "authentication", parsed.Proofs[0]["proofPurpose"])
+			require.Equal(t, "challenge", parsed.Proofs[0]["challenge"])
+			require.Equal(t, signatureType, parsed.Proofs[0]["type"])
+
+			var credentialResponse cm.CredentialResponse
+
+			responseJSON, err := json.Marshal(parsed.CustomFields["credential_response"])
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(responseJSON, &credentialResponse))
+			require.Equal(t, "dcc75a16-19f5-4273-84ce-4da69ee2b7fe", credentialResponse.ManifestID)
+			require.Equal(t, "9b1deb4d-3b7d-4bad-9bdd-2b0d7b3dcb6d", credentialResponse.ApplicationID)
+			require.Len(t, credentialResponse.OutputDescriptorMappingObjects, 1)
+			require.Equal(t, outputDescriptorID, credentialResponse.OutputDescriptorMappingObjects[0].ID)
+
+			var responseMap map[string]interface{}
+
+			require.NoError(t, json.Unmarshal(responseBytes, &responseMap))
+
+			vcs, err := credentialResponse.ResolveDescriptorMaps(responseMap,
+				verifiable.WithPublicKeyFetcher(fetcher),
+				verifiable.WithJSONLDDocumentLoader(p.JSONLDDocumentLoader()))
+			require.NoError(t, err)
+			require.Len(t, vcs, 1)
+
+			vc := vcs[0]
+			require.Equal(t, []string{"VerifiableCredential", "PermanentResidentCard"}, vc.Types)
+			require.Equal(t, "did:example:123?linked-domains=3", vc.Issuer.ID)
+			require.NotEmpty(t, vc.ID)
+			require.NotNil(t, vc.Expired)
+			require.Equal(t, "assertionMethod", vc.Proofs[0]["proofPurpose"])
+			require.Empty(t, vc.Proofs[0]["challenge"])
+
+			subjects, ok := vc.Subject.([]verifiable.Subject)
+			require.True(t, ok)
+			require.Len(t, subjects, 1)
+			require.Equal(t, "Louis", subjects[0].CustomFields["givenName"])
+			require.Equal(t, "Pasteur", subjects[0].CustomFields["familyName"])
+			require.Equal(t, "1958-07-17", subjects[0].CustomFields["birthDate"])
+		}
+	})
+
+	t.Run("success with template func and no presentation definition", func(t *testing.T) {
+		manifest := newManifest(t)
+		manifest.PresentationDefinition = nil
+
+		i, err := New(manifest, p, WithSigningKey(kid, verificationMethod),
+			WithTemplate(outputDescriptorID, TemplateFunc(
+				func(descriptor *cm.OutputDescriptor, submission *Submission) (*verifiable.Credential, error) {
+					require.Equal(t, outputDescriptorID, descriptor.ID)
+					require.Empty(t, submission.Credentials)
+
+					return &verifiable.Credential{
+						Context: []string{baseContext, citizenshipContext},
+						ID:      "http://example.edu/credentials/1872",
+						Types:   []string{baseType, "PermanentResidentCard"},
+						Subject: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+					}, nil
+				})))
+		require.NoError(t, err)
+
+		response, err := i.Respond(newApplication(t, p))
+		require.NoError(t, err)
+		require.Len(t, response.Credentials(), 1)
+
+		vc, ok := response.Credentials()[0].(*verifiable.Credential)
+		require.True(t, ok)
+		require.Equal(t, "http://example.edu/credentials/1872", vc.ID)
+		require.NotNil(t, vc.Issued)
+		require.Len(t, vc.Proofs, 1)
+	})
+
+	t.Run("failures", func(t *testing.T) {
+		_, err := New(nil, p)
+		require.EqualError(t, err, "credential manifest argument cannot be nil")
+
+		_, err = New(&cm.CredentialManifest{}, p)
+		require.ErrorContains(t, err, "invalid credential manifest")
+
+		_, err = New(newManifest(t), p, WithTemplate(outputDescriptorID, template))
+		require.EqualError(t, err, "missing signing key")
+
+		_, err = New(newManifest(t), p, WithSigningKey(kid, verificationMethod))
+		require.EqualError(t, err, "missing template for output descriptor 'driver_license_output'")
+
+		i, err := New(newManifest(t), p, WithSigningKey(kid, verificationMethod),
+			WithTemplate(outputDescriptorID, template))
+		require.NoError(t, err)
+
+		_, err = i.Respond(nil)
+		require.EqualError(t, err, "credential application argument cannot be nil")
+
+		application := newApplication(t, p)
+		delete(application.CustomFields, "credential_application")
+
+		_, err = i.Respond(application)
+		require.EqualError(t, err, "invalid credential application, missing 'credential_application'")
+
+		application = newApplication(t, p)
+		application.CustomFields["credential_application"] = map[string]interface{}{"id": "app"}
+
+		_, err = i.Respond(application)
+		require.ErrorContains(t, err, "failed to unmarshal credential application")
+
+		application = newApplication(t, p)
+		application.CustomFields["credential_application"] = map[string]interface{}{
+			"id":          "app",
+			"manifest_id": "other",
+		}
+
+		_, err = i.Respond(application)
+		require.ErrorContains(t, err, "credential application does not match credential manifest")
+
+		application = newApplication(t, p)
+		delete(application.CustomFields, "presentation_submission")
+
+		_, err = i.Respond(application, WithMatchOptions(matchOptions(p)...))
+		require.ErrorContains(t, err, "credential application does not satisfy presentation definition")
+
+		i, err = New(newManifest(t), p, WithSigningKey(kid, verificationMethod),
+			WithTemplate(outputDescriptorID, &ClaimMappingTemplate{
+				Claims: map[string]string{"licenseNumber": "$.credentials.prc_input.credentialSubject.licenseNumber"},
+			}))
+		require.NoError(t, err)
+
+		_, err = i.Respond(newApplication(t, p), WithMatchOptions(matchOptions(p)...))
+		require.ErrorContains(t, err, "failed to issue credential for output descriptor 'driver_license_output'")
+		require.ErrorContains(t, err, "failed to map claim 'licenseNumber'")
+
+		i, err = New(newManifest(t), p, WithSigningKey(kid, verificationMethod),
+			WithTemplate(outputDescriptorID, TemplateFunc(
+				func(*cm.OutputDescriptor, *Submission) (*verifiable.Credential, error) {
+					return nil, errors.New("template error")
+				})))
+		require.NoError(t, err)
+
+		_, err = i.Respond(newApplication(t, p), WithMatchOptions(matchOptions(p)...))
+		require.ErrorContains(t, err, "apply template: template error")
+
+		i, err = New(newManifest(t), p, WithSigningKey(kid, verificationMethod),
+			WithSignatureType("BbsBlsSignature2020"), WithTemplate(outputDescriptorID, template))
+		require.NoError(t, err)
+
+		_, err = i.Respond(newApplication(t, p), WithMatchOptions(matchOptions(p)...))
+		require.ErrorContains(t, err, "unsupported signature type 'BbsBlsSignature2020'")
+
+		i, err = New(newManifest(t), p, WithSigningKey("unknown", verificationMethod),
+			WithTemplate(outputDescriptorID, template))
+		require.NoError(t, err)
+
+		_, err = i.Respond(newApplication(t, p), WithMatchOptions(matchOptions(p)...))
+		require.ErrorContains(t, err, "failed to get signing key")
+	})
+}
+
+func TestClaimMappingTemplate_Credential(t *testing.T) {
+	_, err := (&ClaimMappingTemplate{}).Credential(nil, nil)
+	require.EqualError(t, err, "missing submission")
+
+	vc, err := (&ClaimMappingTemplate{
+		Claims: map[string]string{"holder": "$.holder"},
+	}).Credential(nil, &Submission{Holder: "did:example:holder"})
+	require.NoError(t, err)
+	require.Equal(t, []string{baseContext}, vc.Context)
+	require.Equal(t, []string{baseType}, vc.Types)
+	require.Nil(t, vc.Expired)
+	require.Equal(t, map[string]interface{}{
+		"id":     "did:example:holder",
+		"holder": "did:example:holder",
+	}, vc.Subject)
+}
+
+func newProvider(t *testing.T) (*mockprovider.Provider, string, string, []byte) {
+	t.Helper()
+
+	cr, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	kmsProvider, err := mockkms.NewProviderForKMS(storage.NewMockStoreProvider(), &noop.NoLock{})
+	require.NoError(t, err)
+
+	km, err := localkms.New("local-lock://custom/master/key/", kmsProvider)
+	require.NoError(t, err)
+
+	loader, err := ldtestutil.DocumentLoader()
+	require.NoError(t, err)
+
+	kid, pubKey, err := km.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	_, verificationMethod := fingerprint.CreateDIDKey(pubKey)
+
+	return &mockprovider.Provider{
+		KMSValue:            km,
+		CryptoValue:         cr,
+		DocumentLoaderValue: loader,
+	}, kid, verificationMethod, pubKey
+}
+
+func publicKeyFetcher(t *testing.T, signatureType string, pubKey []byte) verifiable.PublicKeyFetcher {
+	t.Helper()
+
+	if signatureType != JSONWebSignature2020 {
+		return verifiable.SingleKey(pubKey, kms.ED25519)
+	}
+
+	j, err := jwksupport.JWKFromKey(ed25519.PublicKey(pubKey))
+	require.NoError(t, err)
+
+	return func(string, string) (*sigverifier.PublicKey, error) {
+		return &sigverifier.PublicKey{Type: "JsonWebKey2020", JWK: j}, nil
+	}
+}
+
+func newManifest(t *testing.T) *cm.CredentialManifest {
+	t.Helper()
+
+	var manifest cm.CredentialManifest
+
+	require.NoError(t, json.Unmarshal(credentialManifest, &manifest))
+
+	return &manifest
+}
+
+func newApplication(t *testing.T, p *mockprovider.Provider) *verifiable.Presentation {
+	t.Helper()
+
+	application, err := verifiable.ParsePresentation(credentialApplication,
+		verifiable.WithPresDisabledProofCheck(),
+		verifiable.WithPresJSONLDDocumentLoader(p.JSONLDDocumentLoader()))
+	require.NoError(t, err)
+
+	return application
+}
+
+func matchOptions(p *mockprovider.Provider) []presexch.MatchOption {
+	return []presexch.MatchOption{
+		presexch.WithCredentialOptions(verifiable.WithJSONLDDocumentLoader(p.JSONLDDocumentLoader()),
+			verifiable.WithDisabledProofCheck()),
+	}
+}
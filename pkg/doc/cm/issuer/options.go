@@ -0,0 +1,67 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuer
+
+import "github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+
+type options struct {
+	templates          map[string]Template
+	keyID              string
+	verificationMethod string
+	signatureType      string
+}
+
+// Opt is an option for the Credential Manifest issuer.
+type Opt func(opts *options)
+
+// WithTemplate sets the Template that builds the credential issued for the Output Descriptor with the given ID.
+func WithTemplate(outputDescriptorID string, template Template) Opt {
+	return func(opts *options) {
+		opts.templates[outputDescriptorID] = template
+	}
+}
+
+// WithSigningKey sets the ID of the KMS key signing the issued credentials and the Credential Response,
+// along with the verification method its public key is published under.
+func WithSigningKey(keyID, verificationMethod string) Opt {
+	return func(opts *options) {
+		opts.keyID = keyID
+		opts.verificationMethod = verificationMethod
+	}
+}
+
+// WithSignatureType sets the linked data proof type, Ed25519Signature2018 (default) or JsonWebSignature2020.
+func WithSignatureType(signatureType string) Opt {
+	return func(opts *options) {
+		opts.signatureType = signatureType
+	}
+}
+
+type respondOptions struct {
+	matchOptions []presexch.MatchOption
+	challenge    string
+	domain       string
+}
+
+// RespondOpt is an option for Issuer.Respond.
+type RespondOpt func(opts *respondOptions)
+
+// WithMatchOptions sets the options used when matching the Credential Application against the presentation
+// definition of the Credential Manifest.
+func WithMatchOptions(matchOptions ...presexch.MatchOption) RespondOpt {
+	return func(opts *respondOptions) {
+		opts.matchOptions = matchOptions
+	}
+}
+
+// WithChallenge sets the challenge and domain of the Credential Response proof.
+func WithChallenge(challenge, domain string) RespondOpt {
+	return func(opts *respondOptions) {
+		opts.challenge = challenge
+		opts.domain = domain
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package issuer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PaesslerAG/jsonpath"
+
+	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+const (
+	baseContext = "https://www.w3.org/2018/credentials/v1"
+	baseType    = "VerifiableCredential"
+)
+
+// Submission holds the data submitted with a Credential Application that a Template maps into a credential.
+type Submission struct {
+	// Holder is the holder of the Credential Application presentation.
+	Holder string
+	// Credentials holds the submitted credentials keyed by the ID of the Input Descriptor they satisfy.
+	Credentials map[string]*verifiable.Credential
+}
+
+// Template builds the unsigned credential issued for an Output Descriptor from a Submission.
+// The issuer fills in the ID, issuer and issuance date of the credential if the template leaves them empty.
+type Template interface {
+	Credential(descriptor *cm.OutputDescriptor, submission *Submission) (*verifiable.Credential, error)
+}
+
+// TemplateFunc is a function adapter for the Template interface.
+type TemplateFunc func(descriptor *cm.OutputDescriptor, submission *Submission) (*verifiable.Credential, error)
+
+// Credential calls f(descriptor, submission).
+func (f TemplateFunc) Credential(descriptor *cm.OutputDescriptor,
+	submission *Submission) (*verifiable.Credential, error) {
+	return f(descriptor, submission)
+}
+
+// ClaimMappingTemplate is a Template that copies claims from the submitted credentials into the credential subject.
+//
+// Claims maps credential subject properties to JSONPath expressions evaluated against a document of the form
+//
+//	{"holder": "<holder>", "credentials": {"<input descriptor ID>": <credential>}}
+//
+// e.g. "$.credentials.prc_input.credentialSubject.givenName". The subject ID defaults to the holder.
+type ClaimMappingTemplate struct {
+	// Context holds the JSON-LD contexts added after the base credentials context.
+	Context []string
+	// Types holds the credential types added after VerifiableCredential.
+	Types []string
+	// Claims maps credential subject properties to JSONPath expressions.
+	Claims map[string]string
+	// Validity sets the expiration date of the credential relative to its issuance, if not zero.
+	Validity time.Duration
+}
+
+// Credential maps the claims of the submission into a new credential.
+func (t *ClaimMappingTemplate) Credential(_ *cm.OutputDescriptor,
+	submission *Submission) (*verifiable.Credential, error) {
+	document, err := submission.document()
+	if err != nil {
+		return nil, err
+	}
+
+	subject := map[string]interface{}{}
+
+	if submission.Holder != "" {
+		subject["id"] = submission.Holder
+	}
+
+	for name, path := range t.Claims {
+		value, e := jsonpath.Get(path, document)
+		if e != nil {
+			return nil, fmt.Errorf("failed to map claim '%s': %w", name, e)
+		}
+
+		subject[name] = value
+	}
+
+	issued := time.Now().UTC()
+
+	vc := &verifiable.Credential{
+		Context: append([]string{baseContext}, t.Context...),
+		Types:   append([]string{baseType}, t.Types...),
+		Subject: subject,
+		Issued:  util.NewTime(issued),
+	}
+
+	if t.Validity != 0 {
+		vc.Expired = util.NewTime(issued.Add(t.Validity))
+	}
+
+	return vc, nil
+}
+
+func (s *Submission) document() (map[string]interface{}, error) {
+	if s == nil {
+		return nil, errors.New("missing submission")
+	}
+
+	credentials := make(map[string]interface{}, len(s.Credentials))
+
+	for id, vc := range s.Credentials {
+		// Map the claims of JWT credentials rather than the compact JWT.
+		c := *vc
+		c.JWT = ""
+
+		vcBytes, err := c.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal credential '%s': %w", id, err)
+		}
+
+		var vcMap map[string]interface{}
+
+		err = json.Unmarshal(vcBytes, &vcMap)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal credential '%s': %w", id, err)
+		}
+
+		credentials[id] = vcMap
+	}
+
+	return map[string]interface{}{
+		"holder":      s.Holder,
+		"credentials": credentials,
+	}, nil
+}
@@ -29,6 +29,9 @@ const (
 	A256CBCHS384 = EncAlg(A256CBCHS384ALG)
 	// A256CBCHS512 for A256CBC-HS512 (AES256-CBC+HMAC-SHA512) content encryption.
 	A256CBCHS512 = EncAlg(A256CBCHS512ALG)
+	// A256GCMHKDF1MB for A256GCM-HKDF-1MB (AES256-GCM with HKDF derived keys over 1MB segments) streaming content
+	// encryption, see JWEEncrypt.EncryptStream.
+	A256GCMHKDF1MB = EncAlg(A256GCMHKDF1MBALG)
 )
 
 // Encrypter interface to Encrypt/Decrypt JWE messages.
@@ -43,3 +46,27 @@ func NewJWEEncrypt(encAlg EncAlg, envelopMediaType, cty, senderKID string, sende
 	recipientsPubKeys []*cryptoapi.PublicKey, crypto cryptoapi.Crypto) (*JWEEncrypt, error) {
 	return jose2.NewJWEEncrypt(encAlg, envelopMediaType, cty, senderKID, senderKH, recipientsPubKeys, crypto)
 }
+
+// NewJWEEncryptWithKEK creates a new JWEEncrypt instance to build JWEs with the 'A256KW' key management algorithm: the
+// CEK is wrapped with AES Key Wrap using the AES-256 key encryption key kek (eg: a kms AES256GCMNoPrefixType key
+// handle) shared with the recipient under kid.
+func NewJWEEncryptWithKEK(encAlg EncAlg, envelopMediaType, cty, kid string, kek interface{},
+	crypto cryptoapi.Crypto) (*JWEEncrypt, error) {
+	return jose2.NewJWEEncryptWithKEK(encAlg, envelopMediaType, cty, kid, kek, crypto)
+}
+
+// NewJWEEncryptDirect creates a new JWEEncrypt instance to build JWEs with the 'dir' key management algorithm: the
+// AES-256 key cek (eg: a kms AES256GCMNoPrefixType key handle) shared with the recipient under kid is used as the CEK
+// of A256GCM content encryption.
+func NewJWEEncryptDirect(envelopMediaType, cty, kid string, cek interface{},
+	crypto cryptoapi.Crypto) (*JWEEncrypt, error) {
+	return jose2.NewJWEEncryptDirect(envelopMediaType, cty, kid, cek, crypto)
+}
+
+// NewJWEEncryptECDHESDirect creates a new JWEEncrypt instance to build JWEs with the 'ECDH-ES' key management
+// algorithm: the CEK is derived by an ECDH-ES key agreement in direct mode between an ephemeral key and
+// recipientPubKey (an EC or X25519 OKP key). There is no wrapped CEK, so only one recipient is supported.
+func NewJWEEncryptECDHESDirect(encAlg EncAlg, envelopMediaType, cty string,
+	recipientPubKey *cryptoapi.PublicKey) (*JWEEncrypt, error) {
+	return jose2.NewJWEEncryptECDHESDirect(encAlg, envelopMediaType, cty, recipientPubKey)
+}
@@ -71,9 +71,15 @@ type Format = presexch.Format
 // JwtType contains alg.
 type JwtType = presexch.JwtType
 
-// LdpType contains proof_type.
+// LdpType contains proof_type and cryptosuite.
 type LdpType = presexch.LdpType
 
+// SDJWTType contains the algs of the SD-JWT issuer signature and of the key binding JWT.
+type SDJWTType = presexch.SDJWTType
+
+// MsoMdocType contains the alg of the mdoc issuer signature.
+type MsoMdocType = presexch.MsoMdocType
+
 const (
 	// FormatJWT presentation exchange format.
 	FormatJWT = presexch.FormatJWT
@@ -81,12 +87,20 @@ const (
 	FormatJWTVC = presexch.FormatJWTVC
 	// FormatJWTVP presentation exchange format.
 	FormatJWTVP = presexch.FormatJWTVP
+	// FormatJWTVCJSON presentation exchange format of JWT credentials, as registered by OpenID4VP.
+	FormatJWTVCJSON = presexch.FormatJWTVCJSON
+	// FormatJWTVPJSON presentation exchange format of JWT presentations, as registered by OpenID4VP.
+	FormatJWTVPJSON = presexch.FormatJWTVPJSON
+	// FormatSDJWTVC presentation exchange format of SD-JWT credentials.
+	FormatSDJWTVC = presexch.FormatSDJWTVC
 	// FormatLDP presentation exchange format.
 	FormatLDP = presexch.FormatLDP
 	// FormatLDPVC presentation exchange format.
 	FormatLDPVC = presexch.FormatLDPVC
 	// FormatLDPVP presentation exchange format.
 	FormatLDPVP = presexch.FormatLDPVP
+	// FormatMsoMdoc presentation exchange format of ISO 18013-5 mdocs.
+	FormatMsoMdoc = presexch.FormatMsoMdoc
 )
 
 // MatchedSubmissionRequirement contains information about VCs that matched a presentation definition.
@@ -95,6 +109,35 @@ type MatchedSubmissionRequirement = presexch.MatchedSubmissionRequirement
 // MatchedInputDescriptor contains information about VCs that matched an input descriptor of presentation definition.
 type MatchedInputDescriptor = presexch.MatchedInputDescriptor
 
+// CredentialExplanation explains why a credential matches an input descriptor or not.
+type CredentialExplanation = presexch.CredentialExplanation
+
+// Mismatch describes why a credential does not match an input descriptor.
+type Mismatch = presexch.Mismatch
+
+// MismatchReason is the reason a credential does not match an input descriptor.
+type MismatchReason = presexch.MismatchReason
+
+const (
+	// MismatchFormat means the credential does not satisfy any format of the input descriptor.
+	MismatchFormat = presexch.MismatchFormat
+	// MismatchSchema means the credential does not satisfy the schema of the input descriptor.
+	MismatchSchema = presexch.MismatchSchema
+	// MismatchSubjectIsIssuer means the subject of the credential is not its issuer, as required.
+	MismatchSubjectIsIssuer = presexch.MismatchSubjectIsIssuer
+	// MismatchFieldMissing means none of the paths of a constraint field is present in the credential.
+	MismatchFieldMissing = presexch.MismatchFieldMissing
+	// MismatchFieldFilter means the values of a constraint field do not satisfy its filter.
+	MismatchFieldFilter = presexch.MismatchFieldFilter
+	// MismatchClaims means the claims of the credential cannot be read, eg invalid SD-JWT disclosures.
+	MismatchClaims = presexch.MismatchClaims
+	// MismatchRelational means the credential does not satisfy an is_holder or same_subject constraint.
+	MismatchRelational = presexch.MismatchRelational
+)
+
+// MdocMatch is a mdoc matching an InputDescriptor.
+type MdocMatch = presexch.MdocMatch
+
 // MatchValue holds a matched credential from PresentationDefinition.Match, along with the ID of the
 // presentation that held the matched credential.
 type MatchValue = presexch.MatchValue
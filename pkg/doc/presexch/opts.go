@@ -62,6 +62,12 @@ func WithSelectiveDisclosureApply() MatchRequirementsOpt {
 	return presexch.WithSelectiveDisclosureApply()
 }
 
+// WithExplanation explains, for every input descriptor, why each credential matches it or not,
+// see MatchedInputDescriptor.Explanations.
+func WithExplanation() MatchRequirementsOpt {
+	return presexch.WithExplanation()
+}
+
 // WithSDCredentialOptions used when applying selective disclosure.
 func WithSDCredentialOptions(options ...verifiable.CredentialOpt) MatchRequirementsOpt {
 	return presexch.WithSDCredentialOptions(options...)
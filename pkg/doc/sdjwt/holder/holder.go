@@ -92,6 +92,16 @@ func WithHolderVerification(info *BindingInfo) Option {
 	return holder.WithHolderVerification(info)
 }
 
+// DisclosureSelection is the minimal set of disclosures revealing the claims at the requested JSON paths.
+type DisclosureSelection = holder.DisclosureSelection
+
+// SelectDisclosures selects the disclosures of the combined format for issuance needed to reveal the claims
+// at the JSON paths, eg $.address.street or $.nationalities[1], including the disclosures of parents
+// of recursively disclosable claims. The selected disclosures can be passed to CreatePresentation.
+func SelectDisclosures(combinedFormatForIssuance string, paths []string) (*DisclosureSelection, error) {
+	return holder.SelectDisclosures(combinedFormatForIssuance, paths)
+}
+
 // CreatePresentation is a convenience method to assemble combined format for presentation
 // using selected disclosures (claimsToDisclose) and optional holder binding.
 // This call assumes that combinedFormatForIssuance has already been parsed and verified using Parse() function.
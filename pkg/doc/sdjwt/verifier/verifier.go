@@ -84,6 +84,15 @@ func WithExpectedTypHeader(typ string) verifier.ParseOpt {
 	return verifier.WithExpectedTypHeader(typ)
 }
 
+// StatusChecker checks the status claim of a credential, eg against a Token Status List.
+type StatusChecker = verifier.StatusChecker
+
+// WithStatusChecker option is for checking the status claim of the SD-JWT, if present,
+// eg with statuslist.Checker.
+func WithStatusChecker(checker StatusChecker) verifier.ParseOpt {
+	return verifier.WithStatusChecker(checker)
+}
+
 // Parse parses combined format for presentation and returns verified claims.
 // The Verifier has to verify that all disclosed claim values were part of the original, Issuer-signed SD-JWT.
 //
@@ -89,6 +89,88 @@ func WithDisabledProofCheck() CredentialOpt {
 	return verifiable.WithDisabledProofCheck()
 }
 
+// ProofCheckPolicy defines which proofs of a proof set must be valid for the embedded proof check to pass.
+type ProofCheckPolicy = verifiable.ProofCheckPolicy
+
+const (
+	// AllProofsValid requires every embedded proof to be valid. This is the default policy.
+	AllProofsValid = verifiable.AllProofsValid
+
+	// AnyProofValid requires at least one embedded proof to be valid.
+	AnyProofValid = verifiable.AnyProofValid
+)
+
+// ProofCheckResult holds the outcome of checking a single embedded proof of a credential or presentation.
+type ProofCheckResult = verifiable.ProofCheckResult
+
+// WithProofCheckPolicy option defines which proofs of a proof set must be valid for the proof check to pass.
+// By default, all proofs must be valid.
+func WithProofCheckPolicy(policy ProofCheckPolicy) CredentialOpt {
+	return verifiable.WithProofCheckPolicy(policy)
+}
+
+// CredentialStatusChecker checks the status of a credential which defines credentialStatus.
+type CredentialStatusChecker = verifiable.CredentialStatusChecker
+
+// WithStatusChecker sets the checker used by VerifyCredential to check the status of a credential.
+func WithStatusChecker(checker CredentialStatusChecker) CredentialOpt {
+	return verifiable.WithStatusChecker(checker)
+}
+
+// WithIssuerDIDResolver sets the resolver used by VerifyCredential to resolve the DID of the credential issuer.
+func WithIssuerDIDResolver(resolver didResolver) CredentialOpt {
+	return verifiable.WithIssuerDIDResolver(resolver)
+}
+
+// VerificationCheck is the name of a single check made by VerifyCredential or VerifyPresentation.
+type VerificationCheck = verifiable.VerificationCheck
+
+const (
+	// SchemaCheck validates the document against the JSON schema.
+	SchemaCheck = verifiable.SchemaCheck
+	// JSONLDCheck validates the document as JSON-LD.
+	JSONLDCheck = verifiable.JSONLDCheck
+	// ProofCheck verifies the JWS or the embedded proofs of the document.
+	ProofCheck = verifiable.ProofCheck
+	// ValidityPeriodCheck checks the credential is already issued and not expired.
+	ValidityPeriodCheck = verifiable.ValidityPeriodCheck
+	// StatusCheck checks the credential status.
+	StatusCheck = verifiable.StatusCheck
+	// IssuerCheck resolves the DID of the credential issuer.
+	IssuerCheck = verifiable.IssuerCheck
+	// HolderBindingCheck checks the document is presented by its holder.
+	HolderBindingCheck = verifiable.HolderBindingCheck
+)
+
+// CheckOutcome is the outcome of a single verification check.
+type CheckOutcome = verifiable.CheckOutcome
+
+const (
+	// CheckPassed means the check was made and passed.
+	CheckPassed = verifiable.CheckPassed
+	// CheckFailed means the check was made and failed.
+	CheckFailed = verifiable.CheckFailed
+	// CheckSkipped means the check does not apply to the document or the options it needs were not provided.
+	CheckSkipped = verifiable.CheckSkipped
+)
+
+// CheckResult holds the outcome of a single verification check.
+type CheckResult = verifiable.CheckResult
+
+// VerificationReport holds the outcome of every check made by VerifyCredential or VerifyPresentation.
+type VerificationReport = verifiable.VerificationReport
+
+// VerifyCredential verifies the Verifiable Credential and reports the outcome of each check separately.
+func VerifyCredential(vcData []byte, opts ...CredentialOpt) (*VerificationReport, error) {
+	return verifiable.VerifyCredential(vcData, opts...)
+}
+
+// VerifyPresentation verifies the Verifiable Presentation and the credentials embedded into it,
+// and reports the outcome of each check separately.
+func VerifyPresentation(vpData []byte, opts ...PresentationOpt) (*VerificationReport, error) {
+	return verifiable.VerifyPresentation(vpData, opts...)
+}
+
 // WithCredDisableValidation options for disabling of JSON-LD and json-schema validation.
 func WithCredDisableValidation() CredentialOpt {
 	return verifiable.WithCredDisableValidation()
@@ -421,6 +503,9 @@ const (
 // LinkedDataProofContext holds options needed to build a Linked Data Proof.
 type LinkedDataProofContext = verifiable.LinkedDataProofContext
 
+// DataIntegrityProofContext holds parameters for creating or validating a Data Integrity Proof.
+type DataIntegrityProofContext = verifiable.DataIntegrityProofContext
+
 // MarshalledCredential defines marshalled Verifiable Credential enclosed into Presentation.
 // MarshalledCredential can be passed to verifiable.ParseCredential().
 type MarshalledCredential = verifiable.MarshalledCredential
@@ -465,6 +550,17 @@ func WithPresDisabledProofCheck() PresentationOpt {
 	return verifiable.WithPresDisabledProofCheck()
 }
 
+// WithPresProofCheckPolicy option defines which proofs of a proof set must be valid for the proof check to pass.
+// By default, all proofs must be valid.
+func WithPresProofCheckPolicy(policy ProofCheckPolicy) PresentationOpt {
+	return verifiable.WithPresProofCheckPolicy(policy)
+}
+
+// WithPresCredentialOpts sets additional options used by VerifyPresentation to verify the embedded credentials.
+func WithPresCredentialOpts(credOpts ...CredentialOpt) PresentationOpt {
+	return verifiable.WithPresCredentialOpts(credOpts...)
+}
+
 // WithPresStrictValidation enabled strict JSON-LD validation of VP.
 // In case of JSON-LD validation, the comparison of JSON-LD VP document after compaction with original VP one is made.
 // In case of mismatch a validation exception is raised.
@@ -557,7 +557,8 @@ func WithKeyType(keyType kms.KeyType) ProviderOption {
 func WithKeyAgreementType(keyAgreementType kms.KeyType) ProviderOption {
 	return func(opts *Provider) error {
 		switch keyAgreementType {
-		case kms.X25519ECDHKWType, kms.NISTP256ECDHKWType, kms.NISTP384ECDHKWType, kms.NISTP521ECDHKWType:
+		case kms.X25519ECDHKWType, kms.NISTP256ECDHKWType, kms.NISTP384ECDHKWType, kms.NISTP521ECDHKWType,
+			kms.X25519MLKEM768ECDHKWType:
 			opts.keyAgreementType = keyAgreementType
 			return nil
 		default:
@@ -832,6 +832,10 @@ func TestNewProvider(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, kms.NISTP256ECDHKWType, prov.KeyAgreementType())
 
+		prov, err = New(WithKeyAgreementType(kms.X25519MLKEM768ECDHKWType))
+		require.NoError(t, err)
+		require.Equal(t, kms.X25519MLKEM768ECDHKWType, prov.KeyAgreementType())
+
 		_, err = New(WithKeyAgreementType(kms.XChaCha20Poly1305Type))
 		require.EqualError(t, err, "option failed: invalid KeyAgreement key type: XChaCha20Poly1305")
 	})
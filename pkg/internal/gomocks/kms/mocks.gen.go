@@ -128,6 +128,62 @@ func (mr *MockKeyManagerMockRecorder) CreateAndExportPubKeyBytes(arg0 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAndExportPubKeyBytes", reflect.TypeOf((*MockKeyManager)(nil).CreateAndExportPubKeyBytes), varargs...)
 }
 
+// Delete mocks base method.
+func (m *MockKeyManager) Delete(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockKeyManagerMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockKeyManager)(nil).Delete), arg0)
+}
+
+// Destroy mocks base method.
+func (m *MockKeyManager) Destroy(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Destroy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Destroy indicates an expected call of Destroy.
+func (mr *MockKeyManagerMockRecorder) Destroy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destroy", reflect.TypeOf((*MockKeyManager)(nil).Destroy), arg0)
+}
+
+// Disable mocks base method.
+func (m *MockKeyManager) Disable(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockKeyManagerMockRecorder) Disable(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockKeyManager)(nil).Disable), arg0)
+}
+
+// Enable mocks base method.
+func (m *MockKeyManager) Enable(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enable", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enable indicates an expected call of Enable.
+func (mr *MockKeyManagerMockRecorder) Enable(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enable", reflect.TypeOf((*MockKeyManager)(nil).Enable), arg0)
+}
+
 // ExportPubKeyBytes mocks base method.
 func (m *MockKeyManager) ExportPubKeyBytes(arg0 string) ([]byte, kms.KeyType, error) {
 	m.ctrl.T.Helper()
@@ -159,6 +215,21 @@ func (mr *MockKeyManagerMockRecorder) Get(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockKeyManager)(nil).Get), arg0)
 }
 
+// GetMetadata mocks base method.
+func (m *MockKeyManager) GetMetadata(arg0 string) (*kms.KeyMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetadata", arg0)
+	ret0, _ := ret[0].(*kms.KeyMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetadata indicates an expected call of GetMetadata.
+func (mr *MockKeyManagerMockRecorder) GetMetadata(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetadata", reflect.TypeOf((*MockKeyManager)(nil).GetMetadata), arg0)
+}
+
 // ImportPrivateKey mocks base method.
 func (m *MockKeyManager) ImportPrivateKey(arg0 interface{}, arg1 kms.KeyType, arg2 ...kms.PrivateKeyOpts) (string, interface{}, error) {
 	m.ctrl.T.Helper()
@@ -180,6 +251,25 @@ func (mr *MockKeyManagerMockRecorder) ImportPrivateKey(arg0, arg1 interface{}, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportPrivateKey", reflect.TypeOf((*MockKeyManager)(nil).ImportPrivateKey), varargs...)
 }
 
+// List mocks base method.
+func (m *MockKeyManager) List(arg0 ...kms.ListOpts) ([]*kms.KeyMetadata, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "List", varargs...)
+	ret0, _ := ret[0].([]*kms.KeyMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockKeyManagerMockRecorder) List(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockKeyManager)(nil).List), arg0...)
+}
+
 // PubKeyBytesToHandle mocks base method.
 func (m *MockKeyManager) PubKeyBytesToHandle(arg0 []byte, arg1 kms.KeyType, arg2 ...kms.KeyOpts) (interface{}, error) {
 	m.ctrl.T.Helper()
@@ -220,3 +310,22 @@ func (mr *MockKeyManagerMockRecorder) Rotate(arg0, arg1 interface{}, arg2 ...int
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockKeyManager)(nil).Rotate), varargs...)
 }
+
+// UpdateMetadata mocks base method.
+func (m *MockKeyManager) UpdateMetadata(arg0 string, arg1 ...kms.MetadataOpts) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateMetadata", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMetadata indicates an expected call of UpdateMetadata.
+func (mr *MockKeyManagerMockRecorder) UpdateMetadata(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetadata", reflect.TypeOf((*MockKeyManager)(nil).UpdateMetadata), varargs...)
+}
@@ -21,9 +21,18 @@ type KeyManager = kmsapi.KeyManager
 // key ID could be found.
 var ErrKeyNotFound = kms.ErrKeyNotFound
 
+// ErrKeyDisabled is the error returned by a KMS when a disabled key is requested for use.
+var ErrKeyDisabled = kms.ErrKeyDisabled
+
+// ErrKeyDestroyed is the error returned by a KMS when a destroyed key is requested for use or update.
+var ErrKeyDestroyed = kms.ErrKeyDestroyed
+
 // Store defines the storage capability required by a KeyManager Provider.
 type Store = kmsapi.Store
 
+// MetadataStore is an optional extension of Store for the KeyManagers keeping the lifecycle metadata of their keys.
+type MetadataStore = kmsapi.MetadataStore
+
 // Provider for KeyManager builder/constructor.
 type Provider = kmsapi.Provider
 
@@ -41,6 +50,10 @@ const (
 	ChaCha20Poly1305 = kmsapi.ChaCha20Poly1305
 	// XChaCha20Poly1305 key type value.
 	XChaCha20Poly1305 = kmsapi.XChaCha20Poly1305
+	// AES256GCMHKDF4KB streaming AEAD key type value, using 4KB ciphertext segments.
+	AES256GCMHKDF4KB = kmsapi.AES256GCMHKDF4KB
+	// AES256GCMHKDF1MB streaming AEAD key type value, using 1MB ciphertext segments.
+	AES256GCMHKDF1MB = kmsapi.AES256GCMHKDF1MB
 	// ECDSAP256DER key type value.
 	ECDSAP256DER = kmsapi.ECDSAP256DER
 	// ECDSAP384DER key type value.
@@ -63,6 +76,8 @@ const (
 	RSARS256 = kmsapi.RSARS256
 	// RSAPS256 key type value.
 	RSAPS256 = kmsapi.RSAPS256
+	// RSAOAEP RSA-OAEP decryption key type value.
+	RSAOAEP = kmsapi.RSAOAEP
 	// HMACSHA256Tag256 key type value.
 	HMACSHA256Tag256 = kmsapi.HMACSHA256Tag256
 	// NISTP256ECDHKW key type value.
@@ -73,12 +88,22 @@ const (
 	NISTP521ECDHKW = kmsapi.NISTP521ECDHKW
 	// X25519ECDHKW key type value.
 	X25519ECDHKW = kmsapi.X25519ECDHKW
+	// X25519MLKEM768ECDHKW hybrid X25519 and ML-KEM-768 (FIPS 203) key agreement key type value.
+	X25519MLKEM768ECDHKW = kmsapi.X25519MLKEM768ECDHKW
 	// BLS12381G2 BBS+ key type value.
 	BLS12381G2 = kmsapi.BLS12381G2
 	// CLCredDef key type value.
 	CLCredDef = kmsapi.CLCredDef
 	// CLMasterSecret key type value.
 	CLMasterSecret = kmsapi.CLMasterSecret
+	// MLDSA44 ML-DSA-44 (FIPS 204) key type value.
+	MLDSA44 = kmsapi.MLDSA44
+	// MLDSA65 ML-DSA-65 (FIPS 204) key type value.
+	MLDSA65 = kmsapi.MLDSA65
+	// MLDSA87 ML-DSA-87 (FIPS 204) key type value.
+	MLDSA87 = kmsapi.MLDSA87
+	// MLDSA65ED25519 composite ML-DSA-65 and Ed25519 key type value.
+	MLDSA65ED25519 = kmsapi.MLDSA65ED25519
 )
 
 // KeyType represents a key type supported by the KMS.
@@ -95,6 +120,10 @@ const (
 	ChaCha20Poly1305Type = kmsapi.ChaCha20Poly1305Type
 	// XChaCha20Poly1305Type key type value.
 	XChaCha20Poly1305Type = kmsapi.XChaCha20Poly1305Type
+	// AES256GCMHKDF4KBType streaming AEAD key type value.
+	AES256GCMHKDF4KBType = kmsapi.AES256GCMHKDF4KBType
+	// AES256GCMHKDF1MBType streaming AEAD key type value.
+	AES256GCMHKDF1MBType = kmsapi.AES256GCMHKDF1MBType
 	// ECDSAP256TypeDER key type value.
 	ECDSAP256TypeDER = kmsapi.ECDSAP256TypeDER
 	// ECDSASecp256k1TypeDER key type value.
@@ -117,6 +146,8 @@ const (
 	RSARS256Type = kmsapi.RSARS256Type
 	// RSAPS256Type key type value.
 	RSAPS256Type = kmsapi.RSAPS256Type
+	// RSAOAEPType RSA-OAEP decryption key type value.
+	RSAOAEPType = kmsapi.RSAOAEPType
 	// HMACSHA256Tag256Type key type value.
 	HMACSHA256Tag256Type = kmsapi.HMACSHA256Tag256Type
 	// NISTP256ECDHKWType key type value.
@@ -127,12 +158,22 @@ const (
 	NISTP521ECDHKWType = kmsapi.NISTP521ECDHKWType
 	// X25519ECDHKWType key type value.
 	X25519ECDHKWType = kmsapi.X25519ECDHKWType
+	// X25519MLKEM768ECDHKWType hybrid X25519 and ML-KEM-768 key agreement key type value.
+	X25519MLKEM768ECDHKWType = kmsapi.X25519MLKEM768ECDHKWType
 	// BLS12381G2Type BBS+ key type value.
 	BLS12381G2Type = kmsapi.BLS12381G2Type
 	// CLCredDefType type value.
 	CLCredDefType = kmsapi.CLCredDefType
 	// CLMasterSecretType key type value.
 	CLMasterSecretType = kmsapi.CLMasterSecretType
+	// MLDSA44Type ML-DSA-44 key type value.
+	MLDSA44Type = kmsapi.MLDSA44Type
+	// MLDSA65Type ML-DSA-65 key type value.
+	MLDSA65Type = kmsapi.MLDSA65Type
+	// MLDSA87Type ML-DSA-87 key type value.
+	MLDSA87Type = kmsapi.MLDSA87Type
+	// MLDSA65ED25519Type composite ML-DSA-65 and Ed25519 key type value.
+	MLDSA65ED25519Type = kmsapi.MLDSA65ED25519Type
 )
 
 // CryptoBox is a libsodium crypto service used by legacy authcrypt packer.
@@ -154,3 +195,49 @@ type KeyOpts = kmsapi.KeyOpts
 func WithAttrs(attrs []string) kmsapi.KeyOpts {
 	return kmsapi.WithAttrs(attrs)
 }
+
+// KeyState represents the lifecycle state of a key managed by the KMS.
+type KeyState = kmsapi.KeyState
+
+const (
+	// KeyStateEnabled is the state of a key available for use.
+	KeyStateEnabled = kmsapi.KeyStateEnabled
+	// KeyStateDisabled is the state of a key kept in storage but unavailable for use.
+	KeyStateDisabled = kmsapi.KeyStateDisabled
+	// KeyStateDestroyed is the state of a key whose material has been erased.
+	KeyStateDestroyed = kmsapi.KeyStateDestroyed
+)
+
+// KeyMetadata holds the lifecycle metadata of a key managed by the KMS.
+type KeyMetadata = kmsapi.KeyMetadata
+
+// MetadataOpts are the update key metadata option.
+type MetadataOpts = kmsapi.MetadataOpts
+
+// WithLabels option is for replacing the labels of a key.
+func WithLabels(labels map[string]string) kmsapi.MetadataOpts {
+	return kmsapi.WithLabels(labels)
+}
+
+// WithUsage option is for replacing the usage (eg. "sign", "encrypt") of a key.
+func WithUsage(usage ...string) kmsapi.MetadataOpts {
+	return kmsapi.WithUsage(usage...)
+}
+
+// ListOpts are the list keys option.
+type ListOpts = kmsapi.ListOpts
+
+// WithKeyTypeFilter option is for listing the keys of type kt only.
+func WithKeyTypeFilter(kt KeyType) kmsapi.ListOpts {
+	return kmsapi.WithKeyTypeFilter(kt)
+}
+
+// WithStateFilter option is for listing the keys in the given state only.
+func WithStateFilter(state KeyState) kmsapi.ListOpts {
+	return kmsapi.WithStateFilter(state)
+}
+
+// WithLabelFilter option is for listing the keys having the label name set to value only.
+func WithLabelFilter(name, value string) kmsapi.ListOpts {
+	return kmsapi.WithLabelFilter(name, value)
+}
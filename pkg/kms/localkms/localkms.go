@@ -33,3 +33,17 @@ type LocalKMS = localkms.LocalKMS
 func New(primaryKeyURI string, p kms.Provider) (*LocalKMS, error) {
 	return localkms.New(primaryKeyURI, p)
 }
+
+// RewrapProgressKeyID is the ID of the entry saved in the KMS store by Rewrap to track its progress. It is removed
+// once all keysets are rewrapped.
+const RewrapProgressKeyID = localkms.RewrapProgressKeyID
+
+// MasterKey identifies the master key protecting the keysets of a LocalKMS: the primary key URI given to New and
+// the secret lock service holding it.
+type MasterKey = localkms.MasterKey
+
+// Rewrap re-encrypts the keysets found in store, decrypting them with the `from` master key and encrypting them with
+// the `to` master key. It is resumable: see localkms.Rewrap in the kmscrypto component for details.
+func Rewrap(store kms.Store, from, to MasterKey, keyIDs ...string) (int, error) {
+	return localkms.Rewrap(store, from, to, keyIDs...)
+}
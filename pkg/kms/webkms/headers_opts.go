@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package webkms
 
 import (
+	"time"
+
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/webkms"
 )
 
@@ -38,3 +40,29 @@ func WithCache(cacheSize int) Opt {
 func WithMarshalFn(fn marshalFunc) Opt {
 	return webkms.WithMarshalFn(fn)
 }
+
+// WithRetry option retries up to maxRetries times the requests failing with a network error or with a 429, 502, 503
+// or 504 http status, waiting backoff before the first retry and doubling it before each next one. The POST and PUT
+// requests are sent with an 'Idempotency-Key' header, set to the same random value for all attempts, for the key
+// server to perform a request only once.
+func WithRetry(maxRetries int, backoff time.Duration) Opt {
+	return webkms.WithRetry(maxRetries, backoff)
+}
+
+// WithKeyCache option caches in memory, for up to ttl (no expiration if zero), the key handles and the exported public
+// keys of up to cacheSize keys used by remoteKMS to save a request to the key server for each ExportPubKeyBytes call.
+func WithKeyCache(cacheSize int, ttl time.Duration) Opt {
+	return webkms.WithKeyCache(cacheSize, ttl)
+}
+
+// SignFunc signs data for the HTTP signature of a request (eg: with a Sign call of a crypto.Crypto and a private key
+// handle).
+type SignFunc = webkms.SignFunc
+
+// WithHTTPSignature option authenticates the requests sent to the key server with an HTTP signature (see
+// https://datatracker.ietf.org/doc/html/draft-cavage-http-signatures-12) computed by sign with the key keyID of
+// algorithm alg (eg: "ed25519" or "ecdsa-sha256"). The signature covers the request target, 'Host', 'Date' and, for
+// requests with a body, 'Digest' headers.
+func WithHTTPSignature(keyID, alg string, sign SignFunc) Opt {
+	return webkms.WithHTTPSignature(keyID, alg, sign)
+}
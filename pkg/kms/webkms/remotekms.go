@@ -7,7 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package webkms
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"net/http"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/webkms"
 )
@@ -49,3 +52,9 @@ func CreateKeyStore(httpClient HTTPClient, keyserverURL, controller, vaultURL st
 func New(keystoreURL string, client HTTPClient, opts ...Opt) *RemoteKMS {
 	return webkms.New(keystoreURL, client, opts...)
 }
+
+// NewMutualTLSClient creates an http client authenticating to the key server with the clientCert certificate (mutual
+// TLS) and trusting the key server certificates issued by rootCAs (the system's roots if nil).
+func NewMutualTLSClient(clientCert tls.Certificate, rootCAs *x509.CertPool, timeout time.Duration) *http.Client {
+	return webkms.NewMutualTLSClient(clientCert, rootCAs, timeout)
+}
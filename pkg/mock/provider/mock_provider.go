@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/framework/aries/api"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	"github.com/hyperledger/aries-framework-go/pkg/store/did"
 	"github.com/hyperledger/aries-framework-go/pkg/store/ld"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
@@ -53,6 +54,7 @@ type Provider struct {
 	GetDIDsMaxRetriesValue            uint64
 	DIDRotatorValue                   middleware.DIDCommMessageMiddleware
 	MessengerValue                    service.Messenger
+	SecretLockValue                   secretlock.Service
 }
 
 // Messenger return messenger.
@@ -123,6 +125,11 @@ func (p *Provider) KMS() kms.KeyManager {
 	return p.KMSValue
 }
 
+// SecretLock returns a secret lock service.
+func (p *Provider) SecretLock() secretlock.Service {
+	return p.SecretLockValue
+}
+
 // Crypto returns a crypto.
 func (p *Provider) Crypto() crypto.Crypto {
 	return p.CryptoValue
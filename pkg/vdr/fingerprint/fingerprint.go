@@ -27,6 +27,12 @@ const (
 	P384PubKeyMultiCodec = fingerprint.P384PubKeyMultiCodec
 	// P521PubKeyMultiCodec for NIST P-521 public key in multicodec table.
 	P521PubKeyMultiCodec = fingerprint.P521PubKeyMultiCodec
+	// MLDSA44PubKeyMultiCodec for ML-DSA-44 public key in multicodec table.
+	MLDSA44PubKeyMultiCodec = fingerprint.MLDSA44PubKeyMultiCodec
+	// MLDSA65PubKeyMultiCodec for ML-DSA-65 public key in multicodec table.
+	MLDSA65PubKeyMultiCodec = fingerprint.MLDSA65PubKeyMultiCodec
+	// MLDSA87PubKeyMultiCodec for ML-DSA-87 public key in multicodec table.
+	MLDSA87PubKeyMultiCodec = fingerprint.MLDSA87PubKeyMultiCodec
 )
 
 // CreateDIDKey calls CreateDIDKeyByCode with Ed25519 key code.
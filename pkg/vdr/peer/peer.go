@@ -22,6 +22,21 @@ const (
 	DefaultServiceEndpoint = "defaultServiceEndpoint"
 	// DIDMethod is the peer did method name: https://identity.foundation/peer-did-method-spec/#method-name.
 	DIDMethod = "peer"
+	// NumAlgo is the Create option selecting the numeric algorithm of the new peer DID, one of NumAlgo0, NumAlgo1,
+	// NumAlgo2 or NumAlgo4 (defaults to NumAlgo1).
+	NumAlgo = peer.NumAlgo
+)
+
+// Peer DID numeric algorithms: https://identity.foundation/peer-did-method-spec/#generation-method
+const (
+	// NumAlgo0 is the inception key without doc algorithm: the DID is the multibase encoded key.
+	NumAlgo0 = peer.NumAlgo0
+	// NumAlgo1 is the genesis doc algorithm: the DID is the hash of a stored genesis doc.
+	NumAlgo1 = peer.NumAlgo1
+	// NumAlgo2 is the multiple inception keys without doc algorithm: the DID contains the keys and services.
+	NumAlgo2 = peer.NumAlgo2
+	// NumAlgo4 is the short form and long form algorithm: the long form DID contains the hash of the doc and the doc.
+	NumAlgo4 = peer.NumAlgo4
 )
 
 // VDR implements building new peer dids.
@@ -200,12 +200,17 @@ func (cs *contentStore) Save(auth string, ct ContentType, content []byte, option
 			return err
 		}
 
-		key, err := getContentID(content)
-		if err != nil {
-			return err
+		key := opts.contentID
+		if key == "" {
+			var err error
+
+			key, err = getContentID(content)
+			if err != nil {
+				return err
+			}
 		}
 
-		err = cs.mapCollection(auth, key, opts.collectionID, ct)
+		err := cs.mapCollection(auth, key, opts.collectionID, ct)
 		if err != nil {
 			return err
 		}
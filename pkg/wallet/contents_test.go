@@ -247,7 +247,7 @@ func TestContentStores(t *testing.T) {
 			},
 		}
 
-		kmsStore, err := kms.NewAriesProviderWrapper(sp)
+		kmsStore, err := kms.NewAriesProviderWrapper(getMockStorageProvider())
 		require.NoError(t, err)
 
 		kmgr, err := keyManager().createKeyManager(profileInfo, kmsStore, &unlockOpts{passphrase: samplePassPhrase})
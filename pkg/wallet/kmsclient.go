@@ -20,6 +20,8 @@ import (
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/google/tink/go/subtle/random"
 
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/hdkey"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/hdkms"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
@@ -62,6 +64,9 @@ var (
 
 	// ErrWalletLocked when key manager operation is attempted without unlocking wallet.
 	ErrWalletLocked = errors.New("wallet locked")
+
+	// ErrHDKeysNotEnabled when keys are recovered from a wallet profile created without mnemonic.
+	ErrHDKeysNotEnabled = errors.New("wallet profile does not derive its keys from a mnemonic")
 )
 
 // walletKMSInstance is key manager store singleton - access only via keyManager()
@@ -101,8 +106,7 @@ func (k *walletKeyManager) createKeyManager(profileInfo *profile,
 	// create key manager
 	if profileInfo.MasterLockCipher != "" {
 		// local kms
-		keyManager, err = createLocalKeyManager(profileInfo.User, opts.passphrase,
-			profileInfo.MasterLockCipher, opts.secretLockSvc, storeProvider)
+		keyManager, err = createLocalKeyManager(profileInfo, opts.passphrase, opts.secretLockSvc, storeProvider)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create local key manager: %w", err)
 		}
@@ -128,6 +132,23 @@ func createMasterLock(secretLockSvc secretlock.Service) (string, error) {
 	return masterLockEnc.Ciphertext, nil
 }
 
+// createSeedLock encrypts the seed of mnemonic with the secret lock service provided.
+func createSeedLock(secretLockSvc secretlock.Service, mnemonic string) (string, error) {
+	seed, err := hdkey.MnemonicToSeed(mnemonic, "")
+	if err != nil {
+		return "", err
+	}
+
+	seedEnc, err := secretLockSvc.Encrypt(localKeyURIPrefix, &secretlock.EncryptRequest{
+		Plaintext: string(seed),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt HD seed with secret lock service provided: %w", err)
+	}
+
+	return seedEnc.Ciphertext, nil
+}
+
 type kmsProvider struct {
 	storageProvider kms.Store
 	secretLock      secretlock.Service
@@ -141,9 +162,10 @@ func (k *kmsProvider) SecretLock() secretlock.Service {
 	return k.secretLock
 }
 
-// createLocalKeyManager creates and returns local KMS instance.
-func createLocalKeyManager(user, passphrase, masterLockCipher string,
-	masterLocker secretlock.Service, storeProvider kms.Store) (*localkms.LocalKMS, error) {
+// createLocalKeyManager creates and returns local KMS instance, deriving its keys from the HD seed of the profile if
+// set.
+func createLocalKeyManager(profileInfo *profile, passphrase string,
+	masterLocker secretlock.Service, storeProvider kms.Store) (kms.KeyManager, error) {
 	var err error
 	if passphrase != "" {
 		masterLocker, err = getDefaultSecretLock(passphrase)
@@ -152,15 +174,28 @@ func createLocalKeyManager(user, passphrase, masterLockCipher string,
 		}
 	}
 
-	secretLockSvc, err := local.NewService(bytes.NewBufferString(masterLockCipher), masterLocker)
+	secretLockSvc, err := local.NewService(bytes.NewBufferString(profileInfo.MasterLockCipher), masterLocker)
 	if err != nil {
 		return nil, err
 	}
 
-	return localkms.New(localKeyURIPrefix+user, &kmsProvider{
+	provider := &kmsProvider{
 		storageProvider: storeProvider,
 		secretLock:      secretLockSvc,
+	}
+
+	if profileInfo.HDSeedCipher == "" {
+		return localkms.New(localKeyURIPrefix+profileInfo.User, provider)
+	}
+
+	seed, err := masterLocker.Decrypt(localKeyURIPrefix, &secretlock.DecryptRequest{
+		Ciphertext: profileInfo.HDSeedCipher,
 	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt HD seed: %w", err)
+	}
+
+	return hdkms.New(localKeyURIPrefix+profileInfo.User, provider, []byte(seed.Plaintext))
 }
 
 // getDefaultSecretLock returns hkdf secret lock service from passphrase.
@@ -75,6 +75,9 @@ type DeriveOptions struct {
 	Frame map[string]interface{} `json:"frame,omitempty"`
 	// Nonce to prove uniqueness or freshness of the proof.
 	Nonce string `json:"nonce,omitempty"`
+	// SelectivePointers are JSON pointers to the claims to disclose, used instead of Frame
+	// when the credential is secured with an ecdsa-sd-2023 Data Integrity proof.
+	SelectivePointers []string `json:"selectivePointers,omitempty"`
 }
 
 // QueryByExampleDefinition is model for QueryByExample query type.
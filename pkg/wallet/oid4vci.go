@@ -0,0 +1,907 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/sdjwtvc"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+// OpenID4VCI constants.
+const (
+	// CredentialOfferScheme is the URI scheme of credential offers sent to wallets.
+	CredentialOfferScheme = "openid-credential-offer"
+	// PreAuthorizedCodeGrantType is the grant type of the pre-authorized code flow.
+	PreAuthorizedCodeGrantType = "urn:ietf:params:oauth:grant-type:pre-authorized_code"
+	// ProofJWTType is the typ header of the proof of possession JWTs.
+	ProofJWTType = "openid4vci-proof+jwt"
+
+	wellKnownCredentialIssuer   = "/.well-known/openid-credential-issuer"
+	wellKnownAuthorizationSever = "/.well-known/oauth-authorization-server"
+
+	authorizationCodeGrantType = "authorization_code"
+	openIDCredentialType       = "openid_credential"
+	pkceMethodS256             = "S256"
+	oid4vciRandomBytes         = 32
+	defaultOID4VCITimeout      = time.Minute
+	// maxOID4VCIResponseSize is the maximum size of the responses of the issuer and authorization server.
+	maxOID4VCIResponseSize = 1 << 20
+
+	formatJWTVCJSON   = "jwt_vc_json"
+	formatJWTVCJSONLD = "jwt_vc_json-ld"
+	formatLDPVC       = "ldp_vc"
+	formatVCSDJWT     = "vc+sd-jwt"
+	formatDCSDJWT     = "dc+sd-jwt"
+
+	errorIssuancePending = "issuance_pending"
+	errorInvalidProof    = "invalid_proof"
+	errorInvalidNonce    = "invalid_nonce"
+)
+
+// ErrIssuancePending is returned when a deferred credential is not issued yet.
+var ErrIssuancePending = errors.New("credential issuance is pending")
+
+// HTTPClient represents an HTTP client.
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// CredentialOffer is an OpenID4VCI credential offer.
+// Refer https://openid.net/specs/openid-4-verifiable-credential-issuance-1_0.html#name-credential-offer.
+type CredentialOffer struct {
+	CredentialIssuer           string                 `json:"credential_issuer"`
+	CredentialConfigurationIDs []string               `json:"credential_configuration_ids"`
+	Grants                     *CredentialOfferGrants `json:"grants,omitempty"`
+}
+
+// CredentialOfferGrants are the grants of a credential offer.
+type CredentialOfferGrants struct {
+	AuthorizationCode *AuthorizationCodeGrant `json:"authorization_code,omitempty"`
+	PreAuthorizedCode *PreAuthorizedCodeGrant `json:"urn:ietf:params:oauth:grant-type:pre-authorized_code,omitempty"`
+}
+
+// AuthorizationCodeGrant is the authorization code grant of a credential offer.
+type AuthorizationCodeGrant struct {
+	IssuerState         string `json:"issuer_state,omitempty"`
+	AuthorizationServer string `json:"authorization_server,omitempty"`
+}
+
+// PreAuthorizedCodeGrant is the pre-authorized code grant of a credential offer.
+type PreAuthorizedCodeGrant struct {
+	PreAuthorizedCode   string  `json:"pre-authorized_code"`
+	TxCode              *TxCode `json:"tx_code,omitempty"`
+	AuthorizationServer string  `json:"authorization_server,omitempty"`
+}
+
+// TxCode describes the transaction code the user has to enter, sent to the user out of band.
+type TxCode struct {
+	InputMode   string `json:"input_mode,omitempty"`
+	Length      int    `json:"length,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// CredentialIssuerMetadata is the OpenID4VCI credential issuer metadata.
+type CredentialIssuerMetadata struct {
+	CredentialIssuer                  string                              `json:"credential_issuer"`
+	AuthorizationServers              []string                            `json:"authorization_servers,omitempty"`
+	CredentialEndpoint                string                              `json:"credential_endpoint"`
+	BatchCredentialEndpoint           string                              `json:"batch_credential_endpoint,omitempty"`
+	DeferredCredentialEndpoint        string                              `json:"deferred_credential_endpoint,omitempty"`
+	NonceEndpoint                     string                              `json:"nonce_endpoint,omitempty"`
+	CredentialConfigurationsSupported map[string]*CredentialConfiguration `json:"credential_configurations_supported"`
+}
+
+// CredentialConfiguration is a credential configuration supported by the credential issuer.
+type CredentialConfiguration struct {
+	Format string `json:"format"`
+	Scope  string `json:"scope,omitempty"`
+}
+
+// OID4VCIAuthorization holds the state of the authorization code flow, between the redirection
+// of the user to the authorization URL and the redirection back to the wallet.
+type OID4VCIAuthorization struct {
+	// AuthorizationURL is the URL the user has to be redirected to.
+	AuthorizationURL string           `json:"authorizationURL"`
+	State            string           `json:"state"`
+	CodeVerifier     string           `json:"codeVerifier"`
+	RedirectURI      string           `json:"redirectURI"`
+	Offer            *CredentialOffer `json:"offer"`
+}
+
+// DeferredCredential references a credential the issuer will issue later, to be fetched with
+// RequestDeferredCredential.
+type DeferredCredential struct {
+	CredentialIssuer          string `json:"credentialIssuer"`
+	CredentialConfigurationID string `json:"credentialConfigurationID"`
+	TransactionID             string `json:"transactionID"`
+	AccessToken               string `json:"accessToken"`
+}
+
+// OID4VCIResult is the result of an OpenID4VCI credential issuance.
+type OID4VCIResult struct {
+	// Credentials are the issued credentials, saved into the wallet.
+	Credentials []json.RawMessage `json:"credentials,omitempty"`
+	// Deferred are the credentials issued later.
+	Deferred []*DeferredCredential `json:"deferred,omitempty"`
+}
+
+type authorizationServerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	CNonce      string `json:"c_nonce,omitempty"`
+}
+
+type credentialProof struct {
+	ProofType string `json:"proof_type"`
+	JWT       string `json:"jwt"`
+}
+
+type credentialRequest struct {
+	CredentialConfigurationID string           `json:"credential_configuration_id,omitempty"`
+	Format                    string           `json:"format,omitempty"`
+	Proof                     *credentialProof `json:"proof,omitempty"`
+}
+
+type credentialResponse struct {
+	Credential  json.RawMessage `json:"credential,omitempty"`
+	Credentials []struct {
+		Credential json.RawMessage `json:"credential"`
+	} `json:"credentials,omitempty"`
+	TransactionID string `json:"transaction_id,omitempty"`
+	CNonce        string `json:"c_nonce,omitempty"`
+}
+
+type batchCredentialResponse struct {
+	CredentialResponses []*credentialResponse `json:"credential_responses"`
+	CNonce              string                `json:"c_nonce,omitempty"`
+}
+
+// oauthError is the error response of the OAuth and OpenID4VCI endpoints.
+type oauthError struct {
+	status      int
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+	CNonce      string `json:"c_nonce,omitempty"`
+}
+
+func (e *oauthError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("endpoint returned status %d", e.status)
+	}
+
+	if e.Description == "" {
+		return fmt.Sprintf("endpoint returned error %s", e.Code)
+	}
+
+	return fmt.Sprintf("endpoint returned error %s: %s", e.Code, e.Description)
+}
+
+// ResolveCredentialOffer parses an OpenID4VCI credential offer URI, with the offer by value in
+// the credential_offer parameter or by reference in the credential_offer_uri parameter.
+func (c *Wallet) ResolveCredentialOffer(offerURI string, options ...OID4VCIOptions) (*CredentialOffer, error) {
+	opts := newOID4VCIOpts(options)
+
+	u, err := url.Parse(offerURI)
+	if err != nil {
+		return nil, fmt.Errorf("invalid credential offer URI: %w", err)
+	}
+
+	var offer CredentialOffer
+
+	switch {
+	case u.Query().Get("credential_offer") != "":
+		err = json.Unmarshal([]byte(u.Query().Get("credential_offer")), &offer)
+	case u.Query().Get("credential_offer_uri") != "":
+		err = opts.getJSON(u.Query().Get("credential_offer_uri"), &offer)
+	default:
+		return nil, errors.New("credential offer URI has neither credential_offer nor credential_offer_uri")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("read credential offer: %w", err)
+	}
+
+	if offer.CredentialIssuer == "" || len(offer.CredentialConfigurationIDs) == 0 {
+		return nil, errors.New("credential offer requires credential_issuer and credential_configuration_ids")
+	}
+
+	return &offer, nil
+}
+
+// AcceptCredentialOffer requests the credentials of the offer with its pre-authorized code, and saves them
+// into the wallet. The transaction code is provided by the WithTxCode option when required by the offer.
+//
+// Proofs of possession are signed with the key of the WithProofKeyID option.
+func (c *Wallet) AcceptCredentialOffer(authToken string, offer *CredentialOffer,
+	options ...OID4VCIOptions) (*OID4VCIResult, error) {
+	opts := newOID4VCIOpts(options)
+
+	if offer.Grants == nil || offer.Grants.PreAuthorizedCode == nil {
+		return nil, errors.New("credential offer has no pre-authorized code grant")
+	}
+
+	grant := offer.Grants.PreAuthorizedCode
+
+	if grant.TxCode != nil && opts.txCode == "" {
+		return nil, errors.New("credential offer requires a transaction code")
+	}
+
+	issuerMetadata, asMetadata, err := opts.discover(offer.CredentialIssuer, grant.AuthorizationServer)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":          {PreAuthorizedCodeGrantType},
+		"pre-authorized_code": {grant.PreAuthorizedCode},
+	}
+
+	if opts.txCode != "" {
+		form.Set("tx_code", opts.txCode)
+	}
+
+	if opts.clientID != "" {
+		form.Set("client_id", opts.clientID)
+	}
+
+	token, err := opts.requestToken(asMetadata.TokenEndpoint, form)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.requestCredentials(authToken, offer, issuerMetadata, token, opts)
+}
+
+// AuthorizeCredentialOffer starts the authorization code flow with PKCE for the credentials of the offer.
+// The user has to be redirected to the returned authorization URL, and the authorization code received
+// on the redirect URI of the WithRedirectURI option is then passed to CompleteCredentialAuthorization.
+func (c *Wallet) AuthorizeCredentialOffer(offer *CredentialOffer,
+	options ...OID4VCIOptions) (*OID4VCIAuthorization, error) {
+	opts := newOID4VCIOpts(options)
+
+	if opts.redirectURI == "" || opts.clientID == "" {
+		return nil, errors.New("client ID and redirect URI are required for the authorization code flow")
+	}
+
+	var grant AuthorizationCodeGrant
+
+	if offer.Grants != nil && offer.Grants.AuthorizationCode != nil {
+		grant = *offer.Grants.AuthorizationCode
+	}
+
+	_, asMetadata, err := opts.discover(offer.CredentialIssuer, grant.AuthorizationServer)
+	if err != nil {
+		return nil, err
+	}
+
+	if asMetadata.AuthorizationEndpoint == "" {
+		return nil, errors.New("authorization server has no authorization endpoint")
+	}
+
+	state, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+
+	codeVerifier, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+
+	var details []map[string]string
+
+	for _, id := range opts.configurationIDs(offer) {
+		details = append(details, map[string]string{
+			"type":                        openIDCredentialType,
+			"credential_configuration_id": id,
+		})
+	}
+
+	detailsBytes, err := json.Marshal(details)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := sha256.Sum256([]byte(codeVerifier))
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {opts.clientID},
+		"redirect_uri":          {opts.redirectURI},
+		"state":                 {state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {pkceMethodS256},
+		"authorization_details": {string(detailsBytes)},
+	}
+
+	if grant.IssuerState != "" {
+		query.Set("issuer_state", grant.IssuerState)
+	}
+
+	separator := "?"
+	if strings.Contains(asMetadata.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return &OID4VCIAuthorization{
+		AuthorizationURL: asMetadata.AuthorizationEndpoint + separator + query.Encode(),
+		State:            state,
+		CodeVerifier:     codeVerifier,
+		RedirectURI:      opts.redirectURI,
+		Offer:            offer,
+	}, nil
+}
+
+// CompleteCredentialAuthorization exchanges the authorization code received on the redirect URI for an
+// access token, requests the credentials of the authorized offer and saves them into the wallet.
+// The state received on the redirect URI must match the state of the authorization.
+func (c *Wallet) CompleteCredentialAuthorization(authToken string, authorization *OID4VCIAuthorization,
+	code, state string, options ...OID4VCIOptions) (*OID4VCIResult, error) {
+	opts := newOID4VCIOpts(options)
+
+	if state != authorization.State {
+		return nil, errors.New("authorization state mismatch")
+	}
+
+	var authorizationServer string
+
+	if grants := authorization.Offer.Grants; grants != nil && grants.AuthorizationCode != nil {
+		authorizationServer = grants.AuthorizationCode.AuthorizationServer
+	}
+
+	issuerMetadata, asMetadata, err := opts.discover(authorization.Offer.CredentialIssuer, authorizationServer)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := opts.requestToken(asMetadata.TokenEndpoint, url.Values{
+		"grant_type":    {authorizationCodeGrantType},
+		"code":          {code},
+		"redirect_uri":  {authorization.RedirectURI},
+		"code_verifier": {authorization.CodeVerifier},
+		"client_id":     {opts.clientID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.requestCredentials(authToken, authorization.Offer, issuerMetadata, token, opts)
+}
+
+// RequestDeferredCredential fetches a deferred credential and saves it into the wallet.
+// It returns ErrIssuancePending if the credential is not issued yet.
+func (c *Wallet) RequestDeferredCredential(authToken string, deferred *DeferredCredential,
+	options ...OID4VCIOptions) (*OID4VCIResult, error) {
+	opts := newOID4VCIOpts(options)
+
+	issuerMetadata, err := opts.credentialIssuerMetadata(deferred.CredentialIssuer)
+	if err != nil {
+		return nil, err
+	}
+
+	if issuerMetadata.DeferredCredentialEndpoint == "" {
+		return nil, errors.New("credential issuer has no deferred credential endpoint")
+	}
+
+	var resp credentialResponse
+
+	err = opts.postJSON(issuerMetadata.DeferredCredentialEndpoint, deferred.AccessToken,
+		map[string]string{"transaction_id": deferred.TransactionID}, &resp)
+	if err != nil {
+		var oauthErr *oauthError
+		if errors.As(err, &oauthErr) && oauthErr.Code == errorIssuancePending {
+			return nil, ErrIssuancePending
+		}
+
+		return nil, fmt.Errorf("request deferred credential: %w", err)
+	}
+
+	if _, ok := issuerMetadata.CredentialConfigurationsSupported[deferred.CredentialConfigurationID]; !ok {
+		return nil, fmt.Errorf("credential configuration %s is not supported by the credential issuer",
+			deferred.CredentialConfigurationID)
+	}
+
+	result := &OID4VCIResult{}
+
+	err = c.saveCredentialResponse(authToken, &resp, deferred.CredentialConfigurationID, issuerMetadata,
+		deferred, result, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// requestCredentials requests the offered credentials with the access token, through the batch credential
+// endpoint when the issuer supports it and several credentials are requested.
+func (c *Wallet) requestCredentials(authToken string, offer *CredentialOffer, metadata *CredentialIssuerMetadata,
+	token *tokenResponse, opts *oid4vciOpts) (*OID4VCIResult, error) {
+	ids := opts.configurationIDs(offer)
+
+	for _, id := range ids {
+		if _, ok := metadata.CredentialConfigurationsSupported[id]; !ok {
+			return nil, fmt.Errorf("credential configuration %s is not supported by the credential issuer", id)
+		}
+	}
+
+	nonce := token.CNonce
+
+	if nonce == "" && metadata.NonceEndpoint != "" {
+		var nonceResp struct {
+			CNonce string `json:"c_nonce"`
+		}
+
+		if err := opts.postJSON(metadata.NonceEndpoint, "", nil, &nonceResp); err != nil {
+			return nil, fmt.Errorf("request nonce: %w", err)
+		}
+
+		nonce = nonceResp.CNonce
+	}
+
+	deferred := &DeferredCredential{CredentialIssuer: metadata.CredentialIssuer, AccessToken: token.AccessToken}
+	result := &OID4VCIResult{}
+
+	if len(ids) > 1 && metadata.BatchCredentialEndpoint != "" {
+		if err := c.requestBatch(authToken, ids, metadata, token.AccessToken, nonce, deferred, result, opts); err != nil {
+			return nil, err
+		}
+
+		return result, nil
+	}
+
+	for _, id := range ids {
+		var (
+			resp *credentialResponse
+			err  error
+		)
+
+		resp, nonce, err = c.requestCredential(authToken, id, metadata, token.AccessToken, nonce, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = c.saveCredentialResponse(authToken, resp, id, metadata, deferred, result, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// requestCredential requests a credential and returns the nonce for the next request.
+func (c *Wallet) requestCredential(authToken, configurationID string, metadata *CredentialIssuerMetadata,
+	accessToken, nonce string, opts *oid4vciOpts) (*credentialResponse, string, error) {
+	var resp credentialResponse
+
+	err := retryWithFreshNonce(nonce, func(nonce string) error {
+		req, err := c.newCredentialRequest(authToken, configurationID, metadata, nonce, opts)
+		if err != nil {
+			return err
+		}
+
+		return opts.postJSON(metadata.CredentialEndpoint, accessToken, req, &resp)
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("request credential %s: %w", configurationID, err)
+	}
+
+	if resp.CNonce != "" {
+		nonce = resp.CNonce
+	}
+
+	return &resp, nonce, nil
+}
+
+func (c *Wallet) requestBatch(authToken string, ids []string, metadata *CredentialIssuerMetadata,
+	accessToken, nonce string, deferred *DeferredCredential, result *OID4VCIResult, opts *oid4vciOpts) error {
+	var resp batchCredentialResponse
+
+	err := retryWithFreshNonce(nonce, func(nonce string) error {
+		var requests []*credentialRequest
+
+		for _, id := range ids {
+			req, err := c.newCredentialRequest(authToken, id, metadata, nonce, opts)
+			if err != nil {
+				return err
+			}
+
+			requests = append(requests, req)
+		}
+
+		return opts.postJSON(metadata.BatchCredentialEndpoint, accessToken,
+			map[string]interface{}{"credential_requests": requests}, &resp)
+	})
+	if err != nil {
+		return fmt.Errorf("request batch credentials: %w", err)
+	}
+
+	// the credential responses are in the order of the credential requests.
+	if len(resp.CredentialResponses) != len(ids) {
+		return fmt.Errorf("batch credential response has %d credential responses for %d credential requests",
+			len(resp.CredentialResponses), len(ids))
+	}
+
+	for i, credResp := range resp.CredentialResponses {
+		if err = c.saveCredentialResponse(authToken, credResp, ids[i], metadata, deferred, result, opts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// retryWithFreshNonce sends the request once more with the nonce returned by the issuer if the proof nonce
+// is rejected.
+func retryWithFreshNonce(nonce string, request func(nonce string) error) error {
+	err := request(nonce)
+
+	var oauthErr *oauthError
+
+	if errors.As(err, &oauthErr) && oauthErr.CNonce != "" &&
+		(oauthErr.Code == errorInvalidProof || oauthErr.Code == errorInvalidNonce) {
+		return request(oauthErr.CNonce)
+	}
+
+	return err
+}
+
+func (c *Wallet) newCredentialRequest(authToken, configurationID string, metadata *CredentialIssuerMetadata,
+	nonce string, opts *oid4vciOpts) (*credentialRequest, error) {
+	req := &credentialRequest{
+		CredentialConfigurationID: configurationID,
+		Format:                    metadata.CredentialConfigurationsSupported[configurationID].Format,
+	}
+
+	if opts.proofKeyID == "" {
+		return req, nil
+	}
+
+	claims := map[string]interface{}{
+		"aud": metadata.CredentialIssuer,
+		"iat": time.Now().Unix(),
+	}
+
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+
+	if opts.clientID != "" {
+		claims["iss"] = opts.clientID
+	}
+
+	jwt, err := c.SignJWT(authToken, map[string]interface{}{"typ": ProofJWTType}, claims, opts.proofKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("sign proof of possession: %w", err)
+	}
+
+	req.Proof = &credentialProof{ProofType: "jwt", JWT: jwt}
+
+	return req, nil
+}
+
+// saveCredentialResponse verifies the credentials of the response and saves them into the wallet,
+// or records the transaction ID of a deferred credential.
+func (c *Wallet) saveCredentialResponse(authToken string, resp *credentialResponse, configurationID string,
+	metadata *CredentialIssuerMetadata, deferred *DeferredCredential, result *OID4VCIResult, opts *oid4vciOpts) error {
+	credentials := make([]json.RawMessage, 0, len(resp.Credentials)+1)
+
+	if len(resp.Credential) > 0 {
+		credentials = append(credentials, resp.Credential)
+	}
+
+	for _, cred := range resp.Credentials {
+		credentials = append(credentials, cred.Credential)
+	}
+
+	if len(credentials) == 0 {
+		if resp.TransactionID == "" {
+			return errors.New("credential response has neither credential nor transaction_id")
+		}
+
+		d := *deferred
+		d.CredentialConfigurationID = configurationID
+		d.TransactionID = resp.TransactionID
+		result.Deferred = append(result.Deferred, &d)
+
+		return nil
+	}
+
+	format := metadata.CredentialConfigurationsSupported[configurationID].Format
+
+	// all the credentials are verified before any is saved.
+	for _, cred := range credentials {
+		if err := c.verifyIssuedCredential(authToken, cred, format, metadata, opts); err != nil {
+			return fmt.Errorf("verify credential %s: %w", configurationID, err)
+		}
+	}
+
+	for _, cred := range credentials {
+		id, err := getContentID(cred)
+		if err != nil {
+			// credentials like SD-JWT VCs may have no ID, they are saved by digest.
+			digest := sha256.Sum256(cred)
+			id = hex.EncodeToString(digest[:])
+		}
+
+		addOpts := append([]AddContentOptions{withContentID(id)}, opts.addOpts...)
+
+		if err = c.contents.Save(authToken, Credential, cred, addOpts...); err != nil {
+			return fmt.Errorf("save credential: %w", err)
+		}
+
+		result.Credentials = append(result.Credentials, cred)
+	}
+
+	return nil
+}
+
+// verifyIssuedCredential verifies the proof of a credential issued in the format of its credential configuration,
+// and checks its issuer: SD-JWT VCs must be issued by the credential issuer, with a key of its JWT VC Issuer
+// Metadata, and W3C credentials by a DID which resolves to the key of their proof.
+func (c *Wallet) verifyIssuedCredential(authToken string, credential json.RawMessage, format string,
+	metadata *CredentialIssuerMetadata, opts *oid4vciOpts) error {
+	var jwt string
+
+	isJWT := json.Unmarshal(credential, &jwt) == nil
+
+	switch format {
+	case formatVCSDJWT, formatDCSDJWT:
+		if !isJWT {
+			return fmt.Errorf("%s credential is not a string", format)
+		}
+
+		vc, err := sdjwtvc.NewVerifier(sdjwtvc.WithHTTPClient(opts.httpClient)).Verify(jwt)
+		if err != nil {
+			return err
+		}
+
+		if vc.Issuer != metadata.CredentialIssuer {
+			return fmt.Errorf("credential is issued by %s instead of the credential issuer %s",
+				vc.Issuer, metadata.CredentialIssuer)
+		}
+
+		return nil
+	case formatJWTVCJSON, formatJWTVCJSONLD:
+		if !isJWT {
+			return fmt.Errorf("%s credential is not a JWT", format)
+		}
+	case formatLDPVC:
+		if isJWT {
+			return fmt.Errorf("%s credential is not a JSON object", format)
+		}
+	default:
+		return fmt.Errorf("credential format %s is not supported", format)
+	}
+
+	vdr := newContentBasedVDR(authToken, c.vdr, c.contents)
+
+	report, err := verifiable.VerifyCredential(credential,
+		verifiable.WithPublicKeyFetcher(verifiable.NewVDRKeyResolver(vdr).PublicKeyFetcher()),
+		verifiable.WithIssuerDIDResolver(vdr),
+		verifiable.WithJSONLDDocumentLoader(c.jsonldDocumentLoader))
+	if err != nil {
+		return err
+	}
+
+	for _, result := range report.Checks {
+		switch {
+		case result.Outcome == verifiable.CheckFailed:
+			return fmt.Errorf("%s check failed: %w", result.Check, result.Err)
+		case result.Outcome == verifiable.CheckSkipped &&
+			(result.Check == verifiable.ProofCheck || result.Check == verifiable.IssuerCheck):
+			// the proof and the issuer must be verified, not only not rejected.
+			return fmt.Errorf("%s check is skipped: %w", result.Check, result.Err)
+		}
+	}
+
+	return nil
+}
+
+func (opts *oid4vciOpts) configurationIDs(offer *CredentialOffer) []string {
+	if len(opts.credentialConfigurationIDs) > 0 {
+		return opts.credentialConfigurationIDs
+	}
+
+	return offer.CredentialConfigurationIDs
+}
+
+// discover fetches the metadata of the credential issuer and of its authorization server, the one of the grant
+// if set or else the first one of the credential issuer, which is its own authorization server by default.
+func (opts *oid4vciOpts) discover(credentialIssuer,
+	authorizationServer string) (*CredentialIssuerMetadata, *authorizationServerMetadata, error) {
+	issuerMetadata, err := opts.credentialIssuerMetadata(credentialIssuer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if authorizationServer == "" {
+		authorizationServer = issuerMetadata.CredentialIssuer
+
+		if len(issuerMetadata.AuthorizationServers) > 0 {
+			authorizationServer = issuerMetadata.AuthorizationServers[0]
+		}
+	}
+
+	var asMetadata authorizationServerMetadata
+
+	if err = opts.getJSON(wellKnownURL(authorizationServer, wellKnownAuthorizationSever), &asMetadata); err != nil {
+		return nil, nil, fmt.Errorf("fetch authorization server metadata: %w", err)
+	}
+
+	if asMetadata.TokenEndpoint == "" {
+		return nil, nil, errors.New("authorization server has no token endpoint")
+	}
+
+	return issuerMetadata, &asMetadata, nil
+}
+
+func (opts *oid4vciOpts) credentialIssuerMetadata(credentialIssuer string) (*CredentialIssuerMetadata, error) {
+	var metadata CredentialIssuerMetadata
+
+	if err := opts.getJSON(wellKnownURL(credentialIssuer, wellKnownCredentialIssuer), &metadata); err != nil {
+		return nil, fmt.Errorf("fetch credential issuer metadata: %w", err)
+	}
+
+	if metadata.CredentialIssuer != credentialIssuer {
+		return nil, fmt.Errorf("credential issuer metadata is for %s, not %s", metadata.CredentialIssuer,
+			credentialIssuer)
+	}
+
+	if metadata.CredentialEndpoint == "" {
+		return nil, errors.New("credential issuer has no credential endpoint")
+	}
+
+	return &metadata, nil
+}
+
+func (opts *oid4vciOpts) requestToken(tokenEndpoint string, form url.Values) (*tokenResponse, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, tokenEndpoint,
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("new token request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var token tokenResponse
+
+	if err = opts.do(req, &token); err != nil {
+		return nil, fmt.Errorf("request token: %w", err)
+	}
+
+	if token.AccessToken == "" {
+		return nil, errors.New("token response has no access token")
+	}
+
+	return &token, nil
+}
+
+func (opts *oid4vciOpts) getJSON(endpoint string, result interface{}) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+
+	return opts.do(req, result)
+}
+
+func (opts *oid4vciOpts) postJSON(endpoint, accessToken string, body, result interface{}) error {
+	var reqBody io.Reader = http.NoBody
+
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		reqBody = bytes.NewReader(bodyBytes)
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, endpoint, reqBody)
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	return opts.do(req, result)
+}
+
+func (opts *oid4vciOpts) do(req *http.Request, result interface{}) error {
+	body, err := doHTTP(opts.httpClient, req)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
+
+// doHTTP sends the request and returns the response body, or the OAuth error of the response.
+func doHTTP(httpClient HTTPClient, req *http.Request) ([]byte, error) {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("httpClient do: %w", err)
+	}
+
+	defer func() {
+		if e := resp.Body.Close(); e != nil {
+			logger.Warnf("failed to close response body: %v", e)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		oauthErr := &oauthError{status: resp.StatusCode}
+
+		// the error response is optional, the status is reported otherwise.
+		_ = json.NewDecoder(io.LimitReader(resp.Body, maxOID4VCIResponseSize)).Decode(oauthErr) // nolint:errcheck
+
+		return nil, oauthErr
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxOID4VCIResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	if len(body) > maxOID4VCIResponseSize {
+		return nil, fmt.Errorf("response exceeds the maximum size of %d bytes", maxOID4VCIResponseSize)
+	}
+
+	return body, nil
+}
+
+// wellKnownURL inserts the well-known path between the host and the path of the identifier.
+func wellKnownURL(identifier, wellKnown string) string {
+	u, err := url.Parse(identifier)
+	if err != nil || u.Host == "" {
+		return strings.TrimSuffix(identifier, "/") + wellKnown
+	}
+
+	u.Path = wellKnown + strings.TrimSuffix(u.Path, "/")
+	u.RawPath = ""
+
+	return u.String()
+}
+
+func randomString() (string, error) {
+	b := make([]byte, oid4vciRandomBytes)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate random value: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
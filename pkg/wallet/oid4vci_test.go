@@ -0,0 +1,665 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jwkkid"
+	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/key"
+)
+
+const (
+	samplePreAuthCode = "pre-auth-code"
+	sampleTxCode      = "493536"
+	sampleAuthCode    = "auth-code"
+	sampleAccessToken = "access-token"
+	sampleClientID    = "wallet-client"
+	sampleRedirectURI = "https://wallet.example.com/cb"
+	sampleTxID        = "tx-1"
+)
+
+// issuerStub is an in-process OpenID4VCI credential issuer, also acting as its authorization server.
+type issuerStub struct {
+	t      *testing.T
+	server *httptest.Server
+
+	// issuerKey signs the credentials of the issuer DID, a did:key.
+	issuerKey   ed25519.PrivateKey
+	issuerDID   string
+	issuerKeyID string
+
+	mu            sync.Mutex
+	nonce         string
+	codeChallenge string
+	pending       bool
+	noBatch       bool
+	deferred      bool
+	unsigned      bool
+	proofs        []string
+}
+
+func newIssuerStub(t *testing.T) *issuerStub {
+	t.Helper()
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	s := &issuerStub{t: t, nonce: "nonce-1", issuerKey: privKey}
+	s.issuerDID, s.issuerKeyID = fingerprint.CreateDIDKey(pubKey)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-credential-issuer", s.issuerMetadata)
+	mux.HandleFunc("/.well-known/oauth-authorization-server", s.asMetadata)
+	mux.HandleFunc("/offer", s.offer)
+	mux.HandleFunc("/token", s.token)
+	mux.HandleFunc("/credential", s.credential)
+	mux.HandleFunc("/batch_credential", s.batchCredential)
+	mux.HandleFunc("/deferred_credential", s.deferredCredential)
+
+	s.server = httptest.NewTLSServer(mux)
+	t.Cleanup(s.server.Close)
+
+	return s
+}
+
+func (s *issuerStub) url() string {
+	return s.server.URL
+}
+
+func (s *issuerStub) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	require.NoError(s.t, json.NewEncoder(w).Encode(v))
+}
+
+func (s *issuerStub) issuerMetadata(w http.ResponseWriter, _ *http.Request) {
+	metadata := map[string]interface{}{
+		"credential_issuer":            s.url(),
+		"credential_endpoint":          s.url() + "/credential",
+		"deferred_credential_endpoint": s.url() + "/deferred_credential",
+		"credential_configurations_supported": map[string]interface{}{
+			"UniversityDegree": map[string]interface{}{"format": "jwt_vc_json"},
+			"DriversLicense":   map[string]interface{}{"format": "jwt_vc_json"},
+			"MobileDL":         map[string]interface{}{"format": "mso_mdoc"},
+		},
+	}
+
+	if !s.noBatch {
+		metadata["batch_credential_endpoint"] = s.url() + "/batch_credential"
+	}
+
+	s.writeJSON(w, http.StatusOK, metadata)
+}
+
+func (s *issuerStub) asMetadata(w http.ResponseWriter, _ *http.Request) {
+	s.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                 s.url(),
+		"authorization_endpoint": s.url() + "/authorize",
+		"token_endpoint":         s.url() + "/token",
+	})
+}
+
+func (s *issuerStub) offer(w http.ResponseWriter, _ *http.Request) {
+	s.writeJSON(w, http.StatusOK, s.preAuthOffer())
+}
+
+func (s *issuerStub) preAuthOffer() *CredentialOffer {
+	return &CredentialOffer{
+		CredentialIssuer:           s.url(),
+		CredentialConfigurationIDs: []string{"UniversityDegree"},
+		Grants: &CredentialOfferGrants{
+			PreAuthorizedCode: &PreAuthorizedCodeGrant{
+				PreAuthorizedCode: samplePreAuthCode,
+				TxCode:            &TxCode{InputMode: "numeric", Length: len(sampleTxCode)},
+			},
+		},
+	}
+}
+
+func (s *issuerStub) token(w http.ResponseWriter, r *http.Request) {
+	require.NoError(s.t, r.ParseForm())
+
+	switch r.PostForm.Get("grant_type") {
+	case PreAuthorizedCodeGrantType:
+		if r.PostForm.Get("pre-authorized_code") != samplePreAuthCode || r.PostForm.Get("tx_code") != sampleTxCode {
+			s.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+
+			return
+		}
+	case authorizationCodeGrantType:
+		challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+
+		if r.PostForm.Get("code") != sampleAuthCode || r.PostForm.Get("redirect_uri") != sampleRedirectURI ||
+			base64.RawURLEncoding.EncodeToString(challenge[:]) != s.codeChallenge {
+			s.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+
+			return
+		}
+	default:
+		s.writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+
+		return
+	}
+
+	// the token nonce is stale, to make the wallet retry with the nonce of the credential error.
+	s.writeJSON(w, http.StatusOK, map[string]string{
+		"access_token": sampleAccessToken,
+		"token_type":   "Bearer",
+		"c_nonce":      "stale-nonce",
+	})
+}
+
+// checkProof checks the request authorization and proof, and writes the error response if invalid.
+func (s *issuerStub) checkProof(w http.ResponseWriter, r *http.Request, req *credentialRequest) bool {
+	if r.Header.Get("Authorization") != "Bearer "+sampleAccessToken {
+		s.writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_token"})
+
+		return false
+	}
+
+	require.NotNil(s.t, req.Proof)
+	require.Equal(s.t, "jwt", req.Proof.ProofType)
+
+	parts := strings.Split(req.Proof.JWT, ".")
+	require.Len(s.t, parts, 3)
+
+	var headers, claims map[string]interface{}
+
+	headersBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	require.NoError(s.t, err)
+	require.NoError(s.t, json.Unmarshal(headersBytes, &headers))
+
+	claimsBytes, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(s.t, err)
+	require.NoError(s.t, json.Unmarshal(claimsBytes, &claims))
+
+	require.Equal(s.t, ProofJWTType, headers["typ"])
+	require.Equal(s.t, sampleVerificationMethod, headers["kid"])
+	require.Equal(s.t, s.url(), claims["aud"])
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.proofs = append(s.proofs, req.Proof.JWT)
+
+	if claims["nonce"] != s.nonce {
+		s.writeJSON(w, http.StatusBadRequest, map[string]string{
+			"error":   errorInvalidProof,
+			"c_nonce": s.nonce,
+		})
+
+		return false
+	}
+
+	return true
+}
+
+func (s *issuerStub) credentialResponse(configurationID string) map[string]interface{} {
+	if s.deferred {
+		return map[string]interface{}{"transaction_id": sampleTxID}
+	}
+
+	return map[string]interface{}{"credential": s.jwtCredential(configurationID)}
+}
+
+func (s *issuerStub) credential(w http.ResponseWriter, r *http.Request) {
+	var req credentialRequest
+
+	require.NoError(s.t, json.NewDecoder(r.Body).Decode(&req))
+
+	if !s.checkProof(w, r, &req) {
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, s.credentialResponse(req.CredentialConfigurationID))
+}
+
+func (s *issuerStub) batchCredential(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		CredentialRequests []*credentialRequest `json:"credential_requests"`
+	}
+
+	require.NoError(s.t, json.NewDecoder(r.Body).Decode(&req))
+
+	var responses []map[string]interface{}
+
+	for _, credReq := range req.CredentialRequests {
+		if !s.checkProof(w, r, credReq) {
+			return
+		}
+
+		responses = append(responses, s.credentialResponse(credReq.CredentialConfigurationID))
+	}
+
+	s.writeJSON(w, http.StatusOK, map[string]interface{}{"credential_responses": responses})
+}
+
+func (s *issuerStub) deferredCredential(w http.ResponseWriter, r *http.Request) {
+	var req map[string]string
+
+	require.NoError(s.t, json.NewDecoder(r.Body).Decode(&req))
+	require.Equal(s.t, sampleTxID, req["transaction_id"])
+	require.Equal(s.t, "Bearer "+sampleAccessToken, r.Header.Get("Authorization"))
+
+	if s.pending {
+		s.writeJSON(w, http.StatusBadRequest, map[string]string{"error": errorIssuancePending})
+
+		return
+	}
+
+	s.writeJSON(w, http.StatusOK, map[string]interface{}{"credential": s.jwtCredential("UniversityDegree")})
+}
+
+// jwtCredential returns a JWT VC without jti signed by the issuer DID, or an unsecured JWT VC.
+func (s *issuerStub) jwtCredential(configurationID string) string {
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss": s.issuerDID,
+		"vc": map[string]interface{}{
+			"@context":          []string{"https://www.w3.org/2018/credentials/v1"},
+			"type":              []string{"VerifiableCredential", configurationID},
+			"issuer":            s.issuerDID,
+			"credentialSubject": map[string]string{"id": didKey},
+			"nonce":             uuid.New().String(),
+		},
+	})
+	require.NoError(s.t, err)
+
+	if s.unsigned {
+		return base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." +
+			base64.RawURLEncoding.EncodeToString(claims) + "."
+	}
+
+	headers, err := json.Marshal(map[string]string{"alg": "EdDSA", "typ": "JWT", "kid": s.issuerKeyID})
+	require.NoError(s.t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headers) + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	return signingInput + "." +
+		base64.RawURLEncoding.EncodeToString(ed25519.Sign(s.issuerKey, []byte(signingInput)))
+}
+
+func newOID4VCIWallet(t *testing.T) (*Wallet, string) {
+	t.Helper()
+
+	user := uuid.New().String()
+
+	mockctx := newMockProvider(t)
+	mockctx.VDRegistryValue = &mockvdr.MockVDRegistry{
+		ResolveFunc: func(didID string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error) {
+			return key.New().Read(didID)
+		},
+	}
+
+	var err error
+
+	mockctx.CryptoValue, err = tinkcrypto.New()
+	require.NoError(t, err)
+
+	require.NoError(t, CreateProfile(user, mockctx, WithPassphrase(samplePassPhrase)))
+
+	walletInstance, err := New(user, mockctx)
+	require.NoError(t, err)
+
+	authToken, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { walletInstance.Close() })
+
+	session, err := sessionManager().getSession(authToken)
+	require.NoError(t, err)
+
+	edPriv := ed25519.PrivateKey(base58.Decode(pkBase58))
+
+	edPub, ok := edPriv.Public().(ed25519.PublicKey)
+	require.True(t, ok)
+
+	kmsKID, err := jwkkid.CreateKID(edPub, kms.ED25519Type)
+	require.NoError(t, err)
+
+	_, _, err = session.KeyManager.ImportPrivateKey(edPriv, kms.ED25519, kms.WithKeyID(kmsKID))
+	require.NoError(t, err)
+
+	return walletInstance, authToken
+}
+
+func TestWallet_ResolveCredentialOffer(t *testing.T) {
+	issuer := newIssuerStub(t)
+	walletInstance, _ := newOID4VCIWallet(t)
+
+	t.Run("by value", func(t *testing.T) {
+		offerBytes, err := json.Marshal(issuer.preAuthOffer())
+		require.NoError(t, err)
+
+		offer, err := walletInstance.ResolveCredentialOffer(CredentialOfferScheme + "://?credential_offer=" +
+			url.QueryEscape(string(offerBytes)))
+		require.NoError(t, err)
+		require.Equal(t, issuer.preAuthOffer(), offer)
+	})
+
+	t.Run("by reference", func(t *testing.T) {
+		offer, err := walletInstance.ResolveCredentialOffer(CredentialOfferScheme+"://?credential_offer_uri="+
+			url.QueryEscape(issuer.url()+"/offer"), WithOID4VCIHTTPClient(issuer.server.Client()))
+		require.NoError(t, err)
+		require.Equal(t, issuer.preAuthOffer(), offer)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		_, err := walletInstance.ResolveCredentialOffer(CredentialOfferScheme + "://")
+		require.EqualError(t, err, "credential offer URI has neither credential_offer nor credential_offer_uri")
+
+		_, err = walletInstance.ResolveCredentialOffer(CredentialOfferScheme + "://?credential_offer=%7B%7D")
+		require.EqualError(t, err, "credential offer requires credential_issuer and credential_configuration_ids")
+
+		_, err = walletInstance.ResolveCredentialOffer(CredentialOfferScheme + "://?credential_offer=invalid")
+		require.ErrorContains(t, err, "read credential offer")
+
+		_, err = walletInstance.ResolveCredentialOffer(CredentialOfferScheme+"://?credential_offer_uri="+
+			url.QueryEscape(issuer.url()+"/missing"), WithOID4VCIHTTPClient(issuer.server.Client()))
+		require.ErrorContains(t, err, "endpoint returned status 404")
+	})
+}
+
+func TestWallet_AcceptCredentialOffer(t *testing.T) {
+	t.Run("pre-authorized code with tx_code", func(t *testing.T) {
+		issuer := newIssuerStub(t)
+		walletInstance, authToken := newOID4VCIWallet(t)
+
+		result, err := walletInstance.AcceptCredentialOffer(authToken, issuer.preAuthOffer(),
+			WithOID4VCIHTTPClient(issuer.server.Client()), WithTxCode(sampleTxCode),
+			WithProofKeyID(sampleVerificationMethod))
+		require.NoError(t, err)
+		require.Len(t, result.Credentials, 1)
+		require.Empty(t, result.Deferred)
+
+		// the stale nonce of the token response is rejected, then the proof is signed with the fresh one.
+		require.Len(t, issuer.proofs, 2)
+
+		require.NoError(t, walletInstance.VerifyJWT(issuer.proofs[1]))
+
+		credentials, err := walletInstance.GetAll(authToken, Credential)
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+
+		for _, cred := range credentials {
+			require.JSONEq(t, string(result.Credentials[0]), string(cred))
+		}
+	})
+
+	t.Run("batch credentials into a collection", func(t *testing.T) {
+		issuer := newIssuerStub(t)
+		walletInstance, authToken := newOID4VCIWallet(t)
+
+		const collectionID = "did:example:acme123456789abcdefghi"
+
+		require.NoError(t, walletInstance.Add(authToken, Collection, []byte(`{
+			"@context": ["https://w3id.org/wallet/v1"],
+			"id": "`+collectionID+`",
+			"type": "Organization",
+			"name": "Acme Corp"
+		}`)))
+
+		offer := issuer.preAuthOffer()
+		offer.CredentialConfigurationIDs = []string{"UniversityDegree", "DriversLicense"}
+
+		result, err := walletInstance.AcceptCredentialOffer(authToken, offer,
+			WithOID4VCIHTTPClient(issuer.server.Client()), WithTxCode(sampleTxCode),
+			WithProofKeyID(sampleVerificationMethod), WithSaveOptions(AddByCollection(collectionID)))
+		require.NoError(t, err)
+		require.Len(t, result.Credentials, 2)
+
+		credentials, err := walletInstance.GetAll(authToken, Credential, FilterByCollection(collectionID))
+		require.NoError(t, err)
+		require.Len(t, credentials, 2)
+	})
+
+	t.Run("requested configurations without batch endpoint", func(t *testing.T) {
+		issuer := newIssuerStub(t)
+		issuer.noBatch = true
+		walletInstance, authToken := newOID4VCIWallet(t)
+
+		offer := issuer.preAuthOffer()
+		offer.CredentialConfigurationIDs = []string{"UniversityDegree", "DriversLicense"}
+
+		result, err := walletInstance.AcceptCredentialOffer(authToken, offer,
+			WithOID4VCIHTTPClient(issuer.server.Client()), WithTxCode(sampleTxCode),
+			WithProofKeyID(sampleVerificationMethod), WithCredentialConfigurations("DriversLicense"))
+		require.NoError(t, err)
+		require.Len(t, result.Credentials, 1)
+		require.Contains(t, credentialTypes(t, result.Credentials[0]), "DriversLicense")
+	})
+
+	t.Run("deferred credential", func(t *testing.T) {
+		issuer := newIssuerStub(t)
+		issuer.deferred = true
+		issuer.pending = true
+		walletInstance, authToken := newOID4VCIWallet(t)
+
+		opts := []OID4VCIOptions{
+			WithOID4VCIHTTPClient(issuer.server.Client()), WithTxCode(sampleTxCode),
+			WithProofKeyID(sampleVerificationMethod),
+		}
+
+		result, err := walletInstance.AcceptCredentialOffer(authToken, issuer.preAuthOffer(), opts...)
+		require.NoError(t, err)
+		require.Empty(t, result.Credentials)
+		require.Equal(t, []*DeferredCredential{{
+			CredentialIssuer:          issuer.url(),
+			CredentialConfigurationID: "UniversityDegree",
+			TransactionID:             sampleTxID,
+			AccessToken:               sampleAccessToken,
+		}}, result.Deferred)
+
+		_, err = walletInstance.RequestDeferredCredential(authToken, result.Deferred[0], opts...)
+		require.ErrorIs(t, err, ErrIssuancePending)
+
+		issuer.pending = false
+
+		deferredResult, err := walletInstance.RequestDeferredCredential(authToken, result.Deferred[0], opts...)
+		require.NoError(t, err)
+		require.Len(t, deferredResult.Credentials, 1)
+
+		credentials, err := walletInstance.GetAll(authToken, Credential)
+		require.NoError(t, err)
+		require.Len(t, credentials, 1)
+
+		deferred := *result.Deferred[0]
+		deferred.CredentialConfigurationID = "Passport"
+
+		deferredResult, err = walletInstance.RequestDeferredCredential(authToken, &deferred, opts...)
+		require.EqualError(t, err, "credential configuration Passport is not supported by the credential issuer")
+		require.Nil(t, deferredResult)
+	})
+
+	t.Run("unverified credentials are not saved", func(t *testing.T) {
+		issuer := newIssuerStub(t)
+		issuer.unsigned = true
+		walletInstance, authToken := newOID4VCIWallet(t)
+
+		opts := []OID4VCIOptions{
+			WithOID4VCIHTTPClient(issuer.server.Client()), WithTxCode(sampleTxCode),
+			WithProofKeyID(sampleVerificationMethod),
+		}
+
+		offer := issuer.preAuthOffer()
+		offer.CredentialConfigurationIDs = []string{"UniversityDegree", "DriversLicense"}
+
+		result, err := walletInstance.AcceptCredentialOffer(authToken, offer, opts...)
+		require.ErrorContains(t, err, "verify credential UniversityDegree: proof check failed")
+		require.Nil(t, result)
+
+		issuer.noBatch = true
+
+		result, err = walletInstance.AcceptCredentialOffer(authToken, offer, opts...)
+		require.ErrorContains(t, err, "verify credential UniversityDegree: proof check failed")
+		require.Nil(t, result)
+
+		issuer.unsigned = false
+		offer.CredentialConfigurationIDs = []string{"MobileDL"}
+
+		result, err = walletInstance.AcceptCredentialOffer(authToken, offer, opts...)
+		require.EqualError(t, err, "verify credential MobileDL: credential format mso_mdoc is not supported")
+		require.Nil(t, result)
+
+		credentials, err := walletInstance.GetAll(authToken, Credential)
+		require.NoError(t, err)
+		require.Empty(t, credentials)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		issuer := newIssuerStub(t)
+		walletInstance, authToken := newOID4VCIWallet(t)
+		client := WithOID4VCIHTTPClient(issuer.server.Client())
+
+		_, err := walletInstance.AcceptCredentialOffer(authToken, &CredentialOffer{CredentialIssuer: issuer.url()})
+		require.EqualError(t, err, "credential offer has no pre-authorized code grant")
+
+		_, err = walletInstance.AcceptCredentialOffer(authToken, issuer.preAuthOffer(), client)
+		require.EqualError(t, err, "credential offer requires a transaction code")
+
+		_, err = walletInstance.AcceptCredentialOffer(authToken, issuer.preAuthOffer(), client, WithTxCode("000000"))
+		require.EqualError(t, err, "request token: endpoint returned error invalid_grant")
+
+		offer := issuer.preAuthOffer()
+		offer.CredentialConfigurationIDs = []string{"Passport"}
+
+		_, err = walletInstance.AcceptCredentialOffer(authToken, offer, client, WithTxCode(sampleTxCode))
+		require.EqualError(t, err, "credential configuration Passport is not supported by the credential issuer")
+
+		offer = issuer.preAuthOffer()
+		offer.CredentialIssuer = issuer.url() + "/other"
+
+		_, err = walletInstance.AcceptCredentialOffer(authToken, offer, client, WithTxCode(sampleTxCode))
+		require.ErrorContains(t, err, "fetch credential issuer metadata")
+
+		_, err = walletInstance.AcceptCredentialOffer(authToken, issuer.preAuthOffer(), client,
+			WithTxCode(sampleTxCode), WithProofKeyID("did:foo:bar#keyID#extraKeyID"))
+		require.ErrorContains(t, err, "sign proof of possession")
+
+		_, err = walletInstance.AcceptCredentialOffer(sampleFakeTkn, issuer.preAuthOffer(), client,
+			WithTxCode(sampleTxCode), WithProofKeyID(sampleVerificationMethod))
+		require.ErrorIs(t, err, ErrWalletLocked)
+	})
+}
+
+func TestWallet_AuthorizeCredentialOffer(t *testing.T) {
+	issuer := newIssuerStub(t)
+	walletInstance, authToken := newOID4VCIWallet(t)
+
+	offer := &CredentialOffer{
+		CredentialIssuer:           issuer.url(),
+		CredentialConfigurationIDs: []string{"UniversityDegree"},
+		Grants: &CredentialOfferGrants{
+			AuthorizationCode: &AuthorizationCodeGrant{IssuerState: "issuer-state"},
+		},
+	}
+
+	opts := []OID4VCIOptions{
+		WithOID4VCIHTTPClient(issuer.server.Client()), WithClientID(sampleClientID),
+		WithRedirectURI(sampleRedirectURI), WithProofKeyID(sampleVerificationMethod),
+	}
+
+	t.Run("authorization code with PKCE", func(t *testing.T) {
+		authorization, err := walletInstance.AuthorizeCredentialOffer(offer, opts...)
+		require.NoError(t, err)
+
+		authURL, err := url.Parse(authorization.AuthorizationURL)
+		require.NoError(t, err)
+		require.Equal(t, issuer.url()+"/authorize", authURL.Scheme+"://"+authURL.Host+authURL.Path)
+
+		query := authURL.Query()
+		require.Equal(t, "code", query.Get("response_type"))
+		require.Equal(t, sampleClientID, query.Get("client_id"))
+		require.Equal(t, sampleRedirectURI, query.Get("redirect_uri"))
+		require.Equal(t, "issuer-state", query.Get("issuer_state"))
+		require.Equal(t, authorization.State, query.Get("state"))
+		require.Equal(t, pkceMethodS256, query.Get("code_challenge_method"))
+		require.JSONEq(t, `[{"type":"openid_credential","credential_configuration_id":"UniversityDegree"}]`,
+			query.Get("authorization_details"))
+
+		issuer.codeChallenge = query.Get("code_challenge")
+
+		result, err := walletInstance.CompleteCredentialAuthorization(authToken, authorization, sampleAuthCode,
+			authorization.State, opts...)
+		require.NoError(t, err)
+		require.Len(t, result.Credentials, 1)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		_, err := walletInstance.AuthorizeCredentialOffer(offer)
+		require.EqualError(t, err, "client ID and redirect URI are required for the authorization code flow")
+
+		authorization, err := walletInstance.AuthorizeCredentialOffer(offer, opts...)
+		require.NoError(t, err)
+
+		_, err = walletInstance.CompleteCredentialAuthorization(authToken, authorization, sampleAuthCode,
+			"other-state", opts...)
+		require.EqualError(t, err, "authorization state mismatch")
+
+		// the code challenge of the authorization is not the one registered by the issuer.
+		issuer.codeChallenge = "other-challenge"
+
+		_, err = walletInstance.CompleteCredentialAuthorization(authToken, authorization, sampleAuthCode,
+			authorization.State, opts...)
+		require.EqualError(t, err, "request token: endpoint returned error invalid_grant")
+	})
+}
+
+func TestDoHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := w.Write(make([]byte, maxOID4VCIResponseSize+1))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	_, err = doHTTP(server.Client(), req)
+	require.EqualError(t, err, fmt.Sprintf("response exceeds the maximum size of %d bytes", maxOID4VCIResponseSize))
+}
+
+func TestWellKnownURL(t *testing.T) {
+	require.Equal(t, "https://issuer.example.com/.well-known/openid-credential-issuer",
+		wellKnownURL("https://issuer.example.com/", wellKnownCredentialIssuer))
+	require.Equal(t, "https://issuer.example.com/.well-known/openid-credential-issuer/tenant",
+		wellKnownURL("https://issuer.example.com/tenant", wellKnownCredentialIssuer))
+}
+
+func credentialTypes(t *testing.T, credential json.RawMessage) string {
+	t.Helper()
+
+	var jwt string
+
+	require.NoError(t, json.Unmarshal(credential, &jwt))
+
+	claims, err := base64.RawURLEncoding.DecodeString(strings.Split(jwt, ".")[1])
+	require.NoError(t, err)
+
+	return fmt.Sprint(string(claims))
+}
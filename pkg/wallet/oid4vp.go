@@ -0,0 +1,788 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/did"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/presexch"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/verifiable"
+)
+
+// OpenID4VP and SIOPv2 constants.
+const (
+	// ResponseTypeVPToken is the response type requesting a verifiable presentation.
+	ResponseTypeVPToken = "vp_token"
+	// ResponseTypeIDToken is the response type requesting a self-issued ID token.
+	ResponseTypeIDToken = "id_token"
+
+	// ResponseModeDirectPost posts the response to the response URI of the verifier.
+	ResponseModeDirectPost = "direct_post"
+	// ResponseModeDirectPostJWT posts the response to the response URI of the verifier, encrypted in a JWE.
+	ResponseModeDirectPostJWT = "direct_post.jwt"
+	// ResponseModeFragment returns the response in the fragment of the redirect URI.
+	ResponseModeFragment = "fragment"
+
+	// ClientIDSchemeRedirectURI is the client ID scheme of verifiers identified by their redirect URI,
+	// sending unsigned requests.
+	ClientIDSchemeRedirectURI = "redirect_uri"
+	// ClientIDSchemeDID is the client ID scheme of verifiers identified by a DID, signing requests with
+	// a key of their DID.
+	ClientIDSchemeDID = "did"
+	// ClientIDSchemeX509SANDNS is the client ID scheme of verifiers identified by a DNS name, signing requests
+	// with the key of an X.509 certificate holding the DNS name.
+	ClientIDSchemeX509SANDNS = "x509_san_dns"
+
+	requestObjectMediaType = "application/oauth-authz-req+jwt"
+	idTokenTTL             = 10 * time.Minute
+)
+
+// AuthorizationRequest is an OpenID4VP or SIOPv2 authorization request of a verifier.
+// Refer https://openid.net/specs/openid-4-verifiable-presentations-1_0.html#name-authorization-request.
+type AuthorizationRequest struct {
+	ClientID               string                           `json:"client_id"`
+	ClientIDScheme         string                           `json:"client_id_scheme,omitempty"`
+	ResponseType           string                           `json:"response_type"`
+	ResponseMode           string                           `json:"response_mode,omitempty"`
+	ResponseURI            string                           `json:"response_uri,omitempty"`
+	RedirectURI            string                           `json:"redirect_uri,omitempty"`
+	Nonce                  string                           `json:"nonce"`
+	State                  string                           `json:"state,omitempty"`
+	Scope                  string                           `json:"scope,omitempty"`
+	PresentationDefinition *presexch.PresentationDefinition `json:"presentation_definition,omitempty"`
+	ClientMetadata         *ClientMetadata                  `json:"client_metadata,omitempty"`
+
+	PresentationDefinitionURI string `json:"presentation_definition_uri,omitempty"`
+	ClientMetadataURI         string `json:"client_metadata_uri,omitempty"`
+}
+
+// ClientMetadata is the metadata of a verifier.
+type ClientMetadata struct {
+	JWKS *JWKS `json:"jwks,omitempty"`
+	// AuthorizationEncryptedResponseAlg and AuthorizationEncryptedResponseEnc are the JWE algorithms of the
+	// direct_post.jwt responses.
+	AuthorizationEncryptedResponseAlg string                 `json:"authorization_encrypted_response_alg,omitempty"`
+	AuthorizationEncryptedResponseEnc string                 `json:"authorization_encrypted_response_enc,omitempty"`
+	VPFormats                         map[string]interface{} `json:"vp_formats,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set.
+type JWKS struct {
+	Keys []*jwk.JWK `json:"keys"`
+}
+
+// AuthorizationResponse is the response to an authorization request.
+type AuthorizationResponse struct {
+	// VPToken is a JWT VP as a JSON string, or a VP with embedded proof.
+	VPToken                json.RawMessage                  `json:"vp_token,omitempty"`
+	PresentationSubmission *presexch.PresentationSubmission `json:"presentation_submission,omitempty"`
+	IDToken                string                           `json:"id_token,omitempty"`
+	State                  string                           `json:"state,omitempty"`
+
+	// RedirectURI is the URI the user has to be redirected to, returned by the verifier for posted responses,
+	// or the redirect URI of the request with the response in its fragment.
+	RedirectURI string `json:"-"`
+}
+
+// ResolveAuthorizationRequest parses an OpenID4VP or SIOPv2 authorization request URI, with the request
+// parameters in the URI, or in a request object by value in the request parameter or by reference in the
+// request_uri parameter.
+//
+// Signed request objects are verified according to the client ID scheme of the verifier.
+func (c *Wallet) ResolveAuthorizationRequest(requestURI string,
+	options ...OID4VPOptions) (*AuthorizationRequest, error) {
+	opts := newOID4VPOpts(options)
+
+	u, err := url.Parse(requestURI)
+	if err != nil {
+		return nil, fmt.Errorf("invalid authorization request URI: %w", err)
+	}
+
+	query := u.Query()
+
+	requestObject := query.Get("request")
+
+	if query.Get("request_uri") != "" {
+		requestObject, err = opts.fetchRequestObject(query.Get("request_uri"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var request *AuthorizationRequest
+
+	if requestObject != "" {
+		request, err = c.parseRequestObject(strings.TrimSpace(requestObject), opts)
+		if err != nil {
+			return nil, err
+		}
+
+		if query.Get("client_id") != "" && query.Get("client_id") != request.ClientID {
+			return nil, errors.New("client_id of the request object does not match the client_id parameter")
+		}
+	} else {
+		request, err = parseRequestParameters(query)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = checkClientID(request, false); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = opts.resolveReferences(request); err != nil {
+		return nil, err
+	}
+
+	if err = checkAuthorizationRequest(request); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// RespondAuthorizationRequest responds to an authorization request with a verifiable presentation of the
+// wallet credentials matching the presentation definition, and a self-issued ID token for SIOPv2 requests.
+// Both are signed with the DID of the proof options, and bound to the verifier and the request nonce.
+//
+// Responses are posted to the verifier for the direct_post and direct_post.jwt response modes, and
+// returned in the fragment of the redirect URI otherwise.
+func (c *Wallet) RespondAuthorizationRequest(authToken string, request *AuthorizationRequest,
+	proofOptions *ProofOptions, options ...OID4VPOptions) (*AuthorizationResponse, error) {
+	opts := newOID4VPOpts(options)
+
+	if proofOptions == nil {
+		return nil, errors.New("invalid proof option, 'controller' is required")
+	}
+
+	// the proof options of the caller are not modified.
+	proofOpts := *proofOptions
+	proofOpts.Challenge = request.Nonce
+	proofOpts.Domain = request.ClientID
+
+	if err := c.validateProofOption(authToken, &proofOpts, did.Authentication); err != nil {
+		return nil, fmt.Errorf("failed to prepare proof: %w", err)
+	}
+
+	response := &AuthorizationResponse{State: request.State}
+
+	if hasResponseType(request, ResponseTypeVPToken) {
+		if err := c.createVPToken(authToken, request, &proofOpts, response); err != nil {
+			return nil, err
+		}
+	}
+
+	if hasResponseType(request, ResponseTypeIDToken) {
+		if err := c.createIDToken(authToken, request, &proofOpts, response); err != nil {
+			return nil, err
+		}
+	}
+
+	switch request.ResponseMode {
+	case ResponseModeDirectPost, ResponseModeDirectPostJWT:
+		if err := c.postAuthorizationResponse(request, response, opts); err != nil {
+			return nil, err
+		}
+
+		return response, nil
+	default:
+		params, err := response.params()
+		if err != nil {
+			return nil, err
+		}
+
+		response.RedirectURI = request.RedirectURI + "#" + params.Encode()
+
+		return response, nil
+	}
+}
+
+func (c *Wallet) createVPToken(authToken string, request *AuthorizationRequest, proofOpts *ProofOptions,
+	response *AuthorizationResponse) error {
+	if request.PresentationDefinition == nil {
+		return errors.New("authorization request has no presentation definition")
+	}
+
+	definition, err := json.Marshal(request.PresentationDefinition)
+	if err != nil {
+		return err
+	}
+
+	presentations, err := c.Query(authToken, &QueryParams{
+		Type:  PresentationExchange.Name(),
+		Query: []json.RawMessage{definition},
+	})
+	if err != nil {
+		return fmt.Errorf("query credentials: %w", err)
+	}
+
+	vp := presentations[0]
+
+	submission, ok := vp.CustomFields["presentation_submission"].(*presexch.PresentationSubmission)
+	if !ok {
+		return errors.New("presentation has no presentation submission")
+	}
+
+	delete(vp.CustomFields, "presentation_submission")
+
+	vp.Holder = proofOpts.Controller
+
+	format := presexch.FormatLDPVP
+
+	if proofOpts.ProofFormat == ExternalJWTProofFormat {
+		format = presexch.FormatJWTVP
+
+		vpJWT, e := c.signVPJWT(authToken, vp, request, proofOpts)
+		if e != nil {
+			return e
+		}
+
+		response.VPToken, err = json.Marshal(vpJWT)
+	} else {
+		if err = c.addLinkedDataProof(authToken, vp, proofOpts, did.Authentication); err != nil {
+			return fmt.Errorf("failed to prove credentials: %w", err)
+		}
+
+		response.VPToken, err = json.Marshal(vp)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// the vp_token holds the presentation, with the credentials nested in the vp claim of JWT VPs.
+	for _, descriptor := range submission.DescriptorMap {
+		descriptor.Format = format
+
+		if format == presexch.FormatJWTVP && descriptor.PathNested != nil {
+			descriptor.PathNested.Path = strings.Replace(descriptor.PathNested.Path, "$.", "$.vp.", 1)
+		}
+	}
+
+	response.PresentationSubmission = submission
+
+	return nil
+}
+
+func (c *Wallet) signVPJWT(authToken string, vp *verifiable.Presentation, request *AuthorizationRequest,
+	proofOpts *ProofOptions) (string, error) {
+	presClaims, err := vp.JWTClaims([]string{request.ClientID}, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate JWT claims for VP: %w", err)
+	}
+
+	claims, err := jwt.PayloadToMap(presClaims)
+	if err != nil {
+		return "", err
+	}
+
+	claims["nonce"] = request.Nonce
+
+	vpJWT, err := c.SignJWT(authToken, nil, claims, proofOpts.VerificationMethod)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate JWT VP: %w", err)
+	}
+
+	return vpJWT, nil
+}
+
+// createIDToken creates a SIOPv2 self-issued ID token, with the DID as subject identifier.
+func (c *Wallet) createIDToken(authToken string, request *AuthorizationRequest, proofOpts *ProofOptions,
+	response *AuthorizationResponse) error {
+	now := time.Now()
+
+	idToken, err := c.SignJWT(authToken, nil, map[string]interface{}{
+		"iss":   proofOpts.Controller,
+		"sub":   proofOpts.Controller,
+		"aud":   request.ClientID,
+		"nonce": request.Nonce,
+		"iat":   now.Unix(),
+		"exp":   now.Add(idTokenTTL).Unix(),
+	}, proofOpts.VerificationMethod)
+	if err != nil {
+		return fmt.Errorf("failed to sign ID token: %w", err)
+	}
+
+	response.IDToken = idToken
+
+	return nil
+}
+
+func (c *Wallet) postAuthorizationResponse(request *AuthorizationRequest, response *AuthorizationResponse,
+	opts *oid4vpOpts) error {
+	params, err := response.params()
+	if err != nil {
+		return err
+	}
+
+	if request.ResponseMode == ResponseModeDirectPostJWT {
+		jwe, e := c.encryptResponse(request, params)
+		if e != nil {
+			return e
+		}
+
+		params = url.Values{"response": {jwe}}
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, request.ResponseURI,
+		strings.NewReader(params.Encode()))
+	if err != nil {
+		return fmt.Errorf("new response request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	body, err := doHTTP(opts.httpClient, req)
+	if err != nil {
+		return fmt.Errorf("post authorization response: %w", err)
+	}
+
+	var result struct {
+		RedirectURI string `json:"redirect_uri"`
+	}
+
+	// the verifier may return no redirect URI.
+	if len(body) > 0 {
+		if err = json.Unmarshal(body, &result); err != nil {
+			return fmt.Errorf("decode response: %w", err)
+		}
+	}
+
+	response.RedirectURI = result.RedirectURI
+
+	return nil
+}
+
+// encryptResponse encrypts the response parameters for the encryption key of the verifier metadata.
+func (c *Wallet) encryptResponse(request *AuthorizationRequest, params url.Values) (string, error) {
+	metadata := request.ClientMetadata
+	if metadata == nil || metadata.JWKS == nil {
+		return "", errors.New("client metadata has no encryption key for the direct_post.jwt response mode")
+	}
+
+	var recipientKey *crypto.PublicKey
+
+	for _, key := range metadata.JWKS.Keys {
+		if key.Use != "" && key.Use != "enc" {
+			continue
+		}
+
+		pubKey, err := jwksupport.PublicKeyFromJWK(key)
+		if err == nil && (pubKey.Type == "EC" || pubKey.Type == "OKP") {
+			recipientKey = pubKey
+
+			break
+		}
+	}
+
+	if recipientKey == nil {
+		return "", errors.New("client metadata has no supported encryption key")
+	}
+
+	// OKP keys are only supported with ECDH-ES in direct mode, EC keys also with ECDH-ES+A256KW.
+	alg := metadata.AuthorizationEncryptedResponseAlg
+	if alg == "" {
+		alg = tinkcrypto.ECDHESA256KWAlg
+
+		if recipientKey.Type == "OKP" {
+			alg = jose.ECDHESALG
+		}
+	}
+
+	if alg != jose.ECDHESALG && (alg != tinkcrypto.ECDHESA256KWAlg || recipientKey.Type != "EC") {
+		return "", fmt.Errorf("unsupported response encryption algorithm %s", alg)
+	}
+
+	enc := jose.A256GCM
+	if metadata.AuthorizationEncryptedResponseEnc != "" {
+		enc = jose.EncAlg(metadata.AuthorizationEncryptedResponseEnc)
+	}
+
+	payload := map[string]interface{}{}
+
+	for name := range params {
+		value := params.Get(name)
+
+		// JSON parameters are nested as JSON values.
+		if name == "presentation_submission" || (name == "vp_token" && strings.HasPrefix(value, "{")) {
+			payload[name] = json.RawMessage(value)
+		} else {
+			payload[name] = value
+		}
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	var encrypter *jose.JWEEncrypt
+
+	if alg == jose.ECDHESALG {
+		encrypter, err = jose.NewJWEEncryptECDHESDirect(enc, "", "", recipientKey)
+	} else {
+		encrypter, err = jose.NewJWEEncrypt(enc, "", "", "", nil, []*crypto.PublicKey{recipientKey}, c.walletCrypto)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("new response encrypter: %w", err)
+	}
+
+	jwe, err := encrypter.Encrypt(payloadBytes)
+	if err != nil {
+		return "", fmt.Errorf("encrypt response: %w", err)
+	}
+
+	return jwe.CompactSerialize(json.Marshal)
+}
+
+// params returns the response as form parameters, with JSON values serialized.
+func (r *AuthorizationResponse) params() (url.Values, error) {
+	params := url.Values{}
+
+	if len(r.VPToken) > 0 {
+		var vpJWT string
+
+		if err := json.Unmarshal(r.VPToken, &vpJWT); err == nil {
+			params.Set("vp_token", vpJWT)
+		} else {
+			params.Set("vp_token", string(r.VPToken))
+		}
+
+		submission, err := json.Marshal(r.PresentationSubmission)
+		if err != nil {
+			return nil, err
+		}
+
+		params.Set("presentation_submission", string(submission))
+	}
+
+	if r.IDToken != "" {
+		params.Set("id_token", r.IDToken)
+	}
+
+	if r.State != "" {
+		params.Set("state", r.State)
+	}
+
+	return params, nil
+}
+
+// parseRequestObject verifies the request object according to the client ID scheme of its claims and
+// returns its request.
+func (c *Wallet) parseRequestObject(requestObject string, opts *oid4vpOpts) (*AuthorizationRequest, error) {
+	// the claims are read first, to select the verifier of the client ID scheme.
+	unverified, _, err := jwt.Parse(requestObject, jwt.WithSignatureVerifier(&noopVerifier{}))
+	if err != nil {
+		return nil, fmt.Errorf("parse request object: %w", err)
+	}
+
+	var request AuthorizationRequest
+
+	if err = unverified.DecodeClaims(&request); err != nil {
+		return nil, fmt.Errorf("decode request object: %w", err)
+	}
+
+	if alg, _ := unverified.Headers.Algorithm(); alg == jwt.AlgorithmNone { //nolint:errcheck
+		return &request, checkClientID(&request, false)
+	}
+
+	if err = checkClientID(&request, true); err != nil {
+		return nil, err
+	}
+
+	var sigVerifier jose.SignatureVerifier
+
+	switch request.ClientIDScheme {
+	case ClientIDSchemeDID:
+		sigVerifier, err = c.didRequestVerifier(request.ClientID, unverified.Headers)
+	case ClientIDSchemeX509SANDNS:
+		sigVerifier, err = x509RequestVerifier(request.ClientID, unverified.Headers, opts.trustedRoots)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, _, err = jwt.Parse(requestObject, jwt.WithSignatureVerifier(sigVerifier)); err != nil {
+		return nil, fmt.Errorf("verify request object: %w", err)
+	}
+
+	return &request, nil
+}
+
+// didRequestVerifier verifies request objects signed with a key of the DID of the client ID.
+func (c *Wallet) didRequestVerifier(clientID string, headers jose.Headers) (jose.SignatureVerifier, error) {
+	kid, _ := headers.KeyID() //nolint:errcheck
+
+	if !strings.HasPrefix(kid, clientID+"#") {
+		return nil, fmt.Errorf("request object kid %s is not a key of the client ID %s", kid, clientID)
+	}
+
+	return jwt.NewVerifier(jwt.KeyResolverFunc(verifiable.NewVDRKeyResolver(c.vdr).PublicKeyFetcher())), nil
+}
+
+// x509RequestVerifier verifies request objects signed with the key of a trusted X.509 certificate holding
+// the DNS name of the client ID.
+func x509RequestVerifier(clientID string, headers jose.Headers,
+	trustedRoots *x509.CertPool) (jose.SignatureVerifier, error) {
+	x5c, ok := headers[jose.HeaderX509CertificateChain].([]interface{})
+	if !ok || len(x5c) == 0 {
+		return nil, errors.New("request object has no x5c header")
+	}
+
+	var certs []*x509.Certificate
+
+	for _, encoded := range x5c {
+		der, ok := encoded.(string)
+		if !ok {
+			return nil, errors.New("invalid x5c header")
+		}
+
+		certBytes, err := base64.StdEncoding.DecodeString(der)
+		if err != nil {
+			return nil, fmt.Errorf("decode x5c certificate: %w", err)
+		}
+
+		cert, err := x509.ParseCertificate(certBytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse x5c certificate: %w", err)
+		}
+
+		certs = append(certs, cert)
+	}
+
+	intermediates := x509.NewCertPool()
+
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         trustedRoots,
+		Intermediates: intermediates,
+		DNSName:       clientID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("verify request object certificate: %w", err)
+	}
+
+	key, err := jwksupport.JWKFromKey(certs[0].PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("request object certificate key: %w", err)
+	}
+
+	return jwt.GetVerifier(&verifier.PublicKey{Type: "JsonWebKey2020", JWK: key})
+}
+
+// checkClientID checks and sets the client ID scheme of the request, inferred from the client ID if not set.
+// Only the redirect_uri scheme accepts unsigned requests.
+func checkClientID(request *AuthorizationRequest, signed bool) error {
+	if request.ClientID == "" {
+		return errors.New("authorization request has no client_id")
+	}
+
+	if request.ClientIDScheme == "" {
+		request.ClientIDScheme = ClientIDSchemeRedirectURI
+
+		if strings.HasPrefix(request.ClientID, "did:") {
+			request.ClientIDScheme = ClientIDSchemeDID
+		}
+	}
+
+	switch request.ClientIDScheme {
+	case ClientIDSchemeRedirectURI:
+		if signed {
+			return errors.New("requests of the redirect_uri client ID scheme must not be signed")
+		}
+
+		if request.ClientID != request.ResponseURI && request.ClientID != request.RedirectURI {
+			return errors.New("client_id must be the response or redirect URI for the redirect_uri scheme")
+		}
+	case ClientIDSchemeDID, ClientIDSchemeX509SANDNS:
+		if !signed {
+			return fmt.Errorf("requests of the %s client ID scheme must be signed", request.ClientIDScheme)
+		}
+
+		if request.ClientIDScheme == ClientIDSchemeX509SANDNS {
+			return checkURIHosts(request)
+		}
+	default:
+		return fmt.Errorf("unsupported client ID scheme %s", request.ClientIDScheme)
+	}
+
+	return nil
+}
+
+// checkURIHosts checks the response and redirect URIs of a request of the x509_san_dns client ID scheme are
+// hosted at the DNS name of the client ID, which the certificate of the request object is checked against.
+func checkURIHosts(request *AuthorizationRequest) error {
+	for _, param := range []struct{ name, uri string }{
+		{"response_uri", request.ResponseURI},
+		{"redirect_uri", request.RedirectURI},
+	} {
+		if param.uri == "" {
+			continue
+		}
+
+		u, err := url.Parse(param.uri)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", param.name, err)
+		}
+
+		if u.Hostname() != request.ClientID {
+			return fmt.Errorf("%s host must be the client_id for the %s client ID scheme", param.name,
+				ClientIDSchemeX509SANDNS)
+		}
+	}
+
+	return nil
+}
+
+func checkAuthorizationRequest(request *AuthorizationRequest) error {
+	if !hasResponseType(request, ResponseTypeVPToken) && !hasResponseType(request, ResponseTypeIDToken) {
+		return fmt.Errorf("unsupported response type %s", request.ResponseType)
+	}
+
+	if request.Nonce == "" {
+		return errors.New("authorization request has no nonce")
+	}
+
+	if hasResponseType(request, ResponseTypeVPToken) && request.PresentationDefinition == nil {
+		return errors.New("authorization request has no presentation definition")
+	}
+
+	switch request.ResponseMode {
+	case ResponseModeDirectPost, ResponseModeDirectPostJWT:
+		if request.ResponseURI == "" {
+			return fmt.Errorf("response_uri is required for the %s response mode", request.ResponseMode)
+		}
+	case "", ResponseModeFragment:
+		if request.RedirectURI == "" {
+			return errors.New("redirect_uri is required for the fragment response mode")
+		}
+	default:
+		return fmt.Errorf("unsupported response mode %s", request.ResponseMode)
+	}
+
+	return nil
+}
+
+func hasResponseType(request *AuthorizationRequest, responseType string) bool {
+	for _, t := range strings.Fields(request.ResponseType) {
+		if t == responseType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseRequestParameters reads the request from the parameters of the request URI.
+func parseRequestParameters(query url.Values) (*AuthorizationRequest, error) {
+	params := map[string]interface{}{}
+
+	for name := range query {
+		value := query.Get(name)
+
+		switch name {
+		case "presentation_definition", "client_metadata":
+			params[name] = json.RawMessage(value)
+		default:
+			params[name] = value
+		}
+	}
+
+	paramsBytes, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid authorization request parameters: %w", err)
+	}
+
+	var request AuthorizationRequest
+
+	if err = json.Unmarshal(paramsBytes, &request); err != nil {
+		return nil, fmt.Errorf("invalid authorization request parameters: %w", err)
+	}
+
+	return &request, nil
+}
+
+func (opts *oid4vpOpts) fetchRequestObject(requestURI string) (string, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, requestURI, nil)
+	if err != nil {
+		return "", fmt.Errorf("new request: %w", err)
+	}
+
+	req.Header.Set("Accept", requestObjectMediaType)
+
+	body, err := doHTTP(opts.httpClient, req)
+	if err != nil {
+		return "", fmt.Errorf("fetch request object: %w", err)
+	}
+
+	return string(body), nil
+}
+
+// resolveReferences fetches the presentation definition and client metadata passed by reference.
+func (opts *oid4vpOpts) resolveReferences(request *AuthorizationRequest) error {
+	if request.PresentationDefinition == nil && request.PresentationDefinitionURI != "" {
+		request.PresentationDefinition = &presexch.PresentationDefinition{}
+
+		if err := opts.getJSON(request.PresentationDefinitionURI, request.PresentationDefinition); err != nil {
+			return fmt.Errorf("fetch presentation definition: %w", err)
+		}
+	}
+
+	if request.ClientMetadata == nil && request.ClientMetadataURI != "" {
+		request.ClientMetadata = &ClientMetadata{}
+
+		if err := opts.getJSON(request.ClientMetadataURI, request.ClientMetadata); err != nil {
+			return fmt.Errorf("fetch client metadata: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (opts *oid4vpOpts) getJSON(endpoint string, result interface{}) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+
+	body, err := doHTTP(opts.httpClient, req)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, result)
+}
+
+// noopVerifier accepts any signature, to read the claims selecting the actual verifier.
+type noopVerifier struct{}
+
+func (v *noopVerifier) Verify(_ jose.Headers, _, _, _ []byte) error {
+	return nil
+}
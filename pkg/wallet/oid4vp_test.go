@@ -0,0 +1,619 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package wallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/internal/testdata"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jwt"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+const (
+	sampleVerifierDNS = "verifier.example.com"
+	sampleNonce       = "n-0S6_WzA2Mj"
+	sampleState       = "af0ifjsldkj"
+	samplePD          = `{
+  "id": "degree-request",
+  "input_descriptors": [{
+    "id": "degree",
+    "schema": [{"uri": "https://www.w3.org/2018/credentials#VerifiableCredential"}],
+    "constraints": {
+      "fields": [{
+        "path": ["$.credentialSubject.degree.type"],
+        "filter": {"type": "string", "const": "BachelorDegree"}
+      }]
+    }
+  }]
+}`
+)
+
+// verifierStub is an in-process OpenID4VP verifier.
+type verifierStub struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu            sync.Mutex
+	requestObject string
+	responses     []url.Values
+}
+
+func newVerifierStub(t *testing.T) *verifierStub {
+	t.Helper()
+
+	v := &verifierStub{t: t}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/request", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, requestObjectMediaType, r.Header.Get("Accept"))
+
+		w.Header().Set("Content-Type", requestObjectMediaType)
+		_, err := w.Write([]byte(v.requestObject))
+		require.NoError(t, err)
+	})
+	mux.HandleFunc("/definition", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := w.Write([]byte(samplePD))
+		require.NoError(t, err)
+	})
+	mux.HandleFunc("/response", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		v.mu.Lock()
+		v.responses = append(v.responses, r.PostForm)
+		v.mu.Unlock()
+
+		_, err := w.Write([]byte(`{"redirect_uri":"` + v.url() + `/done"}`))
+		require.NoError(t, err)
+	})
+
+	v.server = httptest.NewTLSServer(mux)
+	t.Cleanup(v.server.Close)
+
+	return v
+}
+
+func (v *verifierStub) url() string {
+	return v.server.URL
+}
+
+func (v *verifierStub) lastResponse() url.Values {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	require.NotEmpty(v.t, v.responses)
+
+	return v.responses[len(v.responses)-1]
+}
+
+// directPostRequest returns the parameters of a direct_post request of the redirect_uri client ID scheme.
+func (v *verifierStub) directPostRequest(responseType string) map[string]interface{} {
+	return map[string]interface{}{
+		"client_id":               v.url() + "/response",
+		"response_type":           responseType,
+		"response_mode":           ResponseModeDirectPost,
+		"response_uri":            v.url() + "/response",
+		"nonce":                   sampleNonce,
+		"state":                   sampleState,
+		"presentation_definition": json.RawMessage(samplePD),
+	}
+}
+
+func requestURI(t *testing.T, params map[string]interface{}) string {
+	t.Helper()
+
+	query := url.Values{}
+
+	for name, value := range params {
+		if s, ok := value.(string); ok {
+			query.Set(name, s)
+
+			continue
+		}
+
+		valueBytes, err := json.Marshal(value)
+		require.NoError(t, err)
+
+		query.Set(name, string(valueBytes))
+	}
+
+	return "openid4vp://?" + query.Encode()
+}
+
+// es256Signer signs JWTs with an ECDSA P-256 key and the x5c header of its certificate chain.
+type es256Signer struct {
+	key *ecdsa.PrivateKey
+	x5c []string
+}
+
+func (s *es256Signer) Sign(data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+
+	r, ss, err := ecdsa.Sign(rand.Reader, s.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	ss.FillBytes(sig[32:])
+
+	return sig, nil
+}
+
+func (s *es256Signer) Headers() jose.Headers {
+	return jose.Headers{
+		jose.HeaderAlgorithm:            "ES256",
+		jose.HeaderX509CertificateChain: s.x5c,
+	}
+}
+
+// newX509Signer returns a signer with a certificate for the DNS name issued by a new CA, and the CA pool.
+func newX509Signer(t *testing.T, dnsName string) (*es256Signer, *x509.CertPool) {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	leafDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: dnsName},
+		DNSNames:     []string{dnsName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}, caCert, &leafKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+
+	return &es256Signer{key: leafKey, x5c: []string{base64.StdEncoding.EncodeToString(leafDER)}}, roots
+}
+
+func signRequestObject(t *testing.T, claims map[string]interface{}, headers jose.Headers,
+	signer jose.Signer) string {
+	t.Helper()
+
+	token, err := jwt.NewSigned(claims, headers, signer)
+	require.NoError(t, err)
+
+	requestObject, err := token.Serialize(false)
+	require.NoError(t, err)
+
+	return requestObject
+}
+
+func TestWallet_ResolveAuthorizationRequest(t *testing.T) {
+	verifier := newVerifierStub(t)
+	walletInstance, _ := newOID4VCIWallet(t)
+	client := WithOID4VPHTTPClient(verifier.server.Client())
+
+	t.Run("parameters of the redirect_uri client ID scheme", func(t *testing.T) {
+		params := verifier.directPostRequest(ResponseTypeVPToken)
+		delete(params, "presentation_definition")
+		params["presentation_definition_uri"] = verifier.url() + "/definition"
+
+		request, err := walletInstance.ResolveAuthorizationRequest(requestURI(t, params), client)
+		require.NoError(t, err)
+		require.Equal(t, ClientIDSchemeRedirectURI, request.ClientIDScheme)
+		require.Equal(t, sampleNonce, request.Nonce)
+		require.Equal(t, sampleState, request.State)
+		require.Equal(t, "degree-request", request.PresentationDefinition.ID)
+	})
+
+	t.Run("request_uri of the did client ID scheme", func(t *testing.T) {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		verifierDID, keyID := fingerprint.CreateDIDKey(pub)
+
+		claims := verifier.directPostRequest(ResponseTypeVPToken)
+		claims["client_id"] = verifierDID
+		claims["client_id_scheme"] = ClientIDSchemeDID
+
+		verifier.requestObject = signRequestObject(t, claims, jose.Headers{jose.HeaderKeyID: keyID},
+			jwt.NewEd25519Signer(priv))
+
+		request, err := walletInstance.ResolveAuthorizationRequest(requestURI(t, map[string]interface{}{
+			"client_id":   verifierDID,
+			"request_uri": verifier.url() + "/request",
+		}), client)
+		require.NoError(t, err)
+		require.Equal(t, verifierDID, request.ClientID)
+		require.Equal(t, "degree-request", request.PresentationDefinition.ID)
+
+		t.Run("client_id mismatch", func(t *testing.T) {
+			_, err = walletInstance.ResolveAuthorizationRequest(requestURI(t, map[string]interface{}{
+				"client_id":   "did:example:other",
+				"request_uri": verifier.url() + "/request",
+			}), client)
+			require.EqualError(t, err, "client_id of the request object does not match the client_id parameter")
+		})
+
+		t.Run("kid of another DID", func(t *testing.T) {
+			otherPub, otherPriv, e := ed25519.GenerateKey(rand.Reader)
+			require.NoError(t, e)
+
+			_, otherKeyID := fingerprint.CreateDIDKey(otherPub)
+
+			_, err = walletInstance.ResolveAuthorizationRequest(requestURI(t, map[string]interface{}{
+				"request": signRequestObject(t, claims, jose.Headers{jose.HeaderKeyID: otherKeyID},
+					jwt.NewEd25519Signer(otherPriv)),
+			}), client)
+			require.ErrorContains(t, err, "is not a key of the client ID")
+		})
+
+		t.Run("invalid signature", func(t *testing.T) {
+			_, otherPriv, e := ed25519.GenerateKey(rand.Reader)
+			require.NoError(t, e)
+
+			_, err = walletInstance.ResolveAuthorizationRequest(requestURI(t, map[string]interface{}{
+				"request": signRequestObject(t, claims, jose.Headers{jose.HeaderKeyID: keyID},
+					jwt.NewEd25519Signer(otherPriv)),
+			}), client)
+			require.ErrorContains(t, err, "verify request object")
+		})
+	})
+
+	t.Run("request of the x509_san_dns client ID scheme", func(t *testing.T) {
+		signer, roots := newX509Signer(t, sampleVerifierDNS)
+
+		claims := verifier.directPostRequest(ResponseTypeVPToken)
+		claims["client_id"] = sampleVerifierDNS
+		claims["client_id_scheme"] = ClientIDSchemeX509SANDNS
+		claims["response_uri"] = "https://" + sampleVerifierDNS + "/response"
+
+		uri := requestURI(t, map[string]interface{}{
+			"client_id": sampleVerifierDNS,
+			"request":   signRequestObject(t, claims, nil, signer),
+		})
+
+		request, err := walletInstance.ResolveAuthorizationRequest(uri, client, WithTrustedRoots(roots))
+		require.NoError(t, err)
+		require.Equal(t, sampleVerifierDNS, request.ClientID)
+
+		_, err = walletInstance.ResolveAuthorizationRequest(uri, client, WithTrustedRoots(x509.NewCertPool()))
+		require.ErrorContains(t, err, "verify request object certificate")
+
+		claims["client_id"] = "other.example.com"
+		claims["response_uri"] = "https://other.example.com/response"
+
+		_, err = walletInstance.ResolveAuthorizationRequest(requestURI(t, map[string]interface{}{
+			"request": signRequestObject(t, claims, nil, signer),
+		}), client, WithTrustedRoots(roots))
+		require.ErrorContains(t, err, "verify request object certificate")
+
+		t.Run("response URI of another host", func(t *testing.T) {
+			claims["client_id"] = sampleVerifierDNS
+			claims["response_uri"] = "https://attacker.example.com/response"
+
+			_, err = walletInstance.ResolveAuthorizationRequest(requestURI(t, map[string]interface{}{
+				"request": signRequestObject(t, claims, nil, signer),
+			}), client, WithTrustedRoots(roots))
+			require.EqualError(t, err, "response_uri host must be the client_id for the x509_san_dns client ID scheme")
+		})
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		params := verifier.directPostRequest(ResponseTypeVPToken)
+		params["client_id"] = "did:example:verifier"
+
+		_, err := walletInstance.ResolveAuthorizationRequest(requestURI(t, params))
+		require.EqualError(t, err, "requests of the did client ID scheme must be signed")
+
+		params = verifier.directPostRequest(ResponseTypeVPToken)
+		params["client_id"] = "https://other.example.com"
+
+		_, err = walletInstance.ResolveAuthorizationRequest(requestURI(t, params))
+		require.EqualError(t, err, "client_id must be the response or redirect URI for the redirect_uri scheme")
+
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		_, err = walletInstance.ResolveAuthorizationRequest(requestURI(t, map[string]interface{}{
+			"request": signRequestObject(t, verifier.directPostRequest(ResponseTypeVPToken), nil,
+				jwt.NewEd25519Signer(priv)),
+		}))
+		require.EqualError(t, err, "requests of the redirect_uri client ID scheme must not be signed")
+
+		params = verifier.directPostRequest(ResponseTypeVPToken)
+		delete(params, "nonce")
+
+		_, err = walletInstance.ResolveAuthorizationRequest(requestURI(t, params))
+		require.EqualError(t, err, "authorization request has no nonce")
+
+		params = verifier.directPostRequest("code")
+
+		_, err = walletInstance.ResolveAuthorizationRequest(requestURI(t, params))
+		require.EqualError(t, err, "unsupported response type code")
+
+		params = verifier.directPostRequest(ResponseTypeVPToken)
+		delete(params, "presentation_definition")
+
+		_, err = walletInstance.ResolveAuthorizationRequest(requestURI(t, params))
+		require.EqualError(t, err, "authorization request has no presentation definition")
+
+		_, err = walletInstance.ResolveAuthorizationRequest(requestURI(t, map[string]interface{}{
+			"request_uri": verifier.url() + "/missing",
+		}), client)
+		require.ErrorContains(t, err, "fetch request object: endpoint returned status 404")
+	})
+}
+
+func TestWallet_RespondAuthorizationRequest(t *testing.T) {
+	verifier := newVerifierStub(t)
+	walletInstance, authToken := newOID4VCIWallet(t)
+	client := WithOID4VPHTTPClient(verifier.server.Client())
+
+	require.NoError(t, walletInstance.Add(authToken, Credential, testdata.SampleUDCVC))
+	require.NoError(t, walletInstance.Add(authToken, Key, testdata.SampleWalletContentKeyBase58))
+
+	resolve := func(t *testing.T, params map[string]interface{}) *AuthorizationRequest {
+		t.Helper()
+
+		request, err := walletInstance.ResolveAuthorizationRequest(requestURI(t, params), client)
+		require.NoError(t, err)
+
+		return request
+	}
+
+	t.Run("direct_post with JWT VP and ID token", func(t *testing.T) {
+		request := resolve(t, verifier.directPostRequest(ResponseTypeVPToken+" "+ResponseTypeIDToken))
+
+		response, err := walletInstance.RespondAuthorizationRequest(authToken, request,
+			&ProofOptions{Controller: didKey, ProofFormat: ExternalJWTProofFormat}, client)
+		require.NoError(t, err)
+		require.Equal(t, verifier.url()+"/done", response.RedirectURI)
+
+		form := verifier.lastResponse()
+		require.Equal(t, sampleState, form.Get("state"))
+
+		vpToken := form.Get("vp_token")
+		require.NoError(t, walletInstance.VerifyJWT(vpToken))
+
+		vpJWT, _, err := jwt.Parse(vpToken, jwt.WithSignatureVerifier(&noopVerifier{}))
+		require.NoError(t, err)
+
+		var vpClaims struct {
+			Audience string `json:"aud"`
+			Nonce    string `json:"nonce"`
+			Issuer   string `json:"iss"`
+		}
+
+		require.NoError(t, vpJWT.DecodeClaims(&vpClaims))
+		require.Equal(t, request.ClientID, vpClaims.Audience)
+		require.Equal(t, sampleNonce, vpClaims.Nonce)
+		require.Equal(t, didKey, vpClaims.Issuer)
+
+		var submission map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(form.Get("presentation_submission")), &submission))
+		require.Equal(t, "degree-request", submission["definition_id"])
+
+		descriptor := submission["descriptor_map"].([]interface{})[0].(map[string]interface{})
+		require.Equal(t, "jwt_vp", descriptor["format"])
+		require.Equal(t, "$", descriptor["path"])
+		require.Equal(t, "$.vp.verifiableCredential[0]",
+			descriptor["path_nested"].(map[string]interface{})["path"])
+
+		idToken, _, err := jwt.Parse(form.Get("id_token"), jwt.WithSignatureVerifier(&noopVerifier{}))
+		require.NoError(t, err)
+		require.NoError(t, walletInstance.VerifyJWT(form.Get("id_token")))
+		require.Equal(t, didKey, idToken.Payload["sub"])
+		require.Equal(t, sampleNonce, idToken.Payload["nonce"])
+	})
+
+	t.Run("direct_post.jwt with LD VP", func(t *testing.T) {
+		p, err := mockkms.NewProviderForKMS(storage.NewMockStoreProvider(), &noop.NoLock{})
+		require.NoError(t, err)
+
+		verifierKMS, err := localkms.New("local-lock://test/master/key/", p)
+		require.NoError(t, err)
+
+		verifierCrypto, err := tinkcrypto.New()
+		require.NoError(t, err)
+
+		kid, pubKeyBytes, err := verifierKMS.CreateAndExportPubKeyBytes(kms.NISTP256ECDHKWType)
+		require.NoError(t, err)
+
+		encKey, err := jwksupport.PubKeyBytesToJWK(pubKeyBytes, kms.NISTP256ECDHKWType)
+		require.NoError(t, err)
+
+		encKey.KeyID = kid
+
+		params := verifier.directPostRequest(ResponseTypeVPToken)
+		params["response_mode"] = ResponseModeDirectPostJWT
+		params["client_metadata"] = &ClientMetadata{
+			JWKS:                              &JWKS{Keys: []*jwk.JWK{encKey}},
+			AuthorizationEncryptedResponseAlg: tinkcrypto.ECDHESA256KWAlg,
+			AuthorizationEncryptedResponseEnc: jose.A256GCMALG,
+		}
+
+		request := resolve(t, params)
+
+		response, err := walletInstance.RespondAuthorizationRequest(authToken, request,
+			&ProofOptions{Controller: didKey}, client)
+		require.NoError(t, err)
+		require.Equal(t, verifier.url()+"/done", response.RedirectURI)
+
+		form := verifier.lastResponse()
+		require.Empty(t, form.Get("vp_token"))
+
+		jwe, err := jose.Deserialize(form.Get("response"))
+		require.NoError(t, err)
+
+		plaintext, err := jose.NewJWEDecrypt(nil, verifierCrypto, verifierKMS).Decrypt(jwe)
+		require.NoError(t, err)
+
+		var decrypted struct {
+			VPToken struct {
+				Proof map[string]interface{} `json:"proof"`
+			} `json:"vp_token"`
+			PresentationSubmission map[string]interface{} `json:"presentation_submission"`
+			State                  string                 `json:"state"`
+		}
+
+		require.NoError(t, json.Unmarshal(plaintext, &decrypted))
+		require.Equal(t, sampleState, decrypted.State)
+		require.Equal(t, sampleNonce, decrypted.VPToken.Proof["challenge"])
+		require.Equal(t, request.ClientID, decrypted.VPToken.Proof["domain"])
+		require.Equal(t, "degree-request", decrypted.PresentationSubmission["definition_id"])
+	})
+
+	t.Run("direct_post.jwt with X25519 key", func(t *testing.T) {
+		p, err := mockkms.NewProviderForKMS(storage.NewMockStoreProvider(), &noop.NoLock{})
+		require.NoError(t, err)
+
+		verifierKMS, err := localkms.New("local-lock://test/master/key/", p)
+		require.NoError(t, err)
+
+		verifierCrypto, err := tinkcrypto.New()
+		require.NoError(t, err)
+
+		kid, pubKeyBytes, err := verifierKMS.CreateAndExportPubKeyBytes(kms.X25519ECDHKWType)
+		require.NoError(t, err)
+
+		pubKey := &crypto.PublicKey{}
+		require.NoError(t, json.Unmarshal(pubKeyBytes, pubKey))
+
+		encKey, err := jwksupport.JWKFromX25519Key(pubKey.X)
+		require.NoError(t, err)
+
+		encKey.KeyID = kid
+
+		params := verifier.directPostRequest(ResponseTypeVPToken)
+		params["response_mode"] = ResponseModeDirectPostJWT
+		params["client_metadata"] = &ClientMetadata{
+			JWKS:                              &JWKS{Keys: []*jwk.JWK{encKey}},
+			AuthorizationEncryptedResponseEnc: jose.XC20PALG,
+		}
+
+		request := resolve(t, params)
+
+		_, err = walletInstance.RespondAuthorizationRequest(authToken, request, &ProofOptions{Controller: didKey}, client)
+		require.NoError(t, err)
+
+		jwe, err := jose.Deserialize(verifier.lastResponse().Get("response"))
+		require.NoError(t, err)
+
+		alg, ok := jwe.ProtectedHeaders.Algorithm()
+		require.True(t, ok)
+		require.Equal(t, jose.ECDHESALG, alg)
+
+		enc, ok := jwe.ProtectedHeaders.Encryption()
+		require.True(t, ok)
+		require.Equal(t, jose.XC20PALG, enc)
+
+		plaintext, err := jose.NewJWEDecrypt(nil, verifierCrypto, verifierKMS).Decrypt(jwe)
+		require.NoError(t, err)
+		require.Contains(t, string(plaintext), sampleState)
+
+		request.ClientMetadata.AuthorizationEncryptedResponseAlg = tinkcrypto.ECDHESXC20PKWAlg
+
+		_, err = walletInstance.RespondAuthorizationRequest(authToken, request, &ProofOptions{Controller: didKey}, client)
+		require.EqualError(t, err, "unsupported response encryption algorithm "+tinkcrypto.ECDHESXC20PKWAlg)
+	})
+
+	t.Run("SIOPv2 ID token in the redirect URI fragment", func(t *testing.T) {
+		request := resolve(t, map[string]interface{}{
+			"client_id":     "https://rp.example.com/cb",
+			"response_type": ResponseTypeIDToken,
+			"redirect_uri":  "https://rp.example.com/cb",
+			"nonce":         sampleNonce,
+		})
+
+		response, err := walletInstance.RespondAuthorizationRequest(authToken, request,
+			&ProofOptions{Controller: didKey})
+		require.NoError(t, err)
+		require.Empty(t, response.VPToken)
+		require.True(t, strings.HasPrefix(response.RedirectURI, "https://rp.example.com/cb#id_token="))
+		require.NoError(t, walletInstance.VerifyJWT(response.IDToken))
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		request := resolve(t, verifier.directPostRequest(ResponseTypeVPToken))
+
+		_, err := walletInstance.RespondAuthorizationRequest(authToken, request, nil, client)
+		require.EqualError(t, err, "invalid proof option, 'controller' is required")
+
+		_, err = walletInstance.RespondAuthorizationRequest(sampleFakeTkn, request,
+			&ProofOptions{Controller: didKey}, client)
+		require.ErrorContains(t, err, "invalid auth token")
+
+		params := verifier.directPostRequest(ResponseTypeVPToken)
+		params["presentation_definition"] = json.RawMessage(strings.Replace(samplePD, "BachelorDegree",
+			"MasterDegree", 1))
+
+		_, err = walletInstance.RespondAuthorizationRequest(authToken, resolve(t, params),
+			&ProofOptions{Controller: didKey}, client)
+		require.ErrorContains(t, err, "query credentials")
+
+		params = verifier.directPostRequest(ResponseTypeVPToken)
+		params["response_mode"] = ResponseModeDirectPostJWT
+
+		_, err = walletInstance.RespondAuthorizationRequest(authToken, resolve(t, params),
+			&ProofOptions{Controller: didKey}, client)
+		require.EqualError(t, err,
+			"client metadata has no encryption key for the direct_post.jwt response mode")
+
+		request = resolve(t, verifier.directPostRequest(ResponseTypeVPToken))
+		request.ResponseURI = verifier.url() + "/unknown"
+
+		response, err := walletInstance.RespondAuthorizationRequest(authToken, request,
+			&ProofOptions{Controller: didKey}, client)
+		require.Error(t, err)
+		require.Nil(t, response)
+	})
+}
@@ -7,7 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package wallet
 
 import (
+	"crypto/x509"
 	"encoding/json"
+	"net/http"
 	"time"
 
 	"github.com/hyperledger/aries-framework-go/component/storage/edv"
@@ -23,6 +25,9 @@ type profileOpts struct {
 	secretLockSvc secretlock.Service
 	passphrase    string
 
+	// hierarchical deterministic keys of local kms
+	mnemonic string
+
 	// remote(web) kms options
 	keyServerURL string
 
@@ -47,6 +52,16 @@ func WithPassphrase(passphrase string) ProfileOptions {
 	}
 }
 
+// WithMnemonic option to derive the Ed25519 and secp256k1 keys of local kms from the seed of a BIP39 mnemonic, so
+// that they can be recovered from the mnemonic with 'Wallet.RecoverKeys' if the wallet storage is lost.
+// The seed is saved in the profile, encrypted like the local kms master key.
+// This option must be provided along with 'WithPassphrase' or 'WithSecretLockService' option.
+func WithMnemonic(mnemonic string) ProfileOptions {
+	return func(opts *profileOpts) {
+		opts.mnemonic = mnemonic
+	}
+}
+
 // WithKeyServerURL option, when provided then wallet will use remote kms for key operations.
 // This option will be ignore if provided with 'WithSecretLockService' option.
 func WithKeyServerURL(url string) ProfileOptions {
@@ -275,6 +290,9 @@ type addContentOpts struct {
 
 	// indicated if the model of data saved into the wallet should be validated.
 	validateDataModel bool
+
+	// ID of the content, overriding the ID read from the content.
+	contentID string
 }
 
 // AddByCollection option for grouping wallet contents by collection ID.
@@ -284,6 +302,13 @@ func AddByCollection(collectionID string) AddContentOptions {
 	}
 }
 
+// withContentID option for saving contents without ID, like credentials issued without ID.
+func withContentID(id string) AddContentOptions {
+	return func(opts *addContentOpts) {
+		opts.contentID = id
+	}
+}
+
 // ValidateContent enables data model validations of adding content.
 func ValidateContent() AddContentOptions {
 	return func(opts *addContentOpts) {
@@ -501,3 +526,127 @@ func ResolveCredentialID(descriptorID, credentialID string) ResolveManifestOptio
 		opts.credentialID = credentialID
 	}
 }
+
+// OID4VCIOptions is option for OpenID4VCI credential issuance.
+type OID4VCIOptions func(opts *oid4vciOpts)
+
+// oid4vciOpts contains options for OpenID4VCI credential issuance.
+type oid4vciOpts struct {
+	// HTTP client for the credential issuer and authorization server requests.
+	httpClient HTTPClient
+
+	// transaction code of the pre-authorized code flow.
+	txCode string
+
+	// ID of the key signing the proofs of possession, a DID URL.
+	proofKeyID string
+
+	// OAuth client ID of the wallet.
+	clientID string
+
+	// redirect URI of the authorization code flow.
+	redirectURI string
+
+	// credential configurations to request, all the offered ones by default.
+	credentialConfigurationIDs []string
+
+	// options for saving the issued credentials.
+	addOpts []AddContentOptions
+}
+
+func newOID4VCIOpts(options []OID4VCIOptions) *oid4vciOpts {
+	opts := &oid4vciOpts{httpClient: &http.Client{Timeout: defaultOID4VCITimeout}}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return opts
+}
+
+// WithOID4VCIHTTPClient option for the HTTP client used for OpenID4VCI requests.
+func WithOID4VCIHTTPClient(client HTTPClient) OID4VCIOptions {
+	return func(opts *oid4vciOpts) {
+		opts.httpClient = client
+	}
+}
+
+// WithTxCode option for the transaction code of a pre-authorized code offer.
+func WithTxCode(txCode string) OID4VCIOptions {
+	return func(opts *oid4vciOpts) {
+		opts.txCode = txCode
+	}
+}
+
+// WithProofKeyID option for the key signing the proofs of possession, a DID URL of a key in the wallet.
+// Credentials are requested without proof if not provided.
+func WithProofKeyID(keyID string) OID4VCIOptions {
+	return func(opts *oid4vciOpts) {
+		opts.proofKeyID = keyID
+	}
+}
+
+// WithClientID option for the OAuth client ID of the wallet.
+func WithClientID(clientID string) OID4VCIOptions {
+	return func(opts *oid4vciOpts) {
+		opts.clientID = clientID
+	}
+}
+
+// WithRedirectURI option for the redirect URI of the authorization code flow.
+func WithRedirectURI(redirectURI string) OID4VCIOptions {
+	return func(opts *oid4vciOpts) {
+		opts.redirectURI = redirectURI
+	}
+}
+
+// WithCredentialConfigurations option for requesting only some of the offered credential configurations.
+func WithCredentialConfigurations(ids ...string) OID4VCIOptions {
+	return func(opts *oid4vciOpts) {
+		opts.credentialConfigurationIDs = ids
+	}
+}
+
+// WithSaveOptions option for saving the issued credentials, eg into a collection.
+func WithSaveOptions(options ...AddContentOptions) OID4VCIOptions {
+	return func(opts *oid4vciOpts) {
+		opts.addOpts = options
+	}
+}
+
+// OID4VPOptions is option for OpenID4VP and SIOPv2 authorization requests.
+type OID4VPOptions func(opts *oid4vpOpts)
+
+// oid4vpOpts contains options for OpenID4VP and SIOPv2 authorization requests.
+type oid4vpOpts struct {
+	// HTTP client for the verifier requests.
+	httpClient HTTPClient
+
+	// trusted roots of the certificates of the x509_san_dns client ID scheme.
+	trustedRoots *x509.CertPool
+}
+
+func newOID4VPOpts(options []OID4VPOptions) *oid4vpOpts {
+	opts := &oid4vpOpts{httpClient: &http.Client{Timeout: defaultOID4VCITimeout}}
+
+	for _, option := range options {
+		option(opts)
+	}
+
+	return opts
+}
+
+// WithOID4VPHTTPClient option for the HTTP client used for the verifier requests.
+func WithOID4VPHTTPClient(client HTTPClient) OID4VPOptions {
+	return func(opts *oid4vpOpts) {
+		opts.httpClient = client
+	}
+}
+
+// WithTrustedRoots option for the trusted roots of the verifier certificates of the x509_san_dns client ID
+// scheme, the system roots by default.
+func WithTrustedRoots(roots *x509.CertPool) OID4VPOptions {
+	return func(opts *oid4vpOpts) {
+		opts.trustedRoots = roots
+	}
+}
@@ -40,6 +40,9 @@ type profile struct {
 	// KeyServerURL for remotekms.
 	KeyServerURL string
 
+	// Encrypted seed of the hierarchical deterministic keys of localkms, if enabled.
+	HDSeedCipher string
+
 	// EDV configuration
 	EDVConf *edvConf
 }
@@ -63,7 +66,7 @@ type edvConf struct {
 func createProfile(user string, opts *profileOpts) (*profile, error) {
 	profile := &profile{User: user, ID: uuid.New().String()}
 
-	err := profile.setKMSOptions(opts.passphrase, opts.secretLockSvc, opts.keyServerURL)
+	err := profile.setKMSOptions(opts.passphrase, opts.secretLockSvc, opts.keyServerURL, opts.mnemonic)
 	if err != nil {
 		return nil, err
 	}
@@ -76,7 +79,8 @@ func createProfile(user string, opts *profileOpts) (*profile, error) {
 	return profile, nil
 }
 
-func (pr *profile) setKMSOptions(passphrase string, secretLockSvc secretlock.Service, keyServerURL string) error {
+func (pr *profile) setKMSOptions(passphrase string, secretLockSvc secretlock.Service, keyServerURL,
+	mnemonic string) error {
 	pr.resetKMSOptions()
 
 	var err error
@@ -106,7 +110,17 @@ func (pr *profile) setKMSOptions(passphrase string, secretLockSvc secretlock.Ser
 		return fmt.Errorf("invalid create profile options")
 	}
 
-	return nil
+	if mnemonic == "" {
+		return nil
+	}
+
+	if pr.MasterLockCipher == "" {
+		return errors.New("hierarchical deterministic keys are only supported by local kms")
+	}
+
+	pr.HDSeedCipher, err = createSeedLock(secretLockSvc, mnemonic)
+
+	return err
 }
 
 func (pr *profile) setEDVOptions(opts *edvConf) error {
@@ -148,6 +162,7 @@ func (pr *profile) setupEDVMacKey(keyManager kms.KeyManager) error {
 func (pr *profile) resetKMSOptions() {
 	pr.KeyServerURL = ""
 	pr.MasterLockCipher = ""
+	pr.HDSeedCipher = ""
 }
 
 // getUserKeyPrefix is key prefix for vc wallet profile store user key.
@@ -14,6 +14,7 @@ import (
 
 	"github.com/piprate/json-gold/ld"
 
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/hdkms"
 	"github.com/hyperledger/aries-framework-go/pkg/common/log"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/doc/cm"
@@ -217,7 +218,7 @@ func createOrUpdate(userID string, ctx provider, update bool, options ...Profile
 			return fmt.Errorf("failed to update wallet user profile: %w", err)
 		}
 
-		err = profile.setKMSOptions(opts.passphrase, opts.secretLockSvc, opts.keyServerURL)
+		err = profile.setKMSOptions(opts.passphrase, opts.secretLockSvc, opts.keyServerURL, opts.mnemonic)
 		if err != nil {
 			return fmt.Errorf("failed to update wallet user profile KMS options: %w", err)
 		}
@@ -553,10 +554,18 @@ func (c *Wallet) Derive(authToken string, credential CredentialToDerive, options
 		return nil, fmt.Errorf("failed to resolve request : %w", err)
 	}
 
-	derived, err := vc.GenerateBBSSelectiveDisclosure(options.Frame, []byte(options.Nonce),
-		verifiable.WithPublicKeyFetcher(
-			verifiable.NewVDRKeyResolver(newContentBasedVDR(authToken, c.vdr, c.contents)).PublicKeyFetcher(),
-		), verifiable.WithJSONLDDocumentLoader(c.jsonldDocumentLoader))
+	var derived *verifiable.Credential
+
+	if vc.HasECDSASDProof() {
+		derived, err = vc.GenerateECDSASDSelectiveDisclosure(options.SelectivePointers,
+			verifiable.WithJSONLDDocumentLoader(c.jsonldDocumentLoader))
+	} else {
+		derived, err = vc.GenerateBBSSelectiveDisclosure(options.Frame, []byte(options.Nonce),
+			verifiable.WithPublicKeyFetcher(
+				verifiable.NewVDRKeyResolver(newContentBasedVDR(authToken, c.vdr, c.contents)).PublicKeyFetcher(),
+			), verifiable.WithJSONLDDocumentLoader(c.jsonldDocumentLoader))
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("failed to derive credential : %w", err)
 	}
@@ -586,6 +595,48 @@ func (c *Wallet) CreateKeyPair(authToken string, keyType kms.KeyType) (*KeyPair,
 	}, nil
 }
 
+// RecoverKeys regenerates the first keys of given type from the mnemonic of a wallet profile created with
+// 'WithMnemonic' option, typically after its storage was lost. Recovered keys get back their original key IDs.
+//
+//	Args:
+//		- authToken: authorization for performing recover keys operation.
+//		- keyType: type of the keys to be recovered, Ed25519 or secp256k1.
+//		- count: number of keys of given type to be recovered.
+//
+//	Returns the recovered key pairs in creation order.
+func (c *Wallet) RecoverKeys(authToken string, keyType kms.KeyType, count int) ([]*KeyPair, error) {
+	session, err := sessionManager().getSession(authToken)
+	if err != nil {
+		return nil, err
+	}
+
+	hdKeyManager, ok := session.KeyManager.(*hdkms.HDKMS)
+	if !ok {
+		return nil, ErrHDKeysNotEnabled
+	}
+
+	kids, err := hdKeyManager.Recover(keyType, count)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPairs := make([]*KeyPair, len(kids))
+
+	for i, kid := range kids {
+		pubBytes, _, e := hdKeyManager.ExportPubKeyBytes(kid)
+		if e != nil {
+			return nil, e
+		}
+
+		keyPairs[i] = &KeyPair{
+			KeyID:     kid,
+			PublicKey: base64.RawURLEncoding.EncodeToString(pubBytes),
+		}
+	}
+
+	return keyPairs, nil
+}
+
 // ResolveCredentialManifest resolves given credential manifest by credential response or credential.
 // Supports: https://identity.foundation/credential-manifest/
 //
@@ -26,6 +26,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite/ecdsasd2023"
+	sigutil "github.com/hyperledger/aries-framework-go/component/models/signature/util"
 	"github.com/hyperledger/aries-framework-go/component/storage/edv"
 	"github.com/hyperledger/aries-framework-go/internal/testdata"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/primitive/bbs12381g2pub"
@@ -2695,6 +2699,67 @@ func TestWallet_Derive(t *testing.T) {
 		require.True(t, walletInstance.Close())
 	})
 
+	t.Run("Test derive an ecdsa-sd-2023 credential - success", func(t *testing.T) {
+		walletInstance, err := New(user, mockctx)
+		require.NotEmpty(t, walletInstance)
+		require.NoError(t, err)
+
+		tkn, err := walletInstance.Open(WithUnlockByPassphrase(samplePassPhrase))
+		require.NoError(t, err)
+
+		docLoader, err := ldtestutil.DocumentLoader()
+		require.NoError(t, err)
+
+		issuerSigner, err := sigutil.NewSigner(kms.ECDSAP256TypeIEEEP1363)
+		require.NoError(t, err)
+
+		pubJWK, err := jwksupport.JWKFromKey(issuerSigner.PublicKey())
+		require.NoError(t, err)
+
+		const issuerDID = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+
+		vm, err := did.NewVerificationMethodFromJWK(issuerDID+"#key-1", "JsonWebKey2020", issuerDID, pubJWK)
+		require.NoError(t, err)
+
+		signer, err := dataintegrity.NewSigner(&dataintegrity.Options{
+			DIDResolver: &mockvdr.MockVDRegistry{
+				ResolveValue: &did.Doc{
+					ID:              issuerDID,
+					AssertionMethod: []did.Verification{{VerificationMethod: *vm, Relationship: did.AssertionMethod}},
+				},
+			},
+		}, ecdsasd2023.NewSignerInitializer(&ecdsasd2023.SignerInitializerOptions{
+			LDDocumentLoader: docLoader,
+			SignerGetter:     ecdsasd2023.WithStaticSigner(issuerSigner),
+		}))
+		require.NoError(t, err)
+
+		sdVC, err := verifiable.ParseCredential(testdata.SampleUDCVC, verifiable.WithDisabledProofCheck(),
+			verifiable.WithJSONLDDocumentLoader(docLoader))
+		require.NoError(t, err)
+
+		require.NoError(t, sdVC.AddDataIntegrityProof(&verifiable.DataIntegrityProofContext{
+			SigningKeyID:      issuerDID + "#key-1",
+			CryptoSuite:       ecdsasd2023.SuiteType,
+			MandatoryPointers: []string{"/issuer", "/issuanceDate"},
+		}, signer))
+
+		vc, err := walletInstance.Derive(tkn, FromCredential(sdVC), &DeriveOptions{
+			SelectivePointers: []string{"/credentialSubject/degree"},
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, vc)
+		require.Len(t, vc.Proofs, 1)
+		require.Equal(t, ecdsasd2023.SuiteType, vc.Proofs[0]["cryptosuite"])
+
+		subject, ok := vc.Subject.([]verifiable.Subject)
+		require.True(t, ok)
+		require.NotEmpty(t, subject[0].CustomFields["degree"])
+		require.Empty(t, subject[0].CustomFields["name"])
+
+		require.True(t, walletInstance.Close())
+	})
+
 	t.Run("Test derive credential failures", func(t *testing.T) {
 		walletInstance, err := New(user, mockctx)
 		require.NotEmpty(t, walletInstance)
@@ -2785,6 +2850,76 @@ func TestWallet_CreateKeyPair(t *testing.T) {
 	})
 }
 
+func TestWallet_RecoverKeys(t *testing.T) {
+	const mnemonic = "legal winner thank year wave sausage worth useful legal winner thank yellow"
+
+	user := uuid.New().String()
+	mockctx := newMockProvider(t)
+	err := CreateProfile(user, mockctx, WithPassphrase(samplePassPhrase), WithMnemonic(mnemonic))
+	require.NoError(t, err)
+
+	wallet, err := New(user, mockctx)
+	require.NoError(t, err)
+
+	token, err := wallet.Open(WithUnlockByPassphrase(samplePassPhrase), WithUnlockExpiry(500*time.Millisecond))
+	require.NoError(t, err)
+
+	keyPair, err := wallet.CreateKeyPair(token, kms.ED25519)
+	require.NoError(t, err)
+	require.True(t, wallet.Close())
+
+	t.Run("test recovering keys in a new storage", func(t *testing.T) {
+		recoveredctx := newMockProvider(t)
+		err := CreateProfile(user, recoveredctx, WithPassphrase(samplePassPhrase), WithMnemonic(mnemonic))
+		require.NoError(t, err)
+
+		recovered, err := New(user, recoveredctx)
+		require.NoError(t, err)
+
+		token, err := recovered.Open(WithUnlockByPassphrase(samplePassPhrase), WithUnlockExpiry(500*time.Millisecond))
+		require.NoError(t, err)
+
+		defer recovered.Close()
+
+		keyPairs, err := recovered.RecoverKeys(token, kms.ED25519, 1)
+		require.NoError(t, err)
+		require.Equal(t, []*KeyPair{keyPair}, keyPairs)
+
+		_, err = recovered.RecoverKeys(token, kms.X25519ECDHKWType, 1)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "cannot be derived")
+
+		_, err = recovered.RecoverKeys(sampleFakeTkn, kms.ED25519, 1)
+		require.True(t, errors.Is(err, ErrInvalidAuthToken))
+	})
+
+	t.Run("test recovering keys from a profile without mnemonic", func(t *testing.T) {
+		otherUser := uuid.New().String()
+		err := CreateProfile(otherUser, mockctx, WithPassphrase(samplePassPhrase))
+		require.NoError(t, err)
+
+		other, err := New(otherUser, mockctx)
+		require.NoError(t, err)
+
+		token, err := other.Open(WithUnlockByPassphrase(samplePassPhrase), WithUnlockExpiry(500*time.Millisecond))
+		require.NoError(t, err)
+
+		defer other.Close()
+
+		_, err = other.RecoverKeys(token, kms.ED25519, 1)
+		require.True(t, errors.Is(err, ErrHDKeysNotEnabled))
+	})
+
+	t.Run("test profile failures with mnemonic", func(t *testing.T) {
+		err := CreateProfile(uuid.New().String(), mockctx, WithKeyServerURL(sampleKeyServerURL), WithMnemonic(mnemonic))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "only supported by local kms")
+
+		err = CreateProfile(uuid.New().String(), mockctx, WithPassphrase(samplePassPhrase), WithMnemonic("invalid"))
+		require.Error(t, err)
+	})
+}
+
 func TestWallet_ResolveCredentialManifest(t *testing.T) {
 	mockctx := newMockProvider(t)
 	user := uuid.New().String()
@@ -0,0 +1,30 @@
+#!/bin/bash
+#
+# Copyright Gen Digital Inc. All Rights Reserved.
+#
+# SPDX-License-Identifier: Apache-2.0
+#
+set -e
+
+echo "Running $0"
+
+# Requires SoftHSM2 (softhsm2-util and libsofthsm2.so).
+PKCS11_MODULE=${PKCS11_MODULE:-/usr/lib/softhsm/libsofthsm2.so}
+PKCS11_TOKEN_LABEL=${PKCS11_TOKEN_LABEL:-aries}
+PKCS11_PIN=${PKCS11_PIN:-1234}
+
+SOFTHSM2_DIR=$(mktemp -d)
+trap 'rm -rf "$SOFTHSM2_DIR"' EXIT
+
+mkdir "$SOFTHSM2_DIR/tokens"
+echo "directories.tokendir = $SOFTHSM2_DIR/tokens" > "$SOFTHSM2_DIR/softhsm2.conf"
+export SOFTHSM2_CONF=$SOFTHSM2_DIR/softhsm2.conf
+
+softhsm2-util --init-token --free --label "$PKCS11_TOKEN_LABEL" --pin "$PKCS11_PIN" --so-pin 5678
+
+export PKCS11_MODULE PKCS11_TOKEN_LABEL PKCS11_PIN
+
+ # Running kmscrypto PKCS#11 unit tests against SoftHSM2
+cd component/kmscrypto
+go test -tags pkcs11 ./pkcs11/... ./secretlock/pkcs11lock/... ./kms/pkcs11kms/... ./crypto/pkcs11crypto/... \
+  -count=1 -race -timeout=10m
@@ -13,6 +13,8 @@ SPDX-License-Identifier: Apache-2.0
 // primitives or via webkms for remote KMS BBS+ signing.
 package crypto
 
+import "io"
+
 // Crypto interface provides all crypto operations needed in the Aries framework.
 type Crypto interface {
 	// Encrypt will encrypt msg and aad using a matching AEAD primitive in kh key handle of a public key
@@ -48,6 +50,8 @@ type Crypto interface {
 	// using WithTag() option. These allow ECDH-1PU key unwrapping (aka Authcrypt).
 	// The absence of these options uses ECDH-ES key wrapping (aka Anoncrypt). Another option that can
 	// be used is WithXC20PKW() to instruct the WrapKey to use XC20P key wrapping instead of the default A256GCM.
+	// cek is wrapped with RSA-OAEP-256 for RSA recipient public keys, or with AES Key Wrap (A256KW) using the WithKEK()
+	// option.
 	// returns:
 	// 		RecipientWrappedKey containing the wrapped cek value
 	// 		error in case of errors
@@ -58,6 +62,8 @@ type Crypto interface {
 	// using WithTag() option. These allow ECDH-1PU key unwrapping (aka Authcrypt).
 	// The absence of these options uses ECDH-ES key unwrapping (aka Anoncrypt). There is no need to
 	// use WithXC20PKW() for UnwrapKey since the function will use the wrapping algorithm based on recWK.Alg.
+	// recWK.Alg values 'RSA-OAEP', 'RSA-OAEP-256' and 'A256KW' unwrap the key with the RSA or AES key in kh, while
+	// 'ECDH-ES' returns the key derived by a direct key agreement described with the WithDirectKeyAgreement() option.
 	// returns:
 	// 		unwrapped key in raw bytes
 	// 		error in case of errors
@@ -104,6 +110,26 @@ type Crypto interface {
 		secrets []byte, correctnessProof []byte, nonces [][]byte, did string) ([]byte, []byte, error)
 }
 
+// StreamingCrypto is an optional interface of Crypto services able to encrypt payloads too large to be held in memory,
+// like big attachments or documents. Payloads are encrypted as sequences of authenticated segments (see Tink's
+// StreamingAEAD), so that encryption and decryption only need a segment in memory at a time.
+type StreamingCrypto interface {
+	// NewEncryptingWriter returns a writer encrypting the plaintext written to it with aad using a matching streaming
+	// AEAD primitive in kh key handle and writing the ciphertext to w. The writer must be closed to write the last
+	// segment of the ciphertext.
+	// returns:
+	// 		io.WriteCloser of the plaintext
+	//		error in case of errors
+	NewEncryptingWriter(w io.Writer, aad []byte, kh interface{}) (io.WriteCloser, error)
+	// NewDecryptingReader returns a reader decrypting the ciphertext read from r with aad using a matching streaming
+	// AEAD primitive in kh key handle. Segments are authenticated as they are read: Read returns an error if the
+	// ciphertext was modified or truncated.
+	// returns:
+	// 		io.Reader of the plaintext
+	//		error in case of errors
+	NewDecryptingReader(r io.Reader, aad []byte, kh interface{}) (io.Reader, error)
+}
+
 // RecipientWrappedKey contains recipient key material required to unwrap CEK.
 type RecipientWrappedKey struct {
 	KID          string    `json:"kid,omitempty"`
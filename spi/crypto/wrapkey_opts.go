@@ -11,6 +11,9 @@ type wrapKeyOpts struct {
 	useXC20PKW bool
 	tag        []byte
 	epk        *PrivateKey
+	kek        interface{}
+	encAlg     string
+	cekSize    int
 }
 
 // NewOpt creates a new empty wrap key option.
@@ -42,6 +45,21 @@ func (pk *wrapKeyOpts) EPK() *PrivateKey {
 	return pk.epk
 }
 
+// KEK gets the symmetric key encryption key to be used for key wrapping instead of a key agreement.
+func (pk *wrapKeyOpts) KEK() interface{} {
+	return pk.kek
+}
+
+// EncAlg gets the content encryption algorithm of the CEK derived by a direct key agreement.
+func (pk *wrapKeyOpts) EncAlg() string {
+	return pk.encAlg
+}
+
+// CEKSize gets the size of the CEK derived by a direct key agreement.
+func (pk *wrapKeyOpts) CEKSize() int {
+	return pk.cekSize
+}
+
 // WrapKeyOpts are the crypto.Wrap key options.
 type WrapKeyOpts func(opts *wrapKeyOpts)
 
@@ -86,3 +104,23 @@ func WithEPK(epk *PrivateKey) WrapKeyOpts {
 		opts.epk = epk
 	}
 }
+
+// WithKEK option is to instruct the key wrapping function to wrap the key with the symmetric key encryption key in kek
+// (eg: a kms AES256GCMNoPrefixType key handle) using AES Key Wrap (ie JWE 'A256KW' alg) instead of a key agreement
+// with the recipient public key, whose KID is kept in the resulting wrapped key. It is useful for WrapKey() call only
+// since UnwrapKey() receives the key encryption key as its key handle argument.
+func WithKEK(kek interface{}) WrapKeyOpts {
+	return func(opts *wrapKeyOpts) {
+		opts.kek = kek
+	}
+}
+
+// WithDirectKeyAgreement option is to instruct UnwrapKey() to return the CEK derived by the ECDH-ES key agreement in
+// direct mode (ie JWE 'ECDH-ES' alg, where no CEK is wrapped). encAlg is the JWE content encryption algorithm (used as
+// the KDF's AlgorithmID) and cekSize the size of its key in bytes.
+func WithDirectKeyAgreement(encAlg string, cekSize int) WrapKeyOpts {
+	return func(opts *wrapKeyOpts) {
+		opts.encAlg = encAlg
+		opts.cekSize = cekSize
+	}
+}
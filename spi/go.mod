@@ -4,4 +4,4 @@
 
 module github.com/hyperledger/aries-framework-go/spi
 
-go 1.20
+go 1.22.0
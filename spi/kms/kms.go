@@ -9,6 +9,8 @@
 package kms
 
 import (
+	"time"
+
 	"github.com/hyperledger/aries-framework-go/spi/secretlock"
 )
 
@@ -66,6 +68,41 @@ type KeyManager interface {
 	//  - handle instance (to private key)
 	//  - error if import failure (key empty, invalid, doesn't match keyType, unsupported keyType or storing key failed)
 	ImportPrivateKey(privKey interface{}, kt KeyType, opts ...PrivateKeyOpts) (string, interface{}, error)
+	// Delete removes the key referenced by keyID along with its metadata from the KMS storage.
+	// Deleting a non-existent key does not return an error.
+	// Returns:
+	//  - error if failure
+	Delete(keyID string) error
+	// Disable marks the key referenced by keyID as disabled. A disabled key is kept in storage but cannot be fetched,
+	// rotated or exported until it is enabled again.
+	// Returns:
+	//  - error if the key is not found, destroyed or failure
+	Disable(keyID string) error
+	// Enable restores a key previously disabled with Disable.
+	// Returns:
+	//  - error if the key is not found, destroyed or failure
+	Enable(keyID string) error
+	// Destroy erases the key material referenced by keyID. Unlike Delete, the metadata of the key is kept with the
+	// KeyStateDestroyed state, to keep a record of the destruction in key inventories. Destruction is irreversible.
+	// Returns:
+	//  - error if the key is not found or failure
+	Destroy(keyID string) error
+	// GetMetadata returns the metadata of the key referenced by keyID.
+	// Returns:
+	//  - key metadata
+	//  - error if the key is not found or failure
+	GetMetadata(keyID string) (*KeyMetadata, error)
+	// UpdateMetadata sets the labels and usage of the key referenced by keyID described in `opts`. Options not set
+	// leave the current values unchanged.
+	// Returns:
+	//  - error if the key is not found, destroyed or failure
+	UpdateMetadata(keyID string, opts ...MetadataOpts) error
+	// List returns the metadata of the keys matching the filters described in `opts`, such as the key type, state
+	// or labels. All the keys are returned if no filter is set.
+	// Returns:
+	//  - list of key metadata
+	//  - error if failure
+	List(opts ...ListOpts) ([]*KeyMetadata, error)
 }
 
 // Store defines the storage capability required by a KeyManager Provider.
@@ -81,6 +118,55 @@ type Store interface {
 	Delete(keysetID string) error
 }
 
+// MetadataStore is an optional extension of Store for the KeyManagers keeping the lifecycle metadata of their keys.
+// Implementations are expected to index the metadata (eg. as tags of a storage.Store) to answer QueryMetadata
+// without scanning all the keys.
+type MetadataStore interface {
+	// PutMetadata stores the given metadata under its KeyID, replacing any previous metadata of that key.
+	PutMetadata(metadata *KeyMetadata) error
+	// GetMetadata retrieves the metadata stored under the given keysetID. If no metadata is found, the returned error
+	// is expected to wrap ErrKeyNotFound.
+	GetMetadata(keysetID string) (*KeyMetadata, error)
+	// DeleteMetadata deletes the metadata stored under the given keysetID. Attempting to delete non-existent
+	// metadata is not an error.
+	DeleteMetadata(keysetID string) error
+	// QueryMetadata returns the metadata matching the filters described in `opts`.
+	QueryMetadata(opts ...ListOpts) ([]*KeyMetadata, error)
+}
+
+// IterableStore is an optional extension of Store for the Stores able to list the keysets they hold, used by
+// operations walking all the keys of a KMS, such as master key rotation.
+type IterableStore interface {
+	// KeysetIDs returns the IDs of the keysets stored. The list is complete only if AllKeysetsListed returns true.
+	KeysetIDs() ([]string, error)
+	// AllKeysetsListed reports whether KeysetIDs lists all the keysets stored. It returns false for the Stores holding
+	// keysets stored by versions which did not index them, whose IDs must be known to the caller.
+	AllKeysetsListed() (bool, error)
+}
+
+// KeyState represents the lifecycle state of a key managed by the KMS.
+type KeyState string
+
+const (
+	// KeyStateEnabled is the state of a key available for use.
+	KeyStateEnabled = KeyState("enabled")
+	// KeyStateDisabled is the state of a key kept in storage but unavailable for use.
+	KeyStateDisabled = KeyState("disabled")
+	// KeyStateDestroyed is the state of a key whose material has been erased.
+	KeyStateDestroyed = KeyState("destroyed")
+)
+
+// KeyMetadata holds the lifecycle metadata of a key managed by the KMS.
+type KeyMetadata struct {
+	KeyID   string            `json:"keyID"`
+	KeyType KeyType           `json:"keyType,omitempty"`
+	State   KeyState          `json:"state"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Usage   []string          `json:"usage,omitempty"`
+	Created *time.Time        `json:"created,omitempty"`
+	Updated *time.Time        `json:"updated,omitempty"`
+}
+
 // Provider for KeyManager builder/constructor.
 type Provider interface {
 	StorageProvider() Store
@@ -101,6 +187,10 @@ const (
 	ChaCha20Poly1305 = "ChaCha20Poly1305"
 	// XChaCha20Poly1305 key type value.
 	XChaCha20Poly1305 = "XChaCha20Poly1305"
+	// AES256GCMHKDF4KB streaming AEAD key type value, using 4KB ciphertext segments.
+	AES256GCMHKDF4KB = "AES256GCMHKDF4KB"
+	// AES256GCMHKDF1MB streaming AEAD key type value, using 1MB ciphertext segments.
+	AES256GCMHKDF1MB = "AES256GCMHKDF1MB"
 	// ECDSAP256DER key type value.
 	ECDSAP256DER = "ECDSAP256DER"
 	// ECDSAP384DER key type value.
@@ -123,6 +213,8 @@ const (
 	RSARS256 = "RSARS256"
 	// RSAPS256 key type value.
 	RSAPS256 = "RSAPS256"
+	// RSAOAEP RSA-OAEP decryption key type value.
+	RSAOAEP = "RSAOAEP"
 	// HMACSHA256Tag256 key type value.
 	HMACSHA256Tag256 = "HMACSHA256Tag256"
 	// NISTP256ECDHKW key type value.
@@ -133,12 +225,22 @@ const (
 	NISTP521ECDHKW = "NISTP521ECDHKW"
 	// X25519ECDHKW key type value.
 	X25519ECDHKW = "X25519ECDHKW"
+	// X25519MLKEM768ECDHKW hybrid X25519 and ML-KEM-768 (FIPS 203) key agreement key type value.
+	X25519MLKEM768ECDHKW = "X25519MLKEM768ECDHKW"
 	// BLS12381G2 BBS+ key type value.
 	BLS12381G2 = "BLS12381G2"
 	// CLCredDef key type value.
 	CLCredDef = "CLCredDef"
 	// CLMasterSecret key type value.
 	CLMasterSecret = "CLMasterSecret"
+	// MLDSA44 ML-DSA-44 (FIPS 204) key type value.
+	MLDSA44 = "MLDSA44"
+	// MLDSA65 ML-DSA-65 (FIPS 204) key type value.
+	MLDSA65 = "MLDSA65"
+	// MLDSA87 ML-DSA-87 (FIPS 204) key type value.
+	MLDSA87 = "MLDSA87"
+	// MLDSA65ED25519 composite ML-DSA-65 and Ed25519 key type value.
+	MLDSA65ED25519 = "MLDSA65ED25519"
 )
 
 // KeyType represents a key type supported by the KMS.
@@ -155,6 +257,10 @@ const (
 	ChaCha20Poly1305Type = KeyType(ChaCha20Poly1305)
 	// XChaCha20Poly1305Type key type value.
 	XChaCha20Poly1305Type = KeyType(XChaCha20Poly1305)
+	// AES256GCMHKDF4KBType streaming AEAD key type value.
+	AES256GCMHKDF4KBType = KeyType(AES256GCMHKDF4KB)
+	// AES256GCMHKDF1MBType streaming AEAD key type value.
+	AES256GCMHKDF1MBType = KeyType(AES256GCMHKDF1MB)
 	// ECDSAP256TypeDER key type value.
 	ECDSAP256TypeDER = KeyType(ECDSAP256DER)
 	// ECDSASecp256k1TypeDER key type value.
@@ -177,6 +283,8 @@ const (
 	RSARS256Type = KeyType(RSARS256)
 	// RSAPS256Type key type value.
 	RSAPS256Type = KeyType(RSAPS256)
+	// RSAOAEPType RSA-OAEP decryption key type value.
+	RSAOAEPType = KeyType(RSAOAEP)
 	// HMACSHA256Tag256Type key type value.
 	HMACSHA256Tag256Type = KeyType(HMACSHA256Tag256)
 	// NISTP256ECDHKWType key type value.
@@ -187,10 +295,20 @@ const (
 	NISTP521ECDHKWType = KeyType(NISTP521ECDHKW)
 	// X25519ECDHKWType key type value.
 	X25519ECDHKWType = KeyType(X25519ECDHKW)
+	// X25519MLKEM768ECDHKWType hybrid X25519 and ML-KEM-768 key agreement key type value.
+	X25519MLKEM768ECDHKWType = KeyType(X25519MLKEM768ECDHKW)
 	// BLS12381G2Type BBS+ key type value.
 	BLS12381G2Type = KeyType(BLS12381G2)
 	// CLCredDefType type value.
 	CLCredDefType = KeyType(CLCredDef)
 	// CLMasterSecretType key type value.
 	CLMasterSecretType = KeyType(CLMasterSecret)
+	// MLDSA44Type ML-DSA-44 key type value.
+	MLDSA44Type = KeyType(MLDSA44)
+	// MLDSA65Type ML-DSA-65 key type value.
+	MLDSA65Type = KeyType(MLDSA65)
+	// MLDSA87Type ML-DSA-87 key type value.
+	MLDSA87Type = KeyType(MLDSA87)
+	// MLDSA65ED25519Type composite ML-DSA-65 and Ed25519 key type value.
+	MLDSA65ED25519Type = KeyType(MLDSA65ED25519)
 )
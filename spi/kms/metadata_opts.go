@@ -0,0 +1,135 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kms
+
+// metadataOpts holds options for UpdateMetadata.
+type metadataOpts struct {
+	labels map[string]string
+	usage  []string
+}
+
+// NewMetadataOpt creates a new empty metadata option.
+// Not to be used directly. It's intended for implementations of KeyManager interface
+// Use WithLabels() and WithUsage() option functions below instead.
+func NewMetadataOpt() *metadataOpts { // nolint
+	return &metadataOpts{}
+}
+
+// Labels gets the labels to be set on a key, nil if they must be left unchanged.
+// Not to be used directly. It's intended for implementations of KeyManager interface
+// Use WithLabels() option function below instead.
+func (mo *metadataOpts) Labels() map[string]string {
+	return mo.labels
+}
+
+// Usage gets the usage to be set on a key, nil if it must be left unchanged.
+// Not to be used directly. It's intended for implementations of KeyManager interface
+// Use WithUsage() option function below instead.
+func (mo *metadataOpts) Usage() []string {
+	return mo.usage
+}
+
+// MetadataOpts are the update key metadata option.
+type MetadataOpts func(opts *metadataOpts)
+
+// WithLabels option is for replacing the labels of a key.
+func WithLabels(labels map[string]string) MetadataOpts {
+	return func(opts *metadataOpts) {
+		opts.labels = labels
+	}
+}
+
+// WithUsage option is for replacing the usage (eg. "sign", "encrypt") of a key.
+func WithUsage(usage ...string) MetadataOpts {
+	return func(opts *metadataOpts) {
+		opts.usage = usage
+	}
+}
+
+// listOpts holds options for List.
+type listOpts struct {
+	keyType KeyType
+	state   KeyState
+	labels  map[string]string
+}
+
+// NewListOpt creates a new empty list option.
+// Not to be used directly. It's intended for implementations of KeyManager and MetadataStore interfaces
+// Use WithKeyTypeFilter(), WithStateFilter() and WithLabelFilter() option functions below instead.
+func NewListOpt() *listOpts { // nolint
+	return &listOpts{}
+}
+
+// KeyType gets the key type the listed keys must have, empty for any.
+// Not to be used directly. It's intended for implementations of KeyManager and MetadataStore interfaces
+// Use WithKeyTypeFilter() option function below instead.
+func (lo *listOpts) KeyType() KeyType {
+	return lo.keyType
+}
+
+// State gets the state the listed keys must be in, empty for any.
+// Not to be used directly. It's intended for implementations of KeyManager and MetadataStore interfaces
+// Use WithStateFilter() option function below instead.
+func (lo *listOpts) State() KeyState {
+	return lo.state
+}
+
+// Labels gets the labels the listed keys must have.
+// Not to be used directly. It's intended for implementations of KeyManager and MetadataStore interfaces
+// Use WithLabelFilter() option function below instead.
+func (lo *listOpts) Labels() map[string]string {
+	return lo.labels
+}
+
+// Match reports whether metadata satisfies all the filters of the options.
+// Not to be used directly. It's intended for implementations of KeyManager and MetadataStore interfaces.
+func (lo *listOpts) Match(metadata *KeyMetadata) bool {
+	if lo.keyType != "" && metadata.KeyType != lo.keyType {
+		return false
+	}
+
+	if lo.state != "" && metadata.State != lo.state {
+		return false
+	}
+
+	for name, value := range lo.labels {
+		if v, ok := metadata.Labels[name]; !ok || v != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ListOpts are the list keys option.
+type ListOpts func(opts *listOpts)
+
+// WithKeyTypeFilter option is for listing the keys of type kt only.
+func WithKeyTypeFilter(kt KeyType) ListOpts {
+	return func(opts *listOpts) {
+		opts.keyType = kt
+	}
+}
+
+// WithStateFilter option is for listing the keys in the given state only.
+func WithStateFilter(state KeyState) ListOpts {
+	return func(opts *listOpts) {
+		opts.state = state
+	}
+}
+
+// WithLabelFilter option is for listing the keys having the label name set to value only. It can be repeated to
+// require several labels.
+func WithLabelFilter(name, value string) ListOpts {
+	return func(opts *listOpts) {
+		if opts.labels == nil {
+			opts.labels = map[string]string{}
+		}
+
+		opts.labels[name] = value
+	}
+}